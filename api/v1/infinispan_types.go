@@ -20,8 +20,190 @@ type InfinispanSecurity struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Authentication Secret",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret", "urn:alm:descriptor:com.tectonic.ui:fieldDependency:security.endpointAuthentication:true"}
 	EndpointSecretName string `json:"endpointSecretName,omitempty"`
+	// The secret that contains the password of the operator user in the password field, which the operator uses to manage
+	// the cluster. The password is copied to the <cluster_name>-generated-operator-secret secret. Defaults to a password
+	// generated by the operator
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Admin Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	AdminSecretName string `json:"adminSecretName,omitempty"`
 	// +optional
 	EndpointEncryption *EndpointEncryption `json:"endpointEncryption,omitempty"`
+	// Authenticates users of the Hot Rod and REST endpoints, and of the Console, with bearer tokens issued by an OpenID
+	// Connect provider such as Keycloak. Requires user authentication
+	// +optional
+	EndpointOIDC *EndpointOIDC `json:"endpointOidc,omitempty"`
+	// Authenticates users of the Hot Rod endpoint with Kerberos tickets via the GSSAPI and GS2-KRB5 SASL mechanisms.
+	// Requires user authentication
+	// +optional
+	EndpointKerberos *EndpointKerberos `json:"endpointKerberos,omitempty"`
+	// Authenticates users of the REST endpoint with the tokens of their Kubernetes ServiceAccount, which are validated
+	// with the TokenReview API by a sidecar of the server pods. Requires user authentication
+	// +optional
+	EndpointServiceAccount *EndpointServiceAccount `json:"endpointServiceAccount,omitempty"`
+	// Reads the users of the endpoints and the keystore password from HashiCorp Vault instead of Kubernetes secrets
+	// +optional
+	Vault *Vault `json:"vault,omitempty"`
+	// The pod-level security attributes of the pods created for the Infinispan cluster
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// The security options of the containers created for the Infinispan cluster
+	// +optional
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+	// Disables the console and administrative REST operations on the endpoint used by applications, so that they are
+	// only available within the Kubernetes cluster through the admin Service
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Restrict Admin Access",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	RestrictAdminAccess bool `json:"restrictAdminAccess,omitempty"`
+	// Removes the admin role from the users of the endpoints, and requires that none of them has the username or the
+	// password of the operator user, so that leaked application credentials cannot perform administrative operations.
+	// Implies restrictAdminAccess
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Restrict Admin Operations",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	RestrictAdminOperations bool `json:"restrictAdminOperations,omitempty"`
+	// Records the authorization decisions of the servers, with the user that performed each operation, in the security
+	// audit log. Requires authorization
+	// +optional
+	AuditLog *AuditLog `json:"auditLog,omitempty"`
+	// Secrets that the operator adds to the credential store of the servers, such as LDAP bind passwords or truststore
+	// passwords, so that the security realms of a custom server configuration reference them with
+	// <credential-reference store="credentials" alias="..."/> instead of plain text passwords
+	// +optional
+	Credentials []CredentialStoreEntry `json:"credentials,omitempty"`
+	// The algorithms that the passwords of the users created by the operator are hashed with in the properties realms of
+	// the servers. The operator user is also hashed with digest-md5, which the operator authenticates with. Defaults to
+	// all algorithms
+	// +optional
+	PasswordAlgorithms []PasswordAlgorithm `json:"passwordAlgorithms,omitempty"`
+}
+
+// PasswordAlgorithm specifies an algorithm that passwords are hashed with in the properties realms of the servers
+// +kubebuilder:validation:Enum=digest-md5;digest-sha;digest-sha-256;digest-sha-384;digest-sha-512;scram-sha-1;scram-sha-256;scram-sha-384;scram-sha-512
+type PasswordAlgorithm string
+
+const (
+	PasswordAlgorithmDigestMD5    PasswordAlgorithm = "digest-md5"
+	PasswordAlgorithmDigestSHA    PasswordAlgorithm = "digest-sha"
+	PasswordAlgorithmDigestSHA256 PasswordAlgorithm = "digest-sha-256"
+	PasswordAlgorithmDigestSHA384 PasswordAlgorithm = "digest-sha-384"
+	PasswordAlgorithmDigestSHA512 PasswordAlgorithm = "digest-sha-512"
+	PasswordAlgorithmScramSHA1    PasswordAlgorithm = "scram-sha-1"
+	PasswordAlgorithmScramSHA256  PasswordAlgorithm = "scram-sha-256"
+	PasswordAlgorithmScramSHA384  PasswordAlgorithm = "scram-sha-384"
+	PasswordAlgorithmScramSHA512  PasswordAlgorithm = "scram-sha-512"
+)
+
+// CredentialStoreEntry adds the value of a Secret key to the credential store of the servers
+type CredentialStoreEntry struct {
+	// The alias of the credential in the credential store
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([a-z0-9._-]*[a-z0-9])?$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Credential Alias",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Alias string `json:"alias"`
+	// The Secret that contains the credential
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Credential Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	SecretName string `json:"secretName"`
+	// The key of the credential in the Secret. Defaults to password
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Credential Secret Key",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Key string `json:"key,omitempty"`
+}
+
+// AuditLogSinkType specifies where the security audit log is written
+// +kubebuilder:validation:Enum=Console;File
+type AuditLogSinkType string
+
+const (
+	// AuditLogSinkConsole writes audit events to the console of the server with the other log messages
+	AuditLogSinkConsole AuditLogSinkType = "Console"
+	// AuditLogSinkFile writes audit events to the audit.log file of the server log directory
+	AuditLogSinkFile AuditLogSinkType = "File"
+)
+
+// AuditLog configures the security audit log of the servers
+type AuditLog struct {
+	// Enable or disable the security audit log
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Toggle Audit Log",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+	// Where audit events are written. Defaults to Console
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Audit Log Sink",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Console", "urn:alm:descriptor:com.tectonic.ui:select:File"}
+	Sink AuditLogSinkType `json:"sink,omitempty"`
+	// The level of the org.infinispan.AUDIT log category. Defaults to info
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Audit Log Level",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:trace", "urn:alm:descriptor:com.tectonic.ui:select:debug", "urn:alm:descriptor:com.tectonic.ui:select:info", "urn:alm:descriptor:com.tectonic.ui:select:warn", "urn:alm:descriptor:com.tectonic.ui:select:error"}
+	Level LoggingLevelType `json:"level,omitempty"`
+}
+
+// EndpointOIDC configures the token realm that validates bearer tokens with an OpenID Connect provider
+type EndpointOIDC struct {
+	// The URL of the realm of the OpenID Connect provider, for example https://keycloak.example.com/auth/realms/infinispan
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OIDC Auth Server URL",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	AuthServerURL string `json:"authServerUrl"`
+	// The public client that the Console uses to redirect users to the provider for single sign-on
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OIDC Console Client ID",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ClientID string `json:"clientId"`
+	// The secret that contains the clientId and clientSecret of the confidential client that the servers introspect
+	// tokens with
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OIDC Client Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	ClientSecretName string `json:"clientSecretName"`
+	// The claim of the token that contains the name of the user, which is mapped to roles with the authorization
+	// configuration. Defaults to preferred_username
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OIDC Principal Claim",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PrincipalClaim string `json:"principalClaim,omitempty"`
+}
+
+// EndpointServiceAccount configures the token realm that validates Kubernetes ServiceAccount tokens with the
+// TokenReview API
+type EndpointServiceAccount struct {
+	// The audiences that tokens must be issued for. Defaults to the host name of the cluster Service, for example
+	// example-infinispan.my-namespace.svc
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+// EndpointKerberos configures the Kerberos identity of the servers
+type EndpointKerberos struct {
+	// The Kerberos service principal of the servers, for example hotrod/example-infinispan.my-namespace.svc@EXAMPLE.COM
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kerberos Principal",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Principal string `json:"principal"`
+	// The secret that contains the keytab of the service principal in the keytab field, and the Kerberos configuration in
+	// the krb5.conf field
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kerberos Keytab Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	KeytabSecretName string `json:"keytabSecretName"`
+}
+
+// VaultProviderType specifies how the secrets stored in HashiCorp Vault are mounted in the pods
+// +kubebuilder:validation:Enum=AgentInjector;CSI
+type VaultProviderType string
+
+const (
+	// VaultProviderAgentInjector renders the secrets with the sidecar of the Vault Agent Injector
+	VaultProviderAgentInjector VaultProviderType = "AgentInjector"
+	// VaultProviderCSI mounts the secrets with the Vault provider of the Secrets Store CSI driver
+	VaultProviderCSI VaultProviderType = "CSI"
+)
+
+// Vault configures the servers to read the users.properties, groups.properties and server.properties files of their
+// credentials from HashiCorp Vault
+type Vault struct {
+	// How the secrets are mounted in the pods. AgentInjector adds the annotations of the Vault Agent Injector to the pods,
+	// CSI mounts a volume of the Secrets Store CSI driver
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Vault Provider",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:AgentInjector","urn:alm:descriptor:com.tectonic.ui:select:CSI"}
+	Provider VaultProviderType `json:"provider"`
+	// The Vault role that the Vault Agent authenticates with. Required by the AgentInjector provider
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Vault Role",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Role string `json:"role,omitempty"`
+	// The path of the KV version 2 secret that contains the users, groups and keystorePassword fields, for example
+	// secret/data/infinispan. Required by the AgentInjector provider
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Vault Secret Path",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SecretPath string `json:"secretPath,omitempty"`
+	// The SecretProviderClass that mounts the users.properties, groups.properties and server.properties files. Required
+	// by the CSI provider
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Vault Secret Provider Class",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SecretProviderClass string `json:"secretProviderClass,omitempty"`
 }
 
 type Authorization struct {
@@ -55,6 +237,11 @@ const (
 	CertificateSourceTypeNoneNoEncryption CertificateSourceType = "None"
 )
 
+// OperatorCertServiceName is the certServiceName with which the operator issues the endpoint certificates itself, signed
+// by a CA that the operator creates for the cluster. Unlike the certificates of the OpenShift service CA, the certificates
+// are also valid for the external hostnames of the cluster
+const OperatorCertServiceName = "infinispan.org"
+
 // ClientCertType specifies a client certificate validation mechanism.
 // +kubebuilder:validation:Enum=None;Authenticate;Validate
 type ClientCertType string
@@ -74,7 +261,9 @@ type EndpointEncryption struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Configure Encryption",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Service","urn:alm:descriptor:com.tectonic.ui:select:Secret","urn:alm:descriptor:com.tectonic.ui:select:None"}
 	Type CertificateSourceType `json:"type,omitempty"`
-	// A service that provides TLS certificates
+	// A service that provides TLS certificates. Set to infinispan.org for the operator to issue certificates that are
+	// also valid for the external hostnames of the cluster. Defaults to service.beta.openshift.io on OpenShift, otherwise
+	// to infinispan.org
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Encryption Service",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text", "urn:alm:descriptor:com.tectonic.ui:fieldDependency:security.endpointEncryption.type:Service"}
 	CertServiceName string `json:"certServiceName,omitempty"`
@@ -82,10 +271,59 @@ type EndpointEncryption struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Encryption Secret",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret", "urn:alm:descriptor:com.tectonic.ui:fieldDependency:security.endpointEncryption.type:Secret"}
 	CertSecretName string `json:"certSecretName,omitempty"`
+	// The keystore in the Secret of certSecretName. Defaults to the keystore.p12 or keystore.jks key of the Secret, with
+	// the password and alias in the password and alias keys
 	// +optional
+	Keystore *EndpointKeystore `json:"keystore,omitempty"`
+	// Whether the Hot Rod and REST endpoints require client certificates, and how they are verified. Requires
+	// encryption. Defaults to None
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Client Certificates",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:None", "urn:alm:descriptor:com.tectonic.ui:select:Authenticate", "urn:alm:descriptor:com.tectonic.ui:select:Validate"}
 	ClientCert ClientCertType `json:"clientCert,omitempty"`
+	// The secret that contains the truststore, or the PEM certificates, that client certificates are verified with.
+	// Defaults to <cluster_name>-client-cert-secret
 	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Client Certificate Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
 	ClientCertSecretName string `json:"clientCertSecretName,omitempty"`
+	// The TLS protocols that the Hot Rod and REST endpoints accept. Defaults to the protocols enabled by the server
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS Protocols",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Protocols []TLSProtocol `json:"protocols,omitempty"`
+	// The cipher suites that the Hot Rod and REST endpoints accept, with their IANA names, for example
+	// TLS_AES_256_GCM_SHA384. Defaults to the cipher suites enabled by the server
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS Cipher Suites",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+}
+
+// KeystoreType specifies the format of a keystore
+// +kubebuilder:validation:Enum=PKCS12;JKS
+type KeystoreType string
+
+const (
+	KeystoreTypePKCS12 KeystoreType = "PKCS12"
+	KeystoreTypeJKS    KeystoreType = "JKS"
+)
+
+// EndpointKeystore configures a keystore provided in the Secret of spec.security.endpointEncryption.certSecretName
+type EndpointKeystore struct {
+	// The key of the keystore in the Secret. Defaults to keystore.p12
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Keystore Filename",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Filename string `json:"filename,omitempty"`
+	// The format of the keystore. Defaults to JKS if the filename ends with .jks, otherwise PKCS12
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Keystore Type",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:PKCS12", "urn:alm:descriptor:com.tectonic.ui:select:JKS"}
+	Type KeystoreType `json:"type,omitempty"`
+	// The alias of the certificate and key in the keystore. Defaults to the alias key of the Secret, or to the only
+	// entry of the keystore
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Keystore Alias",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Alias string `json:"alias,omitempty"`
+	// The key of the keystore password in the Secret. Defaults to password
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Keystore Password Key",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PasswordKey string `json:"passwordKey,omitempty"`
 }
 
 // InfinispanServiceContainerSpec resource requirements specific for service
@@ -135,18 +373,133 @@ type InfinispanServiceSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Number of Owners",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
 	ReplicationFactor int32 `json:"replicationFactor,omitempty"`
+	// Labels added to the Services used within the Kubernetes cluster. Use spec.expose.labels for the resources that
+	// expose the cluster externally
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations added to the Services used within the Kubernetes cluster. Use spec.expose.annotations for the
+	// resources that expose the cluster externally
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// +optional
+	Discovery *InfinispanDiscoverySpec `json:"discovery,omitempty"`
+}
+
+// InfinispanDiscoverySpec configures the headless Service used by the pods to discover each other with DNS_PING
+type InfinispanDiscoverySpec struct {
+	// The name of the discovery Service. Defaults to the name of the Infinispan CR with the "-ping" suffix. Cannot be
+	// changed after the cluster is created
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Discovery Service Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Name string `json:"name,omitempty"`
+	// If true, the DNS records of the discovery Service include pods that are not ready, so that pods discover each
+	// other whilst they are starting
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Publish Not Ready Addresses",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	PublishNotReadyAddresses bool `json:"publishNotReadyAddresses,omitempty"`
 }
 
 // InfinispanContainerSpec specify resource requirements per container
 type InfinispanContainerSpec struct {
 	// +optional
 	ExtraJvmOpts string `json:"extraJvmOpts,omitempty"`
+	// JVM options used by the CLI of Batch jobs instead of extraJvmOpts, which only applies to the server
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CLI Extra JVM Options",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	CliExtraJvmOpts string `json:"cliExtraJvmOpts,omitempty"`
+	// JVM options used by the Gossip Router of cross-site deployments instead of extraJvmOpts, which only applies to
+	// the server
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Router Extra JVM Options",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RouterExtraJvmOpts string `json:"routerExtraJvmOpts,omitempty"`
 	// +optional
 	Memory string `json:"memory,omitempty"`
 	// +optional
 	CPU string `json:"cpu,omitempty"`
+	// The percentage of the container memory limit used for the JVM heap. The operator sets the maximum heap size
+	// accordingly and recalculates it when spec.container.memory changes
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max RAM Percentage",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxRamPercentage *int32 `json:"maxRamPercentage,omitempty"`
+	// The garbage collector used by the server JVM. The operator adds the JVM flags required by the server image
+	// +optional
+	// +kubebuilder:validation:Enum=G1;ZGC;Shenandoah;Serial
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GC Policy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:G1", "urn:alm:descriptor:com.tectonic.ui:select:ZGC", "urn:alm:descriptor:com.tectonic.ui:select:Shenandoah", "urn:alm:descriptor:com.tectonic.ui:select:Serial"}
+	GCPolicy GCPolicyType `json:"gcPolicy,omitempty"`
+	// Additional arguments passed to the server start script. Arguments configured by the operator cannot be overridden
+	// +optional
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+	// The absolute path of the server root directory. The operator mounts the server configuration, data and libraries
+	// relative to this directory. Cannot be changed once the cluster has been created
+	// +optional
+	ServerRoot string `json:"serverRoot,omitempty"`
+	// The absolute path of the directory used by the server for log files
+	// +optional
+	LogDir string `json:"logDir,omitempty"`
+	// The IANA time zone of the server, for example Europe/Rome. Sets the TZ environment variable of the server pods
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+	// The locale of the server, for example en_US.UTF-8. Sets the LANG and LC_ALL environment variables of the server pods
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([a-zA-Z]{1,3}(_[A-Z]{2})?|POSIX)(\.[\w-]+)?(@\w+)?$`
+	Locale string `json:"locale,omitempty"`
+	// The duration in seconds that server pods are given to shut down gracefully, for example to passivate the entries
+	// of file stores, before they are killed. Defaults to 30
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Termination Grace Period Seconds",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// Additional environment variables of the server container, for example proxy settings or agent configuration.
+	// Values can reference Secrets and ConfigMaps. Variables configured by the operator cannot be overridden
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// +optional
+	Diagnostics *InfinispanContainerDiagnosticsSpec `json:"diagnostics,omitempty"`
+}
+
+// InfinispanContainerDiagnosticsSpec enables JVM diagnostics of the server pods. Diagnostic files are written to the
+// data volume so that they survive restarts of the server container
+type InfinispanContainerDiagnosticsSpec struct {
+	// Writes the GC log of the server JVM to the data volume, rotating it when it reaches gcLogFileSize
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GC Logging",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	GCLogging bool `json:"gcLogging,omitempty"`
+	// The number of rotated GC log files that are kept. Defaults to 5
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	GCLogFileCount *int32 `json:"gcLogFileCount,omitempty"`
+	// The maximum size of a GC log file before it is rotated, for example 20M. Defaults to 20M
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[1-9][0-9]*[KMG]?$`
+	GCLogFileSize string `json:"gcLogFileSize,omitempty"`
+	// Starts a continuous Java Flight Recorder recording that is dumped to the data volume when the JVM shuts down.
+	// The recording is not dumped if the container is killed, for example when it exceeds its memory limit
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Flight Recorder",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	FlightRecorder bool `json:"flightRecorder,omitempty"`
+	// The maximum size of the data kept by the continuous recording, for example 250M. Defaults to 250M
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[1-9][0-9]*[KMG]?$`
+	FlightRecorderMaxSize string `json:"flightRecorderMaxSize,omitempty"`
+	// Writes a heap dump to the data volume when the server JVM throws an OutOfMemoryError. The data volume must have
+	// enough free space for a dump of the size of the heap
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Heap Dump on OutOfMemoryError",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	HeapDumpOnOutOfMemoryError bool `json:"heapDumpOnOutOfMemoryError,omitempty"`
 }
 
+type GCPolicyType string
+
+const (
+	GCPolicyG1         GCPolicyType = "G1"
+	GCPolicyZGC        GCPolicyType = "ZGC"
+	GCPolicyShenandoah GCPolicyType = "Shenandoah"
+	GCPolicySerial     GCPolicyType = "Serial"
+)
+
 // InfinispanSitesLocalSpec enables cross-site replication
 type InfinispanSitesLocalSpec struct {
 	Name   string              `json:"name"`
@@ -155,6 +508,19 @@ type InfinispanSitesLocalSpec struct {
 	MaxRelayNodes int32 `json:"maxRelayNodes,omitempty"`
 	// +optional
 	Encryption *EncryptionSiteSpec `json:"encryption,omitempty"`
+	// +optional
+	GossipRouter *GossipRouterSpec `json:"gossipRouter,omitempty"`
+}
+
+// GossipRouterSpec configures the Gossip Router Deployment, through which the TUNNEL stack of each pod relays messages
+// to the remote sites
+type GossipRouterSpec struct {
+	// The number of Gossip Router pods. Defaults to spec.replicas. The Gossip Router is always stopped when the
+	// cluster is scaled to 0 pods
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gossip Router Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	Replicas *int32 `json:"replicas,omitempty"`
 }
 
 type InfinispanSiteLocationSpec struct {
@@ -177,6 +543,17 @@ type InfinispanSiteLocationSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Backup Location Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
 	SecretName string `json:"secretName,omitempty"`
+	// If true, the backups of all caches to the site are taken offline, e.g. for planned maintenance of the site. The
+	// backups are brought online again once the field is removed or set to false
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Take Backup Location Offline",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Offline bool `json:"offline,omitempty"`
+	// The Secret with the CA certificates, in the ca.crt key, that sign the cross-site certificates of the remote site.
+	// The operator combines the certificates of all locations with the certificates of spec.service.sites.local.encryption.trustStore
+	// in the relay truststore of the local site, so that sites with different PKI roots can interoperate
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Backup Location CA Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CASecretName string `json:"caSecretName,omitempty"`
 }
 
 type InfinispanSitesSpec struct {
@@ -196,12 +573,28 @@ const (
 	LoggingLevelError LoggingLevelType = "error"
 )
 
+// IPFamilyPolicyType describes the IP family policy of the Services of the cluster. The type mirrors the Service
+// ipFamilyPolicy field of Kubernetes 1.20 and later
+// +kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+type IPFamilyPolicyType string
+
+const (
+	// IPFamilyPolicySingleStack assigns a cluster IP of the ipFamily of the cluster to the Services
+	IPFamilyPolicySingleStack IPFamilyPolicyType = "SingleStack"
+	// IPFamilyPolicyPreferDualStack assigns cluster IPs of both families to the Services on dual-stack clusters and a
+	// cluster IP of the ipFamily of the cluster on single-stack clusters
+	IPFamilyPolicyPreferDualStack IPFamilyPolicyType = "PreferDualStack"
+	// IPFamilyPolicyRequireDualStack assigns cluster IPs of both families to the Services and fails on single-stack
+	// clusters
+	IPFamilyPolicyRequireDualStack IPFamilyPolicyType = "RequireDualStack"
+)
+
 type InfinispanLoggingSpec struct {
 	Categories map[string]LoggingLevelType `json:"categories,omitempty"`
 }
 
 // ExposeType describe different exposition methods for Infinispan
-// +kubebuilder:validation:Enum=NodePort;LoadBalancer;Route
+// +kubebuilder:validation:Enum=NodePort;LoadBalancer;Route;Ingress;Gateway;ClusterIP
 type ExposeType string
 
 const (
@@ -217,10 +610,52 @@ const (
 	// ExposeTypeRoute means the service will be exposed via
 	// `Route` on Openshift or via `Ingress` on Kubernetes
 	ExposeTypeRoute ExposeType = "Route"
+
+	// ExposeTypeIngress means the service will be exposed via a
+	// networking.k8s.io/v1 `Ingress`
+	ExposeTypeIngress ExposeType = "Ingress"
+
+	// ExposeTypeGateway means the service will be exposed via a Gateway API
+	// `HTTPRoute` attached to an existing `Gateway`, or a `TLSRoute` with TLS
+	// passthrough if encryption is enabled
+	ExposeTypeGateway ExposeType = "Gateway"
+
+	// ExposeTypeClusterIP means the cluster is only reachable through its
+	// 'ClusterIP' services. No external resources are created, and those
+	// created for a previous type are removed
+	ExposeTypeClusterIP = ExposeType(corev1.ServiceTypeClusterIP)
+)
+
+// HotRodClientIntelligence the topology information that Hot Rod clients request from the servers
+// +kubebuilder:validation:Enum=BASIC;TOPOLOGY_AWARE;HASH_DISTRIBUTION_AWARE
+type HotRodClientIntelligence string
+
+const (
+	// ClientIntelligenceBasic clients only connect to the configured servers and ignore the topology of the cluster
+	ClientIntelligenceBasic HotRodClientIntelligence = "BASIC"
+	// ClientIntelligenceTopologyAware clients connect to all servers of the cluster topology
+	ClientIntelligenceTopologyAware HotRodClientIntelligence = "TOPOLOGY_AWARE"
+	// ClientIntelligenceHashDistributionAware clients send requests to the servers that own the keys
+	ClientIntelligenceHashDistributionAware HotRodClientIntelligence = "HASH_DISTRIBUTION_AWARE"
+)
+
+// RouteTerminationType describe where TLS is terminated for the Route expose type
+// +kubebuilder:validation:Enum=passthrough;reencrypt;edge
+type RouteTerminationType string
+
+const (
+	// RouteTerminationPassthrough means the encrypted traffic is sent to the pods without the router terminating TLS
+	RouteTerminationPassthrough RouteTerminationType = "passthrough"
+
+	// RouteTerminationReencrypt means the router terminates TLS and re-encrypts the traffic to the pods
+	RouteTerminationReencrypt RouteTerminationType = "reencrypt"
+
+	// RouteTerminationEdge means the router terminates TLS and sends unencrypted traffic to the pods
+	RouteTerminationEdge RouteTerminationType = "edge"
 )
 
 // CrossSiteExposeType describe different exposition methods for Infinispan Cross-Site service
-// +kubebuilder:validation:Enum=NodePort;LoadBalancer;ClusterIP;Route
+// +kubebuilder:validation:Enum=NodePort;LoadBalancer;ClusterIP;Route;ClusterSet
 type CrossSiteExposeType string
 
 const (
@@ -239,6 +674,10 @@ const (
 
 	// CrossSiteExposeTypeRoute route
 	CrossSiteExposeTypeRoute = "Route"
+
+	// CrossSiteExposeTypeClusterSet means an internal 'ClusterIP' service will be created and exported to the other
+	// clusters of the ClusterSet with a multi-cluster ServiceExport, e.g. with Submariner
+	CrossSiteExposeTypeClusterSet = "ClusterSet"
 )
 
 // CrossSiteSchemeType specifies the supported url scheme's allowed in InfinispanSiteLocationSpec.URL
@@ -258,12 +697,134 @@ type ExposeSpec struct {
 	NodePort int32 `json:"nodePort,omitempty"`
 	// +optional
 	Port int32 `json:"port,omitempty"`
+	// The ports of the individual endpoints exposed by the NodePort and LoadBalancer expose types, instead of
+	// spec.expose.nodePort and spec.expose.port. Only the configured endpoints are exposed
+	// +optional
+	Endpoints *ExposeEndpointsSpec `json:"endpoints,omitempty"`
 	// The network hostname for your Infinispan cluster
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Route Hostname",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text", "urn:alm:descriptor:com.tectonic.ui:fieldDependency:expose.type:Route"}
 	Host string `json:"host,omitempty"`
+	// Annotations added to the Service, Route, Ingress, HTTPRoute or TLSRoute that exposes the cluster
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// Labels added to the Service, Route, Ingress, HTTPRoute or TLSRoute that exposes the cluster
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// The name of the Gateway that the HTTPRoute or TLSRoute of the Gateway expose type is attached to
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text", "urn:alm:descriptor:com.tectonic.ui:fieldDependency:expose.type:Gateway"}
+	GatewayName string `json:"gatewayName,omitempty"`
+	// The namespace of the Gateway. Defaults to the namespace of the Infinispan CR
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway Namespace",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text", "urn:alm:descriptor:com.tectonic.ui:fieldDependency:expose.type:Gateway"}
+	GatewayNamespace string `json:"gatewayNamespace,omitempty"`
+	// The name of the Gateway listener that the route of the Gateway expose type is attached to. Defaults to all
+	// listeners of the Gateway that allow the route
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway Listener Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text", "urn:alm:descriptor:com.tectonic.ui:fieldDependency:expose.type:Gateway"}
+	GatewayListenerName string `json:"gatewayListenerName,omitempty"`
+	// The IngressClass of the Ingress expose type. Defaults to the default IngressClass of the cluster
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingress Class Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text", "urn:alm:descriptor:com.tectonic.ui:fieldDependency:expose.type:Ingress"}
+	IngressClassName string `json:"ingressClassName,omitempty"`
+	// The name of the Secret containing the TLS certificate that the Ingress expose type terminates TLS with for spec.expose.host
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingress TLS Secret",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret", "urn:alm:descriptor:com.tectonic.ui:fieldDependency:expose.type:Ingress"}
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+	// Where the Route expose type terminates TLS. Defaults to passthrough if encryption is enabled, otherwise the Route
+	// is not secured
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Route TLS Termination",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:passthrough", "urn:alm:descriptor:com.tectonic.ui:select:reencrypt", "urn:alm:descriptor:com.tectonic.ui:select:edge", "urn:alm:descriptor:com.tectonic.ui:fieldDependency:expose.type:Route"}
+	RouteTermination RouteTerminationType `json:"routeTermination,omitempty"`
+	// The name of the Secret containing the "ca.crt" that the router uses to verify the certificates of the pods with
+	// reencrypt termination. Defaults to the CA bundle of the cluster
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Route Destination CA Secret",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret", "urn:alm:descriptor:com.tectonic.ui:fieldDependency:expose.routeTermination:reencrypt"}
+	RouteDestinationCASecretName string `json:"routeDestinationCASecretName,omitempty"`
+	// The static IP requested for the Service of the LoadBalancer expose type. Ignored by cloud providers that do not
+	// support it
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Load Balancer IP",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text", "urn:alm:descriptor:com.tectonic.ui:fieldDependency:expose.type:LoadBalancer"}
+	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
+	// The CIDRs of the clients allowed to connect to the LoadBalancer expose type, for example 10.0.0.0/8. Defaults to
+	// all clients
+	// +optional
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+	// Whether the Service of the NodePort or LoadBalancer expose type routes external traffic to pods on all nodes or
+	// only to pods on the node that receives the traffic. Local preserves the IP address of clients. Defaults to Cluster
+	// +optional
+	// +kubebuilder:validation:Enum=Cluster;Local
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="External Traffic Policy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Cluster", "urn:alm:descriptor:com.tectonic.ui:select:Local"}
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicyType `json:"externalTrafficPolicy,omitempty"`
+	// Set to ClientIP so that the Service of the NodePort or LoadBalancer expose type sends the connections of a client
+	// to the same pod. Defaults to None
+	// +optional
+	// +kubebuilder:validation:Enum=None;ClientIP
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Session Affinity",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:None", "urn:alm:descriptor:com.tectonic.ui:select:ClientIP"}
+	SessionAffinity corev1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+	// How long the connections of a client are sent to the same pod with ClientIP session affinity. Defaults to 10800
+	// seconds
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=86400
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Session Affinity Timeout Seconds",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number", "urn:alm:descriptor:com.tectonic.ui:fieldDependency:expose.sessionAffinity:ClientIP"}
+	SessionAffinityTimeoutSeconds int32 `json:"sessionAffinityTimeoutSeconds,omitempty"`
+	// The DNS names that ExternalDNS publishes for the exposed cluster
+	// +optional
+	ExternalDNS *ExternalDNSSpec `json:"externalDNS,omitempty"`
+	// The client intelligence that Hot Rod clients outside the Kubernetes cluster must use, reported in
+	// status.clientIntelligence. Defaults to BASIC, as the pod addresses of the cluster topology are usually unreachable
+	// from outside the Kubernetes cluster. Only supported with the NodePort, LoadBalancer and Route expose types, and the
+	// Gateway expose type with encryption
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hot Rod Client Intelligence",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:BASIC", "urn:alm:descriptor:com.tectonic.ui:select:TOPOLOGY_AWARE", "urn:alm:descriptor:com.tectonic.ui:select:HASH_DISTRIBUTION_AWARE"}
+	ClientIntelligence HotRodClientIntelligence `json:"clientIntelligence,omitempty"`
+	// If true, a Service of the NodePort or LoadBalancer expose type is also created for each pod and every server
+	// advertises the address of its Service in the Hot Rod topology, so that clients outside the Kubernetes cluster can use
+	// HASH_DISTRIBUTION_AWARE intelligence
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Toggle Per-Pod Services",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	PerPod bool `json:"perPod,omitempty"`
+}
+
+// ExternalDNSSpec configures the annotations that ExternalDNS uses to create DNS records for the exposed cluster
+type ExternalDNSSpec struct {
+	// The fully qualified DNS names that resolve to the address of the exposed cluster
+	// +kubebuilder:validation:MinItems=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ExternalDNS Hostnames"
+	Hostnames []string `json:"hostnames"`
+	// The TTL of the DNS records in seconds. Defaults to the TTL configured for ExternalDNS
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ExternalDNS TTL",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	TTL int32 `json:"ttl,omitempty"`
+}
+
+// ExposeEndpointsSpec configures the endpoints exposed by the NodePort and LoadBalancer expose types
+type ExposeEndpointsSpec struct {
+	// The Hot Rod endpoint. Defaults to port 11222
+	// +optional
+	HotRod *ExposeEndpointSpec `json:"hotrod,omitempty"`
+	// The REST endpoint. Defaults to port 8080
+	// +optional
+	Rest *ExposeEndpointSpec `json:"rest,omitempty"`
+	// The admin endpoint used by the operator and the CLI. Defaults to port 11223
+	// +optional
+	Admin *ExposeEndpointSpec `json:"admin,omitempty"`
+}
+
+// ExposeEndpointSpec configures the ports of an exposed endpoint
+type ExposeEndpointSpec struct {
+	// The port of the endpoint in the Service
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+	// The NodePort of the endpoint with the NodePort expose type. Must be in the NodePort range of the cluster.
+	// Allocated by Kubernetes if not configured
+	// +optional
+	NodePort int32 `json:"nodePort,omitempty"`
 }
 
 // CrossSiteExposeSpec describe how Infinispan Cross-Site service will be exposed externally
@@ -343,7 +904,9 @@ type InfinispanCloudEvents struct {
 }
 
 type ConfigListenerSpec struct {
-	// If true, a dedicated pod is used to ensure that all config resources created on the Infinispan server have a matching CR resource
+	// If true, a dedicated pod is used to ensure that all config resources created on the Infinispan server have a matching CR resource.
+	// If false, Cache CRs are only pushed to the server. Defaults to the CONFIG_LISTENER_ENABLED environment variable of
+	// the operator, true if unset
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Toggle Config Listener",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	Enabled bool `json:"enabled"`
@@ -356,6 +919,24 @@ type InfinispanSpec struct {
 	Replicas int32 `json:"replicas"`
 	// +optional
 	Image *string `json:"image,omitempty"`
+	// Secrets used to pull the Infinispan image from a private registry
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image Pull Secrets",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// Image pull policy for the Infinispan image. One of Always, Never, IfNotPresent
+	// +optional
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image Pull Policy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:imagePullPolicy"}
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// The ServiceAccount used by Infinispan pods, for example to access cloud resources with workload identity bindings
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Account Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Mount the API token of the ServiceAccount in Infinispan pods. If false, the operator does not grant any permissions
+	// to spec.serviceAccountName. Defaults to the automountServiceAccountToken value of the ServiceAccount
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Automount Service Account Token",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
 	// +optional
 	Security InfinispanSecurity `json:"security,omitempty"`
 	// +optional
@@ -381,11 +962,389 @@ type InfinispanSpec struct {
 	Upgrades *InfinispanUpgradesSpec `json:"upgrades,omitempty"`
 	// +optional
 	ConfigListener *ConfigListenerSpec `json:"configListener,omitempty"`
+	// +optional
+	IntegrityCheck *IntegrityCheckSpec `json:"integrityCheck,omitempty"`
+	// +optional
+	Jmx *InfinispanJmxSpec `json:"jmx,omitempty"`
+	// +optional
+	Recommendations *RecommendationsSpec `json:"recommendations,omitempty"`
+	// +optional
+	StorageMonitoring *StorageMonitoringSpec `json:"storageMonitoring,omitempty"`
+	// +optional
+	Autostop *AutostopSpec `json:"autostop,omitempty"`
+	// +optional
+	DriftDetection *DriftDetectionSpec `json:"driftDetection,omitempty"`
+	// Enables or disables the protocols of the user endpoint and assigns them dedicated ports
+	// +optional
+	Endpoints *InfinispanEndpointsSpec `json:"endpoints,omitempty"`
+	// +optional
+	ServiceMesh *ServiceMeshSpec `json:"serviceMesh,omitempty"`
+	// The IP family of the pod network. IPv6 configures cluster discovery and transport for IPv6-only clusters and
+	// dual-stack clusters where IPv6 is the primary family. Defaults to IPv4. Cannot be changed after the cluster is
+	// created
+	// +optional
+	// +kubebuilder:validation:Enum=IPv4;IPv6
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="IP Family",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:IPv4","urn:alm:descriptor:com.tectonic.ui:select:IPv6"}
+	IPFamily corev1.IPFamily `json:"ipFamily,omitempty"`
+	// The IP family policy of the ping, admin, user and external Services. Defaults to PreferDualStack. The ipFamily is
+	// the primary IP family of the Services
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="IP Family Policy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:SingleStack","urn:alm:descriptor:com.tectonic.ui:select:PreferDualStack","urn:alm:descriptor:com.tectonic.ui:select:RequireDualStack"}
+	IPFamilyPolicy IPFamilyPolicyType `json:"ipFamilyPolicy,omitempty"`
+	// If true, the cluster is configured for local development with a single pod, ephemeral storage, no pod
+	// anti-affinity, no liveness probe, a NodePort console without TLS and a default cache. Cannot be changed after
+	// the cluster is created
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Toggle Dev Mode",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	DevMode bool `json:"devMode,omitempty"`
+	// A preset of defaults for the fields that are not configured. The edge profile minimises the resources of the
+	// cluster for edge and IoT deployments with a single pod, a small heap, the serial GC with the DataGrid service, no
+	// Console, no ServiceMonitor and no ConfigListener. Cannot be changed after the cluster is created
+	// +optional
+	// +kubebuilder:validation:Enum=edge
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Profile",xDescriptors="urn:alm:descriptor:com.tectonic.ui:select:edge"
+	Profile InfinispanProfile `json:"profile,omitempty"`
+	// +optional
+	CapacityFactor *CapacityFactorSpec `json:"capacityFactor,omitempty"`
+	// +optional
+	ShadowReplication *ShadowReplicationSpec `json:"shadowReplication,omitempty"`
+	// +optional
+	OperationGate *OperationGateSpec `json:"operationGate,omitempty"`
+}
+
+// CapacityFactorSpec configures the capacity factor of the pods in each zone, so that pods scheduled on the larger
+// nodes of heterogeneous node pools own proportionally more segments of the distributed caches
+type CapacityFactorSpec struct {
+	// The label of the nodes that identifies their zone. Defaults to topology.kubernetes.io/zone
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Capacity Factor Topology Key",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	TopologyKey string `json:"topologyKey,omitempty"`
+	// The capacity factor of the pods in zones without a configured factor, e.g. "1.5". Defaults to "1"
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Default Capacity Factor",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Default string `json:"default,omitempty"`
+	// The capacity factor of the pods in each zone
+	// +kubebuilder:validation:MinItems=1
+	Zones []ZoneCapacityFactor `json:"zones"`
+}
+
+// ZoneCapacityFactor the capacity factor of the pods scheduled on the nodes of a zone
+type ZoneCapacityFactor struct {
+	// The value of the topology key label of the nodes in the zone
+	// +kubebuilder:validation:MinLength=1
+	Zone string `json:"zone"`
+	// The capacity factor of the pods in the zone, e.g. "2" or "0.5"
+	Factor string `json:"factor"`
+}
+
+// ShadowReplicationSpec configures the asynchronous replication of the writes to caches of this cluster to another
+// Infinispan cluster in the same namespace, so that a new cluster receives production traffic and can be validated
+// before clients are moved to it
+type ShadowReplicationSpec struct {
+	// The name of the Infinispan CR that receives the writes. Caches that do not exist in the target cluster are created
+	// with the configuration of the cache in this cluster
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Shadow Replication Target",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Target string `json:"target"`
+	// The names of the caches whose writes are replicated
+	// +kubebuilder:validation:MinItems=1
+	Caches []string `json:"caches"`
+	// The time between measurements of the replication lag. Defaults to 1m
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Shadow Replication Lag Check Interval",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	LagCheckInterval *metav1.Duration `json:"lagCheckInterval,omitempty"`
+}
+
+// OperationGatePolicy the action taken for Backup, Restore and Batch CRs targeting a cluster that is not healthy
+type OperationGatePolicy string
+
+const (
+	// OperationGateQueue operations wait until the cluster is healthy
+	OperationGateQueue OperationGatePolicy = "Queue"
+	// OperationGateReject operations fail if the cluster is not healthy when they are started
+	OperationGateReject OperationGatePolicy = "Reject"
+)
+
+// OperationGateSpec configures the admission of Backup, Restore, Batch and Cache operations whilst the cluster is not
+// well formed, is upgrading, is stopping or runs an unsupported server version
+type OperationGateSpec struct {
+	// Queue Backup, Restore and Batch operations until the cluster is healthy, or Reject them. Cache CRs are always
+	// queued. Defaults to Queue
+	// +optional
+	// +kubebuilder:validation:Enum=Queue;Reject
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Operation Gate Policy",xDescriptors="urn:alm:descriptor:com.tectonic.ui:select:Queue,urn:alm:descriptor:com.tectonic.ui:select:Reject"
+	Policy OperationGatePolicy `json:"policy,omitempty"`
+}
+
+// InfinispanProfile a preset of defaults for the Infinispan CR
+type InfinispanProfile string
+
+const (
+	// ProfileEdge minimal resources for edge and IoT deployments
+	ProfileEdge InfinispanProfile = "edge"
+)
+
+// DriftDetectionMode the action taken when resources managed by the operator are modified by another field manager
+type DriftDetectionMode string
+
+const (
+	// DriftDetectionModeRevert patches the modified fields back to the state desired by the operator
+	DriftDetectionModeRevert DriftDetectionMode = "Revert"
+	// DriftDetectionModeDetect reports the modified resources in the Drifted condition without changing them
+	DriftDetectionModeDetect DriftDetectionMode = "Detect"
+)
+
+// DriftDetectionSpec configures the detection of changes to the StatefulSet, Services and ConfigMap of the cluster that
+// were not made by the operator, e.g. with "kubectl edit"
+type DriftDetectionSpec struct {
+	// Revert patches the modified fields back to the desired state, Detect only reports them in the Drifted
+	// condition. Defaults to Revert
+	// +optional
+	// +kubebuilder:validation:Enum=Revert;Detect
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Drift Detection Mode",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Revert", "urn:alm:descriptor:com.tectonic.ui:select:Detect"}
+	Mode DriftDetectionMode `json:"mode,omitempty"`
+}
+
+// AutostopSpec configures recurring windows in which the cluster is gracefully shut down, for example outside of
+// office hours. The data of the cluster is preserved in its persistent volumes whilst it is stopped
+type AutostopSpec struct {
+	// The cron schedule, e.g. "0 20 * * 1-5", at which the cluster is gracefully shut down
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Autostop Schedule",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Schedule string `json:"schedule"`
+	// The cron schedule, e.g. "0 7 * * 1-5", at which the cluster is restarted with the number of replicas it had
+	// before it was shut down
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Autostop Wake Schedule",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	WakeSchedule string `json:"wakeSchedule"`
+	// The time zone of the schedules, e.g. "Europe/Prague". Defaults to UTC
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Autostop Time Zone",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// InfinispanJmxSpec configures remote JMX access to the server pods for tooling that doesn't support REST metrics
+type InfinispanJmxSpec struct {
+	// If true, remote JMX is enabled on an internal port of each pod, which is exposed by a headless service
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Toggle JMX",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+	// The Secret containing the "username" and "password" keys used to authenticate JMX connections
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="JMX Credentials Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// InfinispanEndpointsSpec configures the protocols served by the user endpoint. The admin endpoint used by the
+// operator is not affected
+type InfinispanEndpointsSpec struct {
+	// The Hot Rod protocol
+	// +optional
+	HotRod *InfinispanEndpointSpec `json:"hotrod,omitempty"`
+	// The REST protocol
+	// +optional
+	Rest *InfinispanEndpointSpec `json:"rest,omitempty"`
+	// The Infinispan Console and CLI access, which are served by the REST protocol
+	// +optional
+	Console *InfinispanConsoleSpec `json:"console,omitempty"`
+	// The delivery of cache events to client listeners, for example the invalidations of Hot Rod near caches. Changes
+	// are applied with a rolling restart of the pods
+	// +optional
+	ClientListeners *ClientListenersSpec `json:"clientListeners,omitempty"`
+}
+
+// ClientListenersSpec tunes the delivery of cache events to client listeners for event-heavy client workloads
+type ClientListenersSpec struct {
+	// The maximum number of threads that deliver cache events to client listeners. Defaults to 1
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Client Listener Threads",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxThreads int32 `json:"maxThreads,omitempty"`
+	// The number of cache events that are queued whilst all threads are busy, before the writes that raise further
+	// events are blocked. Defaults to 1000
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Client Listener Queue Length",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	QueueLength int32 `json:"queueLength,omitempty"`
+	// The size in bytes of the socket send buffer of the Hot Rod connections, which buffers the events that clients
+	// have not yet read. Defaults to the buffer size of the operating system
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hot Rod Send Buffer Size",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	SendBufferSize int32 `json:"sendBufferSize,omitempty"`
+}
+
+// InfinispanEndpointSpec enables a protocol of the user endpoint
+type InfinispanEndpointSpec struct {
+	// If false, the protocol is disabled. Defaults to true
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Toggle Protocol",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+	// A dedicated port for the protocol. By default all protocols share port 11222
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Port",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	Port int32 `json:"port,omitempty"`
+}
+
+// ServiceMeshSpec configures the pods for Istio sidecars with mTLS in STRICT mode
+type ServiceMeshSpec struct {
+	// If true, the JGroups, cross-site and admin ports are excluded from the traffic intercepted by the sidecars, so
+	// that the pods can form a cluster and the operator can reach the admin endpoint of each pod, and the probes are
+	// sent through the sidecar agent
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Toggle Service Mesh Compatibility",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+}
+
+// InfinispanConsoleSpec enables the Infinispan Console on the user endpoint
+type InfinispanConsoleSpec struct {
+	// If false, the Console and CLI access are disabled on the user endpoint. Defaults to true
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Toggle Console",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+	// How the Console is reached from outside the Kubernetes cluster. Defaults to the address of spec.expose
+	// +optional
+	Expose *ConsoleExposeSpec `json:"expose,omitempty"`
+}
+
+// ConsoleExposeType describe how the Infinispan Console is exposed
+// +kubebuilder:validation:Enum=Cluster;None;Route;Ingress
+type ConsoleExposeType string
+
+const (
+	// ConsoleExposeTypeCluster means the Console is reached at the address of spec.expose
+	ConsoleExposeTypeCluster ConsoleExposeType = "Cluster"
+
+	// ConsoleExposeTypeNone means the Console is not reachable from outside the Kubernetes cluster
+	ConsoleExposeTypeNone ConsoleExposeType = "None"
+
+	// ConsoleExposeTypeRoute means the Console is exposed with a dedicated `Route`, or a dedicated `Ingress` on
+	// platforms without Routes
+	ConsoleExposeTypeRoute ConsoleExposeType = "Route"
+
+	// ConsoleExposeTypeIngress means the Console is exposed with a dedicated `Ingress`
+	ConsoleExposeTypeIngress ConsoleExposeType = "Ingress"
+)
+
+// ConsoleExposeSpec configures how the Infinispan Console is reached from outside the Kubernetes cluster. With a type
+// other than Cluster, the Ingress, HTTPRoute and non-passthrough Route of spec.expose only route the REST API
+type ConsoleExposeSpec struct {
+	// Type specifies how the Console is exposed
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Console Expose Type",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Cluster", "urn:alm:descriptor:com.tectonic.ui:select:None", "urn:alm:descriptor:com.tectonic.ui:select:Route", "urn:alm:descriptor:com.tectonic.ui:select:Ingress"}
+	Type ConsoleExposeType `json:"type"`
+	// The hostname of the dedicated Route or Ingress. Required with the Ingress type
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Console Hostname",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Host string `json:"host,omitempty"`
+	// Annotations added to the dedicated Route or Ingress, for example to require authentication in the ingress
+	// controller before requests reach the Console
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// IntegrityCheckSpec configures a periodic check that sampled entries of replicated caches have the same value on all pods
+type IntegrityCheckSpec struct {
+	// If true, the operator periodically verifies that sampled entries of replicated caches have the same value on all pods
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Toggle Integrity Check",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+	// The time between checks. Defaults to 1h
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Integrity Check Interval",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// The maximum number of keys sampled from each cache. Defaults to 20
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Integrity Check Sample Size",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	SampleSize *int32 `json:"sampleSize,omitempty"`
+	// The caches to check. Defaults to all replicated caches
+	// +optional
+	Caches []string `json:"caches,omitempty"`
+}
+
+// RecommendationsSpec configures the periodic comparison of the resource usage of the pods with the configured limits
+type RecommendationsSpec struct {
+	// If true, the operator periodically publishes sizing recommendations based on the heap usage and GC activity of the pods
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Toggle Sizing Recommendations",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+	// The time between evaluations. Defaults to 15m
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Sizing Recommendations Interval",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// StorageMonitoringSpec configures the periodic measurement of the usage of the data volume of each pod
+type StorageMonitoringSpec struct {
+	// If true, the operator periodically measures the usage of the data volume of each pod and reports the volumes whose
+	// usage exceeds the threshold with the StoragePressure condition and events
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Toggle Storage Monitoring",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+	// The time between measurements. Defaults to 5m
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage Monitoring Interval",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// The percentage of the capacity of a data volume above which the volume is under pressure. Defaults to 80
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage Pressure Threshold",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	Threshold *int32 `json:"threshold,omitempty"`
+	// +optional
+	AutoExpand *StorageAutoExpandSpec `json:"autoExpand,omitempty"`
+}
+
+// StorageAutoExpandSpec configures the expansion of the PersistentVolumeClaims of data volumes that are under pressure.
+// Requires a StorageClass with allowVolumeExpansion=true
+type StorageAutoExpandSpec struct {
+	// If true, the operator increases the storage requested by the PersistentVolumeClaim of a data volume under pressure
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Toggle Storage Auto Expansion",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+	// The percentage by which the requested storage is increased on each expansion. Defaults to 25
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage Expansion Increment",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	Increment *int32 `json:"increment,omitempty"`
+	// The size that the PersistentVolumeClaims are never expanded beyond, e.g. "10Gi"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage Expansion Max Size",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	MaxSize string `json:"maxSize"`
 }
 
 // InfinispanUpgradesSpec defines the Infinispan upgrade strategy
 type InfinispanUpgradesSpec struct {
+	// The upgrade strategy. Shutdown stops all pods before upgrading, HotRodRolling creates a target cluster and migrates
+	// data using remote stores without downtime. Defaults to Shutdown
+	// +kubebuilder:validation:Enum=Shutdown;HotRodRolling
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Upgrade Type",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Shutdown", "urn:alm:descriptor:com.tectonic.ui:select:HotRodRolling"}
 	Type UpgradeType `json:"type"`
+	// If set, the operator rolls out changes to the pod template one pod at a time in descending ordinal order, waiting for
+	// each updated pod to be ready and for the cluster to finish rebalancing before updating the next pod. Pods with an
+	// ordinal lower than the partition are not updated until the partition is decreased, so a value of replicas-1 only
+	// updates a single canary pod. A value of 0 updates all pods
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rollout Partition",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	Partition *int32 `json:"partition,omitempty"`
+	// The maximum number of pods that the operator updates at the same time when it rolls out changes to the pod
+	// template, trading the duration of the rollout against the number of pods that are unavailable. Enables the rollout
+	// of spec.upgrades.partition if configured on its own. Defaults to 1
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rollout Max Unavailable",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxUnavailable *int32 `json:"maxUnavailable,omitempty"`
+	// +optional
+	DataMigration *DataMigrationSpec `json:"dataMigration,omitempty"`
+}
+
+// DataMigrationSpec configures the migration of persistent data when a Shutdown upgrade changes the major version of the
+// operand. The upgrade only proceeds once a Backup of the cluster has succeeded
+type DataMigrationSpec struct {
+	// The ConfigMap containing a StoreMigrator properties file for each store that must be migrated. The files are
+	// applied to the data of every pod before the upgraded pods are started
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Data Migration ConfigMap",xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap"
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// If true, upgrades between major versions start the upgraded pods on the existing data without migrating it
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Skip Data Migration",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Skip bool `json:"skip,omitempty"`
 }
 
 type UpgradeType string
@@ -408,6 +1367,41 @@ const (
 	ConditionWellFormed          ConditionType = "WellFormed"
 	ConditionCrossSiteViewFormed ConditionType = "CrossSiteViewFormed"
 	ConditionGossipRouterReady   ConditionType = "GossipRouterReady"
+	// ConditionHotRodRollingUpgrade is true whilst a Hot Rod rolling upgrade is in progress, with the current stage as message
+	ConditionHotRodRollingUpgrade ConditionType = "HotRodRollingUpgrade"
+	// ConditionDataMigrated is false whilst the persistent data of the cluster must be migrated before the pods of a
+	// Shutdown upgrade between major versions are started, and true once the migration has succeeded
+	ConditionDataMigrated ConditionType = "DataMigrated"
+	// ConditionDrifted is true whilst resources managed by the operator have been modified by another field manager and
+	// spec.driftDetection.mode=Detect, with the modified resources as message
+	ConditionDrifted ConditionType = "Drifted"
+	// ConditionOperationBlocked is true whilst an operation waits for the operation holding status.operationLock to
+	// complete, with the waiting operation as message
+	ConditionOperationBlocked ConditionType = "OperationBlocked"
+	// ConditionOperationFailed is true if the operation holding status.operationLock did not complete before the
+	// deadline and the lock was released, with the operation as message
+	ConditionOperationFailed ConditionType = "OperationFailed"
+	// ConditionServerWarnings is true if the most recently started pods logged deprecation or configuration warnings
+	// whilst the server was starting, with the warnings as message
+	ConditionServerWarnings ConditionType = "ServerWarnings"
+	// ConditionStoragePressure is true if the usage of the data volume of any pod exceeds
+	// spec.storageMonitoring.threshold, with the volumes under pressure as message
+	ConditionStoragePressure ConditionType = "StoragePressure"
+	// ConditionClusterAction is true whilst an action requested with the infinispan.org/action annotation is in
+	// progress, with the action and its phase as message
+	ConditionClusterAction ConditionType = "ClusterAction"
+	// ConditionShadowReplication is true whilst the writes to the caches of spec.shadowReplication are replicated to the
+	// target cluster, or false with the reason as message
+	ConditionShadowReplication ConditionType = "ShadowReplication"
+	// ConditionIncompatibleOperand is true if the server version of the image is not supported by this version of the
+	// operator, with the nearest supported version as message. The cluster is not reconciled whilst the condition is true
+	ConditionIncompatibleOperand ConditionType = "IncompatibleOperand"
+	// ConditionCertificateUpdating is true whilst the pods are restarted one at a time to load the certificates of a
+	// renamed spec.security.endpointEncryption.certSecretName, with the progress of the rollout as message
+	ConditionCertificateUpdating ConditionType = "CertificateUpdating"
+	// ConditionCABundleFailed is true if the CA certificates of the keystore could not be read, with the error as
+	// message. The previously published <cluster>-ca-bundle ConfigMap is kept whilst the condition is true
+	ConditionCABundleFailed ConditionType = "CABundleFailed"
 )
 
 // InfinispanCondition define a condition of the cluster
@@ -430,12 +1424,22 @@ type DeploymentStatus struct {
 	Stopped []string `json:"stopped,omitempty"`
 }
 
+// PodPublicAddress the address at which clients outside the Kubernetes cluster connect to a single pod
+type PodPublicAddress struct {
+	// The name of the pod
+	Pod string `json:"pod"`
+	// The host:port address of the Service of the pod
+	Address string `json:"address"`
+}
+
 // InfinispanStatus defines the observed state of Infinispan
 type InfinispanStatus struct {
 	// +optional
 	Conditions []InfinispanCondition `json:"conditions,omitempty"`
 	// +optional
 	StatefulSetName string `json:"statefulSetName,omitempty"`
+	// The secrets that contain the credentials of the operator user in adminSecretName, and of the application users in
+	// endpointSecretName if authentication is enabled
 	// +optional
 	Security *InfinispanSecurity `json:"security,omitempty"`
 	// +optional
@@ -448,8 +1452,317 @@ type InfinispanStatus struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Infinispan Console URL",xDescriptors="urn:alm:descriptor:org.w3:link"
 	ConsoleUrl *string `json:"consoleUrl,omitempty"`
+	// The host[:port] addresses at which clients connect to the exposed cluster. The hostnames of
+	// spec.expose.externalDNS are used when configured
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Public Addresses"
+	PublicAddresses []string `json:"publicAddresses,omitempty"`
+	// The client intelligence that Hot Rod clients must use to connect to the public addresses of the exposed cluster
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Hot Rod Client Intelligence"
+	ClientIntelligence HotRodClientIntelligence `json:"clientIntelligence,omitempty"`
+	// The host:port address of the Service of each pod that the server advertises in the Hot Rod topology, when
+	// spec.expose.perPod is true
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Pod Public Addresses"
+	PodPublicAddresses []PodPublicAddress `json:"podPublicAddresses,omitempty"`
 	// +optional
 	HotRodRollingUpgradeStatus *HotRodRollingUpgradeStatus `json:"hotRodRollingUpgradeStatus,omitempty"`
+	// The result of the most recent integrity check
+	// +optional
+	IntegrityCheck *IntegrityCheckStatus `json:"integrityCheck,omitempty"`
+	// The sizing recommendations of the most recent evaluation
+	// +optional
+	Recommendations *RecommendationsStatus `json:"recommendations,omitempty"`
+	// The usage of the data volumes measured by the most recent storage check
+	// +optional
+	Storage *StorageStatus `json:"storage,omitempty"`
+	// The state of the scheduled hibernation of the cluster
+	// +optional
+	Autostop *AutostopStatus `json:"autostop,omitempty"`
+	// The most recent successful Backup and Restore of the cluster
+	// +optional
+	DisasterRecovery *DisasterRecoveryStatus `json:"disasterRecovery,omitempty"`
+	// The operation that currently has exclusive access to the cluster
+	// +optional
+	OperationLock *OperationLock `json:"operationLock,omitempty"`
+	// The most recent action requested with the infinispan.org/action annotation
+	// +optional
+	Action *ClusterActionStatus `json:"action,omitempty"`
+	// The state of the replication of writes to the cluster of spec.shadowReplication.target
+	// +optional
+	ShadowReplication *ShadowReplicationStatus `json:"shadowReplication,omitempty"`
+	// The cross-site view of the local site and the status of the backups to each remote site
+	// +optional
+	Sites *CrossSiteStatus `json:"sites,omitempty"`
+	// The most recent state transfer requested with the infinispan.org/xsite-state-transfer annotation
+	// +optional
+	XSiteStateTransfer *XSiteStateTransferStatus `json:"xsiteStateTransfer,omitempty"`
+}
+
+// ClusterActionType an action on all pods of the cluster, requested with the infinispan.org/action annotation
+type ClusterActionType string
+
+const (
+	// ClusterActionGracefulShutdown shuts down all pods, preserving the state of the cluster for the next start
+	ClusterActionGracefulShutdown ClusterActionType = "gracefulShutdown"
+	// ClusterActionStart starts a cluster that was shut down with the number of replicas it had before the shutdown
+	ClusterActionStart ClusterActionType = "start"
+	// ClusterActionRestart shuts down all pods and starts the cluster again with the same number of replicas
+	ClusterActionRestart ClusterActionType = "restart"
+)
+
+// ClusterActionPhase the progress of a ClusterAction
+type ClusterActionPhase string
+
+const (
+	ClusterActionPhaseShuttingDown ClusterActionPhase = "ShuttingDown"
+	ClusterActionPhaseStarting     ClusterActionPhase = "Starting"
+	ClusterActionPhaseSucceeded    ClusterActionPhase = "Succeeded"
+	ClusterActionPhaseFailed       ClusterActionPhase = "Failed"
+)
+
+type ClusterActionStatus struct {
+	// The requested action
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cluster Action"
+	Action ClusterActionType `json:"action"`
+	// The current phase of the action
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cluster Action Phase"
+	Phase ClusterActionPhase `json:"phase"`
+	// The number of replicas that the cluster is started with
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// The outcome of the action, or the reason it was rejected
+	// +optional
+	Message string `json:"message,omitempty"`
+	// The time at which the action was requested
+	StartTime metav1.Time `json:"startTime"`
+	// The time at which the action succeeded or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// XSiteStateTransferPhase the progress of a cross-site state transfer
+type XSiteStateTransferPhase string
+
+const (
+	XSiteStateTransferPhaseInProgress XSiteStateTransferPhase = "InProgress"
+	XSiteStateTransferPhaseSucceeded  XSiteStateTransferPhase = "Succeeded"
+	XSiteStateTransferPhaseFailed     XSiteStateTransferPhase = "Failed"
+	XSiteStateTransferPhaseCancelled  XSiteStateTransferPhase = "Cancelled"
+)
+
+type XSiteStateTransferStatus struct {
+	// The remote site that the state is pushed to
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cross-Site State Transfer Site"
+	Site string `json:"site"`
+	// The current phase of the state transfer
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cross-Site State Transfer Phase"
+	Phase XSiteStateTransferPhase `json:"phase"`
+	// The state transfer of each cache
+	// +optional
+	Caches []XSiteCacheStateTransfer `json:"caches,omitempty"`
+	// The outcome of the state transfer, or the reason it was rejected
+	// +optional
+	Message string `json:"message,omitempty"`
+	// The time at which the state transfer was requested
+	StartTime metav1.Time `json:"startTime"`
+	// The time at which the state transfer succeeded, failed or was cancelled
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+type XSiteCacheStateTransfer struct {
+	// The name of the cache
+	Name string `json:"name"`
+	// The state reported by the server, SENDING, OK, ERROR or CANCELED
+	// +optional
+	State string `json:"state,omitempty"`
+}
+
+// CrossSiteStatus the cross-site view of the local site, as reported by the coordinator of the cluster
+type CrossSiteStatus struct {
+	// The sites in the cross-site view of the local site
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cross-Site View"
+	View []string `json:"view,omitempty"`
+	// The status of the backups to each remote site
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cross-Site Backups"
+	Backups []SiteBackupStatus `json:"backups,omitempty"`
+	// The sites whose backups were taken offline by the operator because of spec.service.sites.locations[].offline
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cross-Site Offline Locations"
+	OfflineLocations []string `json:"offlineLocations,omitempty"`
+}
+
+// SiteBackupState the status of the backups of all caches to a remote site
+type SiteBackupState string
+
+const (
+	SiteBackupOnline  SiteBackupState = "Online"
+	SiteBackupOffline SiteBackupState = "Offline"
+	// SiteBackupMixed means that the backups of some caches to the site are offline
+	SiteBackupMixed SiteBackupState = "Mixed"
+)
+
+type SiteBackupStatus struct {
+	// The name of the remote site
+	Name string `json:"name"`
+	// Online if the backups of all caches to the site are online, Offline if all of them are offline, else Mixed
+	Status SiteBackupState `json:"status"`
+	// The caches with an offline backup to the site, if the status is Mixed
+	// +optional
+	OfflineCaches []string `json:"offlineCaches,omitempty"`
+}
+
+type ShadowReplicationStatus struct {
+	// The name of the Infinispan CR that receives the writes
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Shadow Replication Target"
+	Target string `json:"target"`
+	// The time at which the replication lag was last measured
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+	// The state of the replication of each cache
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Shadow Replication Caches"
+	Caches []ShadowCacheStatus `json:"caches,omitempty"`
+}
+
+type ShadowCacheStatus struct {
+	// The name of the cache
+	Name string `json:"name"`
+	// True once writes to the cache are replicated to the target cluster
+	Connected bool `json:"connected"`
+	// True once the entries that existed before the cache was connected have been copied to the target cluster
+	Synced bool `json:"synced"`
+	// The number of entries in the cache of this cluster, including the entries loaded from the target cluster
+	// +optional
+	SourceEntries int32 `json:"sourceEntries,omitempty"`
+	// The number of entries in the cache of the target cluster
+	// +optional
+	TargetEntries int32 `json:"targetEntries,omitempty"`
+	// The number of entries of the cache of this cluster that are missing from the target cluster
+	// +optional
+	Lag int32 `json:"lag,omitempty"`
+}
+
+// OperationType an operation on the cluster that must not be interleaved with other operations
+type OperationType string
+
+const (
+	OperationUpgrade            OperationType = "Upgrade"
+	OperationBackup             OperationType = "Backup"
+	OperationRestore            OperationType = "Restore"
+	OperationScaling            OperationType = "Scaling"
+	OperationXSiteStateTransfer OperationType = "XSiteStateTransfer"
+)
+
+type OperationLock struct {
+	// The operation that holds the lock
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Operation In Progress"
+	Operation OperationType `json:"operation"`
+	// The name of the CR that requested the operation, e.g. the Backup CR. Empty for operations started by the operator
+	// +optional
+	Owner string `json:"owner,omitempty"`
+	// The time at which the lock was acquired
+	AcquiredTime metav1.Time `json:"acquiredTime"`
+}
+
+type AutostopStatus struct {
+	// True if the cluster was shut down by spec.autostop.schedule and has not yet been woken
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Hibernated"
+	Hibernated bool `json:"hibernated"`
+	// The number of replicas that the cluster is restarted with
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// The time at which the cluster was most recently shut down or woken by the schedules
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+type DisasterRecoveryStatus struct {
+	// The most recent Backup of the cluster that succeeded
+	// +optional
+	LastBackup *BackupRecord `json:"lastBackup,omitempty"`
+	// The most recent Restore of the cluster that succeeded
+	// +optional
+	LastRestore *RestoreRecord `json:"lastRestore,omitempty"`
+}
+
+type BackupRecord struct {
+	// The name of the Backup CR
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Backup"
+	Name string `json:"name"`
+	// The time at which the Backup completed
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Backup Time"
+	CompletionTime metav1.Time `json:"completionTime"`
+	// The name of the PersistentVolumeClaim containing the backup archive
+	PVC string `json:"pvc"`
+	// The size of the backup archive, e.g. "15Mi", omitted if the archive could not be measured
+	// +optional
+	Size string `json:"size,omitempty"`
+}
+
+type RestoreRecord struct {
+	// The name of the Restore CR
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Restore"
+	Name string `json:"name"`
+	// The name of the Backup CR that was restored
+	Backup string `json:"backup"`
+	// The time at which the Restore completed
+	CompletionTime metav1.Time `json:"completionTime"`
+}
+
+type IntegrityCheckStatus struct {
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+	// The number of sampled keys that don't have the same value on all pods, across all checked caches
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Integrity Check Discrepancies"
+	Discrepancies int32 `json:"discrepancies"`
+	// The ConfigMap containing the results of each checked cache
+	ReportConfigMap string `json:"reportConfigMap"`
+}
+
+type RecommendationsStatus struct {
+	LastEvaluationTime metav1.Time `json:"lastEvaluationTime"`
+	// The memory and replicas recommended for the observed usage
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Sizing Recommendations"
+	Items []Recommendation `json:"items,omitempty"`
+}
+
+type StorageStatus struct {
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+	// The usage of the data volume of each ready pod
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Data Volume Usage"
+	Volumes []VolumeUsage `json:"volumes,omitempty"`
+}
+
+type VolumeUsage struct {
+	// The name of the pod that mounts the volume
+	Pod string `json:"pod"`
+	// The name of the PersistentVolumeClaim of the volume
+	PVC string `json:"pvc"`
+	// The capacity of the file system of the volume, e.g. "1Gi"
+	Capacity string `json:"capacity"`
+	// The percentage of the capacity that is used
+	UsedPercent int32 `json:"usedPercent"`
+}
+
+// RecommendationType the resource that a sizing recommendation applies to
+type RecommendationType string
+
+const (
+	RecommendationTypeMemory   RecommendationType = "Memory"
+	RecommendationTypeReplicas RecommendationType = "Replicas"
+)
+
+type Recommendation struct {
+	Type RecommendationType `json:"type"`
+	// The recommended value of the resource, e.g. "2Gi" for Memory or "2" for Replicas
+	Value string `json:"value"`
+	// A human readable description of the usage that caused the recommendation
+	Message string `json:"message"`
 }
 
 type HotRodRollingUpgradeStatus struct {
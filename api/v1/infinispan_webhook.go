@@ -3,15 +3,31 @@ package v1
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	// Embed the time zone database so that spec.container.timezone and spec.autostop.timeZone can be validated regardless
+	// of the operator image
+	_ "time/tzdata"
 
 	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/cron"
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
@@ -20,6 +36,56 @@ import (
 )
 
 var (
+	gcFlag = regexp.MustCompile(`-XX:\+Use\w+GC\b`)
+	// kerberosPrincipal matches a service principal of the form service/host@REALM
+	kerberosPrincipal = regexp.MustCompile(`^[^/@\s]+/[^/@\s]+@[^/@\s]+$`)
+	// cipherSuiteName matches the IANA name of a TLS cipher suite
+	cipherSuiteName = regexp.MustCompile(`^TLS_[A-Z0-9_]+$`)
+	// deniedServerArgs server start arguments configured by the operator which cannot be set in spec.container.extraArgs
+	deniedServerArgs = []string{
+		"-b", "--bind-address",
+		"-c", "--server-config",
+		"-g", "--cluster-name",
+		"-j", "--cluster-stack",
+		"-k", "--cluster-address",
+		"-l", "--logging-config",
+		"-n", "--node-name",
+		"-o", "--port-offset",
+		"-p", "--port",
+		"-s", "--server-root",
+	}
+	// deniedServerProperties system properties configured by the operator which cannot be set in spec.container.extraArgs
+	deniedServerProperties = []string{
+		"infinispan.server.root.path",
+		"infinispan.server.log.path",
+	}
+	// deniedEnvVars env variables of the server container configured by the operator which cannot be set in spec.container.env
+	deniedEnvVars = []string{
+		"ADMIN_IDENTITIES_HASH",
+		"CONFIG_HASH",
+		"CREDENTIALS_HASH",
+		"DEFAULT_IMAGE",
+		"EXTRA_JAVA_OPTIONS",
+		"IDENTITIES_BATCH",
+		"IDENTITIES_HASH",
+		"JAVA_OPTIONS",
+		"KERBEROS_HASH",
+		"LANG",
+		"LC_ALL",
+		"MANAGED_ENV",
+		"OIDC_CLIENT_HASH",
+		"PASSWORD_ALGORITHMS",
+		"SITE_TLS_HASH",
+		"TZ",
+	}
+	// reservedCredentialAliases are the aliases of the credential store entries managed by the operator
+	reservedCredentialAliases = []string{
+		"keystore",
+		"oidc",
+		consts.TransportKeystoreCredentialAlias,
+		consts.TransportTruststoreCredentialAlias,
+		"truststore",
+	}
 	log              = ctrl.Log.WithName("webhook").WithName("Infinispan")
 	eventRec         record.EventRecorder
 	servingCertsMode string
@@ -41,6 +107,9 @@ var _ webhook.Defaulter = &Infinispan{}
 
 // Default implements webhook.Defaulter so a webhook will be registered for the type
 func (i *Infinispan) Default() {
+	if i.IsEdgeProfile() {
+		i.applyEdgeProfileDefaults()
+	}
 	if i.Spec.Service.Type == "" {
 		i.Spec.Service.Type = ServiceTypeCache
 	}
@@ -50,11 +119,14 @@ func (i *Infinispan) Default() {
 	if i.Spec.Container.Memory == "" {
 		i.Spec.Container.Memory = consts.DefaultMemorySize.String()
 	}
+	if i.IsDevMode() {
+		i.applyDevModeDefaults()
+	}
 	if i.IsDataGrid() {
 		if i.Spec.Service.Container == nil {
 			i.Spec.Service.Container = &InfinispanServiceContainerSpec{}
 		}
-		if i.Spec.Service.Container.Storage == nil {
+		if i.Spec.Service.Container.Storage == nil && !i.IsEphemeralStorage() {
 			i.Spec.Service.Container.Storage = pointer.StringPtr(consts.DefaultPVSize.String())
 		}
 	}
@@ -73,11 +145,11 @@ func (i *Infinispan) Default() {
 	}
 	if i.Spec.ConfigListener == nil {
 		i.Spec.ConfigListener = &ConfigListenerSpec{
-			Enabled: true,
+			Enabled: consts.ConfigListenerEnabled,
 		}
 	}
 
-	if i.Spec.Affinity == nil {
+	if i.Spec.Affinity == nil && !i.IsDevMode() && !i.IsEdgeProfile() {
 		// The user hasn't configured Affinity, so we utilise the default strategy of preferring pods are deployed on distinct nodes
 		i.Spec.Affinity = &corev1.Affinity{
 			PodAntiAffinity: &corev1.PodAntiAffinity{
@@ -115,18 +187,107 @@ func (i *Infinispan) Default() {
 	}
 }
 
+// applyDevModeDefaults relaxes the defaults of any fields that the user hasn't configured, so that a single pod can be
+// deployed on a local cluster, e.g. kind, without persistent volumes or certificates
+func (i *Infinispan) applyDevModeDefaults() {
+	if i.Spec.Service.Container == nil {
+		i.Spec.Service.Container = &InfinispanServiceContainerSpec{}
+	}
+	if i.Spec.Service.Container.Storage == nil {
+		i.Spec.Service.Container.EphemeralStorage = true
+	}
+	if i.Spec.Security.EndpointEncryption == nil {
+		i.Spec.Security.EndpointEncryption = &EndpointEncryption{
+			Type: CertificateSourceTypeNoneNoEncryption,
+		}
+	}
+	if i.Spec.Expose == nil {
+		i.Spec.Expose = &ExposeSpec{
+			Type: ExposeTypeNodePort,
+		}
+	}
+}
+
+// applyEdgeProfileDefaults minimises the resources of any fields that the user hasn't configured, so that a single pod
+// can be deployed on edge and IoT devices. Must be applied before the other defaults, as it replaces some of them
+func (i *Infinispan) applyEdgeProfileDefaults() {
+	if i.Spec.Container.Memory == "" {
+		i.Spec.Container.Memory = consts.DefaultEdgeMemorySize.String()
+	}
+	if i.Spec.Container.GCPolicy == "" && i.Spec.Service.Type == ServiceTypeDataGrid {
+		i.Spec.Container.GCPolicy = GCPolicySerial
+	}
+	if i.Spec.Service.ReplicationFactor == 0 && i.Spec.Service.Type != ServiceTypeDataGrid {
+		i.Spec.Service.ReplicationFactor = 1
+	}
+	if i.Spec.ConfigListener == nil {
+		i.Spec.ConfigListener = &ConfigListenerSpec{
+			Enabled: false,
+		}
+	}
+	if i.Spec.Endpoints == nil {
+		i.Spec.Endpoints = &InfinispanEndpointsSpec{}
+	}
+	if i.Spec.Endpoints.Console == nil {
+		i.Spec.Endpoints.Console = &InfinispanConsoleSpec{
+			Enabled: pointer.BoolPtr(false),
+		}
+	}
+	if _, ok := i.Annotations[ServiceMonitoringAnnotation]; !ok {
+		if i.Annotations == nil {
+			i.Annotations = make(map[string]string)
+		}
+		i.Annotations[ServiceMonitoringAnnotation] = strconv.FormatBool(false)
+	}
+}
+
 // +kubebuilder:webhook:path=/validate-infinispan-org-v1-infinispan,mutating=false,failurePolicy=fail,sideEffects=None,groups=infinispan.org,resources=infinispans,verbs=create;update,versions=v1,name=vinfinispan.kb.io,admissionReviewVersions={v1,v1beta1}
 
 var _ webhook.Validator = &Infinispan{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (i *Infinispan) ValidateCreate() error {
-	return i.validate()
+	return i.validate(true)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (i *Infinispan) ValidateUpdate(old runtime.Object) error {
-	return i.validate()
+	oldIspn := old.(*Infinispan)
+	if i.ServerRoot() != oldIspn.ServerRoot() {
+		f := field.NewPath("spec").Child("container").Child("serverRoot")
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: GroupVersion.Group, Kind: "Infinispan"},
+			i.Name, field.ErrorList{field.Forbidden(f, "field is immutable")})
+	}
+	if i.Spec.DevMode != oldIspn.Spec.DevMode {
+		f := field.NewPath("spec").Child("devMode")
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: GroupVersion.Group, Kind: "Infinispan"},
+			i.Name, field.ErrorList{field.Forbidden(f, "field is immutable")})
+	}
+	if i.Spec.Profile != oldIspn.Spec.Profile {
+		f := field.NewPath("spec").Child("profile")
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: GroupVersion.Group, Kind: "Infinispan"},
+			i.Name, field.ErrorList{field.Forbidden(f, "field is immutable")})
+	}
+	// The default discovery service name follows the StatefulSet during Hot Rod rolling upgrades, so only compare the custom name
+	if i.discoveryServiceName() != oldIspn.discoveryServiceName() {
+		f := field.NewPath("spec").Child("service").Child("discovery").Child("name")
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: GroupVersion.Group, Kind: "Infinispan"},
+			i.Name, field.ErrorList{field.Forbidden(f, "field is immutable")})
+	}
+	// Pods of different IP families are unable to form a cluster, so the family can't be changed by a rolling update
+	if i.IsIPv6() != oldIspn.IsIPv6() {
+		f := field.NewPath("spec").Child("ipFamily")
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: GroupVersion.Group, Kind: "Infinispan"},
+			i.Name, field.ErrorList{field.Forbidden(f, "field is immutable")})
+	}
+	// The applied configuration is only checked when it changes, so that updates by other clients are not affected by
+	// the unknown fields of an earlier kubectl apply
+	return i.validate(i.Annotations[lastAppliedAnnotation] != oldIspn.Annotations[lastAppliedAnnotation])
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -135,7 +296,7 @@ func (i *Infinispan) ValidateDelete() error {
 	return nil
 }
 
-func (i *Infinispan) validate() error {
+func (i *Infinispan) validate(checkUnknownFields bool) error {
 	var allErrs field.ErrorList
 
 	if i.Spec.Container.CPU != "" {
@@ -162,6 +323,63 @@ func (i *Infinispan) validate() error {
 		}
 	}
 
+	if i.Spec.Container.MaxRamPercentage != nil {
+		path := field.NewPath("spec").Child("container").Child("maxRamPercentage")
+		if !i.IsDataGrid() {
+			msg := fmt.Sprintf("field only supported with 'spec.service.type=%s'", ServiceTypeDataGrid)
+			allErrs = append(allErrs, field.Forbidden(path, msg))
+		}
+		if strings.Contains(i.Spec.Container.ExtraJvmOpts, "-Xmx") || strings.Contains(i.Spec.Container.ExtraJvmOpts, "MaxRAMPercentage") {
+			msg := "field cannot be combined with a maximum heap size configured in 'spec.container.extraJvmOpts'"
+			allErrs = append(allErrs, field.Forbidden(path, msg))
+		}
+	}
+
+	if i.Spec.Container.GCPolicy != "" {
+		path := field.NewPath("spec").Child("container").Child("gcPolicy")
+		if !i.IsDataGrid() {
+			msg := fmt.Sprintf("field only supported with 'spec.service.type=%s'", ServiceTypeDataGrid)
+			allErrs = append(allErrs, field.Forbidden(path, msg))
+		}
+		if i.ImageType() == ImageTypeNative && i.Spec.Container.GCPolicy != GCPolicySerial {
+			msg := fmt.Sprintf("native images only support '%s'", GCPolicySerial)
+			allErrs = append(allErrs, field.Invalid(path, i.Spec.Container.GCPolicy, msg))
+		}
+		if gcFlag.MatchString(i.Spec.Container.ExtraJvmOpts) {
+			msg := "field cannot be combined with a garbage collector configured in 'spec.container.extraJvmOpts'"
+			allErrs = append(allErrs, field.Forbidden(path, msg))
+		}
+	}
+
+	if diagnostics := i.Spec.Container.Diagnostics; diagnostics != nil {
+		path := field.NewPath("spec").Child("container").Child("diagnostics")
+		if !i.IsDataGrid() {
+			msg := fmt.Sprintf("field only supported with 'spec.service.type=%s'", ServiceTypeDataGrid)
+			allErrs = append(allErrs, field.Forbidden(path, msg))
+		}
+		if i.ImageType() == ImageTypeNative && (diagnostics.GCLogging || diagnostics.FlightRecorder || diagnostics.HeapDumpOnOutOfMemoryError) {
+			allErrs = append(allErrs, field.Forbidden(path, "GC logging, flight recordings and heap dumps are not supported by native images"))
+		}
+		if diagnostics.GCLogging && strings.Contains(i.Spec.Container.ExtraJvmOpts, "-Xlog:gc") {
+			msg := "field cannot be combined with a GC log configured in 'spec.container.extraJvmOpts'"
+			allErrs = append(allErrs, field.Forbidden(path.Child("gcLogging"), msg))
+		}
+		if diagnostics.FlightRecorder && strings.Contains(i.Spec.Container.ExtraJvmOpts, "StartFlightRecording") {
+			msg := "field cannot be combined with a flight recording configured in 'spec.container.extraJvmOpts'"
+			allErrs = append(allErrs, field.Forbidden(path.Child("flightRecorder"), msg))
+		}
+		if diagnostics.HeapDumpOnOutOfMemoryError && strings.Contains(i.Spec.Container.ExtraJvmOpts, "HeapDumpOnOutOfMemoryError") {
+			msg := "field cannot be combined with a heap dump configured in 'spec.container.extraJvmOpts'"
+			allErrs = append(allErrs, field.Forbidden(path.Child("heapDumpOnOutOfMemoryError"), msg))
+		}
+	}
+
+	allErrs = append(allErrs, i.validateContainer()...)
+
+	if checkUnknownFields {
+		allErrs = append(allErrs, i.validateUnknownFields()...)
+	}
+
 	// Warn if memory size exceeds persistent vol
 	if i.IsDataGrid() && !i.IsEphemeralStorage() && i.StorageSize() != "" {
 		size, err := resource.ParseQuantity(i.StorageSize())
@@ -174,6 +392,13 @@ func (i *Infinispan) validate() error {
 		}
 	}
 
+	// Clusters without authentication are intended for development environments behind network isolation
+	if !i.IsAuthenticationEnabled() && (i.IsExposed() || i.HasConsoleExternalService() || i.IsExposedPerPod()) {
+		errMsg := "Authentication is disabled for a cluster that is exposed outside of Kubernetes. Any client can access and modify data."
+		eventRec.Event(i, corev1.EventTypeWarning, "AuthenticationDisabled", errMsg)
+		log.Info(errMsg, "Request.Namespace", i.Namespace, "Request.Name", i.Name)
+	}
+
 	if err := i.validateCacheService(); err != nil {
 		allErrs = append(allErrs, err)
 	}
@@ -184,6 +409,114 @@ func (i *Infinispan) validate() error {
 		allErrs = append(allErrs, err)
 	}
 
+	if ee := i.Spec.Security.EndpointEncryption; ee != nil && ee.ClientCert != "" && ee.ClientCert != ClientCertNone && !i.IsEncryptionEnabled() {
+		msg := fmt.Sprintf("client certificates require encryption, 'spec.security.endpointEncryption.type' must not be %s", CertificateSourceTypeNoneNoEncryption)
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("security").Child("endpointEncryption").Child("clientCert"), msg))
+	}
+
+	if ee := i.Spec.Security.EndpointEncryption; ee != nil && ee.Keystore != nil && (!i.IsEncryptionEnabled() || i.IsEncryptionCertFromService()) {
+		msg := fmt.Sprintf("field can only be configured for 'spec.security.endpointEncryption.type=%s'", CertificateSourceTypeSecret)
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("security").Child("endpointEncryption").Child("keystore"), msg))
+	}
+
+	if ee := i.Spec.Security.EndpointEncryption; ee != nil && (len(ee.Protocols) > 0 || len(ee.CipherSuites) > 0) {
+		allErrs = append(allErrs, i.validateTLSEngine(ee)...)
+	}
+
+	if auditLog := i.Spec.Security.AuditLog; auditLog != nil && auditLog.Enabled {
+		path := field.NewPath("spec").Child("security").Child("auditLog")
+		if !i.IsAuthorizationEnabled() {
+			allErrs = append(allErrs, field.Forbidden(path, "the audit log records authorization decisions and requires 'spec.security.authorization.enabled=true'"))
+		}
+		if _, ok := i.GetLogCategoriesForConfig()[consts.AuditLogCategory]; ok {
+			msg := fmt.Sprintf("the level of the '%s' category is configured by 'spec.security.auditLog.level' when the audit log is enabled", consts.AuditLogCategory)
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("logging").Child("categories").Key(consts.AuditLogCategory), msg))
+		}
+	}
+
+	if i.HasCredentials() {
+		allErrs = append(allErrs, i.validateCredentials()...)
+	}
+
+	if i.Spec.Security.AdminSecretName == i.GetAdminSecretName() {
+		msg := "field must reference a secret that is not generated by the operator"
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("security").Child("adminSecretName"), i.Spec.Security.AdminSecretName, msg))
+	}
+
+	if oidc := i.Spec.Security.EndpointOIDC; oidc != nil {
+		path := field.NewPath("spec").Child("security").Child("endpointOidc")
+		if !i.IsAuthenticationEnabled() {
+			allErrs = append(allErrs, field.Forbidden(path, "field requires 'spec.security.endpointAuthentication=true'"))
+		}
+		if ee := i.Spec.Security.EndpointEncryption; ee != nil && ee.ClientCert == ClientCertAuthenticate {
+			msg := fmt.Sprintf("field cannot be combined with 'spec.security.endpointEncryption.clientCert=%s', as users are authenticated by their certificates", ClientCertAuthenticate)
+			allErrs = append(allErrs, field.Forbidden(path, msg))
+		}
+		if u, err := url.Parse(oidc.AuthServerURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(path.Child("authServerUrl"), oidc.AuthServerURL, "must be an absolute http or https URL"))
+		}
+	}
+
+	if kerberos := i.Spec.Security.EndpointKerberos; kerberos != nil {
+		path := field.NewPath("spec").Child("security").Child("endpointKerberos")
+		if !i.IsAuthenticationEnabled() {
+			allErrs = append(allErrs, field.Forbidden(path, "field requires 'spec.security.endpointAuthentication=true'"))
+		}
+		if !kerberosPrincipal.MatchString(kerberos.Principal) {
+			allErrs = append(allErrs, field.Invalid(path.Child("principal"), kerberos.Principal, "must be a service principal of the form service/host@REALM"))
+		}
+	}
+
+	if sa := i.Spec.Security.EndpointServiceAccount; sa != nil {
+		path := field.NewPath("spec").Child("security").Child("endpointServiceAccount")
+		if !i.IsAuthenticationEnabled() {
+			allErrs = append(allErrs, field.Forbidden(path, "field requires 'spec.security.endpointAuthentication=true'"))
+		}
+		if i.Spec.Security.EndpointOIDC != nil {
+			allErrs = append(allErrs, field.Forbidden(path, "field cannot be combined with 'spec.security.endpointOidc', as both validate the bearer tokens of the REST endpoint"))
+		}
+		if !i.IsRestEnabled() {
+			allErrs = append(allErrs, field.Forbidden(path, "field requires the rest protocol, which is disabled in 'spec.endpoints'"))
+		}
+		for idx, audience := range sa.Audiences {
+			if audience == "" {
+				allErrs = append(allErrs, field.Required(path.Child("audiences").Index(idx), "audience must not be empty"))
+			}
+		}
+	}
+
+	algorithms := map[PasswordAlgorithm]bool{}
+	for idx, algorithm := range i.Spec.Security.PasswordAlgorithms {
+		if algorithms[algorithm] {
+			allErrs = append(allErrs, field.Duplicate(field.NewPath("spec").Child("security").Child("passwordAlgorithms").Index(idx), algorithm))
+		}
+		algorithms[algorithm] = true
+	}
+
+	if i.Spec.Security.RestrictAdminOperations && !i.IsAuthenticationEnabled() {
+		path := field.NewPath("spec").Child("security").Child("restrictAdminOperations")
+		allErrs = append(allErrs, field.Forbidden(path, "field requires 'spec.security.endpointAuthentication=true'"))
+	}
+
+	if vault := i.Spec.Security.Vault; vault != nil {
+		path := field.NewPath("spec").Child("security").Child("vault")
+		switch vault.Provider {
+		case VaultProviderAgentInjector:
+			msg := fmt.Sprintf("field must be provided for 'spec.security.vault.provider=%s'", VaultProviderAgentInjector)
+			if vault.Role == "" {
+				allErrs = append(allErrs, field.Required(path.Child("role"), msg))
+			}
+			if vault.SecretPath == "" {
+				allErrs = append(allErrs, field.Required(path.Child("secretPath"), msg))
+			}
+		case VaultProviderCSI:
+			if vault.SecretProviderClass == "" {
+				msg := fmt.Sprintf("field must be provided for 'spec.security.vault.provider=%s'", VaultProviderCSI)
+				allErrs = append(allErrs, field.Required(path.Child("secretProviderClass"), msg))
+			}
+		}
+	}
+
 	// Validate Hot Rod Rolling Upgrades
 	if i.Spec.Upgrades.Type == UpgradeTypeHotRodRolling {
 		if !i.IsDataGrid() {
@@ -197,6 +530,269 @@ func (i *Infinispan) validate() error {
 			err := field.Forbidden(field.NewPath("spec").Child("service").Child("sites"), msg)
 			allErrs = append(allErrs, err)
 		}
+
+		// The pods of the target StatefulSet are discovered with a dedicated ping service named after the StatefulSet
+		if discovery := i.Spec.Service.Discovery; discovery != nil && discovery.Name != "" {
+			msg := fmt.Sprintf("custom discovery service name not supported with %s upgrades", UpgradeTypeHotRodRolling)
+			err := field.Forbidden(field.NewPath("spec").Child("service").Child("discovery").Child("name"), msg)
+			allErrs = append(allErrs, err)
+		}
+
+		// The Services are created for the pods of the current StatefulSet only
+		if i.IsExposed() && i.Spec.Expose.PerPod {
+			msg := fmt.Sprintf("per-pod Services not supported with %s upgrades", UpgradeTypeHotRodRolling)
+			err := field.Forbidden(field.NewPath("spec").Child("expose").Child("perPod"), msg)
+			allErrs = append(allErrs, err)
+		}
+
+		// Both features connect the caches of the cluster to another cluster with Remote Stores
+		if i.Spec.ShadowReplication != nil {
+			msg := fmt.Sprintf("shadow replication not supported with %s upgrades", UpgradeTypeHotRodRolling)
+			err := field.Forbidden(field.NewPath("spec").Child("shadowReplication"), msg)
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	servicePath := field.NewPath("spec").Child("service")
+	allErrs = append(allErrs, metav1validation.ValidateLabels(i.Spec.Service.Labels, servicePath.Child("labels"))...)
+	allErrs = append(allErrs, apivalidation.ValidateAnnotations(i.Spec.Service.Annotations, servicePath.Child("annotations"))...)
+	if discovery := i.Spec.Service.Discovery; discovery != nil && discovery.Name != "" {
+		namePath := servicePath.Child("discovery").Child("name")
+		for _, msg := range apivalidation.NameIsDNS1035Label(discovery.Name, false) {
+			allErrs = append(allErrs, field.Invalid(namePath, discovery.Name, msg))
+		}
+		switch discovery.Name {
+		case i.GetServiceName(), i.GetAdminServiceName(), i.GetServiceExternalName(), i.GetSiteServiceName():
+			allErrs = append(allErrs, field.Invalid(namePath, discovery.Name, "name is already used by another Service of the cluster"))
+		}
+	}
+	if i.IsExposed() {
+		exposePath := field.NewPath("spec").Child("expose")
+		allErrs = append(allErrs, metav1validation.ValidateLabels(i.Spec.Expose.Labels, exposePath.Child("labels"))...)
+		allErrs = append(allErrs, apivalidation.ValidateAnnotations(i.Spec.Expose.Annotations, exposePath.Child("annotations"))...)
+		for idx, hostname := range i.ExternalDNSHostnames() {
+			var errs []string
+			if strings.HasPrefix(hostname, "*.") {
+				errs = validation.IsWildcardDNS1123Subdomain(hostname)
+			} else {
+				errs = validation.IsDNS1123Subdomain(hostname)
+			}
+			for _, msg := range errs {
+				allErrs = append(allErrs, field.Invalid(exposePath.Child("externalDNS").Child("hostnames").Index(idx), hostname, msg))
+			}
+		}
+		if i.Spec.Expose.PerPod {
+			if !i.IsExposedPerPod() {
+				msg := fmt.Sprintf("per-pod Services not supported with 'spec.expose.type=%s'", i.GetExposeType())
+				allErrs = append(allErrs, field.Forbidden(exposePath.Child("perPod"), msg))
+			} else if !i.IsHotRodEnabled() {
+				allErrs = append(allErrs, field.Forbidden(exposePath.Child("perPod"), "per-pod Services require the Hot Rod endpoint"))
+			}
+		}
+		if i.Spec.Expose.ClientIntelligence != "" && i.ExternalClientIntelligence() == "" {
+			msg := fmt.Sprintf("Hot Rod clients cannot connect with 'spec.expose.type=%s'", i.GetExposeType())
+			allErrs = append(allErrs, field.Forbidden(exposePath.Child("clientIntelligence"), msg))
+		}
+	}
+
+	if i.IsClusterIPOnly() && !reflect.DeepEqual(*i.Spec.Expose, ExposeSpec{Type: ExposeTypeClusterIP}) {
+		msg := fmt.Sprintf("no other fields are supported with 'spec.expose.type=%s', as the cluster is not exposed", ExposeTypeClusterIP)
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("expose"), msg))
+	}
+
+	if i.IsExposed() && i.GetExposeType() == ExposeTypeGateway && i.Spec.Expose.GatewayName == "" {
+		msg := fmt.Sprintf("field must be provided for 'spec.expose.type=%s'", ExposeTypeGateway)
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("expose").Child("gatewayName"), msg))
+	}
+
+	if i.IsExposed() && i.IsGatewayTLSPassthrough() && i.Spec.Expose.Host == "" {
+		msg := fmt.Sprintf("field must be provided for 'spec.expose.type=%s' with encryption, as the Gateway routes TLS passthrough connections by SNI hostname", ExposeTypeGateway)
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("expose").Child("host"), msg))
+	}
+
+	if i.IsExposed() && i.GetExposeType() != ExposeTypeIngress {
+		exposePath := field.NewPath("spec").Child("expose")
+		msg := fmt.Sprintf("only supported with 'spec.expose.type=%s'", ExposeTypeIngress)
+		if i.Spec.Expose.IngressClassName != "" {
+			allErrs = append(allErrs, field.Forbidden(exposePath.Child("ingressClassName"), msg))
+		}
+		if i.Spec.Expose.TLSSecretName != "" {
+			allErrs = append(allErrs, field.Forbidden(exposePath.Child("tlsSecretName"), msg))
+		}
+	}
+
+	if i.IsExposed() {
+		exposePath := field.NewPath("spec").Child("expose")
+		termination := i.Spec.Expose.RouteTermination
+		if termination != "" && i.GetExposeType() != ExposeTypeRoute {
+			msg := fmt.Sprintf("only supported with 'spec.expose.type=%s'", ExposeTypeRoute)
+			allErrs = append(allErrs, field.Forbidden(exposePath.Child("routeTermination"), msg))
+		}
+		switch {
+		case (termination == RouteTerminationPassthrough || termination == RouteTerminationReencrypt) && !i.IsEncryptionEnabled():
+			msg := fmt.Sprintf("'%s' termination requires endpoint encryption to be enabled", termination)
+			allErrs = append(allErrs, field.Invalid(exposePath.Child("routeTermination"), termination, msg))
+		case termination == RouteTerminationEdge && i.IsEncryptionEnabled():
+			msg := fmt.Sprintf("'%s' termination requires endpoint encryption to be disabled", termination)
+			allErrs = append(allErrs, field.Invalid(exposePath.Child("routeTermination"), termination, msg))
+		}
+		if i.Spec.Expose.RouteDestinationCASecretName != "" && termination != RouteTerminationReencrypt {
+			msg := fmt.Sprintf("only supported with 'spec.expose.routeTermination=%s'", RouteTerminationReencrypt)
+			allErrs = append(allErrs, field.Forbidden(exposePath.Child("routeDestinationCASecretName"), msg))
+		}
+	}
+
+	if i.IsExposed() {
+		exposePath := field.NewPath("spec").Child("expose")
+		if i.GetExposeType() != ExposeTypeLoadBalancer {
+			msg := fmt.Sprintf("only supported with 'spec.expose.type=%s'", ExposeTypeLoadBalancer)
+			if i.Spec.Expose.LoadBalancerIP != "" {
+				allErrs = append(allErrs, field.Forbidden(exposePath.Child("loadBalancerIP"), msg))
+			}
+			if len(i.Spec.Expose.LoadBalancerSourceRanges) > 0 {
+				allErrs = append(allErrs, field.Forbidden(exposePath.Child("loadBalancerSourceRanges"), msg))
+			}
+		}
+		if t := i.GetExposeType(); t != ExposeTypeNodePort && t != ExposeTypeLoadBalancer {
+			msg := fmt.Sprintf("only supported with 'spec.expose.type=%s' or 'spec.expose.type=%s'", ExposeTypeNodePort, ExposeTypeLoadBalancer)
+			if i.Spec.Expose.ExternalTrafficPolicy != "" {
+				allErrs = append(allErrs, field.Forbidden(exposePath.Child("externalTrafficPolicy"), msg))
+			}
+			if i.Spec.Expose.SessionAffinity != "" {
+				allErrs = append(allErrs, field.Forbidden(exposePath.Child("sessionAffinity"), msg))
+			}
+		}
+		if i.Spec.Expose.SessionAffinityTimeoutSeconds > 0 && i.Spec.Expose.SessionAffinity != corev1.ServiceAffinityClientIP {
+			msg := fmt.Sprintf("only supported with 'spec.expose.sessionAffinity=%s'", corev1.ServiceAffinityClientIP)
+			allErrs = append(allErrs, field.Forbidden(exposePath.Child("sessionAffinityTimeoutSeconds"), msg))
+		}
+		if ip := i.Spec.Expose.LoadBalancerIP; ip != "" && net.ParseIP(ip) == nil {
+			allErrs = append(allErrs, field.Invalid(exposePath.Child("loadBalancerIP"), ip, "must be a valid IP address"))
+		}
+		for idx, cidr := range i.Spec.Expose.LoadBalancerSourceRanges {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				allErrs = append(allErrs, field.Invalid(exposePath.Child("loadBalancerSourceRanges").Index(idx), cidr, "must be a valid CIDR, for example 10.0.0.0/8"))
+			}
+		}
+	}
+
+	if i.IsExposed() {
+		allErrs = append(allErrs, i.validateExposePorts()...)
+	}
+
+	if i.Spec.Endpoints != nil {
+		allErrs = append(allErrs, i.validateEndpoints()...)
+	}
+
+	if i.Spec.CapacityFactor != nil {
+		allErrs = append(allErrs, i.validateCapacityFactor()...)
+	}
+
+	if i.Spec.ShadowReplication != nil {
+		allErrs = append(allErrs, i.validateShadowReplication()...)
+	}
+
+	if i.HasSites() {
+		allErrs = append(allErrs, i.validateSites()...)
+	}
+
+	if i.IsExposed() && i.Spec.Expose.TLSSecretName != "" && i.Spec.Expose.Host == "" {
+		msg := "field must be provided when 'spec.expose.tlsSecretName' is configured"
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("expose").Child("host"), msg))
+	}
+
+	if migration := i.Spec.Upgrades.DataMigration; migration != nil && migration.ConfigMapName != "" {
+		f := field.NewPath("spec").Child("upgrades").Child("dataMigration").Child("configMapName")
+		if i.Spec.Upgrades.Type != UpgradeTypeShutdown {
+			msg := fmt.Sprintf("data migration only supported with 'spec.upgrades.type=%s'", UpgradeTypeShutdown)
+			allErrs = append(allErrs, field.Forbidden(f, msg))
+		} else if i.ImageType() == ImageTypeNative {
+			allErrs = append(allErrs, field.Forbidden(f, "data migration not supported with native images"))
+		}
+	}
+
+	if maxUnavailable := i.Spec.Upgrades.MaxUnavailable; maxUnavailable != nil && i.IsCache() && *maxUnavailable >= i.Spec.Service.ReplicationFactor {
+		f := field.NewPath("spec").Child("upgrades").Child("maxUnavailable")
+		msg := fmt.Sprintf("must be less than 'spec.service.replicationFactor=%d' so that entries are not lost during rollouts", i.Spec.Service.ReplicationFactor)
+		allErrs = append(allErrs, field.Invalid(f, *maxUnavailable, msg))
+	}
+
+	if i.Spec.IntegrityCheck != nil && i.Spec.IntegrityCheck.Interval != nil && i.Spec.IntegrityCheck.Interval.Duration <= 0 {
+		f := field.NewPath("spec").Child("integrityCheck").Child("interval")
+		allErrs = append(allErrs, field.Invalid(f, i.Spec.IntegrityCheck.Interval.Duration.String(), "interval must be greater than zero"))
+	}
+
+	if i.Spec.Recommendations != nil && i.Spec.Recommendations.Interval != nil && i.Spec.Recommendations.Interval.Duration <= 0 {
+		f := field.NewPath("spec").Child("recommendations").Child("interval")
+		allErrs = append(allErrs, field.Invalid(f, i.Spec.Recommendations.Interval.Duration.String(), "interval must be greater than zero"))
+	}
+
+	if i.IsJmxEnabled() {
+		path := field.NewPath("spec").Child("jmx")
+		if !i.IsDataGrid() {
+			msg := fmt.Sprintf("JMX only supported with 'spec.service.type=%s'", ServiceTypeDataGrid)
+			allErrs = append(allErrs, field.Forbidden(path.Child("enabled"), msg))
+		} else if i.ImageType() == ImageTypeNative {
+			allErrs = append(allErrs, field.Forbidden(path.Child("enabled"), "JMX not supported with native images"))
+		}
+		if i.Spec.Jmx.SecretName == "" {
+			allErrs = append(allErrs, field.Required(path.Child("secretName"), "field must be provided when JMX is enabled"))
+		}
+	}
+
+	if i.IsAutostopEnabled() {
+		allErrs = append(allErrs, i.validateAutostop()...)
+	}
+
+	if i.Spec.StorageMonitoring != nil && i.Spec.StorageMonitoring.Enabled {
+		allErrs = append(allErrs, i.validateStorageMonitoring()...)
+	}
+
+	if i.IsDevMode() {
+		if i.Spec.Replicas > 1 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("replicas"), i.Spec.Replicas, "dev mode only supports a single replica"))
+		}
+		if pattern := devModeDeniedPattern(i.Namespace); pattern != "" {
+			msg := fmt.Sprintf("dev mode is not allowed in namespaces matching '%s'", pattern)
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("devMode"), msg))
+		}
+	}
+
+	if action, ok := i.Annotations[consts.AnnotationAction]; ok {
+		switch ClusterActionType(action) {
+		case ClusterActionGracefulShutdown, ClusterActionStart, ClusterActionRestart:
+		default:
+			f := field.NewPath("metadata").Child("annotations").Key(consts.AnnotationAction)
+			allErrs = append(allErrs, field.NotSupported(f, action, []string{string(ClusterActionGracefulShutdown), string(ClusterActionStart), string(ClusterActionRestart)}))
+		}
+	}
+
+	if request, ok := i.Annotations[consts.AnnotationXSiteStateTransfer]; ok {
+		f := field.NewPath("metadata").Child("annotations").Key(consts.AnnotationXSiteStateTransfer)
+		if !i.HasSites() {
+			allErrs = append(allErrs, field.Forbidden(f, "cross-site replication is not configured"))
+		} else if _, remote := i.GetRemoteSiteLocations()[request]; !remote && request != "cancel" {
+			var supported []string
+			for location := range i.GetRemoteSiteLocations() {
+				supported = append(supported, location)
+			}
+			sort.Strings(supported)
+			supported = append(supported, "cancel")
+			allErrs = append(allErrs, field.NotSupported(f, request, supported))
+		}
+	}
+
+	if i.IsEdgeProfile() {
+		profilePath := field.NewPath("spec").Child("profile")
+		if i.Spec.Replicas > 1 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("replicas"), i.Spec.Replicas, "edge profile only supports a single replica"))
+		}
+		if i.IsDevMode() {
+			allErrs = append(allErrs, field.Forbidden(profilePath, "edge profile cannot be combined with 'spec.devMode'"))
+		}
+		if i.HasSites() {
+			allErrs = append(allErrs, field.Forbidden(profilePath, "edge profile does not support cross-site replication"))
+		}
 	}
 
 	if i.HasExternalArtifacts() {
@@ -218,6 +814,500 @@ func (i *Infinispan) validate() error {
 	return nil
 }
 
+// validateUnknownFields rejects the unknown fields of the applied configuration in strict mode, otherwise records a
+// warning event so that misspelled fields are not silently ignored
+func (i *Infinispan) validateUnknownFields() field.ErrorList {
+	unknown := i.UnknownFields()
+	if len(unknown) == 0 {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if i.IsStrictValidation() {
+		for _, f := range unknown {
+			allErrs = append(allErrs, field.Forbidden(f.Path, f.String()))
+		}
+		return allErrs
+	}
+	msgs := make([]string, len(unknown))
+	for idx, f := range unknown {
+		msgs[idx] = f.String()
+	}
+	errMsg := fmt.Sprintf("Fields ignored as they are not part of the schema: %s", strings.Join(msgs, "; "))
+	eventRec.Event(i, corev1.EventTypeWarning, "UnknownFields", errMsg)
+	log.Info(errMsg, "Request.Namespace", i.Namespace, "Request.Name", i.Name)
+	return nil
+}
+
+func (i *Infinispan) validateAutostop() field.ErrorList {
+	var allErrs field.ErrorList
+	autostopPath := field.NewPath("spec").Child("autostop")
+	if !i.IsDataGrid() || i.IsEphemeralStorage() {
+		msg := fmt.Sprintf("autostop only supported with 'spec.service.type=%s' and persistent storage", ServiceTypeDataGrid)
+		allErrs = append(allErrs, field.Forbidden(autostopPath, msg))
+	}
+	if _, err := cron.Parse(i.Spec.Autostop.Schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(autostopPath.Child("schedule"), i.Spec.Autostop.Schedule, err.Error()))
+	}
+	if _, err := cron.Parse(i.Spec.Autostop.WakeSchedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(autostopPath.Child("wakeSchedule"), i.Spec.Autostop.WakeSchedule, err.Error()))
+	}
+	if _, err := i.AutostopLocation(); err != nil {
+		allErrs = append(allErrs, field.Invalid(autostopPath.Child("timeZone"), i.Spec.Autostop.TimeZone, "must be an IANA time zone name"))
+	}
+	return allErrs
+}
+
+// validateCredentials verifies that the aliases of spec.security.credentials are unique and do not replace the
+// credentials that the operator adds to the credential store
+func (i *Infinispan) validateCredentials() field.ErrorList {
+	var allErrs field.ErrorList
+	credentialsPath := field.NewPath("spec").Child("security").Child("credentials")
+	aliases := map[string]bool{}
+	for idx, credential := range i.Spec.Security.Credentials {
+		aliasPath := credentialsPath.Index(idx).Child("alias")
+		if aliases[credential.Alias] {
+			allErrs = append(allErrs, field.Duplicate(aliasPath, credential.Alias))
+		}
+		aliases[credential.Alias] = true
+		for _, reserved := range reservedCredentialAliases {
+			if credential.Alias == reserved {
+				allErrs = append(allErrs, field.Forbidden(aliasPath, fmt.Sprintf("the '%s' alias is reserved for the credentials managed by the operator", reserved)))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateTLSEngine verifies that the cipher suites can be negotiated with the TLS protocols of the endpoints
+func (i *Infinispan) validateTLSEngine(ee *EndpointEncryption) field.ErrorList {
+	var allErrs field.ErrorList
+	encryptionPath := field.NewPath("spec").Child("security").Child("endpointEncryption")
+	if !i.IsEncryptionEnabled() {
+		msg := "TLS protocols and cipher suites can only be configured when endpoint encryption is enabled"
+		if len(ee.Protocols) > 0 {
+			allErrs = append(allErrs, field.Forbidden(encryptionPath.Child("protocols"), msg))
+		}
+		if len(ee.CipherSuites) > 0 {
+			allErrs = append(allErrs, field.Forbidden(encryptionPath.Child("cipherSuites"), msg))
+		}
+		return allErrs
+	}
+
+	var tls12, tls13 bool
+	for _, protocol := range ee.Protocols {
+		tls12 = tls12 || protocol == TLSVersion12
+		tls13 = tls13 || protocol == TLSVersion13
+	}
+	if len(ee.Protocols) == 0 {
+		tls12, tls13 = true, true
+	}
+
+	if len(ee.CipherSuites) == 0 {
+		return allErrs
+	}
+	var tls12Suites, tls13Suites bool
+	for idx, cipherSuite := range ee.CipherSuites {
+		if !cipherSuiteName.MatchString(cipherSuite) {
+			allErrs = append(allErrs, field.Invalid(encryptionPath.Child("cipherSuites").Index(idx), cipherSuite, "must be the IANA name of a cipher suite, for example TLS_AES_256_GCM_SHA384"))
+		} else if IsTLS13CipherSuite(cipherSuite) {
+			tls13Suites = true
+		} else {
+			tls12Suites = true
+		}
+	}
+	if tls13 && !tls12 && !tls13Suites {
+		allErrs = append(allErrs, field.Invalid(encryptionPath.Child("cipherSuites"), ee.CipherSuites, "at least one TLSv1.3 cipher suite is required when only TLSv1.3 is enabled"))
+	}
+	if tls12 && !tls13 && !tls12Suites {
+		allErrs = append(allErrs, field.Invalid(encryptionPath.Child("cipherSuites"), ee.CipherSuites, "at least one TLSv1.2 cipher suite is required when only TLSv1.2 is enabled"))
+	}
+	return allErrs
+}
+
+func (i *Infinispan) validateExposePorts() field.ErrorList {
+	var allErrs field.ErrorList
+	exposePath := field.NewPath("spec").Child("expose")
+	expose := i.Spec.Expose
+	minNodePort, maxNodePort := nodePortRange()
+	nodePortMsg := fmt.Sprintf("must be in the NodePort range %d-%d of the cluster", minNodePort, maxNodePort)
+	if expose.NodePort != 0 && (expose.NodePort < minNodePort || expose.NodePort > maxNodePort) {
+		allErrs = append(allErrs, field.Invalid(exposePath.Child("nodePort"), expose.NodePort, nodePortMsg))
+	}
+
+	if expose.Endpoints == nil {
+		return allErrs
+	}
+	endpointsPath := exposePath.Child("endpoints")
+	if i.GetExposeType() != ExposeTypeNodePort && i.GetExposeType() != ExposeTypeLoadBalancer {
+		msg := fmt.Sprintf("only supported with 'spec.expose.type=%s' or 'spec.expose.type=%s'", ExposeTypeNodePort, ExposeTypeLoadBalancer)
+		return append(allErrs, field.Forbidden(endpointsPath, msg))
+	}
+	if expose.NodePort != 0 || expose.Port != 0 {
+		allErrs = append(allErrs, field.Forbidden(endpointsPath, "field cannot be combined with 'spec.expose.nodePort' or 'spec.expose.port'"))
+	}
+	if expose.Endpoints.Admin != nil && i.IsAdminAccessRestricted() {
+		allErrs = append(allErrs, field.Forbidden(endpointsPath.Child("admin"), "the admin endpoint cannot be exposed when 'spec.security.restrictAdminAccess' or 'spec.security.restrictAdminOperations' is true"))
+	}
+
+	exposed := i.GetExposedEndpoints()
+	if len(exposed) == 0 {
+		allErrs = append(allErrs, field.Required(endpointsPath, "at least one endpoint must be configured"))
+	}
+	ports := map[int32]string{}
+	nodePorts := map[int32]string{}
+	for _, endpoint := range exposed {
+		endpointPath := endpointsPath.Child(endpoint.Name)
+		if other, exists := ports[endpoint.Port]; exists {
+			allErrs = append(allErrs, field.Duplicate(endpointPath.Child("port"), fmt.Sprintf("%d already used by the %s endpoint", endpoint.Port, other)))
+		}
+		ports[endpoint.Port] = endpoint.Name
+
+		if endpoint.NodePort == 0 {
+			continue
+		}
+		if i.GetExposeType() != ExposeTypeNodePort {
+			msg := fmt.Sprintf("only supported with 'spec.expose.type=%s'", ExposeTypeNodePort)
+			allErrs = append(allErrs, field.Forbidden(endpointPath.Child("nodePort"), msg))
+		} else if endpoint.NodePort < minNodePort || endpoint.NodePort > maxNodePort {
+			allErrs = append(allErrs, field.Invalid(endpointPath.Child("nodePort"), endpoint.NodePort, nodePortMsg))
+		}
+		if other, exists := nodePorts[endpoint.NodePort]; exists {
+			allErrs = append(allErrs, field.Duplicate(endpointPath.Child("nodePort"), fmt.Sprintf("%d already used by the %s endpoint", endpoint.NodePort, other)))
+		}
+		nodePorts[endpoint.NodePort] = endpoint.Name
+	}
+	return allErrs
+}
+
+// reservedPorts are the container ports used by the operator and the server, which cannot be assigned to a protocol
+var reservedPorts = map[int32]string{
+	consts.InfinispanUserPort:  "the user endpoint",
+	consts.InfinispanAdminPort: "the admin endpoint",
+	consts.InfinispanPingPort:  "cluster discovery",
+	consts.CrossSitePort:       "cross-site replication",
+	consts.InfinispanJmxPort:   "JMX",
+	7800:                       "the JGroups transport",
+}
+
+func (i *Infinispan) validateStorageMonitoring() field.ErrorList {
+	var allErrs field.ErrorList
+	spec := i.Spec.StorageMonitoring
+	path := field.NewPath("spec").Child("storageMonitoring")
+	if i.IsEphemeralStorage() {
+		allErrs = append(allErrs, field.Forbidden(path.Child("enabled"), "storage monitoring requires persistent storage"))
+	}
+	if spec.Interval != nil && spec.Interval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("interval"), spec.Interval.Duration.String(), "interval must be greater than zero"))
+	}
+	if spec.AutoExpand == nil || !spec.AutoExpand.Enabled {
+		return allErrs
+	}
+	maxSizePath := path.Child("autoExpand").Child("maxSize")
+	if spec.AutoExpand.MaxSize == "" {
+		return append(allErrs, field.Required(maxSizePath, "field must be provided when autoExpand is enabled"))
+	}
+	maxSize, err := resource.ParseQuantity(spec.AutoExpand.MaxSize)
+	if err != nil {
+		return append(allErrs, field.Invalid(maxSizePath, spec.AutoExpand.MaxSize, err.Error()))
+	}
+	if i.IsDataGrid() && i.StorageSize() != "" {
+		if size, err := resource.ParseQuantity(i.StorageSize()); err == nil && maxSize.Cmp(size) < 0 {
+			msg := fmt.Sprintf("must not be less than 'spec.service.container.storage=%s'", i.StorageSize())
+			allErrs = append(allErrs, field.Invalid(maxSizePath, spec.AutoExpand.MaxSize, msg))
+		}
+	}
+	return allErrs
+}
+
+func (i *Infinispan) validateConsoleExpose(exposePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	expose := i.Spec.Endpoints.Console.Expose
+	switch expose.Type {
+	case ConsoleExposeTypeRoute, ConsoleExposeTypeIngress:
+		if !i.IsConsoleEnabled() {
+			allErrs = append(allErrs, field.Forbidden(exposePath.Child("type"), "the console is disabled in 'spec.endpoints.console.enabled'"))
+		}
+		if expose.Type == ConsoleExposeTypeIngress && expose.Host == "" {
+			msg := fmt.Sprintf("field must be provided for 'spec.endpoints.console.expose.type=%s'", ConsoleExposeTypeIngress)
+			allErrs = append(allErrs, field.Required(exposePath.Child("host"), msg))
+		}
+	default:
+		msg := fmt.Sprintf("only supported with 'spec.endpoints.console.expose.type=%s' or 'spec.endpoints.console.expose.type=%s'", ConsoleExposeTypeRoute, ConsoleExposeTypeIngress)
+		if expose.Host != "" {
+			allErrs = append(allErrs, field.Forbidden(exposePath.Child("host"), msg))
+		}
+		if len(expose.Annotations) > 0 {
+			allErrs = append(allErrs, field.Forbidden(exposePath.Child("annotations"), msg))
+		}
+	}
+	// Services and TLS passthrough forward connections without inspecting the path of requests
+	if expose.Type == ConsoleExposeTypeNone && i.IsConsoleEnabled() && !i.CanExposeWithoutConsole() {
+		msg := fmt.Sprintf("the console cannot be excluded from 'spec.expose.type=%s', disable the console with 'spec.endpoints.console.enabled=false'", i.GetExposeType())
+		allErrs = append(allErrs, field.Forbidden(exposePath.Child("type"), msg))
+	}
+	return allErrs
+}
+
+func (i *Infinispan) validateEndpoints() field.ErrorList {
+	var allErrs field.ErrorList
+	endpointsPath := field.NewPath("spec").Child("endpoints")
+	endpoints := i.Spec.Endpoints
+	if !i.IsHotRodEnabled() && !i.IsRestEnabled() {
+		allErrs = append(allErrs, field.Forbidden(endpointsPath, "at least one of the hotrod or rest protocols must be enabled"))
+	}
+	if console := endpoints.Console; console != nil && console.Enabled != nil && *console.Enabled && !i.IsRestEnabled() {
+		allErrs = append(allErrs, field.Forbidden(endpointsPath.Child("console").Child("enabled"), "the console requires the rest protocol"))
+	}
+	if console := endpoints.Console; console != nil && console.Expose != nil {
+		allErrs = append(allErrs, i.validateConsoleExpose(endpointsPath.Child("console").Child("expose"))...)
+	}
+	if !i.IsRestEnabled() && (i.GetExposeType() == ExposeTypeIngress || i.GetExposeType() == ExposeTypeGateway && !i.IsEncryptionEnabled()) {
+		msg := fmt.Sprintf("the rest protocol cannot be disabled with 'spec.expose.type=%s'", i.GetExposeType())
+		allErrs = append(allErrs, field.Forbidden(endpointsPath.Child("rest").Child("enabled"), msg))
+	}
+	if expose := i.Spec.Expose; i.IsExposed() && expose.Endpoints != nil {
+		exposePath := field.NewPath("spec").Child("expose").Child("endpoints")
+		if expose.Endpoints.HotRod != nil && !i.IsHotRodEnabled() {
+			allErrs = append(allErrs, field.Forbidden(exposePath.Child("hotrod"), "the hotrod protocol is disabled in 'spec.endpoints'"))
+		}
+		if expose.Endpoints.Rest != nil && !i.IsRestEnabled() {
+			allErrs = append(allErrs, field.Forbidden(exposePath.Child("rest"), "the rest protocol is disabled in 'spec.endpoints'"))
+		}
+	}
+	if listeners := endpoints.ClientListeners; listeners != nil && listeners.SendBufferSize > 0 && !i.IsHotRodEnabled() {
+		allErrs = append(allErrs, field.Forbidden(endpointsPath.Child("clientListeners").Child("sendBufferSize"), "the hotrod protocol is disabled"))
+	}
+
+	validatePort := func(name string, endpoint *InfinispanEndpointSpec) {
+		if endpoint == nil || endpoint.Port == 0 {
+			return
+		}
+		if use, reserved := reservedPorts[endpoint.Port]; reserved {
+			allErrs = append(allErrs, field.Invalid(endpointsPath.Child(name).Child("port"), endpoint.Port, fmt.Sprintf("port is reserved for %s", use)))
+		}
+	}
+	validatePort("hotrod", endpoints.HotRod)
+	validatePort("rest", endpoints.Rest)
+	if endpoints.HotRod != nil && endpoints.Rest != nil && endpoints.HotRod.Port != 0 && endpoints.HotRod.Port == endpoints.Rest.Port {
+		allErrs = append(allErrs, field.Duplicate(endpointsPath.Child("rest").Child("port"), fmt.Sprintf("%d already used by the hotrod protocol", endpoints.Rest.Port)))
+	}
+	return allErrs
+}
+
+// validateSites verifies that the fields of spec.service.sites.local.expose apply to the cross-site expose type, and that
+// the remote sites are able to reach the local site with it
+func (i *Infinispan) validateSites() field.ErrorList {
+	var allErrs field.ErrorList
+	sitesPath := field.NewPath("spec").Child("service").Child("sites")
+	exposePath := sitesPath.Child("local").Child("expose")
+	expose := i.Spec.Service.Sites.Local.Expose
+	exposeType := i.GetCrossSiteExposeType()
+
+	forbiddenFor := func(name string, supported CrossSiteExposeType) {
+		msg := fmt.Sprintf("only supported with 'spec.service.sites.local.expose.type=%s'", supported)
+		allErrs = append(allErrs, field.Forbidden(exposePath.Child(name), msg))
+	}
+	if expose.NodePort != 0 {
+		minNodePort, maxNodePort := nodePortRange()
+		if exposeType != CrossSiteExposeTypeNodePort {
+			forbiddenFor("nodePort", CrossSiteExposeTypeNodePort)
+		} else if expose.NodePort < minNodePort || expose.NodePort > maxNodePort {
+			msg := fmt.Sprintf("must be in the NodePort range %d-%d of the cluster", minNodePort, maxNodePort)
+			allErrs = append(allErrs, field.Invalid(exposePath.Child("nodePort"), expose.NodePort, msg))
+		}
+	}
+	if expose.Port != 0 && exposeType != CrossSiteExposeTypeLoadBalancer {
+		forbiddenFor("port", CrossSiteExposeTypeLoadBalancer)
+	}
+	if expose.RouteHostName != "" && exposeType != CrossSiteExposeTypeRoute {
+		forbiddenFor("routeHostName", CrossSiteExposeTypeRoute)
+	}
+	if i.IsClusterIPOnly() && exposeType != CrossSiteExposeTypeClusterIP && exposeType != CrossSiteExposeTypeClusterSet {
+		msg := fmt.Sprintf("the cross-site service cannot be exposed outside of Kubernetes with 'spec.expose.type=%s'", ExposeTypeClusterIP)
+		allErrs = append(allErrs, field.Forbidden(exposePath.Child("type"), msg))
+	}
+
+	for idx, location := range i.Spec.Service.Sites.Locations {
+		locationPath := sitesPath.Child("locations").Index(idx)
+		if location.Name == i.Spec.Service.Sites.Local.Name {
+			if location.Offline {
+				allErrs = append(allErrs, field.Forbidden(locationPath.Child("offline"), "the local site has no backups to itself"))
+			}
+			if location.CASecretName != "" {
+				allErrs = append(allErrs, field.Forbidden(locationPath.Child("caSecretName"), "the CA of the local site is configured with 'spec.service.sites.local.encryption.trustStore'"))
+			}
+			continue
+		}
+		if location.CASecretName != "" && !i.IsSiteTLSEnabled() {
+			allErrs = append(allErrs, field.Forbidden(locationPath.Child("caSecretName"), "only supported when 'spec.service.sites.local.encryption' is configured"))
+		}
+		scheme := strings.SplitN(location.URL, "://", 2)[0]
+		switch {
+		case location.URL == "":
+			// The remote site is resolved in the same Kubernetes cluster, or in the ClusterSet, by its Service name
+			if consts.GetWithDefault(location.ClusterName, i.Name) == i.Name && consts.GetWithDefault(location.Namespace, i.Namespace) == i.Namespace {
+				msg := "the clusterName or namespace of a location without url must differ from the local cluster"
+				allErrs = append(allErrs, field.Invalid(locationPath, location.Name, msg))
+			}
+		case scheme != consts.StaticCrossSiteUriSchema:
+			// The remote sites of a ClusterSet are resolved by the Services that they export
+			if exposeType == CrossSiteExposeTypeClusterSet {
+				msg := fmt.Sprintf("remove the url to resolve the remote site in the ClusterSet, or use the '%s' scheme", consts.StaticCrossSiteUriSchema)
+				allErrs = append(allErrs, field.Forbidden(locationPath.Child("url"), msg))
+				continue
+			}
+			// The remote site is in another Kubernetes cluster, which cannot connect to a ClusterIP Service
+			if exposeType == CrossSiteExposeTypeClusterIP {
+				msg := fmt.Sprintf("sites in other Kubernetes clusters cannot reach the local site with 'spec.service.sites.local.expose.type=%s'", CrossSiteExposeTypeClusterIP)
+				allErrs = append(allErrs, field.Forbidden(locationPath.Child("url"), msg))
+			}
+			if location.SecretName == "" {
+				msg := fmt.Sprintf("the access secret of the remote Kubernetes API is required with the '%s' scheme", scheme)
+				allErrs = append(allErrs, field.Required(locationPath.Child("secretName"), msg))
+			}
+		}
+	}
+	return allErrs
+}
+
+func (i *Infinispan) validateCapacityFactor() field.ErrorList {
+	var allErrs field.ErrorList
+	capacityPath := field.NewPath("spec").Child("capacityFactor")
+	spec := i.Spec.CapacityFactor
+	validateFactor := func(path *field.Path, factor string) {
+		if f, err := strconv.ParseFloat(factor, 32); err != nil || f <= 0 {
+			allErrs = append(allErrs, field.Invalid(path, factor, "must be a number greater than 0, e.g. \"1.5\""))
+		}
+	}
+	if spec.TopologyKey != "" {
+		for _, msg := range validation.IsQualifiedName(spec.TopologyKey) {
+			allErrs = append(allErrs, field.Invalid(capacityPath.Child("topologyKey"), spec.TopologyKey, msg))
+		}
+	}
+	if spec.Default != "" {
+		validateFactor(capacityPath.Child("default"), spec.Default)
+	}
+	zones := map[string]bool{}
+	for idx, zone := range spec.Zones {
+		zonePath := capacityPath.Child("zones").Index(idx)
+		if zones[zone.Zone] {
+			allErrs = append(allErrs, field.Duplicate(zonePath.Child("zone"), zone.Zone))
+		}
+		zones[zone.Zone] = true
+		validateFactor(zonePath.Child("factor"), zone.Factor)
+	}
+	return allErrs
+}
+
+func (i *Infinispan) validateShadowReplication() field.ErrorList {
+	var allErrs field.ErrorList
+	shadowPath := field.NewPath("spec").Child("shadowReplication")
+	spec := i.Spec.ShadowReplication
+	if spec.Target == i.Name {
+		allErrs = append(allErrs, field.Invalid(shadowPath.Child("target"), spec.Target, "must not be the name of this cluster"))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(spec.Target) {
+			allErrs = append(allErrs, field.Invalid(shadowPath.Child("target"), spec.Target, msg))
+		}
+	}
+	caches := map[string]bool{}
+	for idx, cache := range spec.Caches {
+		if caches[cache] {
+			allErrs = append(allErrs, field.Duplicate(shadowPath.Child("caches").Index(idx), cache))
+		}
+		caches[cache] = true
+	}
+	return allErrs
+}
+
+// nodePortRange returns the bounds of the operator's NODE_PORT_RANGE, falling back to the Kubernetes default range if
+// the variable is malformed
+func nodePortRange() (int32, int32) {
+	var min, max int32
+	if _, err := fmt.Sscanf(consts.NodePortRange, "%d-%d", &min, &max); err != nil || min > max {
+		return 30000, 32767
+	}
+	return min, max
+}
+
+// devModeDeniedPattern returns the pattern of the operator's DEV_MODE_DENIED_NAMESPACES that matches the namespace, or
+// an empty string if dev mode is allowed in the namespace
+func devModeDeniedPattern(namespace string) string {
+	for _, pattern := range strings.Split(consts.DevModeDeniedNamespaces, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if matched, _ := path.Match(pattern, namespace); pattern != "" && matched {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// ValidateContainerEnv validates the env variables of a server container, which must not override the variables
+// configured by the operator or be defined more than once
+func ValidateContainerEnv(envVars []corev1.EnvVar, envPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	seenEnv := map[string]bool{}
+	for idx, env := range envVars {
+		namePath := envPath.Index(idx).Child("name")
+		for _, denied := range deniedEnvVars {
+			if env.Name == denied {
+				allErrs = append(allErrs, field.Forbidden(namePath, "variable is configured by the operator and cannot be overridden"))
+			}
+		}
+		if seenEnv[env.Name] {
+			allErrs = append(allErrs, field.Duplicate(namePath, env.Name))
+		}
+		seenEnv[env.Name] = true
+	}
+	return allErrs
+}
+
+func (i *Infinispan) validateContainer() field.ErrorList {
+	var allErrs field.ErrorList
+	containerPath := field.NewPath("spec").Child("container")
+	for idx, arg := range i.Spec.Container.ExtraArgs {
+		if isDeniedServerArg(arg) {
+			msg := "argument is configured by the operator and cannot be overridden"
+			allErrs = append(allErrs, field.Forbidden(containerPath.Child("extraArgs").Index(idx), msg))
+		}
+	}
+	allErrs = append(allErrs, ValidateContainerEnv(i.Spec.Container.Env, containerPath.Child("env"))...)
+	if root := i.Spec.Container.ServerRoot; root != "" && !path.IsAbs(root) {
+		allErrs = append(allErrs, field.Invalid(containerPath.Child("serverRoot"), root, "must be an absolute path"))
+	}
+	if logDir := i.Spec.Container.LogDir; logDir != "" && !path.IsAbs(logDir) {
+		allErrs = append(allErrs, field.Invalid(containerPath.Child("logDir"), logDir, "must be an absolute path"))
+	}
+	if timezone := i.Spec.Container.Timezone; timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			allErrs = append(allErrs, field.Invalid(containerPath.Child("timezone"), timezone, "must be an IANA time zone name"))
+		}
+	}
+	return allErrs
+}
+
+// isDeniedServerArg returns true if the argument, in either its '--opt=val' or '-oval' form, is managed by the operator
+func isDeniedServerArg(arg string) bool {
+	for _, denied := range deniedServerArgs {
+		if arg == denied || strings.HasPrefix(arg, denied+"=") {
+			return true
+		}
+		// Short options accept the value without a separator
+		if len(denied) == 2 && strings.HasPrefix(arg, denied) && !strings.HasPrefix(arg, "--") {
+			return true
+		}
+	}
+	if strings.HasPrefix(arg, "-D") {
+		property := strings.SplitN(strings.TrimPrefix(arg, "-D"), "=", 2)[0]
+		for _, denied := range deniedServerProperties {
+			if property == denied {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (i *Infinispan) validateCacheService() *field.Error {
 	// If a CacheService is requested, checks that the pods have enough memory
 	if i.Spec.Service.Type == ServiceTypeCache {
@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	// +kubebuilder:scaffold:imports
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -110,6 +111,26 @@ var _ = Describe("Infinispan Webhooks", func() {
 			Expect(spec.ConfigListener.Enabled).Should(BeTrue())
 		})
 
+		It("Should apply the operator default of the ConfigListener", func() {
+			configListenerEnabled := consts.ConfigListenerEnabled
+			consts.ConfigListenerEnabled = false
+			defer func() { consts.ConfigListenerEnabled = configListenerEnabled }()
+
+			created := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, created)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, key, created)).Should(Succeed())
+			Expect(created.Spec.ConfigListener.Enabled).Should(BeFalse())
+		})
+
 		It("Should calculate default Labels", func() {
 
 			testTable := []struct {
@@ -413,6 +434,1585 @@ var _ = Describe("Infinispan Webhooks", func() {
 			err = k8sClient.Create(ctx, ispn.DeepCopy())
 			expectInvalidErrStatus(err, statusDetailCause{metav1.CauseTypeFieldValueDuplicate, "spec.dependencies.artifacts[0]", "At most one of"})
 		})
+
+		It("Should reject server arguments configured by the operator", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Container: InfinispanContainerSpec{
+						ExtraArgs:  []string{"-Dcustom.property=true", "--bind-address=127.0.0.1", "-c", "-Dinfinispan.server.log.path=/tmp"},
+						ServerRoot: "server",
+						LogDir:     "/var/log/infinispan",
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, []statusDetailCause{{
+				"FieldValueForbidden", "spec.container.extraArgs[1]", "configured by the operator",
+			}, {
+				"FieldValueForbidden", "spec.container.extraArgs[2]", "configured by the operator",
+			}, {
+				"FieldValueForbidden", "spec.container.extraArgs[3]", "configured by the operator",
+			}, {
+				metav1.CauseTypeFieldValueInvalid, "spec.container.serverRoot", "absolute path",
+			}}...)
+
+			ispn.Spec.Container.ExtraArgs = []string{"-Dcustom.property=true"}
+			ispn.Spec.Container.ServerRoot = "/data/server"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+
+			// Ensure that the server root cannot be changed once created
+			updated := &Infinispan{}
+			Expect(k8sClient.Get(ctx, key, updated)).Should(Succeed())
+			updated.Spec.Container.ServerRoot = "/opt/infinispan/server"
+			err = k8sClient.Update(ctx, updated)
+			expectInvalidErrStatus(err, statusDetailCause{"FieldValueForbidden", "spec.container.serverRoot", "immutable"})
+		})
+
+		It("Should reject env variables configured by the operator", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Container: InfinispanContainerSpec{
+						Env: []corev1.EnvVar{
+							{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+							{Name: "JAVA_OPTIONS", Value: "-Xmx1G"},
+							{Name: "HTTP_PROXY", Value: "http://other-proxy:3128"},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, []statusDetailCause{{
+				"FieldValueForbidden", "spec.container.env[1].name", "configured by the operator",
+			}, {
+				metav1.CauseTypeFieldValueDuplicate, "spec.container.env[2].name", "HTTP_PROXY",
+			}}...)
+
+			ispn.Spec.Container.Env = ispn.Spec.Container.Env[:1]
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject invalid JMX configuration", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Service: InfinispanServiceSpec{
+						Type: ServiceTypeCache,
+					},
+					Jmx: &InfinispanJmxSpec{
+						Enabled: true,
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, []statusDetailCause{{
+				"FieldValueForbidden", "spec.jmx.enabled", "spec.service.type=DataGrid",
+			}, {
+				metav1.CauseTypeFieldValueRequired, "spec.jmx.secretName", "JMX is enabled",
+			}}...)
+
+			ispn.Spec.Service.Type = ServiceTypeDataGrid
+			ispn.Spec.Jmx.SecretName = "jmx-credentials"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject diagnostics configured by the operator", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Service: InfinispanServiceSpec{
+						Type: ServiceTypeDataGrid,
+					},
+					Container: InfinispanContainerSpec{
+						ExtraJvmOpts: "-Xlog:gc*:file=/tmp/gc.log -XX:StartFlightRecording=filename=/tmp/rec.jfr -XX:+HeapDumpOnOutOfMemoryError",
+						Diagnostics: &InfinispanContainerDiagnosticsSpec{
+							GCLogging:                  true,
+							FlightRecorder:             true,
+							HeapDumpOnOutOfMemoryError: true,
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, []statusDetailCause{{
+				"FieldValueForbidden", "spec.container.diagnostics.gcLogging", "GC log configured in 'spec.container.extraJvmOpts'",
+			}, {
+				"FieldValueForbidden", "spec.container.diagnostics.flightRecorder", "flight recording configured in 'spec.container.extraJvmOpts'",
+			}, {
+				"FieldValueForbidden", "spec.container.diagnostics.heapDumpOnOutOfMemoryError", "heap dump configured in 'spec.container.extraJvmOpts'",
+			}}...)
+
+			ispn.Spec.Container.ExtraJvmOpts = ""
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject unknown fields in strict mode", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+					Annotations: map[string]string{
+						consts.AnnotationStrictValidation: "true",
+						lastAppliedAnnotation:             `{"spec":{"replica":2}}`,
+					},
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.replica", "did you mean 'spec.replicas'?",
+			})
+
+			ispn.Annotations[consts.AnnotationStrictValidation] = "false"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should only allow traffic policies for the NodePort and LoadBalancer expose types", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Expose: &ExposeSpec{
+						Type:                          ExposeTypeRoute,
+						ExternalTrafficPolicy:         corev1.ServiceExternalTrafficPolicyTypeLocal,
+						SessionAffinity:               corev1.ServiceAffinityClientIP,
+						SessionAffinityTimeoutSeconds: 60,
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.expose.externalTrafficPolicy", "only supported with 'spec.expose.type=NodePort'",
+			}, statusDetailCause{
+				"FieldValueForbidden", "spec.expose.sessionAffinity", "only supported with 'spec.expose.type=NodePort'",
+			})
+
+			ispn.Spec.Expose.Type = ExposeTypeLoadBalancer
+			ispn.Spec.Expose.SessionAffinity = corev1.ServiceAffinityNone
+			err = k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.expose.sessionAffinityTimeoutSeconds", "spec.expose.sessionAffinity=ClientIP",
+			})
+
+			ispn.Spec.Expose.SessionAffinity = corev1.ServiceAffinityClientIP
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should validate the console expose type", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Expose: &ExposeSpec{
+						Type: ExposeTypeLoadBalancer,
+					},
+					Endpoints: &InfinispanEndpointsSpec{
+						Console: &InfinispanConsoleSpec{
+							Enabled: pointer.BoolPtr(true),
+							Expose: &ConsoleExposeSpec{
+								Type: ConsoleExposeTypeNone,
+								Host: "console.example.com",
+							},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.endpoints.console.expose.host", "spec.endpoints.console.expose.type=Route",
+			}, statusDetailCause{
+				"FieldValueForbidden", "spec.endpoints.console.expose.type", "the console cannot be excluded from 'spec.expose.type=LoadBalancer'",
+			})
+
+			ispn.Spec.Endpoints.Console.Expose.Type = ConsoleExposeTypeIngress
+			ispn.Spec.Endpoints.Console.Expose.Host = ""
+			err = k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				metav1.CauseTypeFieldValueRequired, "spec.endpoints.console.expose.host", "spec.endpoints.console.expose.type=Ingress",
+			})
+
+			ispn.Spec.Endpoints.Console.Expose.Host = "console.example.com"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should require a Gateway name for the Gateway expose type", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Expose: &ExposeSpec{
+						Type: ExposeTypeGateway,
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				metav1.CauseTypeFieldValueRequired, "spec.expose.gatewayName", "spec.expose.type=Gateway",
+			})
+
+			ispn.Spec.Expose.GatewayName = "shared-gateway"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should require a host for the Gateway expose type with encryption", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Expose: &ExposeSpec{
+						Type:        ExposeTypeGateway,
+						GatewayName: "shared-gateway",
+					},
+					Security: InfinispanSecurity{
+						EndpointEncryption: &EndpointEncryption{
+							Type:           CertificateSourceTypeSecret,
+							CertSecretName: "tls-secret",
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				metav1.CauseTypeFieldValueRequired, "spec.expose.host", "SNI hostname",
+			})
+
+			ispn.Spec.Expose.Host = "infinispan.example.com"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should not expose the cluster with the ClusterIP expose type", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Expose: &ExposeSpec{
+						Type:     ExposeTypeClusterIP,
+						NodePort: 30500,
+					},
+					Service: InfinispanServiceSpec{
+						Type: ServiceTypeDataGrid,
+						Sites: &InfinispanSitesSpec{
+							Local: InfinispanSitesLocalSpec{
+								Name: "SiteA",
+								Expose: CrossSiteExposeSpec{
+									Type: CrossSiteExposeTypeLoadBalancer,
+								},
+							},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.expose", "no other fields are supported",
+			}, statusDetailCause{
+				"FieldValueForbidden", "spec.service.sites.local.expose.type", "cannot be exposed outside of Kubernetes",
+			})
+
+			ispn.Spec.Expose.NodePort = 0
+			ispn.Spec.Service.Sites.Local.Expose.Type = CrossSiteExposeTypeClusterIP
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should only allow Ingress class and TLS secret for the Ingress expose type", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Expose: &ExposeSpec{
+						Type:             ExposeTypeRoute,
+						IngressClassName: "nginx",
+						TLSSecretName:    "infinispan-tls",
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, []statusDetailCause{{
+				"FieldValueForbidden", "spec.expose.ingressClassName", "spec.expose.type=Ingress",
+			}, {
+				"FieldValueForbidden", "spec.expose.tlsSecretName", "spec.expose.type=Ingress",
+			}, {
+				metav1.CauseTypeFieldValueRequired, "spec.expose.host", "spec.expose.tlsSecretName",
+			}}...)
+
+			ispn.Spec.Expose.Type = ExposeTypeIngress
+			ispn.Spec.Expose.Host = "infinispan.example.com"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should validate the LoadBalancer IP and source ranges", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Expose: &ExposeSpec{
+						Type:                     ExposeTypeNodePort,
+						LoadBalancerIP:           "not-an-ip",
+						LoadBalancerSourceRanges: []string{"10.0.0.0/8", "10.0.0.1"},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, []statusDetailCause{{
+				"FieldValueForbidden", "spec.expose.loadBalancerIP", "spec.expose.type=LoadBalancer",
+			}, {
+				"FieldValueForbidden", "spec.expose.loadBalancerSourceRanges", "spec.expose.type=LoadBalancer",
+			}, {
+				metav1.CauseTypeFieldValueInvalid, "spec.expose.loadBalancerIP", "must be a valid IP address",
+			}, {
+				metav1.CauseTypeFieldValueInvalid, "spec.expose.loadBalancerSourceRanges[1]", "must be a valid CIDR",
+			}}...)
+
+			ispn.Spec.Expose.Type = ExposeTypeLoadBalancer
+			ispn.Spec.Expose.LoadBalancerIP = "192.0.2.10"
+			ispn.Spec.Expose.LoadBalancerSourceRanges = []string{"10.0.0.0/8", "10.0.0.1/32"}
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should validate the ports of exposed endpoints", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Expose: &ExposeSpec{
+						Type:     ExposeTypeNodePort,
+						NodePort: 30222,
+						Endpoints: &ExposeEndpointsSpec{
+							HotRod: &ExposeEndpointSpec{NodePort: 80},
+							Rest:   &ExposeEndpointSpec{Port: 11222, NodePort: 31000},
+							Admin:  &ExposeEndpointSpec{NodePort: 31000},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, []statusDetailCause{{
+				"FieldValueForbidden", "spec.expose.endpoints", "cannot be combined with 'spec.expose.nodePort'",
+			}, {
+				metav1.CauseTypeFieldValueInvalid, "spec.expose.endpoints.hotrod.nodePort", "NodePort range 30000-32767",
+			}, {
+				"FieldValueDuplicate", "spec.expose.endpoints.rest.port", "already used by the hotrod endpoint",
+			}, {
+				"FieldValueDuplicate", "spec.expose.endpoints.admin.nodePort", "already used by the rest endpoint",
+			}}...)
+
+			ispn.Spec.Expose.NodePort = 0
+			ispn.Spec.Expose.Endpoints.HotRod.NodePort = 30222
+			ispn.Spec.Expose.Endpoints.Rest.Port = 0
+			ispn.Spec.Expose.Endpoints.Admin.NodePort = 30223
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should not expose the admin endpoint if admin access is restricted", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Security: InfinispanSecurity{
+						RestrictAdminAccess: true,
+					},
+					Expose: &ExposeSpec{
+						Type: ExposeTypeNodePort,
+						Endpoints: &ExposeEndpointsSpec{
+							HotRod: &ExposeEndpointSpec{},
+							Admin:  &ExposeEndpointSpec{},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.expose.endpoints.admin", "spec.security.restrictAdminAccess",
+			})
+
+			ispn.Spec.Expose.Endpoints.Admin = nil
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject duplicate password algorithms", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Security: InfinispanSecurity{
+						PasswordAlgorithms: []PasswordAlgorithm{PasswordAlgorithmScramSHA512, PasswordAlgorithmScramSHA256, PasswordAlgorithmScramSHA512},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				metav1.CauseTypeFieldValueDuplicate, "spec.security.passwordAlgorithms[2]", "scram-sha-512",
+			})
+
+			ispn.Spec.Security.PasswordAlgorithms = ispn.Spec.Security.PasswordAlgorithms[:2]
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should restrict admin operations only with authentication", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Security: InfinispanSecurity{
+						EndpointAuthentication:  pointer.BoolPtr(false),
+						RestrictAdminOperations: true,
+					},
+					Expose: &ExposeSpec{
+						Type: ExposeTypeNodePort,
+						Endpoints: &ExposeEndpointsSpec{
+							HotRod: &ExposeEndpointSpec{},
+							Admin:  &ExposeEndpointSpec{},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.security.restrictAdminOperations", "spec.security.endpointAuthentication=true",
+			}, statusDetailCause{
+				"FieldValueForbidden", "spec.expose.endpoints.admin", "spec.security.restrictAdminOperations",
+			})
+
+			ispn.Spec.Security.EndpointAuthentication = nil
+			ispn.Spec.Expose.Endpoints.Admin = nil
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+			Expect(ispn.IsConsoleEnabled()).Should(BeFalse())
+		})
+
+		It("Should validate the user endpoint protocols", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Endpoints: &InfinispanEndpointsSpec{
+						HotRod:          &InfinispanEndpointSpec{Enabled: pointer.BoolPtr(false), Port: 11223},
+						Rest:            &InfinispanEndpointSpec{Enabled: pointer.BoolPtr(false)},
+						Console:         &InfinispanConsoleSpec{Enabled: pointer.BoolPtr(true)},
+						ClientListeners: &ClientListenersSpec{SendBufferSize: 1048576},
+					},
+					Expose: &ExposeSpec{
+						Type: ExposeTypeNodePort,
+						Endpoints: &ExposeEndpointsSpec{
+							HotRod: &ExposeEndpointSpec{},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err,
+				statusDetailCause{"FieldValueForbidden", "spec.endpoints", "at least one of the hotrod or rest protocols must be enabled"},
+				statusDetailCause{"FieldValueForbidden", "spec.endpoints.console.enabled", "the console requires the rest protocol"},
+				statusDetailCause{"FieldValueForbidden", "spec.expose.endpoints.hotrod", "the hotrod protocol is disabled"},
+				statusDetailCause{"FieldValueForbidden", "spec.endpoints.clientListeners.sendBufferSize", "the hotrod protocol is disabled"},
+				statusDetailCause{metav1.CauseTypeFieldValueInvalid, "spec.endpoints.hotrod.port", "port is reserved for the admin endpoint"},
+			)
+
+			ispn.Spec.Endpoints.HotRod = &InfinispanEndpointSpec{Port: 11322}
+			ispn.Spec.Endpoints.Console = nil
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should validate the cross-site expose settings", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Service: InfinispanServiceSpec{
+						Type: ServiceTypeDataGrid,
+						Sites: &InfinispanSitesSpec{
+							Local: InfinispanSitesLocalSpec{
+								Name: "SiteA",
+								Expose: CrossSiteExposeSpec{
+									Type:     CrossSiteExposeTypeClusterIP,
+									NodePort: 30500,
+								},
+							},
+							Locations: []InfinispanSiteLocationSpec{{
+								Name: "SiteB",
+								URL:  "kubernetes://api.site-b.example.com:6443",
+							}, {
+								Name: "SiteC",
+							}},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.service.sites.local.expose.nodePort", "expose.type=NodePort",
+			}, statusDetailCause{
+				"FieldValueForbidden", "spec.service.sites.locations[0].url", "cannot reach the local site",
+			}, statusDetailCause{
+				metav1.CauseTypeFieldValueRequired, "spec.service.sites.locations[0].secretName", "access secret",
+			}, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.service.sites.locations[1]", "must differ from the local cluster",
+			})
+
+			ispn.Spec.Service.Sites.Local.Expose.NodePort = 0
+			ispn.Spec.Service.Sites.Locations = []InfinispanSiteLocationSpec{{
+				Name:    "SiteA",
+				Offline: true,
+			}, {
+				Name:      "SiteB",
+				Namespace: "site-b",
+				Offline:   true,
+			}}
+			err = k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.service.sites.locations[0].offline", "no backups to itself",
+			})
+
+			ispn.Spec.Service.Sites.Locations = ispn.Spec.Service.Sites.Locations[1:]
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should only accept the CA of remote sites with cross-site encryption", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Service: InfinispanServiceSpec{
+						Type: ServiceTypeDataGrid,
+						Sites: &InfinispanSitesSpec{
+							Local: InfinispanSitesLocalSpec{
+								Name: "SiteA",
+								Expose: CrossSiteExposeSpec{
+									Type: CrossSiteExposeTypeClusterIP,
+								},
+							},
+							Locations: []InfinispanSiteLocationSpec{{
+								Name:         "SiteA",
+								CASecretName: "site-a-ca",
+							}, {
+								Name:         "SiteB",
+								Namespace:    "site-b",
+								CASecretName: "site-b-ca",
+							}},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.service.sites.locations[0].caSecretName", "local.encryption.trustStore",
+			}, statusDetailCause{
+				"FieldValueForbidden", "spec.service.sites.locations[1].caSecretName", "only supported when",
+			})
+
+			ispn.Spec.Service.Sites.Locations = ispn.Spec.Service.Sites.Locations[1:]
+			ispn.Spec.Service.Sites.Local.Encryption = &EncryptionSiteSpec{
+				TransportKeyStore: CrossSiteKeyStore{SecretName: "transport-tls"},
+				RouterKeyStore:    CrossSiteKeyStore{SecretName: "router-tls"},
+			}
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should resolve the remote sites of a ClusterSet by their exported Service", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Service: InfinispanServiceSpec{
+						Type: ServiceTypeDataGrid,
+						Sites: &InfinispanSitesSpec{
+							Local: InfinispanSitesLocalSpec{
+								Name: "SiteA",
+								Expose: CrossSiteExposeSpec{
+									Type: CrossSiteExposeTypeClusterSet,
+								},
+							},
+							Locations: []InfinispanSiteLocationSpec{{
+								Name:       "SiteB",
+								URL:        "kubernetes://api.site-b.example.com:6443",
+								SecretName: "site-b-token",
+							}},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.service.sites.locations[0].url", "resolve the remote site in the ClusterSet",
+			})
+
+			ispn.Spec.Service.Sites.Locations[0].URL = ""
+			ispn.Spec.Service.Sites.Locations[0].Namespace = "site-b"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should validate the cross-site state transfer annotation", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        key.Name,
+					Namespace:   key.Namespace,
+					Annotations: map[string]string{consts.AnnotationXSiteStateTransfer: "SiteB"},
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "metadata.annotations[infinispan.org/xsite-state-transfer]", "not configured",
+			})
+
+			ispn.Spec.Service = InfinispanServiceSpec{
+				Type: ServiceTypeDataGrid,
+				Sites: &InfinispanSitesSpec{
+					Local: InfinispanSitesLocalSpec{
+						Name: "SiteA",
+						Expose: CrossSiteExposeSpec{
+							Type: CrossSiteExposeTypeClusterIP,
+						},
+					},
+					Locations: []InfinispanSiteLocationSpec{{
+						Name:      "SiteB",
+						Namespace: "site-b",
+					}},
+				},
+			}
+			ispn.Annotations[consts.AnnotationXSiteStateTransfer] = "SiteA"
+			err = k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				metav1.CauseTypeFieldValueNotSupported, "metadata.annotations[infinispan.org/xsite-state-transfer]", "supported values",
+			})
+
+			ispn.Annotations[consts.AnnotationXSiteStateTransfer] = "SiteB"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should validate the Route termination", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Security: InfinispanSecurity{
+						EndpointEncryption: &EndpointEncryption{
+							Type: CertificateSourceTypeNoneNoEncryption,
+						},
+					},
+					Expose: &ExposeSpec{
+						Type:                         ExposeTypeRoute,
+						RouteTermination:             RouteTerminationReencrypt,
+						RouteDestinationCASecretName: "destination-ca",
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.expose.routeTermination", "requires endpoint encryption to be enabled",
+			})
+
+			ispn.Spec.Expose.RouteTermination = RouteTerminationEdge
+			err = k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.expose.routeDestinationCASecretName", "spec.expose.routeTermination=reencrypt",
+			})
+
+			ispn.Spec.Expose.RouteDestinationCASecretName = ""
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject a rollout max unavailable that loses Cache service entries", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 4,
+					Upgrades: &InfinispanUpgradesSpec{
+						Type:           UpgradeTypeShutdown,
+						MaxUnavailable: pointer.Int32Ptr(2),
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.upgrades.maxUnavailable", "spec.service.replicationFactor=2",
+			})
+
+			ispn.Spec.Upgrades.MaxUnavailable = pointer.Int32Ptr(1)
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject data migration with Hot Rod rolling upgrades", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Service: InfinispanServiceSpec{
+						Type: ServiceTypeDataGrid,
+					},
+					Upgrades: &InfinispanUpgradesSpec{
+						Type: UpgradeTypeHotRodRolling,
+						DataMigration: &DataMigrationSpec{
+							ConfigMapName: "store-migrator",
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.upgrades.dataMigration.configMapName", "spec.upgrades.type=Shutdown",
+			})
+
+			ispn.Spec.Upgrades.Type = UpgradeTypeShutdown
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should initiate dev mode defaults", func() {
+
+			created := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					DevMode:  true,
+					Service: InfinispanServiceSpec{
+						Type: ServiceTypeDataGrid,
+					},
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, created)).Should(Succeed())
+
+			Expect(k8sClient.Get(ctx, key, created)).Should(Succeed())
+			spec := created.Spec
+			Expect(spec.Service.Container.EphemeralStorage).Should(BeTrue())
+			Expect(spec.Service.Container.Storage).Should(BeNil())
+			Expect(spec.Affinity).Should(BeNil())
+			Expect(spec.Security.EndpointEncryption.Type).Should(Equal(CertificateSourceTypeNoneNoEncryption))
+			Expect(spec.Expose.Type).Should(Equal(ExposeTypeNodePort))
+
+			created.Spec.DevMode = false
+			err := k8sClient.Update(ctx, created)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.devMode", "immutable",
+			})
+		})
+
+		It("Should reject dev mode with multiple replicas or in denied namespaces", func() {
+			deniedNamespaces := consts.DevModeDeniedNamespaces
+			consts.DevModeDeniedNamespaces = "prod-*, " + key.Namespace
+			defer func() { consts.DevModeDeniedNamespaces = deniedNamespaces }()
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 2,
+					DevMode:  true,
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.replicas", "single replica",
+			}, statusDetailCause{
+				"FieldValueForbidden", "spec.devMode", key.Namespace,
+			})
+
+			consts.DevModeDeniedNamespaces = "prod-*"
+			ispn.Spec.Replicas = 1
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should initiate edge profile defaults", func() {
+
+			created := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Profile:  ProfileEdge,
+					Service: InfinispanServiceSpec{
+						Type: ServiceTypeDataGrid,
+					},
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, created)).Should(Succeed())
+
+			Expect(k8sClient.Get(ctx, key, created)).Should(Succeed())
+			spec := created.Spec
+			Expect(spec.Container.Memory).Should(Equal(consts.DefaultEdgeMemorySize.String()))
+			Expect(spec.Container.GCPolicy).Should(Equal(GCPolicySerial))
+			Expect(spec.ConfigListener.Enabled).Should(BeFalse())
+			Expect(spec.Affinity).Should(BeNil())
+			Expect(created.IsConsoleEnabled()).Should(BeFalse())
+			Expect(created.IsServiceMonitorEnabled()).Should(BeFalse())
+
+			created.Spec.Profile = ""
+			err := k8sClient.Update(ctx, created)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.profile", "immutable",
+			})
+		})
+
+		It("Should reject edge profile with multiple replicas or dev mode", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 2,
+					DevMode:  true,
+					Profile:  ProfileEdge,
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.replicas", "single replica",
+			}, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.replicas", "single replica",
+			}, statusDetailCause{
+				"FieldValueForbidden", "spec.profile", "spec.devMode",
+			})
+
+			ispn.Spec.Replicas = 1
+			ispn.Spec.DevMode = false
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject changes to the IP family", func() {
+
+			created := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					IPFamily: corev1.IPv6Protocol,
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, created)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, key, created)).Should(Succeed())
+			Expect(created.IsIPv6()).Should(BeTrue())
+
+			created.Spec.IPFamily = corev1.IPv4Protocol
+			err := k8sClient.Update(ctx, created)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.ipFamily", "immutable",
+			})
+		})
+
+		It("Should reject unsupported cluster actions", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        key.Name,
+					Namespace:   key.Namespace,
+					Annotations: map[string]string{consts.AnnotationAction: "shutdown"},
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				metav1.CauseTypeFieldValueNotSupported, "metadata.annotations[infinispan.org/action]", "supported values",
+			})
+
+			ispn.Annotations[consts.AnnotationAction] = string(ClusterActionGracefulShutdown)
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject invalid discovery service configuration", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Service: InfinispanServiceSpec{
+						Discovery: &InfinispanDiscoverySpec{
+							Name: "Invalid_Name",
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.service.discovery.name", "DNS-1035 label",
+			})
+
+			ispn.Spec.Service.Discovery.Name = ispn.GetAdminServiceName()
+			err = k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.service.discovery.name", "already used",
+			})
+
+			ispn.Spec.Service.Discovery.Name = "shared-discovery"
+			ispn.Spec.Service.Discovery.PublishNotReadyAddresses = true
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, key, ispn)).Should(Succeed())
+			Expect(ispn.GetPingServiceName()).Should(Equal("shared-discovery"))
+
+			ispn.Spec.Service.Discovery.Name = "other-discovery"
+			err = k8sClient.Update(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.service.discovery.name", "immutable",
+			})
+		})
+
+		It("Should reject invalid ExternalDNS hostnames", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Expose: &ExposeSpec{
+						Type: ExposeTypeLoadBalancer,
+						ExternalDNS: &ExternalDNSSpec{
+							Hostnames: []string{"infinispan.example.com", "Invalid_Host.example.com"},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.expose.externalDNS.hostnames[1]", "RFC 1123 subdomain",
+			})
+
+			ispn.Spec.Expose.ExternalDNS.Hostnames[1] = "*.example.com"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject client intelligence for expose types without Hot Rod", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Expose: &ExposeSpec{
+						Type:               ExposeTypeGateway,
+						GatewayName:        "gateway",
+						ClientIntelligence: ClientIntelligenceHashDistributionAware,
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.expose.clientIntelligence", "Hot Rod clients cannot connect",
+			})
+
+			ispn.Spec.Expose = &ExposeSpec{
+				Type:               ExposeTypeLoadBalancer,
+				ClientIntelligence: ClientIntelligenceHashDistributionAware,
+			}
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject per-pod Services for unsupported expose types", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Expose: &ExposeSpec{
+						Type:   ExposeTypeRoute,
+						PerPod: true,
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.expose.perPod", "per-pod Services not supported",
+			})
+
+			ispn.Spec.Expose.Type = ExposeTypeNodePort
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject invalid capacity factors", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					CapacityFactor: &CapacityFactorSpec{
+						TopologyKey: "invalid key",
+						Default:     "0",
+						Zones: []ZoneCapacityFactor{
+							{Zone: "zone-a", Factor: "2"},
+							{Zone: "zone-a", Factor: "large"},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.capacityFactor.topologyKey", "qualified name",
+			}, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.capacityFactor.default", "greater than 0",
+			}, statusDetailCause{
+				metav1.CauseTypeFieldValueDuplicate, "spec.capacityFactor.zones[1].zone", "Duplicate value",
+			}, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.capacityFactor.zones[1].factor", "greater than 0",
+			})
+
+			ispn.Spec.CapacityFactor.TopologyKey = ""
+			ispn.Spec.CapacityFactor.Default = "0.5"
+			ispn.Spec.CapacityFactor.Zones[1] = ZoneCapacityFactor{Zone: "zone-b", Factor: "1.5"}
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject invalid shadow replication configuration", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					ShadowReplication: &ShadowReplicationSpec{
+						Target: key.Name,
+						Caches: []string{"sessions", "sessions"},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.shadowReplication.target", "must not be the name of this cluster",
+			}, statusDetailCause{
+				metav1.CauseTypeFieldValueDuplicate, "spec.shadowReplication.caches[1]", "Duplicate value",
+			})
+
+			ispn.Spec.ShadowReplication.Target = "new-cluster"
+			ispn.Spec.ShadowReplication.Caches = []string{"sessions", "products"}
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject invalid autostop configuration", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Autostop: &AutostopSpec{
+						Schedule:     "0 20 * * 1-5",
+						WakeSchedule: "0 25 * * 1-5",
+						TimeZone:     "Europe/Atlantis",
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.autostop", "spec.service.type=DataGrid",
+			}, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.autostop.wakeSchedule", "hour '25'",
+			}, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.autostop.timeZone", "IANA time zone",
+			})
+
+			ispn.Spec.Service.Type = ServiceTypeDataGrid
+			ispn.Spec.Autostop.WakeSchedule = "0 7 * * 1-5"
+			ispn.Spec.Autostop.TimeZone = "Europe/Prague"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject invalid storage monitoring configuration", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Service: InfinispanServiceSpec{
+						Container: &InfinispanServiceContainerSpec{EphemeralStorage: true},
+					},
+					StorageMonitoring: &StorageMonitoringSpec{
+						Enabled:  true,
+						Interval: &metav1.Duration{Duration: -time.Minute},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.storageMonitoring.enabled", "persistent storage",
+			}, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.storageMonitoring.interval", "greater than zero",
+			})
+
+			ispn.Spec.Service.Type = ServiceTypeDataGrid
+			ispn.Spec.Service.Container = &InfinispanServiceContainerSpec{Storage: pointer.StringPtr("1Gi")}
+			ispn.Spec.StorageMonitoring.Interval = nil
+			ispn.Spec.StorageMonitoring.AutoExpand = &StorageAutoExpandSpec{Enabled: true, MaxSize: "500Mi"}
+			err = k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				metav1.CauseTypeFieldValueInvalid, "spec.storageMonitoring.autoExpand.maxSize", "spec.service.container.storage=1Gi",
+			})
+
+			ispn.Spec.StorageMonitoring.AutoExpand.MaxSize = "5Gi"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject a keystore without certificates from a Secret", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Security: InfinispanSecurity{
+						EndpointEncryption: &EndpointEncryption{
+							Type:     CertificateSourceTypeService,
+							Keystore: &EndpointKeystore{Filename: "server.jks", Alias: "server"},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.security.endpointEncryption.keystore", "type=Secret",
+			})
+
+			ispn.Spec.Security.EndpointEncryption.Type = CertificateSourceTypeSecret
+			ispn.Spec.Security.EndpointEncryption.CertSecretName = "tls-secret"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should validate the OIDC token realm", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Security: InfinispanSecurity{
+						EndpointAuthentication: pointer.BoolPtr(false),
+						EndpointOIDC: &EndpointOIDC{
+							AuthServerURL:    "keycloak/auth/realms/infinispan",
+							ClientID:         "infinispan-console",
+							ClientSecretName: "oidc-secret",
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.security.endpointOidc", "requires 'spec.security.endpointAuthentication=true'",
+			}, statusDetailCause{
+				"FieldValueInvalid", "spec.security.endpointOidc.authServerUrl", "must be an absolute http or https URL",
+			})
+
+			ispn.Spec.Security.EndpointAuthentication = pointer.BoolPtr(true)
+			ispn.Spec.Security.EndpointOIDC.AuthServerURL = "https://keycloak.example.com/auth/realms/infinispan"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should validate the Kerberos identity", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Security: InfinispanSecurity{
+						EndpointAuthentication: pointer.BoolPtr(false),
+						EndpointKerberos: &EndpointKerberos{
+							Principal:        "hotrod@EXAMPLE.COM",
+							KeytabSecretName: "keytab-secret",
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.security.endpointKerberos", "requires 'spec.security.endpointAuthentication=true'",
+			}, statusDetailCause{
+				"FieldValueInvalid", "spec.security.endpointKerberos.principal", "must be a service principal",
+			})
+
+			ispn.Spec.Security.EndpointAuthentication = pointer.BoolPtr(true)
+			ispn.Spec.Security.EndpointKerberos.Principal = "hotrod/example-infinispan.default.svc@EXAMPLE.COM"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should validate ServiceAccount token authentication", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Security: InfinispanSecurity{
+						EndpointAuthentication: pointer.BoolPtr(false),
+						EndpointServiceAccount: &EndpointServiceAccount{
+							Audiences: []string{""},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.security.endpointServiceAccount", "requires 'spec.security.endpointAuthentication=true'",
+			}, statusDetailCause{
+				"FieldValueRequired", "spec.security.endpointServiceAccount.audiences[0]", "audience must not be empty",
+			})
+
+			ispn.Spec.Security.EndpointAuthentication = pointer.BoolPtr(true)
+			ispn.Spec.Security.EndpointServiceAccount.Audiences = []string{"infinispan"}
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject the generated secret as admin secret", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Security: InfinispanSecurity{
+						AdminSecretName: key.Name + "-generated-operator-secret",
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueInvalid", "spec.security.adminSecretName", "must reference a secret that is not generated by the operator",
+			})
+
+			ispn.Spec.Security.AdminSecretName = "admin-credentials"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject duplicate and reserved credential aliases", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Security: InfinispanSecurity{
+						Credentials: []CredentialStoreEntry{
+							{Alias: "ldap-bind", SecretName: "ldap-credentials"},
+							{Alias: "ldap-bind", SecretName: "ldap-credentials", Key: "truststore-password"},
+							{Alias: "keystore", SecretName: "keystore-credentials"},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueDuplicate", "spec.security.credentials[1].alias", "Duplicate value",
+			}, statusDetailCause{
+				"FieldValueForbidden", "spec.security.credentials[2].alias", "the 'keystore' alias is reserved",
+			})
+
+			ispn.Spec.Security.Credentials = []CredentialStoreEntry{
+				{Alias: "ldap-bind", SecretName: "ldap-credentials"},
+				{Alias: "ldap-truststore", SecretName: "ldap-credentials", Key: "truststore-password"},
+			}
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should require authorization for the audit log", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Security: InfinispanSecurity{
+						AuditLog: &AuditLog{Enabled: true, Sink: AuditLogSinkFile},
+					},
+					Logging: &InfinispanLoggingSpec{
+						Categories: map[string]LoggingLevelType{"org.infinispan.AUDIT": LoggingLevelDebug},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.security.auditLog", "requires 'spec.security.authorization.enabled=true'",
+			}, statusDetailCause{
+				"FieldValueForbidden", "spec.logging.categories[org.infinispan.AUDIT]", "configured by 'spec.security.auditLog.level'",
+			})
+
+			ispn.Spec.Security.Authorization = &Authorization{Enabled: true}
+			ispn.Spec.Logging = nil
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should validate the TLS protocols and cipher suites", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Security: InfinispanSecurity{
+						EndpointEncryption: &EndpointEncryption{
+							Type:      CertificateSourceTypeNoneNoEncryption,
+							Protocols: []TLSProtocol{TLSVersion13},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.security.endpointEncryption.protocols", "only be configured when endpoint encryption is enabled",
+			})
+
+			ispn.Spec.Security.EndpointEncryption.Type = CertificateSourceTypeSecret
+			ispn.Spec.Security.EndpointEncryption.CertSecretName = "tls-secret"
+			ispn.Spec.Security.EndpointEncryption.CipherSuites = []string{"tls_aes_128_gcm_sha256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}
+			err = k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueInvalid", "spec.security.endpointEncryption.cipherSuites[0]", "must be the IANA name of a cipher suite",
+			}, statusDetailCause{
+				"FieldValueInvalid", "spec.security.endpointEncryption.cipherSuites", "at least one TLSv1.3 cipher suite is required",
+			})
+
+			ispn.Spec.Security.EndpointEncryption.CipherSuites = []string{"TLS_AES_256_GCM_SHA384"}
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should require the settings of the Vault provider", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Security: InfinispanSecurity{
+						Vault: &Vault{
+							Provider: VaultProviderAgentInjector,
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueRequired", "spec.security.vault.role", "must be provided for 'spec.security.vault.provider=AgentInjector'",
+			}, statusDetailCause{
+				"FieldValueRequired", "spec.security.vault.secretPath", "must be provided for 'spec.security.vault.provider=AgentInjector'",
+			})
+
+			ispn.Spec.Security.Vault = &Vault{Provider: VaultProviderCSI}
+			err = k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueRequired", "spec.security.vault.secretProviderClass", "must be provided for 'spec.security.vault.provider=CSI'",
+			})
+
+			ispn.Spec.Security.Vault.SecretProviderClass = "infinispan-vault"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+		})
+
+		It("Should reject client certificates without encryption", func() {
+
+			ispn := &Infinispan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: InfinispanSpec{
+					Replicas: 1,
+					Security: InfinispanSecurity{
+						EndpointEncryption: &EndpointEncryption{
+							Type:       CertificateSourceTypeNoneNoEncryption,
+							ClientCert: ClientCertAuthenticate,
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, ispn)
+			expectInvalidErrStatus(err, statusDetailCause{
+				"FieldValueForbidden", "spec.security.endpointEncryption.clientCert", "require encryption",
+			})
+
+			ispn.Spec.Security.EndpointEncryption.Type = CertificateSourceTypeSecret
+			ispn.Spec.Security.EndpointEncryption.CertSecretName = "tls-secret"
+			Expect(k8sClient.Create(ctx, ispn)).Should(Succeed())
+			Expect(ispn.Spec.Security.EndpointEncryption.ClientCertSecretName).Should(Equal(key.Name + "-client-cert-secret"))
+		})
 	})
 })
 
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	consts "github.com/infinispan/infinispan-operator/controllers/constants"
@@ -57,9 +58,10 @@ const (
 	// ServiceMonitoringAnnotation defines if we need to create ServiceMonitor or not
 	ServiceMonitoringAnnotation string = "infinispan.org/monitoring"
 
-	SiteServiceNameTemplate = "%v-site"
-	SiteRouteNameSuffix     = "-route-site"
-	SiteServiceFQNTemplate  = "%s.%s.svc.cluster.local"
+	SiteServiceNameTemplate          = "%v-site"
+	SiteRouteNameSuffix              = "-route-site"
+	SiteServiceFQNTemplate           = "%s.%s.svc.cluster.local"
+	SiteServiceClusterSetFQNTemplate = "%s.%s.svc.clusterset.local"
 
 	GossipRouterDeploymentNameTemplate = "%s-router"
 )
@@ -136,6 +138,56 @@ func (ispn *Infinispan) RemoveCondition(condition ConditionType) bool {
 	return false
 }
 
+// AcquireOperationLock acquires status.operationLock for the operation requested by owner. Returns nil if the lock is
+// acquired or already held by the operation, otherwise the lock held by the conflicting operation. Scaling is permitted
+// whilst an Upgrade holds the lock, as upgrades scale the cluster themselves
+func (ispn *Infinispan) AcquireOperationLock(operation OperationType, owner string) *OperationLock {
+	lock := ispn.Status.OperationLock
+	if lock == nil {
+		ispn.Status.OperationLock = &OperationLock{
+			Operation:    operation,
+			Owner:        owner,
+			AcquiredTime: metav1.Now(),
+		}
+		ispn.RemoveCondition(ConditionOperationFailed)
+		return nil
+	}
+	if lock.Operation == operation && lock.Owner == owner {
+		if operation == OperationScaling {
+			// A new spec.replicas supersedes the scaling in progress, so its deadline is restarted
+			lock.AcquiredTime = metav1.Now()
+		}
+		return nil
+	}
+	if lock.Operation == OperationUpgrade && operation == OperationScaling {
+		return nil
+	}
+	return lock
+}
+
+// ReleaseOperationLock releases status.operationLock if it is held by the operation requested by owner. Returns true if
+// the lock was released
+func (ispn *Infinispan) ReleaseOperationLock(operation OperationType, owner string) bool {
+	if ispn.HoldsOperationLock(operation, owner) {
+		ispn.Status.OperationLock = nil
+		return true
+	}
+	return false
+}
+
+// HoldsOperationLock returns true if status.operationLock is held by the operation requested by owner
+func (ispn *Infinispan) HoldsOperationLock(operation OperationType, owner string) bool {
+	lock := ispn.Status.OperationLock
+	return lock != nil && lock.Operation == operation && lock.Owner == owner
+}
+
+func (l *OperationLock) String() string {
+	if l.Owner == "" {
+		return string(l.Operation)
+	}
+	return fmt.Sprintf("%s '%s'", l.Operation, l.Owner)
+}
+
 func (ispn *Infinispan) ExpectConditionStatus(expected map[ConditionType]metav1.ConditionStatus) error {
 	for key, value := range expected {
 		c := ispn.GetCondition(key)
@@ -176,6 +228,14 @@ func (ispn *Infinispan) ApplyEndpointEncryptionSettings(servingCertsMode string)
 		if encryption.CertSecretName == "" {
 			encryption.CertSecretName = ispn.Name + "-cert-secret"
 		}
+	} else if ispn.IsEncryptionCertFromService() {
+		// Without a platform service the operator issues the certificates itself
+		if encryption.CertServiceName == "" {
+			encryption.CertServiceName = OperatorCertServiceName
+		}
+		if encryption.CertSecretName == "" && ispn.IsEncryptionCertFromOperator() {
+			encryption.CertSecretName = ispn.Name + "-cert-secret"
+		}
 	}
 
 	if encryption != nil {
@@ -203,6 +263,29 @@ func (ispn *Infinispan) ImageType() ImageType {
 	return ImageTypeJVM
 }
 
+// TerminationGracePeriodSeconds returns the grace period defined in the spec, or the period that Kubernetes defaults to
+func (ispn *Infinispan) TerminationGracePeriodSeconds() int64 {
+	if period := ispn.Spec.Container.TerminationGracePeriodSeconds; period != nil {
+		return *period
+	}
+	return corev1.DefaultTerminationGracePeriodSeconds
+}
+
+// ImagePullPolicy returns the pull policy defined in the spec, or the policy that Kubernetes defaults to for ImageName()
+func (ispn *Infinispan) ImagePullPolicy() corev1.PullPolicy {
+	if ispn.Spec.ImagePullPolicy != "" {
+		return ispn.Spec.ImagePullPolicy
+	}
+	image := ispn.ImageName()
+	if strings.Contains(image, "@") {
+		return corev1.PullIfNotPresent
+	}
+	if tag := image[strings.LastIndex(image, "/")+1:]; !strings.Contains(tag, ":") || strings.HasSuffix(tag, ":latest") {
+		return corev1.PullAlways
+	}
+	return corev1.PullIfNotPresent
+}
+
 func (ispn *Infinispan) IsDataGrid() bool {
 	return ServiceTypeDataGrid == ispn.Spec.Service.Type
 }
@@ -232,9 +315,24 @@ func (ispn *Infinispan) GetAdminServiceName() string {
 }
 
 func (ispn *Infinispan) GetPingServiceName() string {
+	if name := ispn.discoveryServiceName(); name != "" {
+		return name
+	}
 	return fmt.Sprintf("%s-ping", ispn.GetStatefulSetName())
 }
 
+func (ispn *Infinispan) discoveryServiceName() string {
+	if ispn.Spec.Service.Discovery == nil {
+		return ""
+	}
+	return ispn.Spec.Service.Discovery.Name
+}
+
+// IsPublishNotReadyAddresses returns true if pods that are not ready are included in the records of the ping service
+func (ispn *Infinispan) IsPublishNotReadyAddresses() bool {
+	return ispn.Spec.Service.Discovery != nil && ispn.Spec.Service.Discovery.PublishNotReadyAddresses
+}
+
 // GetStatefulSetName returns the name of the StatefulSet associated with the CRD. After one or more live migrations,
 // the name can change
 func (ispn *Infinispan) GetStatefulSetName() string {
@@ -281,15 +379,71 @@ func (ispn *Infinispan) GetSiteLocationsName() (locations []string) {
 	return
 }
 
-// IsExposed ...
+// IsExposed returns true if the cluster is exposed outside of Kubernetes with spec.expose
 func (ispn *Infinispan) IsExposed() bool {
-	return ispn.Spec.Expose != nil && ispn.Spec.Expose.Type != ""
+	return ispn.Spec.Expose != nil && ispn.Spec.Expose.Type != "" && ispn.Spec.Expose.Type != ExposeTypeClusterIP
+}
+
+// IsClusterIPOnly returns true if spec.expose.type=ClusterIP guarantees that the cluster is not exposed outside of
+// Kubernetes
+func (ispn *Infinispan) IsClusterIPOnly() bool {
+	return ispn.Spec.Expose != nil && ispn.Spec.Expose.Type == ExposeTypeClusterIP
 }
 
 func (ispn *Infinispan) GetExposeType() ExposeType {
 	return ispn.Spec.Expose.Type
 }
 
+// ExposedEndpoint is an endpoint of the server exposed by the NodePort and LoadBalancer expose types
+// +kubebuilder:object:generate=false
+type ExposedEndpoint struct {
+	Name       string
+	Port       int32
+	NodePort   int32
+	TargetPort int
+}
+
+// GetExposedEndpoints returns the endpoints exposed by the NodePort and LoadBalancer expose types. Hot Rod and REST are
+// served by the same server port, so that their Service ports share the same target port
+func (ispn *Infinispan) GetExposedEndpoints() []ExposedEndpoint {
+	endpoints := ispn.Spec.Expose.Endpoints
+	if endpoints == nil {
+		return nil
+	}
+	var exposed []ExposedEndpoint
+	add := func(name string, spec *ExposeEndpointSpec, defaultPort int32, targetPort int) {
+		if spec == nil {
+			return
+		}
+		port := spec.Port
+		if port == 0 {
+			port = defaultPort
+		}
+		exposed = append(exposed, ExposedEndpoint{Name: name, Port: port, NodePort: spec.NodePort, TargetPort: targetPort})
+	}
+	add("hotrod", endpoints.HotRod, consts.InfinispanUserPort, int(ispn.HotRodPort()))
+	add("rest", endpoints.Rest, consts.InfinispanRestExposePort, int(ispn.RestPort()))
+	add("admin", endpoints.Admin, consts.InfinispanAdminPort, consts.InfinispanAdminPort)
+	return exposed
+}
+
+// GetRouteTermination returns where the Route expose type terminates TLS, or an empty string if the Route is not secured
+func (ispn *Infinispan) GetRouteTermination() RouteTerminationType {
+	if termination := ispn.Spec.Expose.RouteTermination; termination != "" {
+		return termination
+	}
+	if ispn.IsEncryptionEnabled() {
+		return RouteTerminationPassthrough
+	}
+	return ""
+}
+
+// IsGatewayTLSPassthrough returns true if the Gateway expose type uses a TLSRoute that passes the encrypted traffic
+// through to the pods, which allows Hot Rod clients to connect through the Gateway
+func (ispn *Infinispan) IsGatewayTLSPassthrough() bool {
+	return ispn.GetExposeType() == ExposeTypeGateway && ispn.IsEncryptionEnabled()
+}
+
 func (ispn *Infinispan) GetSiteServiceName() string {
 	return fmt.Sprintf(SiteServiceNameTemplate, ispn.Name)
 }
@@ -322,6 +476,11 @@ func (ispn *Infinispan) GetRemoteSiteServiceFQN(locationName string) string {
 	return fmt.Sprintf(SiteServiceFQNTemplate, ispn.GetRemoteSiteServiceName(locationName), ispn.GetRemoteSiteNamespace(locationName))
 }
 
+// GetRemoteSiteServiceClusterSetFQN returns the ClusterSet domain name of the Service exported by the remote site
+func (ispn *Infinispan) GetRemoteSiteServiceClusterSetFQN(locationName string) string {
+	return fmt.Sprintf(SiteServiceClusterSetFQNTemplate, ispn.GetRemoteSiteServiceName(locationName), ispn.GetRemoteSiteNamespace(locationName))
+}
+
 func (ispn *Infinispan) GetRemoteSiteNamespace(locationName string) string {
 	remoteLocation := ispn.GetRemoteSiteLocations()[locationName]
 	return consts.GetWithDefault(remoteLocation.Namespace, ispn.Namespace)
@@ -358,6 +517,15 @@ func (ispn *Infinispan) GetAdminSecretName() string {
 	return fmt.Sprintf("%v-generated-operator-secret", ispn.GetName())
 }
 
+// GetAdminCredentialsSecretName returns the name of the secret that the password of the operator user is defined in,
+// which is the admin secret unless the user provides the password with spec.security.adminSecretName
+func (ispn *Infinispan) GetAdminCredentialsSecretName() string {
+	if ispn.Spec.Security.AdminSecretName != "" {
+		return ispn.Spec.Security.AdminSecretName
+	}
+	return ispn.GetAdminSecretName()
+}
+
 func (ispn *Infinispan) GetAuthorizationRoles() []AuthorizationRole {
 	if !ispn.IsAuthorizationEnabled() {
 		return make([]AuthorizationRole, 0)
@@ -369,10 +537,96 @@ func (ispn *Infinispan) IsAuthorizationEnabled() bool {
 	return ispn.Spec.Security.Authorization != nil && ispn.Spec.Security.Authorization.Enabled
 }
 
+// IsAuditLogEnabled returns true if the servers record authorization decisions in the security audit log
+func (ispn *Infinispan) IsAuditLogEnabled() bool {
+	return ispn.IsAuthorizationEnabled() && ispn.Spec.Security.AuditLog != nil && ispn.Spec.Security.AuditLog.Enabled
+}
+
+// GetAuditLogSink returns where the security audit log is written
+func (ispn *Infinispan) GetAuditLogSink() AuditLogSinkType {
+	if sink := ispn.Spec.Security.AuditLog.Sink; sink != "" {
+		return sink
+	}
+	return AuditLogSinkConsole
+}
+
+// GetAuditLogLevel returns the level of the audit log category
+func (ispn *Infinispan) GetAuditLogLevel() string {
+	return consts.GetWithDefault(string(ispn.Spec.Security.AuditLog.Level), string(LoggingLevelInfo))
+}
+
 func (ispn *Infinispan) IsAuthenticationEnabled() bool {
 	return ispn.Spec.Security.EndpointAuthentication == nil || *ispn.Spec.Security.EndpointAuthentication
 }
 
+// IsOIDCEnabled returns true if users can authenticate with bearer tokens issued by an OpenID Connect provider
+func (ispn *Infinispan) IsOIDCEnabled() bool {
+	return ispn.IsAuthenticationEnabled() && ispn.Spec.Security.EndpointOIDC != nil
+}
+
+// GetOIDCPrincipalClaim returns the token claim that contains the name of the user
+func (ispn *Infinispan) GetOIDCPrincipalClaim() string {
+	if claim := ispn.Spec.Security.EndpointOIDC.PrincipalClaim; claim != "" {
+		return claim
+	}
+	return consts.DefaultOIDCPrincipalClaim
+}
+
+// IsServiceAccountAuthEnabled returns true if users of the REST endpoint can authenticate with ServiceAccount tokens
+func (ispn *Infinispan) IsServiceAccountAuthEnabled() bool {
+	return ispn.IsAuthenticationEnabled() && ispn.Spec.Security.EndpointServiceAccount != nil
+}
+
+// GetServiceAccountTokenAudiences returns the audiences that ServiceAccount tokens must be issued for
+func (ispn *Infinispan) GetServiceAccountTokenAudiences() []string {
+	if audiences := ispn.Spec.Security.EndpointServiceAccount.Audiences; len(audiences) > 0 {
+		return audiences
+	}
+	return []string{fmt.Sprintf("%s.%s.svc", ispn.GetServiceName(), ispn.Namespace)}
+}
+
+// GetTokenReviewName returns the name of the ServiceAccount that the token review sidecar calls the TokenReview API
+// with when spec.serviceAccountName is not set
+func (ispn *Infinispan) GetTokenReviewName() string {
+	return fmt.Sprintf("%s-token-review", ispn.Name)
+}
+
+// GetPodServiceAccountName returns the ServiceAccount of Infinispan pods. A projected token can only be issued for the
+// ServiceAccount of the pod, so pods run with the token review ServiceAccount when ServiceAccount tokens are accepted
+// and spec.serviceAccountName is not set
+func (ispn *Infinispan) GetPodServiceAccountName() string {
+	if ispn.Spec.ServiceAccountName == "" && ispn.IsServiceAccountAuthEnabled() {
+		return ispn.GetTokenReviewName()
+	}
+	return ispn.Spec.ServiceAccountName
+}
+
+// IsKerberosEnabled returns true if Hot Rod users can authenticate with Kerberos tickets
+func (ispn *Infinispan) IsKerberosEnabled() bool {
+	return ispn.IsAuthenticationEnabled() && ispn.Spec.Security.EndpointKerberos != nil
+}
+
+// HasCredentials returns true if secrets are added to the credential store of the servers with spec.security.credentials
+func (ispn *Infinispan) HasCredentials() bool {
+	return len(ispn.Spec.Security.Credentials) > 0
+}
+
+// GetKey returns the key of the Secret that contains the credential
+func (c *CredentialStoreEntry) GetKey() string {
+	return consts.GetWithDefault(c.Key, consts.DefaultCredentialKey)
+}
+
+// IsVaultEnabled returns true if the servers read their credentials from HashiCorp Vault
+func (ispn *Infinispan) IsVaultEnabled() bool {
+	return ispn.Spec.Security.Vault != nil
+}
+
+// IsEndpointSecretEnabled returns true if the users of the endpoints are defined by the endpoint Secret, instead of being
+// read by the servers from Vault
+func (ispn *Infinispan) IsEndpointSecretEnabled() bool {
+	return ispn.IsAuthenticationEnabled() && !ispn.IsVaultEnabled()
+}
+
 func (ispn *Infinispan) IsClientCertEnabled() bool {
 	return ispn.IsEncryptionEnabled() && ispn.Spec.Security.EndpointEncryption.ClientCert != "" && ispn.Spec.Security.EndpointEncryption.ClientCert != ClientCertNone
 }
@@ -392,6 +646,319 @@ func (ispn *Infinispan) GetInfinispanSecuritySecretName() string {
 	return fmt.Sprintf("%v-infinispan-security", ispn.Name)
 }
 
+// GetCABundleConfigMapName returns the ConfigMap containing the CA bundle that clients must trust
+func (ispn *Infinispan) GetCABundleConfigMapName() string {
+	return fmt.Sprintf("%v-ca-bundle", ispn.Name)
+}
+
+// GetIntegrityReportConfigMapName returns the name of the ConfigMap containing the results of the integrity check
+func (ispn *Infinispan) GetIntegrityReportConfigMapName() string {
+	return fmt.Sprintf("%v-integrity-report", ispn.Name)
+}
+
+// IsIntegrityCheckEnabled returns true if the periodic integrity check of replicated caches is enabled
+func (ispn *Infinispan) IsIntegrityCheckEnabled() bool {
+	return ispn.Spec.IntegrityCheck != nil && ispn.Spec.IntegrityCheck.Enabled
+}
+
+// IntegrityCheckInterval returns the time between integrity checks
+func (ispn *Infinispan) IntegrityCheckInterval() time.Duration {
+	if spec := ispn.Spec.IntegrityCheck; spec != nil && spec.Interval != nil {
+		return spec.Interval.Duration
+	}
+	return consts.DefaultIntegrityCheckInterval
+}
+
+// IntegrityCheckSampleSize returns the maximum number of keys checked per cache
+func (ispn *Infinispan) IntegrityCheckSampleSize() int {
+	if spec := ispn.Spec.IntegrityCheck; spec != nil && spec.SampleSize != nil {
+		return int(*spec.SampleSize)
+	}
+	return consts.DefaultIntegrityCheckSampleSize
+}
+
+// IsAutoscalingEnabled returns true if the number of replicas is managed by the autoscaler
+func (ispn *Infinispan) IsAutoscalingEnabled() bool {
+	return ispn.IsCache() && ispn.Spec.Autoscale != nil && !ispn.Spec.Autoscale.Disabled
+}
+
+// IsRecommendationsEnabled returns true if the periodic sizing recommendations are enabled
+func (ispn *Infinispan) IsRecommendationsEnabled() bool {
+	return ispn.Spec.Recommendations != nil && ispn.Spec.Recommendations.Enabled
+}
+
+// RecommendationsInterval returns the time between sizing recommendation evaluations
+func (ispn *Infinispan) RecommendationsInterval() time.Duration {
+	if spec := ispn.Spec.Recommendations; spec != nil && spec.Interval != nil {
+		return spec.Interval.Duration
+	}
+	return consts.DefaultRecommendationsInterval
+}
+
+// IsStorageMonitoringEnabled returns true if the usage of the data volumes is periodically measured
+func (ispn *Infinispan) IsStorageMonitoringEnabled() bool {
+	return ispn.Spec.StorageMonitoring != nil && ispn.Spec.StorageMonitoring.Enabled && !ispn.IsEphemeralStorage()
+}
+
+// StorageMonitoringInterval returns the time between measurements of the usage of the data volumes
+func (ispn *Infinispan) StorageMonitoringInterval() time.Duration {
+	if spec := ispn.Spec.StorageMonitoring; spec != nil && spec.Interval != nil {
+		return spec.Interval.Duration
+	}
+	return consts.DefaultStorageMonitoringInterval
+}
+
+// StoragePressureThreshold returns the percentage of the capacity of a data volume above which it is under pressure
+func (ispn *Infinispan) StoragePressureThreshold() int32 {
+	if spec := ispn.Spec.StorageMonitoring; spec != nil && spec.Threshold != nil {
+		return *spec.Threshold
+	}
+	return consts.DefaultStoragePressureThreshold
+}
+
+// IsStorageAutoExpandEnabled returns true if the PersistentVolumeClaims of data volumes under pressure are expanded
+func (ispn *Infinispan) IsStorageAutoExpandEnabled() bool {
+	return ispn.IsStorageMonitoringEnabled() && ispn.Spec.StorageMonitoring.AutoExpand != nil && ispn.Spec.StorageMonitoring.AutoExpand.Enabled
+}
+
+// StorageExpansionIncrement returns the percentage by which the storage of a data volume under pressure is increased
+func (ispn *Infinispan) StorageExpansionIncrement() int32 {
+	if spec := ispn.Spec.StorageMonitoring; spec != nil && spec.AutoExpand != nil && spec.AutoExpand.Increment != nil {
+		return *spec.AutoExpand.Increment
+	}
+	return consts.DefaultStorageExpansionIncrement
+}
+
+// IsJmxEnabled returns true if remote JMX access to the server pods is enabled
+func (ispn *Infinispan) IsJmxEnabled() bool {
+	return ispn.Spec.Jmx != nil && ispn.Spec.Jmx.Enabled
+}
+
+// IsCapacityFactorEnabled returns true if the capacity factor of the pods is configured per zone
+func (ispn *Infinispan) IsCapacityFactorEnabled() bool {
+	return ispn.Spec.CapacityFactor != nil && len(ispn.Spec.CapacityFactor.Zones) > 0
+}
+
+// CapacityFactorTopologyKey returns the label of the nodes that identifies their zone
+func (ispn *Infinispan) CapacityFactorTopologyKey() string {
+	if ispn.Spec.CapacityFactor == nil || ispn.Spec.CapacityFactor.TopologyKey == "" {
+		return corev1.LabelZoneFailureDomainStable
+	}
+	return ispn.Spec.CapacityFactor.TopologyKey
+}
+
+// CapacityFactorForZone returns the capacity factor of the pods scheduled on the nodes of the given zone
+func (ispn *Infinispan) CapacityFactorForZone(zone string) string {
+	spec := ispn.Spec.CapacityFactor
+	if spec == nil {
+		return consts.DefaultCapacityFactor
+	}
+	for _, z := range spec.Zones {
+		if z.Zone == zone {
+			return z.Factor
+		}
+	}
+	if spec.Default != "" {
+		return spec.Default
+	}
+	return consts.DefaultCapacityFactor
+}
+
+// IsShadowReplicationEnabled returns true if the writes to caches are replicated to another cluster
+func (ispn *Infinispan) IsShadowReplicationEnabled() bool {
+	return ispn.Spec.ShadowReplication != nil && ispn.Spec.ShadowReplication.Target != ""
+}
+
+// ShadowReplicationLagCheckInterval returns the time between measurements of the shadow replication lag
+func (ispn *Infinispan) ShadowReplicationLagCheckInterval() time.Duration {
+	if spec := ispn.Spec.ShadowReplication; spec != nil && spec.LagCheckInterval != nil {
+		return spec.LagCheckInterval.Duration
+	}
+	return consts.DefaultShadowReplicationLagCheckInterval
+}
+
+// IsServiceMeshEnabled returns true if the pods are configured for service mesh sidecars
+func (ispn *Infinispan) IsServiceMeshEnabled() bool {
+	return ispn.Spec.ServiceMesh != nil && ispn.Spec.ServiceMesh.Enabled
+}
+
+// IsXSiteStateTransferInProgress returns true if a state transfer requested with the infinispan.org/xsite-state-transfer
+// annotation has neither completed nor been cancelled
+func (ispn *Infinispan) IsXSiteStateTransferInProgress() bool {
+	transfer := ispn.Status.XSiteStateTransfer
+	return transfer != nil && transfer.Phase == XSiteStateTransferPhaseInProgress
+}
+
+// IsClusterActionInProgress returns true if an action requested with the infinispan.org/action annotation has neither
+// succeeded nor failed
+func (ispn *Infinispan) IsClusterActionInProgress() bool {
+	action := ispn.Status.Action
+	return action != nil && action.Phase != ClusterActionPhaseSucceeded && action.Phase != ClusterActionPhaseFailed
+}
+
+// IsIPv6 returns true if the cluster is configured for an IPv6 pod network
+func (ispn *Infinispan) IsIPv6() bool {
+	return ispn.Spec.IPFamily == corev1.IPv6Protocol
+}
+
+// GetIPFamilyPolicy returns the IP family policy of the Services, PreferDualStack if it is not configured
+func (ispn *Infinispan) GetIPFamilyPolicy() IPFamilyPolicyType {
+	if ispn.Spec.IPFamilyPolicy == "" {
+		return IPFamilyPolicyPreferDualStack
+	}
+	return ispn.Spec.IPFamilyPolicy
+}
+
+// GetServiceIPFamilies returns the IP families of the Services, the ipFamily of the cluster first. The secondary family
+// is only requested with the RequireDualStack policy, as Kubernetes adds it to PreferDualStack Services on dual-stack
+// clusters and rejects families that are not configured
+func (ispn *Infinispan) GetServiceIPFamilies() []corev1.IPFamily {
+	primary, secondary := corev1.IPv4Protocol, corev1.IPv6Protocol
+	if ispn.IsIPv6() {
+		primary, secondary = secondary, primary
+	}
+	if ispn.GetIPFamilyPolicy() == IPFamilyPolicyRequireDualStack {
+		return []corev1.IPFamily{primary, secondary}
+	}
+	return []corev1.IPFamily{primary}
+}
+
+// IsHotRodEnabled returns true if the user endpoint serves the Hot Rod protocol
+func (ispn *Infinispan) IsHotRodEnabled() bool {
+	return ispn.Spec.Endpoints == nil || isEndpointEnabled(ispn.Spec.Endpoints.HotRod)
+}
+
+// IsRestEnabled returns true if the user endpoint serves the REST protocol
+func (ispn *Infinispan) IsRestEnabled() bool {
+	return ispn.Spec.Endpoints == nil || isEndpointEnabled(ispn.Spec.Endpoints.Rest)
+}
+
+// GetConsoleExposeType returns how the Console is reached from outside the Kubernetes cluster
+func (ispn *Infinispan) GetConsoleExposeType() ConsoleExposeType {
+	if endpoints := ispn.Spec.Endpoints; endpoints != nil && endpoints.Console != nil && endpoints.Console.Expose != nil {
+		return endpoints.Console.Expose.Type
+	}
+	return ConsoleExposeTypeCluster
+}
+
+// IsConsoleExposedWithCluster returns true if the Console is reached at the address of spec.expose
+func (ispn *Infinispan) IsConsoleExposedWithCluster() bool {
+	return ispn.GetConsoleExposeType() == ConsoleExposeTypeCluster
+}
+
+// CanExposeWithoutConsole returns true if spec.expose can route the REST API without the Console, which requires an
+// expose type that routes HTTP requests by path
+func (ispn *Infinispan) CanExposeWithoutConsole() bool {
+	if !ispn.IsExposed() {
+		return true
+	}
+	switch ispn.GetExposeType() {
+	case ExposeTypeIngress:
+		return true
+	case ExposeTypeRoute:
+		return ispn.GetRouteTermination() != RouteTerminationPassthrough
+	case ExposeTypeGateway:
+		return !ispn.IsGatewayTLSPassthrough()
+	}
+	return false
+}
+
+// HasConsoleExternalService returns true if the Console is exposed with a dedicated Route or Ingress
+func (ispn *Infinispan) HasConsoleExternalService() bool {
+	t := ispn.GetConsoleExposeType()
+	return ispn.IsConsoleEnabled() && (t == ConsoleExposeTypeRoute || t == ConsoleExposeTypeIngress)
+}
+
+// GetConsoleExternalName returns the name of the dedicated Route or Ingress of the Console
+func (ispn *Infinispan) GetConsoleExternalName() string {
+	return fmt.Sprintf("%s-console", ispn.Name)
+}
+
+// IsAdminAccessRestricted returns true if administrative operations are only available on the admin endpoint
+func (ispn *Infinispan) IsAdminAccessRestricted() bool {
+	return ispn.Spec.Security.RestrictAdminAccess || ispn.Spec.Security.RestrictAdminOperations
+}
+
+// IsAdminOperationsRestricted returns true if the users of the endpoints cannot have the admin role or the credentials
+// of the operator user
+func (ispn *Infinispan) IsAdminOperationsRestricted() bool {
+	return ispn.Spec.Security.RestrictAdminOperations
+}
+
+// GetPasswordAlgorithms returns the algorithms that the passwords of the users of the endpoints are hashed with, or nil
+// if the server defaults are used
+func (ispn *Infinispan) GetPasswordAlgorithms() []string {
+	var algorithms []string
+	for _, algorithm := range ispn.Spec.Security.PasswordAlgorithms {
+		algorithms = append(algorithms, string(algorithm))
+	}
+	return algorithms
+}
+
+// GetAdminPasswordAlgorithms returns the algorithms that the password of the operator user is hashed with, which always
+// include digest-md5 as the operator authenticates with HTTP Digest authentication, or nil if the server defaults are
+// used
+func (ispn *Infinispan) GetAdminPasswordAlgorithms() []string {
+	algorithms := ispn.GetPasswordAlgorithms()
+	if algorithms == nil {
+		return nil
+	}
+	for _, algorithm := range algorithms {
+		if algorithm == string(PasswordAlgorithmDigestMD5) {
+			return algorithms
+		}
+	}
+	return append(algorithms, string(PasswordAlgorithmDigestMD5))
+}
+
+// IsConsoleEnabled returns true if the Console and CLI access are available on the user endpoint
+func (ispn *Infinispan) IsConsoleEnabled() bool {
+	if !ispn.IsRestEnabled() || ispn.IsAdminAccessRestricted() {
+		return false
+	}
+	endpoints := ispn.Spec.Endpoints
+	return endpoints == nil || endpoints.Console == nil || endpoints.Console.Enabled == nil || *endpoints.Console.Enabled
+}
+
+// HotRodPort returns the container port that serves the Hot Rod protocol
+func (ispn *Infinispan) HotRodPort() int32 {
+	if endpoints := ispn.Spec.Endpoints; endpoints != nil && endpoints.HotRod != nil && endpoints.HotRod.Port > 0 {
+		return endpoints.HotRod.Port
+	}
+	return consts.InfinispanUserPort
+}
+
+// RestPort returns the container port that serves the REST protocol
+func (ispn *Infinispan) RestPort() int32 {
+	if endpoints := ispn.Spec.Endpoints; endpoints != nil && endpoints.Rest != nil && endpoints.Rest.Port > 0 {
+		return endpoints.Rest.Port
+	}
+	return consts.InfinispanUserPort
+}
+
+// ClientListeners returns the configuration of the delivery of cache events to client listeners, or nil if the server
+// defaults apply
+func (ispn *Infinispan) ClientListeners() *ClientListenersSpec {
+	if ispn.Spec.Endpoints == nil {
+		return nil
+	}
+	return ispn.Spec.Endpoints.ClientListeners
+}
+
+func isEndpointEnabled(endpoint *InfinispanEndpointSpec) bool {
+	return endpoint == nil || endpoint.Enabled == nil || *endpoint.Enabled
+}
+
+// GetJmxServiceName returns the name of the headless service exposing the JMX port of each pod
+func (ispn *Infinispan) GetJmxServiceName() string {
+	return fmt.Sprintf("%s-jmx", ispn.Name)
+}
+
+// ServerRoot returns the server root directory of the Infinispan container
+func (ispn *Infinispan) ServerRoot() string {
+	return consts.GetWithDefault(ispn.Spec.Container.ServerRoot, consts.ServerRoot)
+}
+
 // GetServiceMonitorName returns the ServiceMonitor name for the cluster
 func (ispn *Infinispan) GetServiceMonitorName() string {
 	return fmt.Sprintf("%v-monitor", ispn.Name)
@@ -405,6 +972,11 @@ func (ispn *Infinispan) GetKeystoreSecretName() string {
 	return ispn.Spec.Security.EndpointEncryption.CertSecretName
 }
 
+// IsTLS13CipherSuite returns true if the cipher suite can only be used with TLSv1.3, which defines its own cipher suites
+func IsTLS13CipherSuite(cipherSuite string) bool {
+	return strings.HasPrefix(cipherSuite, "TLS_AES_") || strings.HasPrefix(cipherSuite, "TLS_CHACHA20_")
+}
+
 func (ispn *Infinispan) GetTruststoreSecretName() string {
 	if ispn.Spec.Security.EndpointEncryption == nil {
 		return ""
@@ -454,19 +1026,121 @@ func getRequestLimits(str string) (requests resource.Quantity, limits resource.Q
 func (ispn *Infinispan) GetJavaOptions() string {
 	switch ispn.Spec.Service.Type {
 	case ServiceTypeDataGrid:
-		return ispn.Spec.Container.ExtraJvmOpts
+		var opts []string
+		for _, opt := range []string{ispn.maxHeapSizeOption(), ispn.gcPolicyOptions(), ispn.diagnosticsOptions(), ispn.jmxOptions(), ispn.ipFamilyOptions(), ispn.kerberosOptions(), ispn.Spec.Container.ExtraJvmOpts} {
+			if opt != "" {
+				opts = append(opts, opt)
+			}
+		}
+		return strings.Join(opts, " ")
 	case ServiceTypeCache:
+		extraJvmOpts := ispn.Spec.Container.ExtraJvmOpts
+		for _, opt := range []string{ispn.kerberosOptions(), ispn.ipFamilyOptions()} {
+			if opt != "" {
+				extraJvmOpts = strings.TrimSpace(opt + " " + extraJvmOpts)
+			}
+		}
 		switch ispn.ImageType() {
 		case ImageTypeJVM:
 			return fmt.Sprintf(consts.CacheServiceJavaOptions, consts.CacheServiceFixedMemoryXmxMb, consts.CacheServiceFixedMemoryXmxMb, consts.CacheServiceMaxRamMb,
-				consts.CacheServiceMinHeapFreeRatio, consts.CacheServiceMaxHeapFreeRatio, ispn.Spec.Container.ExtraJvmOpts)
+				consts.CacheServiceMinHeapFreeRatio, consts.CacheServiceMaxHeapFreeRatio, extraJvmOpts)
 		case ImageTypeNative:
-			return fmt.Sprintf(consts.CacheServiceNativeJavaOptions, consts.CacheServiceFixedMemoryXmxMb, consts.CacheServiceFixedMemoryXmxMb, ispn.Spec.Container.ExtraJvmOpts)
+			return fmt.Sprintf(consts.CacheServiceNativeJavaOptions, consts.CacheServiceFixedMemoryXmxMb, consts.CacheServiceFixedMemoryXmxMb, extraJvmOpts)
 		}
 	}
 	return ""
 }
 
+// maxHeapSizeOption returns the -Xmx option derived from spec.container.maxRamPercentage and the container memory limit
+func (ispn *Infinispan) maxHeapSizeOption() string {
+	percentage := ispn.Spec.Container.MaxRamPercentage
+	if percentage == nil {
+		return ""
+	}
+	_, memLimit, err := ispn.Spec.Container.GetMemoryResources()
+	if err != nil || memLimit.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("-Xmx%dM", memLimit.Value()*int64(*percentage)/100/(1024*1024))
+}
+
+// gcPolicyOptions returns the JVM flags that enable the garbage collector defined in spec.container.gcPolicy.
+// Native images are built with the Serial collector and don't accept GC flags at runtime
+func (ispn *Infinispan) gcPolicyOptions() string {
+	if ispn.ImageType() == ImageTypeNative {
+		return ""
+	}
+	switch ispn.Spec.Container.GCPolicy {
+	case GCPolicyG1:
+		return "-XX:+UseG1GC"
+	case GCPolicyZGC:
+		// ZGC is experimental on JDK 11 based images, unlocking is a no-op on later JDKs
+		return "-XX:+UnlockExperimentalVMOptions -XX:+UseZGC"
+	case GCPolicyShenandoah:
+		return "-XX:+UnlockExperimentalVMOptions -XX:+UseShenandoahGC"
+	case GCPolicySerial:
+		return "-XX:+UseSerialGC"
+	}
+	return ""
+}
+
+// diagnosticsOptions returns the JVM flags that enable the GC log, the continuous flight recording and the heap dump
+// defined in spec.container.diagnostics. All are written to the data volume of the server pod
+func (ispn *Infinispan) diagnosticsOptions() string {
+	diagnostics := ispn.Spec.Container.Diagnostics
+	if diagnostics == nil || ispn.ImageType() == ImageTypeNative {
+		return ""
+	}
+	var opts []string
+	dataDir := ispn.ServerRoot() + "/data"
+	if diagnostics.GCLogging {
+		fileCount := consts.DefaultGCLogFileCount
+		if diagnostics.GCLogFileCount != nil {
+			fileCount = *diagnostics.GCLogFileCount
+		}
+		fileSize := consts.GetWithDefault(diagnostics.GCLogFileSize, consts.DefaultGCLogFileSize)
+		opts = append(opts, fmt.Sprintf("-Xlog:gc*:file=%s/%s:time,uptime,level,tags:filecount=%d,filesize=%s", dataDir, consts.GCLogFilename, fileCount, fileSize))
+	}
+	if diagnostics.FlightRecorder {
+		maxSize := consts.GetWithDefault(diagnostics.FlightRecorderMaxSize, consts.DefaultFlightRecorderMaxSize)
+		opts = append(opts, fmt.Sprintf("-XX:StartFlightRecording=name=continuous,disk=true,dumponexit=true,maxsize=%s,filename=%s/%s", maxSize, dataDir, consts.FlightRecordingFilename))
+	}
+	if diagnostics.HeapDumpOnOutOfMemoryError {
+		// The JVM does not overwrite an existing dump, so only the first OutOfMemoryError is dumped until it is removed
+		opts = append(opts, fmt.Sprintf("-XX:+HeapDumpOnOutOfMemoryError -XX:HeapDumpPath=%s/%s", dataDir, consts.HeapDumpFilename))
+	}
+	return strings.Join(opts, " ")
+}
+
+// jmxOptions returns the JVM flags that enable authenticated remote JMX on the internal JMX port. The credential files
+// are created from the spec.jmx.secretName Secret by an init container
+func (ispn *Infinispan) jmxOptions() string {
+	if !ispn.IsJmxEnabled() {
+		return ""
+	}
+	return fmt.Sprintf("-Dcom.sun.management.jmxremote.port=%[1]d -Dcom.sun.management.jmxremote.rmi.port=%[1]d "+
+		"-Dcom.sun.management.jmxremote.ssl=false -Dcom.sun.management.jmxremote.authenticate=true "+
+		"-Dcom.sun.management.jmxremote.password.file=%[2]s/%[3]s -Dcom.sun.management.jmxremote.access.file=%[2]s/%[4]s",
+		consts.InfinispanJmxPort, consts.ServerJmxRoot, consts.JmxPasswordFilename, consts.JmxAccessFilename)
+}
+
+// kerberosOptions returns the JVM flag that points to the Kerberos configuration mounted from the keytab Secret
+func (ispn *Infinispan) kerberosOptions() string {
+	if !ispn.IsKerberosEnabled() {
+		return ""
+	}
+	return fmt.Sprintf("-Djava.security.krb5.conf=%s/%s", consts.ServerKerberosRoot, consts.KerberosConfigKey)
+}
+
+// ipFamilyOptions returns the JVM flags that make name resolution prefer IPv6 addresses, so that the server binds to and
+// connects to the IPv6 addresses of the pods on IPv6 networks
+func (ispn *Infinispan) ipFamilyOptions() string {
+	if !ispn.IsIPv6() {
+		return ""
+	}
+	return "-Djava.net.preferIPv6Addresses=true"
+}
+
 // GetLogCategoriesForConfig return a map of log category for the Infinispan configuration
 func (ispn *Infinispan) GetLogCategoriesForConfig() map[string]string {
 	var categories map[string]LoggingLevelType
@@ -504,6 +1178,42 @@ func (ispn *Infinispan) EnsureClusterStability() error {
 	return ispn.ExpectConditionStatus(conditions)
 }
 
+// HealthGate returns the reason Backup, Restore, Batch and Cache operations must not start on the cluster, or nil if
+// the cluster is healthy
+func (ispn *Infinispan) HealthGate() error {
+	if err := ispn.EnsureClusterStability(); err != nil {
+		return err
+	}
+	for _, condition := range []ConditionType{ConditionHotRodRollingUpgrade, ConditionIncompatibleOperand} {
+		if ispn.IsConditionTrue(condition) {
+			return fmt.Errorf("key '%s' has Status '%s'", condition, metav1.ConditionTrue)
+		}
+	}
+	return nil
+}
+
+// OperationGate returns nil if Backup, Restore and Batch operations can start on the cluster. Otherwise the error
+// reports whether the operation is queued until the cluster is healthy or rejected, with reject true if the operation
+// must fail
+func (ispn *Infinispan) OperationGate() (reject bool, err error) {
+	gateErr := ispn.HealthGate()
+	if gateErr == nil {
+		return false, nil
+	}
+	if ispn.OperationGatePolicy() == OperationGateReject {
+		return true, fmt.Errorf("rejected as Infinispan '%s' is not healthy: %w", ispn.Name, gateErr)
+	}
+	return false, fmt.Errorf("queued until Infinispan '%s' is healthy: %w", ispn.Name, gateErr)
+}
+
+// OperationGatePolicy returns the action taken for operations targeting the cluster whilst it is not healthy
+func (ispn *Infinispan) OperationGatePolicy() OperationGatePolicy {
+	if gate := ispn.Spec.OperationGate; gate != nil && gate.Policy != "" {
+		return gate.Policy
+	}
+	return OperationGateQueue
+}
+
 func (ispn *Infinispan) IsUpgradeNeeded(logger logr.Logger) bool {
 	if ispn.IsUpgradeCondition() {
 		if ispn.GetCondition(ConditionStopping).Status == metav1.ConditionFalse {
@@ -532,6 +1242,37 @@ func (ispn *Infinispan) IsEncryptionCertFromService() bool {
 	return ee != nil && (ee.Type == CertificateSourceTypeService || ee.Type == CertificateSourceTypeServiceLowCase)
 }
 
+// IsEncryptionCertFromOperator returns true if the operator issues the encryption certificates itself
+func (ispn *Infinispan) IsEncryptionCertFromOperator() bool {
+	return ispn.IsEncryptionCertFromService() && ispn.Spec.Security.EndpointEncryption.CertServiceName == OperatorCertServiceName
+}
+
+// GetCertCASecretName returns the name of the Secret with the CA that signs the certificates issued by the operator
+func (ispn *Infinispan) GetCertCASecretName() string {
+	return fmt.Sprintf("%s-cert-ca", ispn.Name)
+}
+
+// EndpointCertificateHostnames returns the hostnames that the certificates issued by the operator are valid for: the
+// DNS names of the cluster Service, the host of spec.expose, the ExternalDNS hostnames and the host of the Console.
+// Addresses assigned by the platform, such as the hostname of a LoadBalancer, are not known in advance
+func (ispn *Infinispan) EndpointCertificateHostnames() []string {
+	svc := ispn.GetServiceName()
+	hostnames := []string{
+		svc,
+		fmt.Sprintf("%s.%s", svc, ispn.Namespace),
+		fmt.Sprintf("%s.%s.svc", svc, ispn.Namespace),
+		fmt.Sprintf(SiteServiceFQNTemplate, svc, ispn.Namespace),
+	}
+	if ispn.IsExposed() && ispn.Spec.Expose.Host != "" {
+		hostnames = append(hostnames, ispn.Spec.Expose.Host)
+	}
+	hostnames = append(hostnames, ispn.ExternalDNSHostnames()...)
+	if ispn.HasConsoleExternalService() && ispn.Spec.Endpoints.Console.Expose.Host != "" {
+		hostnames = append(hostnames, ispn.Spec.Endpoints.Console.Expose.Host)
+	}
+	return hostnames
+}
+
 // IsEncryptionCertSourceDefined returns true if encryption certificates source is defined
 func (ispn *Infinispan) IsEncryptionCertSourceDefined() bool {
 	ee := ispn.Spec.Security.EndpointEncryption
@@ -547,6 +1288,48 @@ func (ispn *Infinispan) IsEphemeralStorage() bool {
 	return false
 }
 
+// IsDevMode returns true if the cluster is configured for local development
+func (ispn *Infinispan) IsDevMode() bool {
+	return ispn.Spec.DevMode
+}
+
+// IsEdgeProfile returns true if the cluster is configured with the minimal resources of the edge profile
+func (ispn *Infinispan) IsEdgeProfile() bool {
+	return ispn.Spec.Profile == ProfileEdge
+}
+
+// IsDriftDetectionEnabled returns true if changes to the managed resources by other field managers are detected
+func (ispn *Infinispan) IsDriftDetectionEnabled() bool {
+	return ispn.Spec.DriftDetection != nil
+}
+
+// DriftDetectionMode returns the action taken when the managed resources have drifted
+func (ispn *Infinispan) DriftDetectionMode() DriftDetectionMode {
+	if ispn.Spec.DriftDetection == nil || ispn.Spec.DriftDetection.Mode == "" {
+		return DriftDetectionModeRevert
+	}
+	return ispn.Spec.DriftDetection.Mode
+}
+
+// IsAutostopEnabled returns true if the cluster is shut down and woken by cron schedules
+func (ispn *Infinispan) IsAutostopEnabled() bool {
+	return ispn.Spec.Autostop != nil
+}
+
+// AutostopLocation returns the time zone in which the autostop schedules are evaluated
+func (ispn *Infinispan) AutostopLocation() (*time.Location, error) {
+	if ispn.Spec.Autostop == nil || ispn.Spec.Autostop.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(ispn.Spec.Autostop.TimeZone)
+}
+
+// IsDataMigrationSkipped returns true if upgrades between major versions must not migrate the persistent data
+func (ispn *Infinispan) IsDataMigrationSkipped() bool {
+	u := ispn.Spec.Upgrades
+	return u != nil && u.DataMigration != nil && u.DataMigration.Skip
+}
+
 // StorageClassName returns a storage class name if it defined
 func (ispn *Infinispan) StorageClassName() string {
 	sc := ispn.Spec.Service.Container
@@ -591,10 +1374,153 @@ func (ispn *Infinispan) ServiceSelectorLabels() map[string]string {
 	}
 }
 
-// ExternalServiceLabels returns all labels to be applied to the external service pods, including those defined by the
-// user. It's values should never be used as a selector.
+// InternalServiceLabels returns all labels to be applied to the Services used within the Kubernetes cluster, including
+// spec.service.labels. It's values should never be used as a selector.
+func (ispn *Infinispan) InternalServiceLabels(app string) map[string]string {
+	return scopedMetadata(ispn.ServiceLabels(app), ispn.Spec.Service.Labels, ispn.Labels(app))
+}
+
+// InternalServiceAnnotations returns all annotations to be applied to the Services used within the Kubernetes cluster,
+// including spec.service.annotations
+func (ispn *Infinispan) InternalServiceAnnotations() map[string]string {
+	return scopedMetadata(ispn.ServiceAnnotations(), ispn.Spec.Service.Annotations, nil)
+}
+
+// ExternalServiceLabels returns all labels to be applied to the resources exposing the cluster, including those defined
+// by the user. It's values should never be used as a selector.
 func (ispn *Infinispan) ExternalServiceLabels() map[string]string {
-	return ispn.ServiceLabels("infinispan-service-external")
+	var labels map[string]string
+	if ispn.IsExposed() {
+		labels = ispn.Spec.Expose.Labels
+	}
+	return scopedMetadata(ispn.ServiceLabels("infinispan-service-external"), labels, ispn.ExternalServiceSelectorLabels())
+}
+
+// ConsoleExternalLabels returns all labels to be applied to the dedicated Route or Ingress of the Console
+func (ispn *Infinispan) ConsoleExternalLabels() map[string]string {
+	return scopedMetadata(ispn.ServiceLabels("infinispan-console-external"), nil, ispn.ConsoleExternalSelectorLabels())
+}
+
+// ConsoleExternalAnnotations returns all annotations to be applied to the dedicated Route or Ingress of the Console,
+// including spec.endpoints.console.expose.annotations
+func (ispn *Infinispan) ConsoleExternalAnnotations() map[string]string {
+	var annotations map[string]string
+	if ispn.HasConsoleExternalService() {
+		annotations = ispn.Spec.Endpoints.Console.Expose.Annotations
+	}
+	return scopedMetadata(ispn.ServiceAnnotations(), annotations, nil)
+}
+
+// PodExternalServiceLabels returns all labels to be applied to the Services that expose individual pods, including
+// spec.expose.labels
+func (ispn *Infinispan) PodExternalServiceLabels() map[string]string {
+	var labels map[string]string
+	if ispn.IsExposed() {
+		labels = ispn.Spec.Expose.Labels
+	}
+	return scopedMetadata(ispn.ServiceLabels("infinispan-service-external-pod"), labels, ispn.PodExternalServiceSelectorLabels())
+}
+
+// ExternalServiceAnnotations returns all annotations to be applied to the resources exposing the cluster, including
+// spec.expose.annotations
+func (ispn *Infinispan) ExternalServiceAnnotations() map[string]string {
+	var annotations map[string]string
+	if ispn.IsExposed() {
+		annotations = ispn.Spec.Expose.Annotations
+	}
+	return scopedMetadata(ispn.ServiceAnnotations(), annotations, ispn.externalDNSAnnotations())
+}
+
+// PodExternalServiceAnnotations returns all annotations to be applied to the Services that expose individual pods,
+// including spec.expose.annotations. The ExternalDNS annotations are only applied to the Service of the cluster
+func (ispn *Infinispan) PodExternalServiceAnnotations() map[string]string {
+	var annotations map[string]string
+	if ispn.IsExposed() {
+		annotations = ispn.Spec.Expose.Annotations
+	}
+	return scopedMetadata(ispn.ServiceAnnotations(), annotations, nil)
+}
+
+// externalDNSAnnotations returns the ExternalDNS annotations configured by spec.expose.externalDNS
+func (ispn *Infinispan) externalDNSAnnotations() map[string]string {
+	hostnames := ispn.ExternalDNSHostnames()
+	if len(hostnames) == 0 {
+		return nil
+	}
+	annotations := map[string]string{
+		consts.ExternalDNSHostnameAnnotation: strings.Join(hostnames, ","),
+	}
+	if ttl := ispn.Spec.Expose.ExternalDNS.TTL; ttl > 0 {
+		annotations[consts.ExternalDNSTTLAnnotation] = strconv.Itoa(int(ttl))
+	}
+	return annotations
+}
+
+// ExternalDNSHostnames returns the DNS names published by ExternalDNS for the exposed cluster
+func (ispn *Infinispan) ExternalDNSHostnames() []string {
+	if !ispn.IsExposed() || ispn.Spec.Expose.ExternalDNS == nil {
+		return nil
+	}
+	return ispn.Spec.Expose.ExternalDNS.Hostnames
+}
+
+// IsExposedPerPod returns true if a NodePort or LoadBalancer Service is created for each pod
+func (ispn *Infinispan) IsExposedPerPod() bool {
+	if !ispn.IsExposed() || !ispn.Spec.Expose.PerPod {
+		return false
+	}
+	exposeType := ispn.GetExposeType()
+	return exposeType == ExposeTypeNodePort || exposeType == ExposeTypeLoadBalancer
+}
+
+// GetPodServiceExternalName returns the name of the Service that exposes the pod with the given ordinal
+func (ispn *Infinispan) GetPodServiceExternalName(ordinal int32) string {
+	return fmt.Sprintf("%s-%d-external", ispn.GetStatefulSetName(), ordinal)
+}
+
+// PodExternalServiceSelectorLabels returns the labels of the Services that expose individual pods
+func (ispn *Infinispan) PodExternalServiceSelectorLabels() map[string]string {
+	return ispn.Labels("infinispan-service-external-pod")
+}
+
+// ExternalClientIntelligence returns the client intelligence that Hot Rod clients must use to connect to the exposed
+// cluster, or an empty string if Hot Rod clients cannot connect with the expose type
+func (ispn *Infinispan) ExternalClientIntelligence() HotRodClientIntelligence {
+	if !ispn.IsExposed() {
+		return ""
+	}
+	switch ispn.GetExposeType() {
+	case ExposeTypeNodePort, ExposeTypeLoadBalancer, ExposeTypeRoute:
+		if ispn.Spec.Expose.ClientIntelligence != "" {
+			return ispn.Spec.Expose.ClientIntelligence
+		}
+		if ispn.IsExposedPerPod() {
+			return ClientIntelligenceHashDistributionAware
+		}
+		return ClientIntelligenceBasic
+	case ExposeTypeGateway:
+		// Hot Rod clients can only connect through the Gateway with TLS passthrough
+		if !ispn.IsGatewayTLSPassthrough() {
+			return ""
+		}
+		if ispn.Spec.Expose.ClientIntelligence != "" {
+			return ispn.Spec.Expose.ClientIntelligence
+		}
+		return ClientIntelligenceBasic
+	}
+	return ""
+}
+
+// scopedMetadata adds the scoped values to the metadata, without overriding the reserved values that the operator uses
+// to select its resources
+func scopedMetadata(metadata, scoped, reserved map[string]string) map[string]string {
+	for k, v := range scoped {
+		metadata[k] = v
+	}
+	for k, v := range reserved {
+		metadata[k] = v
+	}
+	return metadata
 }
 
 // ExternalServiceSelectorLabels returns the minimum required labels to identify an external service. It does not contain any user
@@ -603,6 +1529,11 @@ func (ispn *Infinispan) ExternalServiceSelectorLabels() map[string]string {
 	return ispn.Labels("infinispan-service-external")
 }
 
+// ConsoleExternalSelectorLabels returns the labels that select the dedicated Route or Ingress of the Console
+func (ispn *Infinispan) ConsoleExternalSelectorLabels() map[string]string {
+	return ispn.Labels("infinispan-console-external")
+}
+
 // PodLabels returns all labels to be applied to Infinispan pods, including those defined by the user. It's values
 // should never be used as a selector.
 func (ispn *Infinispan) PodLabels() map[string]string {
@@ -768,6 +1699,19 @@ func (ispn *Infinispan) IsServiceMonitorEnabled() bool {
 	return false
 }
 
+// GossipRouterReplicas returns the number of Gossip Router pods, 0 if the cluster is scaled to 0 pods
+func (ispn *Infinispan) GossipRouterReplicas() int32 {
+	if ispn.Spec.Replicas <= 0 {
+		return 0
+	}
+	if ispn.Spec.Service.Sites != nil {
+		if router := ispn.Spec.Service.Sites.Local.GossipRouter; router != nil && router.Replicas != nil {
+			return *router.Replicas
+		}
+	}
+	return ispn.Spec.Replicas
+}
+
 // GetGossipRouterDeploymentName returns the Gossip Router deployment name
 func (ispn *Infinispan) GetGossipRouterDeploymentName() string {
 	return fmt.Sprintf(GossipRouterDeploymentNameTemplate, ispn.Name)
@@ -854,6 +1798,29 @@ func (ispn *Infinispan) GetSiteTrustStoreFileName() string {
 	return consts.GetWithDefault(tls.TrustStore.Filename, consts.DefaultSiteTrustStoreFileName)
 }
 
+// GetSiteCASecretNames returns the names of the Secrets with the CA certificates of the remote site locations
+func (ispn *Infinispan) GetSiteCASecretNames() (secretNames []string) {
+	if !ispn.IsSiteTLSEnabled() {
+		return nil
+	}
+	for _, location := range ispn.Spec.Service.Sites.Locations {
+		if location.Name != ispn.Spec.Service.Sites.Local.Name && location.CASecretName != "" {
+			secretNames = append(secretNames, location.CASecretName)
+		}
+	}
+	return
+}
+
+// HasSiteCAs returns true if the operator assembles the relay truststore from the CA certificates of the remote sites
+func (ispn *Infinispan) HasSiteCAs() bool {
+	return len(ispn.GetSiteCASecretNames()) > 0
+}
+
+// GetSiteRelayTrustStoreSecretName returns the name of the Secret with the relay truststore assembled by the operator
+func (ispn *Infinispan) GetSiteRelayTrustStoreSecretName() string {
+	return fmt.Sprintf("%s-relay-truststore", ispn.Name)
+}
+
 func (ispn *Infinispan) IsConfigListenerEnabled() bool {
 	return ispn.Spec.ConfigListener != nil && ispn.Spec.ConfigListener.Enabled
 }
@@ -862,6 +1829,17 @@ func (ispn *Infinispan) GetConfigListenerName() string {
 	return fmt.Sprintf("%s-config-listener", ispn.Name)
 }
 
+// GetServerRoleName returns the name of the Role and RoleBinding that grant permissions to spec.serviceAccountName
+func (ispn *Infinispan) GetServerRoleName() string {
+	return fmt.Sprintf("%s-server", ispn.Name)
+}
+
+// IsServerRoleRequired returns true if Infinispan pods run with spec.serviceAccountName and its API token is mounted
+func (ispn *Infinispan) IsServerRoleRequired() bool {
+	token := ispn.Spec.AutomountServiceAccountToken
+	return ispn.Spec.ServiceAccountName != "" && (token == nil || *token)
+}
+
 func (ispn *Infinispan) UserConfigDefined() bool {
 	return ispn.Spec.ConfigMapName != ""
 }
@@ -873,3 +1851,24 @@ func (ispn *Infinispan) GracefulShutdownUpgrades() bool {
 func (ispn *Infinispan) HotRodRollingUpgrades() bool {
 	return ispn.Spec.Upgrades != nil && ispn.Spec.Upgrades.Type == UpgradeTypeHotRodRolling
 }
+
+// IsPartitionedRollout returns true if changes to the pod template are rolled out by the operator
+func (ispn *Infinispan) IsPartitionedRollout() bool {
+	return ispn.Spec.Upgrades != nil && (ispn.Spec.Upgrades.Partition != nil || ispn.Spec.Upgrades.MaxUnavailable != nil)
+}
+
+// RolloutPartition returns the ordinal at which a partitioned rollout is paused
+func (ispn *Infinispan) RolloutPartition() int32 {
+	if ispn.IsPartitionedRollout() && ispn.Spec.Upgrades.Partition != nil {
+		return *ispn.Spec.Upgrades.Partition
+	}
+	return 0
+}
+
+// RolloutMaxUnavailable returns the number of pods that a partitioned rollout updates at the same time
+func (ispn *Infinispan) RolloutMaxUnavailable() int32 {
+	if ispn.IsPartitionedRollout() && ispn.Spec.Upgrades.MaxUnavailable != nil {
+		return *ispn.Spec.Upgrades.MaxUnavailable
+	}
+	return 1
+}
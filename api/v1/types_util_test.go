@@ -4,10 +4,15 @@ import (
 	"encoding/json"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 const namespace = "testing-namespace"
@@ -131,3 +136,375 @@ func TestApplyOperatorAnnotations(t *testing.T) {
 		assert.True(t, reflect.DeepEqual(ispn.Annotations, annotationPodMap) || len(annotationPodMap) == 0 && ispn.Annotations == nil)
 	}
 }
+
+func TestScopedServiceMetadata(t *testing.T) {
+	ispn := &Infinispan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "example-infinispan",
+			Namespace:   namespace,
+			Annotations: map[string]string{TargetAnnotations: "team", "team": "data"},
+		},
+		Spec: InfinispanSpec{
+			Service: InfinispanServiceSpec{
+				Labels:      map[string]string{"tier": "internal", "app": "override"},
+				Annotations: map[string]string{"prometheus.io/scrape": "true"},
+			},
+			Expose: &ExposeSpec{
+				Type:        ExposeTypeLoadBalancer,
+				Labels:      map[string]string{"tier": "external"},
+				Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-type": "nlb"},
+			},
+		},
+	}
+
+	labels := ispn.InternalServiceLabels("infinispan-service")
+	assert.Equal(t, "internal", labels["tier"])
+	// Labels used as selectors cannot be overridden
+	assert.Equal(t, "infinispan-service", labels["app"])
+	assert.Equal(t, map[string]string{"team": "data", "prometheus.io/scrape": "true"}, ispn.InternalServiceAnnotations())
+
+	assert.Equal(t, "external", ispn.ExternalServiceLabels()["tier"])
+	assert.Equal(t, "infinispan-service-external", ispn.ExternalServiceLabels()["app"])
+	assert.Equal(t, map[string]string{"team": "data", "service.beta.kubernetes.io/aws-load-balancer-type": "nlb"}, ispn.ExternalServiceAnnotations())
+}
+
+func TestExternalDNSAnnotations(t *testing.T) {
+	ispn := &Infinispan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example-infinispan",
+			Namespace: namespace,
+		},
+		Spec: InfinispanSpec{
+			Expose: &ExposeSpec{
+				Type: ExposeTypeLoadBalancer,
+				Annotations: map[string]string{
+					consts.ExternalDNSHostnameAnnotation: "ignored.example.com",
+				},
+				ExternalDNS: &ExternalDNSSpec{
+					Hostnames: []string{"infinispan.example.com", "cache.example.com"},
+					TTL:       60,
+				},
+			},
+		},
+	}
+
+	annotations := ispn.ExternalServiceAnnotations()
+	assert.Equal(t, "infinispan.example.com,cache.example.com", annotations[consts.ExternalDNSHostnameAnnotation])
+	assert.Equal(t, "60", annotations[consts.ExternalDNSTTLAnnotation])
+	assert.Empty(t, ispn.InternalServiceAnnotations()[consts.ExternalDNSHostnameAnnotation])
+
+	ispn.Spec.Expose.ExternalDNS.TTL = 0
+	assert.NotContains(t, ispn.ExternalServiceAnnotations(), consts.ExternalDNSTTLAnnotation)
+}
+
+func TestExternalClientIntelligence(t *testing.T) {
+	ispn := &Infinispan{}
+	assert.Empty(t, ispn.ExternalClientIntelligence())
+
+	ispn.Spec.Expose = &ExposeSpec{Type: ExposeTypeNodePort}
+	assert.Equal(t, ClientIntelligenceBasic, ispn.ExternalClientIntelligence())
+
+	ispn.Spec.Expose.ClientIntelligence = ClientIntelligenceHashDistributionAware
+	assert.Equal(t, ClientIntelligenceHashDistributionAware, ispn.ExternalClientIntelligence())
+
+	// Per-pod Services make the topology reachable
+	ispn.Spec.Expose = &ExposeSpec{Type: ExposeTypeLoadBalancer, PerPod: true}
+	assert.Equal(t, ClientIntelligenceHashDistributionAware, ispn.ExternalClientIntelligence())
+
+	// The Gateway expose type only routes HTTP traffic
+	ispn.Spec.Expose.Type = ExposeTypeGateway
+	assert.Empty(t, ispn.ExternalClientIntelligence())
+}
+
+func TestCapacityFactorForZone(t *testing.T) {
+	ispn := &Infinispan{}
+	assert.False(t, ispn.IsCapacityFactorEnabled())
+	assert.Equal(t, "1", ispn.CapacityFactorForZone("zone-a"))
+
+	ispn.Spec.CapacityFactor = &CapacityFactorSpec{
+		Zones: []ZoneCapacityFactor{{Zone: "zone-a", Factor: "2"}},
+	}
+	assert.True(t, ispn.IsCapacityFactorEnabled())
+	assert.Equal(t, corev1.LabelZoneFailureDomainStable, ispn.CapacityFactorTopologyKey())
+	assert.Equal(t, "2", ispn.CapacityFactorForZone("zone-a"))
+	assert.Equal(t, "1", ispn.CapacityFactorForZone("zone-b"))
+
+	ispn.Spec.CapacityFactor.Default = "0.5"
+	assert.Equal(t, "0.5", ispn.CapacityFactorForZone("zone-b"))
+	assert.Equal(t, "0.5", ispn.CapacityFactorForZone(""))
+}
+
+func TestImagePullPolicy(t *testing.T) {
+	testTable := []struct {
+		Image          string
+		PullPolicy     corev1.PullPolicy
+		ExpectedPolicy corev1.PullPolicy
+	}{
+		{"quay.io/infinispan/server:13.0", corev1.PullNever, corev1.PullNever},
+		{"quay.io/infinispan/server:13.0", "", corev1.PullIfNotPresent},
+		{"quay.io/infinispan/server:latest", "", corev1.PullAlways},
+		{"quay.io/infinispan/server", "", corev1.PullAlways},
+		{"registry:5000/infinispan/server", "", corev1.PullAlways},
+		{"registry:5000/infinispan/server:13.0", "", corev1.PullIfNotPresent},
+		{"quay.io/infinispan/server@sha256:b7dd9d8ca49e6c6ad5e8a8c5b7e1b5c3a1d9e8b0b8f3c5a2e1d0c9b8a7f6e5d4", "", corev1.PullIfNotPresent},
+	}
+	for _, testItem := range testTable {
+		ispn := &Infinispan{Spec: InfinispanSpec{Image: &testItem.Image, ImagePullPolicy: testItem.PullPolicy}}
+		assert.Equal(t, testItem.ExpectedPolicy, ispn.ImagePullPolicy(), testItem.Image)
+	}
+}
+
+func TestGetJavaOptions(t *testing.T) {
+	testTable := []struct {
+		name             string
+		memory           string
+		maxRamPercentage *int32
+		gcPolicy         GCPolicyType
+		extraJvmOpts     string
+		expected         string
+	}{
+		{"extra options only", "1Gi", nil, "", "-Dfoo=bar", "-Dfoo=bar"},
+		{"heap derived from limit", "1Gi", pointer.Int32Ptr(50), "", "", "-Xmx512M"},
+		{"heap derived from limit with request", "2Gi:1Gi", pointer.Int32Ptr(75), "", "-Dfoo=bar", "-Xmx1536M -Dfoo=bar"},
+		{"gc policy", "1Gi", nil, GCPolicyG1, "-Dfoo=bar", "-XX:+UseG1GC -Dfoo=bar"},
+		{"experimental gc policy", "1Gi", pointer.Int32Ptr(50), GCPolicyZGC, "", "-Xmx512M -XX:+UnlockExperimentalVMOptions -XX:+UseZGC"},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ispn := &Infinispan{
+				Spec: InfinispanSpec{
+					Service:   InfinispanServiceSpec{Type: ServiceTypeDataGrid},
+					Container: InfinispanContainerSpec{Memory: tt.memory, MaxRamPercentage: tt.maxRamPercentage, GCPolicy: tt.gcPolicy, ExtraJvmOpts: tt.extraJvmOpts},
+				},
+			}
+			assert.Equal(t, tt.expected, ispn.GetJavaOptions())
+		})
+	}
+}
+
+func TestGetJavaOptionsDiagnostics(t *testing.T) {
+	ispn := &Infinispan{
+		Spec: InfinispanSpec{
+			Service: InfinispanServiceSpec{Type: ServiceTypeDataGrid},
+			Container: InfinispanContainerSpec{
+				ExtraJvmOpts: "-Dfoo=bar",
+				Diagnostics:  &InfinispanContainerDiagnosticsSpec{GCLogging: true, FlightRecorder: true, FlightRecorderMaxSize: "1G"},
+			},
+		},
+	}
+	assert.Equal(t, "-Xlog:gc*:file=/opt/infinispan/server/data/gc.log:time,uptime,level,tags:filecount=5,filesize=20M "+
+		"-XX:StartFlightRecording=name=continuous,disk=true,dumponexit=true,maxsize=1G,filename=/opt/infinispan/server/data/continuous.jfr -Dfoo=bar",
+		ispn.GetJavaOptions())
+
+	ispn.Spec.Container.Diagnostics = &InfinispanContainerDiagnosticsSpec{GCLogging: true, GCLogFileCount: pointer.Int32Ptr(2), GCLogFileSize: "5M"}
+	ispn.Spec.Container.ServerRoot = "/server"
+	assert.Equal(t, "-Xlog:gc*:file=/server/data/gc.log:time,uptime,level,tags:filecount=2,filesize=5M -Dfoo=bar", ispn.GetJavaOptions())
+
+	ispn.Spec.Container.Diagnostics = &InfinispanContainerDiagnosticsSpec{HeapDumpOnOutOfMemoryError: true}
+	assert.Equal(t, "-XX:+HeapDumpOnOutOfMemoryError -XX:HeapDumpPath=/server/data/java_oom.hprof -Dfoo=bar", ispn.GetJavaOptions())
+}
+
+func TestGetJavaOptionsNativeImage(t *testing.T) {
+	ispn := &Infinispan{
+		Spec: InfinispanSpec{
+			Image:     pointer.StringPtr("quay.io/infinispan/server-native:13.0"),
+			Service:   InfinispanServiceSpec{Type: ServiceTypeDataGrid},
+			Container: InfinispanContainerSpec{GCPolicy: GCPolicySerial},
+		},
+	}
+	assert.Equal(t, "", ispn.GetJavaOptions(), "GC flags are not passed to native images")
+}
+
+func TestGetJavaOptionsIPv6(t *testing.T) {
+	ispn := &Infinispan{
+		Spec: InfinispanSpec{
+			IPFamily:  corev1.IPv6Protocol,
+			Service:   InfinispanServiceSpec{Type: ServiceTypeDataGrid},
+			Container: InfinispanContainerSpec{ExtraJvmOpts: "-Dfoo=bar"},
+		},
+	}
+	assert.Equal(t, "-Djava.net.preferIPv6Addresses=true -Dfoo=bar", ispn.GetJavaOptions())
+
+	ispn.Spec.Service.Type = ServiceTypeCache
+	assert.True(t, strings.HasSuffix(ispn.GetJavaOptions(), "-Djava.net.preferIPv6Addresses=true -Dfoo=bar"))
+
+	ispn.Spec.IPFamily = corev1.IPv4Protocol
+	assert.NotContains(t, ispn.GetJavaOptions(), "preferIPv6Addresses")
+}
+
+func TestGetServiceIPFamilies(t *testing.T) {
+	ispn := &Infinispan{}
+	assert.Equal(t, IPFamilyPolicyPreferDualStack, ispn.GetIPFamilyPolicy())
+	assert.Equal(t, []corev1.IPFamily{corev1.IPv4Protocol}, ispn.GetServiceIPFamilies())
+
+	ispn.Spec.IPFamily = corev1.IPv6Protocol
+	assert.Equal(t, []corev1.IPFamily{corev1.IPv6Protocol}, ispn.GetServiceIPFamilies())
+
+	ispn.Spec.IPFamilyPolicy = IPFamilyPolicyRequireDualStack
+	assert.Equal(t, []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}, ispn.GetServiceIPFamilies())
+
+	ispn.Spec.IPFamilyPolicy = IPFamilyPolicySingleStack
+	assert.Equal(t, IPFamilyPolicySingleStack, ispn.GetIPFamilyPolicy())
+	assert.Equal(t, []corev1.IPFamily{corev1.IPv6Protocol}, ispn.GetServiceIPFamilies())
+}
+
+func TestGetJavaOptionsKerberos(t *testing.T) {
+	ispn := &Infinispan{
+		Spec: InfinispanSpec{
+			Security: InfinispanSecurity{
+				EndpointKerberos: &EndpointKerberos{Principal: "hotrod/host@EXAMPLE.COM", KeytabSecretName: "keytab"},
+			},
+			Service:   InfinispanServiceSpec{Type: ServiceTypeDataGrid},
+			Container: InfinispanContainerSpec{ExtraJvmOpts: "-Dfoo=bar"},
+		},
+	}
+	assert.Equal(t, "-Djava.security.krb5.conf=/etc/security/kerberos/krb5.conf -Dfoo=bar", ispn.GetJavaOptions())
+
+	ispn.Spec.Service.Type = ServiceTypeCache
+	assert.True(t, strings.HasSuffix(ispn.GetJavaOptions(), "-Djava.security.krb5.conf=/etc/security/kerberos/krb5.conf -Dfoo=bar"))
+
+	// Kerberos is only enabled with user authentication
+	ispn.Spec.Security.EndpointAuthentication = pointer.BoolPtr(false)
+	assert.NotContains(t, ispn.GetJavaOptions(), "krb5.conf")
+}
+
+func TestOperationLock(t *testing.T) {
+	ispn := &Infinispan{}
+	assert.Nil(t, ispn.AcquireOperationLock(OperationBackup, "example-backup"))
+	assert.True(t, ispn.HoldsOperationLock(OperationBackup, "example-backup"))
+	assert.Nil(t, ispn.AcquireOperationLock(OperationBackup, "example-backup"), "lock is reentrant")
+
+	conflict := ispn.AcquireOperationLock(OperationBackup, "other-backup")
+	assert.NotNil(t, conflict)
+	assert.Equal(t, "Backup 'example-backup'", conflict.String())
+	assert.NotNil(t, ispn.AcquireOperationLock(OperationScaling, ""))
+	assert.NotNil(t, ispn.AcquireOperationLock(OperationUpgrade, ""))
+
+	assert.False(t, ispn.ReleaseOperationLock(OperationBackup, "other-backup"))
+	assert.True(t, ispn.ReleaseOperationLock(OperationBackup, "example-backup"))
+	assert.Nil(t, ispn.Status.OperationLock)
+
+	// Upgrades scale the cluster whilst holding the lock
+	assert.Nil(t, ispn.AcquireOperationLock(OperationUpgrade, ""))
+	assert.Nil(t, ispn.AcquireOperationLock(OperationScaling, ""))
+	assert.True(t, ispn.HoldsOperationLock(OperationUpgrade, ""))
+	assert.Equal(t, "Upgrade", ispn.AcquireOperationLock(OperationRestore, "example-restore").String())
+
+	// A new spec.replicas restarts the deadline of the scaling in progress
+	ispn.Status.OperationLock = &OperationLock{Operation: OperationScaling, AcquiredTime: metav1.NewTime(time.Now().Add(-time.Hour))}
+	assert.Nil(t, ispn.AcquireOperationLock(OperationScaling, ""))
+	assert.WithinDuration(t, time.Now(), ispn.Status.OperationLock.AcquiredTime.Time, time.Minute)
+}
+
+func TestIsServerRoleRequired(t *testing.T) {
+	ispn := &Infinispan{}
+	assert.False(t, ispn.IsServerRoleRequired())
+
+	ispn.Spec.ServiceAccountName = "workload-identity"
+	assert.True(t, ispn.IsServerRoleRequired())
+
+	ispn.Spec.AutomountServiceAccountToken = pointer.BoolPtr(true)
+	assert.True(t, ispn.IsServerRoleRequired())
+
+	ispn.Spec.AutomountServiceAccountToken = pointer.BoolPtr(false)
+	assert.False(t, ispn.IsServerRoleRequired())
+}
+
+func TestGetPodServiceAccountName(t *testing.T) {
+	ispn := &Infinispan{ObjectMeta: metav1.ObjectMeta{Name: "example"}}
+	assert.Empty(t, ispn.GetPodServiceAccountName())
+
+	// The sidecar reviews tokens with a projected token of the pod's ServiceAccount
+	ispn.Spec.Security.EndpointServiceAccount = &EndpointServiceAccount{}
+	assert.Equal(t, "example-token-review", ispn.GetPodServiceAccountName())
+
+	ispn.Spec.ServiceAccountName = "workload-identity"
+	assert.Equal(t, "workload-identity", ispn.GetPodServiceAccountName())
+}
+
+func TestPasswordAlgorithms(t *testing.T) {
+	ispn := &Infinispan{}
+	assert.Nil(t, ispn.GetPasswordAlgorithms())
+	assert.Nil(t, ispn.GetAdminPasswordAlgorithms())
+
+	ispn.Spec.Security.PasswordAlgorithms = []PasswordAlgorithm{PasswordAlgorithmScramSHA512}
+	assert.Equal(t, []string{"scram-sha-512"}, ispn.GetPasswordAlgorithms())
+	assert.Equal(t, []string{"scram-sha-512", "digest-md5"}, ispn.GetAdminPasswordAlgorithms())
+
+	ispn.Spec.Security.PasswordAlgorithms = []PasswordAlgorithm{PasswordAlgorithmDigestMD5, PasswordAlgorithmScramSHA256}
+	assert.Equal(t, []string{"digest-md5", "scram-sha-256"}, ispn.GetAdminPasswordAlgorithms())
+}
+
+func TestGossipRouterReplicas(t *testing.T) {
+	ispn := &Infinispan{
+		Spec: InfinispanSpec{
+			Replicas: 3,
+			Service: InfinispanServiceSpec{
+				Sites: &InfinispanSitesSpec{},
+			},
+		},
+	}
+	assert.Equal(t, int32(3), ispn.GossipRouterReplicas())
+
+	ispn.Spec.Service.Sites.Local.GossipRouter = &GossipRouterSpec{Replicas: pointer.Int32Ptr(2)}
+	assert.Equal(t, int32(2), ispn.GossipRouterReplicas())
+
+	ispn.Spec.Replicas = 0
+	assert.Equal(t, int32(0), ispn.GossipRouterReplicas())
+}
+
+func TestOperationGate(t *testing.T) {
+	ispn := &Infinispan{
+		ObjectMeta: metav1.ObjectMeta{Name: "example"},
+		Status: InfinispanStatus{
+			Conditions: []InfinispanCondition{
+				{Type: ConditionPrelimChecksPassed, Status: metav1.ConditionTrue},
+				{Type: ConditionWellFormed, Status: metav1.ConditionTrue},
+			},
+		},
+	}
+	reject, err := ispn.OperationGate()
+	assert.NoError(t, err)
+	assert.False(t, reject)
+
+	ispn.SetCondition(ConditionHotRodRollingUpgrade, metav1.ConditionTrue, "")
+	reject, err = ispn.OperationGate()
+	assert.False(t, reject)
+	assert.EqualError(t, err, "queued until Infinispan 'example' is healthy: key 'HotRodRollingUpgrade' has Status 'True'")
+
+	ispn.Spec.OperationGate = &OperationGateSpec{Policy: OperationGateReject}
+	reject, err = ispn.OperationGate()
+	assert.True(t, reject)
+	assert.EqualError(t, err, "rejected as Infinispan 'example' is not healthy: key 'HotRodRollingUpgrade' has Status 'True'")
+}
+
+func TestEndpointCertificateHostnames(t *testing.T) {
+	ispn := &Infinispan{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "ns"},
+		Spec: InfinispanSpec{
+			Security: InfinispanSecurity{
+				EndpointEncryption: &EndpointEncryption{Type: CertificateSourceTypeService},
+			},
+		},
+	}
+	ispn.ApplyEndpointEncryptionSettings("")
+	assert.True(t, ispn.IsEncryptionCertFromOperator())
+	assert.Equal(t, "example-cert-secret", ispn.GetKeystoreSecretName())
+	assert.Equal(t, []string{"example", "example.ns", "example.ns.svc", "example.ns.svc.cluster.local"}, ispn.EndpointCertificateHostnames())
+
+	ispn.Spec.Expose = &ExposeSpec{
+		Type:        ExposeTypeRoute,
+		Host:        "infinispan.example.com",
+		ExternalDNS: &ExternalDNSSpec{Hostnames: []string{"dns.example.com"}},
+	}
+	ispn.Spec.Endpoints = &InfinispanEndpointsSpec{
+		Console: &InfinispanConsoleSpec{Expose: &ConsoleExposeSpec{Type: ConsoleExposeTypeIngress, Host: "console.example.com"}},
+	}
+	assert.Equal(t, []string{"infinispan.example.com", "dns.example.com", "console.example.com"}, ispn.EndpointCertificateHostnames()[4:])
+
+	// The certificates of the OpenShift service CA are used if available
+	openshift := &Infinispan{ObjectMeta: metav1.ObjectMeta{Name: "example"}}
+	openshift.ApplyEndpointEncryptionSettings("openshift.io")
+	assert.True(t, openshift.IsEncryptionCertFromService())
+	assert.False(t, openshift.IsEncryptionCertFromOperator())
+}
@@ -0,0 +1,181 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// lastAppliedAnnotation holds the configuration applied by kubectl apply, including fields that were pruned by the
+// API server because they are not part of the CRD schema
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// UnknownField is a field of the applied configuration that is not part of the Infinispan schema
+// +kubebuilder:object:generate=false
+type UnknownField struct {
+	Path       *field.Path
+	Suggestion string
+}
+
+func (f UnknownField) String() string {
+	if f.Suggestion == "" {
+		return fmt.Sprintf("unknown field '%s'", f.Path)
+	}
+	return fmt.Sprintf("unknown field '%s', did you mean '%s'?", f.Path, f.Suggestion)
+}
+
+// IsStrictValidation returns true if CRs containing unknown fields are rejected. The operator's STRICT_VALIDATION is
+// overridden by the infinispan.org/strict-validation annotation of the CR
+func (ispn *Infinispan) IsStrictValidation() bool {
+	if strict, ok := ispn.Annotations[consts.AnnotationStrictValidation]; ok {
+		return strict == "true"
+	}
+	return consts.StrictValidation
+}
+
+// UnknownFields returns the spec fields of the last configuration applied with kubectl that are not part of the
+// Infinispan schema. The API server silently prunes unknown fields, so they are only visible in the applied configuration
+func (ispn *Infinispan) UnknownFields() []UnknownField {
+	applied := ispn.Annotations[lastAppliedAnnotation]
+	if applied == "" {
+		return nil
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(applied), &obj); err != nil {
+		return nil
+	}
+	spec, ok := obj["spec"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return unknownFields(spec, reflect.TypeOf(InfinispanSpec{}), field.NewPath("spec"))
+}
+
+func unknownFields(value interface{}, t reflect.Type, path *field.Path) []UnknownField {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// Types with custom decoding, e.g. resource.Quantity, are not described by their fields
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return nil
+	}
+
+	var unknown []UnknownField
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		fields := jsonFields(t)
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fieldType, known := fields[name]
+			if !known {
+				unknown = append(unknown, UnknownField{Path: path.Child(name), Suggestion: suggestField(name, fields, path)})
+				continue
+			}
+			unknown = append(unknown, unknownFields(obj[name], fieldType, path.Child(name))...)
+		}
+	case reflect.Slice:
+		if items, ok := value.([]interface{}); ok {
+			for idx, item := range items {
+				unknown = append(unknown, unknownFields(item, t.Elem(), path.Index(idx))...)
+			}
+		}
+	case reflect.Map:
+		if entries, ok := value.(map[string]interface{}); ok {
+			for key, entry := range entries {
+				unknown = append(unknown, unknownFields(entry, t.Elem(), path.Key(key))...)
+			}
+		}
+	}
+	return unknown
+}
+
+// jsonFields returns the types of the JSON fields of a struct, including the fields of inlined structs
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for idx := 0; idx < t.NumField(); idx++ {
+		f := t.Field(idx)
+		tag := f.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && (name == "" || strings.Contains(tag, ",inline")) {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			for n, ft := range jsonFields(embedded) {
+				fields[n] = ft
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// suggestField returns the path of the known field that is closest to a misspelled name, or an empty string if no
+// field is similar enough
+func suggestField(name string, fields map[string]reflect.Type, path *field.Path) string {
+	best, bestDistance := "", 3
+	lowerName := strings.ToLower(name)
+	for candidate := range fields {
+		lowerCandidate := strings.ToLower(candidate)
+		distance := levenshtein(lowerName, lowerCandidate)
+		if distance < bestDistance || (distance == bestDistance && best != "" && candidate < best) {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return path.Child(best).String()
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
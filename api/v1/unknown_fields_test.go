@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"testing"
+
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUnknownFields(t *testing.T) {
+	applied := `{"apiVersion":"infinispan.org/v1","kind":"Infinispan","spec":{
+		"replica": 2,
+		"service": {"type": "DataGrid", "container": {"storage": "2Gi", "storageClasName": "gp2"}},
+		"container": {"memory": "1Gi", "env": [{"name": "FOO", "value": "bar", "valueFrum": {}}]},
+		"expose": {"type": "LoadBalancer", "annotations": {"anything": "goes"}},
+		"affinity": {"podAntiAffinity": {}},
+		"unrelated": true
+	}}`
+	ispn := &Infinispan{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{lastAppliedAnnotation: applied}}}
+
+	var messages []string
+	for _, f := range ispn.UnknownFields() {
+		messages = append(messages, f.String())
+	}
+	assert.Equal(t, []string{
+		"unknown field 'spec.container.env[0].valueFrum', did you mean 'spec.container.env[0].valueFrom'?",
+		"unknown field 'spec.replica', did you mean 'spec.replicas'?",
+		"unknown field 'spec.service.container.storageClasName', did you mean 'spec.service.container.storageClassName'?",
+		"unknown field 'spec.unrelated'",
+	}, messages)
+
+	ispn.Annotations = nil
+	assert.Empty(t, ispn.UnknownFields(), "CRs not applied with kubectl are not checked")
+}
+
+func TestIsStrictValidation(t *testing.T) {
+	ispn := &Infinispan{}
+	assert.Equal(t, consts.StrictValidation, ispn.IsStrictValidation())
+	ispn.Annotations = map[string]string{consts.AnnotationStrictValidation: "true"}
+	assert.True(t, ispn.IsStrictValidation())
+	ispn.Annotations[consts.AnnotationStrictValidation] = "false"
+	assert.False(t, ispn.IsStrictValidation())
+}
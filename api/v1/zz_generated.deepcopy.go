@@ -6,9 +6,25 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLog) DeepCopyInto(out *AuditLog) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLog.
+func (in *AuditLog) DeepCopy() *AuditLog {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Authorization) DeepCopyInto(out *Authorization) {
 	*out = *in
@@ -67,141 +83,125 @@ func (in *Autoscale) DeepCopy() *Autoscale {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConfigListenerSpec) DeepCopyInto(out *ConfigListenerSpec) {
+func (in *AutostopSpec) DeepCopyInto(out *AutostopSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigListenerSpec.
-func (in *ConfigListenerSpec) DeepCopy() *ConfigListenerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutostopSpec.
+func (in *AutostopSpec) DeepCopy() *AutostopSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ConfigListenerSpec)
+	out := new(AutostopSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CrossSiteExposeSpec) DeepCopyInto(out *CrossSiteExposeSpec) {
+func (in *AutostopStatus) DeepCopyInto(out *AutostopStatus) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossSiteExposeSpec.
-func (in *CrossSiteExposeSpec) DeepCopy() *CrossSiteExposeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutostopStatus.
+func (in *AutostopStatus) DeepCopy() *AutostopStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CrossSiteExposeSpec)
+	out := new(AutostopStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CrossSiteKeyStore) DeepCopyInto(out *CrossSiteKeyStore) {
+func (in *BackupRecord) DeepCopyInto(out *BackupRecord) {
 	*out = *in
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossSiteKeyStore.
-func (in *CrossSiteKeyStore) DeepCopy() *CrossSiteKeyStore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRecord.
+func (in *BackupRecord) DeepCopy() *BackupRecord {
 	if in == nil {
 		return nil
 	}
-	out := new(CrossSiteKeyStore)
+	out := new(BackupRecord)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CrossSiteTrustStore) DeepCopyInto(out *CrossSiteTrustStore) {
+func (in *CapacityFactorSpec) DeepCopyInto(out *CapacityFactorSpec) {
 	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ZoneCapacityFactor, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossSiteTrustStore.
-func (in *CrossSiteTrustStore) DeepCopy() *CrossSiteTrustStore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityFactorSpec.
+func (in *CapacityFactorSpec) DeepCopy() *CapacityFactorSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CrossSiteTrustStore)
+	out := new(CapacityFactorSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentStatus) DeepCopyInto(out *DeploymentStatus) {
+func (in *ClientListenersSpec) DeepCopyInto(out *ClientListenersSpec) {
 	*out = *in
-	if in.Ready != nil {
-		in, out := &in.Ready, &out.Ready
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Starting != nil {
-		in, out := &in.Starting, &out.Starting
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Stopped != nil {
-		in, out := &in.Stopped, &out.Stopped
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatus.
-func (in *DeploymentStatus) DeepCopy() *DeploymentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientListenersSpec.
+func (in *ClientListenersSpec) DeepCopy() *ClientListenersSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentStatus)
+	out := new(ClientListenersSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EncryptionSiteSpec) DeepCopyInto(out *EncryptionSiteSpec) {
+func (in *ClusterActionStatus) DeepCopyInto(out *ClusterActionStatus) {
 	*out = *in
-	out.TransportKeyStore = in.TransportKeyStore
-	out.RouterKeyStore = in.RouterKeyStore
-	if in.TrustStore != nil {
-		in, out := &in.TrustStore, &out.TrustStore
-		*out = new(CrossSiteTrustStore)
-		**out = **in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = new(metav1.Time)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionSiteSpec.
-func (in *EncryptionSiteSpec) DeepCopy() *EncryptionSiteSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterActionStatus.
+func (in *ClusterActionStatus) DeepCopy() *ClusterActionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(EncryptionSiteSpec)
+	out := new(ClusterActionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EndpointEncryption) DeepCopyInto(out *EndpointEncryption) {
+func (in *ConfigListenerSpec) DeepCopyInto(out *ConfigListenerSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointEncryption.
-func (in *EndpointEncryption) DeepCopy() *EndpointEncryption {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigListenerSpec.
+func (in *ConfigListenerSpec) DeepCopy() *ConfigListenerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EndpointEncryption)
+	out := new(ConfigListenerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExposeSpec) DeepCopyInto(out *ExposeSpec) {
+func (in *ConsoleExposeSpec) DeepCopyInto(out *ConsoleExposeSpec) {
 	*out = *in
 	if in.Annotations != nil {
 		in, out := &in.Annotations, &out.Annotations
@@ -212,259 +212,910 @@ func (in *ExposeSpec) DeepCopyInto(out *ExposeSpec) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposeSpec.
-func (in *ExposeSpec) DeepCopy() *ExposeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsoleExposeSpec.
+func (in *ConsoleExposeSpec) DeepCopy() *ConsoleExposeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ExposeSpec)
+	out := new(ConsoleExposeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HotRodRollingUpgradeStatus) DeepCopyInto(out *HotRodRollingUpgradeStatus) {
+func (in *CredentialStoreEntry) DeepCopyInto(out *CredentialStoreEntry) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HotRodRollingUpgradeStatus.
-func (in *HotRodRollingUpgradeStatus) DeepCopy() *HotRodRollingUpgradeStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialStoreEntry.
+func (in *CredentialStoreEntry) DeepCopy() *CredentialStoreEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(HotRodRollingUpgradeStatus)
+	out := new(CredentialStoreEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Infinispan) DeepCopyInto(out *Infinispan) {
+func (in *CrossSiteExposeSpec) DeepCopyInto(out *CrossSiteExposeSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Infinispan.
-func (in *Infinispan) DeepCopy() *Infinispan {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossSiteExposeSpec.
+func (in *CrossSiteExposeSpec) DeepCopy() *CrossSiteExposeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Infinispan)
+	out := new(CrossSiteExposeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Infinispan) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfinispanCloudEvents) DeepCopyInto(out *InfinispanCloudEvents) {
+func (in *CrossSiteKeyStore) DeepCopyInto(out *CrossSiteKeyStore) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanCloudEvents.
-func (in *InfinispanCloudEvents) DeepCopy() *InfinispanCloudEvents {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossSiteKeyStore.
+func (in *CrossSiteKeyStore) DeepCopy() *CrossSiteKeyStore {
 	if in == nil {
 		return nil
 	}
-	out := new(InfinispanCloudEvents)
+	out := new(CrossSiteKeyStore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfinispanCondition) DeepCopyInto(out *InfinispanCondition) {
+func (in *CrossSiteStatus) DeepCopyInto(out *CrossSiteStatus) {
 	*out = *in
+	if in.View != nil {
+		in, out := &in.View, &out.View
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = make([]SiteBackupStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OfflineLocations != nil {
+		in, out := &in.OfflineLocations, &out.OfflineLocations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanCondition.
-func (in *InfinispanCondition) DeepCopy() *InfinispanCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossSiteStatus.
+func (in *CrossSiteStatus) DeepCopy() *CrossSiteStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(InfinispanCondition)
+	out := new(CrossSiteStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfinispanContainerSpec) DeepCopyInto(out *InfinispanContainerSpec) {
+func (in *CrossSiteTrustStore) DeepCopyInto(out *CrossSiteTrustStore) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanContainerSpec.
-func (in *InfinispanContainerSpec) DeepCopy() *InfinispanContainerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossSiteTrustStore.
+func (in *CrossSiteTrustStore) DeepCopy() *CrossSiteTrustStore {
 	if in == nil {
 		return nil
 	}
-	out := new(InfinispanContainerSpec)
+	out := new(CrossSiteTrustStore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfinispanExternalArtifacts) DeepCopyInto(out *InfinispanExternalArtifacts) {
+func (in *DataMigrationSpec) DeepCopyInto(out *DataMigrationSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanExternalArtifacts.
-func (in *InfinispanExternalArtifacts) DeepCopy() *InfinispanExternalArtifacts {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataMigrationSpec.
+func (in *DataMigrationSpec) DeepCopy() *DataMigrationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InfinispanExternalArtifacts)
+	out := new(DataMigrationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfinispanExternalDependencies) DeepCopyInto(out *InfinispanExternalDependencies) {
+func (in *DeploymentStatus) DeepCopyInto(out *DeploymentStatus) {
 	*out = *in
-	if in.Artifacts != nil {
-		in, out := &in.Artifacts, &out.Artifacts
-		*out = make([]InfinispanExternalArtifacts, len(*in))
+	if in.Ready != nil {
+		in, out := &in.Ready, &out.Ready
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Starting != nil {
+		in, out := &in.Starting, &out.Starting
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Stopped != nil {
+		in, out := &in.Stopped, &out.Stopped
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanExternalDependencies.
-func (in *InfinispanExternalDependencies) DeepCopy() *InfinispanExternalDependencies {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatus.
+func (in *DeploymentStatus) DeepCopy() *DeploymentStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(InfinispanExternalDependencies)
+	out := new(DeploymentStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfinispanList) DeepCopyInto(out *InfinispanList) {
+func (in *DisasterRecoveryStatus) DeepCopyInto(out *DisasterRecoveryStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Infinispan, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.LastBackup != nil {
+		in, out := &in.LastBackup, &out.LastBackup
+		*out = new(BackupRecord)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastRestore != nil {
+		in, out := &in.LastRestore, &out.LastRestore
+		*out = new(RestoreRecord)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanList.
-func (in *InfinispanList) DeepCopy() *InfinispanList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisasterRecoveryStatus.
+func (in *DisasterRecoveryStatus) DeepCopy() *DisasterRecoveryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(InfinispanList)
+	out := new(DisasterRecoveryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InfinispanList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfinispanLoggingSpec) DeepCopyInto(out *InfinispanLoggingSpec) {
+func (in *DriftDetectionSpec) DeepCopyInto(out *DriftDetectionSpec) {
 	*out = *in
-	if in.Categories != nil {
-		in, out := &in.Categories, &out.Categories
-		*out = make(map[string]LoggingLevelType, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanLoggingSpec.
-func (in *InfinispanLoggingSpec) DeepCopy() *InfinispanLoggingSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftDetectionSpec.
+func (in *DriftDetectionSpec) DeepCopy() *DriftDetectionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InfinispanLoggingSpec)
+	out := new(DriftDetectionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfinispanSecurity) DeepCopyInto(out *InfinispanSecurity) {
+func (in *EncryptionSiteSpec) DeepCopyInto(out *EncryptionSiteSpec) {
 	*out = *in
-	if in.Authorization != nil {
-		in, out := &in.Authorization, &out.Authorization
-		*out = new(Authorization)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.EndpointAuthentication != nil {
-		in, out := &in.EndpointAuthentication, &out.EndpointAuthentication
-		*out = new(bool)
-		**out = **in
-	}
-	if in.EndpointEncryption != nil {
-		in, out := &in.EndpointEncryption, &out.EndpointEncryption
-		*out = new(EndpointEncryption)
+	out.TransportKeyStore = in.TransportKeyStore
+	out.RouterKeyStore = in.RouterKeyStore
+	if in.TrustStore != nil {
+		in, out := &in.TrustStore, &out.TrustStore
+		*out = new(CrossSiteTrustStore)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanSecurity.
-func (in *InfinispanSecurity) DeepCopy() *InfinispanSecurity {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionSiteSpec.
+func (in *EncryptionSiteSpec) DeepCopy() *EncryptionSiteSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InfinispanSecurity)
+	out := new(EncryptionSiteSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfinispanServiceContainerSpec) DeepCopyInto(out *InfinispanServiceContainerSpec) {
+func (in *EndpointEncryption) DeepCopyInto(out *EndpointEncryption) {
 	*out = *in
-	if in.Storage != nil {
-		in, out := &in.Storage, &out.Storage
-		*out = new(string)
+	if in.Keystore != nil {
+		in, out := &in.Keystore, &out.Keystore
+		*out = new(EndpointKeystore)
 		**out = **in
 	}
+	if in.Protocols != nil {
+		in, out := &in.Protocols, &out.Protocols
+		*out = make([]TLSProtocol, len(*in))
+		copy(*out, *in)
+	}
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanServiceContainerSpec.
-func (in *InfinispanServiceContainerSpec) DeepCopy() *InfinispanServiceContainerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointEncryption.
+func (in *EndpointEncryption) DeepCopy() *EndpointEncryption {
 	if in == nil {
 		return nil
 	}
-	out := new(InfinispanServiceContainerSpec)
+	out := new(EndpointEncryption)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfinispanServiceSpec) DeepCopyInto(out *InfinispanServiceSpec) {
+func (in *EndpointKerberos) DeepCopyInto(out *EndpointKerberos) {
 	*out = *in
-	if in.Container != nil {
-		in, out := &in.Container, &out.Container
-		*out = new(InfinispanServiceContainerSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Sites != nil {
-		in, out := &in.Sites, &out.Sites
-		*out = new(InfinispanSitesSpec)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanServiceSpec.
-func (in *InfinispanServiceSpec) DeepCopy() *InfinispanServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointKerberos.
+func (in *EndpointKerberos) DeepCopy() *EndpointKerberos {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointKerberos)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointKeystore) DeepCopyInto(out *EndpointKeystore) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointKeystore.
+func (in *EndpointKeystore) DeepCopy() *EndpointKeystore {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointKeystore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointOIDC) DeepCopyInto(out *EndpointOIDC) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointOIDC.
+func (in *EndpointOIDC) DeepCopy() *EndpointOIDC {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointOIDC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointServiceAccount) DeepCopyInto(out *EndpointServiceAccount) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointServiceAccount.
+func (in *EndpointServiceAccount) DeepCopy() *EndpointServiceAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointServiceAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposeEndpointSpec) DeepCopyInto(out *ExposeEndpointSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposeEndpointSpec.
+func (in *ExposeEndpointSpec) DeepCopy() *ExposeEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposeEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposeEndpointsSpec) DeepCopyInto(out *ExposeEndpointsSpec) {
+	*out = *in
+	if in.HotRod != nil {
+		in, out := &in.HotRod, &out.HotRod
+		*out = new(ExposeEndpointSpec)
+		**out = **in
+	}
+	if in.Rest != nil {
+		in, out := &in.Rest, &out.Rest
+		*out = new(ExposeEndpointSpec)
+		**out = **in
+	}
+	if in.Admin != nil {
+		in, out := &in.Admin, &out.Admin
+		*out = new(ExposeEndpointSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposeEndpointsSpec.
+func (in *ExposeEndpointsSpec) DeepCopy() *ExposeEndpointsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposeEndpointsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposeSpec) DeepCopyInto(out *ExposeSpec) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = new(ExposeEndpointsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExternalDNS != nil {
+		in, out := &in.ExternalDNS, &out.ExternalDNS
+		*out = new(ExternalDNSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposeSpec.
+func (in *ExposeSpec) DeepCopy() *ExposeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSSpec) DeepCopyInto(out *ExternalDNSSpec) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSSpec.
+func (in *ExternalDNSSpec) DeepCopy() *ExternalDNSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GossipRouterSpec) DeepCopyInto(out *GossipRouterSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GossipRouterSpec.
+func (in *GossipRouterSpec) DeepCopy() *GossipRouterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GossipRouterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HotRodRollingUpgradeStatus) DeepCopyInto(out *HotRodRollingUpgradeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HotRodRollingUpgradeStatus.
+func (in *HotRodRollingUpgradeStatus) DeepCopy() *HotRodRollingUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HotRodRollingUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Infinispan) DeepCopyInto(out *Infinispan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Infinispan.
+func (in *Infinispan) DeepCopy() *Infinispan {
+	if in == nil {
+		return nil
+	}
+	out := new(Infinispan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Infinispan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanCloudEvents) DeepCopyInto(out *InfinispanCloudEvents) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanCloudEvents.
+func (in *InfinispanCloudEvents) DeepCopy() *InfinispanCloudEvents {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanCloudEvents)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanCondition) DeepCopyInto(out *InfinispanCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanCondition.
+func (in *InfinispanCondition) DeepCopy() *InfinispanCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanConsoleSpec) DeepCopyInto(out *InfinispanConsoleSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(ConsoleExposeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanConsoleSpec.
+func (in *InfinispanConsoleSpec) DeepCopy() *InfinispanConsoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanConsoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanContainerDiagnosticsSpec) DeepCopyInto(out *InfinispanContainerDiagnosticsSpec) {
+	*out = *in
+	if in.GCLogFileCount != nil {
+		in, out := &in.GCLogFileCount, &out.GCLogFileCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanContainerDiagnosticsSpec.
+func (in *InfinispanContainerDiagnosticsSpec) DeepCopy() *InfinispanContainerDiagnosticsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanContainerDiagnosticsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanContainerSpec) DeepCopyInto(out *InfinispanContainerSpec) {
+	*out = *in
+	if in.MaxRamPercentage != nil {
+		in, out := &in.MaxRamPercentage, &out.MaxRamPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Diagnostics != nil {
+		in, out := &in.Diagnostics, &out.Diagnostics
+		*out = new(InfinispanContainerDiagnosticsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanContainerSpec.
+func (in *InfinispanContainerSpec) DeepCopy() *InfinispanContainerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanContainerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanDiscoverySpec) DeepCopyInto(out *InfinispanDiscoverySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanDiscoverySpec.
+func (in *InfinispanDiscoverySpec) DeepCopy() *InfinispanDiscoverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanDiscoverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanEndpointSpec) DeepCopyInto(out *InfinispanEndpointSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanEndpointSpec.
+func (in *InfinispanEndpointSpec) DeepCopy() *InfinispanEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanEndpointsSpec) DeepCopyInto(out *InfinispanEndpointsSpec) {
+	*out = *in
+	if in.HotRod != nil {
+		in, out := &in.HotRod, &out.HotRod
+		*out = new(InfinispanEndpointSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rest != nil {
+		in, out := &in.Rest, &out.Rest
+		*out = new(InfinispanEndpointSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Console != nil {
+		in, out := &in.Console, &out.Console
+		*out = new(InfinispanConsoleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientListeners != nil {
+		in, out := &in.ClientListeners, &out.ClientListeners
+		*out = new(ClientListenersSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanEndpointsSpec.
+func (in *InfinispanEndpointsSpec) DeepCopy() *InfinispanEndpointsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanEndpointsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanExternalArtifacts) DeepCopyInto(out *InfinispanExternalArtifacts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanExternalArtifacts.
+func (in *InfinispanExternalArtifacts) DeepCopy() *InfinispanExternalArtifacts {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanExternalArtifacts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanExternalDependencies) DeepCopyInto(out *InfinispanExternalDependencies) {
+	*out = *in
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]InfinispanExternalArtifacts, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanExternalDependencies.
+func (in *InfinispanExternalDependencies) DeepCopy() *InfinispanExternalDependencies {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanExternalDependencies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanJmxSpec) DeepCopyInto(out *InfinispanJmxSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanJmxSpec.
+func (in *InfinispanJmxSpec) DeepCopy() *InfinispanJmxSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanJmxSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanList) DeepCopyInto(out *InfinispanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Infinispan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanList.
+func (in *InfinispanList) DeepCopy() *InfinispanList {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InfinispanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanLoggingSpec) DeepCopyInto(out *InfinispanLoggingSpec) {
+	*out = *in
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make(map[string]LoggingLevelType, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanLoggingSpec.
+func (in *InfinispanLoggingSpec) DeepCopy() *InfinispanLoggingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanLoggingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanSecurity) DeepCopyInto(out *InfinispanSecurity) {
+	*out = *in
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(Authorization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EndpointAuthentication != nil {
+		in, out := &in.EndpointAuthentication, &out.EndpointAuthentication
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EndpointEncryption != nil {
+		in, out := &in.EndpointEncryption, &out.EndpointEncryption
+		*out = new(EndpointEncryption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EndpointOIDC != nil {
+		in, out := &in.EndpointOIDC, &out.EndpointOIDC
+		*out = new(EndpointOIDC)
+		**out = **in
+	}
+	if in.EndpointKerberos != nil {
+		in, out := &in.EndpointKerberos, &out.EndpointKerberos
+		*out = new(EndpointKerberos)
+		**out = **in
+	}
+	if in.EndpointServiceAccount != nil {
+		in, out := &in.EndpointServiceAccount, &out.EndpointServiceAccount
+		*out = new(EndpointServiceAccount)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(Vault)
+		**out = **in
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerSecurityContext != nil {
+		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuditLog != nil {
+		in, out := &in.AuditLog, &out.AuditLog
+		*out = new(AuditLog)
+		**out = **in
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = make([]CredentialStoreEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.PasswordAlgorithms != nil {
+		in, out := &in.PasswordAlgorithms, &out.PasswordAlgorithms
+		*out = make([]PasswordAlgorithm, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanSecurity.
+func (in *InfinispanSecurity) DeepCopy() *InfinispanSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanServiceContainerSpec) DeepCopyInto(out *InfinispanServiceContainerSpec) {
+	*out = *in
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanServiceContainerSpec.
+func (in *InfinispanServiceContainerSpec) DeepCopy() *InfinispanServiceContainerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanServiceContainerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanServiceSpec) DeepCopyInto(out *InfinispanServiceSpec) {
+	*out = *in
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(InfinispanServiceContainerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sites != nil {
+		in, out := &in.Sites, &out.Sites
+		*out = new(InfinispanSitesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Discovery != nil {
+		in, out := &in.Discovery, &out.Discovery
+		*out = new(InfinispanDiscoverySpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanServiceSpec.
+func (in *InfinispanServiceSpec) DeepCopy() *InfinispanServiceSpec {
 	if in == nil {
 		return nil
 	}
@@ -474,184 +1125,750 @@ func (in *InfinispanServiceSpec) DeepCopy() *InfinispanServiceSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfinispanSiteLocationSpec) DeepCopyInto(out *InfinispanSiteLocationSpec) {
+func (in *InfinispanSiteLocationSpec) DeepCopyInto(out *InfinispanSiteLocationSpec) {
+	*out = *in
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanSiteLocationSpec.
+func (in *InfinispanSiteLocationSpec) DeepCopy() *InfinispanSiteLocationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanSiteLocationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanSitesLocalSpec) DeepCopyInto(out *InfinispanSitesLocalSpec) {
+	*out = *in
+	in.Expose.DeepCopyInto(&out.Expose)
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(EncryptionSiteSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GossipRouter != nil {
+		in, out := &in.GossipRouter, &out.GossipRouter
+		*out = new(GossipRouterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanSitesLocalSpec.
+func (in *InfinispanSitesLocalSpec) DeepCopy() *InfinispanSitesLocalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanSitesLocalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanSitesSpec) DeepCopyInto(out *InfinispanSitesSpec) {
+	*out = *in
+	in.Local.DeepCopyInto(&out.Local)
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]InfinispanSiteLocationSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanSitesSpec.
+func (in *InfinispanSitesSpec) DeepCopy() *InfinispanSitesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanSitesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanSpec) DeepCopyInto(out *InfinispanSpec) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutomountServiceAccountToken != nil {
+		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
+	in.Security.DeepCopyInto(&out.Security)
+	in.Container.DeepCopyInto(&out.Container)
+	in.Service.DeepCopyInto(&out.Service)
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(InfinispanLoggingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(ExposeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autoscale != nil {
+		in, out := &in.Autoscale, &out.Autoscale
+		*out = new(Autoscale)
+		**out = **in
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudEvents != nil {
+		in, out := &in.CloudEvents, &out.CloudEvents
+		*out = new(InfinispanCloudEvents)
+		**out = **in
+	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = new(InfinispanExternalDependencies)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrades != nil {
+		in, out := &in.Upgrades, &out.Upgrades
+		*out = new(InfinispanUpgradesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigListener != nil {
+		in, out := &in.ConfigListener, &out.ConfigListener
+		*out = new(ConfigListenerSpec)
+		**out = **in
+	}
+	if in.IntegrityCheck != nil {
+		in, out := &in.IntegrityCheck, &out.IntegrityCheck
+		*out = new(IntegrityCheckSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Jmx != nil {
+		in, out := &in.Jmx, &out.Jmx
+		*out = new(InfinispanJmxSpec)
+		**out = **in
+	}
+	if in.Recommendations != nil {
+		in, out := &in.Recommendations, &out.Recommendations
+		*out = new(RecommendationsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageMonitoring != nil {
+		in, out := &in.StorageMonitoring, &out.StorageMonitoring
+		*out = new(StorageMonitoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autostop != nil {
+		in, out := &in.Autostop, &out.Autostop
+		*out = new(AutostopSpec)
+		**out = **in
+	}
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(DriftDetectionSpec)
+		**out = **in
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = new(InfinispanEndpointsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceMesh != nil {
+		in, out := &in.ServiceMesh, &out.ServiceMesh
+		*out = new(ServiceMeshSpec)
+		**out = **in
+	}
+	if in.CapacityFactor != nil {
+		in, out := &in.CapacityFactor, &out.CapacityFactor
+		*out = new(CapacityFactorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShadowReplication != nil {
+		in, out := &in.ShadowReplication, &out.ShadowReplication
+		*out = new(ShadowReplicationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OperationGate != nil {
+		in, out := &in.OperationGate, &out.OperationGate
+		*out = new(OperationGateSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanSpec.
+func (in *InfinispanSpec) DeepCopy() *InfinispanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanStatus) DeepCopyInto(out *InfinispanStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]InfinispanCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(InfinispanSecurity)
+		(*in).DeepCopyInto(*out)
+	}
+	in.PodStatus.DeepCopyInto(&out.PodStatus)
+	if in.ConsoleUrl != nil {
+		in, out := &in.ConsoleUrl, &out.ConsoleUrl
+		*out = new(string)
+		**out = **in
+	}
+	if in.PublicAddresses != nil {
+		in, out := &in.PublicAddresses, &out.PublicAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodPublicAddresses != nil {
+		in, out := &in.PodPublicAddresses, &out.PodPublicAddresses
+		*out = make([]PodPublicAddress, len(*in))
+		copy(*out, *in)
+	}
+	if in.HotRodRollingUpgradeStatus != nil {
+		in, out := &in.HotRodRollingUpgradeStatus, &out.HotRodRollingUpgradeStatus
+		*out = new(HotRodRollingUpgradeStatus)
+		**out = **in
+	}
+	if in.IntegrityCheck != nil {
+		in, out := &in.IntegrityCheck, &out.IntegrityCheck
+		*out = new(IntegrityCheckStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Recommendations != nil {
+		in, out := &in.Recommendations, &out.Recommendations
+		*out = new(RecommendationsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autostop != nil {
+		in, out := &in.Autostop, &out.Autostop
+		*out = new(AutostopStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisasterRecovery != nil {
+		in, out := &in.DisasterRecovery, &out.DisasterRecovery
+		*out = new(DisasterRecoveryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OperationLock != nil {
+		in, out := &in.OperationLock, &out.OperationLock
+		*out = new(OperationLock)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(ClusterActionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShadowReplication != nil {
+		in, out := &in.ShadowReplication, &out.ShadowReplication
+		*out = new(ShadowReplicationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sites != nil {
+		in, out := &in.Sites, &out.Sites
+		*out = new(CrossSiteStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.XSiteStateTransfer != nil {
+		in, out := &in.XSiteStateTransfer, &out.XSiteStateTransfer
+		*out = new(XSiteStateTransferStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanStatus.
+func (in *InfinispanStatus) DeepCopy() *InfinispanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanUpgradesSpec) DeepCopyInto(out *InfinispanUpgradesSpec) {
+	*out = *in
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DataMigration != nil {
+		in, out := &in.DataMigration, &out.DataMigration
+		*out = new(DataMigrationSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanUpgradesSpec.
+func (in *InfinispanUpgradesSpec) DeepCopy() *InfinispanUpgradesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanUpgradesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrityCheckSpec) DeepCopyInto(out *IntegrityCheckSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SampleSize != nil {
+		in, out := &in.SampleSize, &out.SampleSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Caches != nil {
+		in, out := &in.Caches, &out.Caches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrityCheckSpec.
+func (in *IntegrityCheckSpec) DeepCopy() *IntegrityCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrityCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrityCheckStatus) DeepCopyInto(out *IntegrityCheckStatus) {
+	*out = *in
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrityCheckStatus.
+func (in *IntegrityCheckStatus) DeepCopy() *IntegrityCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrityCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationGateSpec) DeepCopyInto(out *OperationGateSpec) {
 	*out = *in
-	if in.Host != nil {
-		in, out := &in.Host, &out.Host
-		*out = new(string)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationGateSpec.
+func (in *OperationGateSpec) DeepCopy() *OperationGateSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Port != nil {
-		in, out := &in.Port, &out.Port
-		*out = new(int32)
+	out := new(OperationGateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationLock) DeepCopyInto(out *OperationLock) {
+	*out = *in
+	in.AcquiredTime.DeepCopyInto(&out.AcquiredTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationLock.
+func (in *OperationLock) DeepCopy() *OperationLock {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationLock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodPublicAddress) DeepCopyInto(out *PodPublicAddress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodPublicAddress.
+func (in *PodPublicAddress) DeepCopy() *PodPublicAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(PodPublicAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Recommendation) DeepCopyInto(out *Recommendation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Recommendation.
+func (in *Recommendation) DeepCopy() *Recommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(Recommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecommendationsSpec) DeepCopyInto(out *RecommendationsSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanSiteLocationSpec.
-func (in *InfinispanSiteLocationSpec) DeepCopy() *InfinispanSiteLocationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecommendationsSpec.
+func (in *RecommendationsSpec) DeepCopy() *RecommendationsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InfinispanSiteLocationSpec)
+	out := new(RecommendationsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfinispanSitesLocalSpec) DeepCopyInto(out *InfinispanSitesLocalSpec) {
+func (in *RecommendationsStatus) DeepCopyInto(out *RecommendationsStatus) {
 	*out = *in
-	in.Expose.DeepCopyInto(&out.Expose)
-	if in.Encryption != nil {
-		in, out := &in.Encryption, &out.Encryption
-		*out = new(EncryptionSiteSpec)
-		(*in).DeepCopyInto(*out)
+	in.LastEvaluationTime.DeepCopyInto(&out.LastEvaluationTime)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Recommendation, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanSitesLocalSpec.
-func (in *InfinispanSitesLocalSpec) DeepCopy() *InfinispanSitesLocalSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecommendationsStatus.
+func (in *RecommendationsStatus) DeepCopy() *RecommendationsStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(InfinispanSitesLocalSpec)
+	out := new(RecommendationsStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfinispanSitesSpec) DeepCopyInto(out *InfinispanSitesSpec) {
+func (in *RestoreRecord) DeepCopyInto(out *RestoreRecord) {
 	*out = *in
-	in.Local.DeepCopyInto(&out.Local)
-	if in.Locations != nil {
-		in, out := &in.Locations, &out.Locations
-		*out = make([]InfinispanSiteLocationSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreRecord.
+func (in *RestoreRecord) DeepCopy() *RestoreRecord {
+	if in == nil {
+		return nil
 	}
+	out := new(RestoreRecord)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanSitesSpec.
-func (in *InfinispanSitesSpec) DeepCopy() *InfinispanSitesSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMeshSpec) DeepCopyInto(out *ServiceMeshSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMeshSpec.
+func (in *ServiceMeshSpec) DeepCopy() *ServiceMeshSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InfinispanSitesSpec)
+	out := new(ServiceMeshSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfinispanSpec) DeepCopyInto(out *InfinispanSpec) {
+func (in *ShadowCacheStatus) DeepCopyInto(out *ShadowCacheStatus) {
 	*out = *in
-	if in.Image != nil {
-		in, out := &in.Image, &out.Image
-		*out = new(string)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShadowCacheStatus.
+func (in *ShadowCacheStatus) DeepCopy() *ShadowCacheStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ShadowCacheStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShadowReplicationSpec) DeepCopyInto(out *ShadowReplicationSpec) {
+	*out = *in
+	if in.Caches != nil {
+		in, out := &in.Caches, &out.Caches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LagCheckInterval != nil {
+		in, out := &in.LagCheckInterval, &out.LagCheckInterval
+		*out = new(metav1.Duration)
 		**out = **in
 	}
-	in.Security.DeepCopyInto(&out.Security)
-	out.Container = in.Container
-	in.Service.DeepCopyInto(&out.Service)
-	if in.Logging != nil {
-		in, out := &in.Logging, &out.Logging
-		*out = new(InfinispanLoggingSpec)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShadowReplicationSpec.
+func (in *ShadowReplicationSpec) DeepCopy() *ShadowReplicationSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Expose != nil {
-		in, out := &in.Expose, &out.Expose
-		*out = new(ExposeSpec)
+	out := new(ShadowReplicationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShadowReplicationStatus) DeepCopyInto(out *ShadowReplicationStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = new(metav1.Time)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Autoscale != nil {
-		in, out := &in.Autoscale, &out.Autoscale
-		*out = new(Autoscale)
-		**out = **in
+	if in.Caches != nil {
+		in, out := &in.Caches, &out.Caches
+		*out = make([]ShadowCacheStatus, len(*in))
+		copy(*out, *in)
 	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(corev1.Affinity)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShadowReplicationStatus.
+func (in *ShadowReplicationStatus) DeepCopy() *ShadowReplicationStatus {
+	if in == nil {
+		return nil
 	}
-	if in.CloudEvents != nil {
-		in, out := &in.CloudEvents, &out.CloudEvents
-		*out = new(InfinispanCloudEvents)
+	out := new(ShadowReplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SiteBackupStatus) DeepCopyInto(out *SiteBackupStatus) {
+	*out = *in
+	if in.OfflineCaches != nil {
+		in, out := &in.OfflineCaches, &out.OfflineCaches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SiteBackupStatus.
+func (in *SiteBackupStatus) DeepCopy() *SiteBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SiteBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageAutoExpandSpec) DeepCopyInto(out *StorageAutoExpandSpec) {
+	*out = *in
+	if in.Increment != nil {
+		in, out := &in.Increment, &out.Increment
+		*out = new(int32)
 		**out = **in
 	}
-	if in.Dependencies != nil {
-		in, out := &in.Dependencies, &out.Dependencies
-		*out = new(InfinispanExternalDependencies)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageAutoExpandSpec.
+func (in *StorageAutoExpandSpec) DeepCopy() *StorageAutoExpandSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Upgrades != nil {
-		in, out := &in.Upgrades, &out.Upgrades
-		*out = new(InfinispanUpgradesSpec)
+	out := new(StorageAutoExpandSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageMonitoringSpec) DeepCopyInto(out *StorageMonitoringSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
 		**out = **in
 	}
-	if in.ConfigListener != nil {
-		in, out := &in.ConfigListener, &out.ConfigListener
-		*out = new(ConfigListenerSpec)
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(int32)
 		**out = **in
 	}
+	if in.AutoExpand != nil {
+		in, out := &in.AutoExpand, &out.AutoExpand
+		*out = new(StorageAutoExpandSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanSpec.
-func (in *InfinispanSpec) DeepCopy() *InfinispanSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMonitoringSpec.
+func (in *StorageMonitoringSpec) DeepCopy() *StorageMonitoringSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InfinispanSpec)
+	out := new(StorageMonitoringSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfinispanStatus) DeepCopyInto(out *InfinispanStatus) {
+func (in *StorageStatus) DeepCopyInto(out *StorageStatus) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]InfinispanCondition, len(*in))
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]VolumeUsage, len(*in))
 		copy(*out, *in)
 	}
-	if in.Security != nil {
-		in, out := &in.Security, &out.Security
-		*out = new(InfinispanSecurity)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageStatus.
+func (in *StorageStatus) DeepCopy() *StorageStatus {
+	if in == nil {
+		return nil
 	}
-	in.PodStatus.DeepCopyInto(&out.PodStatus)
-	if in.ConsoleUrl != nil {
-		in, out := &in.ConsoleUrl, &out.ConsoleUrl
-		*out = new(string)
-		**out = **in
+	out := new(StorageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Vault) DeepCopyInto(out *Vault) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vault.
+func (in *Vault) DeepCopy() *Vault {
+	if in == nil {
+		return nil
 	}
-	if in.HotRodRollingUpgradeStatus != nil {
-		in, out := &in.HotRodRollingUpgradeStatus, &out.HotRodRollingUpgradeStatus
-		*out = new(HotRodRollingUpgradeStatus)
-		**out = **in
+	out := new(Vault)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeUsage) DeepCopyInto(out *VolumeUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeUsage.
+func (in *VolumeUsage) DeepCopy() *VolumeUsage {
+	if in == nil {
+		return nil
 	}
+	out := new(VolumeUsage)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanStatus.
-func (in *InfinispanStatus) DeepCopy() *InfinispanStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XSiteCacheStateTransfer) DeepCopyInto(out *XSiteCacheStateTransfer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XSiteCacheStateTransfer.
+func (in *XSiteCacheStateTransfer) DeepCopy() *XSiteCacheStateTransfer {
 	if in == nil {
 		return nil
 	}
-	out := new(InfinispanStatus)
+	out := new(XSiteCacheStateTransfer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfinispanUpgradesSpec) DeepCopyInto(out *InfinispanUpgradesSpec) {
+func (in *XSiteStateTransferStatus) DeepCopyInto(out *XSiteStateTransferStatus) {
+	*out = *in
+	if in.Caches != nil {
+		in, out := &in.Caches, &out.Caches
+		*out = make([]XSiteCacheStateTransfer, len(*in))
+		copy(*out, *in)
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = new(metav1.Time)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XSiteStateTransferStatus.
+func (in *XSiteStateTransferStatus) DeepCopy() *XSiteStateTransferStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(XSiteStateTransferStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneCapacityFactor) DeepCopyInto(out *ZoneCapacityFactor) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanUpgradesSpec.
-func (in *InfinispanUpgradesSpec) DeepCopy() *InfinispanUpgradesSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneCapacityFactor.
+func (in *ZoneCapacityFactor) DeepCopy() *ZoneCapacityFactor {
 	if in == nil {
 		return nil
 	}
-	out := new(InfinispanUpgradesSpec)
+	out := new(ZoneCapacityFactor)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -77,6 +77,9 @@ type BackupStatus struct {
 	// The name of the created PersistentVolumeClaim used to store the backup
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Persistent Volume Claim"
 	PVC string `json:"pvc,omitempty"`
+	// Conditions of the backup operation
+	// +optional
+	Conditions []OperationCondition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -3,6 +3,7 @@ package v2alpha1
 import (
 	"reflect"
 
+	v1 "github.com/infinispan/infinispan-operator/api/v1"
 	"github.com/infinispan/infinispan-operator/controllers/constants"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -53,6 +54,7 @@ func (b *Backup) ValidateCreate() error {
 	if b.Spec.Cluster == "" {
 		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("cluster"), "'spec.cluster' must be configured"))
 	}
+	allErrs = append(allErrs, v1.ValidateContainerEnv(b.Spec.Container.Env, field.NewPath("spec").Child("container").Child("env"))...)
 	return b.StatusError(allErrs)
 }
 
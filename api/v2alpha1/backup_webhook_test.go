@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	// +kubebuilder:scaffold:imports
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -87,6 +88,32 @@ var _ = Describe("Backup Webhook", func() {
 			expectInvalidErrStatus(err, statusDetailCause{metav1.CauseTypeFieldValueRequired, "spec.cluster", "'spec.cluster' must be configured"})
 		})
 
+		It("Should reject env variables configured by the operator", func() {
+
+			rejected := &Backup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: BackupSpec{
+					Cluster: "some-cluster",
+					Container: v1.InfinispanContainerSpec{
+						Env: []corev1.EnvVar{
+							{Name: "JAVA_OPTIONS", Value: "-Xmx1g"},
+							{Name: "CUSTOM", Value: "a"},
+							{Name: "CUSTOM", Value: "b"},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, rejected)
+			expectInvalidErrStatus(err, []statusDetailCause{
+				{"FieldValueForbidden", "spec.container.env[0].name", "configured by the operator"},
+				{"FieldValueDuplicate", "spec.container.env[2].name", "Duplicate value"},
+			}...)
+		})
+
 		It("Should return error if any spec value is updated", func() {
 
 			created := &Backup{
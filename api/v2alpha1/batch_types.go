@@ -45,6 +45,9 @@ type BatchStatus struct {
 	// The UUID of the Infinispan instance that the Batch is associated with
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cluster UUID"
 	ClusterUID *types.UID `json:"clusterUID,omitempty"`
+	// Conditions of the batch operation
+	// +optional
+	Conditions []OperationCondition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
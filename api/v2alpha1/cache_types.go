@@ -12,6 +12,12 @@ type CacheConditionType string
 
 const (
 	CacheConditionReady CacheConditionType = "Ready"
+	// CacheConditionClusterHealthGatePassed is false whilst the cache is not reconciled because the Infinispan cluster
+	// is not healthy, with the message reporting whether the Cache is queued or was rejected
+	CacheConditionClusterHealthGatePassed CacheConditionType = "ClusterHealthGatePassed"
+	// CacheConditionTemplateValid is false whilst the servers reject the template of spec.template or
+	// spec.templateFrom, with the message reporting the error of the server
+	CacheConditionTemplateValid CacheConditionType = "TemplateValid"
 )
 
 // AdminAuth description of the auth info
@@ -38,12 +44,76 @@ type CacheSpec struct {
 	// Name of the cache to be created. If empty ObjectMeta.Name will be used
 	// +optional
 	Name string `json:"name,omitempty"`
-	// Cache template in XML format
+	// Cache template in XML, JSON or YAML format. The variables $(CLUSTER_NAME), $(NAMESPACE) and $(CACHE_NAME) are
+	// replaced with the values of the Cache CR
 	// +optional
 	Template string `json:"template,omitempty"`
-	// Name of the template to be used to create this cache
+	// Cache template loaded from a ConfigMap or Secret key in the namespace of the Cache CR. Template variables are
+	// replaced in the same way as spec.template
+	// +optional
+	TemplateFrom *CacheTemplateSource `json:"templateFrom,omitempty"`
+	// Name of the template to be used to create this cache, e.g. one of the session-cache, reference-data or
+	// write-heavy templates that the operator installs on every cluster
 	// +optional
 	TemplateName string `json:"templateName,omitempty"`
+	// How changes to the template of an existing cache are applied
+	// +optional
+	Updates *CacheUpdateSpec `json:"updates,omitempty"`
+	// The media types used to store the keys and values of the cache. Added to spec.template, or used to create a
+	// distributed cache if no template is configured
+	// +optional
+	Encoding *CacheEncodingSpec `json:"encoding,omitempty"`
+	// The authorization of the cache. Added to spec.template, or used to create a distributed cache if no template is
+	// configured
+	// +optional
+	Security *CacheSecuritySpec `json:"security,omitempty"`
+}
+
+// CacheTemplateSource references the key of a ConfigMap or Secret that contains a cache template
+type CacheTemplateSource struct {
+	// The ConfigMap key containing the template
+	// +optional
+	ConfigMapKeyRef *v1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// The Secret key containing the template
+	// +optional
+	SecretKeyRef *v1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// CacheEncodingSpec configures the encoding of the cache entries, which must match the marshaller of the clients
+type CacheEncodingSpec struct {
+	// The encoding of the keys
+	// +optional
+	Key *CacheMediaTypeSpec `json:"key,omitempty"`
+	// The encoding of the values
+	// +optional
+	Value *CacheMediaTypeSpec `json:"value,omitempty"`
+}
+
+// CacheMediaTypeSpec defines the media type of cache keys or values
+type CacheMediaTypeSpec struct {
+	// The media type, for example application/x-protostream or application/x-java-object;type=java.lang.String
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Media Type",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	MediaType string `json:"mediaType"`
+}
+
+// CacheSecuritySpec configures the authorization of a cache
+type CacheSecuritySpec struct {
+	// The roles that can access the cache. Users must have one of the roles, with the permission required by the
+	// operation, for example a role with the READ permission to read entries
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Authorization Roles",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Roles []string `json:"roles"`
+}
+
+// CacheUpdateSpec configures how template changes are applied to an existing cache
+type CacheUpdateSpec struct {
+	// Delete and recreate the cache when the template changes attributes that cannot be updated at runtime
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Recreate on immutable change",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	RecreateOnImmutableChange bool `json:"recreateOnImmutableChange,omitempty"`
+	// Copy the cache entries to a temporary cache before the cache is recreated and restore them afterwards
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Migrate data on recreate",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	MigrateData bool `json:"migrateData,omitempty"`
 }
 
 // CacheCondition define a condition of the cluster
@@ -57,11 +127,46 @@ type CacheCondition struct {
 	Message string `json:"message,omitempty"`
 }
 
+// CacheDryRunOperation the operation that reconciling the Cache CR would perform on the server
+type CacheDryRunOperation string
+
+const (
+	// CacheDryRunCreate the cache does not exist and would be created
+	CacheDryRunCreate CacheDryRunOperation = "Create"
+	// CacheDryRunUpdate the configuration would be updated at runtime
+	CacheDryRunUpdate CacheDryRunOperation = "Update"
+	// CacheDryRunRecreate the configuration changes immutable attributes, so the cache would be recreated as
+	// spec.updates.recreateOnImmutableChange is true
+	CacheDryRunRecreate CacheDryRunOperation = "Recreate"
+	// CacheDryRunNone the cache already has the configuration of the Cache CR
+	CacheDryRunNone CacheDryRunOperation = "None"
+	// CacheDryRunRejected the server would reject the configuration or update of the Cache CR
+	CacheDryRunRejected CacheDryRunOperation = "Rejected"
+)
+
+// CacheDryRunStatus the result of reconciling the Cache CR without modifying the server
+type CacheDryRunStatus struct {
+	// The generation of the Cache CR that was evaluated
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// The operation that the server would perform
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Dry Run Operation"
+	Operation CacheDryRunOperation `json:"operation"`
+	// The configuration that the server would receive, converted to JSON and normalized by the server
+	// +optional
+	Configuration string `json:"configuration,omitempty"`
+	// Human-readable message with the details of the operation
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // CacheStatus defines the observed state of Cache
 type CacheStatus struct {
 	// Conditions list for this cache
 	// +optional
 	Conditions []CacheCondition `json:"conditions,omitempty"`
+	// The result of the dry run requested with the infinispan.org/dry-run annotation
+	// +optional
+	DryRun *CacheDryRunStatus `json:"dryRun,omitempty"`
 	// Deprecated. This is no longer set. Service name that exposes the cache inside the cluster
 	// +optional
 	ServiceName string `json:"serviceName,omitempty"`
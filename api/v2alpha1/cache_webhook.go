@@ -1,7 +1,17 @@
 package v2alpha1
 
 import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	stdmime "mime"
+	"regexp"
+	"strings"
+
 	"github.com/go-logr/logr"
+	"github.com/infinispan/infinispan-operator/pkg/mime"
+	"gopkg.in/yaml.v2"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -36,22 +46,171 @@ var _ webhook.Validator = &Cache{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (c *Cache) ValidateCreate() error {
-	return c.validate()
+	return c.validate(nil)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (c *Cache) ValidateUpdate(old runtime.Object) error {
-	return c.validate()
+	return c.validate(old.(*Cache))
 }
 
-func (c *Cache) validate() error {
+func (c *Cache) validate(old *Cache) error {
 	var allErrs field.ErrorList
 	if c.Spec.ClusterName == "" {
 		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("clusterName"), "'spec.clusterName' must be configured"))
 	}
+	if c.Spec.TemplateFrom != nil {
+		allErrs = append(allErrs, c.validateTemplateFrom()...)
+	}
+	if c.Spec.Encoding != nil {
+		allErrs = append(allErrs, c.validateEncoding()...)
+	}
+	if c.Spec.Security != nil {
+		allErrs = append(allErrs, c.validateSecurity()...)
+	}
+	// The template is only validated when it changes, so that updates of the metadata do not require the cluster
+	if strings.TrimSpace(c.Spec.Template) != "" && (old == nil || old.Spec.Template != c.Spec.Template) {
+		allErrs = append(allErrs, c.validateTemplate()...)
+	}
 	return c.StatusError(allErrs)
 }
 
+// validateTemplate verifies that spec.template is well-formed XML, JSON or YAML. Whether the servers accept the cache
+// configuration is reported by the TemplateValid condition of the Cache controller
+func (c *Cache) validateTemplate() field.ErrorList {
+	path := field.NewPath("spec").Child("template")
+	template := strings.TrimSpace(c.Spec.Template)
+	markup := mime.GuessMarkup(template)
+	if err := wellFormed(template, markup); err != nil {
+		return field.ErrorList{field.Invalid(path, string(markup), fmt.Sprintf("template is not well-formed: %v", err))}
+	}
+	return nil
+}
+
+// wellFormed returns an error if the template cannot be parsed as the given markup
+func wellFormed(template string, markup mime.MimeType) error {
+	switch markup {
+	case mime.ApplicationXml:
+		decoder := xml.NewDecoder(strings.NewReader(template))
+		for {
+			if _, err := decoder.Token(); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+		}
+	case mime.ApplicationJson:
+		var config interface{}
+		return json.Unmarshal([]byte(template), &config)
+	default:
+		var config interface{}
+		return yaml.Unmarshal([]byte(template), &config)
+	}
+}
+
+func (c *Cache) validateTemplateFrom() field.ErrorList {
+	var allErrs field.ErrorList
+	path := field.NewPath("spec").Child("templateFrom")
+	source := c.Spec.TemplateFrom
+	if c.Spec.Template != "" || c.Spec.TemplateName != "" {
+		allErrs = append(allErrs, field.Forbidden(path, "field cannot be combined with 'spec.template' or 'spec.templateName'"))
+	}
+	switch {
+	case source.ConfigMapKeyRef == nil && source.SecretKeyRef == nil:
+		allErrs = append(allErrs, field.Required(path, "one of 'configMapKeyRef' or 'secretKeyRef' must be configured"))
+	case source.ConfigMapKeyRef != nil && source.SecretKeyRef != nil:
+		allErrs = append(allErrs, field.Forbidden(path, "only one of 'configMapKeyRef' or 'secretKeyRef' can be configured"))
+	case source.ConfigMapKeyRef != nil:
+		allErrs = append(allErrs, validateKeyRef(path.Child("configMapKeyRef"), source.ConfigMapKeyRef.Name, source.ConfigMapKeyRef.Key)...)
+	default:
+		allErrs = append(allErrs, validateKeyRef(path.Child("secretKeyRef"), source.SecretKeyRef.Name, source.SecretKeyRef.Key)...)
+	}
+	return allErrs
+}
+
+func validateKeyRef(path *field.Path, name, key string) field.ErrorList {
+	var allErrs field.ErrorList
+	if name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("name"), "the name of the resource must be configured"))
+	}
+	if key == "" {
+		allErrs = append(allErrs, field.Required(path.Child("key"), "the key of the template must be configured"))
+	}
+	return allErrs
+}
+
+// templateEncoding matches an encoding element in XML, JSON or YAML cache templates
+var templateEncoding = regexp.MustCompile(`<encoding[\s>/]|"encoding"\s*:|(?m)^\s*encoding\s*:`)
+
+func (c *Cache) validateEncoding() field.ErrorList {
+	var allErrs field.ErrorList
+	encodingPath := field.NewPath("spec").Child("encoding")
+	encoding := c.Spec.Encoding
+	if encoding.Key == nil && encoding.Value == nil {
+		allErrs = append(allErrs, field.Required(encodingPath, "the media type of the keys or values must be configured"))
+	}
+	if c.Spec.TemplateName != "" {
+		allErrs = append(allErrs, field.Forbidden(encodingPath, "field cannot be combined with 'spec.templateName'"))
+	}
+	if templateEncoding.MatchString(c.Spec.Template) {
+		allErrs = append(allErrs, field.Forbidden(encodingPath, "field cannot be combined with an encoding configured in 'spec.template'"))
+	}
+	if encoding.Key != nil {
+		if err := validateMediaType(encoding.Key.MediaType); err != nil {
+			allErrs = append(allErrs, field.Invalid(encodingPath.Child("key").Child("mediaType"), encoding.Key.MediaType, err.Error()))
+		}
+	}
+	if encoding.Value != nil {
+		if err := validateMediaType(encoding.Value.MediaType); err != nil {
+			allErrs = append(allErrs, field.Invalid(encodingPath.Child("value").Child("mediaType"), encoding.Value.MediaType, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// templateAuthorization matches an authorization element in XML, JSON or YAML cache templates
+var templateAuthorization = regexp.MustCompile(`<authorization[\s>/]|"authorization"\s*:|(?m)^\s*authorization\s*:`)
+
+func (c *Cache) validateSecurity() field.ErrorList {
+	var allErrs field.ErrorList
+	securityPath := field.NewPath("spec").Child("security")
+	if len(c.Spec.Security.Roles) == 0 {
+		allErrs = append(allErrs, field.Required(securityPath.Child("roles"), "at least one role must be configured"))
+	}
+	if c.Spec.TemplateName != "" {
+		allErrs = append(allErrs, field.Forbidden(securityPath, "field cannot be combined with 'spec.templateName'"))
+	}
+	if templateAuthorization.MatchString(c.Spec.Template) {
+		allErrs = append(allErrs, field.Forbidden(securityPath, "field cannot be combined with an authorization configured in 'spec.template'"))
+	}
+	roles := map[string]bool{}
+	for idx, role := range c.Spec.Security.Roles {
+		rolePath := securityPath.Child("roles").Index(idx)
+		if role == "" || strings.ContainsAny(role, " \t\n,") {
+			allErrs = append(allErrs, field.Invalid(rolePath, role, "role must not be empty or contain whitespace or commas"))
+		} else if roles[role] {
+			allErrs = append(allErrs, field.Duplicate(rolePath, role))
+		}
+		roles[role] = true
+	}
+	return allErrs
+}
+
+func validateMediaType(mediaType string) error {
+	base, _, err := stdmime.ParseMediaType(mediaType)
+	if err != nil {
+		return err
+	}
+	var supported []string
+	for _, m := range mime.EncodingMediaTypes {
+		if base == string(m) {
+			return nil
+		}
+		supported = append(supported, string(m))
+	}
+	return fmt.Errorf("unsupported media type, must be one of %s", strings.Join(supported, ", "))
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (c *Cache) ValidateDelete() error {
 	// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
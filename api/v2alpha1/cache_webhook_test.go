@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	// +kubebuilder:scaffold:imports
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -87,5 +88,124 @@ var _ = Describe("Cache Webhook", func() {
 			err := k8sClient.Create(ctx, rejected)
 			expectInvalidErrStatus(err, statusDetailCause{metav1.CauseTypeFieldValueRequired, "spec.clusterName", "'spec.clusterName' must be configured"})
 		})
+
+		It("Should reject invalid template sources", func() {
+
+			rejected := &Cache{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: CacheSpec{
+					ClusterName:  "some-cluster",
+					Template:     "<distributed-cache/>",
+					TemplateFrom: &CacheTemplateSource{},
+				},
+			}
+
+			err := k8sClient.Create(ctx, rejected)
+			expectInvalidErrStatus(err,
+				statusDetailCause{"FieldValueForbidden", "spec.templateFrom", "field cannot be combined with 'spec.template' or 'spec.templateName'"},
+				statusDetailCause{metav1.CauseTypeFieldValueRequired, "spec.templateFrom", "one of 'configMapKeyRef' or 'secretKeyRef' must be configured"},
+			)
+
+			rejected.Spec.Template = ""
+			rejected.Spec.TemplateFrom.ConfigMapKeyRef = &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cache-templates"}}
+			err = k8sClient.Create(ctx, rejected)
+			expectInvalidErrStatus(err, statusDetailCause{metav1.CauseTypeFieldValueRequired, "spec.templateFrom.configMapKeyRef.key", "the key of the template must be configured"})
+
+			rejected.Spec.TemplateFrom.ConfigMapKeyRef.Key = "cache.xml"
+			Expect(k8sClient.Create(ctx, rejected)).Should(Succeed())
+		})
+
+		It("Should reject invalid encoding", func() {
+
+			rejected := &Cache{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: CacheSpec{
+					ClusterName:  "some-cluster",
+					TemplateName: "org.infinispan.DIST_SYNC",
+					Encoding: &CacheEncodingSpec{
+						Key: &CacheMediaTypeSpec{MediaType: "application/x-unknown-format"},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, rejected)
+			expectInvalidErrStatus(err,
+				statusDetailCause{"FieldValueForbidden", "spec.encoding", "field cannot be combined with 'spec.templateName'"},
+				statusDetailCause{metav1.CauseTypeFieldValueInvalid, "spec.encoding.key.mediaType", "unsupported media type"},
+			)
+
+			rejected.Spec.TemplateName = ""
+			rejected.Spec.Template = `<distributed-cache><encoding media-type="application/x-protostream"/></distributed-cache>`
+			rejected.Spec.Encoding.Key.MediaType = "application/x-protostream"
+			err = k8sClient.Create(ctx, rejected)
+			expectInvalidErrStatus(err, statusDetailCause{"FieldValueForbidden", "spec.encoding", "field cannot be combined with an encoding configured in 'spec.template'"})
+		})
+
+		It("Should reject invalid security", func() {
+
+			rejected := &Cache{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: CacheSpec{
+					ClusterName:  "some-cluster",
+					TemplateName: "org.infinispan.DIST_SYNC",
+					Security: &CacheSecuritySpec{
+						Roles: []string{"admin", "app users", "admin"},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, rejected)
+			expectInvalidErrStatus(err,
+				statusDetailCause{"FieldValueForbidden", "spec.security", "field cannot be combined with 'spec.templateName'"},
+				statusDetailCause{metav1.CauseTypeFieldValueInvalid, "spec.security.roles[1]", "must not be empty or contain whitespace"},
+				statusDetailCause{metav1.CauseTypeFieldValueDuplicate, "spec.security.roles[2]", ""},
+			)
+
+			rejected.Spec.TemplateName = ""
+			rejected.Spec.Template = `<distributed-cache><security><authorization roles="admin"/></security></distributed-cache>`
+			rejected.Spec.Security.Roles = []string{"admin"}
+			err = k8sClient.Create(ctx, rejected)
+			expectInvalidErrStatus(err, statusDetailCause{"FieldValueForbidden", "spec.security", "field cannot be combined with an authorization configured in 'spec.template'"})
+
+			rejected.Spec.Template = ""
+			Expect(k8sClient.Create(ctx, rejected)).Should(Succeed())
+		})
+
+		It("Should reject templates that are not well-formed", func() {
+
+			rejected := &Cache{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: CacheSpec{
+					ClusterName: "some-cluster",
+					Template:    `<distributed-cache mode="SYNC"><encoding media-type="application/x-protostream"/>`,
+				},
+			}
+
+			err := k8sClient.Create(ctx, rejected)
+			expectInvalidErrStatus(err, statusDetailCause{metav1.CauseTypeFieldValueInvalid, "spec.template", "template is not well-formed"})
+
+			rejected.Spec.Template = `{"distributed-cache": {"mode": "SYNC",}}`
+			err = k8sClient.Create(ctx, rejected)
+			expectInvalidErrStatus(err, statusDetailCause{metav1.CauseTypeFieldValueInvalid, "spec.template", "template is not well-formed"})
+
+			rejected.Spec.Template = "distributedCache:\n  mode: \"SYNC\"\n owners: 2"
+			err = k8sClient.Create(ctx, rejected)
+			expectInvalidErrStatus(err, statusDetailCause{metav1.CauseTypeFieldValueInvalid, "spec.template", "template is not well-formed"})
+
+			rejected.Spec.Template = "distributedCache:\n  mode: \"SYNC\"\n  owners: 2"
+			Expect(k8sClient.Create(ctx, rejected)).Should(Succeed())
+		})
 	})
 })
@@ -0,0 +1,40 @@
+package v2alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OperationConditionType the conditions of Backup, Restore and Batch operations
+type OperationConditionType string
+
+const (
+	// OperationConditionClusterHealthGatePassed is true once the Infinispan cluster was healthy when the operation was
+	// started. Whilst false, the message reports whether the operation is queued or was rejected
+	OperationConditionClusterHealthGatePassed OperationConditionType = "ClusterHealthGatePassed"
+)
+
+// OperationCondition define a condition of the operation
+type OperationCondition struct {
+	// Type is the type of the condition.
+	Type OperationConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status metav1.ConditionStatus `json:"status"`
+	// Human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// setOperationCondition sets the status and message of the condition, returning true if the condition changed
+func setOperationCondition(conditions *[]OperationCondition, condition OperationConditionType, status metav1.ConditionStatus, message string) bool {
+	for idx := range *conditions {
+		c := &(*conditions)[idx]
+		if c.Type == condition {
+			changed := c.Status != status || c.Message != message
+			c.Status = status
+			c.Message = message
+			return changed
+		}
+	}
+	*conditions = append(*conditions, OperationCondition{Type: condition, Status: status, Message: message})
+	return true
+}
@@ -66,6 +66,9 @@ type RestoreStatus struct {
 	// Reason indicates the reason for any restore related failures.
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Reason"
 	Reason string `json:"reason,omitempty"`
+	// Conditions of the restore operation
+	// +optional
+	Conditions []OperationCondition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
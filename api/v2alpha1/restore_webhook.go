@@ -3,6 +3,7 @@ package v2alpha1
 import (
 	"reflect"
 
+	v1 "github.com/infinispan/infinispan-operator/api/v1"
 	"github.com/infinispan/infinispan-operator/controllers/constants"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -56,6 +57,7 @@ func (b *Restore) ValidateCreate() error {
 	if b.Spec.Backup == "" {
 		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("backup"), "'spec.backup' must be configured"))
 	}
+	allErrs = append(allErrs, v1.ValidateContainerEnv(b.Spec.Container.Env, field.NewPath("spec").Child("container").Child("env"))...)
 	return b.StatusError(allErrs)
 }
 
@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	// +kubebuilder:scaffold:imports
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -91,6 +92,33 @@ var _ = Describe("Restore Webhook", func() {
 			}...)
 		})
 
+		It("Should reject env variables configured by the operator", func() {
+
+			rejected := &Restore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: RestoreSpec{
+					Cluster: "some-cluster",
+					Backup:  "some-backup",
+					Container: v1.InfinispanContainerSpec{
+						Env: []corev1.EnvVar{
+							{Name: "JAVA_OPTIONS", Value: "-Xmx1g"},
+							{Name: "CUSTOM", Value: "a"},
+							{Name: "CUSTOM", Value: "b"},
+						},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, rejected)
+			expectInvalidErrStatus(err, []statusDetailCause{
+				{"FieldValueForbidden", "spec.container.env[0].name", "configured by the operator"},
+				{"FieldValueDuplicate", "spec.container.env[2].name", "Duplicate value"},
+			}...)
+		})
+
 		It("Should return error if any spec value is updated", func() {
 
 			created := &Restore{
@@ -39,3 +39,18 @@ func (b *Batch) ConfigMapName() string {
 	}
 	return b.Name
 }
+
+// SetCondition set condition to status
+func (b *Backup) SetCondition(condition OperationConditionType, status metav1.ConditionStatus, message string) bool {
+	return setOperationCondition(&b.Status.Conditions, condition, status, message)
+}
+
+// SetCondition set condition to status
+func (r *Restore) SetCondition(condition OperationConditionType, status metav1.ConditionStatus, message string) bool {
+	return setOperationCondition(&r.Status.Conditions, condition, status, message)
+}
+
+// SetCondition set condition to status
+func (b *Batch) SetCondition(condition OperationConditionType, status metav1.ConditionStatus, message string) bool {
+	return setOperationCondition(&b.Status.Conditions, condition, status, message)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 
+	v1 "github.com/infinispan/infinispan-operator/api/v1"
 	"github.com/infinispan/infinispan-operator/api/v2alpha1"
 	"github.com/infinispan/infinispan-operator/controllers/constants"
 	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -34,8 +36,11 @@ type BackupReconciler struct {
 type backupResource struct {
 	instance *v2alpha1.Backup
 	client   client.Client
+	kube     *kube.Kubernetes
 	scheme   *runtime.Scheme
 	ctx      context.Context
+	// The size of the backup archive, measured once the backup has succeeded
+	size string
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -52,6 +57,7 @@ func (r *BackupReconciler) ResourceInstance(ctx context.Context, key types.Names
 	return &backupResource{
 		instance: instance,
 		client:   r.Client,
+		kube:     ctrl.Kube,
 		scheme:   ctrl.Scheme,
 		ctx:      ctx,
 	}, nil
@@ -73,6 +79,10 @@ func (r *backupResource) Phase() zeroCapacityPhase {
 	return zeroCapacityPhase(r.instance.Status.Phase)
 }
 
+func (r *backupResource) Operation() v1.OperationType {
+	return v1.OperationBackup
+}
+
 func (r *backupResource) UpdatePhase(phase zeroCapacityPhase, phaseErr error) error {
 	_, err := r.update(func() {
 		backup := r.instance
@@ -86,6 +96,13 @@ func (r *backupResource) UpdatePhase(phase zeroCapacityPhase, phaseErr error) er
 	return err
 }
 
+func (r *backupResource) UpdateCondition(condition v2alpha1.OperationConditionType, status metav1.ConditionStatus, message string) error {
+	_, err := r.update(func() {
+		r.instance.SetCondition(condition, status, message)
+	})
+	return err
+}
+
 func (r *backupResource) update(mutate func()) (bool, error) {
 	backup := r.instance
 	res, err := kube.CreateOrPatch(r.ctx, r.client, backup, func() error {
@@ -205,5 +222,22 @@ func (r *backupResource) ExecStatus(client api.Infinispan) (zeroCapacityPhase, e
 	if err != nil {
 		return ZeroUnknown, err
 	}
-	return zeroCapacityPhase(status), nil
+	phase := zeroCapacityPhase(status)
+	if phase == ZeroSucceeded {
+		// The size is informational only, so the Backup doesn't fail if the archive can't be measured
+		archive := fmt.Sprintf("%[1]s/%[2]s/%[2]s.zip", BackupDataMountPath, name)
+		if size, err := kube.GetPodFileSizeBytes(provision.InfinispanContainer, archive, name, r.instance.Namespace, r.kube); err == nil {
+			r.size = resource.NewQuantity(size, resource.BinarySI).String()
+		}
+	}
+	return phase, nil
+}
+
+func (r *backupResource) RecordSuccess(status *v1.InfinispanStatus) {
+	status.DisasterRecovery.LastBackup = &v1.BackupRecord{
+		Name:           r.instance.Name,
+		CompletionTime: metav1.Now(),
+		PVC:            r.instance.Name,
+		Size:           r.size,
+	}
 }
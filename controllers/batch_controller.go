@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	v1 "github.com/infinispan/infinispan-operator/api/v1"
@@ -29,8 +30,14 @@ const (
 	BatchVolumeName           = "batch-volume"
 	BatchVolumeRoot           = "/etc/batch"
 	AdminIdentitiesVolumeName = "admin-identities-volume"
+	BatchScriptVolumeName     = "batch-script-volume"
+	BatchScriptRoot           = "/tmp/batch"
 )
 
+// BatchPlaceholders the placeholders that are substituted in the batch file when the Job starts. Values are read from
+// the environment of the Job container so that credentials are never written to the batch ConfigMap
+var BatchPlaceholders = []string{"ADMIN_USER", "ADMIN_PASSWORD", "CLUSTER_URL"}
+
 // BatchReconciler reconciles a Batch object
 type BatchReconciler struct {
 	client.Client
@@ -116,8 +123,17 @@ func (r *batchRequest) initializeResources() (reconcile.Result, error) {
 		return *result, err
 	}
 
-	if err := infinispan.EnsureClusterStability(); err != nil {
+	if reject, err := infinispan.OperationGate(); err != nil {
 		r.log.Info(fmt.Sprintf("Infinispan '%s' not ready: %s", spec.Cluster, err.Error()))
+		if _, updErr := r.update(func() error {
+			batch.SetCondition(v2.OperationConditionClusterHealthGatePassed, metav1.ConditionFalse, err.Error())
+			return nil
+		}); updErr != nil {
+			return reconcile.Result{}, updErr
+		}
+		if reject {
+			return reconcile.Result{}, r.UpdatePhase(v2.BatchFailed, err)
+		}
 		return reconcile.Result{RequeueAfter: consts.DefaultWaitOnCluster}, nil
 	}
 
@@ -142,6 +158,7 @@ func (r *batchRequest) initializeResources() (reconcile.Result, error) {
 	_, err := r.update(func() error {
 		batch.Status.ClusterUID = &infinispan.UID
 		batch.Status.Phase = v2.BatchInitialized
+		batch.SetCondition(v2.OperationConditionClusterHealthGatePassed, metav1.ConditionTrue, "")
 		return nil
 	})
 	return reconcile.Result{}, err
@@ -160,7 +177,31 @@ func (r *batchRequest) execute() (reconcile.Result, error) {
 		return reconcile.Result{}, r.UpdatePhase(v2.BatchFailed, err)
 	}
 
-	cliArgs := fmt.Sprintf("--properties '%s/%s' --file '%s/%s'", consts.ServerAdminIdentitiesRoot, consts.CliPropertiesFilename, BatchVolumeRoot, BatchFilename)
+	job := batchJob(batch, infinispan)
+	_, err := controllerutil.CreateOrUpdate(r.ctx, r.Client, job, func() error {
+		return controllerutil.SetControllerReference(batch, job, r.scheme)
+	})
+
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("unable to create batch job '%s': %w", batch.Name, err)
+	}
+	return reconcile.Result{}, r.UpdatePhase(v2.BatchRunning, nil)
+}
+
+// batchJob returns the Job that executes the batch with the Infinispan server CLI
+func batchJob(batch *v2.Batch, infinispan *v1.Infinispan) *batchv1.Job {
+	cliArgs := fmt.Sprintf("--properties '%s/%s' --file '%s/%s'", consts.ServerAdminIdentitiesRoot, consts.CliPropertiesFilename, BatchScriptRoot, BatchFilename)
+	secretEnv := func(name, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: infinispan.GetAdminSecretName()},
+					Key:                  key,
+				},
+			},
+		}
+	}
 
 	labels := infinispan.PodLabels()
 	for k, v := range batchLabels(batch.Name) {
@@ -180,15 +221,30 @@ func (r *batchRequest) execute() (reconcile.Result, error) {
 					Annotations: infinispan.PodAnnotations(),
 				},
 				Spec: corev1.PodSpec{
+					ImagePullSecrets: infinispan.Spec.ImagePullSecrets,
+					SecurityContext:  infinispan.Spec.Security.PodSecurityContext,
 					Containers: []corev1.Container{{
-						Name:    batch.Name,
-						Image:   infinispan.ImageName(),
-						Command: []string{"/opt/infinispan/bin/cli.sh", cliArgs},
+						Name:            batch.Name,
+						Image:           infinispan.ImageName(),
+						ImagePullPolicy: infinispan.ImagePullPolicy(),
+						SecurityContext: infinispan.Spec.Security.ContainerSecurityContext,
+						Command:         []string{"/bin/bash", "-c", batchSubstitutionScript() + " && exec /opt/infinispan/bin/cli.sh " + cliArgs},
+						Env: []corev1.EnvVar{
+							secretEnv("ADMIN_USER", consts.AdminUsernameKey),
+							secretEnv("ADMIN_PASSWORD", consts.AdminPasswordKey),
+							{Name: "CLUSTER_URL", Value: fmt.Sprintf("http://%s:%d", infinispan.GetAdminServiceName(), consts.InfinispanAdminPort)},
+							// Read by cli.sh, spec.container.extraJvmOpts only applies to the server
+							{Name: "JAVA_OPTS", Value: infinispan.Spec.Container.CliExtraJvmOpts},
+						},
 						VolumeMounts: []corev1.VolumeMount{
 							{
 								Name:      BatchVolumeName,
 								MountPath: BatchVolumeRoot,
 							},
+							{
+								Name:      BatchScriptVolumeName,
+								MountPath: BatchScriptRoot,
+							},
 							{
 								Name:      AdminIdentitiesVolumeName,
 								MountPath: consts.ServerAdminIdentitiesRoot,
@@ -206,6 +262,13 @@ func (r *batchRequest) execute() (reconcile.Result, error) {
 								},
 							},
 						},
+						// Volume for the batch file with substituted placeholders
+						{
+							Name: BatchScriptVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
 						// Volume for cli.properties
 						{
 							Name: AdminIdentitiesVolumeName,
@@ -220,15 +283,21 @@ func (r *batchRequest) execute() (reconcile.Result, error) {
 			},
 		},
 	}
+	return job
+}
 
-	_, err := controllerutil.CreateOrUpdate(r.ctx, r.Client, job, func() error {
-		return controllerutil.SetControllerReference(batch, job, r.scheme)
-	})
-
-	if err != nil {
-		return reconcile.Result{}, fmt.Errorf("unable to create batch job '%s': %w", batch.Name, err)
+// batchSubstitutionScript returns the shell commands that copy the batch file to BatchScriptRoot, replacing each
+// $(PLACEHOLDER) with the value of the environment variable of the same name. The replacement is performed with bash
+// parameter expansion so that values containing special characters are copied verbatim. Placeholders are escaped as
+// $$(PLACEHOLDER) so that they are not expanded by the kubelet
+func batchSubstitutionScript() string {
+	var script strings.Builder
+	script.WriteString(`while IFS= read -r line || [ -n "$line" ]; do `)
+	for _, placeholder := range BatchPlaceholders {
+		fmt.Fprintf(&script, `line=${line//'$$(%[1]s)'/"$%[1]s"}; `, placeholder)
 	}
-	return reconcile.Result{}, r.UpdatePhase(v2.BatchRunning, nil)
+	fmt.Fprintf(&script, `printf '%%s\n' "$line"; done < '%s/%s' > '%s/%s'`, BatchVolumeRoot, BatchFilename, BatchScriptRoot, BatchFilename)
+	return script.String()
 }
 
 func (r *batchRequest) waitToComplete() (reconcile.Result, error) {
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"testing"
+
+	v1 "github.com/infinispan/infinispan-operator/api/v1"
+	v2 "github.com/infinispan/infinispan-operator/api/v2alpha1"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestBatchJobImagePull(t *testing.T) {
+	pullSecrets := []corev1.LocalObjectReference{{Name: "registry-secret"}}
+	infinispan := &v1.Infinispan{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan", Namespace: "testing-namespace"},
+		Spec: v1.InfinispanSpec{
+			Image:            pointer.StringPtr("quay.io/infinispan/server:13.0"),
+			ImagePullSecrets: pullSecrets,
+			ImagePullPolicy:  corev1.PullNever,
+		},
+	}
+	batch := &v2.Batch{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-batch", Namespace: "testing-namespace"},
+		Spec:       v2.BatchSpec{Cluster: infinispan.Name, Config: pointer.StringPtr("create cache --template=org.infinispan.DIST_SYNC batch-cache")},
+	}
+
+	spec := batchJob(batch, infinispan).Spec.Template.Spec
+	assert.Equal(t, pullSecrets, spec.ImagePullSecrets)
+	assert.Equal(t, corev1.PullNever, spec.Containers[0].ImagePullPolicy)
+
+	infinispan.Spec.ImagePullPolicy = ""
+	spec = batchJob(batch, infinispan).Spec.Template.Spec
+	assert.Equal(t, corev1.PullIfNotPresent, spec.Containers[0].ImagePullPolicy)
+}
+
+func TestBatchJobSecurityContext(t *testing.T) {
+	infinispan := &v1.Infinispan{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan", Namespace: "testing-namespace"},
+		Spec: v1.InfinispanSpec{
+			Security: v1.InfinispanSecurity{
+				PodSecurityContext:       &corev1.PodSecurityContext{RunAsNonRoot: pointer.BoolPtr(true)},
+				ContainerSecurityContext: &corev1.SecurityContext{AllowPrivilegeEscalation: pointer.BoolPtr(false)},
+			},
+		},
+	}
+	batch := &v2.Batch{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-batch", Namespace: "testing-namespace"},
+		Spec:       v2.BatchSpec{Cluster: infinispan.Name, Config: pointer.StringPtr("create cache --template=org.infinispan.DIST_SYNC batch-cache")},
+	}
+
+	spec := batchJob(batch, infinispan).Spec.Template.Spec
+	assert.Equal(t, infinispan.Spec.Security.PodSecurityContext, spec.SecurityContext)
+	assert.Equal(t, infinispan.Spec.Security.ContainerSecurityContext, spec.Containers[0].SecurityContext)
+}
+
+func TestBatchJobPlaceholders(t *testing.T) {
+	infinispan := &v1.Infinispan{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan", Namespace: "testing-namespace"},
+	}
+	batch := &v2.Batch{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-batch", Namespace: "testing-namespace"},
+		Spec:       v2.BatchSpec{Cluster: infinispan.Name, Config: pointer.StringPtr("connect $(CLUSTER_URL) -u $(ADMIN_USER) -p $(ADMIN_PASSWORD)")},
+	}
+
+	container := batchJob(batch, infinispan).Spec.Template.Spec.Containers[0]
+	env := container.Env
+	assert.Equal(t, infinispan.GetAdminSecretName(), env[kube.GetEnvVarIndex("ADMIN_USER", &env)].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "password", env[kube.GetEnvVarIndex("ADMIN_PASSWORD", &env)].ValueFrom.SecretKeyRef.Key)
+	assert.Equal(t, "http://example-infinispan-admin:11223", env[kube.GetEnvVarIndex("CLUSTER_URL", &env)].Value)
+
+	script := container.Command[2]
+	for _, placeholder := range BatchPlaceholders {
+		// Placeholders must be escaped so that the kubelet does not expand them before the script runs
+		assert.Contains(t, script, "'$$("+placeholder+")'")
+		assert.NotContains(t, script, "'$("+placeholder+")'")
+	}
+	assert.Contains(t, script, "--file '/tmp/batch/batch'")
+}
+
+func TestBatchJobCliJvmOpts(t *testing.T) {
+	infinispan := &v1.Infinispan{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan", Namespace: "testing-namespace"},
+		Spec: v1.InfinispanSpec{
+			Container: v1.InfinispanContainerSpec{
+				ExtraJvmOpts:    "-XX:+UseNUMA -javaagent:/opt/agent.jar",
+				CliExtraJvmOpts: "-Xmx256m",
+			},
+		},
+	}
+	batch := &v2.Batch{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-batch", Namespace: "testing-namespace"},
+		Spec:       v2.BatchSpec{Cluster: infinispan.Name, Config: pointer.StringPtr("stats")},
+	}
+
+	env := batchJob(batch, infinispan).Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "-Xmx256m", env[kube.GetEnvVarIndex("JAVA_OPTS", &env)].Value)
+	assert.Equal(t, -1, kube.GetEnvVarIndex("EXTRA_JAVA_OPTIONS", &env))
+}
@@ -3,8 +3,6 @@ package controllers
 import (
 	"context"
 	"fmt"
-	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/manage"
-	"k8s.io/apimachinery/pkg/util/validation"
 	"regexp"
 	"strconv"
 	"strings"
@@ -14,16 +12,20 @@ import (
 	v1 "github.com/infinispan/infinispan-operator/api/v1"
 	"github.com/infinispan/infinispan-operator/api/v2alpha1"
 	"github.com/infinispan/infinispan-operator/controllers/constants"
-	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
 	"github.com/infinispan/infinispan-operator/pkg/mime"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/cache"
+	cacheContext "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/cache/context"
+	cachePipelineBuilder "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/cache/pipeline"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -36,10 +38,11 @@ import (
 // CacheReconciler reconciles a Cache object
 type CacheReconciler struct {
 	client.Client
-	log        logr.Logger
-	scheme     *runtime.Scheme
-	kubernetes *kube.Kubernetes
-	eventRec   record.EventRecorder
+	log             logr.Logger
+	scheme          *runtime.Scheme
+	kubernetes      *kube.Kubernetes
+	eventRec        record.EventRecorder
+	contextProvider cache.ContextProvider
 }
 
 type CacheListener struct {
@@ -50,15 +53,6 @@ type CacheListener struct {
 	Log        *zap.SugaredLogger
 }
 
-type cacheRequest struct {
-	*CacheReconciler
-	ctx        context.Context
-	cache      *v2alpha1.Cache
-	infinispan *v1.Infinispan
-	ispnClient api.Infinispan
-	reqLogger  logr.Logger
-}
-
 // SetupWithManager sets up the controller with the Manager.
 func (r *CacheReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	r.Client = mgr.GetClient()
@@ -66,6 +60,7 @@ func (r *CacheReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager
 	r.scheme = mgr.GetScheme()
 	r.kubernetes = kube.NewKubernetesFromController(mgr)
 	r.eventRec = mgr.GetEventRecorderFor("cache-controller")
+	r.contextProvider = cacheContext.Provider(r.Client, r.kubernetes, r.eventRec)
 
 	if err := mgr.GetFieldIndexer().IndexField(ctx, &v2alpha1.Cache{}, "spec.clusterName", func(obj client.Object) []string {
 		return []string{obj.(*v2alpha1.Cache).Spec.ClusterName}
@@ -73,6 +68,24 @@ func (r *CacheReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager
 		return err
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &v2alpha1.Cache{}, "spec.templateFrom.configMapKeyRef.name", func(obj client.Object) []string {
+		if source := obj.(*v2alpha1.Cache).Spec.TemplateFrom; source != nil && source.ConfigMapKeyRef != nil {
+			return []string{source.ConfigMapKeyRef.Name}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &v2alpha1.Cache{}, "spec.templateFrom.secretKeyRef.name", func(obj client.Object) []string {
+		if source := obj.(*v2alpha1.Cache).Spec.TemplateFrom; source != nil && source.SecretKeyRef != nil {
+			return []string{source.SecretKeyRef.Name}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
 	builder := ctrl.NewControllerManagedBy(mgr).For(&v2alpha1.Cache{})
 	builder.Watches(
 		&source.Kind{Type: &v1.Infinispan{}},
@@ -96,9 +109,27 @@ func (r *CacheReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager
 				return requests
 			}),
 	)
+	// Apply changes of the templates referenced by spec.templateFrom
+	builder.Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.templateSourceRequests(ctx, "spec.templateFrom.configMapKeyRef.name")))
+	builder.Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.templateSourceRequests(ctx, "spec.templateFrom.secretKeyRef.name")))
 	return builder.Complete(r)
 }
 
+// templateSourceRequests returns a MapFunc that enqueues the Cache CRs whose template is loaded from a ConfigMap or Secret
+func (r *CacheReconciler) templateSourceRequests(ctx context.Context, indexField string) handler.MapFunc {
+	return func(a client.Object) []reconcile.Request {
+		cacheList := &v2alpha1.CacheList{}
+		if err := r.kubernetes.ResourcesListByField(a.GetNamespace(), indexField, a.GetName(), cacheList, ctx); err != nil {
+			r.log.Error(err, "watches failed to list Cache CRs")
+		}
+		var requests []reconcile.Request
+		for _, item := range cacheList.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: item.GetNamespace(), Name: item.GetName()}})
+		}
+		return requests
+	}
+}
+
 // +kubebuilder:rbac:groups=infinispan.org,namespace=infinispan-operator-system,resources=caches;caches/status;caches/finalizers,verbs=get;list;watch;create;update;patch;delete
 
 func (r *CacheReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
@@ -116,225 +147,23 @@ func (r *CacheReconciler) Reconcile(ctx context.Context, request ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
-	infinispan := &v1.Infinispan{}
-	cache := &cacheRequest{
-		CacheReconciler: r,
-		ctx:             ctx,
-		cache:           instance,
-		infinispan:      infinispan,
-		reqLogger:       reqLogger,
-	}
+	pipeline := cachePipelineBuilder.Builder().
+		For(instance).
+		WithContextProvider(r.contextProvider).
+		WithLogger(reqLogger).
+		Build()
 
-	if cache.markedForDeletion() {
-		reqLogger.Info("Cache CR marked for deletion. Attempting to remove.")
-		// The ConfigListener has marked this resource for deletion
-		// Remove finalizer and delete CR. No need to update the server as the cache has already been removed
-		if err := cache.removeFinalizer(); err != nil {
-			if errors.IsNotFound(err) {
-				reqLogger.Info("Cache CR not found, nothing todo.")
-				return ctrl.Result{}, nil
-			}
-			return ctrl.Result{}, err
-		}
-		if err := cache.kubernetes.Client.Delete(ctx, instance); err != nil {
-			if errors.IsNotFound(err) {
-				reqLogger.Info("Cache CR not found, nothing todo.")
-				return ctrl.Result{}, nil
-			}
-			return ctrl.Result{}, err
-		}
-		reqLogger.Info("Cache CR Removed.")
-		return ctrl.Result{}, nil
-	}
-
-	crDeleted := instance.GetDeletionTimestamp() != nil
-
-	// Fetch the Infinispan cluster
-	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Spec.ClusterName}, infinispan); err != nil {
-		if errors.IsNotFound(err) {
-			reqLogger.Error(err, fmt.Sprintf("Infinispan cluster %s not found", instance.Spec.ClusterName))
-			if crDeleted {
-				return ctrl.Result{}, cache.removeFinalizer()
-			}
-			// No need to requeue request here as the Infinispan watch ensures that a request is queued when the cluster is updated
-			return ctrl.Result{}, cache.update(func() error {
-				// Set CacheConditionReady to false in case the cluster was previously WellFormed
-				instance.SetCondition(v2alpha1.CacheConditionReady, metav1.ConditionFalse, "")
-				return nil
-			})
-		}
-		return ctrl.Result{}, err
-	}
-
-	// Cluster must be well formed
-	if !infinispan.IsWellFormed() {
-		reqLogger.Info(fmt.Sprintf("Infinispan cluster %s not well formed", infinispan.Name))
-		// No need to requeue request here as the Infinispan watch ensures that a request is queued when the cluster is updated
-		return ctrl.Result{}, nil
-	}
-
-	ispnClient, err := NewInfinispan(ctx, infinispan, r.kubernetes)
-	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("unable to create Infinispan client: %w", err)
-	}
-	cache.ispnClient = ispnClient
-
-	if crDeleted {
-		if controllerutil.ContainsFinalizer(instance, constants.InfinispanFinalizer) {
-			// Remove Deleted caches from the server before removing the Finalizer
-			cacheName := instance.GetCacheName()
-			if err := ispnClient.Cache(cacheName).Delete(); err != nil {
-				return ctrl.Result{}, err
-			}
-			return ctrl.Result{}, cache.removeFinalizer()
-		}
-		return ctrl.Result{}, nil
-	}
-
-	// Don't contact the Infinispan server for resources created by the ConfigListener
-	if cache.reconcileOnServer() {
-		if result, err := cache.ispnCreateOrUpdate(); result != nil {
-			if err != nil {
-				return *result, cache.update(func() error {
-					instance.SetCondition(v2alpha1.CacheConditionReady, metav1.ConditionFalse, err.Error())
-					return nil
-				})
-			}
-			return *result, err
-		}
-	}
-
-	err = cache.update(func() error {
-		instance.SetCondition(v2alpha1.CacheConditionReady, metav1.ConditionTrue, "")
-		// Add finalizer so that the Cache is removed on the server when the Cache CR is deleted
-		if !controllerutil.ContainsFinalizer(instance, constants.InfinispanFinalizer) {
-			controllerutil.AddFinalizer(instance, constants.InfinispanFinalizer)
-		}
-		return nil
-	})
-	return ctrl.Result{}, err
+	retry, delay, err := pipeline.Process(ctx)
+	reqLogger.Info("Done", "requeue", retry, "requeueAfter", delay, "error", err)
+	return ctrl.Result{Requeue: retry, RequeueAfter: delay}, err
 }
 
-func (r *cacheRequest) update(mutate func() error) error {
-	cache := r.cache
-	_, err := kube.CreateOrPatch(r.ctx, r.Client, cache, func() error {
-		if cache.CreationTimestamp.IsZero() {
-			return errors.NewNotFound(schema.ParseGroupResource("cache.infinispan.org"), cache.Name)
-		}
-		return mutate()
-	})
-	if err != nil {
-		return fmt.Errorf("unable to update cache %s: %w", cache.Name, err)
-	}
-	return nil
-}
-
-// Determine if reconciliation was triggered by the ConfigListener
-func (r *cacheRequest) reconcileOnServer() bool {
-	if val, exists := r.cache.ObjectMeta.Annotations[constants.ListenerAnnotationGeneration]; exists {
-		generation, _ := strconv.ParseInt(val, 10, 64)
-		return generation != r.cache.GetGeneration()
-	}
-	return true
-}
-
-func (r *cacheRequest) markedForDeletion() bool {
-	_, exists := r.cache.ObjectMeta.Annotations[constants.ListenerAnnotationDelete]
+// isRecreating returns true if the cache of the Cache CR is being recreated by the operator
+func isRecreating(cache *v2alpha1.Cache) bool {
+	_, exists := cache.Annotations[constants.CacheAnnotationRecreate]
 	return exists
 }
 
-func (r *cacheRequest) removeFinalizer() error {
-	return r.update(func() error {
-		controllerutil.RemoveFinalizer(r.cache, constants.InfinispanFinalizer)
-		return nil
-	})
-}
-
-func (r *cacheRequest) ispnCreateOrUpdate() (*ctrl.Result, error) {
-	cacheName := r.cache.GetCacheName()
-	cacheClient := r.ispnClient.Cache(cacheName)
-
-	cacheExists, err := cacheClient.Exists()
-	if err != nil {
-		err := fmt.Errorf("unable to determine if cache exists: %w", err)
-		r.reqLogger.Error(err, "")
-		return &ctrl.Result{}, err
-	}
-
-	if r.infinispan.IsDataGrid() {
-		err = r.reconcileDataGrid(cacheExists, cacheClient)
-	} else {
-		err = r.reconcileCacheService(cacheExists, cacheClient)
-	}
-	if err != nil {
-		return &ctrl.Result{Requeue: true}, err
-	}
-	return nil, nil
-}
-
-func (r *cacheRequest) reconcileCacheService(cacheExists bool, cache api.Cache) error {
-	spec := r.cache.Spec
-	if cacheExists {
-		err := fmt.Errorf("cannot update an existing cache in a CacheService cluster")
-		r.reqLogger.Error(err, "Error updating cache")
-		return err
-	}
-
-	if spec.TemplateName != "" || spec.Template != "" {
-		err := fmt.Errorf("cannot create a cache with a template in a CacheService cluster")
-		r.reqLogger.Error(err, "Error creating cache")
-		return err
-	}
-
-	podList, err := PodList(r.infinispan, r.kubernetes, r.ctx)
-	if err != nil {
-		r.reqLogger.Error(err, "failed to list pods")
-		return err
-	}
-
-	template, err := manage.DefaultCacheTemplateXML(podList.Items[0].Name, r.infinispan, r.kubernetes, r.reqLogger)
-	if err != nil {
-		err = fmt.Errorf("unable to obtain default cache template: %w", err)
-		r.reqLogger.Error(err, "Error getting default XML")
-		return err
-	}
-	if err = cache.Create(template, mime.ApplicationXml); err != nil {
-		err = fmt.Errorf("unable to create cache using default template: %w", err)
-		r.reqLogger.Error(err, "Error in creating cache")
-		return err
-	}
-	return nil
-}
-
-func (r *cacheRequest) reconcileDataGrid(cacheExists bool, cache api.Cache) error {
-	spec := r.cache.Spec
-	if cacheExists {
-		if spec.Template != "" {
-			err := cache.UpdateConfig(spec.Template, mime.GuessMarkup(spec.Template))
-			if err != nil {
-				return fmt.Errorf("unable to update cache template: %w", err)
-			}
-		}
-		return nil
-	}
-
-	var err error
-	if spec.TemplateName != "" {
-		if err = cache.CreateWithTemplate(spec.TemplateName); err != nil {
-			err = fmt.Errorf("unable to create cache with template name '%s': %w", spec.TemplateName, err)
-		}
-	} else {
-		if err = cache.Create(spec.Template, mime.GuessMarkup(spec.Template)); err != nil {
-			err = fmt.Errorf("unable to create cache with template: %w", err)
-		}
-	}
-
-	if err != nil {
-		r.reqLogger.Error(err, "Unable to create Cache")
-	}
-	return err
-}
-
 func (cl *CacheListener) RemoveStaleResources(podName string) error {
 	cl.Log.Info("Checking for stale cache resources")
 	k8s := cl.Kubernetes
@@ -396,6 +225,11 @@ func (cl *CacheListener) CreateOrUpdate(data []byte) error {
 		return nil
 	}
 
+	if strings.HasSuffix(cacheName, constants.CacheMigrationSuffix) {
+		cl.Log.Debugf("Ignoring temporary migration cache %s", cacheName)
+		return nil
+	}
+
 	cache, err := cl.findExistingCacheCR(cacheName, clusterName)
 	if err != nil {
 		return err
@@ -434,6 +268,10 @@ func (cl *CacheListener) CreateOrUpdate(data []byte) error {
 			return fmt.Errorf("unable to create Cache CR for cache '%s': %w", cacheName, err)
 		}
 		cl.Log.Infof("Cache CR '%s' created", cache.Name)
+	} else if cache.Spec.TemplateFrom != nil {
+		// The ConfigMap or Secret of spec.templateFrom is the source of truth, so the template is applied to the server
+		// again when the Cache CR is reconciled
+		cl.Log.Infof("Ignoring update of Cache CR '%s' as its template is loaded from spec.templateFrom", cache.Name)
 	} else {
 		// Update existing Cache
 		maxRetries := 5
@@ -443,10 +281,15 @@ func (cl *CacheListener) CreateOrUpdate(data []byte) error {
 					return errors.NewNotFound(schema.ParseGroupResource("caches.infinispan.org"), cache.Name)
 				}
 				var template, templateName string
-				if cache.Spec.Template != "" {
+				// The server configuration includes the encoding of spec.encoding and the authorization of spec.security, so
+				// it's stored as the template
+				if cache.Spec.Template != "" || cache.Spec.Encoding != nil || cache.Spec.Security != nil {
 					cl.Log.Infof("Update Cache CR for '%s'\n%s", cache.Name, configYaml)
 					// Determinate the original user markup format and convert stream configuration to that format if required
-					mediaType := mime.GuessMarkup(cache.Spec.Template)
+					mediaType := mime.ApplicationYaml
+					if cache.Spec.Template != "" {
+						mediaType = mime.GuessMarkup(cache.Spec.Template)
+					}
 					if mediaType == mime.ApplicationYaml {
 						template = configYaml
 					} else {
@@ -462,17 +305,19 @@ func (cl *CacheListener) CreateOrUpdate(data []byte) error {
 					templateName = cache.Spec.TemplateName
 				}
 
+				controllerutil.AddFinalizer(cache, constants.InfinispanFinalizer)
+				if template == cache.Spec.Template && templateName == cache.Spec.TemplateName {
+					// The spec already matches the server configuration, so the generation is not incremented
+					return nil
+				}
 				if cache.ObjectMeta.Annotations == nil {
 					cache.ObjectMeta.Annotations = make(map[string]string, 1)
 				}
-				controllerutil.AddFinalizer(cache, constants.InfinispanFinalizer)
 				cache.ObjectMeta.Annotations[constants.ListenerAnnotationGeneration] = strconv.FormatInt(cache.GetGeneration()+1, 10)
-				cache.Spec = v2alpha1.CacheSpec{
-					Name:         cacheName,
-					ClusterName:  cl.Infinispan.Name,
-					Template:     template,
-					TemplateName: templateName,
-				}
+				// Only the template is updated, the other fields, e.g. spec.encoding and spec.security, are applied to
+				// the template again when the Cache CR is reconciled
+				cache.Spec.Template = template
+				cache.Spec.TemplateName = templateName
 				return nil
 			})
 			if err == nil {
@@ -529,6 +374,11 @@ func (cl *CacheListener) Delete(data []byte) error {
 		return err
 	}
 
+	if isRecreating(existingCacheCr) {
+		cl.Log.Infof("Cache '%s' is being recreated, ignoring removal", cacheName)
+		return nil
+	}
+
 	cache := &v2alpha1.Cache{}
 	existingCacheCr.DeepCopyInto(cache)
 
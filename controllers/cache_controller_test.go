@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/controllers/constants"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCacheListenerUpdatePreservesSpec(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1.AddToScheme(scheme))
+	require.NoError(t, v2alpha1.AddToScheme(scheme))
+
+	encoding := &v2alpha1.CacheEncodingSpec{Key: &v2alpha1.CacheMediaTypeSpec{MediaType: "application/x-protostream"}}
+	security := &v2alpha1.CacheSecuritySpec{Roles: []string{"admin"}}
+	cache := &v2alpha1.Cache{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-cache", Namespace: "testing-namespace", CreationTimestamp: metav1.Now()},
+		Spec: v2alpha1.CacheSpec{
+			ClusterName: "example-infinispan",
+			Name:        "example-cache",
+			Template:    "distributedCache:\n  mode: SYNC\n",
+			Encoding:    encoding,
+			Security:    security,
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cache).Build()
+	listener := &CacheListener{
+		Infinispan: &v1.Infinispan{ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan", Namespace: "testing-namespace"}},
+		Ctx:        context.TODO(),
+		Kubernetes: &kube.Kubernetes{Client: c},
+		Log:        zap.NewNop().Sugar(),
+	}
+	event := []byte(`infinispan:
+  cacheContainer:
+    caches:
+      example-cache:
+        distributedCache:
+          mode: SYNC
+          owners: 3
+`)
+	require.NoError(t, listener.CreateOrUpdate(event))
+
+	updated := &v2alpha1.Cache{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: cache.Namespace, Name: cache.Name}, updated))
+	assert.Equal(t, "distributedCache:\n  mode: SYNC\n  owners: 3\n", updated.Spec.Template)
+	assert.Equal(t, encoding, updated.Spec.Encoding)
+	assert.Equal(t, security, updated.Spec.Security)
+	assert.Equal(t, "1", updated.Annotations[constants.ListenerAnnotationGeneration])
+
+	// The Cache CR is not updated when the server configuration matches the spec
+	require.NoError(t, listener.CreateOrUpdate(event))
+	unchanged := &v2alpha1.Cache{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: cache.Namespace, Name: cache.Name}, unchanged))
+	assert.Equal(t, updated.ResourceVersion, unchanged.ResourceVersion)
+}
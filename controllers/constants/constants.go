@@ -20,12 +20,19 @@ var (
 	ConfigListenerImageName = os.Getenv(ConfigListenerEnvName)
 	ConfigListenerEnvName   = "CONFIG_LISTENER_IMAGE"
 
+	// ConfigListenerEnabled the value of spec.configListener.enabled for Infinispan CRs that don't configure it, so that the
+	// ConfigListener Deployment can be skipped for all clusters, e.g. on resource constrained edge deployments
+	ConfigListenerEnabled = strings.ToLower(GetEnvWithDefault("CONFIG_LISTENER_ENABLED", "true")) == "true"
+
 	// JGroupsDiagnosticsFlag is used to enable traces for JGroups
 	JGroupsDiagnosticsFlag = strings.ToUpper(GetEnvWithDefault("JGROUPS_DIAGNOSTICS", "FALSE"))
 
 	// DefaultMemorySize string with default size for memory
 	DefaultMemorySize = resource.MustParse("1Gi")
 
+	// DefaultEdgeMemorySize default size for memory with the edge profile
+	DefaultEdgeMemorySize = resource.MustParse("512Mi")
+
 	// DefaultPVSize default size for persistent volume
 	DefaultPVSize = resource.MustParse("1Gi")
 
@@ -41,6 +48,30 @@ var (
 	}
 
 	JGroupsFastMerge = strings.ToUpper(GetEnvWithDefault("TEST_ENVIRONMENT", "false")) == "TRUE"
+
+	// DevModeDeniedNamespaces comma separated namespace patterns, e.g. "prod-*", in which spec.devMode is rejected
+	DevModeDeniedNamespaces = os.Getenv("DEV_MODE_DENIED_NAMESPACES")
+
+	// NodePortRange the "min-max" range of NodePorts configured by the --service-node-port-range flag of the API server
+	NodePortRange = GetEnvWithDefault("NODE_PORT_RANGE", "30000-32767")
+
+	// StrictValidation rejects Infinispan CRs applied with unknown fields instead of recording a warning event
+	StrictValidation = strings.ToLower(GetEnvWithDefault("STRICT_VALIDATION", "false")) == "true"
+
+	// SupersededOperandImages comma separated "image=advisory" entries of the operand images superseded for security
+	// reasons, e.g. "quay.io/infinispan/server:13.0.1=CVE-2022-0001". The advisory is optional
+	SupersededOperandImages = os.Getenv("SUPERSEDED_OPERAND_IMAGES")
+
+	// OperandRefreshPolicy "Automatic" refreshes clusters that pin a superseded operand image with spec.image to the
+	// operator's default image, "Manual" only reports them
+	OperandRefreshPolicy = GetEnvWithDefault("OPERAND_REFRESH_POLICY", "Manual")
+
+	// OperandRefreshWindow the cron schedule, in UTC, at which the maintenance window for automatic operand refreshes
+	// opens. Refreshes are scheduled immediately when empty
+	OperandRefreshWindow = os.Getenv("OPERAND_REFRESH_WINDOW")
+
+	// OperandRefreshWindowDuration how long the maintenance window stays open after OperandRefreshWindow fires
+	OperandRefreshWindowDuration = GetEnvWithDefault("OPERAND_REFRESH_WINDOW_DURATION", "4h")
 )
 
 const (
@@ -48,6 +79,8 @@ const (
 	DefaultOperatorUser = "operator"
 	// DefaultDeveloperUser users to access the cluster rest API
 	DefaultDeveloperUser = "developer"
+	// DefaultCapacityFactor the capacity factor of pods in zones without a configured factor
+	DefaultCapacityFactor = "1"
 	// DefaultCacheName default cache name for the CacheService
 	DefaultCacheName                        = "default"
 	AdminUsernameKey                        = "username"
@@ -58,8 +91,18 @@ const (
 	InfinispanPingPort                      = 8888
 	InfinispanPingPortName                  = "ping"
 	InfinispanUserPort                      = 11222
+	InfinispanHotRodPortName                = "hotrod"
+	InfinispanRestPortName                  = "rest"
+	InfinispanRestExposePort                = 8080
+	RestPathPrefix                          = "/rest"
 	CrossSitePort                           = 7900
 	CrossSitePortName                       = "xsite"
+	JGroupsTcpPort                          = 7800
+	JGroupsFDSockPort                       = 57800
+	ClientListenerMaxThreads                = 1
+	ClientListenerQueueLength               = 1000
+	InfinispanJmxPort                       = 9999
+	InfinispanJmxPortName                   = "jmx"
 	StatefulSetPodLabel                     = "app.kubernetes.io/created-by"
 	StaticCrossSiteUriSchema                = "infinispan+xsite"
 	CacheServiceFixedMemoryXmxMb            = 200
@@ -87,10 +130,73 @@ const (
 	ServerAdminIdentitiesRoot     = ServerSecurityRoot + "/admin"
 	ServerUserIdentitiesRoot      = ServerSecurityRoot + "/user"
 	ServerOperatorSecurity        = ServerSecurityRoot + "/conf/operator-security"
+	ServerJmxRoot                 = ServerSecurityRoot + "/jmx"
+	ServerKerberosRoot            = ServerSecurityRoot + "/kerberos"
+	ServerVaultRoot               = "/vault/secrets"
+	JmxPasswordFilename           = "jmxremote.password"
+	JmxAccessFilename             = "jmxremote.access"
 	ServerRoot                    = "/opt/infinispan/server"
+	GCLogFilename                 = "gc.log"
+	FlightRecordingFilename       = "continuous.jfr"
+	HeapDumpFilename              = "java_oom.hprof"
+	DefaultGCLogFileSize          = "20M"
+	DefaultGCLogFileCount         = int32(5)
+	DefaultFlightRecorderMaxSize  = "250M"
+	AuditLogFilename              = "audit.log"
+	// AuditLogCategory is the log category of the authorization decisions recorded by the server
+	AuditLogCategory = "org.infinispan.AUDIT"
 
 	EncryptTruststoreKey         = "truststore.p12"
 	EncryptTruststorePasswordKey = "truststore-password"
+	// EncryptCAKey is the key of the PEM CA certificates in the keystore Secret and in the cluster's CA bundle ConfigMap
+	EncryptCAKey = "ca.crt"
+
+	// OIDCClientIDKey and OIDCClientSecretKey are the keys of the introspection client credentials in the
+	// spec.security.endpointOidc.clientSecretName Secret
+	OIDCClientIDKey     = "clientId"
+	OIDCClientSecretKey = "clientSecret"
+	// KerberosKeytabKey and KerberosConfigKey are the keys of the keytab and of the Kerberos configuration in the
+	// spec.security.endpointKerberos.keytabSecretName Secret
+	KerberosKeytabKey = "keytab"
+	KerberosConfigKey = "krb5.conf"
+	// VaultUsersFilename, VaultGroupsFilename and VaultServerPropertiesFilename are the files of spec.security.vault that
+	// the servers read from ServerVaultRoot, rendered from the VaultUsersKey, VaultGroupsKey and VaultKeystorePasswordKey
+	// fields of the Vault secret by the Vault Agent Injector
+	VaultUsersFilename            = "users.properties"
+	VaultGroupsFilename           = "groups.properties"
+	VaultServerPropertiesFilename = "server.properties"
+	VaultUsersKey                 = "users"
+	VaultGroupsKey                = "groups"
+	VaultKeystorePasswordKey      = "keystorePassword"
+	// VaultKeystorePasswordProperty is the system property of server.properties that contains the keystore password
+	VaultKeystorePasswordProperty = "infinispan.keystore.password"
+	// DefaultOIDCPrincipalClaim is the token claim that contains the name of the user if none is configured
+	DefaultOIDCPrincipalClaim = "preferred_username"
+	// DefaultCredentialKey is the key of a spec.security.credentials Secret that contains the credential if none is configured
+	DefaultCredentialKey = "password"
+	// TransportKeystoreCredentialAlias and TransportTruststoreCredentialAlias are the aliases of the passwords of the
+	// cross-site keystore and truststore in the credential store of the servers
+	TransportKeystoreCredentialAlias   = "transport-keystore"
+	TransportTruststoreCredentialAlias = "transport-truststore"
+	// TokenReviewPort is the loopback port of the token review sidecar, which introspects the ServiceAccount tokens of
+	// spec.security.endpointServiceAccount for the token realm of the servers
+	TokenReviewPort = 11230
+	// TokenReviewHost is the host of the token review sidecar. The sidecar listens on every loopback address that it
+	// resolves to, so that the server reaches it whether the pod is IPv4, IPv6 or dual-stack
+	TokenReviewHost = "localhost"
+	// TokenReviewIntrospectionPath is the path of the OAuth 2.0 token introspection endpoint of the token review sidecar
+	TokenReviewIntrospectionPath = "/introspect"
+	// TokenReviewHealthPath is the path of the endpoint that the probes of the token review sidecar call
+	TokenReviewHealthPath = "/healthz"
+	// TokenReviewRoot is where the token review sidecar mounts the projected token of the pod's ServiceAccount
+	TokenReviewRoot = "/var/run/secrets/infinispan.org/token-review"
+	// TokenReviewPrincipalClaim is the claim of the introspection response that contains the name of the ServiceAccount
+	TokenReviewPrincipalClaim = "username"
+
+	// ServiceCABundleConfigMapName is the ConfigMap injected in every namespace by the OpenShift service CA operator
+	ServiceCABundleConfigMapName = "openshift-service-ca.crt"
+	// ServiceCABundleKey is the key of the PEM service CA in the ServiceCABundleConfigMapName ConfigMap
+	ServiceCABundleKey = "service-ca.crt"
 
 	DefaultCacheTemplate = `<infinispan>
 		<cache-container>
@@ -107,6 +213,26 @@ const (
 const (
 	// DefaultMinimumAutoscalePollPeriod minimum period for autoscaler polling loop
 	DefaultMinimumAutoscalePollPeriod = 5 * time.Second
+	// DefaultIntegrityCheckInterval time between integrity checks of replicated caches
+	DefaultIntegrityCheckInterval = time.Hour
+	// DefaultIntegrityCheckSampleSize maximum number of keys checked per cache
+	DefaultIntegrityCheckSampleSize = 20
+	// IntegrityCheckTimeout maximum duration of an integrity check, the keys that are not checked in time are reported
+	// as an error of their cache
+	IntegrityCheckTimeout = 10 * time.Minute
+	// OperationLockTimeout maximum duration for which an operation holds status.operationLock, after which the lock is
+	// released so that the operations waiting on it can proceed
+	OperationLockTimeout = 2 * time.Hour
+	// DefaultRecommendationsInterval time between sizing recommendation evaluations
+	DefaultRecommendationsInterval = 15 * time.Minute
+	// DefaultStorageMonitoringInterval time between measurements of the usage of the data volumes
+	DefaultStorageMonitoringInterval = 5 * time.Minute
+	// DefaultStoragePressureThreshold percentage of the capacity of a data volume above which it is under pressure
+	DefaultStoragePressureThreshold = 80
+	// DefaultShadowReplicationLagCheckInterval time between measurements of the shadow replication lag
+	DefaultShadowReplicationLagCheckInterval = time.Minute
+	// DefaultStorageExpansionIncrement percentage by which the storage of a data volume under pressure is increased
+	DefaultStorageExpansionIncrement = 25
 	//DefaultWaitOnCluster delay for the Infinispan cluster wait if it not created while Cache creation
 	DefaultWaitOnCluster = 10 * time.Second
 	// DefaultWaitOnCreateResource delay for wait until resource (Secret, ConfigMap, Service) is created
@@ -132,6 +258,71 @@ const (
 	AnnotationDomain             = "infinispan.org/"
 	ListenerAnnotationGeneration = AnnotationDomain + "listener-generation"
 	ListenerAnnotationDelete     = AnnotationDomain + "listener-delete"
+	// CacheAnnotationRecreate marks Cache CRs whose cache is being recreated, so that the ConfigListener ignores the removal of the cache
+	CacheAnnotationRecreate = AnnotationDomain + "cache-recreate"
+	// CacheAnnotationDryRun requests that Cache CRs are reconciled without modifying the server, recording the operation
+	// that would be performed in status.dryRun
+	CacheAnnotationDryRun = AnnotationDomain + "dry-run"
+	// AnnotationRestartedAt triggers a rolling restart of the Infinispan pods whenever its value on the Infinispan CR changes
+	AnnotationRestartedAt = AnnotationDomain + "restartedAt"
+	// AnnotationAction requests an action on all pods of the cluster. The annotation is removed by the operator once the
+	// action is accepted, with the progress reported in status.action
+	AnnotationAction = AnnotationDomain + "action"
+	// AnnotationXSiteStateTransfer requests the state transfer of the caches to the remote site named by its value, or
+	// the cancellation of the state transfer in progress with the value "cancel". The annotation is removed by the
+	// operator once the request has been processed
+	AnnotationXSiteStateTransfer = AnnotationDomain + "xsite-state-transfer"
+	// AnnotationXSiteStateTransferCaches restricts the state transfer requested with AnnotationXSiteStateTransfer to the
+	// comma separated caches
+	AnnotationXSiteStateTransferCaches = AnnotationDomain + "xsite-state-transfer-caches"
+	// AnnotationContainerEnv records the names of the spec.container.env variables on the StatefulSet pod template, so that
+	// variables removed from the spec can be removed from the server container
+	AnnotationContainerEnv = AnnotationDomain + "container-env"
+	// AnnotationCapacityFactor is set by the operator on each pod to the capacity factor of the zone the pod is scheduled in
+	AnnotationCapacityFactor = AnnotationDomain + "capacity-factor"
+	// AnnotationKeystoreHash is set by the operator on each pod to the hash of the certificates reloaded by the server
+	// at runtime, and on the StatefulSet pod template to the hash of the certificates of a rolling restart
+	AnnotationKeystoreHash = AnnotationDomain + "keystore-hash"
+	// AnnotationIdentitiesHash is set by the operator on each pod to the hash of the user identities applied by the
+	// server at runtime, and on the StatefulSet pod template to the hash of the identities of a rolling restart
+	AnnotationIdentitiesHash = AnnotationDomain + "identities-hash"
+	// AnnotationExternalHost is set by the operator on each pod to the host of the pod's external Service, when
+	// spec.expose.perPod is true
+	AnnotationExternalHost = AnnotationDomain + "external-host"
+	// AnnotationExternalPort is set by the operator on each pod to the port of the pod's external Service, when
+	// spec.expose.perPod is true
+	AnnotationExternalPort = AnnotationDomain + "external-port"
+	// LabelCredentialRequest marks a Secret that requests short-lived credentials for the Infinispan cluster named by the
+	// label value. The operator writes the username and password of a temporary user to the Secret and deletes the
+	// Secret once the credentials expire
+	LabelCredentialRequest = AnnotationDomain + "credential-request"
+	// LabelTokenReviewNamespace is set on the token review ClusterRoleBinding of an Infinispan cluster to the namespace
+	// of the cluster, so that the bindings of clusters deleted whilst the operator was not running can be found
+	LabelTokenReviewNamespace = AnnotationDomain + "token-review-namespace"
+	// AnnotationCredentialTTL configures the lifetime of the credentials requested by a Secret, e.g. 30m
+	AnnotationCredentialTTL = AnnotationDomain + "credential-ttl"
+	// AnnotationCredentialRoles configures the comma separated roles of the temporary user requested by a Secret
+	AnnotationCredentialRoles = AnnotationDomain + "credential-roles"
+	// AnnotationCredentialExpiry is set by the operator on a credential request Secret to the RFC 3339 time at which the
+	// issued credentials expire
+	AnnotationCredentialExpiry = AnnotationDomain + "credential-expiry"
+	// AnnotationTemporaryUsersHash is set by the operator on each pod to the hash of the temporary users created on the
+	// server
+	AnnotationTemporaryUsersHash = AnnotationDomain + "temporary-users-hash"
+	// AnnotationRelayTrustStoreHash is set by the operator on the relay truststore Secret to the hash of the truststore
+	// and the CA certificates of the remote sites that it was assembled from
+	AnnotationRelayTrustStoreHash = AnnotationDomain + "relay-truststore-hash"
+	// AnnotationStrictValidation overrides the operator's STRICT_VALIDATION for an Infinispan CR when set to "true" or "false"
+	AnnotationStrictValidation = AnnotationDomain + "strict-validation"
+	// AnnotationVulnerableOperand is set by the operator on Infinispan CRs whose pods run an operand image listed in
+	// SUPERSEDED_OPERAND_IMAGES, to the security advisory of the image
+	AnnotationVulnerableOperand = AnnotationDomain + "vulnerable-operand"
+	// ExternalDNSHostnameAnnotation configures the DNS names that ExternalDNS publishes for a Service, Ingress, Route or HTTPRoute
+	ExternalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+	// ExternalDNSTTLAnnotation configures the TTL in seconds of the DNS records published by ExternalDNS
+	ExternalDNSTTLAnnotation = "external-dns.alpha.kubernetes.io/ttl"
+	// CacheMigrationSuffix is appended to the name of the temporary cache that holds entries while a cache is recreated
+	CacheMigrationSuffix = "___migration"
 )
 
 // GetWithDefault return value if not empty else return defValue
@@ -9,8 +9,15 @@ import (
 	consts "github.com/infinispan/infinispan-operator/controllers/constants"
 	grafanav1alpha1 "github.com/infinispan/infinispan-operator/pkg/apis/integreatly/v1alpha1"
 	"github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -19,6 +26,23 @@ const (
 	grafanaDashboardNamespaceKey  = "grafana.dashboard.namespace"
 	grafanaDashboardNameKey       = "grafana.dashboard.name"
 	grafanaDashboardMonitoringKey = "grafana.dashboard.monitoring.key"
+
+	grafanaOperatorDashboardEnabledKey = "grafana.dashboard.operator.enabled"
+	grafanaOperatorDashboardNameKey    = "grafana.dashboard.operator.name"
+	// Only used to track the namespace of the created GrafanaDashboard in currentConfig
+	grafanaOperatorDashboardNamespaceKey = "grafana.dashboard.operator.namespace"
+
+	// Label used by the Grafana dashboard sidecar to discover dashboards stored in ConfigMaps
+	grafanaDashboardConfigMapLabel = "grafana_dashboard"
+
+	serverDashboardFile   = "grafana_dashboard.json"
+	operatorDashboardFile = "grafana_operator_dashboard.json"
+
+	// operatorMetricsPort is the default port of the --metrics-bind-address of the operator
+	operatorMetricsPort     = 8080
+	operatorMetricsPortName = "metrics"
+	// operatorMetricsLabel selects the metrics Service of the operator in its ServiceMonitor
+	operatorMetricsLabel = "infinispan.org/operator-metrics"
 )
 
 // +kubebuilder:rbac:groups=integreatly.org,namespace=infinispan-operator-system,resources=grafanadashboards,verbs=get;list;watch;create;delete;update
@@ -49,21 +73,14 @@ func (r *ReconcileOperatorConfig) reconcileGrafana(ctx context.Context, config,
 	if _, err = controllerutil.CreateOrUpdate(ctx, r.Client, infinispanDashboard, func() error {
 		if infinispanDashboard.CreationTimestamp.IsZero() {
 			if grafanaNs == operatorNs {
-				if ownRef, err := kubernetes.GetOperatorPodOwnerRef(operatorNs, r.Client, ctx); err != nil {
-					if errors.Is(err, kubernetes.ErrRunLocal) {
-						r.log.Info(fmt.Sprintf("Not setting controller reference for Grafana Dashboard, cause %s.", err.Error()))
-					} else {
-						return err
-					}
-				} else {
-					r.log.Info("Operator Pod owner found", "Kind", ownRef.Kind, "Name", ownRef.Name)
-					infinispanDashboard.SetOwnerReferences([]metav1.OwnerReference{*ownRef})
+				if err := r.setOperatorOwnerRef(ctx, infinispanDashboard, operatorNs); err != nil {
+					return err
 				}
 			} else {
 				r.log.Info("Not setting controller reference, cause Infinispan and Grafana are in different namespaces.")
 			}
 		}
-		return populateDashboard(infinispanDashboard, config)
+		return populateDashboard(infinispanDashboard, serverDashboardFile, config)
 	}); err != nil {
 		return &reconcile.Result{}, err
 	}
@@ -73,6 +90,225 @@ func (r *ReconcileOperatorConfig) reconcileGrafana(ctx context.Context, config,
 	return &reconcile.Result{}, nil
 }
 
+// reconcileOperatorDashboard installs a dashboard for the health of the operator itself. A GrafanaDashboard is created
+// when the Grafana operator CRDs exist. A ConfigMap, discoverable by the Grafana dashboard sidecar, and a ServiceMonitor
+// that scrapes the metrics of the operator are created in the operator namespace when the Prometheus operator CRDs exist
+func (r *ReconcileOperatorConfig) reconcileOperatorDashboard(ctx context.Context, config, currentConfig map[string]string, operatorNs string) (*reconcile.Result, error) {
+	name := config[grafanaOperatorDashboardNameKey]
+	grafanaNs := consts.GetWithDefault(config[grafanaDashboardNamespaceKey], operatorNs)
+	enabled := config[grafanaOperatorDashboardEnabledKey] == "true"
+
+	// Delete the current operator dashboard if it has been disabled or its name or namespace has changed
+	if curName := currentConfig[grafanaOperatorDashboardNameKey]; curName != "" &&
+		(!enabled || name != curName || grafanaNs != currentConfig[grafanaOperatorDashboardNamespaceKey]) {
+		if err := r.deleteOperatorDashboard(ctx, curName, currentConfig[grafanaOperatorDashboardNamespaceKey], operatorNs); err != nil {
+			return &reconcile.Result{}, err
+		}
+		currentConfig[grafanaOperatorDashboardNameKey] = ""
+		currentConfig[grafanaOperatorDashboardNamespaceKey] = ""
+	}
+
+	if !enabled || name == "" {
+		return &reconcile.Result{}, nil
+	}
+
+	grafanaExists, err := r.kubernetes.IsGroupVersionSupported(grafanav1alpha1.SchemeGroupVersion.String(), grafanav1alpha1.GrafanaDashboardKind)
+	if err != nil {
+		r.log.Error(err, "Error checking Grafana support")
+		return &reconcile.Result{Requeue: true}, nil
+	}
+	prometheusExists, err := r.kubernetes.IsGroupVersionSupported(monitoringv1.SchemeGroupVersion.String(), monitoringv1.ServiceMonitorsKind)
+	if err != nil {
+		r.log.Error(err, "Error checking Prometheus support")
+		return &reconcile.Result{Requeue: true}, nil
+	}
+	if !grafanaExists && !prometheusExists {
+		r.log.Info("Grafana and Prometheus CRDs not present - not installing operator dashboard")
+		return &reconcile.Result{RequeueAfter: consts.DefaultLongWaitOnCreateResource}, nil
+	}
+
+	if grafanaExists {
+		dashboard := &grafanav1alpha1.GrafanaDashboard{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: grafanaNs,
+			},
+		}
+		if _, err = controllerutil.CreateOrUpdate(ctx, r.Client, dashboard, func() error {
+			if dashboard.CreationTimestamp.IsZero() && grafanaNs == operatorNs {
+				if err := r.setOperatorOwnerRef(ctx, dashboard, operatorNs); err != nil {
+					return err
+				}
+			}
+			return populateDashboard(dashboard, operatorDashboardFile, config)
+		}); err != nil {
+			return &reconcile.Result{}, err
+		}
+	}
+
+	if prometheusExists {
+		operatorJSON, err := dashboardJSON(operatorDashboardFile)
+		if err != nil {
+			return &reconcile.Result{}, err
+		}
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: operatorNs,
+			},
+		}
+		if _, err = controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+			if configMap.CreationTimestamp.IsZero() {
+				if err := r.setOperatorOwnerRef(ctx, configMap, operatorNs); err != nil {
+					return err
+				}
+			}
+			if configMap.Labels == nil {
+				configMap.Labels = map[string]string{}
+			}
+			configMap.Labels[grafanaDashboardConfigMapLabel] = "1"
+			configMap.Data = map[string]string{
+				name + ".json": operatorJSON,
+			}
+			return nil
+		}); err != nil {
+			return &reconcile.Result{}, err
+		}
+		if err := r.reconcileOperatorServiceMonitor(ctx, operatorMetricsName(name), operatorNs); err != nil {
+			return &reconcile.Result{}, err
+		}
+	}
+
+	currentConfig[grafanaOperatorDashboardNameKey] = name
+	currentConfig[grafanaOperatorDashboardNamespaceKey] = grafanaNs
+	return &reconcile.Result{}, nil
+}
+
+// reconcileOperatorServiceMonitor creates a Service for the metrics endpoint of the operator pods and a ServiceMonitor
+// that scrapes it, so that the operator dashboard has data without a manual Prometheus configuration
+func (r *ReconcileOperatorConfig) reconcileOperatorServiceMonitor(ctx context.Context, name, operatorNs string) error {
+	selector, err := r.operatorPodSelector(ctx, operatorNs)
+	if err != nil {
+		if errors.Is(err, kubernetes.ErrRunLocal) {
+			r.log.Info(fmt.Sprintf("Not creating the operator ServiceMonitor, cause %s.", err.Error()))
+			return nil
+		}
+		return err
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: operatorNs,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		if service.CreationTimestamp.IsZero() {
+			if err := r.setOperatorOwnerRef(ctx, service, operatorNs); err != nil {
+				return err
+			}
+		}
+		if service.Labels == nil {
+			service.Labels = map[string]string{}
+		}
+		service.Labels[operatorMetricsLabel] = name
+		service.Spec.Selector = selector
+		service.Spec.Ports = []corev1.ServicePort{{
+			Name:       operatorMetricsPortName,
+			Port:       operatorMetricsPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(operatorMetricsPort),
+		}}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	serviceMonitor := &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: operatorNs,
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, serviceMonitor, func() error {
+		if serviceMonitor.CreationTimestamp.IsZero() {
+			if err := r.setOperatorOwnerRef(ctx, serviceMonitor, operatorNs); err != nil {
+				return err
+			}
+		}
+		serviceMonitor.Spec = monitoringv1.ServiceMonitorSpec{
+			Endpoints: []monitoringv1.Endpoint{{
+				Port: operatorMetricsPortName,
+				Path: "/metrics",
+			}},
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{operatorMetricsLabel: name},
+			},
+		}
+		return nil
+	})
+	return err
+}
+
+// operatorPodSelector returns the selector of the Deployment of the operator, or the labels of the operator pod if it is
+// not controlled by a Deployment
+func (r *ReconcileOperatorConfig) operatorPodSelector(ctx context.Context, operatorNs string) (map[string]string, error) {
+	pod, err := kubernetes.GetPod(ctx, r.Client, operatorNs)
+	if err != nil {
+		return nil, err
+	}
+	ownerRef, err := kubernetes.GetOperatorPodOwnerRef(operatorNs, r.Client, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ownerRef.Kind != "Deployment" {
+		return pod.Labels, nil
+	}
+	deployment := &appsv1.Deployment{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: operatorNs, Name: ownerRef.Name}, deployment); err != nil {
+		return nil, err
+	}
+	if deployment.Spec.Selector == nil {
+		return pod.Labels, nil
+	}
+	return deployment.Spec.Selector.MatchLabels, nil
+}
+
+// operatorMetricsName returns the name of the metrics Service and ServiceMonitor of the operator dashboard name
+func operatorMetricsName(name string) string {
+	return name + "-metrics"
+}
+
+func (r *ReconcileOperatorConfig) deleteOperatorDashboard(ctx context.Context, name, grafanaNs, operatorNs string) error {
+	objects := []client.Object{
+		&grafanav1alpha1.GrafanaDashboard{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: grafanaNs}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: operatorNs}},
+		&monitoringv1.ServiceMonitor{ObjectMeta: metav1.ObjectMeta{Name: operatorMetricsName(name), Namespace: operatorNs}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: operatorMetricsName(name), Namespace: operatorNs}},
+	}
+	for _, obj := range objects {
+		if err := r.kubernetes.Client.Delete(ctx, obj); err != nil && !k8serrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// setOperatorOwnerRef sets the operator pod as the owner of the object, so that it is removed with the operator
+func (r *ReconcileOperatorConfig) setOperatorOwnerRef(ctx context.Context, obj metav1.Object, operatorNs string) error {
+	ownRef, err := kubernetes.GetOperatorPodOwnerRef(operatorNs, r.Client, ctx)
+	if err != nil {
+		if errors.Is(err, kubernetes.ErrRunLocal) {
+			r.log.Info(fmt.Sprintf("Not setting controller reference for %s, cause %s.", obj.GetName(), err.Error()))
+			return nil
+		}
+		return err
+	}
+	r.log.Info("Operator Pod owner found", "Kind", ownRef.Kind, "Name", ownRef.Name)
+	obj.SetOwnerReferences([]metav1.OwnerReference{*ownRef})
+	return nil
+}
+
 func emptyDashboard(config map[string]string) *grafanav1alpha1.GrafanaDashboard {
 	return &grafanav1alpha1.GrafanaDashboard{
 		ObjectMeta: metav1.ObjectMeta{
@@ -82,12 +318,8 @@ func emptyDashboard(config map[string]string) *grafanav1alpha1.GrafanaDashboard
 	}
 }
 
-func populateDashboard(dashboard *grafanav1alpha1.GrafanaDashboard, config map[string]string) error {
-	box, err := rice.FindBox("resources")
-	if err != nil {
-		return err
-	}
-	dashboardJSONData, err := box.String("grafana_dashboard.json")
+func populateDashboard(dashboard *grafanav1alpha1.GrafanaDashboard, file string, config map[string]string) error {
+	dashboardJSONData, err := dashboardJSON(file)
 	if err != nil {
 		return err
 	}
@@ -109,6 +341,14 @@ func populateDashboard(dashboard *grafanav1alpha1.GrafanaDashboard, config map[s
 	return nil
 }
 
+func dashboardJSON(file string) (string, error) {
+	box, err := rice.FindBox("resources")
+	if err != nil {
+		return "", err
+	}
+	return box.String(file)
+}
+
 func (r *ReconcileOperatorConfig) deleteDashboardOnKeyChanged(ctx context.Context, newCfg, curCfg map[string]string) error {
 	// If key is changed and old key is not nil, delete old grafana dashboard
 	if (newCfg[grafanaDashboardNameKey] != curCfg[grafanaDashboardNameKey] ||
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileOperatorServiceMonitor(t *testing.T) {
+	ctx := context.TODO()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, monitoringv1.AddToScheme(scheme))
+
+	controller := true
+	deployment := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "infinispan-operator-controller-manager", Namespace: "operators", UID: "deployment-uid"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"control-plane": "controller-manager"}},
+		},
+	}
+	replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name:            "infinispan-operator-controller-manager-1234",
+		Namespace:       "operators",
+		OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: deployment.Name, UID: deployment.UID, Controller: &controller}},
+	}}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:            "infinispan-operator-controller-manager-1234-abcd",
+		Namespace:       "operators",
+		Labels:          map[string]string{"control-plane": "controller-manager", "pod-template-hash": "1234"},
+		OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: replicaSet.Name, Controller: &controller}},
+	}}
+	t.Setenv(kubernetes.PodNameEnvVar, pod.Name)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment, replicaSet, pod).Build()
+	r := &ReconcileOperatorConfig{Client: c, scheme: scheme, log: ctrl.Log.WithName("test")}
+	name := operatorMetricsName("infinispan-operator")
+	require.NoError(t, r.reconcileOperatorServiceMonitor(ctx, name, "operators"))
+
+	service := &corev1.Service{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "operators", Name: name}, service))
+	// The Service selects the pods of the Deployment, not only the pods of the current ReplicaSet
+	assert.Equal(t, map[string]string{"control-plane": "controller-manager"}, service.Spec.Selector)
+	require.Len(t, service.Spec.Ports, 1)
+	assert.Equal(t, int32(operatorMetricsPort), service.Spec.Ports[0].Port)
+	assert.Equal(t, "Deployment", service.OwnerReferences[0].Kind)
+
+	serviceMonitor := &monitoringv1.ServiceMonitor{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "operators", Name: name}, serviceMonitor))
+	assert.Equal(t, []monitoringv1.Endpoint{{Port: operatorMetricsPortName, Path: "/metrics"}}, serviceMonitor.Spec.Endpoints)
+	assert.Equal(t, map[string]string{operatorMetricsLabel: name}, serviceMonitor.Spec.Selector.MatchLabels)
+	assert.Equal(t, name, service.Labels[operatorMetricsLabel])
+
+	// Nothing is created when the operator runs outside the cluster
+	t.Setenv(kubernetes.ForceRunModeEnv, string(kubernetes.LocalRunMode))
+	c = fake.NewClientBuilder().WithScheme(scheme).Build()
+	r.Client = c
+	require.NoError(t, r.reconcileOperatorServiceMonitor(ctx, name, "operators"))
+	services := &corev1.ServiceList{}
+	require.NoError(t, c.List(ctx, services))
+	assert.Empty(t, services.Items)
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
 	pipelineContext "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/context"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/manage"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
 	pipelineBuilder "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/pipeline"
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -15,17 +18,38 @@ import (
 
 	"github.com/go-logr/logr"
 	infinispanv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// secretFields are the indexed fields of the Infinispan CR that reference Secrets provided by the user. Changes to the
+// content of the Secrets are applied to the clusters that reference them
+var secretFields = []string{
+	"spec.security.endpointSecretName",
+	"spec.security.adminSecretName",
+	"spec.security.endpointEncryption.certSecretName",
+	"spec.security.endpointEncryption.clientCertSecretName",
+	"spec.security.endpointOidc.clientSecretName",
+	"spec.security.endpointKerberos.keytabSecretName",
+	"spec.security.credentials",
+	"spec.service.sites.local.encryption",
+	"spec.expose.routeDestinationCASecretName",
+}
+
+// tokenReviewBindingsGCPeriod is the interval at which the token review ClusterRoleBindings of deleted clusters are
+// garbage collected
+const tokenReviewBindingsGCPeriod = time.Hour
+
 // InfinispanReconciler reconciles a Infinispan object
 type InfinispanReconciler struct {
 	client.Client
@@ -55,6 +79,14 @@ func (r *InfinispanReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 	}); err != nil {
 		return err
 	}
+	if err = mgr.GetFieldIndexer().IndexField(ctx, &infinispanv1.Infinispan{}, "spec.security.adminSecretName", func(obj client.Object) []string {
+		if secretName := obj.(*infinispanv1.Infinispan).Spec.Security.AdminSecretName; secretName != "" {
+			return []string{secretName}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
 	if err = mgr.GetFieldIndexer().IndexField(ctx, &infinispanv1.Infinispan{}, "spec.security.endpointEncryption.certSecretName", func(obj client.Object) []string {
 		return []string{obj.(*infinispanv1.Infinispan).GetKeystoreSecretName()}
 	}); err != nil {
@@ -66,14 +98,60 @@ func (r *InfinispanReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		return err
 	}
 
+	if err = mgr.GetFieldIndexer().IndexField(ctx, &infinispanv1.Infinispan{}, "spec.security.endpointOidc.clientSecretName", func(obj client.Object) []string {
+		if oidc := obj.(*infinispanv1.Infinispan).Spec.Security.EndpointOIDC; oidc != nil {
+			return []string{oidc.ClientSecretName}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err = mgr.GetFieldIndexer().IndexField(ctx, &infinispanv1.Infinispan{}, "spec.security.endpointKerberos.keytabSecretName", func(obj client.Object) []string {
+		if kerberos := obj.(*infinispanv1.Infinispan).Spec.Security.EndpointKerberos; kerberos != nil {
+			return []string{kerberos.KeytabSecretName}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err = mgr.GetFieldIndexer().IndexField(ctx, &infinispanv1.Infinispan{}, "spec.security.credentials", func(obj client.Object) []string {
+		var secretNames []string
+		for _, credential := range obj.(*infinispanv1.Infinispan).Spec.Security.Credentials {
+			secretNames = append(secretNames, credential.SecretName)
+		}
+		return secretNames
+	}); err != nil {
+		return err
+	}
+	if err = mgr.GetFieldIndexer().IndexField(ctx, &infinispanv1.Infinispan{}, "spec.service.sites.local.encryption", func(obj client.Object) []string {
+		var secretNames []string
+		ispn := obj.(*infinispanv1.Infinispan)
+		for _, secretName := range append([]string{ispn.GetSiteTransportSecretName(), ispn.GetSiteRouterSecretName(), ispn.GetSiteTrustoreSecretName()}, ispn.GetSiteCASecretNames()...) {
+			if secretName != "" {
+				secretNames = append(secretNames, secretName)
+			}
+		}
+		return secretNames
+	}); err != nil {
+		return err
+	}
+	if err = mgr.GetFieldIndexer().IndexField(ctx, &infinispanv1.Infinispan{}, "spec.expose.routeDestinationCASecretName", func(obj client.Object) []string {
+		if expose := obj.(*infinispanv1.Infinispan).Spec.Expose; expose != nil && expose.RouteDestinationCASecretName != "" {
+			return []string{expose.RouteDestinationCASecretName}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
 	if err = mgr.GetFieldIndexer().IndexField(ctx, &infinispanv1.Infinispan{}, "spec.configMapName", func(obj client.Object) []string {
 		return []string{obj.(*infinispanv1.Infinispan).Spec.ConfigMapName}
 	}); err != nil {
 		return err
 	}
 
-	r.supportedTypes = make(map[schema.GroupVersionKind]struct{}, 3)
-	for _, gvk := range []schema.GroupVersionKind{infinispan.IngressGVK, infinispan.RouteGVK, infinispan.ServiceMonitorGVK} {
+	r.supportedTypes = make(map[schema.GroupVersionKind]struct{}, 6)
+	for _, gvk := range []schema.GroupVersionKind{infinispan.IngressGVK, infinispan.RouteGVK, infinispan.HTTPRouteGVK, infinispan.TLSRouteGVK, infinispan.ServiceMonitorGVK, infinispan.ServiceExportGVK} {
 		// Validate that GroupVersionKind is supported on runtime platform
 		ok, err := kubernetes.IsGroupVersionKindSupported(gvk)
 		if err != nil {
@@ -85,6 +163,19 @@ func (r *InfinispanReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		}
 	}
 
+	// The token review ClusterRoleBinding of a cluster is deleted once the CR is not found, which is missed if the CR
+	// is deleted whilst the operator is not running
+	if err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		wait.UntilWithContext(ctx, func(ctx context.Context) {
+			if err := provision.RemoveOrphanedTokenReviewClusterRoleBindings(ctx, mgr.GetAPIReader(), r.Client); err != nil {
+				r.log.Error(err, "unable to delete the token review ClusterRoleBindings of deleted Infinispan clusters")
+			}
+		}, tokenReviewBindingsGCPeriod)
+		return nil
+	})); err != nil {
+		return err
+	}
+
 	// Initialize default operator labels and annotations
 	if defaultLabels, defaultAnnotations, err := infinispanv1.LoadDefaultLabelsAndAnnotations(); err != nil {
 		return err
@@ -99,15 +190,23 @@ func (r *InfinispanReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		Owns(&corev1.Secret{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&corev1.Service{}).
 		WithEventFilter(predicate.Funcs{
 			CreateFunc: func(e event.CreateEvent) bool {
 				switch e.Object.(type) {
 				case *corev1.ConfigMap:
 					return false
 				case *corev1.Secret:
-					return false
+					// Credential requests must be issued as soon as the Secret is created
+					if _, ok := e.Object.GetLabels()[consts.LabelCredentialRequest]; ok {
+						return true
+					}
+					// Secrets referenced by a cluster can be created after it, for example by the External Secrets Operator
+					return !kube.IsControlledByGVK(e.Object.GetOwnerReferences(), infinispanv1.SchemeBuilder.GroupVersion.WithKind(reflect.TypeOf(infinispanv1.Infinispan{}).Name()))
 				case *appsv1.StatefulSet:
 					return false
+				case *corev1.Service:
+					return false
 				}
 				return true
 			},
@@ -126,22 +225,29 @@ func (r *InfinispanReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 			handler.EnqueueRequestsFromMapFunc(
 				func(a client.Object) []reconcile.Request {
 					var requests []reconcile.Request
-					// Lookup only Secrets not controlled by Infinispan CR GVK. This means it's a custom defined Secret
+					if cluster, ok := a.GetLabels()[consts.LabelCredentialRequest]; ok {
+						return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: a.GetNamespace(), Name: cluster}}}
+					}
+					// Lookup only Secrets not controlled by Infinispan CR GVK. This means it's a custom defined Secret, whose
+					// content may be changed at any time, for example when it is refreshed by the External Secrets Operator
 					if !kube.IsControlledByGVK(a.GetOwnerReferences(), infinispanv1.SchemeBuilder.GroupVersion.WithKind(reflect.TypeOf(infinispanv1.Infinispan{}).Name())) {
-						for _, field := range []string{"spec.security.endpointSecretName", "spec.security.endpointEncryption.certSecretName", "spec.security.endpointEncryption.clientCertSecretName"} {
+						// A Secret can be referenced by several fields of several clusters
+						clusters := map[types.NamespacedName]struct{}{}
+						for _, field := range secretFields {
 							ispnList := &infinispanv1.InfinispanList{}
 							if err := kubernetes.ResourcesListByField(a.GetNamespace(), field, a.GetName(), ispnList, ctx); err != nil {
 								r.log.Error(err, "failed to list Infinispan CR")
 							}
 							for _, item := range ispnList.Items {
-								requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: item.GetNamespace(), Name: item.GetName()}})
-							}
-							if len(requests) > 0 {
-								return requests
+								name := types.NamespacedName{Namespace: item.GetNamespace(), Name: item.GetName()}
+								if _, exists := clusters[name]; !exists {
+									clusters[name] = struct{}{}
+									requests = append(requests, reconcile.Request{NamespacedName: name})
+								}
 							}
 						}
 					}
-					return nil
+					return requests
 				}),
 		).
 		Watches(
@@ -149,6 +255,19 @@ func (r *InfinispanReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 			handler.EnqueueRequestsFromMapFunc(
 				func(a client.Object) []reconcile.Request {
 					var requests []reconcile.Request
+					// Rotation of the OpenShift service CA must be propagated to the client CA bundle of clusters using service certificates
+					if a.GetName() == consts.ServiceCABundleConfigMapName {
+						ispnList := &infinispanv1.InfinispanList{}
+						if err := kubernetes.ResourcesList(a.GetNamespace(), nil, ispnList, ctx); err != nil {
+							r.log.Error(err, "failed to list Infinispan CR")
+						}
+						for _, item := range ispnList.Items {
+							if item.IsEncryptionCertFromService() {
+								requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: item.GetNamespace(), Name: item.GetName()}})
+							}
+						}
+						return requests
+					}
 					// Lookup only ConfigMap not controlled by Infinispan CR GVK. This means it's a custom defined ConfigMap
 					if !kube.IsControlledByGVK(a.GetOwnerReferences(), infinispanv1.SchemeBuilder.GroupVersion.WithKind(reflect.TypeOf(infinispanv1.Infinispan{}).Name())) {
 						ispnList := &infinispanv1.InfinispanList{}
@@ -171,11 +290,11 @@ func (r *InfinispanReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 // +kubebuilder:rbac:groups=infinispan.org,namespace=infinispan-operator-system,resources=infinispans;infinispans/status;infinispans/finalizers,verbs=get;list;watch;create;update;patch
 
 // +kubebuilder:rbac:groups=core,namespace=infinispan-operator-system,resources=persistentvolumeclaims;services;services/finalizers;endpoints;configmaps;pods;secrets,verbs=get;list;watch;create;update;delete;patch;deletecollection
-// +kubebuilder:rbac:groups=core,namespace=infinispan-operator-system,resources=pods/logs,verbs=get
+// +kubebuilder:rbac:groups=core,namespace=infinispan-operator-system,resources=pods/log,verbs=get
 // +kubebuilder:rbac:groups=core,namespace=infinispan-operator-system,resources=pods/exec,verbs=create
 // +kubebuilder:rbac:groups=core;events.k8s.io,namespace=infinispan-operator-system,resources=events,verbs=create;patch
-// +kubebuilder:rbac:groups=core,namespace=infinispan-operator-system,resources=serviceaccounts,verbs=get;list;watch;create;delete
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,namespace=infinispan-operator-system,resources=roles;rolebindings,verbs=create;delete;update
+// +kubebuilder:rbac:groups=core,namespace=infinispan-operator-system,resources=serviceaccounts,verbs=get;list;watch;create;delete;update
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,namespace=infinispan-operator-system,resources=roles;rolebindings,verbs=get;list;watch;create;delete;update
 
 // +kubebuilder:rbac:groups=apps,namespace=infinispan-operator-system,resources=deployments,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=apps,namespace=infinispan-operator-system,resources=replicasets,verbs=get
@@ -185,12 +304,16 @@ func (r *InfinispanReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 // +kubebuilder:rbac:groups=networking.k8s.io,namespace=infinispan-operator-system,resources=customresourcedefinitions;customresourcedefinitions/status,verbs=get;list
 
 // +kubebuilder:rbac:groups=route.openshift.io,namespace=infinispan-operator-system,resources=routes;routes/custom-host,verbs=get;list;watch;create;delete;deletecollection;update
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,namespace=infinispan-operator-system,resources=httproutes;tlsroutes,verbs=get;list;watch;create;delete;update
+// +kubebuilder:rbac:groups=multicluster.x-k8s.io,namespace=infinispan-operator-system,resources=serviceexports,verbs=get;list;watch;create;delete;update
 
 // +kubebuilder:rbac:groups=monitoring.coreos.com,namespace=infinispan-operator-system,resources=servicemonitors,verbs=get;list;watch;create;delete;update
 
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions;customresourcedefinitions/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;list;watch;create;delete;update
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind,resourceNames=system:auth-delegator
 
 // Reconcile the Infinispan CR resource
 func (r *InfinispanReconciler) Reconcile(ctx context.Context, ctrlRequest ctrl.Request) (ctrl.Result, error) {
@@ -200,6 +323,12 @@ func (r *InfinispanReconciler) Reconcile(ctx context.Context, ctrlRequest ctrl.R
 	if err := r.Get(ctx, ctrlRequest.NamespacedName, instance); err != nil {
 		if errors.IsNotFound(err) {
 			r.log.Info("Infinispan CR not found")
+			manage.RemoveClusterMetrics(ctrlRequest.Namespace, ctrlRequest.Name)
+			manage.RemoveServerWarnings(ctrlRequest.Namespace, ctrlRequest.Name)
+			manage.RemoveIntegrityCheck(ctrlRequest.Namespace, ctrlRequest.Name)
+			if err := provision.RemoveTokenReviewClusterRoleBinding(ctx, r.Client, ctrlRequest.Namespace, ctrlRequest.Name); err != nil {
+				return reconcile.Result{}, fmt.Errorf("unable to delete the token review ClusterRoleBinding: %w", err)
+			}
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
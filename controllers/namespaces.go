@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+const (
+	// LabelNamespaceBinding is set by the operator on the RoleBindings that grant it permissions in the namespaces that
+	// opt in. The value is the namespace of the operator
+	LabelNamespaceBinding = "infinispan.org/operator-namespace"
+
+	// namespaceResyncPeriod the period at which the RoleBindings are reconciled without a namespace event, so that
+	// RoleBindings that were removed by other means are restored
+	namespaceResyncPeriod = 10 * time.Minute
+	// namespaceRetryPeriod the delay before the namespaces are updated again after an error
+	namespaceRetryPeriod = 10 * time.Second
+)
+
+// NamespaceCache is the cache of the operator, to which the namespaces that opt in are added
+type NamespaceCache interface {
+	AddNamespace(namespace string) error
+	RemoveNamespace(namespace string)
+}
+
+// OptedInNamespaces returns the sorted names of the namespaces whose labels match the selector
+func OptedInNamespaces(ctx context.Context, c client.Reader, selector labels.Selector) ([]string, error) {
+	namespaceList := &corev1.NamespaceList{}
+	if err := c.List(ctx, namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("unable to list the namespaces that match '%s': %w", selector, err)
+	}
+	namespaces := make([]string, 0, len(namespaceList.Items))
+	for _, ns := range namespaceList.Items {
+		namespaces = append(namespaces, ns.Name)
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// NamespaceBindings watches the namespaces and grants the operator the permissions of a ClusterRole in each namespace
+// that opts in, with a RoleBinding that is deleted once the namespace opts out. The operator therefore has no
+// namespaced permissions in the namespaces that did not opt in. The namespaces are added to and removed from the cache
+// of the operator as they opt in and out, so that the operator does not need to be restarted
+type NamespaceBindings struct {
+	// A client that does not use the cache of the manager, which only contains the namespaces that opted in
+	Client   client.Client
+	Selector labels.Selector
+	// The ClusterRole with the namespaced permissions of the operator. If empty, no RoleBinding is managed and the
+	// permissions must be granted by the installer, for example OLM
+	ClusterRole string
+	// The ServiceAccount of the operator
+	ServiceAccount rbacv1.Subject
+	// The informers of the cluster scoped Namespaces
+	Informers cache.Informers
+	// The cache of the operator
+	Cache NamespaceCache
+	// The namespaces that opted in and are in the cache
+	Namespaces []string
+
+	log logr.Logger
+}
+
+// Start watches the namespaces and updates the RoleBindings and the cache of the operator whenever a namespace opts in
+// or out
+func (b *NamespaceBindings) Start(ctx context.Context) error {
+	informer, err := b.Informers.GetInformer(ctx, &corev1.Namespace{})
+	if err != nil {
+		return fmt.Errorf("unable to watch namespaces: %w", err)
+	}
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	matches := func(obj interface{}) bool {
+		namespace, ok := obj.(*corev1.Namespace)
+		// The final state of deleted namespaces may be unknown
+		return !ok || b.Selector.Matches(labels.Set(namespace.Labels))
+	}
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if matches(obj) {
+				notify()
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if matches(oldObj) != matches(newObj) {
+				notify()
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if matches(obj) {
+				notify()
+			}
+		},
+	})
+	go func() {
+		if err := b.Informers.Start(ctx); err != nil {
+			b.logger().Error(err, "unable to watch namespaces")
+		}
+	}()
+	if !b.Informers.WaitForCacheSync(ctx) {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("unable to sync the namespaces")
+	}
+
+	ticker := time.NewTicker(namespaceResyncPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		case <-ticker.C:
+		}
+		if err := b.update(ctx); err != nil {
+			b.logger().Error(err, "unable to update the namespaces that opted in")
+			time.AfterFunc(namespaceRetryPeriod, notify)
+		}
+	}
+}
+
+// update binds the operator to the namespaces that opted in and adds them to the cache, and removes the namespaces that
+// opted out from the cache. The namespace of the operator is always in the cache
+func (b *NamespaceBindings) update(ctx context.Context) error {
+	namespaces, err := b.Sync(ctx)
+	if err != nil {
+		return err
+	}
+	optedIn := make(map[string]struct{}, len(namespaces))
+	for _, namespace := range namespaces {
+		optedIn[namespace] = struct{}{}
+	}
+
+	watched := make(map[string]struct{}, len(b.Namespaces))
+	defer func() {
+		b.Namespaces = make([]string, 0, len(watched))
+		for namespace := range watched {
+			b.Namespaces = append(b.Namespaces, namespace)
+		}
+		sort.Strings(b.Namespaces)
+	}()
+	for _, namespace := range b.Namespaces {
+		if _, ok := optedIn[namespace]; ok {
+			watched[namespace] = struct{}{}
+			continue
+		}
+		b.logger().Info("Namespace opted out", "namespace", namespace)
+		if namespace != b.ServiceAccount.Namespace {
+			b.Cache.RemoveNamespace(namespace)
+		}
+	}
+	for _, namespace := range namespaces {
+		if _, ok := watched[namespace]; ok {
+			continue
+		}
+		b.logger().Info("Namespace opted in", "namespace", namespace)
+		if err := b.Cache.AddNamespace(namespace); err != nil {
+			return fmt.Errorf("unable to watch namespace '%s': %w", namespace, err)
+		}
+		watched[namespace] = struct{}{}
+	}
+	return nil
+}
+
+// NeedLeaderElection returns false, as the cache of every replica of the operator must contain the namespaces that
+// opted in
+func (b *NamespaceBindings) NeedLeaderElection() bool {
+	return false
+}
+
+// Sync creates the RoleBinding of each namespace that opted in, deletes the RoleBindings of the namespaces that opted
+// out and returns the namespaces that opted in
+func (b *NamespaceBindings) Sync(ctx context.Context) ([]string, error) {
+	namespaces, err := OptedInNamespaces(ctx, b.Client, b.Selector)
+	if err != nil || b.ClusterRole == "" {
+		return namespaces, err
+	}
+
+	optedIn := make(map[string]struct{}, len(namespaces))
+	for _, namespace := range namespaces {
+		optedIn[namespace] = struct{}{}
+		binding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      b.ClusterRole,
+				Namespace: namespace,
+			},
+		}
+		if _, err := controllerutil.CreateOrUpdate(ctx, b.Client, binding, func() error {
+			if binding.Labels == nil {
+				binding.Labels = map[string]string{}
+			}
+			binding.Labels[LabelNamespaceBinding] = b.ServiceAccount.Namespace
+			binding.RoleRef = rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     b.ClusterRole,
+			}
+			binding.Subjects = []rbacv1.Subject{b.ServiceAccount}
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("unable to create the RoleBinding of namespace '%s': %w", namespace, err)
+		}
+	}
+
+	bindings := &rbacv1.RoleBindingList{}
+	if err := b.Client.List(ctx, bindings, client.MatchingLabels{LabelNamespaceBinding: b.ServiceAccount.Namespace}); err != nil {
+		return nil, fmt.Errorf("unable to list the RoleBindings of the operator: %w", err)
+	}
+	for _, binding := range bindings.Items {
+		if _, ok := optedIn[binding.Namespace]; ok {
+			continue
+		}
+		b.logger().Info("Deleting the RoleBinding of a namespace that opted out", "namespace", binding.Namespace)
+		if err := b.Client.Delete(ctx, &binding); err != nil && !k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("unable to delete the RoleBinding of namespace '%s': %w", binding.Namespace, err)
+		}
+	}
+	return namespaces, nil
+}
+
+func (b *NamespaceBindings) logger() logr.Logger {
+	if b.log == nil {
+		b.log = ctrl.Log.WithName("controllers").WithName("NamespaceBindings")
+	}
+	return b.log
+}
@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNamespaceBindingsSync(t *testing.T) {
+	ctx := context.TODO()
+	optedIn := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"infinispan.org/operator": "enabled"}}}
+	other := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}}
+	// The RoleBinding of a namespace that opted out while the operator was not running
+	stale := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{
+		Name:      "infinispan-operator-namespace-manager-role",
+		Namespace: "team-b",
+		Labels:    map[string]string{LabelNamespaceBinding: "infinispan-operator-system"},
+	}}
+	// The RoleBindings of other operator installations are not modified
+	foreign := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{
+		Name:      "infinispan-operator-namespace-manager-role",
+		Namespace: "team-c",
+		Labels:    map[string]string{LabelNamespaceBinding: "other-operator-namespace"},
+	}}
+	selector, err := labels.Parse("infinispan.org/operator=enabled")
+	require.NoError(t, err)
+
+	c := fake.NewClientBuilder().WithObjects(optedIn, other, stale, foreign).Build()
+	b := &NamespaceBindings{
+		Client:         c,
+		Selector:       selector,
+		ClusterRole:    "infinispan-operator-namespace-manager-role",
+		ServiceAccount: rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "infinispan-operator-controller-manager", Namespace: "infinispan-operator-system"},
+	}
+	namespaces, err := b.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team-a"}, namespaces)
+
+	binding := &rbacv1.RoleBinding{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: b.ClusterRole}, binding))
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: b.ClusterRole}, binding.RoleRef)
+	assert.Equal(t, []rbacv1.Subject{b.ServiceAccount}, binding.Subjects)
+	assert.Equal(t, "infinispan-operator-system", binding.Labels[LabelNamespaceBinding])
+
+	bindings := &rbacv1.RoleBindingList{}
+	require.NoError(t, c.List(ctx, bindings, client.InNamespace("team-b")))
+	assert.Empty(t, bindings.Items)
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "team-c", Name: b.ClusterRole}, binding))
+
+	// Opting out deletes the RoleBinding
+	optedIn.Labels = nil
+	require.NoError(t, c.Update(ctx, optedIn))
+	namespaces, err = b.Sync(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, namespaces)
+	require.NoError(t, c.List(ctx, bindings, client.InNamespace("team-a")))
+	assert.Empty(t, bindings.Items)
+
+	// Without a ClusterRole the permissions are granted by the installer
+	b.ClusterRole = ""
+	other.Labels = map[string]string{"infinispan.org/operator": "enabled"}
+	require.NoError(t, c.Update(ctx, other))
+	namespaces, err = b.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team-b"}, namespaces)
+	require.NoError(t, c.List(ctx, bindings, client.InNamespace("team-b")))
+	assert.Empty(t, bindings.Items)
+}
+
+// syncedInformers signals when the informers of the namespaces are synced
+type syncedInformers struct {
+	informertest.FakeInformers
+	synced chan struct{}
+}
+
+func (i *syncedInformers) WaitForCacheSync(ctx context.Context) bool {
+	close(i.synced)
+	return true
+}
+
+// namespaceCache records the namespaces that are added to and removed from the cache
+type namespaceCache chan string
+
+func (c namespaceCache) AddNamespace(namespace string) error {
+	c <- "add " + namespace
+	return nil
+}
+
+func (c namespaceCache) RemoveNamespace(namespace string) {
+	c <- "remove " + namespace
+}
+
+func TestNamespaceBindingsStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	enabled := map[string]string{"infinispan.org/operator": "enabled"}
+	teamA := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: enabled}}
+	teamB := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}}
+	selector, err := labels.Parse("infinispan.org/operator=enabled")
+	require.NoError(t, err)
+
+	c := fake.NewClientBuilder().WithObjects(teamA, teamB).Build()
+	informers := &syncedInformers{synced: make(chan struct{})}
+	cache := make(namespaceCache, 10)
+	b := &NamespaceBindings{
+		Client:         c,
+		Selector:       selector,
+		ClusterRole:    "infinispan-operator-namespace-manager-role",
+		ServiceAccount: rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "infinispan-operator-controller-manager", Namespace: "infinispan-operator-system"},
+		Informers:      informers,
+		Cache:          cache,
+		Namespaces:     []string{"team-a"},
+	}
+	done := make(chan error)
+	go func() { done <- b.Start(ctx) }()
+	<-informers.synced
+	informer, err := informers.FakeInformerFor(&corev1.Namespace{})
+	require.NoError(t, err)
+
+	next := func() string {
+		select {
+		case namespace := <-cache:
+			return namespace
+		case <-time.After(10 * time.Second):
+			t.Fatal("the cache was not updated")
+			return ""
+		}
+	}
+
+	// A namespace that opts in is added to the cache without restarting the operator
+	teamB.Labels = enabled
+	require.NoError(t, c.Update(ctx, teamB))
+	informer.Add(teamB)
+	assert.Equal(t, "add team-b", next())
+	binding := &rbacv1.RoleBinding{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "team-b", Name: b.ClusterRole}, binding))
+
+	// A namespace that opts out is removed from the cache
+	optedOut := teamA.DeepCopy()
+	optedOut.Labels = nil
+	require.NoError(t, c.Update(ctx, optedOut))
+	informer.Update(teamA, optedOut)
+	assert.Equal(t, "remove team-a", next())
+
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Empty(t, cache)
+}
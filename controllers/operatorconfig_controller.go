@@ -8,13 +8,17 @@ import (
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const configMapName = "infinispan-operator-config"
@@ -42,9 +46,22 @@ func (r *ReconcileOperatorConfig) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// Trigger a reconciliation on startup, so that the operator dashboard is installed even if the operator
+	// configuration ConfigMap does not exist
+	startup := make(chan event.GenericEvent, 1)
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		startup <- event.GenericEvent{
+			Object: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: configMapName, Namespace: operatorNS}},
+		}
+		return nil
+	})); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&corev1.ConfigMap{}).
+		Watches(&source.Channel{Source: startup}, &handler.EnqueueRequestForObject{}).
 		WithEventFilter(predicate.Funcs{
 			DeleteFunc: func(e event.DeleteEvent) bool {
 				return e.Object.GetName() == configMapName && e.Object.GetNamespace() == operatorNS
@@ -77,13 +94,22 @@ func (r *ReconcileOperatorConfig) Reconcile(ctx context.Context, request reconci
 	}
 
 	config := map[string]string{
-		grafanaDashboardMonitoringKey: "middleware",
-		grafanaDashboardNameKey:       "infinispan",
+		grafanaDashboardMonitoringKey:      "middleware",
+		grafanaDashboardNameKey:            "infinispan",
+		grafanaOperatorDashboardEnabledKey: "true",
+		grafanaOperatorDashboardNameKey:    "infinispan-operator",
 	}
 	// Merge config value with defaults
 	for k, v := range configMap.Data {
 		config[k] = v
 	}
 	res, err := r.reconcileGrafana(ctx, config, currentConfig, operatorNs)
-	return *res, err
+	if err != nil {
+		return *res, err
+	}
+	operatorRes, err := r.reconcileOperatorDashboard(ctx, config, currentConfig, operatorNs)
+	if err != nil || (!res.Requeue && res.RequeueAfter == 0) {
+		return *operatorRes, err
+	}
+	return *res, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	v1 "github.com/infinispan/infinispan-operator/api/v1"
 	"github.com/infinispan/infinispan-operator/api/v2alpha1"
 	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
@@ -68,6 +69,10 @@ func (r *restore) Phase() zeroCapacityPhase {
 	return zeroCapacityPhase(string(r.instance.Status.Phase))
 }
 
+func (r *restore) Operation() v1.OperationType {
+	return v1.OperationRestore
+}
+
 func (r *restore) UpdatePhase(phase zeroCapacityPhase, phaseErr error) error {
 	_, err := r.update(func() {
 		restore := r.instance
@@ -81,6 +86,13 @@ func (r *restore) UpdatePhase(phase zeroCapacityPhase, phaseErr error) error {
 	return err
 }
 
+func (r *restore) UpdateCondition(condition v2alpha1.OperationConditionType, status metav1.ConditionStatus, message string) error {
+	_, err := r.update(func() {
+		r.instance.SetCondition(condition, status, message)
+	})
+	return err
+}
+
 func (r *restore) update(mutate func()) (bool, error) {
 	restore := r.instance
 	res, err := kube.CreateOrPatch(r.ctx, r.client, restore, func() error {
@@ -149,3 +161,11 @@ func (r *restore) ExecStatus(client api.Infinispan) (zeroCapacityPhase, error) {
 	}
 	return zeroCapacityPhase(status), nil
 }
+
+func (r *restore) RecordSuccess(status *v1.InfinispanStatus) {
+	status.DisasterRecovery.LastRestore = &v1.RestoreRecord{
+		Name:           r.instance.Name,
+		Backup:         r.instance.Spec.Backup,
+		CompletionTime: metav1.Now(),
+	}
+}
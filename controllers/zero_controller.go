@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-logr/logr"
 	v1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
 	consts "github.com/infinispan/infinispan-operator/controllers/constants"
 	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
@@ -17,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -32,14 +34,20 @@ type zeroCapacityResource interface {
 	Cluster() string
 	// The current execution phase of the controller
 	Phase() zeroCapacityPhase
+	// The operation that requires exclusive access to the Infinispan cluster whilst it is executed
+	Operation() v1.OperationType
 	// Update the current state of the resource to reflect the most recent Phase
 	UpdatePhase(phase zeroCapacityPhase, phaseErr error) error
+	// Update a condition of the resource
+	UpdateCondition(condition v2alpha1.OperationConditionType, status metav1.ConditionStatus, message string) error
 	// Ensure that all prerequisite resources are av¬ailable and create any required resources before returning the zero spec
 	Init() (*zeroCapacitySpec, error)
 	// Perform the operation(s) that are required on the zero-capacity pod
 	Exec(client api.Infinispan) error
 	// Return true when the operation(s) have completed, otherwise false
 	ExecStatus(api api.Infinispan) (zeroCapacityPhase, error)
+	// Record the outcome of the operation(s) in the status of the Infinispan cluster once they have succeeded
+	RecordSuccess(status *v1.InfinispanStatus)
 	// Utility method to return a metav1.Object in order to set the controller reference
 	AsMeta() metav1.Object
 }
@@ -173,10 +181,13 @@ func (z *zeroCapacityController) Reconcile(ctx context.Context, request reconcil
 	case ZeroInitialized:
 		return z.execute(ispnClient, request, instance, ctx)
 	case ZeroSucceeded, ZeroFailed:
+		if err := z.releaseOperationLock(instance, infinispan, ctx); err != nil {
+			return reconcile.Result{}, fmt.Errorf("unable to release operation lock of CR '%s': %w", infinispan.Name, err)
+		}
 		return z.cleanupResources(ispnClient, request, ctx)
 	default:
 		// Phase must be ZeroRunning, so wait for execution to complete
-		return z.waitForExecutionToComplete(ispnClient, request, instance)
+		return z.waitForExecutionToComplete(ispnClient, request, instance, infinispan, ctx)
 	}
 }
 
@@ -198,10 +209,31 @@ func (z *zeroCapacityController) initializeResources(request reconcile.Request,
 		return reconcile.Result{}, err
 	}
 
-	if err := infinispan.EnsureClusterStability(); err != nil {
+	if reject, err := infinispan.OperationGate(); err != nil {
 		z.Log.Info(fmt.Sprintf("Infinispan '%s' not ready: %s", clusterName, err.Error()))
+		if err := instance.UpdateCondition(v2alpha1.OperationConditionClusterHealthGatePassed, metav1.ConditionFalse, err.Error()); err != nil {
+			return reconcile.Result{}, err
+		}
+		if reject {
+			return reconcile.Result{}, instance.UpdatePhase(ZeroFailed, err)
+		}
 		return reconcile.Result{RequeueAfter: consts.DefaultWaitOnCluster}, nil
 	}
+	if err := instance.UpdateCondition(v2alpha1.OperationConditionClusterHealthGatePassed, metav1.ConditionTrue, ""); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if conflict, err := z.acquireOperationLock(instance, infinispan, ctx); err != nil {
+		if errors.IsConflict(err) {
+			return reconcile.Result{Requeue: true}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("unable to acquire operation lock of CR '%s': %w", clusterName, err)
+	} else if conflict != nil {
+		// Remain in the Initializing phase until the conflicting operation has completed
+		z.Log.Info(fmt.Sprintf("Infinispan '%s' locked by %s", clusterName, conflict))
+		return reconcile.Result{RequeueAfter: consts.DefaultWaitOnCluster},
+			instance.UpdatePhase(ZeroInitializing, fmt.Errorf("waiting for %s to complete", conflict))
+	}
 
 	podList := &corev1.PodList{}
 	podLabels := infinispan.PodSelectorLabels()
@@ -249,7 +281,7 @@ func (z *zeroCapacityController) execute(ispnClient api.Infinispan, request reco
 	return reconcile.Result{}, instance.UpdatePhase(ZeroRunning, nil)
 }
 
-func (z *zeroCapacityController) waitForExecutionToComplete(ispnClient api.Infinispan, request reconcile.Request, instance zeroCapacityResource) (reconcile.Result, error) {
+func (z *zeroCapacityController) waitForExecutionToComplete(ispnClient api.Infinispan, request reconcile.Request, instance zeroCapacityResource, infinispan *v1.Infinispan, ctx context.Context) (reconcile.Result, error) {
 	phase, err := instance.ExecStatus(ispnClient)
 
 	if err != nil || phase == ZeroFailed {
@@ -258,6 +290,9 @@ func (z *zeroCapacityController) waitForExecutionToComplete(ispnClient api.Infin
 	}
 
 	if phase == ZeroSucceeded {
+		if err := z.recordSuccess(instance, infinispan, ctx); err != nil {
+			return reconcile.Result{}, fmt.Errorf("unable to record success in the status of CR '%s': %w", infinispan.Name, err)
+		}
 		return reconcile.Result{}, instance.UpdatePhase(ZeroSucceeded, nil)
 	}
 
@@ -265,6 +300,42 @@ func (z *zeroCapacityController) waitForExecutionToComplete(ispnClient api.Infin
 	return reconcile.Result{RequeueAfter: 1 * time.Second}, nil
 }
 
+// recordSuccess aggregates the outcome of the operation into the Infinispan status, so that the most recent Backup and
+// Restore of a cluster can be observed without inspecting each of the CRs
+func (z *zeroCapacityController) recordSuccess(instance zeroCapacityResource, infinispan *v1.Infinispan, ctx context.Context) error {
+	_, err := kube.CreateOrPatch(ctx, z.Client, infinispan, func() error {
+		if infinispan.CreationTimestamp.IsZero() {
+			return errors.NewNotFound(schema.ParseGroupResource("infinispan.infinispan.org"), infinispan.Name)
+		}
+		if infinispan.Status.DisasterRecovery == nil {
+			infinispan.Status.DisasterRecovery = &v1.DisasterRecoveryStatus{}
+		}
+		instance.RecordSuccess(&infinispan.Status)
+		return nil
+	})
+	return err
+}
+
+// acquireOperationLock acquires the operation lock of the cluster, so that no other Backup, Restore, upgrade or scaling
+// is started whilst the zero-capacity pod is executing. The status is patched with an optimistic lock, so that the lock
+// cannot be acquired concurrently by another controller. Returns the lock of a conflicting operation, if any
+func (z *zeroCapacityController) acquireOperationLock(instance zeroCapacityResource, infinispan *v1.Infinispan, ctx context.Context) (*v1.OperationLock, error) {
+	patch := client.MergeFromWithOptions(infinispan.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if conflict := infinispan.AcquireOperationLock(instance.Operation(), instance.AsMeta().GetName()); conflict != nil {
+		return conflict, nil
+	}
+	return nil, z.Client.Status().Patch(ctx, infinispan, patch)
+}
+
+// releaseOperationLock releases the operation lock of the cluster if it is held by the instance
+func (z *zeroCapacityController) releaseOperationLock(instance zeroCapacityResource, infinispan *v1.Infinispan, ctx context.Context) error {
+	patch := client.MergeFrom(infinispan.DeepCopy())
+	if !infinispan.ReleaseOperationLock(instance.Operation(), instance.AsMeta().GetName()) {
+		return nil
+	}
+	return z.Client.Status().Patch(ctx, infinispan, patch)
+}
+
 func (z *zeroCapacityController) cleanupResources(ispnClient api.Infinispan, request reconcile.Request, ctx context.Context) (reconcile.Result, error) {
 	// Stop the zero-capacity server so that it leaves the Infinispan cluster
 	if z.isZeroPodReady(request, ctx) {
@@ -290,6 +361,22 @@ func (z *zeroCapacityController) zeroPodSpec(name, namespace string, podSecurity
 	if err != nil {
 		return nil, err
 	}
+	// Derive the heap size from the memory limit of the zero pod instead of the cluster pods
+	zeroIspn := ispn.DeepCopy()
+	zeroIspn.Spec.Container.Memory = zeroSpec.Container.Memory
+	if zeroSpec.Container.MaxRamPercentage != nil {
+		zeroIspn.Spec.Container.MaxRamPercentage = zeroSpec.Container.MaxRamPercentage
+	}
+	// Zero pods are short-lived and don't mount the JMX credential files
+	zeroIspn.Spec.Jmx = nil
+	// Env variables of the Backup or Restore take precedence over the env variables of the cluster
+	for _, env := range zeroSpec.Container.Env {
+		if envIndex := kube.GetEnvVarIndex(env.Name, &zeroIspn.Spec.Container.Env); envIndex >= 0 {
+			zeroIspn.Spec.Container.Env[envIndex] = env
+		} else {
+			zeroIspn.Spec.Container.Env = append(zeroIspn.Spec.Container.Env, env)
+		}
+	}
 	dataVolName := name + "-data"
 	labels := ispn.PodLabels()
 	labels["app"] = "infinispan-zero-pod"
@@ -304,12 +391,17 @@ func (z *zeroCapacityController) zeroPodSpec(name, namespace string, podSecurity
 			Annotations: ispn.PodAnnotations(),
 		},
 		Spec: corev1.PodSpec{
-			SecurityContext: podSecurityCtx,
+			SecurityContext:              podSecurityCtx,
+			ImagePullSecrets:             ispn.Spec.ImagePullSecrets,
+			ServiceAccountName:           ispn.Spec.ServiceAccountName,
+			AutomountServiceAccountToken: ispn.Spec.AutomountServiceAccountToken,
 			Containers: []corev1.Container{{
-				Image:         ispn.ImageName(),
-				Name:          InfinispanContainer,
-				Env:           PodEnv(ispn, &[]corev1.EnvVar{{Name: "IDENTITIES_BATCH", Value: consts.ServerOperatorSecurity + "/" + consts.ServerIdentitiesBatchFilename}}),
-				LivenessProbe: PodLivenessProbe(),
+				Image:           ispn.ImageName(),
+				ImagePullPolicy: ispn.ImagePullPolicy(),
+				SecurityContext: ispn.Spec.Security.ContainerSecurityContext,
+				Name:            InfinispanContainer,
+				Env:             PodEnv(zeroIspn, &[]corev1.EnvVar{{Name: "IDENTITIES_BATCH", Value: consts.ServerOperatorSecurity + "/" + consts.ServerIdentitiesBatchFilename}}),
+				LivenessProbe:   PodLivenessProbe(zeroIspn),
 				Ports: []corev1.ContainerPort{
 					{ContainerPort: consts.InfinispanAdminPort, Name: consts.InfinispanAdminPortName, Protocol: corev1.ProtocolTCP},
 					{ContainerPort: consts.InfinispanPingPort, Name: consts.InfinispanPingPortName, Protocol: corev1.ProtocolTCP},
@@ -317,16 +409,16 @@ func (z *zeroCapacityController) zeroPodSpec(name, namespace string, podSecurity
 				ReadinessProbe: PodReadinessProbe(),
 				Resources:      *podResources,
 				StartupProbe:   PodStartupProbe(),
-				Args:           []string{"-c", "operator/infinispan-zero.xml", "-l", OperatorConfMountPath + "/log4j.xml"},
+				Args:           zeroServerArgs(ispn),
 				VolumeMounts: []corev1.VolumeMount{
 					{
 						Name:      ConfigVolumeName,
-						MountPath: OperatorConfMountPath,
+						MountPath: OperatorConfMountPath(ispn),
 					},
 					// Utilise Ephemeral vol as we're only interested in data related to CR
 					{
 						Name:      dataVolName,
-						MountPath: DataMountPath,
+						MountPath: DataMountPath(ispn),
 					},
 					// Mount configured volume at /zero path so that any created content is stored independent of server data
 					{
@@ -376,3 +468,12 @@ func (z *zeroCapacityController) zeroPodSpec(name, namespace string, podSecurity
 	}
 	return pod, nil
 }
+
+// zeroServerArgs returns the start arguments of a zero-capacity server, honouring the server root of the cluster
+func zeroServerArgs(ispn *v1.Infinispan) []string {
+	args := []string{"-c", "operator/infinispan-zero.xml", "-l", OperatorConfMountPath(ispn) + "/log4j.xml"}
+	if ispn.Spec.Container.ServerRoot != "" {
+		args = append([]string{"-s", ispn.Spec.Container.ServerRoot}, args...)
+	}
+	return args
+}
@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"testing"
+
+	v1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestZeroPodSpec(t *testing.T) {
+	pullSecrets := []corev1.LocalObjectReference{{Name: "registry-secret"}}
+	ispn := &v1.Infinispan{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan", Namespace: "testing-namespace"},
+		Spec: v1.InfinispanSpec{
+			Image:              pointer.StringPtr("quay.io/infinispan/server:13.0"),
+			ImagePullSecrets:   pullSecrets,
+			ImagePullPolicy:    corev1.PullNever,
+			ServiceAccountName: "workload-identity",
+		},
+	}
+	zeroSpec := &zeroCapacitySpec{
+		Volume: zeroCapacityVolumeSpec{
+			MountPath:    "/opt/infinispan/backups",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+		Container: v1.InfinispanContainerSpec{Memory: "1Gi"},
+	}
+	z := &zeroCapacityController{}
+
+	pod, err := z.zeroPodSpec("example-backup", ispn.Namespace, nil, ispn, zeroSpec)
+	require.NoError(t, err)
+	assert.Equal(t, pullSecrets, pod.Spec.ImagePullSecrets)
+	assert.Equal(t, corev1.PullNever, pod.Spec.Containers[0].ImagePullPolicy)
+	assert.Equal(t, "workload-identity", pod.Spec.ServiceAccountName)
+
+	ispn.Spec.ImagePullPolicy = ""
+	pod, err = z.zeroPodSpec("example-backup", ispn.Namespace, nil, ispn, zeroSpec)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.PullIfNotPresent, pod.Spec.Containers[0].ImagePullPolicy)
+}
+
+func TestZeroPodSpecMaxHeapSize(t *testing.T) {
+	ispn := &v1.Infinispan{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan", Namespace: "testing-namespace"},
+		Spec: v1.InfinispanSpec{
+			Image:     pointer.StringPtr("quay.io/infinispan/server:13.0"),
+			Service:   v1.InfinispanServiceSpec{Type: v1.ServiceTypeDataGrid},
+			Container: v1.InfinispanContainerSpec{Memory: "4Gi", MaxRamPercentage: pointer.Int32Ptr(50)},
+		},
+	}
+	zeroSpec := &zeroCapacitySpec{
+		Volume: zeroCapacityVolumeSpec{
+			MountPath:    "/opt/infinispan/backups",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+		Container: v1.InfinispanContainerSpec{Memory: "1Gi"},
+	}
+	z := &zeroCapacityController{}
+
+	pod, err := z.zeroPodSpec("example-backup", ispn.Namespace, nil, ispn, zeroSpec)
+	require.NoError(t, err)
+	env := pod.Spec.Containers[0].Env
+	assert.Equal(t, "-Xmx512M", env[kube.GetEnvVarIndex("JAVA_OPTIONS", &env)].Value)
+
+	zeroSpec.Container.MaxRamPercentage = pointer.Int32Ptr(25)
+	pod, err = z.zeroPodSpec("example-backup", ispn.Namespace, nil, ispn, zeroSpec)
+	require.NoError(t, err)
+	env = pod.Spec.Containers[0].Env
+	assert.Equal(t, "-Xmx256M", env[kube.GetEnvVarIndex("JAVA_OPTIONS", &env)].Value)
+}
+
+func TestRecordSuccess(t *testing.T) {
+	status := &v1.InfinispanStatus{DisasterRecovery: &v1.DisasterRecoveryStatus{}}
+
+	backup := &backupResource{
+		instance: &v2alpha1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "example-backup"}},
+		size:     "15Mi",
+	}
+	backup.RecordSuccess(status)
+	require.NotNil(t, status.DisasterRecovery.LastBackup)
+	assert.Equal(t, "example-backup", status.DisasterRecovery.LastBackup.Name)
+	assert.Equal(t, "example-backup", status.DisasterRecovery.LastBackup.PVC)
+	assert.Equal(t, "15Mi", status.DisasterRecovery.LastBackup.Size)
+	assert.False(t, status.DisasterRecovery.LastBackup.CompletionTime.IsZero())
+	assert.Nil(t, status.DisasterRecovery.LastRestore)
+
+	restore := &restore{
+		instance: &v2alpha1.Restore{
+			ObjectMeta: metav1.ObjectMeta{Name: "example-restore"},
+			Spec:       v2alpha1.RestoreSpec{Backup: "example-backup"},
+		},
+	}
+	restore.RecordSuccess(status)
+	require.NotNil(t, status.DisasterRecovery.LastRestore)
+	assert.Equal(t, "example-restore", status.DisasterRecovery.LastRestore.Name)
+	assert.Equal(t, "example-backup", status.DisasterRecovery.LastRestore.Backup)
+	// Recording a Restore must not discard the most recent Backup
+	assert.Equal(t, "example-backup", status.DisasterRecovery.LastBackup.Name)
+}
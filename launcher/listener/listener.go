@@ -2,7 +2,6 @@ package listener
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
 	"github.com/infinispan/infinispan-operator/controllers"
 	"github.com/infinispan/infinispan-operator/launcher"
@@ -14,6 +13,7 @@ import (
 	v1 "github.com/infinispan/infinispan-operator/api/v1"
 	"github.com/infinispan/infinispan-operator/api/v2alpha1"
 	"github.com/infinispan/infinispan-operator/controllers/constants"
+	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
 	"github.com/infinispan/infinispan-operator/pkg/kubernetes"
 	"github.com/infinispan/infinispan-operator/pkg/mime"
 	"github.com/r3labs/sse/v2"
@@ -66,8 +66,7 @@ func New(ctx context.Context, p Parameters) {
 
 	user := secret.Data[constants.AdminUsernameKey]
 	password := secret.Data[constants.AdminPasswordKey]
-	service := fmt.Sprintf("%s.%s.svc.cluster.local:11223", infinispan.GetAdminServiceName(), p.Namespace)
-	serviceWithAuth := fmt.Sprintf("http://%s:%s@%s", user, password, service)
+	service := fmt.Sprintf("%s.%s.svc.cluster.local:%d", infinispan.GetAdminServiceName(), p.Namespace, constants.InfinispanAdminPort)
 
 	cacheListener := &controllers.CacheListener{
 		Infinispan: infinispan,
@@ -113,9 +112,12 @@ func New(ctx context.Context, p Parameters) {
 	}
 
 	log.Infof("Consuming streams from service '%s'\n", service)
-	containerSse := sse.NewClient(serviceWithAuth + "/rest/v2/container/config?action=listen&includeCurrentState=true")
-	containerSse.Connection.Transport = &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	// The admin endpoint does not use TLS, so authenticate with DIGEST like the operator to never send the password
+	containerSse := sse.NewClient("http://" + service + "/rest/v2/container/config?action=listen&includeCurrentState=true")
+	containerSse.Connection.Transport = &httpClient.DigestTransport{
+		Username:  string(user),
+		Password:  string(password),
+		Transport: http.DefaultTransport,
 	}
 	containerSse.Headers = map[string]string{
 		"Accept": string(mime.ApplicationYaml),
@@ -0,0 +1,238 @@
+package manifests
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	pipelineContext "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/context"
+	pipelineBuilder "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/pipeline"
+	routev1 "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	ingressv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// maxPipelinePasses the number of times that the pipeline is processed before rendering fails
+const maxPipelinePasses = 10
+
+var (
+	scheme = runtime.NewScheme()
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	utilruntime.Must(ispnv1.AddToScheme(scheme))
+	utilruntime.Must(routev1.AddToScheme(scheme))
+	utilruntime.Must(ingressv1.AddToScheme(scheme))
+}
+
+type Parameters struct {
+	// File containing the Infinispan CR and any user provided resources it references. "-" reads from stdin
+	File      string
+	Namespace string
+	OpenShift bool
+	Out       io.Writer
+	// Secrets rendered with their values when true, otherwise only the keys are rendered
+	ShowSecrets bool
+	ZapOptions  *zap.Options
+}
+
+// renderedTypes the resource types printed by Render, in the order that they're printed
+var renderedTypes = []client.ObjectList{
+	&corev1.SecretList{},
+	&corev1.ConfigMapList{},
+	&corev1.ServiceList{},
+	&appsv1.DeploymentList{},
+	&appsv1.StatefulSetList{},
+	&ingressv1.IngressList{},
+	&routev1.RouteList{},
+}
+
+func New(p Parameters) {
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(p.ZapOptions)))
+	if err := Render(context.Background(), p); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Render executes the Infinispan pipeline against an in-memory client and prints the resources that the operator
+// creates for the Infinispan CR. Rendering stops once the StatefulSet is provisioned, as the subsequent handlers
+// require running pods
+func Render(ctx context.Context, p Parameters) error {
+	in := os.Stdin
+	if p.File != "-" {
+		f, err := os.Open(p.File)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	objects, err := decode(in, p.Namespace)
+	if err != nil {
+		return err
+	}
+
+	var i *ispnv1.Infinispan
+	inputs := make(map[string]struct{}, len(objects))
+	for _, obj := range objects {
+		if ispn, ok := obj.(*ispnv1.Infinispan); ok {
+			if i != nil {
+				return fmt.Errorf("only a single Infinispan CR can be rendered")
+			}
+			i = ispn
+			continue
+		}
+		inputs[key(obj)] = struct{}{}
+	}
+	if i == nil {
+		return fmt.Errorf("no Infinispan CR found in '%s'", p.File)
+	}
+	i.Default()
+	i.CreationTimestamp = metav1.Now()
+
+	c := kube.NewTypedObjectClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(), scheme)
+	supportedTypes := map[schema.GroupVersionKind]struct{}{
+		infinispan.IngressGVK: {},
+	}
+	if p.OpenShift {
+		supportedTypes[infinispan.RouteGVK] = struct{}{}
+	}
+	defaultLabels, defaultAnnotations, err := ispnv1.LoadDefaultLabelsAndAnnotations()
+	if err != nil {
+		return err
+	}
+
+	pipeline := pipelineBuilder.Builder().
+		For(i).
+		WithAnnotations(defaultAnnotations).
+		WithContextProvider(pipelineContext.Provider(c, scheme, &kube.Kubernetes{Client: c}, &record.FakeRecorder{})).
+		WithLabels(defaultLabels).
+		WithLogger(ctrl.Log.WithName("manifests")).
+		WithSupportedTypes(supportedTypes).
+		Build()
+
+	// Handlers request a retry without an error once they have updated the Infinispan CR, e.g. to apply the default
+	// metadata, so the pipeline is processed again until the StatefulSet is provisioned
+	statefulSetKey := types.NamespacedName{Namespace: i.Namespace, Name: i.GetStatefulSetName()}
+	var statefulSetErr, pipelineErr error
+	for pass := 0; pass < maxPipelinePasses; pass++ {
+		var retry bool
+		retry, _, pipelineErr = pipeline.Process(ctx)
+		if statefulSetErr = c.Get(ctx, statefulSetKey, &appsv1.StatefulSet{}); statefulSetErr == nil || pipelineErr != nil || !retry {
+			break
+		}
+	}
+
+	if statefulSetErr != nil {
+		if pipelineErr != nil {
+			return fmt.Errorf("unable to render manifests: %w", pipelineErr)
+		}
+		return fmt.Errorf("unable to render manifests, StatefulSet not provisioned: %w", statefulSetErr)
+	}
+
+	for _, list := range renderedTypes {
+		if err := c.List(ctx, list, client.InNamespace(i.Namespace)); err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		sort.Slice(items, func(a, b int) bool {
+			return items[a].(client.Object).GetName() < items[b].(client.Object).GetName()
+		})
+		for _, item := range items {
+			obj := item.(client.Object)
+			if _, exists := inputs[key(obj)]; exists {
+				continue
+			}
+			if secret, ok := obj.(*corev1.Secret); ok && !p.ShowSecrets {
+				redact(secret)
+			}
+			gvk, err := apiutil.GVKForObject(obj, scheme)
+			if err != nil {
+				return err
+			}
+			obj.GetObjectKind().SetGroupVersionKind(gvk)
+			obj.SetResourceVersion("")
+			manifest, err := sigsyaml.Marshal(obj)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(p.Out, "---\n%s", manifest); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decode all of the resources in the YAML or JSON stream, setting the namespace of namespaced resources when absent
+func decode(r io.Reader, namespace string) ([]client.Object, error) {
+	var objects []client.Object
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		u := &unstructured.Unstructured{}
+		if err := decoder.Decode(&u.Object); err != nil {
+			if err == io.EOF {
+				return objects, nil
+			}
+			return nil, fmt.Errorf("unable to decode resources: %w", err)
+		}
+		if len(u.Object) == 0 {
+			continue
+		}
+		obj, err := scheme.New(u.GroupVersionKind())
+		if err != nil {
+			return nil, err
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+			return nil, fmt.Errorf("unable to convert %s '%s': %w", u.GetKind(), u.GetName(), err)
+		}
+		o := obj.(client.Object)
+		if o.GetNamespace() == "" {
+			o.SetNamespace(namespace)
+		}
+		objects = append(objects, o)
+	}
+}
+
+// redact removes the values of a Secret, leaving a skeleton of its keys
+func redact(secret *corev1.Secret) {
+	for k := range secret.Data {
+		secret.Data[k] = []byte{}
+	}
+	for k := range secret.StringData {
+		secret.StringData[k] = ""
+	}
+}
+
+func key(obj client.Object) string {
+	return fmt.Sprintf("%T/%s", obj, obj.GetName())
+}
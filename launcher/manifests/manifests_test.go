@@ -0,0 +1,82 @@
+package manifests
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+const dataGrid = `apiVersion: infinispan.org/v1
+kind: Infinispan
+metadata:
+  name: example-infinispan
+spec:
+  replicas: 2
+  service:
+    type: DataGrid
+`
+
+func TestRender(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "infinispan.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(dataGrid), 0600))
+
+	out := &bytes.Buffer{}
+	require.NoError(t, Render(context.TODO(), Parameters{
+		File:       file,
+		Namespace:  "testing-namespace",
+		Out:        out,
+		ZapOptions: &zap.Options{},
+	}))
+
+	manifests := out.String()
+	assert.NotContains(t, manifests, "\nkind: Infinispan\n", "the input CR is not rendered")
+
+	// The output must be valid input of kubectl apply
+	objects, err := decode(strings.NewReader(manifests), "")
+	require.NoError(t, err)
+	rendered := map[string]client.Object{}
+	for _, obj := range objects {
+		assert.Equal(t, "testing-namespace", obj.GetNamespace(), key(obj))
+		assert.Empty(t, obj.GetResourceVersion(), key(obj))
+		rendered[key(obj)] = obj
+	}
+
+	statefulSet, ok := rendered["*v1.StatefulSet/example-infinispan"].(*appsv1.StatefulSet)
+	require.True(t, ok, "StatefulSet not rendered: %v", keys(rendered))
+	assert.Equal(t, int32(2), *statefulSet.Spec.Replicas)
+	assert.Equal(t, "example-infinispan-configuration", statefulSet.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+
+	for _, service := range []string{"example-infinispan", "example-infinispan-admin", "example-infinispan-ping"} {
+		assert.Contains(t, rendered, "*v1.Service/"+service)
+	}
+	configMap, ok := rendered["*v1.ConfigMap/example-infinispan-configuration"].(*corev1.ConfigMap)
+	require.True(t, ok, "ConfigMap not rendered: %v", keys(rendered))
+	assert.Contains(t, configMap.Data, "infinispan.xml")
+
+	// Secrets are redacted unless requested
+	secret, ok := rendered["*v1.Secret/example-infinispan-generated-operator-secret"].(*corev1.Secret)
+	require.True(t, ok, "admin Secret not rendered: %v", keys(rendered))
+	for k, v := range secret.Data {
+		assert.Empty(t, v, k)
+	}
+}
+
+func keys(objects map[string]client.Object) []string {
+	keys := make([]string, 0, len(objects))
+	for k := range objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
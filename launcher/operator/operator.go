@@ -12,11 +12,16 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
@@ -79,6 +84,8 @@ func NewWithContext(ctx context.Context, p Parameters) {
 		HealthProbeBindAddress: p.HealthProbeBindAddress,
 		LeaderElection:         p.LeaderElection,
 		LeaderElectionID:       "632512e4.infinispan.org",
+		// The field manager of the operator's writes identifies the changes made by other field managers
+		ClientBuilder: kubernetes.NewFieldOwnerClientBuilder(kubernetes.OperatorFieldManager),
 	}
 
 	if strings.Contains(namespace, ",") {
@@ -87,12 +94,54 @@ func NewWithContext(ctx context.Context, p Parameters) {
 		options.Namespace = namespace
 	}
 
+	// Namespaces opt in with a label to be managed by an operator that is installed cluster wide
+	selector, err := kubernetes.GetWatchNamespaceSelector()
+	if err != nil {
+		setupLog.Error(err, "failed to get watch namespace selector")
+		os.Exit(1)
+	}
+	var namespaceBindings *controllers.NamespaceBindings
+	if selector != nil {
+		if namespace != "" {
+			setupLog.Error(fmt.Errorf("%s requires %s to be empty", kubernetes.WatchNamespaceSelectorEnvVar, kubernetes.WatchNamespaceEnvVar), "invalid watch namespace selector")
+			os.Exit(1)
+		}
+		if namespaceBindings, err = newNamespaceBindings(ctx, selector); err != nil {
+			setupLog.Error(err, "unable to bind the operator to the namespaces that opted in")
+			os.Exit(1)
+		}
+		setupLog.Info("Watching namespaces that match the selector", "selector", selector.String(), "namespaces", namespaceBindings.Namespaces)
+		// The operator only watches, and only has permissions in, its own namespace and the namespaces that opted in.
+		// Namespaces are added to and removed from the cache as they opt in and out
+		options.NewCache = kubernetes.NamespacedCacheBuilder(append(namespaceBindings.Namespaces, namespaceBindings.ServiceAccount.Namespace))
+		// Cluster scoped resources cannot be read from a cache of several namespaces
+		options.ClientDisableCacheFor = []client.Object{
+			&corev1.Namespace{},
+			&corev1.Node{},
+			&storagev1.StorageClass{},
+			&rbacv1.ClusterRoleBinding{},
+		}
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	if namespaceBindings != nil {
+		namespaceBindings.Cache = mgr.GetCache().(*kubernetes.NamespacedCache)
+		// Namespaces are cluster scoped, so they are watched with a cache that is separate from the cache of the manager
+		if namespaceBindings.Informers, err = cache.New(mgr.GetConfig(), cache.Options{Scheme: scheme, Mapper: mgr.GetRESTMapper()}); err != nil {
+			setupLog.Error(err, "unable to watch namespaces")
+			os.Exit(1)
+		}
+		if err = mgr.Add(namespaceBindings); err != nil {
+			setupLog.Error(err, "unable to add the RoleBindings of the namespaces that opted in")
+			os.Exit(1)
+		}
+	}
+
 	if err = (&controllers.InfinispanReconciler{}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Infinispan")
 		os.Exit(1)
@@ -164,3 +213,34 @@ func NewWithContext(ctx context.Context, p Parameters) {
 		os.Exit(1)
 	}
 }
+
+// newNamespaceBindings binds the operator to the namespaces that opted in before the manager starts, so that the
+// operator has the permissions to watch them
+func newNamespaceBindings(ctx context.Context, selector labels.Selector) (*controllers.NamespaceBindings, error) {
+	c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+	c = kubernetes.NewFieldOwnerClient(c, kubernetes.OperatorFieldManager)
+	operatorNamespace, err := kubernetes.GetOperatorNamespace()
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine the operator namespace: %w", err)
+	}
+	bindings := &controllers.NamespaceBindings{
+		Client:         c,
+		Selector:       selector,
+		ClusterRole:    os.Getenv(kubernetes.WatchNamespaceClusterRoleEnvVar),
+		ServiceAccount: rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: operatorNamespace},
+	}
+	if bindings.ClusterRole != "" {
+		pod, err := kubernetes.GetPod(ctx, c, operatorNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine the operator ServiceAccount: %w", err)
+		}
+		bindings.ServiceAccount.Name = pod.Spec.ServiceAccountName
+	}
+	if bindings.Namespaces, err = bindings.Sync(ctx); err != nil {
+		return nil, err
+	}
+	return bindings, nil
+}
@@ -0,0 +1,114 @@
+package tokenreview
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/launcher"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/security"
+	corev1 "k8s.io/api/core/v1"
+	authenticationv1 "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+type Parameters struct {
+	// The address that the introspection endpoint listens on
+	Address string
+	// The audiences that ServiceAccount tokens must be issued for
+	Audiences []string
+	// The directory that contains the token and the ca.crt of the ServiceAccount that reviews tokens
+	TokenDir   string
+	ZapOptions *zap.Options
+}
+
+// New serves the token introspection endpoint of the token realm of an Infinispan server. The sidecar authenticates
+// with a projected token of the pod's ServiceAccount that is only mounted in the sidecar, so that the server container
+// is not granted access to the TokenReview API
+func New(p Parameters) {
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(p.ZapOptions)))
+	log := ctrl.Log.WithName("token-review")
+	log.Info(fmt.Sprintf("Starting Infinispan token review Version: %s", launcher.Version))
+
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		log.Error(fmt.Errorf("KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT must be defined"), "unable to locate the Kubernetes API")
+		os.Exit(1)
+	}
+	client, err := authenticationv1.NewForConfig(&rest.Config{
+		Host:            "https://" + net.JoinHostPort(host, port),
+		BearerTokenFile: filepath.Join(p.TokenDir, corev1.ServiceAccountTokenKey),
+		TLSClientConfig: rest.TLSClientConfig{
+			CAFile: filepath.Join(p.TokenDir, corev1.ServiceAccountRootCAKey),
+		},
+	})
+	if err != nil {
+		log.Error(err, "failed to create client")
+		os.Exit(1)
+	}
+
+	listeners, err := listen(p.Address)
+	if err != nil {
+		log.Error(err, "unable to listen", "address", p.Address)
+		os.Exit(1)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(constants.TokenReviewIntrospectionPath, security.TokenIntrospectionHandler(client.TokenReviews(), p.Audiences, log))
+	mux.HandleFunc(constants.TokenReviewHealthPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	log.Info("Serving token introspection", "address", p.Address, "audiences", p.Audiences)
+	errs := make(chan error, len(listeners))
+	for _, l := range listeners {
+		go func(l net.Listener) { errs <- http.Serve(l, mux) }(l)
+	}
+	log.Error(<-errs, "token introspection endpoint stopped")
+	os.Exit(1)
+}
+
+// Probe returns an error if the token introspection endpoint at address is not healthy
+func Probe(address string) error {
+	client := &http.Client{Timeout: 5 * time.Second}
+	rsp, err := client.Get(fmt.Sprintf("http://%s%s", address, constants.TokenReviewHealthPath))
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", rsp.Status)
+	}
+	return nil
+}
+
+// listen listens on every address that the host of address resolves to, so that clients reach the endpoint whichever
+// address they resolve the host to first, e.g. 127.0.0.1 or ::1 for localhost. Addresses of an IP family that is not
+// available are skipped
+func listen(address string) ([]net.Listener, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return nil, err
+	}
+	var listeners []net.Listener
+	for _, ip := range ips {
+		var l net.Listener
+		if l, err = net.Listen("tcp", net.JoinHostPort(ip.String(), port)); err != nil {
+			continue
+		}
+		listeners = append(listeners, l)
+	}
+	if len(listeners) == 0 {
+		return nil, fmt.Errorf("unable to listen on any address of %s: %w", host, err)
+	}
+	return listeners, nil
+}
@@ -0,0 +1,41 @@
+package tokenreview
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenOnLoopbackAddresses(t *testing.T) {
+	free, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := strconv.Itoa(free.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, free.Close())
+
+	address := net.JoinHostPort("localhost", port)
+	listeners, err := listen(address)
+	require.NoError(t, err)
+	ips, err := net.LookupIP("localhost")
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(listeners), len(ips))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(constants.TokenReviewHealthPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	for _, l := range listeners {
+		defer l.Close()
+		go func(l net.Listener) { _ = http.Serve(l, mux) }(l)
+		// Every address that localhost resolves to serves the endpoint
+		assert.NoError(t, Probe(l.Addr().String()))
+	}
+	assert.NoError(t, Probe(address))
+
+	_, err = listen("localhost:not-a-port")
+	assert.Error(t, err)
+}
@@ -5,9 +5,13 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/infinispan/infinispan-operator/controllers/constants"
 	"github.com/infinispan/infinispan-operator/launcher/listener"
+	"github.com/infinispan/infinispan-operator/launcher/manifests"
 	"github.com/infinispan/infinispan-operator/launcher/operator"
+	"github.com/infinispan/infinispan-operator/launcher/tokenreview"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	// +kubebuilder:scaffold:imports
 )
@@ -35,6 +39,22 @@ func main() {
 	listenerCluster := listenerFs.String("cluster", "", "The name of the Infinispan cluster.")
 	zapOpts.BindFlags(listenerFs)
 
+	// Manifests Flags
+	manifestsFs := flag.NewFlagSet("manifests", flag.ExitOnError)
+	manifestsFile := manifestsFs.String("file", "-", "The file containing the Infinispan CR and any Secrets or ConfigMaps that it references. Reads from stdin by default.")
+	manifestsNs := manifestsFs.String("namespace", "default", "The namespace of resources that don't define one.")
+	manifestsOpenShift := manifestsFs.Bool("openshift", false, "Render the resources created on OpenShift, such as Routes.")
+	manifestsShowSecrets := manifestsFs.Bool("show-secrets", false, "Render Secret values instead of only their keys.")
+	zapOpts.BindFlags(manifestsFs)
+
+	// Token Review Flags
+	tokenReviewFs := flag.NewFlagSet("token-review", flag.ExitOnError)
+	tokenReviewAddr := tokenReviewFs.String("address", fmt.Sprintf("%s:%d", constants.TokenReviewHost, constants.TokenReviewPort), "The address the token introspection endpoint binds to.")
+	tokenReviewProbe := tokenReviewFs.Bool("probe", false, "Check the health of the token introspection endpoint at -address and exit.")
+	tokenReviewAudiences := tokenReviewFs.String("audiences", "", "Comma separated list of the audiences that tokens must be issued for.")
+	tokenReviewDir := tokenReviewFs.String("token-dir", constants.TokenReviewRoot, "The directory of the token and ca.crt of the ServiceAccount that reviews tokens.")
+	zapOpts.BindFlags(tokenReviewFs)
+
 	switch os.Args[1] {
 	case "operator":
 		parse(operatorFs, os.Args[2:])
@@ -55,13 +75,42 @@ func main() {
 			Cluster:    *listenerCluster,
 			ZapOptions: &zapOpts,
 		})
+	case "manifests":
+		parse(manifestsFs, os.Args[2:])
+		manifests.New(manifests.Parameters{
+			File:        *manifestsFile,
+			Namespace:   *manifestsNs,
+			OpenShift:   *manifestsOpenShift,
+			Out:         os.Stdout,
+			ShowSecrets: *manifestsShowSecrets,
+			ZapOptions:  &zapOpts,
+		})
+	case "token-review":
+		parse(tokenReviewFs, os.Args[2:])
+		if *tokenReviewProbe {
+			if err := tokenreview.Probe(*tokenReviewAddr); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			return
+		}
+		if *tokenReviewAudiences == "" {
+			tokenReviewFs.Usage()
+			os.Exit(1)
+		}
+		tokenreview.New(tokenreview.Parameters{
+			Address:    *tokenReviewAddr,
+			Audiences:  strings.Split(*tokenReviewAudiences, ","),
+			TokenDir:   *tokenReviewDir,
+			ZapOptions: &zapOpts,
+		})
 	default:
 		exit()
 	}
 }
 
 func exit() {
-	fmt.Println("expected 'operator' or 'listener' subcommands")
+	fmt.Println("expected 'operator', 'listener', 'manifests' or 'token-review' subcommands")
 	os.Exit(1)
 }
 
@@ -0,0 +1,156 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule a parsed cron expression in the standard five field format "minute hour day-of-month month day-of-week"
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// As with cron, if both the day of month and day of week are restricted then either of them must match
+	domRestricted, dowRestricted bool
+}
+
+type bounds struct {
+	name     string
+	min, max int
+}
+
+var fieldBounds = []bounds{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// searchLimit the period after which Next gives up on schedules that can never be satisfied, e.g. "0 0 30 2 *"
+const searchLimit = 5
+
+// Parse parses a five field cron expression. Each field is either "*" or a comma separated list of values and ranges,
+// e.g. "1-5", with an optional step, e.g. "*/15". Sunday is either 0 or 7 in the day of week field
+func Parse(spec string) (*Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != len(fieldBounds) {
+		return nil, fmt.Errorf("expected %d fields in cron expression '%s', found %d", len(fieldBounds), spec, len(fields))
+	}
+
+	bits := make([]uint64, len(fields))
+	for idx, field := range fields {
+		b, err := parseField(field, fieldBounds[idx])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression '%s': %w", spec, err)
+		}
+		bits[idx] = b
+	}
+	// Sunday may be configured as either 0 or 7
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+	return &Schedule{
+		minute:        bits[0],
+		hour:          bits[1],
+		dom:           bits[2],
+		month:         bits[3],
+		dow:           bits[4],
+		domRestricted: !strings.HasPrefix(fields[2], "*"),
+		dowRestricted: !strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangeAndStep := strings.Split(part, "/")
+		if len(rangeAndStep) > 2 {
+			return 0, fmt.Errorf("%s '%s' has more than one step", b.name, part)
+		}
+
+		start, end := b.min, b.max
+		if rangeAndStep[0] != "*" {
+			limits := strings.Split(rangeAndStep[0], "-")
+			if len(limits) > 2 {
+				return 0, fmt.Errorf("%s '%s' is not a valid range", b.name, part)
+			}
+			var err error
+			if start, err = parseValue(limits[0], b); err != nil {
+				return 0, err
+			}
+			if len(limits) == 2 {
+				if end, err = parseValue(limits[1], b); err != nil {
+					return 0, err
+				}
+			} else if len(rangeAndStep) == 1 {
+				end = start
+			}
+			if start > end {
+				return 0, fmt.Errorf("%s range '%s' starts after it ends", b.name, part)
+			}
+		}
+
+		step := 1
+		if len(rangeAndStep) == 2 {
+			var err error
+			if step, err = strconv.Atoi(rangeAndStep[1]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("%s step '%s' must be a positive integer", b.name, rangeAndStep[1])
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(value string, b bounds) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s '%s' is not an integer", b.name, value)
+	}
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("%s '%d' must be between %d and %d", b.name, v, b.min, b.max)
+	}
+	return v, nil
+}
+
+// Next returns the first time after t that matches the schedule, in the location of t, or the zero time if the
+// schedule is not satisfied within the next five years
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(searchLimit, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
@@ -0,0 +1,67 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"1-2-3 * * * *",
+		"*/2/2 * * * *",
+	} {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestNext(t *testing.T) {
+	// Monday
+	from := time.Date(2022, time.March, 7, 10, 30, 15, 0, time.UTC)
+
+	testTable := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2022, time.March, 7, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2022, time.March, 7, 10, 45, 0, 0, time.UTC)},
+		{"0 20 * * *", time.Date(2022, time.March, 7, 20, 0, 0, 0, time.UTC)},
+		{"0 7 * * 1-5", time.Date(2022, time.March, 8, 7, 0, 0, 0, time.UTC)},
+		{"0 20 * * 5", time.Date(2022, time.March, 11, 20, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2022, time.March, 13, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * 0", time.Date(2022, time.March, 13, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, s.Next(from))
+		})
+	}
+}
+
+func TestNextLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Prague")
+	require.NoError(t, err)
+	s, err := Parse("0 20 * * *")
+	require.NoError(t, err)
+
+	next := s.Next(time.Date(2022, time.March, 7, 10, 0, 0, 0, time.UTC).In(loc))
+	assert.Equal(t, time.Date(2022, time.March, 7, 19, 0, 0, 0, time.UTC), next.UTC())
+}
@@ -8,11 +8,28 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
+var requestDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "infinispan_operator_server_request_duration_seconds",
+		Help:    "Duration of the REST requests executed by the operator against Infinispan servers",
+		Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	},
+	[]string{"method", "code"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(requestDuration)
+}
+
 type Credentials struct {
 	Username string
 	Password string
@@ -46,11 +63,11 @@ func (c *Client) CloneForPod(podName string) *Client {
 }
 
 func (c *Client) Get(path string, headers map[string]string) (*http.Response, error) {
-	return c.executeCurlCommand(path, headers)
+	return c.executeCurlCommand(http.MethodGet, path, headers)
 }
 
 func (c *Client) Head(path string, headers map[string]string) (*http.Response, error) {
-	return c.executeCurlCommand(path, headers, "--head")
+	return c.executeCurlCommand(http.MethodHead, path, headers, "--head")
 }
 
 func (c *Client) Post(path, payload string, headers map[string]string) (*http.Response, error) {
@@ -58,7 +75,7 @@ func (c *Client) Post(path, payload string, headers map[string]string) (*http.Re
 	if payload != "" {
 		data = fmt.Sprintf("-d $'%s'", payload)
 	}
-	return c.executeCurlCommand(path, headers, data, "-X POST")
+	return c.executeCurlCommand(http.MethodPost, path, headers, data, "-X POST")
 }
 
 func (c *Client) Put(path, payload string, headers map[string]string) (*http.Response, error) {
@@ -66,14 +83,23 @@ func (c *Client) Put(path, payload string, headers map[string]string) (*http.Res
 	if payload != "" {
 		data = fmt.Sprintf("-d $'%s'", payload)
 	}
-	return c.executeCurlCommand(path, headers, data, "-X PUT")
+	return c.executeCurlCommand(http.MethodPut, path, headers, data, "-X PUT")
 }
 
 func (c *Client) Delete(path string, headers map[string]string) (*http.Response, error) {
-	return c.executeCurlCommand(path, headers, "-X DELETE")
+	return c.executeCurlCommand(http.MethodDelete, path, headers, "-X DELETE")
 }
 
-func (c *Client) executeCurlCommand(path string, headers map[string]string, args ...string) (*http.Response, error) {
+func (c *Client) executeCurlCommand(method, path string, headers map[string]string, args ...string) (rsp *http.Response, err error) {
+	defer func(start time.Time) {
+		// Requests that fail before a response is received are recorded with code "0"
+		code := 0
+		if err == nil && rsp != nil {
+			code = rsp.StatusCode
+		}
+		requestDuration.WithLabelValues(method, strconv.Itoa(code)).Observe(time.Since(start).Seconds())
+	}(time.Now())
+
 	httpURL := fmt.Sprintf("%s://%s:%d/%s", c.Config.Protocol, c.Config.Podname, c.Config.Port, path)
 
 	headerStr := headerString(headers)
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// DigestTransport is a http.RoundTripper that authenticates requests with the DIGEST mechanism of RFC 7616, so that
+// the password is never sent over the network. Requests are first sent without credentials and resent with the response
+// to the challenge of the server. A request rejected because its nonce is stale is resent once with the new nonce. Only
+// requests without a body can be resent, which is sufficient for GET requests
+type DigestTransport struct {
+	Username string
+	Password string
+	// The transport used to send the requests, if nil http.DefaultTransport is used
+	Transport http.RoundTripper
+
+	nonceCount uint32
+}
+
+func (t *DigestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rsp, err := t.transport().RoundTrip(req)
+	// A stale nonce is answered with a new challenge, which is accepted once
+	for attempt := 0; attempt < 2; attempt++ {
+		if err != nil || rsp.StatusCode != http.StatusUnauthorized || req.Body != nil {
+			return rsp, err
+		}
+		challenge, ok := parseDigestChallenge(rsp.Header.Values("WWW-Authenticate"))
+		if !ok || (attempt > 0 && !strings.EqualFold(challenge["stale"], "true")) {
+			return rsp, nil
+		}
+		_, _ = io.Copy(io.Discard, rsp.Body)
+		_ = rsp.Body.Close()
+
+		cnonce := make([]byte, 16)
+		if _, err := rand.Read(cnonce); err != nil {
+			return nil, fmt.Errorf("unable to generate digest client nonce: %w", err)
+		}
+		nc := fmt.Sprintf("%08x", atomic.AddUint32(&t.nonceCount, 1))
+		authorization, err := digestAuthorization(challenge, t.Username, t.Password, req.Method, req.URL.RequestURI(), nc, hex.EncodeToString(cnonce))
+		if err != nil {
+			return nil, err
+		}
+		authReq := req.Clone(req.Context())
+		authReq.Header.Set("Authorization", authorization)
+		rsp, err = t.transport().RoundTrip(authReq)
+	}
+	return rsp, err
+}
+
+func (t *DigestTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+	return http.DefaultTransport
+}
+
+// digestAuthorization returns the Authorization header that answers the challenge with the nonce count nc and the
+// client nonce cnonce
+func digestAuthorization(challenge map[string]string, username, password, method, uri, nc, cnonce string) (string, error) {
+	algorithm := challenge["algorithm"]
+	var hash func(string) string
+	switch strings.ToUpper(algorithm) {
+	case "", "MD5":
+		hash = md5Hex
+	case "SHA-256":
+		hash = sha256Hex
+	default:
+		return "", fmt.Errorf("unsupported digest algorithm '%s'", algorithm)
+	}
+	realm, nonce := challenge["realm"], challenge["nonce"]
+	qop := ""
+	for _, q := range strings.Split(challenge["qop"], ",") {
+		if strings.TrimSpace(q) == "auth" {
+			qop = "auth"
+		}
+	}
+
+	ha1 := hash(username + ":" + realm + ":" + password)
+	ha2 := hash(method + ":" + uri)
+	var response string
+	if qop == "" {
+		response = hash(ha1 + ":" + nonce + ":" + ha2)
+	} else {
+		response = hash(ha1 + ":" + nonce + ":" + nc + ":" + cnonce + ":" + qop + ":" + ha2)
+	}
+
+	authorization := fmt.Sprintf(`Digest username="%s", realm="%s", nonce="%s", uri="%s", response="%s"`, username, realm, nonce, uri, response)
+	if algorithm != "" {
+		authorization += ", algorithm=" + algorithm
+	}
+	if qop != "" {
+		authorization += fmt.Sprintf(`, qop=%s, nc=%s, cnonce="%s"`, qop, nc, cnonce)
+	}
+	if opaque, ok := challenge["opaque"]; ok {
+		authorization += fmt.Sprintf(`, opaque="%s"`, opaque)
+	}
+	return authorization, nil
+}
+
+// parseDigestChallenge returns the parameters of the first DIGEST challenge of the WWW-Authenticate headers
+func parseDigestChallenge(headers []string) (map[string]string, bool) {
+	for _, header := range headers {
+		if len(header) < 7 || !strings.EqualFold(header[:7], "Digest ") {
+			continue
+		}
+		params := map[string]string{}
+		for _, param := range splitParams(header[7:]) {
+			if kv := strings.SplitN(param, "=", 2); len(kv) == 2 {
+				params[strings.ToLower(strings.TrimSpace(kv[0]))] = strings.Trim(strings.TrimSpace(kv[1]), `"`)
+			}
+		}
+		return params, true
+	}
+	return nil, false
+}
+
+// splitParams splits the comma separated parameters of a challenge, ignoring the commas in quoted values
+func splitParams(s string) []string {
+	var params []string
+	quoted := false
+	start := 0
+	for i, c := range s {
+		switch c {
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				params = append(params, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(params, s[start:])
+}
+
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
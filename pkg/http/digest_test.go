@@ -0,0 +1,169 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigestTransport(t *testing.T) {
+	const (
+		realm = "admin"
+		nonce = "dcd98b7102dd2f0e8b11d0f600bfb0c093"
+	)
+	var unauthenticated, authenticated int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		if authorization == "" {
+			unauthenticated++
+			w.Header().Add("WWW-Authenticate", `Basic realm="admin"`)
+			w.Header().Add("WWW-Authenticate", `Digest realm="admin", nonce="`+nonce+`", qop="auth", algorithm=MD5`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		params, ok := parseDigestChallenge([]string{authorization})
+		require.True(t, ok)
+		ha1 := md5Hex("operator:" + realm + ":secret")
+		ha2 := md5Hex(r.Method + ":" + r.URL.RequestURI())
+		expected := md5Hex(ha1 + ":" + nonce + ":" + params["nc"] + ":" + params["cnonce"] + ":auth:" + ha2)
+		assert.Equal(t, "operator", params["username"])
+		assert.Equal(t, "/rest/v2/container/config?action=listen", params["uri"])
+		if params["response"] != expected {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		authenticated++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &DigestTransport{Username: "operator", Password: "secret"}}
+	rsp, err := client.Get(server.URL + "/rest/v2/container/config?action=listen")
+	require.NoError(t, err)
+	defer rsp.Body.Close()
+	assert.Equal(t, http.StatusOK, rsp.StatusCode)
+	assert.Equal(t, 1, unauthenticated)
+	assert.Equal(t, 1, authenticated)
+
+	client = &http.Client{Transport: &DigestTransport{Username: "operator", Password: "wrong"}}
+	rsp, err = client.Get(server.URL + "/rest/v2/container/config?action=listen")
+	require.NoError(t, err)
+	defer rsp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, rsp.StatusCode)
+}
+
+func TestParseDigestChallenge(t *testing.T) {
+	params, ok := parseDigestChallenge([]string{`Digest realm="admin", nonce="abc", qop="auth,auth-int", opaque="x,y"`})
+	require.True(t, ok)
+	assert.Equal(t, "admin", params["realm"])
+	assert.Equal(t, "abc", params["nonce"])
+	assert.Equal(t, "auth,auth-int", params["qop"])
+	assert.Equal(t, "x,y", params["opaque"])
+
+	_, ok = parseDigestChallenge([]string{`Basic realm="admin"`})
+	assert.False(t, ok)
+}
+
+// TestDigestAuthorization verifies the responses against the examples of RFC 2617 section 3.5 and RFC 7616 section 3.9.1
+func TestDigestAuthorization(t *testing.T) {
+	testTable := []struct {
+		name      string
+		challenge map[string]string
+		password  string
+		nc        string
+		cnonce    string
+		expected  string
+	}{
+		{
+			name:      "RFC 2617 MD5 qop=auth",
+			challenge: map[string]string{"realm": "testrealm@host.com", "qop": "auth,auth-int", "nonce": "dcd98b7102dd2f0e8b11d0f600bfb0c093", "opaque": "5ccc069c403ebaf9f0171e9517f40e41"},
+			password:  "Circle Of Life",
+			nc:        "00000001",
+			cnonce:    "0a4f113b",
+			expected:  `Digest username="Mufasa", realm="testrealm@host.com", nonce="dcd98b7102dd2f0e8b11d0f600bfb0c093", uri="/dir/index.html", response="6629fae49393a05397450978507c4ef1", qop=auth, nc=00000001, cnonce="0a4f113b", opaque="5ccc069c403ebaf9f0171e9517f40e41"`,
+		},
+		{
+			name:      "RFC 7616 MD5 qop=auth",
+			challenge: map[string]string{"realm": "http-auth@example.org", "qop": "auth, auth-int", "algorithm": "MD5", "nonce": "7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v", "opaque": "FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"},
+			password:  "Circle of Life",
+			nc:        "00000001",
+			cnonce:    "f2/wE4q74E6zIJEtWaHKaf5wv/H5QzzpXusqGemxURZJ",
+			expected:  `Digest username="Mufasa", realm="http-auth@example.org", nonce="7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v", uri="/dir/index.html", response="8ca523f5e9506fed4657c9700eebdbec", algorithm=MD5, qop=auth, nc=00000001, cnonce="f2/wE4q74E6zIJEtWaHKaf5wv/H5QzzpXusqGemxURZJ", opaque="FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"`,
+		},
+		{
+			name:      "RFC 7616 SHA-256 qop=auth",
+			challenge: map[string]string{"realm": "http-auth@example.org", "qop": "auth, auth-int", "algorithm": "SHA-256", "nonce": "7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v", "opaque": "FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"},
+			password:  "Circle of Life",
+			nc:        "00000001",
+			cnonce:    "f2/wE4q74E6zIJEtWaHKaf5wv/H5QzzpXusqGemxURZJ",
+			expected:  `Digest username="Mufasa", realm="http-auth@example.org", nonce="7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v", uri="/dir/index.html", response="753927fa0e85d155564e2e272a28d1802ca10daf4496794697cf8db5856cb6c1", algorithm=SHA-256, qop=auth, nc=00000001, cnonce="f2/wE4q74E6zIJEtWaHKaf5wv/H5QzzpXusqGemxURZJ", opaque="FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"`,
+		},
+		{
+			name:      "RFC 2069 without qop",
+			challenge: map[string]string{"realm": "testrealm@host.com", "nonce": "dcd98b7102dd2f0e8b11d0f600bfb0c093"},
+			password:  "Circle Of Life",
+			expected:  `Digest username="Mufasa", realm="testrealm@host.com", nonce="dcd98b7102dd2f0e8b11d0f600bfb0c093", uri="/dir/index.html", response="` + md5Hex(md5Hex("Mufasa:testrealm@host.com:Circle Of Life")+":dcd98b7102dd2f0e8b11d0f600bfb0c093:"+md5Hex("GET:/dir/index.html")) + `"`,
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			authorization, err := digestAuthorization(tt.challenge, "Mufasa", tt.password, http.MethodGet, "/dir/index.html", tt.nc, tt.cnonce)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, authorization)
+		})
+	}
+
+	_, err := digestAuthorization(map[string]string{"algorithm": "SHA-512-256"}, "Mufasa", "Circle of Life", http.MethodGet, "/", "00000001", "0a4f113b")
+	assert.Error(t, err)
+}
+
+func TestDigestTransportStaleNonce(t *testing.T) {
+	nonces := []string{"first", "second"}
+	var authenticated []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params, ok := parseDigestChallenge([]string{r.Header.Get("Authorization")})
+		// The first nonce expires once it has been used, the client must answer the new challenge
+		if ok && params["nonce"] == nonces[0] {
+			authenticated = append(authenticated, params["nc"])
+			w.Header().Add("WWW-Authenticate", `Digest realm="admin", nonce="`+nonces[1]+`", qop="auth", stale=true`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if ok && params["nonce"] == nonces[1] {
+			authenticated = append(authenticated, params["nc"])
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Header().Add("WWW-Authenticate", `Digest realm="admin", nonce="`+nonces[0]+`", qop="auth"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &DigestTransport{Username: "operator", Password: "secret"}}
+	rsp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer rsp.Body.Close()
+	assert.Equal(t, http.StatusOK, rsp.StatusCode)
+	assert.Equal(t, []string{"00000001", "00000002"}, authenticated)
+}
+
+func TestDigestTransportRejectedCredentials(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// A challenge without stale=true rejects the credentials, so the request is not resent
+		w.Header().Add("WWW-Authenticate", `Digest realm="admin", nonce="abc", qop="auth"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &DigestTransport{Username: "operator", Password: "wrong"}}
+	rsp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer rsp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, rsp.StatusCode)
+	assert.Equal(t, 2, requests)
+}
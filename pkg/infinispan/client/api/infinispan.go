@@ -3,6 +3,10 @@ package api
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/infinispan/infinispan-operator/pkg/mime"
 )
@@ -49,13 +53,24 @@ type Cache interface {
 	Delete() error
 	Exists() (bool, error)
 	Get(key string) (string, bool, error)
+	Keys(limit int) ([]string, error)
 	Put(key, value string, contentType mime.MimeType) error
 	RollingUpgrade() RollingUpgrade
 	Size() (int, error)
+	Xsite() CacheXsite
 	UpdateConfig(config string, contentType mime.MimeType) error
 }
 
 // RollingUpgrade contains all operations for coordinating rolling upgrades on a specific cache
+type CacheXsite interface {
+	// BackupStatus returns the status, online or offline, of the backup of the cache to each remote site
+	BackupStatus() (map[string]string, error)
+	PushState(site string) error
+	CancelPushState(site string) error
+	// PushStateStatus returns the state of the most recent state transfer of the cache to each remote site
+	PushStateStatus() (map[string]string, error)
+}
+
 type RollingUpgrade interface {
 	AddSource(config string, contentType mime.MimeType) error
 	DisconnectSource() error
@@ -66,6 +81,9 @@ type RollingUpgrade interface {
 // Caches contains all generic cache operations that aren't specific to a single cache
 type Caches interface {
 	ConvertConfiguration(config string, contentType, reqType mime.MimeType) (string, error)
+	// EqualConfiguration returns true if both configurations are equal. Differences in attributes that can be updated
+	// at runtime are ignored when ignoreMutable is true
+	EqualConfiguration(config, other string, contentType mime.MimeType, ignoreMutable bool) (bool, error)
 	Names() ([]string, error)
 }
 
@@ -89,11 +107,33 @@ type Metrics interface {
 // Server contains all operations related to the server process
 type Server interface {
 	Stop() error
+	// ReloadCertificates reloads the keystores and truststores of the endpoints from the filesystem. Returns an error
+	// wrapping ErrUnsupported if the server cannot reload certificates without a restart
+	ReloadCertificates() error
+	// FlushSecurityCache discards the identities and permissions cached by the server, so that changes to the users of
+	// the security realms apply to new requests. Returns an error wrapping ErrUnsupported if the server cannot flush them
+	FlushSecurityCache() error
 }
 
 // Xsite contains all Xsite replated operations
 type Xsite interface {
 	PushAllState() error
+	// BackupStatus returns the status of the backups to each remote site, keyed by site name
+	BackupStatus() (map[string]SiteBackupStatus, error)
+	// TakeOffline takes the backups of all caches to the site offline
+	TakeOffline(site string) error
+	// BringOnline brings the backups of all caches to the site online
+	BringOnline(site string) error
+}
+
+// SiteBackupStatus the status of the backups of all caches to a remote site
+type SiteBackupStatus struct {
+	// online, offline or mixed
+	Status string `json:"status"`
+	// The caches with an online backup to the site, if the status is mixed
+	Online []string `json:"online,omitempty"`
+	// The caches with an offline backup to the site, if the status is mixed
+	Offline []string `json:"offline,omitempty"`
 }
 
 // HealthStatus indicated the possible statuses of the Infinispan server
@@ -144,6 +184,39 @@ type BackupRestoreResources struct {
 	Tasks []string `json:"tasks,omitempty"`
 }
 
+// ErrUnsupported is returned by operations that are not supported by the version of the server
+var ErrUnsupported = errors.New("operation not supported by the server")
+
+// IncompatibleAttributesError is returned by Cache.UpdateConfig when the server rejects a configuration because it
+// modifies attributes that cannot be changed on an existing cache
+type IncompatibleAttributesError struct {
+	Attributes []string
+	Err        error
+}
+
+func (e *IncompatibleAttributesError) Error() string {
+	return fmt.Sprintf("immutable cache attributes cannot be updated: %s", strings.Join(e.Attributes, ", "))
+}
+
+func (e *IncompatibleAttributesError) Unwrap() error {
+	return e.Err
+}
+
+var incompatibleAttributeRegexp = regexp.MustCompile(`Incompatible attribute '([^']+)'`)
+
+// IncompatibleAttributes returns the names of the attributes reported as incompatible in a server error message
+func IncompatibleAttributes(message string) []string {
+	var attributes []string
+	seen := map[string]bool{}
+	for _, match := range incompatibleAttributeRegexp.FindAllStringSubmatch(message, -1) {
+		if attr := match[1]; !seen[attr] {
+			seen[attr] = true
+			attributes = append(attributes, attr)
+		}
+	}
+	return attributes
+}
+
 type ContainerInfo struct {
 	Coordinator bool           `json:"coordinator"`
 	SitesView   *[]interface{} `json:"sites_view,omitempty"`
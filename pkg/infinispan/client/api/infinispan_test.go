@@ -0,0 +1,36 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncompatibleAttributes(t *testing.T) {
+	testTable := []struct {
+		name     string
+		message  string
+		expected []string
+	}{
+		{"no attributes", "unexpected error updating cache, response: ISPN000000: Unknown error", nil},
+		{"single attribute", "ISPN000961: Incompatible attribute 'distributed-cache.encoding.media-type' existing value='application/x-protostream', new value='text/plain'",
+			[]string{"distributed-cache.encoding.media-type"}},
+		{"duplicate attributes removed", "Incompatible attribute 'distributed-cache.mode' existing value='SYNC', new value='ASYNC'\n" +
+			"Incompatible attribute 'distributed-cache.owners' existing value='2', new value='3'\n" +
+			"Incompatible attribute 'distributed-cache.mode' existing value='SYNC', new value='ASYNC'",
+			[]string{"distributed-cache.mode", "distributed-cache.owners"}},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IncompatibleAttributes(tt.message))
+		})
+	}
+}
+
+func TestIncompatibleAttributesError(t *testing.T) {
+	cause := errors.New("server error")
+	err := &IncompatibleAttributesError{Attributes: []string{"a", "b"}, Err: cause}
+	assert.Equal(t, "immutable cache attributes cannot be updated: a, b", err.Error())
+	assert.True(t, errors.Is(err, cause))
+}
@@ -1,10 +1,14 @@
 package v13
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
 	"strconv"
 	"strings"
@@ -122,6 +126,20 @@ func (c *cache) Get(key string) (val string, exists bool, err error) {
 	return body, true, err
 }
 
+func (c *cache) Keys(limit int) (keys []string, err error) {
+	rsp, err := c.HttpClient.Get(fmt.Sprintf("%s?action=keys&limit=%d", c.url(), limit), nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	if err = httpClient.ValidateResponse(rsp, err, "getting cache keys", http.StatusOK); err != nil {
+		return
+	}
+	if err = json.NewDecoder(rsp.Body).Decode(&keys); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
+	}
+	return
+}
+
 func (c *cache) Put(key, value string, contentType mime.MimeType) (err error) {
 	headers := map[string]string{
 		"Content-Type": string(contentType),
@@ -158,6 +176,13 @@ func (c *cache) RollingUpgrade() api.RollingUpgrade {
 	}
 }
 
+func (c *cache) Xsite() api.CacheXsite {
+	return &cacheXsite{
+		cache:      c,
+		HttpClient: c.HttpClient,
+	}
+}
+
 func (c *cache) UpdateConfig(config string, contentType mime.MimeType) (err error) {
 	headers := map[string]string{
 		"Content-Type": string(contentType),
@@ -168,6 +193,12 @@ func (c *cache) UpdateConfig(config string, contentType mime.MimeType) (err erro
 		err = httpClient.CloseBody(rsp, err)
 	}()
 	err = httpClient.ValidateResponse(rsp, err, "updating cache", http.StatusOK)
+	var httpErr *httpClient.HttpError
+	if errors.As(err, &httpErr) {
+		if attributes := api.IncompatibleAttributes(httpErr.Message); len(attributes) > 0 {
+			err = &api.IncompatibleAttributesError{Attributes: attributes, Err: err}
+		}
+	}
 	return
 }
 
@@ -188,6 +219,37 @@ func (c *caches) ConvertConfiguration(config string, contentType mime.MimeType,
 	return readResponseBody(rsp)
 }
 
+func (c *caches) EqualConfiguration(config, other string, contentType mime.MimeType, ignoreMutable bool) (equal bool, err error) {
+	path := fmt.Sprintf("%s?action=compare&ignoreMutable=%t", CachesPath, ignoreMutable)
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for _, part := range []string{config, other} {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", string(contentType))
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return false, err
+		}
+		if _, err := w.Write([]byte(part)); err != nil {
+			return false, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return false, err
+	}
+
+	headers := map[string]string{"Content-Type": writer.FormDataContentType()}
+	rsp, err := c.Post(path, body.String(), headers)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	// The server responds with 409 Conflict if the configurations differ
+	if err = httpClient.ValidateResponse(rsp, err, "comparing cache configurations", http.StatusNoContent, http.StatusConflict); err != nil {
+		return
+	}
+	return rsp.StatusCode == http.StatusNoContent, nil
+}
+
 func (c *caches) Names() (names []string, err error) {
 	rsp, err := c.Get(CachesPath, nil)
 	if err = httpClient.ValidateResponse(rsp, err, "getting caches", http.StatusOK); err != nil {
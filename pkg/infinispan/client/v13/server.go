@@ -1,12 +1,18 @@
 package v13
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 )
 
-const ServerPath = BasePath + "/server"
+const (
+	ServerPath        = BasePath + "/server"
+	SecurityCachePath = BasePath + "/security/cache"
+)
 
 type server struct {
 	httpClient.HttpClient
@@ -20,3 +26,30 @@ func (s *server) Stop() (err error) {
 	err = httpClient.ValidateResponse(rsp, err, "stopping server", http.StatusNoContent)
 	return
 }
+
+func (s *server) ReloadCertificates() (err error) {
+	rsp, err := s.Post(ServerPath+"?action=reload-tls", "", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	err = httpClient.ValidateResponse(rsp, err, "reloading certificates", http.StatusNoContent)
+	// Servers without support for reloading certificates reject the unknown action
+	var httpErr *httpClient.HttpError
+	if errors.As(err, &httpErr) && (httpErr.Status == http.StatusBadRequest || httpErr.Status == http.StatusNotFound || httpErr.Status == http.StatusNotImplemented) {
+		err = fmt.Errorf("%w: %v", api.ErrUnsupported, err)
+	}
+	return
+}
+
+func (s *server) FlushSecurityCache() (err error) {
+	rsp, err := s.Post(SecurityCachePath, "", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	err = httpClient.ValidateResponse(rsp, err, "flushing security cache", http.StatusNoContent)
+	var httpErr *httpClient.HttpError
+	if errors.As(err, &httpErr) && (httpErr.Status == http.StatusNotFound || httpErr.Status == http.StatusMethodNotAllowed || httpErr.Status == http.StatusNotImplemented) {
+		err = fmt.Errorf("%w: %v", api.ErrUnsupported, err)
+	}
+	return
+}
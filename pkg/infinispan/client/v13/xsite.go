@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 )
 
 const XSitePath = CacheManagerPath + "/x-site/backups"
@@ -15,6 +17,42 @@ type xsite struct {
 }
 
 func (x *xsite) PushAllState() (err error) {
+	statuses, err := x.BackupStatus()
+	if err != nil {
+		return
+	}
+
+	// Statuses will be empty if no xsite caches are configured
+	for k, v := range statuses {
+		if v.Status == "online" {
+			url := fmt.Sprintf("%s/%s?action=start-push-state", XSitePath, k)
+			rsp, err := x.Post(url, "", nil)
+			if err = httpClient.ValidateResponse(rsp, err, "Pushing xsite state", http.StatusOK); err != nil {
+				return err
+			}
+		}
+	}
+	return
+}
+
+func (x *xsite) TakeOffline(site string) error {
+	return x.backupAction(site, "take-offline", "Taking xsite backups offline")
+}
+
+func (x *xsite) BringOnline(site string) error {
+	return x.backupAction(site, "bring-online", "Bringing xsite backups online")
+}
+
+func (x *xsite) backupAction(site, action, op string) (err error) {
+	path := fmt.Sprintf("%s/%s?action=%s", XSitePath, url.PathEscape(site), action)
+	rsp, err := x.Post(path, "", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	return httpClient.ValidateResponse(rsp, err, op, http.StatusOK)
+}
+
+func (x *xsite) BackupStatus() (statuses map[string]api.SiteBackupStatus, err error) {
 	rsp, err := x.Get(XSitePath, nil)
 	if err = httpClient.ValidateResponse(rsp, err, "Retrieving xsite status", http.StatusOK); err != nil {
 		return
@@ -27,23 +65,57 @@ func (x *xsite) PushAllState() (err error) {
 		}
 	}()
 
-	type xsiteStatus struct {
-		Status string `json:"status"`
+	if err = json.NewDecoder(rsp.Body).Decode(&statuses); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
 	}
-	var statuses map[string]xsiteStatus
-	if err := json.NewDecoder(rsp.Body).Decode(&statuses); err != nil {
-		return fmt.Errorf("unable to decode: %w", err)
+	return
+}
+
+type cacheXsite struct {
+	*cache
+	httpClient.HttpClient
+}
+
+func (x *cacheXsite) url() string {
+	return x.cache.url() + "/x-site"
+}
+
+func (x *cacheXsite) BackupStatus() (map[string]string, error) {
+	return x.getStatus(x.url()+"/backups/", "Retrieving cache xsite status")
+}
+
+func (x *cacheXsite) PushState(site string) error {
+	return x.backupAction(site, "start-push-state", "Pushing cache xsite state")
+}
+
+func (x *cacheXsite) CancelPushState(site string) error {
+	return x.backupAction(site, "cancel-push-state", "Cancelling cache xsite state push")
+}
+
+func (x *cacheXsite) PushStateStatus() (map[string]string, error) {
+	return x.getStatus(x.url()+"/push-state", "Retrieving cache xsite push state status")
+}
+
+func (x *cacheXsite) backupAction(site, action, op string) (err error) {
+	path := fmt.Sprintf("%s/backups/%s?action=%s", x.url(), url.PathEscape(site), action)
+	rsp, err := x.Post(path, "", nil)
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
+	return httpClient.ValidateResponse(rsp, err, op, http.StatusOK)
+}
+
+func (x *cacheXsite) getStatus(path, op string) (statuses map[string]string, err error) {
+	rsp, err := x.HttpClient.Get(path, nil)
+	if err = httpClient.ValidateResponse(rsp, err, op, http.StatusOK); err != nil {
+		return
 	}
+	defer func() {
+		err = httpClient.CloseBody(rsp, err)
+	}()
 
-	// Statuses will be empty if no xsite caches are configured
-	for k, v := range statuses {
-		if v.Status == "online" {
-			url := fmt.Sprintf("%s/%s?action=start-push-state", XSitePath, k)
-			rsp, err = x.Post(url, "", nil)
-			if err = httpClient.ValidateResponse(rsp, err, "Pushing xsite state", http.StatusOK); err != nil {
-				return
-			}
-		}
+	if err = json.NewDecoder(rsp.Body).Decode(&statuses); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
 	}
 	return
 }
@@ -15,11 +15,18 @@ type RemoteStore struct {
 	RawValues       bool          `json:"raw-values"`
 	Segmented       bool          `json:"segmented"`
 	Shared          bool          `json:"shared"`
+	ReadOnly        bool          `json:"read-only,omitempty"`
+	WriteBehind     *WriteBehind  `json:"write-behind,omitempty"`
 	Cache           string        `json:"cache,omitempty"`
 	RemoteServer    *RemoteServer `json:"remote-server,omitempty"`
 	Security        *Security     `json:"security,omitempty"`
 }
 
+type WriteBehind struct {
+	ModificationQueueSize int  `json:"modification-queue-size,omitempty"`
+	FailSilently          bool `json:"fail-silently"`
+}
+
 type RemoteServer struct {
 	Host string `json:"host,omitempty"`
 	Port int    `json:"port,omitempty"`
@@ -63,31 +70,54 @@ type Truststore struct {
 }
 
 func CreateRemoteStoreConfig(ip string, cache, pass string) (string, error) {
+	return marshalRemoteStore(remoteStore(ip, cache, pass))
+}
 
-	cfg := RemoteStoreConfig{
-		RemoteStore: &RemoteStore{
-			RawValues: true,
-			Shared:    true,
-			Cache:     cache,
-			Segmented: false,
-			RemoteServer: &RemoteServer{
-				Host: ip,
-				Port: constants.InfinispanAdminPort,
-			},
-			Security: &Security{
-				Authentication: &Authentication{
-					ServerName: "infinispan",
-					Digest: &Digest{
-						Username: constants.DefaultOperatorUser,
-						Password: pass,
-						Realm:    "admin",
-					},
+// CreateReadOnlyRemoteStoreConfig returns a Remote Store that loads entries from the cache of the remote cluster, without
+// writing the modifications of the local cache to it
+func CreateReadOnlyRemoteStoreConfig(host, cache, pass string) (string, error) {
+	store := remoteStore(host, cache, pass)
+	store.ReadOnly = true
+	return marshalRemoteStore(store)
+}
+
+// CreateWriteBehindRemoteStoreConfig returns a Remote Store that asynchronously writes the modifications of the local
+// cache to the cache of the remote cluster. Modifications that cannot be written are discarded once the queue is full, so
+// that an unavailable remote cluster never blocks writes to the local cache
+func CreateWriteBehindRemoteStoreConfig(host, cache, pass string, queueSize int) (string, error) {
+	store := remoteStore(host, cache, pass)
+	store.WriteBehind = &WriteBehind{
+		ModificationQueueSize: queueSize,
+		FailSilently:          true,
+	}
+	return marshalRemoteStore(store)
+}
+
+func remoteStore(host, cache, pass string) *RemoteStore {
+	return &RemoteStore{
+		RawValues: true,
+		Shared:    true,
+		Cache:     cache,
+		Segmented: false,
+		RemoteServer: &RemoteServer{
+			Host: host,
+			Port: constants.InfinispanAdminPort,
+		},
+		Security: &Security{
+			Authentication: &Authentication{
+				ServerName: "infinispan",
+				Digest: &Digest{
+					Username: constants.DefaultOperatorUser,
+					Password: pass,
+					Realm:    "admin",
 				},
 			},
 		},
 	}
+}
 
-	doc, err := json.Marshal(cfg)
+func marshalRemoteStore(store *RemoteStore) (string, error) {
+	doc, err := json.Marshal(RemoteStoreConfig{RemoteStore: store})
 	if err != nil {
 		return "", err
 	}
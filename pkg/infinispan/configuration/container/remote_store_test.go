@@ -0,0 +1,31 @@
+package container
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateWriteBehindRemoteStoreConfig(t *testing.T) {
+	doc, err := CreateWriteBehindRemoteStoreConfig("target-admin", "sessions", "pass", 1024)
+	assert.NoError(t, err)
+
+	cfg := &RemoteStoreConfig{}
+	assert.NoError(t, json.Unmarshal([]byte(doc), cfg))
+	assert.Equal(t, "sessions", cfg.RemoteStore.Cache)
+	assert.Equal(t, "target-admin", cfg.RemoteStore.RemoteServer.Host)
+	assert.False(t, cfg.RemoteStore.ReadOnly)
+	assert.Equal(t, &WriteBehind{ModificationQueueSize: 1024, FailSilently: true}, cfg.RemoteStore.WriteBehind)
+}
+
+func TestCreateReadOnlyRemoteStoreConfig(t *testing.T) {
+	doc, err := CreateReadOnlyRemoteStoreConfig("source-admin", "sessions", "pass")
+	assert.NoError(t, err)
+	assert.Contains(t, doc, `"read-only":true`)
+	assert.NotContains(t, doc, "write-behind")
+
+	doc, err = CreateRemoteStoreConfig("source-admin", "sessions", "pass")
+	assert.NoError(t, err)
+	assert.NotContains(t, doc, "read-only")
+}
@@ -7,6 +7,15 @@ import (
 
 type Spec struct {
 	Categories map[string]string
+	// The audit log category of the server, if nil authorization decisions are not logged
+	Audit *Audit
+}
+
+type Audit struct {
+	Category string
+	Level    string
+	// The file in the server log directory that audit events are written to, if empty the console is used
+	Filename string
 }
 
 func Generate(v *version.Version, spec *Spec) (string, error) {
@@ -0,0 +1,48 @@
+package logging
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAudit(t *testing.T) {
+	type logger struct {
+		Name       string `xml:"name,attr"`
+		Level      string `xml:"level,attr"`
+		Additivity string `xml:"additivity,attr"`
+		Appender   struct {
+			Ref string `xml:"ref,attr"`
+		} `xml:"AppenderRef"`
+	}
+	type config struct {
+		Files []struct {
+			Name     string `xml:"name,attr"`
+			FileName string `xml:"fileName,attr"`
+		} `xml:"Appenders>RollingFile"`
+		Loggers []logger `xml:"Loggers>Logger"`
+	}
+	parse := func(spec *Spec) config {
+		log4j, err := Generate(nil, spec)
+		require.NoError(t, err)
+		var parsed config
+		require.NoError(t, xml.NewDecoder(strings.NewReader(log4j)).Decode(&parsed))
+		return parsed
+	}
+
+	c := parse(&Spec{Audit: &Audit{Category: "org.infinispan.AUDIT", Level: "info"}})
+	assert.Empty(t, c.Files)
+	assert.Equal(t, []logger{{Name: "org.infinispan.AUDIT", Level: "INFO"}}, c.Loggers)
+
+	c = parse(&Spec{Audit: &Audit{Category: "org.infinispan.AUDIT", Level: "debug", Filename: "audit.log"}})
+	require.Len(t, c.Files, 1)
+	assert.Equal(t, "AUDIT-FILE", c.Files[0].Name)
+	assert.Equal(t, "${sys:infinispan.server.log.path}/audit.log", c.Files[0].FileName)
+	require.Len(t, c.Loggers, 1)
+	assert.Equal(t, "DEBUG", c.Loggers[0].Level)
+	assert.Equal(t, "false", c.Loggers[0].Additivity)
+	assert.Equal(t, "AUDIT-FILE", c.Loggers[0].Appender.Ref)
+}
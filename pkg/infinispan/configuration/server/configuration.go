@@ -12,6 +12,7 @@ type Spec struct {
 	ClusterName     string
 	Namespace       string
 	StatefulSetName string
+	PingServiceName string
 	Infinispan      Infinispan
 	JGroups         JGroups
 	CloudEvents     *CloudEvents
@@ -20,17 +21,29 @@ type Spec struct {
 	Transport       Transport
 	Truststore      Truststore
 	XSite           *XSite
+	// The aliases of the secrets that spec.security.credentials adds to the credential store
+	Credentials []string
 }
 
 type Infinispan struct {
 	Authorization    *Authorization
+	CapacityFactor   bool
 	ZeroCapacityNode bool
+	// The thread pool that delivers cache events to listeners, if nil the server default is used
+	ListenerExecutor *ThreadPool
+}
+
+type ThreadPool struct {
+	MaxThreads  int32
+	QueueLength int32
 }
 
 type Authorization struct {
 	Enabled    bool
 	RoleMapper string
 	Roles      []AuthorizationRole
+	// Whether authorization decisions are recorded in the audit log category
+	AuditLog bool
 }
 
 type AuthorizationRole struct {
@@ -52,6 +65,10 @@ type BackupSite struct {
 type JGroups struct {
 	Diagnostics bool
 	FastMerge   bool
+	// The fixed port of the FD_SOCK failure detection protocol, if zero a random port is used
+	FDSockPort int32
+	// If true, JGroups binds to the global IPv6 address of the pod and discovers the other pods with AAAA records
+	IPv6 bool
 }
 
 type CloudEvents struct {
@@ -63,8 +80,10 @@ type CloudEvents struct {
 type Keystore struct {
 	Path     string
 	Password string
-	Alias    string
-	CrtPath  string
+	// The system property that the keystore password is read from, if set the password is not in the credential store
+	PasswordProperty string
+	Alias            string
+	CrtPath          string
 }
 
 type Truststore struct {
@@ -88,6 +107,60 @@ type TransportTLS struct {
 type Endpoints struct {
 	Authenticate bool
 	ClientCert   string
+	// The token realm that authenticates users with bearer tokens, if nil only the other realms are configured
+	OIDC *OIDC
+	// The token realm that authenticates REST users with ServiceAccount tokens, if nil these tokens are not accepted
+	TokenReview *TokenReview
+	// The Kerberos identity that Hot Rod users authenticate against, if nil GSSAPI is not available
+	Kerberos *Kerberos
+	// The TLS protocols and cipher suites that the endpoints accept, if nil the defaults of the server are used
+	TLS *EndpointTLS
+	// The directory of the plain text users.properties and groups.properties files of the default realm, if empty the
+	// files created by the identities batch in the server configuration directory are used
+	IdentitiesPath string
+	RestrictAdmin  bool
+	HotRod         Connector
+	Rest           Connector
+}
+
+type EndpointTLS struct {
+	// Space separated list of protocols
+	Protocols string
+	// Colon separated lists of cipher suites, TLSv1.3 cipher suites are configured separately by the server
+	CipherSuites      string
+	CipherSuitesTLS13 string
+}
+
+type OIDC struct {
+	AuthServerURL string
+	// The public client that the Console redirects users to the provider with
+	ClientID string
+	// The confidential client that the servers introspect tokens with. The client secret is read from the credential
+	// store with the oidc alias
+	IntrospectionClientID string
+	PrincipalClaim        string
+}
+
+type TokenReview struct {
+	// The introspection endpoint of the token review sidecar. The sidecar only listens on the loopback interface of the
+	// pod, so it does not verify the client credentials of the servers
+	IntrospectionURL string
+	PrincipalClaim   string
+}
+
+type Kerberos struct {
+	Principal  string
+	KeytabPath string
+}
+
+type Connector struct {
+	Disabled bool
+	// If true, the connector advertises the address of the pod's external Service in the topology
+	External bool
+	// The port of a dedicated socket binding, if zero the connector uses the socket binding of the endpoint
+	Port int32
+	// The size in bytes of the socket send buffer, if zero the operating system default is used
+	SendBufferSize int32
 }
 
 func Generate(v *version.Version, spec *Spec) (string, error) {
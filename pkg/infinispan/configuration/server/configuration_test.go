@@ -0,0 +1,620 @@
+package server
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateCacheTemplateLibrary(t *testing.T) {
+	config, err := Generate(nil, &Spec{
+		ClusterName: "example-infinispan",
+		Infinispan:  Infinispan{Authorization: &Authorization{}},
+	})
+	require.NoError(t, err)
+
+	var parsed struct {
+		CacheContainer struct {
+			Distributed []struct {
+				Name string `xml:"name,attr"`
+			} `xml:"distributed-cache-configuration"`
+			Replicated []struct {
+				Name string `xml:"name,attr"`
+			} `xml:"replicated-cache-configuration"`
+		} `xml:"cache-container"`
+	}
+	require.NoError(t, xml.NewDecoder(strings.NewReader(config)).Decode(&parsed))
+
+	var templates []string
+	for _, c := range parsed.CacheContainer.Distributed {
+		templates = append(templates, c.Name)
+	}
+	for _, c := range parsed.CacheContainer.Replicated {
+		templates = append(templates, c.Name)
+	}
+	assert.ElementsMatch(t, []string{"session-cache", "reference-data", "write-heavy"}, templates)
+}
+
+func TestGenerateRestrictAdmin(t *testing.T) {
+	spec := &Spec{
+		ClusterName: "example-infinispan",
+		Infinispan:  Infinispan{Authorization: &Authorization{}},
+		Endpoints:   Endpoints{ClientCert: "None"},
+	}
+	endpoints := func() map[string]string {
+		config, err := Generate(nil, spec)
+		require.NoError(t, err)
+		var parsed struct {
+			Endpoints []struct {
+				SocketBinding string `xml:"socket-binding,attr"`
+				Admin         string `xml:"admin,attr"`
+			} `xml:"server>endpoints>endpoint"`
+		}
+		require.NoError(t, xml.NewDecoder(strings.NewReader(config)).Decode(&parsed))
+		admin := map[string]string{}
+		for _, e := range parsed.Endpoints {
+			admin[e.SocketBinding] = e.Admin
+		}
+		return admin
+	}
+	assert.Equal(t, map[string]string{"default": "", "admin": ""}, endpoints())
+
+	spec.Endpoints.RestrictAdmin = true
+	assert.Equal(t, map[string]string{"default": "false", "admin": ""}, endpoints())
+}
+
+func TestGenerateEndpointConnectors(t *testing.T) {
+	spec := &Spec{
+		ClusterName: "example-infinispan",
+		Infinispan:  Infinispan{Authorization: &Authorization{}},
+		Endpoints:   Endpoints{ClientCert: "None", Authenticate: true},
+	}
+	type connector struct {
+		SocketBinding string `xml:"socket-binding,attr"`
+	}
+	type parsedConfig struct {
+		SocketBindings []struct {
+			Name string `xml:"name,attr"`
+			Port string `xml:"port,attr"`
+		} `xml:"server>socket-bindings>socket-binding"`
+		Endpoints []struct {
+			SocketBinding string      `xml:"socket-binding,attr"`
+			HotRod        []connector `xml:"hotrod-connector"`
+			Rest          []connector `xml:"rest-connector"`
+		} `xml:"server>endpoints>endpoint"`
+	}
+	generate := func() parsedConfig {
+		config, err := Generate(nil, spec)
+		require.NoError(t, err)
+		var parsed parsedConfig
+		require.NoError(t, xml.NewDecoder(strings.NewReader(config)).Decode(&parsed))
+		require.Len(t, parsed.Endpoints, 2)
+		require.Equal(t, "default", parsed.Endpoints[0].SocketBinding)
+		return parsed
+	}
+
+	parsed := generate()
+	assert.Len(t, parsed.SocketBindings, 2)
+	assert.Equal(t, []connector{{}}, parsed.Endpoints[0].HotRod)
+	assert.Equal(t, []connector{{}}, parsed.Endpoints[0].Rest)
+
+	spec.Endpoints.HotRod.Port = 11322
+	spec.Endpoints.Rest.Disabled = true
+	parsed = generate()
+	assert.Len(t, parsed.SocketBindings, 3)
+	assert.Equal(t, "hotrod", parsed.SocketBindings[2].Name)
+	assert.Equal(t, "11322", parsed.SocketBindings[2].Port)
+	assert.Equal(t, []connector{{SocketBinding: "hotrod"}}, parsed.Endpoints[0].HotRod)
+	assert.Empty(t, parsed.Endpoints[0].Rest)
+	// The admin endpoint used by the operator is not affected
+	assert.Len(t, parsed.Endpoints[1].Rest, 1)
+}
+
+func TestGenerateFDSockPort(t *testing.T) {
+	spec := &Spec{
+		ClusterName: "example-infinispan",
+		Infinispan:  Infinispan{Authorization: &Authorization{}},
+		Endpoints:   Endpoints{ClientCert: "None"},
+	}
+	type fdSock struct {
+		StartPort string `xml:"start_port,attr"`
+		PortRange string `xml:"port_range,attr"`
+	}
+	generate := func(generator func(*version.Version, *Spec) (string, error)) []fdSock {
+		config, err := generator(nil, spec)
+		require.NoError(t, err)
+		var parsed struct {
+			FDSock []fdSock `xml:"jgroups>stack>FD_SOCK"`
+		}
+		require.NoError(t, xml.NewDecoder(strings.NewReader(config)).Decode(&parsed))
+		return parsed.FDSock
+	}
+
+	assert.Empty(t, generate(Generate))
+	assert.Empty(t, generate(GenerateZeroCapacity))
+
+	spec.JGroups.FDSockPort = 57800
+	assert.Equal(t, []fdSock{{StartPort: "57800", PortRange: "0"}}, generate(Generate))
+	assert.Equal(t, []fdSock{{StartPort: "57800", PortRange: "0"}}, generate(GenerateZeroCapacity))
+}
+
+func TestGenerateIPv6(t *testing.T) {
+	spec := &Spec{
+		ClusterName:     "example-infinispan",
+		Namespace:       "default",
+		StatefulSetName: "example-infinispan",
+		PingServiceName: "example-infinispan-ping",
+		Infinispan:      Infinispan{Authorization: &Authorization{}},
+		Endpoints:       Endpoints{ClientCert: "None"},
+	}
+	type stack struct {
+		TCP struct {
+			BindAddr string `xml:"bind_addr,attr"`
+		}
+		DNSPing struct {
+			DNSRecordType string `xml:"dns_record_type,attr"`
+		} `xml:"dns.DNS_PING"`
+	}
+	generate := func(generator func(*version.Version, *Spec) (string, error)) []string {
+		config, err := generator(nil, spec)
+		require.NoError(t, err)
+		var parsed struct {
+			Stacks []stack `xml:"jgroups>stack"`
+		}
+		require.NoError(t, xml.NewDecoder(strings.NewReader(config)).Decode(&parsed))
+		require.Len(t, parsed.Stacks, 1)
+		return []string{parsed.Stacks[0].TCP.BindAddr, parsed.Stacks[0].DNSPing.DNSRecordType}
+	}
+
+	expected := []string{"${jgroups.bind.address:SITE_LOCAL}", "A"}
+	assert.Equal(t, expected, generate(Generate))
+	assert.Equal(t, expected, generate(GenerateZeroCapacity))
+
+	spec.JGroups.IPv6 = true
+	expected = []string{"${jgroups.bind.address:GLOBAL}", "AAAA"}
+	assert.Equal(t, expected, generate(Generate))
+	assert.Equal(t, expected, generate(GenerateZeroCapacity))
+}
+
+func TestGenerateDNSQuery(t *testing.T) {
+	spec := &Spec{
+		ClusterName:     "example-infinispan",
+		Namespace:       "default",
+		StatefulSetName: "example-infinispan",
+		PingServiceName: "shared-discovery",
+		Infinispan:      Infinispan{Authorization: &Authorization{}},
+		Endpoints:       Endpoints{ClientCert: "None"},
+	}
+	generate := func(generator func(*version.Version, *Spec) (string, error)) string {
+		config, err := generator(nil, spec)
+		require.NoError(t, err)
+		var parsed struct {
+			Stacks []struct {
+				DNSPing struct {
+					DNSQuery string `xml:"dns_query,attr"`
+				} `xml:"dns.DNS_PING"`
+			} `xml:"jgroups>stack"`
+		}
+		require.NoError(t, xml.NewDecoder(strings.NewReader(config)).Decode(&parsed))
+		require.Len(t, parsed.Stacks, 1)
+		return parsed.Stacks[0].DNSPing.DNSQuery
+	}
+
+	expected := "shared-discovery.default.svc.cluster.local"
+	assert.Equal(t, expected, generate(Generate))
+	assert.Equal(t, expected, generate(GenerateZeroCapacity))
+}
+
+func TestGenerateCapacityFactor(t *testing.T) {
+	spec := &Spec{
+		ClusterName:     "example-infinispan",
+		Namespace:       "default",
+		StatefulSetName: "example-infinispan",
+		PingServiceName: "example-infinispan-ping",
+		Infinispan:      Infinispan{Authorization: &Authorization{}},
+		Endpoints:       Endpoints{ClientCert: "None"},
+	}
+	capacityFactors := func() []string {
+		config, err := Generate(nil, spec)
+		require.NoError(t, err)
+		var parsed struct {
+			Templates []struct {
+				CapacityFactor string `xml:"capacity-factor,attr"`
+			} `xml:"cache-container>distributed-cache-configuration"`
+		}
+		require.NoError(t, xml.NewDecoder(strings.NewReader(config)).Decode(&parsed))
+		var factors []string
+		for _, template := range parsed.Templates {
+			factors = append(factors, template.CapacityFactor)
+		}
+		return factors
+	}
+
+	assert.Equal(t, []string{"", ""}, capacityFactors())
+
+	spec.Infinispan.CapacityFactor = true
+	factor := "${env.INFINISPAN_CAPACITY_FACTOR:1}"
+	assert.Equal(t, []string{factor, factor}, capacityFactors())
+}
+
+func TestGenerateHotRodExternalAddress(t *testing.T) {
+	spec := &Spec{
+		ClusterName:     "example-infinispan",
+		Namespace:       "default",
+		StatefulSetName: "example-infinispan",
+		PingServiceName: "example-infinispan-ping",
+		Infinispan:      Infinispan{Authorization: &Authorization{}},
+		Endpoints:       Endpoints{ClientCert: "None"},
+	}
+	connector := func() (string, string) {
+		config, err := Generate(nil, spec)
+		require.NoError(t, err)
+		var parsed struct {
+			Endpoints []struct {
+				SocketBinding string `xml:"socket-binding,attr"`
+				HotRod        struct {
+					ExternalHost string `xml:"external-host,attr"`
+					ExternalPort string `xml:"external-port,attr"`
+				} `xml:"hotrod-connector"`
+			} `xml:"server>endpoints>endpoint"`
+		}
+		require.NoError(t, xml.NewDecoder(strings.NewReader(config)).Decode(&parsed))
+		for _, endpoint := range parsed.Endpoints {
+			if endpoint.SocketBinding == "default" {
+				return endpoint.HotRod.ExternalHost, endpoint.HotRod.ExternalPort
+			}
+		}
+		t.Fatal("default endpoint not found")
+		return "", ""
+	}
+
+	host, port := connector()
+	assert.Empty(t, host)
+	assert.Empty(t, port)
+
+	spec.Endpoints.HotRod.External = true
+	host, port = connector()
+	assert.Equal(t, "${env.INFINISPAN_EXTERNAL_HOST}", host)
+	assert.Equal(t, "${env.INFINISPAN_EXTERNAL_PORT}", port)
+}
+
+func TestGenerateClientListeners(t *testing.T) {
+	spec := &Spec{
+		ClusterName:     "example-infinispan",
+		Namespace:       "default",
+		StatefulSetName: "example-infinispan",
+		PingServiceName: "example-infinispan-ping",
+		Infinispan:      Infinispan{Authorization: &Authorization{}},
+		Endpoints:       Endpoints{ClientCert: "None"},
+	}
+	type threadPool struct {
+		Name        string `xml:"name,attr"`
+		MaxThreads  string `xml:"max-threads,attr"`
+		QueueLength string `xml:"queue-length,attr"`
+	}
+	var parsed struct {
+		ThreadPools []threadPool `xml:"threads>blocking-bounded-queue-thread-pool"`
+		Container   struct {
+			ListenerExecutor string `xml:"listener-executor,attr"`
+		} `xml:"cache-container"`
+		Endpoints []struct {
+			SocketBinding string `xml:"socket-binding,attr"`
+			HotRod        struct {
+				SendBufferSize string `xml:"send-buffer-size,attr"`
+			} `xml:"hotrod-connector"`
+		} `xml:"server>endpoints>endpoint"`
+	}
+	generate := func() {
+		config, err := Generate(nil, spec)
+		require.NoError(t, err)
+		parsed.ThreadPools, parsed.Endpoints = nil, nil
+		require.NoError(t, xml.NewDecoder(strings.NewReader(config)).Decode(&parsed))
+	}
+
+	generate()
+	assert.Empty(t, parsed.ThreadPools)
+	assert.Empty(t, parsed.Container.ListenerExecutor)
+	assert.Empty(t, parsed.Endpoints[0].HotRod.SendBufferSize)
+
+	spec.Infinispan.ListenerExecutor = &ThreadPool{MaxThreads: 4, QueueLength: 10000}
+	spec.Endpoints.HotRod.SendBufferSize = 1048576
+	generate()
+	assert.Equal(t, []threadPool{{Name: "listener", MaxThreads: "4", QueueLength: "10000"}}, parsed.ThreadPools)
+	assert.Equal(t, "listener", parsed.Container.ListenerExecutor)
+	assert.Equal(t, "default", parsed.Endpoints[0].SocketBinding)
+	assert.Equal(t, "1048576", parsed.Endpoints[0].HotRod.SendBufferSize)
+}
+
+func TestGenerateTokenRealm(t *testing.T) {
+	spec := &Spec{
+		ClusterName: "example-infinispan",
+		Infinispan:  Infinispan{Authorization: &Authorization{}},
+		Endpoints:   Endpoints{Authenticate: true, ClientCert: "None"},
+	}
+	type tokenRealm struct {
+		AuthServerURL  string `xml:"auth-server-url,attr"`
+		ClientID       string `xml:"client-id,attr"`
+		PrincipalClaim string `xml:"principal-claim,attr"`
+		Introspection  struct {
+			ClientID         string `xml:"client-id,attr"`
+			IntrospectionURL string `xml:"introspection-url,attr"`
+			Credential       struct {
+				Store string `xml:"store,attr"`
+				Alias string `xml:"alias,attr"`
+			} `xml:"credential-reference"`
+		} `xml:"oauth2-introspection"`
+	}
+	parse := func() (realms []tokenRealm, credentialStores int, propertiesRealms int) {
+		config, err := Generate(nil, spec)
+		require.NoError(t, err)
+		var parsed struct {
+			CredentialStores []struct{} `xml:"server>security>credential-stores>credential-store"`
+			Realms           []struct {
+				Name       string       `xml:"name,attr"`
+				Properties []struct{}   `xml:"properties-realm"`
+				Token      []tokenRealm `xml:"token-realm"`
+			} `xml:"server>security>security-realms>security-realm"`
+		}
+		require.NoError(t, xml.NewDecoder(strings.NewReader(config)).Decode(&parsed))
+		for _, r := range parsed.Realms {
+			if r.Name == "default" {
+				return r.Token, len(parsed.CredentialStores), len(r.Properties)
+			}
+		}
+		t.Fatal("default security realm not found")
+		return
+	}
+
+	realms, stores, _ := parse()
+	assert.Empty(t, realms)
+	assert.Zero(t, stores)
+
+	spec.Endpoints.OIDC = &OIDC{
+		AuthServerURL:         "https://keycloak.example.com/auth/realms/infinispan",
+		ClientID:              "infinispan-console",
+		IntrospectionClientID: "infinispan-server",
+		PrincipalClaim:        "preferred_username",
+	}
+	realms, stores, properties := parse()
+	require.Len(t, realms, 1)
+	assert.Equal(t, 1, stores)
+	// Users of the identities secret can still authenticate with their credentials
+	assert.Equal(t, 1, properties)
+	realm := realms[0]
+	assert.Equal(t, "https://keycloak.example.com/auth/realms/infinispan", realm.AuthServerURL)
+	assert.Equal(t, "infinispan-console", realm.ClientID)
+	assert.Equal(t, "preferred_username", realm.PrincipalClaim)
+	assert.Equal(t, "infinispan-server", realm.Introspection.ClientID)
+	assert.Equal(t, "https://keycloak.example.com/auth/realms/infinispan/protocol/openid-connect/token/introspect", realm.Introspection.IntrospectionURL)
+	assert.Equal(t, "credentials", realm.Introspection.Credential.Store)
+	assert.Equal(t, "oidc", realm.Introspection.Credential.Alias)
+
+	spec.Endpoints.OIDC = nil
+	spec.Endpoints.TokenReview = &TokenReview{
+		IntrospectionURL: "http://localhost:11230/introspect",
+		PrincipalClaim:   "username",
+	}
+	realms, stores, properties = parse()
+	require.Len(t, realms, 1)
+	assert.Zero(t, stores)
+	assert.Equal(t, 1, properties)
+	realm = realms[0]
+	assert.Empty(t, realm.AuthServerURL)
+	assert.Equal(t, "username", realm.PrincipalClaim)
+	assert.Equal(t, "example-infinispan", realm.Introspection.ClientID)
+	assert.Equal(t, "http://localhost:11230/introspect", realm.Introspection.IntrospectionURL)
+}
+
+func TestGenerateKerberosIdentity(t *testing.T) {
+	spec := &Spec{
+		ClusterName: "example-infinispan",
+		Infinispan:  Infinispan{Authorization: &Authorization{}},
+		Endpoints:   Endpoints{Authenticate: true, ClientCert: "None"},
+	}
+	type kerberos struct {
+		Principal  string `xml:"principal,attr"`
+		KeytabPath string `xml:"keytab-path,attr"`
+	}
+	parse := func() (identities []kerberos, serverPrincipal string) {
+		config, err := Generate(nil, spec)
+		require.NoError(t, err)
+		var parsed struct {
+			Realms []struct {
+				Name     string     `xml:"name,attr"`
+				Kerberos []kerberos `xml:"server-identities>kerberos"`
+			} `xml:"server>security>security-realms>security-realm"`
+			Sasl struct {
+				ServerPrincipal string `xml:"server-principal,attr"`
+			} `xml:"server>endpoints>endpoint>hotrod-connector>authentication>sasl"`
+		}
+		require.NoError(t, xml.NewDecoder(strings.NewReader(config)).Decode(&parsed))
+		for _, r := range parsed.Realms {
+			if r.Name == "default" {
+				identities = r.Kerberos
+			}
+		}
+		return identities, parsed.Sasl.ServerPrincipal
+	}
+
+	identities, serverPrincipal := parse()
+	assert.Empty(t, identities)
+	assert.Empty(t, serverPrincipal)
+
+	spec.Endpoints.Kerberos = &Kerberos{
+		Principal:  "hotrod/example-infinispan.default.svc@EXAMPLE.COM",
+		KeytabPath: "/etc/security/kerberos/keytab",
+	}
+	identities, serverPrincipal = parse()
+	require.Len(t, identities, 1)
+	assert.Equal(t, "hotrod/example-infinispan.default.svc@EXAMPLE.COM", identities[0].Principal)
+	assert.Equal(t, "/etc/security/kerberos/keytab", identities[0].KeytabPath)
+	assert.Equal(t, "hotrod/example-infinispan.default.svc@EXAMPLE.COM", serverPrincipal)
+}
+
+func TestGenerateVaultCredentials(t *testing.T) {
+	spec := &Spec{
+		ClusterName: "example-infinispan",
+		Infinispan:  Infinispan{Authorization: &Authorization{}},
+		Endpoints:   Endpoints{Authenticate: true, ClientCert: "None"},
+		Keystore:    Keystore{Path: "/etc/encrypt/keystore/keystore.p12"},
+	}
+	type properties struct {
+		Path       string `xml:"path,attr"`
+		RelativeTo string `xml:"relative-to,attr"`
+		PlainText  string `xml:"plain-text,attr"`
+	}
+	type realm struct {
+		Name     string `xml:"name,attr"`
+		Keystore struct {
+			Password string `xml:"keystore-password,attr"`
+		} `xml:"server-identities>ssl>keystore"`
+		Users  properties `xml:"properties-realm>user-properties"`
+		Groups properties `xml:"properties-realm>group-properties"`
+	}
+	parse := func() realm {
+		config, err := Generate(nil, spec)
+		require.NoError(t, err)
+		var parsed struct {
+			Realms []realm `xml:"server>security>security-realms>security-realm"`
+		}
+		require.NoError(t, xml.NewDecoder(strings.NewReader(config)).Decode(&parsed))
+		for _, r := range parsed.Realms {
+			if r.Name == "default" {
+				return r
+			}
+		}
+		t.Fatal("default realm not found")
+		return realm{}
+	}
+
+	r := parse()
+	assert.Equal(t, properties{Path: "cli-users.properties", RelativeTo: "infinispan.server.config.path"}, r.Users)
+	assert.Equal(t, properties{Path: "cli-groups.properties", RelativeTo: "infinispan.server.config.path"}, r.Groups)
+	assert.Empty(t, r.Keystore.Password)
+
+	spec.Endpoints.IdentitiesPath = "/vault/secrets"
+	spec.Keystore.PasswordProperty = "infinispan.keystore.password"
+	r = parse()
+	assert.Equal(t, properties{Path: "/vault/secrets/users.properties", PlainText: "true"}, r.Users)
+	assert.Equal(t, properties{Path: "/vault/secrets/groups.properties"}, r.Groups)
+	assert.Equal(t, "${infinispan.keystore.password}", r.Keystore.Password)
+}
+
+func TestGenerateEndpointTLS(t *testing.T) {
+	spec := &Spec{
+		ClusterName: "example-infinispan",
+		Infinispan:  Infinispan{Authorization: &Authorization{}},
+		Endpoints:   Endpoints{Authenticate: true, ClientCert: "None"},
+		Keystore:    Keystore{Path: "/etc/encrypt/keystore/keystore.p12"},
+	}
+	type engine struct {
+		Protocols         *string `xml:"enabled-protocols,attr"`
+		CipherSuites      *string `xml:"enabled-ciphersuites,attr"`
+		CipherSuitesTLS13 *string `xml:"enabled-ciphersuites-tls13,attr"`
+	}
+	parse := func() *engine {
+		config, err := Generate(nil, spec)
+		require.NoError(t, err)
+		var parsed struct {
+			Realms []struct {
+				Name   string  `xml:"name,attr"`
+				Engine *engine `xml:"server-identities>ssl>engine"`
+			} `xml:"server>security>security-realms>security-realm"`
+		}
+		require.NoError(t, xml.NewDecoder(strings.NewReader(config)).Decode(&parsed))
+		for _, r := range parsed.Realms {
+			if r.Name == "default" {
+				return r.Engine
+			}
+		}
+		t.Fatal("default realm not found")
+		return nil
+	}
+
+	assert.Nil(t, parse())
+
+	spec.Endpoints.TLS = &EndpointTLS{Protocols: "TLSv1.3", CipherSuitesTLS13: "TLS_AES_256_GCM_SHA384:TLS_CHACHA20_POLY1305_SHA256"}
+	e := parse()
+	require.NotNil(t, e)
+	assert.Equal(t, "TLSv1.3", *e.Protocols)
+	assert.Nil(t, e.CipherSuites)
+	assert.Equal(t, "TLS_AES_256_GCM_SHA384:TLS_CHACHA20_POLY1305_SHA256", *e.CipherSuitesTLS13)
+}
+
+func TestGenerateAuditLogger(t *testing.T) {
+	spec := &Spec{
+		ClusterName: "example-infinispan",
+		Infinispan:  Infinispan{Authorization: &Authorization{Enabled: true, RoleMapper: "cluster"}},
+	}
+	auditLogger := func() string {
+		config, err := Generate(nil, spec)
+		require.NoError(t, err)
+		var parsed struct {
+			Authorization struct {
+				AuditLogger string `xml:"audit-logger,attr"`
+			} `xml:"cache-container>security>authorization"`
+		}
+		require.NoError(t, xml.NewDecoder(strings.NewReader(config)).Decode(&parsed))
+		return parsed.Authorization.AuditLogger
+	}
+	assert.Empty(t, auditLogger())
+
+	spec.Infinispan.Authorization.AuditLog = true
+	assert.Equal(t, "org.infinispan.security.audit.LoggingAuditLogger", auditLogger())
+}
+
+func TestGenerateCredentialStore(t *testing.T) {
+	spec := &Spec{
+		ClusterName: "example-infinispan",
+		Infinispan:  Infinispan{Authorization: &Authorization{}},
+	}
+	type reference struct {
+		Store string `xml:"store,attr"`
+		Alias string `xml:"alias,attr"`
+	}
+	type parsed struct {
+		Stores []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"server>security>credential-stores>credential-store"`
+		Realms []struct {
+			Name       string    `xml:"name,attr"`
+			Keystore   reference `xml:"server-identities>ssl>keystore>credential-reference"`
+			Truststore reference `xml:"server-identities>ssl>truststore>credential-reference"`
+		} `xml:"server>security>security-realms>security-realm"`
+	}
+	parse := func() parsed {
+		config, err := Generate(nil, spec)
+		require.NoError(t, err)
+		var p parsed
+		require.NoError(t, xml.NewDecoder(strings.NewReader(config)).Decode(&p))
+		return p
+	}
+
+	assert.Empty(t, parse().Stores)
+
+	spec.Credentials = []string{"ldap-bind"}
+	p := parse()
+	require.Len(t, p.Stores, 1)
+	assert.Equal(t, "credentials", p.Stores[0].Name)
+
+	spec.Credentials = nil
+	spec.Transport.TLS = TransportTLS{
+		Enabled:    true,
+		KeyStore:   Keystore{Alias: "example-infinispan", Path: "/etc/encrypt/transport/keystore.p12"},
+		TrustStore: Truststore{Path: "/etc/encrypt/transport/truststore.p12"},
+	}
+	p = parse()
+	require.Len(t, p.Stores, 1)
+	for _, r := range p.Realms {
+		if r.Name == "transport" {
+			assert.Equal(t, reference{Store: "credentials", Alias: "transport-keystore"}, r.Keystore)
+			assert.Equal(t, reference{Store: "credentials", Alias: "transport-truststore"}, r.Truststore)
+			return
+		}
+	}
+	t.Fatal("transport realm not found")
+}
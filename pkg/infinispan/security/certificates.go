@@ -0,0 +1,127 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+	"time"
+
+	certUtil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+const rsaKeySize = 2048
+
+// GenerateCA returns a self-signed CA certificate and its private key as pem
+func GenerateCA(commonName string, validity time.Duration) (certPem, keyPem []byte, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeySize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Unable to generate CA key: %w", err)
+	}
+	template, err := certificateTemplate(commonName, validity)
+	if err != nil {
+		return nil, nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Unable to create CA certificate: %w", err)
+	}
+	return encodeCertificate(der), encodeKey(key), nil
+}
+
+// GenerateServingCertificate returns a certificate signed by the provided CA, valid for the provided hostnames and IP
+// addresses, and its private key as pem
+func GenerateServingCertificate(caCertPem, caKeyPem []byte, commonName string, hostnames []string, validity time.Duration) (certPem, keyPem []byte, err error) {
+	caCerts, err := certUtil.ParseCertsPEM(caCertPem)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Unable to parse CA certificate: %w", err)
+	}
+	caKey, err := keyutil.ParsePrivateKeyPEM(caKeyPem)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Unable to parse CA key: %w", err)
+	}
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeySize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Unable to generate key: %w", err)
+	}
+	template, err := certificateTemplate(commonName, validity)
+	if err != nil {
+		return nil, nil, err
+	}
+	template.KeyUsage = x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	for _, host := range hostnames {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, caCerts[0], key.Public(), caKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Unable to create certificate: %w", err)
+	}
+	return encodeCertificate(der), encodeKey(key), nil
+}
+
+// CertificateHostnames returns the sorted DNS names and IP addresses of the first certificate of the pem file, together
+// with the time the certificate expires
+func CertificateHostnames(certPem []byte) ([]string, time.Time, error) {
+	certs, err := certUtil.ParseCertsPEM(certPem)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("Unable to parse certificate: %w", err)
+	}
+	hostnames := append([]string{}, certs[0].DNSNames...)
+	for _, ip := range certs[0].IPAddresses {
+		hostnames = append(hostnames, ip.String())
+	}
+	sort.Strings(hostnames)
+	return hostnames, certs[0].NotAfter, nil
+}
+
+// IsCertificateSignedBy returns true if the first certificate of certPem is signed by the first certificate of caCertPem
+func IsCertificateSignedBy(certPem, caCertPem []byte) bool {
+	certs, err := certUtil.ParseCertsPEM(certPem)
+	if err != nil {
+		return false
+	}
+	caCerts, err := certUtil.ParseCertsPEM(caCertPem)
+	if err != nil {
+		return false
+	}
+	return certs[0].CheckSignatureFrom(caCerts[0]) == nil
+}
+
+func certificateTemplate(commonName string, validity time.Duration) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("Unable to generate certificate serial number: %w", err)
+	}
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		// Tolerate clock skew between the operator and the clients
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(validity),
+	}, nil
+}
+
+func encodeCertificate(der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: certUtil.CertificateBlockType, Bytes: der})
+}
+
+func encodeKey(key *rsa.PrivateKey) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: keyutil.RSAPrivateKeyBlockType, Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
@@ -0,0 +1,36 @@
+package security
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certUtil "k8s.io/client-go/util/cert"
+)
+
+func TestGenerateServingCertificate(t *testing.T) {
+	caCert, caKey, err := GenerateCA("example CA", time.Hour)
+	require.NoError(t, err)
+	otherCACert, _, err := GenerateCA("other CA", time.Hour)
+	require.NoError(t, err)
+
+	hostnames := []string{"example.ns.svc", "infinispan.example.com", "203.0.113.10"}
+	cert, key, err := GenerateServingCertificate(caCert, caKey, "example.ns.svc", hostnames, 2*time.Hour)
+	require.NoError(t, err)
+	assert.NotEmpty(t, key)
+
+	certs, err := certUtil.ParseCertsPEM(cert)
+	require.NoError(t, err)
+	assert.Equal(t, "example.ns.svc", certs[0].Subject.CommonName)
+	assert.False(t, certs[0].IsCA)
+
+	actual, notAfter, err := CertificateHostnames(cert)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"203.0.113.10", "example.ns.svc", "infinispan.example.com"}, actual)
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), notAfter, time.Minute)
+
+	assert.True(t, IsCertificateSignedBy(cert, caCert))
+	assert.False(t, IsCertificateSignedBy(cert, otherCACert))
+	assert.False(t, IsCertificateSignedBy([]byte("not pem"), caCert))
+}
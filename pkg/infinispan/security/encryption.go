@@ -11,6 +11,75 @@ import (
 	p12 "software.sslmate.com/src/go-pkcs12"
 )
 
+// GenerateCABundle aggregates the CERTIFICATE entries of the provided pem files into a single pem bundle, omitting duplicates
+func GenerateCABundle(pemFiles [][]byte) []byte {
+	var bundle []byte
+	seen := make(map[string]struct{})
+	for _, pemFile := range pemFiles {
+		pemRaw := pemFile
+		for {
+			block, rest := pem.Decode(pemRaw)
+			if block == nil {
+				break
+			}
+			pemRaw = rest
+			if block.Type != certUtil.CertificateBlockType {
+				continue
+			}
+			if _, exists := seen[string(block.Bytes)]; exists {
+				continue
+			}
+			seen[string(block.Bytes)] = struct{}{}
+			bundle = append(bundle, pem.EncodeToMemory(block)...)
+		}
+	}
+	return bundle
+}
+
+// GetKeystoreCertificates returns the certificate chain of a PKCS12 or JKS keystore as pem
+func GetKeystoreCertificates(keystore []byte, password string, jks bool) ([]byte, error) {
+	var certs []*x509.Certificate
+	if jks {
+		chains, _, err := decodeJKS(keystore, password)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to decode keystore: %w", err)
+		}
+		for _, chain := range chains {
+			certs = append(certs, chain...)
+		}
+	} else {
+		_, cert, caCerts, err := p12.DecodeChain(keystore, password)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to decode keystore: %w", err)
+		}
+		certs = append([]*x509.Certificate{cert}, caCerts...)
+	}
+	var pemCerts []byte
+	for _, c := range certs {
+		pemCerts = append(pemCerts, pem.EncodeToMemory(&pem.Block{Type: certUtil.CertificateBlockType, Bytes: c.Raw})...)
+	}
+	return pemCerts, nil
+}
+
+// GetTruststoreCertificates returns the trusted certificates of a PKCS12 or JKS truststore as pem
+func GetTruststoreCertificates(truststore []byte, password string, jks bool) ([]byte, error) {
+	var certs []*x509.Certificate
+	var err error
+	if jks {
+		_, certs, err = decodeJKS(truststore, password)
+	} else {
+		certs, err = p12.DecodeTrustStore(truststore, password)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode truststore: %w", err)
+	}
+	var pemCerts []byte
+	for _, c := range certs {
+		pemCerts = append(pemCerts, pem.EncodeToMemory(&pem.Block{Type: certUtil.CertificateBlockType, Bytes: c.Raw})...)
+	}
+	return pemCerts, nil
+}
+
 func GenerateTruststore(pemFiles [][]byte, password string) ([]byte, error) {
 	var certs []*x509.Certificate
 	for _, pemFile := range pemFiles {
@@ -0,0 +1,88 @@
+package security
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certUtil "k8s.io/client-go/util/cert"
+	p12 "software.sslmate.com/src/go-pkcs12"
+)
+
+func TestGenerateCABundle(t *testing.T) {
+	caPem, _, _ := selfSignedCert(t, "ca")
+	certPem, _, _ := selfSignedCert(t, "server")
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("key")})
+	caAndCertPem := bytes.Join([][]byte{caPem, certPem}, nil)
+
+	testTable := []struct {
+		name     string
+		pemFiles [][]byte
+		expected []byte
+	}{
+		{"empty input", nil, nil},
+		{"no certificates", [][]byte{keyPem, []byte("not pem")}, nil},
+		{"single certificate", [][]byte{caPem}, caPem},
+		{"duplicates removed across inputs", [][]byte{caPem, caAndCertPem, certPem}, caAndCertPem},
+		{"non certificate blocks skipped", [][]byte{bytes.Join([][]byte{keyPem, certPem}, nil)}, certPem},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GenerateCABundle(tt.pemFiles))
+		})
+	}
+}
+
+func TestGetKeystoreCertificates(t *testing.T) {
+	certPem, cert, key := selfSignedCert(t, "server")
+	keystore, err := p12.Encode(rand.Reader, key, cert, nil, "password")
+	require.NoError(t, err)
+
+	certs, err := GetKeystoreCertificates(keystore, "password", false)
+	require.NoError(t, err)
+	assert.Equal(t, certPem, certs)
+
+	_, err = GetKeystoreCertificates(keystore, "wrong-password", false)
+	assert.Error(t, err)
+}
+
+func TestGetTruststoreCertificates(t *testing.T) {
+	caPem, _, _ := selfSignedCert(t, "ca")
+	otherPem, _, _ := selfSignedCert(t, "other")
+	truststore, err := GenerateTruststore([][]byte{caPem, otherPem}, "password")
+	require.NoError(t, err)
+
+	certs, err := GetTruststoreCertificates(truststore, "password", false)
+	require.NoError(t, err)
+	assert.Equal(t, append(caPem, otherPem...), certs)
+
+	_, err = GetTruststoreCertificates(truststore, "wrong-password", false)
+	assert.Error(t, err)
+}
+
+func selfSignedCert(t *testing.T, commonName string) ([]byte, *x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: certUtil.CertificateBlockType, Bytes: der}), cert, key
+}
@@ -0,0 +1,138 @@
+package security
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+)
+
+const (
+	jksMagic          = 0xFEEDFEED
+	jksPrivateKeyTag  = 1
+	jksTrustedCertTag = 2
+	// jksDigestWhitener is appended to the password when computing the integrity digest of a JKS keystore
+	jksDigestWhitener = "Mighty Aphrodite"
+)
+
+// decodeJKS returns the certificate chains of the private key entries and the trusted certificate entries of a JKS
+// keystore, after verifying the integrity of the keystore with the password. The private keys themselves are not decrypted
+func decodeJKS(keystore []byte, password string) (chains [][]*x509.Certificate, trusted []*x509.Certificate, err error) {
+	if len(keystore) < sha1.Size {
+		return nil, nil, errors.New("keystore is too short")
+	}
+	content, digest := keystore[:len(keystore)-sha1.Size], keystore[len(keystore)-sha1.Size:]
+	if subtle.ConstantTimeCompare(jksDigest(content, password), digest) != 1 {
+		return nil, nil, errors.New("keystore password was incorrect or keystore is corrupt")
+	}
+
+	r := bytes.NewReader(content)
+	var magic, version, count uint32
+	for _, v := range []*uint32{&magic, &version, &count} {
+		if err := binary.Read(r, binary.BigEndian, v); err != nil {
+			return nil, nil, fmt.Errorf("unable to read keystore header: %w", err)
+		}
+	}
+	if magic != jksMagic || (version != 1 && version != 2) {
+		return nil, nil, errors.New("not a JKS keystore")
+	}
+
+	for e := uint32(0); e < count; e++ {
+		var tag uint32
+		if err := binary.Read(r, binary.BigEndian, &tag); err != nil {
+			return nil, nil, fmt.Errorf("unable to read keystore entry: %w", err)
+		}
+		// Skip the alias and the creation timestamp of the entry
+		if _, err := readJKSBytes(r, 2); err != nil {
+			return nil, nil, err
+		}
+		if _, err := r.Seek(8, io.SeekCurrent); err != nil {
+			return nil, nil, err
+		}
+
+		switch tag {
+		case jksPrivateKeyTag:
+			if _, err := readJKSBytes(r, 4); err != nil {
+				return nil, nil, err
+			}
+			var chainLength uint32
+			if err := binary.Read(r, binary.BigEndian, &chainLength); err != nil {
+				return nil, nil, fmt.Errorf("unable to read certificate chain: %w", err)
+			}
+			var chain []*x509.Certificate
+			for c := uint32(0); c < chainLength; c++ {
+				cert, err := readJKSCertificate(r, version)
+				if err != nil {
+					return nil, nil, err
+				}
+				chain = append(chain, cert)
+			}
+			chains = append(chains, chain)
+		case jksTrustedCertTag:
+			cert, err := readJKSCertificate(r, version)
+			if err != nil {
+				return nil, nil, err
+			}
+			trusted = append(trusted, cert)
+		default:
+			return nil, nil, fmt.Errorf("unsupported keystore entry type %d", tag)
+		}
+	}
+	return chains, trusted, nil
+}
+
+// jksDigest returns the integrity digest of the content of a JKS keystore
+func jksDigest(content []byte, password string) []byte {
+	md := sha1.New()
+	for _, c := range utf16.Encode([]rune(password)) {
+		md.Write([]byte{byte(c >> 8), byte(c)})
+	}
+	md.Write([]byte(jksDigestWhitener))
+	md.Write(content)
+	return md.Sum(nil)
+}
+
+func readJKSCertificate(r *bytes.Reader, version uint32) (*x509.Certificate, error) {
+	if version == 2 {
+		// Skip the certificate type, which is always X.509
+		if _, err := readJKSBytes(r, 2); err != nil {
+			return nil, err
+		}
+	}
+	der, err := readJKSBytes(r, 4)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse certificate: %w", err)
+	}
+	return cert, nil
+}
+
+// readJKSBytes reads a byte array prefixed with its length, encoded with lengthSize bytes
+func readJKSBytes(r *bytes.Reader, lengthSize int) ([]byte, error) {
+	var length uint32
+	if lengthSize == 2 {
+		var short uint16
+		if err := binary.Read(r, binary.BigEndian, &short); err != nil {
+			return nil, fmt.Errorf("unable to read keystore: %w", err)
+		}
+		length = uint32(short)
+	} else if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, fmt.Errorf("unable to read keystore: %w", err)
+	}
+	if int64(length) > int64(r.Len()) {
+		return nil, errors.New("unable to read keystore: unexpected end of data")
+	}
+	b := make([]byte, length)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, fmt.Errorf("unable to read keystore: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,62 @@
+package security
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetKeystoreCertificatesJKS(t *testing.T) {
+	certPem, cert, _ := selfSignedCert(t, "server")
+	caPem, ca, _ := selfSignedCert(t, "ca")
+	trustedPem, trusted, _ := selfSignedCert(t, "trusted")
+
+	// A version 2 keystore with a private key entry and a trusted certificate entry
+	var content bytes.Buffer
+	write := func(v interface{}) { require.NoError(t, binary.Write(&content, binary.BigEndian, v)) }
+	writeBytes := func(b []byte, short bool) {
+		if short {
+			write(uint16(len(b)))
+		} else {
+			write(uint32(len(b)))
+		}
+		content.Write(b)
+	}
+	writeCert := func(der []byte) {
+		writeBytes([]byte("X.509"), true)
+		writeBytes(der, false)
+	}
+	write(uint32(jksMagic))
+	write(uint32(2))
+	write(uint32(2))
+	write(uint32(jksPrivateKeyTag))
+	writeBytes([]byte("server"), true)
+	write(int64(0))
+	writeBytes([]byte("encrypted key"), false)
+	write(uint32(2))
+	writeCert(cert.Raw)
+	writeCert(ca.Raw)
+	write(uint32(jksTrustedCertTag))
+	writeBytes([]byte("trusted"), true)
+	write(int64(0))
+	writeCert(trusted.Raw)
+	keystore := append(content.Bytes(), jksDigest(content.Bytes(), "password")...)
+
+	certs, err := GetKeystoreCertificates(keystore, "password", true)
+	require.NoError(t, err)
+	assert.Equal(t, append(certPem, caPem...), certs, "only the chain of the private key is returned")
+	assert.NotContains(t, string(certs), string(trustedPem))
+
+	trustedCerts, err := GetTruststoreCertificates(keystore, "password", true)
+	require.NoError(t, err)
+	assert.Equal(t, trustedPem, trustedCerts, "only the trusted certificate entries are returned")
+
+	_, err = GetKeystoreCertificates(keystore, "wrong-password", true)
+	assert.Error(t, err)
+
+	_, err = GetKeystoreCertificates(keystore[:len(keystore)-1], "password", true)
+	assert.Error(t, err)
+}
@@ -0,0 +1,70 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TokenReviewer creates TokenReviews, as implemented by the TokenReviews client of client-go
+type TokenReviewer interface {
+	Create(ctx context.Context, tokenReview *authv1.TokenReview, opts metav1.CreateOptions) (*authv1.TokenReview, error)
+}
+
+// TokenIntrospection is the OAuth 2.0 token introspection response (RFC 7662) of a ServiceAccount token
+type TokenIntrospection struct {
+	Active bool `json:"active"`
+	// The name of the ServiceAccount, for example system:serviceaccount:my-namespace:my-app
+	Username string   `json:"username,omitempty"`
+	Subject  string   `json:"sub,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+	Audience []string `json:"aud,omitempty"`
+}
+
+// TokenIntrospectionHandler serves the OAuth 2.0 token introspection endpoint that the token realm of the servers
+// validates bearer tokens with. The token of each request is validated with the TokenReview API, so only tokens issued
+// by the Kubernetes cluster for one of the audiences are active
+func TokenIntrospectionHandler(reviewer TokenReviewer, audiences []string, log logr.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		token := r.PostFormValue("token")
+		if token == "" {
+			http.Error(w, "missing required parameter 'token'", http.StatusBadRequest)
+			return
+		}
+
+		review, err := reviewer.Create(r.Context(), &authv1.TokenReview{
+			Spec: authv1.TokenReviewSpec{
+				Token:     token,
+				Audiences: audiences,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			// The server rejects the request, instead of considering the token inactive, so that clients can retry
+			log.Error(err, "unable to review token")
+			http.Error(w, "unable to review token", http.StatusServiceUnavailable)
+			return
+		}
+
+		introspection := &TokenIntrospection{Active: review.Status.Authenticated}
+		if introspection.Active {
+			introspection.Username = review.Status.User.Username
+			introspection.Subject = review.Status.User.UID
+			introspection.Groups = review.Status.User.Groups
+			introspection.Audience = review.Status.Audiences
+		} else if review.Status.Error != "" {
+			log.Info("token rejected", "reason", review.Status.Error)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(introspection); err != nil {
+			log.Error(err, "unable to write introspection response")
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type tokenReviewerFunc func(spec authv1.TokenReviewSpec) (authv1.TokenReviewStatus, error)
+
+func (f tokenReviewerFunc) Create(_ context.Context, review *authv1.TokenReview, _ metav1.CreateOptions) (*authv1.TokenReview, error) {
+	status, err := f(review.Spec)
+	if err != nil {
+		return nil, err
+	}
+	review.Status = status
+	return review, nil
+}
+
+func TestTokenIntrospectionHandler(t *testing.T) {
+	audiences := []string{"example-infinispan.default.svc"}
+	handler := TokenIntrospectionHandler(tokenReviewerFunc(func(spec authv1.TokenReviewSpec) (authv1.TokenReviewStatus, error) {
+		assert.Equal(t, audiences, spec.Audiences)
+		switch spec.Token {
+		case "valid":
+			return authv1.TokenReviewStatus{
+				Authenticated: true,
+				User: authv1.UserInfo{
+					Username: "system:serviceaccount:default:my-app",
+					UID:      "0b7e6a4c",
+					Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:default"},
+				},
+				Audiences: audiences,
+			}, nil
+		case "unavailable":
+			return authv1.TokenReviewStatus{}, errors.New("connection refused")
+		default:
+			return authv1.TokenReviewStatus{Error: "invalid bearer token"}, nil
+		}
+	}), audiences, logr.Discard())
+
+	introspect := func(method, token string) *httptest.ResponseRecorder {
+		form := url.Values{}
+		if token != "" {
+			form.Set("token", token)
+		}
+		req := httptest.NewRequest(method, "/", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	parse := func(rec *httptest.ResponseRecorder) *TokenIntrospection {
+		require.Equal(t, http.StatusOK, rec.Code)
+		introspection := &TokenIntrospection{}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(introspection))
+		return introspection
+	}
+
+	assert.Equal(t, &TokenIntrospection{
+		Active:   true,
+		Username: "system:serviceaccount:default:my-app",
+		Subject:  "0b7e6a4c",
+		Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:default"},
+		Audience: audiences,
+	}, parse(introspect(http.MethodPost, "valid")))
+	assert.Equal(t, &TokenIntrospection{}, parse(introspect(http.MethodPost, "expired")))
+
+	assert.Equal(t, http.StatusServiceUnavailable, introspect(http.MethodPost, "unavailable").Code)
+	assert.Equal(t, http.StatusBadRequest, introspect(http.MethodPost, "").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, introspect(http.MethodGet, "valid").Code)
+}
@@ -96,6 +96,20 @@ func GetUserCredentials() ([]byte, error) {
 	return CreateIdentitiesFor(consts.DefaultDeveloperUser, pass)
 }
 
+// GeneratePassword returns a random password for a server user
+func GeneratePassword() (string, error) {
+	return getRandomStringForAuth(16)
+}
+
+// GenerateUsername returns a unique username with the given prefix
+func GenerateUsername(prefix string) (string, error) {
+	suffix, err := getRandomStringForAuth(6)
+	if err != nil {
+		return "", err
+	}
+	return prefix + strings.ToLower(suffix), nil
+}
+
 // FindPassword finds a user's password
 func FindPassword(usr string, descriptor []byte) (string, error) {
 	var identities Identities
@@ -136,7 +150,9 @@ func AdminPassword(secretName, namespace string, k *kube.Kubernetes, ctx context
 	return passwordFromSecret(consts.DefaultOperatorUser, secretName, namespace, k, ctx)
 }
 
-func IdentitiesCliFileFromSecret(buf []byte, realm, usersFile, groupsFile string) (string, error) {
+// IdentitiesCliFileFromSecret returns the CLI commands that create the users of the identities in a security realm. The
+// passwords are hashed with the given algorithms, or with all algorithms if none are given
+func IdentitiesCliFileFromSecret(buf []byte, realm, usersFile, groupsFile string, algorithms []string) (string, error) {
 	var creds IdentitiesYaml
 	if err := yaml.Unmarshal(buf, &creds); err != nil {
 		return "", err
@@ -147,7 +163,60 @@ func IdentitiesCliFileFromSecret(buf []byte, realm, usersFile, groupsFile string
 		if len(cred.Roles) > 0 {
 			fmt.Fprintf(&b, " --groups %s", strings.Join(cred.Roles, ","))
 		}
-		fmt.Fprintf(&b, "\n")
+		fmt.Fprintf(&b, "%s\n", AlgorithmsCliOption(algorithms))
 	}
 	return b.String(), nil
 }
+
+// AlgorithmsCliOption returns the option of the user create CLI command that hashes the password with the given
+// algorithms, or an empty string if no algorithms are given
+func AlgorithmsCliOption(algorithms []string) string {
+	if len(algorithms) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" --algorithms %s", strings.Join(algorithms, ","))
+}
+
+// ReplaceRole replaces a role of all identities with another role, for example to prevent application users from
+// having the admin role
+func ReplaceRole(descriptor []byte, role, replacement string) ([]byte, error) {
+	var identities Identities
+	if err := yaml.Unmarshal(descriptor, &identities); err != nil {
+		return nil, err
+	}
+	for _, cred := range identities.Credentials {
+		for idx, r := range cred.Roles {
+			if r == role {
+				cred.Roles[idx] = replacement
+			}
+		}
+	}
+	return yaml.Marshal(identities)
+}
+
+// VerifyDistinctCredentials returns an error if any of the identities uses the given username or password
+func VerifyDistinctCredentials(descriptor []byte, usr, pass string) error {
+	var identities Identities
+	if err := yaml.Unmarshal(descriptor, &identities); err != nil {
+		return err
+	}
+	for _, cred := range identities.Credentials {
+		if cred.Username == usr {
+			return fmt.Errorf("user '%s' is reserved for the operator", usr)
+		}
+		if cred.Password == pass {
+			return fmt.Errorf("user '%s' has the same password as the operator", cred.Username)
+		}
+	}
+	return nil
+}
+
+// RestrictUserIdentities grants the deployer role instead of the admin role to the users of the endpoints, so that only
+// the operator user can perform administrative operations, and verifies that none of them can authenticate with the
+// username or password of the operator user
+func RestrictUserIdentities(descriptor []byte, usr, pass string) ([]byte, error) {
+	if err := VerifyDistinctCredentials(descriptor, usr, pass); err != nil {
+		return nil, err
+	}
+	return ReplaceRole(descriptor, "admin", "deployer")
+}
@@ -0,0 +1,61 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestReplaceRole(t *testing.T) {
+	descriptor, err := yaml.Marshal(Identities{
+		Credentials: []Credentials{
+			{Username: "developer", Password: "secret", Roles: []string{"admin"}},
+			{Username: "reader", Password: "secret", Roles: []string{"observer", "admin"}},
+			{Username: "app", Password: "secret"},
+		},
+	})
+	require.NoError(t, err)
+
+	restricted, err := ReplaceRole(descriptor, "admin", "deployer")
+	require.NoError(t, err)
+
+	var identities Identities
+	require.NoError(t, yaml.Unmarshal(restricted, &identities))
+	assert.Equal(t, []string{"deployer"}, identities.Credentials[0].Roles)
+	assert.Equal(t, []string{"observer", "deployer"}, identities.Credentials[1].Roles)
+	assert.Empty(t, identities.Credentials[2].Roles)
+}
+
+func TestVerifyDistinctCredentials(t *testing.T) {
+	descriptor, err := CreateIdentitiesFor("developer", "secret")
+	require.NoError(t, err)
+
+	assert.NoError(t, VerifyDistinctCredentials(descriptor, "operator", "other"))
+	assert.EqualError(t, VerifyDistinctCredentials(descriptor, "developer", "other"), "user 'developer' is reserved for the operator")
+	assert.EqualError(t, VerifyDistinctCredentials(descriptor, "operator", "secret"), "user 'developer' has the same password as the operator")
+}
+
+func TestRestrictUserIdentities(t *testing.T) {
+	descriptor, err := CreateIdentitiesFor("developer", "secret")
+	require.NoError(t, err)
+
+	restricted, err := RestrictUserIdentities(descriptor, "operator", "other")
+	require.NoError(t, err)
+	batch, err := IdentitiesCliFileFromSecret(restricted, "default", "cli-users.properties", "cli-groups.properties", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "user create developer --realm default -p secret --users-file cli-users.properties --groups-file cli-groups.properties --groups deployer\n", batch)
+
+	_, err = RestrictUserIdentities(descriptor, "operator", "secret")
+	assert.Error(t, err)
+}
+
+func TestIdentitiesCliFileAlgorithms(t *testing.T) {
+	descriptor, err := CreateIdentitiesFor("developer", "secret")
+	require.NoError(t, err)
+
+	batch, err := IdentitiesCliFileFromSecret(descriptor, "default", "cli-users.properties", "cli-groups.properties", []string{"scram-sha-256", "scram-sha-512"})
+	require.NoError(t, err)
+	assert.Equal(t, "user create developer --realm default -p secret --users-file cli-users.properties --groups-file cli-groups.properties --groups admin --algorithms scram-sha-256,scram-sha-512\n", batch)
+}
@@ -89,3 +89,31 @@ func SyncCaches(targetClient api.Infinispan, logger logr.Logger) error {
 	}
 	return nil
 }
+
+// MigrateCache copies all entries from the source cache to the target cache using a Remote Store connected to the
+// host's admin endpoint. The Remote Store is disconnected from the target cache once the entries have been copied.
+func MigrateCache(adminPassword, host, sourceName string, target api.Cache, logger logr.Logger) error {
+	rollingUpgrade := target.RollingUpgrade()
+	connected, err := rollingUpgrade.SourceConnected()
+	if err != nil {
+		return fmt.Errorf("failed to call source-connected for cache '%s': %w", sourceName, err)
+	}
+	if !connected {
+		remoteStoreCfg, err := container.CreateRemoteStoreConfig(host, sourceName, adminPassword)
+		if err != nil {
+			return fmt.Errorf("failed to generate remote store config '%s': %w", sourceName, err)
+		}
+		if err = rollingUpgrade.AddSource(remoteStoreCfg, mime.ApplicationJson); err != nil {
+			return fmt.Errorf("failed to add remote store for cache '%s': %w", sourceName, err)
+		}
+	}
+	count, err := rollingUpgrade.SyncData()
+	if err != nil {
+		return fmt.Errorf("failed to sync data from cache '%s': %w", sourceName, err)
+	}
+	logger.Info(fmt.Sprintf("Sync result from cache '%s': %s", sourceName, count))
+	if err = rollingUpgrade.DisconnectSource(); err != nil {
+		return fmt.Errorf("failed to disconnect source cache '%s': %w", sourceName, err)
+	}
+	return nil
+}
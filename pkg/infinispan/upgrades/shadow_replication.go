@@ -0,0 +1,103 @@
+package upgrades
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/configuration/container"
+	"github.com/infinispan/infinispan-operator/pkg/mime"
+)
+
+// ShadowModificationQueueSize the number of modifications of a shadowed cache that are queued whilst the target
+// cluster is unavailable, before further modifications are discarded
+const ShadowModificationQueueSize = 16384
+
+// CreateShadowCache creates the cache in the target cluster with the configuration of the source cache, unless it
+// already exists
+func CreateShadowCache(cacheName string, source, target api.Cache, logger logr.Logger) error {
+	exists, err := target.Exists()
+	if err != nil {
+		return fmt.Errorf("failed to check cache existence '%s': %w", cacheName, err)
+	}
+	if exists {
+		return nil
+	}
+	config, err := source.Config(mime.ApplicationJson)
+	if err != nil {
+		return fmt.Errorf("failed to get cache '%s' config from source cluster: %w", cacheName, err)
+	}
+	if err = target.Create(config, mime.ApplicationJson); err != nil {
+		return fmt.Errorf("failed to create cache '%s': %w", cacheName, err)
+	}
+	logger.Info(fmt.Sprintf("Cache '%s' created in the target cluster", cacheName))
+	return nil
+}
+
+// ConnectShadowCache adds a write-behind Remote Store to the source cache, so that its modifications are replicated
+// asynchronously to the cache of the cluster exposed by the targetHost admin endpoint. Returns true if the Remote Store
+// was added, or false if the cache was already connected
+func ConnectShadowCache(adminPasswordTarget, targetHost, cacheName string, source api.Cache) (bool, error) {
+	rollingUpgrade := source.RollingUpgrade()
+	connected, err := rollingUpgrade.SourceConnected()
+	if err != nil {
+		return false, fmt.Errorf("failed to call source-connected for cache '%s': %w", cacheName, err)
+	}
+	if connected {
+		return false, nil
+	}
+	remoteStoreCfg, err := container.CreateWriteBehindRemoteStoreConfig(targetHost, cacheName, adminPasswordTarget, ShadowModificationQueueSize)
+	if err != nil {
+		return false, fmt.Errorf("failed to generate remote store config '%s': %w", cacheName, err)
+	}
+	if err = rollingUpgrade.AddSource(remoteStoreCfg, mime.ApplicationJson); err != nil {
+		return false, fmt.Errorf("failed to add remote store to cache '%s': %w", cacheName, err)
+	}
+	return true, nil
+}
+
+// SyncShadowCache copies the entries of the source cache, exposed by the sourceHost admin endpoint, to the target cache.
+// The entries are loaded with a read-only Remote Store, so that the target cache never writes back to the source
+// cluster, and the Remote Store is disconnected once the entries have been copied
+func SyncShadowCache(adminPasswordSource, sourceHost, cacheName string, target api.Cache, logger logr.Logger) error {
+	rollingUpgrade := target.RollingUpgrade()
+	connected, err := rollingUpgrade.SourceConnected()
+	if err != nil {
+		return fmt.Errorf("failed to call source-connected for cache '%s': %w", cacheName, err)
+	}
+	if !connected {
+		remoteStoreCfg, err := container.CreateReadOnlyRemoteStoreConfig(sourceHost, cacheName, adminPasswordSource)
+		if err != nil {
+			return fmt.Errorf("failed to generate remote store config '%s': %w", cacheName, err)
+		}
+		if err = rollingUpgrade.AddSource(remoteStoreCfg, mime.ApplicationJson); err != nil {
+			return fmt.Errorf("failed to add remote store to cache '%s': %w", cacheName, err)
+		}
+	}
+	count, err := rollingUpgrade.SyncData()
+	if err != nil {
+		return fmt.Errorf("failed to sync data from cache '%s': %w", cacheName, err)
+	}
+	logger.Info(fmt.Sprintf("Sync result from cache '%s': %s", cacheName, count))
+	if err = rollingUpgrade.DisconnectSource(); err != nil {
+		return fmt.Errorf("failed to disconnect source cache '%s': %w", cacheName, err)
+	}
+	return nil
+}
+
+// DisconnectShadowCache removes the write-behind Remote Store from the source cache, so that its modifications are no
+// longer replicated to the target cluster
+func DisconnectShadowCache(cacheName string, source api.Cache) error {
+	rollingUpgrade := source.RollingUpgrade()
+	connected, err := rollingUpgrade.SourceConnected()
+	if err != nil {
+		return fmt.Errorf("failed to call source-connected for cache '%s': %w", cacheName, err)
+	}
+	if !connected {
+		return nil
+	}
+	if err = rollingUpgrade.DisconnectSource(); err != nil {
+		return fmt.Errorf("failed to disconnect target of cache '%s': %w", cacheName, err)
+	}
+	return nil
+}
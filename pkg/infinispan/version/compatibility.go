@@ -0,0 +1,101 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Stream a minor release stream of the server, e.g. 13.0.x
+type Stream struct {
+	Major uint8
+	Minor uint8
+	// MinPatch the oldest patch release of the stream that the operator supports
+	MinPatch uint8
+}
+
+// Compatibility the server streams that this version of the operator can manage, in ascending order. The server
+// configuration and the management endpoints used by the operator are only known for these streams, so clusters with
+// other server versions must be managed by a different version of the operator
+var Compatibility = []Stream{
+	{Major: 13, Minor: 0, MinPatch: 0},
+}
+
+var imageVersionRegex = regexp.MustCompile(`^(\d+)\.(\d+)(?:\.(\d+))?(?:[.-].*)?$`)
+
+func (s Stream) String() string {
+	if s.MinPatch > 0 {
+		return fmt.Sprintf("%d.%d.%d+", s.Major, s.Minor, s.MinPatch)
+	}
+	return fmt.Sprintf("%d.%d.x", s.Major, s.Minor)
+}
+
+// Oldest returns the oldest supported version of the stream
+func (s Stream) Oldest() *Version {
+	return &Version{Major: s.Major, Minor: s.Minor, Patch: s.MinPatch}
+}
+
+// Contains returns true if the version is a supported release of the stream
+func (s Stream) Contains(v *Version) bool {
+	return v.Major == s.Major && v.Minor == s.Minor && v.Patch >= s.MinPatch
+}
+
+// Compare returns -1, 0 or 1 if the version is older than, the same as or newer than the other version
+func (v *Version) Compare(other *Version) int {
+	for _, diff := range []int{
+		int(v.Major) - int(other.Major),
+		int(v.Minor) - int(other.Minor),
+		int(v.Patch) - int(other.Patch),
+	} {
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// FromImageTag returns the server version of an image tag, e.g. 13.0.10.Final or 13.0, or false if the tag does not
+// identify a version, e.g. latest
+func FromImageTag(tag string) (*Version, bool) {
+	groups := imageVersionRegex.FindStringSubmatch(tag)
+	if groups == nil {
+		return nil, false
+	}
+	v := &Version{}
+	for idx, part := range []*uint8{&v.Major, &v.Minor, &v.Patch} {
+		value := groups[idx+1]
+		if value == "" {
+			continue
+		}
+		number, err := strconv.ParseUint(value, 10, 8)
+		if err != nil {
+			return nil, false
+		}
+		*part = uint8(number)
+	}
+	return v, true
+}
+
+// IsSupported returns true if the version belongs to a stream of the compatibility matrix
+func IsSupported(v *Version) bool {
+	for _, s := range Compatibility {
+		if s.Contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// NearestSupported returns the supported stream that is closest to the version: its own stream if it is a patch
+// release older than supported, else the nearest newer stream, else the newest stream
+func NearestSupported(v *Version) Stream {
+	for _, s := range Compatibility {
+		if v.Compare(s.Oldest()) <= 0 || (v.Major == s.Major && v.Minor == s.Minor) {
+			return s
+		}
+	}
+	return Compatibility[len(Compatibility)-1]
+}
@@ -0,0 +1,48 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromImageTag(t *testing.T) {
+	for tag, expected := range map[string]*Version{
+		"13.0":           {Major: 13, Minor: 0},
+		"13.0.10":        {Major: 13, Minor: 0, Patch: 10},
+		"13.0.10.Final":  {Major: 13, Minor: 0, Patch: 10},
+		"13.0.10-1":      {Major: 13, Minor: 0, Patch: 10},
+		"14.0-openjdk":   {Major: 14, Minor: 0},
+		"latest":         nil,
+		"13":             nil,
+		"300.0.0":        nil,
+		"sha256:abc13.0": nil,
+	} {
+		v, ok := FromImageTag(tag)
+		assert.Equal(t, expected != nil, ok, tag)
+		assert.Equal(t, expected, v, tag)
+	}
+}
+
+func TestCompatibility(t *testing.T) {
+	compatibility := Compatibility
+	defer func() { Compatibility = compatibility }()
+	Compatibility = []Stream{
+		{Major: 12, Minor: 1, MinPatch: 2},
+		{Major: 13, Minor: 0},
+	}
+
+	assert.True(t, IsSupported(&Version{Major: 12, Minor: 1, Patch: 2}))
+	assert.True(t, IsSupported(&Version{Major: 13, Minor: 0, Patch: 7}))
+	assert.False(t, IsSupported(&Version{Major: 12, Minor: 1, Patch: 1}))
+	assert.False(t, IsSupported(&Version{Major: 12, Minor: 2}))
+	assert.False(t, IsSupported(&Version{Major: 14, Minor: 0}))
+
+	assert.Equal(t, Compatibility[0], NearestSupported(&Version{Major: 11, Minor: 0}))
+	assert.Equal(t, Compatibility[0], NearestSupported(&Version{Major: 12, Minor: 1, Patch: 1}))
+	assert.Equal(t, Compatibility[1], NearestSupported(&Version{Major: 12, Minor: 2}))
+	assert.Equal(t, Compatibility[1], NearestSupported(&Version{Major: 14, Minor: 0}))
+
+	assert.Equal(t, "12.1.2+", Compatibility[0].String())
+	assert.Equal(t, "13.0.x", Compatibility[1].String())
+}
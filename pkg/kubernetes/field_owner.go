@@ -0,0 +1,76 @@
+package kubernetes
+
+import (
+	"context"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// OperatorFieldManager is the field manager of all the writes of the operator, so that the fields of the managed
+// resources that were modified by other field managers can be identified
+const OperatorFieldManager = "infinispan-operator"
+
+// NewFieldOwnerClientBuilder returns a builder of the manager client that sets the field owner of every write
+func NewFieldOwnerClientBuilder(owner string) manager.ClientBuilder {
+	return &fieldOwnerClientBuilder{ClientBuilder: manager.NewClientBuilder(), owner: owner}
+}
+
+type fieldOwnerClientBuilder struct {
+	manager.ClientBuilder
+	owner string
+}
+
+func (b *fieldOwnerClientBuilder) WithUncached(objs ...client.Object) manager.ClientBuilder {
+	b.ClientBuilder = b.ClientBuilder.WithUncached(objs...)
+	return b
+}
+
+func (b *fieldOwnerClientBuilder) Build(cache cache.Cache, config *rest.Config, options client.Options) (client.Client, error) {
+	c, err := b.ClientBuilder.Build(cache, config, options)
+	if err != nil {
+		return nil, err
+	}
+	return NewFieldOwnerClient(c, b.owner), nil
+}
+
+// NewFieldOwnerClient returns a client that sets the field owner of every create, update and patch
+func NewFieldOwnerClient(c client.Client, owner string) client.Client {
+	return &fieldOwnerClient{Client: c, owner: client.FieldOwner(owner)}
+}
+
+type fieldOwnerClient struct {
+	client.Client
+	owner client.FieldOwner
+}
+
+func (c *fieldOwnerClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.Client.Create(ctx, obj, append(opts, c.owner)...)
+}
+
+func (c *fieldOwnerClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.Client.Update(ctx, obj, append(opts, c.owner)...)
+}
+
+func (c *fieldOwnerClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.Client.Patch(ctx, obj, patch, append(opts, c.owner)...)
+}
+
+func (c *fieldOwnerClient) Status() client.StatusWriter {
+	return &fieldOwnerStatusWriter{StatusWriter: c.Client.Status(), owner: c.owner}
+}
+
+type fieldOwnerStatusWriter struct {
+	client.StatusWriter
+	owner client.FieldOwner
+}
+
+func (w *fieldOwnerStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return w.StatusWriter.Update(ctx, obj, append(opts, w.owner)...)
+}
+
+func (w *fieldOwnerStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return w.StatusWriter.Patch(ctx, obj, patch, append(opts, w.owner)...)
+}
@@ -0,0 +1,48 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ownerClient records the field manager of the writes
+type ownerClient struct {
+	client.Client
+	managers []string
+}
+
+func (c *ownerClient) Create(_ context.Context, _ client.Object, opts ...client.CreateOption) error {
+	c.managers = append(c.managers, (&client.CreateOptions{}).ApplyOptions(opts).FieldManager)
+	return nil
+}
+
+func (c *ownerClient) Update(_ context.Context, _ client.Object, opts ...client.UpdateOption) error {
+	c.managers = append(c.managers, (&client.UpdateOptions{}).ApplyOptions(opts).FieldManager)
+	return nil
+}
+
+func (c *ownerClient) Patch(_ context.Context, _ client.Object, _ client.Patch, opts ...client.PatchOption) error {
+	c.managers = append(c.managers, (&client.PatchOptions{}).ApplyOptions(opts).FieldManager)
+	return nil
+}
+
+func (c *ownerClient) Status() client.StatusWriter {
+	return c
+}
+
+func TestFieldOwnerClient(t *testing.T) {
+	ctx := context.TODO()
+	recorder := &ownerClient{}
+	c := NewFieldOwnerClient(recorder, OperatorFieldManager)
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, c.Create(ctx, cm))
+	assert.NoError(t, c.Update(ctx, cm))
+	assert.NoError(t, c.Patch(ctx, cm, client.MergeFrom(cm)))
+	assert.NoError(t, c.Status().Update(ctx, cm))
+	assert.NoError(t, c.Status().Patch(ctx, cm, client.MergeFrom(cm)))
+	assert.Equal(t, []string{OperatorFieldManager, OperatorFieldManager, OperatorFieldManager, OperatorFieldManager, OperatorFieldManager}, recorder.managers)
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -29,6 +30,12 @@ const (
 	// which is the namespace where the watch activity happens.
 	// this value is empty if the operator is running with clusterScope.
 	WatchNamespaceEnvVar = "WATCH_NAMESPACE"
+	// WatchNamespaceSelectorEnvVar is the constant for env variable WATCH_NAMESPACE_SELECTOR
+	// which is the label selector of the namespaces that opt in to be managed by a cluster scoped operator.
+	WatchNamespaceSelectorEnvVar = "WATCH_NAMESPACE_SELECTOR"
+	// WatchNamespaceClusterRoleEnvVar is the constant for env variable WATCH_NAMESPACE_CLUSTER_ROLE
+	// which is the ClusterRole that a cluster scoped operator binds itself to in the namespaces that opt in.
+	WatchNamespaceClusterRoleEnvVar = "WATCH_NAMESPACE_CLUSTER_ROLE"
 	// PodNameEnvVar is the constant for env variable POD_NAME
 	// which is the name of the current pod.
 	PodNameEnvVar = "POD_NAME"
@@ -45,6 +52,20 @@ func GetWatchNamespace() (string, error) {
 	return ns, nil
 }
 
+// GetWatchNamespaceSelector returns the label selector of the namespaces the operator reconciles resources in when it
+// watches all namespaces, or nil if resources are reconciled in every watched namespace
+func GetWatchNamespaceSelector() (labels.Selector, error) {
+	selector := os.Getenv(WatchNamespaceSelectorEnvVar)
+	if selector == "" {
+		return nil, nil
+	}
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s '%s': %w", WatchNamespaceSelectorEnvVar, selector, err)
+	}
+	return parsed, nil
+}
+
 // ErrNoNamespace indicates that a namespace could not be found for the current
 // environment
 var ErrNoNamespace = fmt.Errorf("namespace not found for current environment")
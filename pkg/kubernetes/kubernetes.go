@@ -8,6 +8,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"net/http"
 	"sort"
+	"strconv"
 
 	"github.com/go-logr/logr"
 	consts "github.com/infinispan/infinispan-operator/controllers/constants"
@@ -344,7 +345,20 @@ func (k Kubernetes) ResourcesListByField(namespace, fieldName, fieldValue string
 }
 
 func (k Kubernetes) Logs(pod, namespace string, ctx context.Context) (logs string, err error) {
-	readCloser, err := k.RestClient.Get().Namespace(namespace).Resource("pods").Name(pod).SubResource("log").Stream(ctx)
+	return k.LogsHead(pod, "", namespace, 0, ctx)
+}
+
+// LogsHead returns at most limitBytes from the start of the logs of a container, or all of the logs if limitBytes is 0.
+// The container can only be empty if the pod has a single container
+func (k Kubernetes) LogsHead(pod, container, namespace string, limitBytes int64, ctx context.Context) (logs string, err error) {
+	req := k.RestClient.Get().Namespace(namespace).Resource("pods").Name(pod).SubResource("log")
+	if container != "" {
+		req = req.Param("container", container)
+	}
+	if limitBytes > 0 {
+		req = req.Param("limitBytes", strconv.FormatInt(limitBytes, 10))
+	}
+	readCloser, err := req.Stream(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,391 @@
+package kubernetes
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// NamespacedCache is a cache of several namespaces, like the cache of cache.MultiNamespacedCacheBuilder, to which
+// namespaces can be added and from which they can be removed while the manager is running. The event handlers, indexers
+// and field indexes registered with the cache are registered with the cache of every namespace, including the
+// namespaces that are added later, so that the controllers receive the events of the objects of a new namespace.
+// Cluster scoped objects cannot be read from the cache
+type NamespacedCache struct {
+	newCache func(namespace string) (cache.Cache, error)
+	scheme   *runtime.Scheme
+
+	mu sync.RWMutex
+	// ctx is the context the cache was started with, nil until the cache is started
+	ctx       context.Context
+	caches    map[string]*namespaceCache
+	informers map[informerKey]*namespacedInformer
+	indexes   []fieldIndex
+}
+
+var _ cache.Cache = &NamespacedCache{}
+
+type namespaceCache struct {
+	cache.Cache
+	// cancel stops the informers of the namespace
+	cancel context.CancelFunc
+}
+
+type informerKey struct {
+	gvk          schema.GroupVersionKind
+	unstructured bool
+}
+
+type fieldIndex struct {
+	obj          client.Object
+	field        string
+	extractValue client.IndexerFunc
+}
+
+// NamespacedCacheBuilder returns a builder of a NamespacedCache that initially contains the namespaces
+func NamespacedCacheBuilder(namespaces []string) cache.NewCacheFunc {
+	return func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+		c := NewNamespacedCache(opts.Scheme, func(namespace string) (cache.Cache, error) {
+			nsOpts := opts
+			nsOpts.Namespace = namespace
+			return cache.New(config, nsOpts)
+		})
+		for _, namespace := range namespaces {
+			if err := c.AddNamespace(namespace); err != nil {
+				return nil, err
+			}
+		}
+		return c, nil
+	}
+}
+
+// NewNamespacedCache returns an empty NamespacedCache that creates the cache of each namespace with newCache
+func NewNamespacedCache(s *runtime.Scheme, newCache func(namespace string) (cache.Cache, error)) *NamespacedCache {
+	if s == nil {
+		s = scheme.Scheme
+	}
+	return &NamespacedCache{
+		newCache:  newCache,
+		scheme:    s,
+		caches:    make(map[string]*namespaceCache),
+		informers: make(map[informerKey]*namespacedInformer),
+	}
+}
+
+// AddNamespace adds the namespace to the cache. The informers of the namespace are started immediately if the cache
+// has been started
+func (c *NamespacedCache) AddNamespace(namespace string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.caches[namespace]; exists {
+		return nil
+	}
+
+	nsCache, err := c.newCache(namespace)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	for _, index := range c.indexes {
+		if err := nsCache.IndexField(ctx, index.obj, index.field, index.extractValue); err != nil {
+			return err
+		}
+	}
+	informers := make(map[*namespacedInformer]cache.Informer, len(c.informers))
+	for _, informer := range c.informers {
+		nsInformer, err := informer.get(ctx, nsCache)
+		if err != nil {
+			return err
+		}
+		informers[informer] = nsInformer
+	}
+	for informer, nsInformer := range informers {
+		if err := informer.add(namespace, nsInformer); err != nil {
+			return err
+		}
+	}
+
+	entry := &namespaceCache{Cache: nsCache, cancel: func() {}}
+	c.caches[namespace] = entry
+	if c.ctx != nil {
+		c.start(namespace, entry)
+	}
+	return nil
+}
+
+// RemoveNamespace stops the informers of the namespace and removes its objects from the cache
+func (c *NamespacedCache) RemoveNamespace(namespace string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, exists := c.caches[namespace]
+	if !exists {
+		return
+	}
+	entry.cancel()
+	delete(c.caches, namespace)
+	for _, informer := range c.informers {
+		informer.remove(namespace)
+	}
+}
+
+// Namespaces returns the namespaces of the cache
+func (c *NamespacedCache) Namespaces() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	namespaces := make([]string, 0, len(c.caches))
+	for namespace := range c.caches {
+		namespaces = append(namespaces, namespace)
+	}
+	return namespaces
+}
+
+func (c *NamespacedCache) start(namespace string, entry *namespaceCache) {
+	ctx, cancel := context.WithCancel(c.ctx)
+	entry.cancel = cancel
+	go func() {
+		if err := entry.Start(ctx); err != nil {
+			log.Error(err, "unable to start the informers of the namespace", "namespace", namespace)
+		}
+	}()
+}
+
+func (c *NamespacedCache) GetInformer(ctx context.Context, obj client.Object) (cache.Informer, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	_, isUnstructured := obj.(*unstructured.Unstructured)
+	obj = obj.DeepCopyObject().(client.Object)
+	return c.informer(ctx, informerKey{gvk: gvk, unstructured: isUnstructured}, func(ctx context.Context, nsCache cache.Cache) (cache.Informer, error) {
+		return nsCache.GetInformer(ctx, obj)
+	})
+}
+
+func (c *NamespacedCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind) (cache.Informer, error) {
+	return c.informer(ctx, informerKey{gvk: gvk}, func(ctx context.Context, nsCache cache.Cache) (cache.Informer, error) {
+		return nsCache.GetInformerForKind(ctx, gvk)
+	})
+}
+
+// informer returns the informer of the type, which is shared by all of the callers so that the event handlers are only
+// registered once with the cache of a new namespace
+func (c *NamespacedCache) informer(ctx context.Context, key informerKey, get func(context.Context, cache.Cache) (cache.Informer, error)) (cache.Informer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if informer, exists := c.informers[key]; exists {
+		return informer, nil
+	}
+	informer := &namespacedInformer{get: get, informers: make(map[string]cache.Informer, len(c.caches))}
+	for namespace, nsCache := range c.caches {
+		nsInformer, err := get(ctx, nsCache)
+		if err != nil {
+			return nil, err
+		}
+		informer.informers[namespace] = nsInformer
+	}
+	c.informers[key] = informer
+	return informer, nil
+}
+
+// Start starts the informers of every namespace and blocks until the context is done
+func (c *NamespacedCache) Start(ctx context.Context) error {
+	c.mu.Lock()
+	c.ctx = ctx
+	for namespace, entry := range c.caches {
+		c.start(namespace, entry)
+	}
+	c.mu.Unlock()
+	<-ctx.Done()
+	return nil
+}
+
+func (c *NamespacedCache) WaitForCacheSync(ctx context.Context) bool {
+	c.mu.RLock()
+	caches := make([]cache.Cache, 0, len(c.caches))
+	for _, entry := range c.caches {
+		caches = append(caches, entry.Cache)
+	}
+	c.mu.RUnlock()
+
+	synced := true
+	for _, nsCache := range caches {
+		if !nsCache.WaitForCacheSync(ctx) {
+			synced = false
+		}
+	}
+	return synced
+}
+
+func (c *NamespacedCache) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, entry := range c.caches {
+		if err := entry.IndexField(ctx, obj, field, extractValue); err != nil {
+			return err
+		}
+	}
+	c.indexes = append(c.indexes, fieldIndex{obj: obj, field: field, extractValue: extractValue})
+	return nil
+}
+
+// Get returns NotFound for the objects of the namespaces that are not in the cache, e.g. because the namespace was
+// removed while a request of the namespace was queued
+func (c *NamespacedCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	c.mu.RLock()
+	entry, exists := c.caches[key.Namespace]
+	c.mu.RUnlock()
+	if !exists {
+		gvk, err := apiutil.GVKForObject(obj, c.scheme)
+		if err != nil {
+			return err
+		}
+		return errors.NewNotFound(schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind}, key.Name)
+	}
+	return entry.Get(ctx, key, obj)
+}
+
+// List returns the objects of every namespace of the cache, unless the list is restricted to a namespace
+func (c *NamespacedCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+
+	c.mu.RLock()
+	caches := make([]cache.Cache, 0, len(c.caches))
+	for namespace, entry := range c.caches {
+		if listOpts.Namespace == corev1.NamespaceAll || listOpts.Namespace == namespace {
+			caches = append(caches, entry.Cache)
+		}
+	}
+	c.mu.RUnlock()
+
+	if listOpts.Namespace != corev1.NamespaceAll {
+		if len(caches) == 0 {
+			// A namespace that isn't in the cache has no objects
+			return meta.SetList(list, nil)
+		}
+		return caches[0].List(ctx, list, opts...)
+	}
+
+	listAccessor, err := meta.ListAccessor(list)
+	if err != nil {
+		return err
+	}
+	var allItems []runtime.Object
+	var resourceVersion string
+	for _, nsCache := range caches {
+		nsList := list.DeepCopyObject().(client.ObjectList)
+		if err := nsCache.List(ctx, nsList, opts...); err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(nsList)
+		if err != nil {
+			return err
+		}
+		allItems = append(allItems, items...)
+		if accessor, err := meta.ListAccessor(nsList); err == nil {
+			resourceVersion = accessor.GetResourceVersion()
+		}
+	}
+	listAccessor.SetResourceVersion(resourceVersion)
+	return meta.SetList(list, allItems)
+}
+
+// namespacedInformer is the informer of a type in every namespace of a NamespacedCache
+type namespacedInformer struct {
+	get func(context.Context, cache.Cache) (cache.Informer, error)
+
+	mu        sync.RWMutex
+	informers map[string]cache.Informer
+	handlers  []eventHandler
+	indexers  []toolscache.Indexers
+}
+
+var _ cache.Informer = &namespacedInformer{}
+
+type eventHandler struct {
+	handler      toolscache.ResourceEventHandler
+	resyncPeriod *time.Duration
+}
+
+func (h eventHandler) addTo(informer cache.Informer) {
+	if h.resyncPeriod == nil {
+		informer.AddEventHandler(h.handler)
+	} else {
+		informer.AddEventHandlerWithResyncPeriod(h.handler, *h.resyncPeriod)
+	}
+}
+
+// add registers the event handlers and indexers with the informer of a new namespace
+func (i *namespacedInformer) add(namespace string, informer cache.Informer) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, indexers := range i.indexers {
+		if err := informer.AddIndexers(indexers); err != nil {
+			return err
+		}
+	}
+	for _, handler := range i.handlers {
+		handler.addTo(informer)
+	}
+	i.informers[namespace] = informer
+	return nil
+}
+
+func (i *namespacedInformer) remove(namespace string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.informers, namespace)
+}
+
+func (i *namespacedInformer) AddEventHandler(handler toolscache.ResourceEventHandler) {
+	i.addEventHandler(eventHandler{handler: handler})
+}
+
+func (i *namespacedInformer) AddEventHandlerWithResyncPeriod(handler toolscache.ResourceEventHandler, resyncPeriod time.Duration) {
+	i.addEventHandler(eventHandler{handler: handler, resyncPeriod: &resyncPeriod})
+}
+
+func (i *namespacedInformer) addEventHandler(handler eventHandler) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.handlers = append(i.handlers, handler)
+	for _, informer := range i.informers {
+		handler.addTo(informer)
+	}
+}
+
+func (i *namespacedInformer) AddIndexers(indexers toolscache.Indexers) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, informer := range i.informers {
+		if err := informer.AddIndexers(indexers); err != nil {
+			return err
+		}
+	}
+	i.indexers = append(i.indexers, indexers)
+	return nil
+}
+
+func (i *namespacedInformer) HasSynced() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	for _, informer := range i.informers {
+		if !informer.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+)
+
+func TestNamespacedCache(t *testing.T) {
+	fakeCaches := map[string]*informertest.FakeInformers{}
+	c := NewNamespacedCache(nil, func(namespace string) (cache.Cache, error) {
+		fakeCaches[namespace] = &informertest.FakeInformers{}
+		return fakeCaches[namespace], nil
+	})
+	require.NoError(t, c.AddNamespace("operator"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = c.Start(ctx) }()
+
+	informer, err := c.GetInformer(ctx, &corev1.ConfigMap{})
+	require.NoError(t, err)
+	same, err := c.GetInformer(ctx, &corev1.ConfigMap{})
+	require.NoError(t, err)
+	assert.Same(t, informer, same, "the informer of a type is shared")
+
+	var added []string
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { added = append(added, obj.(*corev1.ConfigMap).Namespace) },
+	})
+	fakeInformer := func(namespace string) *controllertest.FakeInformer {
+		i, err := fakeCaches[namespace].FakeInformerFor(&corev1.ConfigMap{})
+		require.NoError(t, err)
+		return i
+	}
+	fakeInformer("operator").Add(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "operator"}})
+
+	// The handlers are registered with the informer of a namespace that is added later
+	require.NoError(t, c.AddNamespace("team-a"))
+	fakeInformer("team-a").Add(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"}})
+	assert.Equal(t, []string{"operator", "team-a"}, added)
+	assert.ElementsMatch(t, []string{"operator", "team-a"}, c.Namespaces())
+
+	fakeInformer("team-a").Synced = true
+	assert.False(t, informer.HasSynced())
+	fakeInformer("operator").Synced = true
+	assert.True(t, informer.HasSynced())
+
+	// The objects of a removed namespace are not found
+	c.RemoveNamespace("team-a")
+	assert.Equal(t, []string{"operator"}, c.Namespaces())
+	err = c.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "example"}, &corev1.ConfigMap{})
+	assert.True(t, errors.IsNotFound(err))
+	assert.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "operator", Name: "example"}, &corev1.ConfigMap{}))
+}
@@ -27,6 +27,31 @@ func GetPodDefaultImage(container corev1.Container) string {
 	return container.Image
 }
 
+// ImageMajorVersion returns the major version of the image tag, e.g. 13 for quay.io/infinispan/server:13.0.1. The
+// second return value is false if the image has no tag or the tag is not a version
+func ImageMajorVersion(image string) (int, bool) {
+	tag, ok := ImageTag(image)
+	if !ok {
+		return 0, false
+	}
+	major := strings.SplitN(tag, ".", 2)[0]
+	version, err := strconv.Atoi(major)
+	return version, err == nil
+}
+
+// ImageTag returns the tag of the image, e.g. 13.0.1 for quay.io/infinispan/server:13.0.1. The second return value is
+// false if the image has no tag or is referenced by digest
+func ImageTag(image string) (string, bool) {
+	if strings.Contains(image, "@") {
+		return "", false
+	}
+	idx := strings.LastIndex(image, ":")
+	if idx < 0 || strings.Contains(image[idx:], "/") {
+		return "", false
+	}
+	return image[idx+1:], true
+}
+
 func AreAllPodsReady(podList *corev1.PodList) bool {
 	for _, pod := range podList.Items {
 		containerStatuses := pod.Status.ContainerStatuses
@@ -210,3 +235,18 @@ func GetPodMaxMemoryUnboundedBytes(container, podName, namespace string, kube *K
 	}
 	return 0, fmt.Errorf("meminfo lacking MemTotal information")
 }
+
+// GetPodFileSizeBytes returns the size of the file at path within the given container
+func GetPodFileSizeBytes(container, path, podName, namespace string, kube *Kubernetes) (int64, error) {
+	execOut, err := kube.ExecWithOptions(ExecOptions{
+		Container: container,
+		Command:   []string{"stat", "-c", "%s", path},
+		PodName:   podName,
+		Namespace: namespace,
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("unexpected error getting size of file '%s', err: %w", path, err)
+	}
+	return strconv.ParseInt(strings.TrimSpace(execOut.String()), 10, 64)
+}
@@ -0,0 +1,57 @@
+package kubernetes
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewTypedObjectClient returns a client that writes the unstructured objects of the types of the scheme as typed
+// objects, as the API server does. The fake client stores unstructured objects as is, which fails the List of the
+// typed objects. The fields that are unknown to the typed objects are dropped
+func NewTypedObjectClient(c client.Client, scheme *runtime.Scheme) client.Client {
+	return &typedObjectClient{Client: c, scheme: scheme}
+}
+
+type typedObjectClient struct {
+	client.Client
+	scheme *runtime.Scheme
+}
+
+func (c *typedObjectClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.write(obj, func(typed client.Object) error {
+		return c.Client.Create(ctx, typed, opts...)
+	})
+}
+
+func (c *typedObjectClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.write(obj, func(typed client.Object) error {
+		return c.Client.Update(ctx, typed, opts...)
+	})
+}
+
+// write converts an unstructured object to its typed object, writes the typed object and converts the result back
+func (c *typedObjectClient) write(obj client.Object, write func(client.Object) error) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok || !c.scheme.Recognizes(u.GroupVersionKind()) {
+		return write(obj)
+	}
+	gvk := u.GroupVersionKind()
+	typed, err := c.scheme.New(gvk)
+	if err != nil {
+		return err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, typed); err != nil {
+		return err
+	}
+	if err := write(typed.(client.Object)); err != nil {
+		return err
+	}
+	if u.Object, err = runtime.DefaultUnstructuredConverter.ToUnstructured(typed); err != nil {
+		return err
+	}
+	u.SetGroupVersionKind(gvk)
+	return nil
+}
@@ -3,14 +3,24 @@ package mime
 type MimeType string
 
 const (
-	ApplicationProtostream MimeType = "application/x-protostream"
-	ApplicationJavaObject  MimeType = "application/x-java-object"
-	ApplicationJson        MimeType = "application/json"
-	ApplicationXml         MimeType = "application/xml"
-	ApplicationYaml        MimeType = "application/yaml"
-	TextPlain              MimeType = "text/plain"
+	ApplicationProtostream          MimeType = "application/x-protostream"
+	ApplicationJavaObject           MimeType = "application/x-java-object"
+	ApplicationJavaSerializedObject MimeType = "application/x-java-serialized-object"
+	ApplicationJBossMarshalling     MimeType = "application/x-jboss-marshalling"
+	ApplicationOctetStream          MimeType = "application/octet-stream"
+	ApplicationUnknown              MimeType = "application/unknown"
+	ApplicationJson                 MimeType = "application/json"
+	ApplicationXml                  MimeType = "application/xml"
+	ApplicationYaml                 MimeType = "application/yaml"
+	TextPlain                       MimeType = "text/plain"
 )
 
+// EncodingMediaTypes are the media types that the server supports for the encoding of cache keys and values
+var EncodingMediaTypes = []MimeType{
+	ApplicationProtostream, ApplicationJavaObject, ApplicationJavaSerializedObject, ApplicationJBossMarshalling,
+	ApplicationOctetStream, ApplicationUnknown, ApplicationJson, ApplicationXml, TextPlain,
+}
+
 func GuessMarkup(config string) MimeType {
 	switch config[0:1] {
 	case "<":
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	ispnApi "github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// Pipeline for Cache reconciliation
+type Pipeline interface {
+	// Process the pipeline
+	// Returns true if processing should be repeated and optional error if occurred
+	// important: even if error occurred it might not be needed to retry processing
+	Process(ctx context.Context) (bool, time.Duration, error)
+}
+
+// Handler an individual stage in the pipeline
+type Handler interface {
+	Handle(c *v2alpha1.Cache, ctx Context)
+}
+
+type HandlerFunc func(c *v2alpha1.Cache, ctx Context)
+
+func (f HandlerFunc) Handle(c *v2alpha1.Cache, ctx Context) {
+	f(c, ctx)
+}
+
+// FlowStatus Pipeline flow control
+type FlowStatus struct {
+	Retry bool
+	Stop  bool
+	Err   error
+	Delay time.Duration
+}
+
+func (f *FlowStatus) String() string {
+	return fmt.Sprintf("Requeue=%t, Stop=%t, Err=%v, Delay=%dms", f.Retry, f.Stop, f.Err, f.Delay.Milliseconds())
+}
+
+// ContextProvider interface used by Pipeline implementations to obtain a Context
+type ContextProvider interface {
+	Get(ctx context.Context, config *ContextProviderConfig) (Context, error)
+}
+
+type ContextProviderConfig struct {
+	Cache  *v2alpha1.Cache
+	Logger logr.Logger
+}
+
+// Context of the pipeline, which is passed to each Handler
+type Context interface {
+	// Infinispan returns the Infinispan CR of the cluster that the cache belongs to
+	// The CR is loaded Lazily and cached per Pipeline execution. A NotFound error is returned if the cluster doesn't exist
+	Infinispan() (*ispnv1.Infinispan, error)
+
+	// InfinispanPods returns all pods associated with the Infinispan cluster's StatefulSet
+	// The list is created Lazily and cached per Pipeline execution
+	InfinispanPods() (*corev1.PodList, error)
+
+	// InfinispanClient returns a client for the Operand servers
+	// The client is created Lazily and cached per Pipeline execution to prevent repeated calls to retrieve the cluster pods
+	InfinispanClient() (ispnApi.Infinispan, error)
+
+	// Ctx the Pipeline's context.Context that should be passed to any functions requiring a context
+	Ctx() context.Context
+
+	// Log the Cache request logger
+	Log() logr.Logger
+
+	// EventRecorder associated with the Cache controller
+	EventRecorder() record.EventRecorder
+
+	// Kubernetes exposes the underlying kubernetes client
+	Kubernetes() *kubernetes.Kubernetes
+
+	// UpdateCache updates the Cache CR resource being reconciled
+	UpdateCache(func()) error
+
+	// DeleteCache deletes the Cache CR resource being reconciled, ignoring NotFound errors
+	DeleteCache() error
+
+	// Requeue indicates that the pipeline should stop once the current Handler has finished execution and
+	// reconciliation should be requeued
+	Requeue(reason error)
+
+	// RequeueAfter indicates that the pipeline should stop once the current Handler has finished execution and
+	// reconciliation should be requeued after delay time
+	RequeueAfter(delay time.Duration, reason error)
+
+	// Stop indicates that the pipeline should stop once the current Handler has finished execution
+	Stop(err error)
+
+	// FlowStatus the current status of the Pipeline
+	FlowStatus() FlowStatus
+}
@@ -0,0 +1,170 @@
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/http/curl"
+	ispnClient "github.com/infinispan/infinispan-operator/pkg/infinispan/client"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	users "github.com/infinispan/infinispan-operator/pkg/infinispan/security"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/cache"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ pipeline.Context = &contextImpl{}
+
+func Provider(client client.Client, kubernetes *kube.Kubernetes, eventRec record.EventRecorder) pipeline.ContextProvider {
+	return &provider{
+		Client:     client,
+		kubernetes: kubernetes,
+		eventRec:   eventRec,
+	}
+}
+
+type provider struct {
+	client.Client
+	kubernetes *kube.Kubernetes
+	eventRec   record.EventRecorder
+}
+
+func (p *provider) Get(ctx context.Context, config *pipeline.ContextProviderConfig) (pipeline.Context, error) {
+	return &contextImpl{
+		provider:              p,
+		flowCtrl:              &flowCtrl{},
+		ContextProviderConfig: config,
+		ctx:                   ctx,
+	}, nil
+}
+
+type contextImpl struct {
+	*flowCtrl
+	*provider
+	*pipeline.ContextProviderConfig
+	ctx        context.Context
+	infinispan *ispnv1.Infinispan
+	ispnClient api.Infinispan
+	ispnPods   *corev1.PodList
+}
+
+func (c *contextImpl) Infinispan() (*ispnv1.Infinispan, error) {
+	if c.infinispan == nil {
+		i := &ispnv1.Infinispan{}
+		if err := c.Client.Get(c.ctx, types.NamespacedName{Namespace: c.Cache.Namespace, Name: c.Cache.Spec.ClusterName}, i); err != nil {
+			return nil, err
+		}
+		c.infinispan = i
+	}
+	return c.infinispan, nil
+}
+
+func (c *contextImpl) InfinispanPods() (*corev1.PodList, error) {
+	if c.ispnPods == nil {
+		i, err := c.Infinispan()
+		if err != nil {
+			return nil, err
+		}
+
+		statefulSet := &appsv1.StatefulSet{}
+		if err := c.Client.Get(c.ctx, types.NamespacedName{Namespace: i.Namespace, Name: i.GetStatefulSetName()}, statefulSet); err != nil {
+			return nil, fmt.Errorf("unable to list Infinispan pods as StatefulSet can't be loaded: %w", err)
+		}
+		podList := &corev1.PodList{}
+		if err := c.kubernetes.ResourcesList(i.Namespace, i.PodSelectorLabels(), podList, c.ctx); err != nil {
+			return nil, fmt.Errorf("unable to list Infinispan pods: %w", err)
+		}
+		kube.FilterPodsByOwnerUID(podList, statefulSet.GetUID())
+		c.ispnPods = podList
+	}
+	return c.ispnPods.DeepCopy(), nil
+}
+
+func (c *contextImpl) InfinispanClient() (api.Infinispan, error) {
+	if c.ispnClient != nil {
+		return c.ispnClient, nil
+	}
+
+	podList, err := c.InfinispanPods()
+	if err != nil {
+		return nil, err
+	}
+	if len(podList.Items) == 0 {
+		return nil, fmt.Errorf("unable to create Infinispan client, no Infinispan pods exists")
+	}
+
+	pod := podList.Items[0].Name
+	for _, p := range podList.Items {
+		if kube.IsPodReady(p) {
+			pod = p.Name
+			break
+		}
+	}
+
+	i := c.infinispan
+	pass, err := users.AdminPassword(i.GetAdminSecretName(), i.Namespace, c.kubernetes, c.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve operator admin identities when creating Infinispan client: %w", err)
+	}
+	curlClient := curl.New(curl.Config{
+		Credentials: &curl.Credentials{
+			Username: consts.DefaultOperatorUser,
+			Password: pass,
+		},
+		Container: provision.InfinispanContainer,
+		Podname:   pod,
+		Namespace: i.Namespace,
+		Protocol:  "http",
+		Port:      consts.InfinispanAdminPort,
+	}, c.kubernetes)
+	c.ispnClient = ispnClient.New(curlClient)
+	return c.ispnClient, nil
+}
+
+func (c *contextImpl) Ctx() context.Context {
+	return c.ctx
+}
+
+func (c *contextImpl) Log() logr.Logger {
+	return c.Logger
+}
+
+func (c *contextImpl) EventRecorder() record.EventRecorder {
+	return c.eventRec
+}
+
+func (c *contextImpl) Kubernetes() *kube.Kubernetes {
+	return c.kubernetes
+}
+
+func (c *contextImpl) UpdateCache(updateFn func()) error {
+	cache := c.Cache
+	_, err := kube.CreateOrPatch(c.ctx, c.Client, cache, func() error {
+		if cache.CreationTimestamp.IsZero() {
+			return errors.NewNotFound(schema.ParseGroupResource("cache.infinispan.org"), cache.Name)
+		}
+		updateFn()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to update cache %s: %w", cache.Name, err)
+	}
+	return nil
+}
+
+func (c *contextImpl) DeleteCache() error {
+	if err := c.Client.Delete(c.ctx, c.Cache); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
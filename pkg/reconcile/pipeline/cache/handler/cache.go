@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/infinispan/infinispan-operator/pkg/mime"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/cache"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/manage"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ListenerDeletion removes Cache CRs that the ConfigListener has marked for deletion. There's no need to update the
+// server as the cache has already been removed
+func ListenerDeletion(c *v2alpha1.Cache, ctx pipeline.Context) {
+	if _, exists := c.Annotations[constants.ListenerAnnotationDelete]; !exists {
+		return
+	}
+
+	ctx.Log().Info("Cache CR marked for deletion. Attempting to remove.")
+	if err := removeFinalizer(c, ctx); err != nil {
+		if kerrors.IsNotFound(err) {
+			ctx.Log().Info("Cache CR not found, nothing todo.")
+			ctx.Stop(nil)
+			return
+		}
+		ctx.Requeue(err)
+		return
+	}
+	if err := ctx.DeleteCache(); err != nil {
+		ctx.Requeue(err)
+		return
+	}
+	ctx.Log().Info("Cache CR Removed.")
+	ctx.Stop(nil)
+}
+
+// ClusterWellFormed stops the pipeline until the Infinispan cluster of the cache exists and is healthy, reporting the
+// wait with the ClusterHealthGatePassed condition. No need to requeue requests here as the Infinispan watch ensures that a request is queued when the cluster is updated
+func ClusterWellFormed(c *v2alpha1.Cache, ctx pipeline.Context) {
+	i, err := ctx.Infinispan()
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			ctx.Requeue(err)
+			return
+		}
+		ctx.Log().Error(err, fmt.Sprintf("Infinispan cluster %s not found", c.Spec.ClusterName))
+		if c.GetDeletionTimestamp() != nil {
+			ctx.Stop(removeFinalizer(c, ctx))
+			return
+		}
+		// Set CacheConditionReady to false in case the cluster was previously WellFormed
+		ctx.Stop(ctx.UpdateCache(func() {
+			c.SetCondition(v2alpha1.CacheConditionReady, metav1.ConditionFalse, "")
+		}))
+		return
+	}
+
+	// Cache CRs declare the desired state of the cache, so they are always queued until the cluster is healthy
+	if err := i.HealthGate(); err != nil {
+		ctx.Log().Info(fmt.Sprintf("Infinispan cluster %s not healthy: %s", i.Name, err))
+		msg := fmt.Sprintf("queued until Infinispan '%s' is healthy: %s", i.Name, err)
+		ctx.Stop(ctx.UpdateCache(func() {
+			c.SetCondition(v2alpha1.CacheConditionClusterHealthGatePassed, metav1.ConditionFalse, msg)
+		}))
+		return
+	}
+	for _, condition := range c.Status.Conditions {
+		if condition.Type == v2alpha1.CacheConditionClusterHealthGatePassed && condition.Status != metav1.ConditionTrue {
+			if err := ctx.UpdateCache(func() {
+				c.SetCondition(v2alpha1.CacheConditionClusterHealthGatePassed, metav1.ConditionTrue, "")
+			}); err != nil {
+				ctx.Requeue(err)
+			}
+			return
+		}
+	}
+}
+
+// CacheDeletion removes the cache from the server when the Cache CR is deleted
+func CacheDeletion(c *v2alpha1.Cache, ctx pipeline.Context) {
+	if c.GetDeletionTimestamp() == nil {
+		return
+	}
+
+	if controllerutil.ContainsFinalizer(c, constants.InfinispanFinalizer) {
+		ispnClient, err := ctx.InfinispanClient()
+		if err != nil {
+			ctx.Requeue(fmt.Errorf("unable to create Infinispan client: %w", err))
+			return
+		}
+		// Remove Deleted caches from the server before removing the Finalizer
+		if err := ispnClient.Cache(c.GetCacheName()).Delete(); err != nil {
+			ctx.Requeue(err)
+			return
+		}
+		ctx.Stop(removeFinalizer(c, ctx))
+		return
+	}
+	ctx.Stop(nil)
+}
+
+// CreateOrUpdateOnServer ensures that the cache exists on the server with the configuration of the Cache CR.
+// The server is not contacted for resources created by the ConfigListener
+func CreateOrUpdateOnServer(c *v2alpha1.Cache, ctx pipeline.Context) {
+	if !reconcileOnServer(c) {
+		return
+	}
+
+	ispnClient, err := ctx.InfinispanClient()
+	if err != nil {
+		ctx.Requeue(fmt.Errorf("unable to create Infinispan client: %w", err))
+		return
+	}
+	cacheClient := ispnClient.Cache(c.GetCacheName())
+
+	cacheExists, err := cacheClient.Exists()
+	if err != nil {
+		err := fmt.Errorf("unable to determine if cache exists: %w", err)
+		ctx.Log().Error(err, "")
+		ctx.Requeue(err)
+		return
+	}
+
+	i, _ := ctx.Infinispan()
+	if i.IsDataGrid() {
+		err = reconcileDataGrid(c, ctx, cacheExists, cacheClient)
+	} else {
+		err = reconcileCacheService(c, ctx, cacheExists, cacheClient)
+	}
+	if err != nil {
+		if updErr := ctx.UpdateCache(func() {
+			c.SetCondition(v2alpha1.CacheConditionReady, metav1.ConditionFalse, err.Error())
+		}); updErr != nil {
+			ctx.Requeue(updErr)
+			return
+		}
+		if isIncompatibleAttributesErr(err) {
+			// Updating immutable attributes fails until the Cache CR is changed, so there's no point in retrying
+			ctx.Stop(nil)
+			return
+		}
+		ctx.Requeue(nil)
+	}
+}
+
+// ReadyCondition marks the Cache CR as Ready and adds the finalizer required to remove the cache from the server when
+// the Cache CR is deleted
+func ReadyCondition(c *v2alpha1.Cache, ctx pipeline.Context) {
+	err := ctx.UpdateCache(func() {
+		c.SetCondition(v2alpha1.CacheConditionReady, metav1.ConditionTrue, "")
+		// Remove the marker of a cache recreation that failed before completing
+		delete(c.Annotations, constants.CacheAnnotationRecreate)
+		if !controllerutil.ContainsFinalizer(c, constants.InfinispanFinalizer) {
+			controllerutil.AddFinalizer(c, constants.InfinispanFinalizer)
+		}
+	})
+	if err != nil {
+		ctx.Requeue(err)
+	}
+}
+
+// reconcileOnServer determines if reconciliation was triggered by the ConfigListener
+func reconcileOnServer(c *v2alpha1.Cache) bool {
+	if val, exists := c.ObjectMeta.Annotations[constants.ListenerAnnotationGeneration]; exists {
+		generation, _ := strconv.ParseInt(val, 10, 64)
+		return generation != c.GetGeneration()
+	}
+	return true
+}
+
+func removeFinalizer(c *v2alpha1.Cache, ctx pipeline.Context) error {
+	return ctx.UpdateCache(func() {
+		controllerutil.RemoveFinalizer(c, constants.InfinispanFinalizer)
+	})
+}
+
+func reconcileCacheService(c *v2alpha1.Cache, ctx pipeline.Context, cacheExists bool, cache api.Cache) error {
+	spec := c.Spec
+	log := ctx.Log()
+	if cacheExists {
+		err := fmt.Errorf("cannot update an existing cache in a CacheService cluster")
+		log.Error(err, "Error updating cache")
+		return err
+	}
+
+	if spec.TemplateName != "" || spec.Template != "" || spec.TemplateFrom != nil || spec.Encoding != nil || spec.Security != nil {
+		err := fmt.Errorf("cannot create a cache with a template, encoding or security in a CacheService cluster")
+		log.Error(err, "Error creating cache")
+		return err
+	}
+
+	i, _ := ctx.Infinispan()
+	podList, err := ctx.InfinispanPods()
+	if err != nil {
+		log.Error(err, "failed to list pods")
+		return err
+	}
+	if len(podList.Items) == 0 {
+		return fmt.Errorf("no Infinispan pods exist")
+	}
+
+	template, err := manage.DefaultCacheTemplateXML(podList.Items[0].Name, i, ctx.Kubernetes(), log)
+	if err != nil {
+		err = fmt.Errorf("unable to obtain default cache template: %w", err)
+		log.Error(err, "Error getting default XML")
+		return err
+	}
+	if err = cache.Create(template, mime.ApplicationXml); err != nil {
+		err = fmt.Errorf("unable to create cache using default template: %w", err)
+		log.Error(err, "Error in creating cache")
+		return err
+	}
+	return nil
+}
+
+func reconcileDataGrid(c *v2alpha1.Cache, ctx pipeline.Context, cacheExists bool, cache api.Cache) error {
+	spec := c.Spec
+	if cacheExists {
+		// Complete any data migration that was interrupted after the cache was recreated
+		if err := restoreMigratedData(c, ctx, cache); err != nil {
+			return err
+		}
+		if spec.Template != "" || spec.TemplateFrom != nil || spec.Encoding != nil || spec.Security != nil {
+			return updateConfig(c, ctx, cache)
+		}
+		return nil
+	}
+
+	var err error
+	if spec.TemplateName != "" {
+		if err = cache.CreateWithTemplate(spec.TemplateName); err != nil {
+			err = fmt.Errorf("unable to create cache with template name '%s': %w", spec.TemplateName, err)
+		}
+	} else {
+		template, markup, tmplErr := cacheTemplate(c, ctx)
+		if tmplErr != nil {
+			err = tmplErr
+		} else if err = cache.Create(template, markup); err != nil {
+			err = fmt.Errorf("unable to create cache with template: %w", err)
+		}
+	}
+
+	if err != nil {
+		ctx.Log().Error(err, "Unable to create Cache")
+	}
+	return err
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/cache"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// testContext implements the subset of pipeline.Context used by the Cache handlers that don't contact the server
+type testContext struct {
+	pipeline.Context
+	infinispan *ispnv1.Infinispan
+	kubernetes *kubernetes.Kubernetes
+	deleted    bool
+	status     pipeline.FlowStatus
+}
+
+func (c *testContext) Infinispan() (*ispnv1.Infinispan, error) {
+	if c.infinispan == nil {
+		return nil, errors.NewNotFound(schema.GroupResource{}, "example-infinispan")
+	}
+	return c.infinispan, nil
+}
+
+func (c *testContext) Ctx() context.Context               { return context.TODO() }
+func (c *testContext) Kubernetes() *kubernetes.Kubernetes { return c.kubernetes }
+func (c *testContext) Log() logr.Logger                   { return ctrl.Log }
+func (c *testContext) UpdateCache(update func()) error    { update(); return nil }
+func (c *testContext) DeleteCache() error                 { c.deleted = true; return nil }
+func (c *testContext) Requeue(reason error) {
+	c.status = pipeline.FlowStatus{Retry: true, Stop: true, Err: reason}
+}
+func (c *testContext) Stop(err error)                  { c.status.Stop = true; c.status.Err = err }
+func (c *testContext) FlowStatus() pipeline.FlowStatus { return c.status }
+
+func testCache() *v2alpha1.Cache {
+	return &v2alpha1.Cache{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-cache", Namespace: "testing-namespace", Generation: 1},
+		Spec:       v2alpha1.CacheSpec{ClusterName: "example-infinispan"},
+	}
+}
+
+func TestListenerDeletion(t *testing.T) {
+	c := testCache()
+	ctx := &testContext{}
+	ListenerDeletion(c, ctx)
+	assert.False(t, ctx.status.Stop, "Cache CRs not marked by the ConfigListener are reconciled")
+	assert.False(t, ctx.deleted)
+
+	c.Annotations = map[string]string{constants.ListenerAnnotationDelete: "true"}
+	controllerutil.AddFinalizer(c, constants.InfinispanFinalizer)
+	ListenerDeletion(c, ctx)
+	assert.True(t, ctx.status.Stop)
+	assert.False(t, ctx.status.Retry)
+	assert.True(t, ctx.deleted)
+	assert.False(t, controllerutil.ContainsFinalizer(c, constants.InfinispanFinalizer))
+}
+
+func TestClusterWellFormed(t *testing.T) {
+	c := testCache()
+	ctx := &testContext{}
+	ClusterWellFormed(c, ctx)
+	assert.True(t, ctx.status.Stop, "pipeline stops when the cluster doesn't exist")
+	assert.False(t, ctx.status.Retry)
+	assert.Equal(t, metav1.ConditionFalse, c.Status.Conditions[0].Status)
+
+	ctx = &testContext{infinispan: &ispnv1.Infinispan{ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan"}}}
+	ClusterWellFormed(c, ctx)
+	assert.True(t, ctx.status.Stop, "pipeline stops when the cluster isn't well formed")
+	assert.Equal(t, v2alpha1.CacheConditionClusterHealthGatePassed, c.Status.Conditions[1].Type)
+	assert.Equal(t, metav1.ConditionFalse, c.Status.Conditions[1].Status)
+	assert.Contains(t, c.Status.Conditions[1].Message, "queued until Infinispan 'example-infinispan' is healthy")
+
+	ctx.infinispan.Status.Conditions = []ispnv1.InfinispanCondition{
+		{Type: ispnv1.ConditionPrelimChecksPassed, Status: metav1.ConditionTrue},
+		{Type: ispnv1.ConditionWellFormed, Status: metav1.ConditionTrue},
+		{Type: ispnv1.ConditionHotRodRollingUpgrade, Status: metav1.ConditionTrue},
+	}
+	ctx.status = pipeline.FlowStatus{}
+	ClusterWellFormed(c, ctx)
+	assert.True(t, ctx.status.Stop, "pipeline stops whilst the cluster is upgrading")
+
+	ctx.infinispan.Status.Conditions = ctx.infinispan.Status.Conditions[:2]
+	ctx.status = pipeline.FlowStatus{}
+	ClusterWellFormed(c, ctx)
+	assert.False(t, ctx.status.Stop)
+	assert.Equal(t, metav1.ConditionTrue, c.Status.Conditions[1].Status)
+	assert.Empty(t, c.Status.Conditions[1].Message)
+}
+
+func TestReadyCondition(t *testing.T) {
+	c := testCache()
+	c.Annotations = map[string]string{constants.CacheAnnotationRecreate: "true"}
+	ctx := &testContext{}
+	ReadyCondition(c, ctx)
+	assert.False(t, ctx.status.Stop)
+	assert.Equal(t, []v2alpha1.CacheCondition{{Type: v2alpha1.CacheConditionReady, Status: metav1.ConditionTrue}}, c.Status.Conditions)
+	assert.True(t, controllerutil.ContainsFinalizer(c, constants.InfinispanFinalizer))
+	assert.NotContains(t, c.Annotations, constants.CacheAnnotationRecreate)
+}
+
+func TestReconcileOnServer(t *testing.T) {
+	c := testCache()
+	assert.True(t, reconcileOnServer(c), "Cache CRs created by users are reconciled on the server")
+
+	c.Annotations = map[string]string{constants.ListenerAnnotationGeneration: "1"}
+	assert.False(t, reconcileOnServer(c), "Cache CRs created by the ConfigListener are not reconciled on the server")
+
+	c.Generation = 2
+	assert.True(t, reconcileOnServer(c), "Cache CRs updated by users after creation by the ConfigListener are reconciled on the server")
+}
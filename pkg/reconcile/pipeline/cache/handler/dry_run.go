@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/mime"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/cache"
+)
+
+// DryRun stops the pipeline before the server is modified when the Cache CR has the infinispan.org/dry-run annotation,
+// recording the operation that CreateOrUpdateOnServer would perform in status.dryRun. The status is removed once the
+// annotation is removed and the Cache CR is reconciled on the server again
+func DryRun(c *v2alpha1.Cache, ctx pipeline.Context) {
+	if _, requested := c.Annotations[constants.CacheAnnotationDryRun]; !requested {
+		if c.Status.DryRun != nil {
+			if err := ctx.UpdateCache(func() {
+				c.Status.DryRun = nil
+			}); err != nil {
+				ctx.Requeue(err)
+			}
+		}
+		return
+	}
+
+	status, err := dryRun(c, ctx)
+	if err != nil {
+		ctx.Requeue(err)
+		return
+	}
+	ctx.Stop(ctx.UpdateCache(func() {
+		c.Status.DryRun = status
+	}))
+}
+
+// dryRun determines the operation that reconciling the Cache CR would perform on the server, using only read
+// operations of the server
+func dryRun(c *v2alpha1.Cache, ctx pipeline.Context) (*v2alpha1.CacheDryRunStatus, error) {
+	spec := c.Spec
+	status := &v2alpha1.CacheDryRunStatus{ObservedGeneration: c.GetGeneration()}
+	if !reconcileOnServer(c) {
+		status.Operation = v2alpha1.CacheDryRunNone
+		status.Message = "the Cache CR was created by the ConfigListener from the cache on the server"
+		return status, nil
+	}
+
+	ispnClient, err := ctx.InfinispanClient()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Infinispan client: %w", err)
+	}
+	cacheClient := ispnClient.Cache(c.GetCacheName())
+	cacheExists, err := cacheClient.Exists()
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine if cache exists: %w", err)
+	}
+
+	if i, _ := ctx.Infinispan(); !i.IsDataGrid() {
+		switch {
+		case cacheExists:
+			status.Operation = v2alpha1.CacheDryRunRejected
+			status.Message = "cannot update an existing cache in a CacheService cluster"
+		case spec.TemplateName != "" || spec.Template != "" || spec.TemplateFrom != nil || spec.Encoding != nil || spec.Security != nil:
+			status.Operation = v2alpha1.CacheDryRunRejected
+			status.Message = "cannot create a cache with a template, encoding or security in a CacheService cluster"
+		default:
+			status.Operation = v2alpha1.CacheDryRunCreate
+			status.Message = "the cache would be created with the default template of the CacheService"
+		}
+		return status, nil
+	}
+
+	if !cacheExists && spec.TemplateName != "" {
+		status.Operation = v2alpha1.CacheDryRunCreate
+		status.Message = fmt.Sprintf("the cache would be created with the template '%s'", spec.TemplateName)
+		return status, nil
+	}
+	if cacheExists && spec.Template == "" && spec.TemplateFrom == nil && spec.Encoding == nil && spec.Security == nil {
+		status.Operation = v2alpha1.CacheDryRunNone
+		status.Message = "existing caches are only updated from spec.template, spec.templateFrom or spec.encoding"
+		return status, nil
+	}
+
+	template, markup, err := cacheTemplate(c, ctx)
+	if err != nil {
+		status.Operation = v2alpha1.CacheDryRunRejected
+		status.Message = err.Error()
+		return status, nil
+	}
+	// The server normalizes the configuration when converting it, and rejects invalid configurations
+	config, err := ispnClient.Caches().ConvertConfiguration(template, markup, mime.ApplicationJson)
+	if err != nil {
+		status.Operation = v2alpha1.CacheDryRunRejected
+		status.Message = fmt.Sprintf("invalid cache template: %v", err)
+		return status, nil
+	}
+	status.Configuration = config
+	if !cacheExists {
+		status.Operation = v2alpha1.CacheDryRunCreate
+		return status, nil
+	}
+
+	current, err := cacheClient.Config(mime.ApplicationJson)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve the configuration of cache '%s': %w", c.GetCacheName(), err)
+	}
+	equal, err := ispnClient.Caches().EqualConfiguration(current, config, mime.ApplicationJson, false)
+	if err != nil {
+		return nil, err
+	}
+	if equal {
+		status.Operation = v2alpha1.CacheDryRunNone
+		return status, nil
+	}
+	mutableChangesOnly, err := ispnClient.Caches().EqualConfiguration(current, config, mime.ApplicationJson, true)
+	if err != nil {
+		return nil, err
+	}
+	if mutableChangesOnly {
+		status.Operation = v2alpha1.CacheDryRunUpdate
+		return status, nil
+	}
+
+	status.Operation = v2alpha1.CacheDryRunRecreate
+	switch {
+	case spec.Updates == nil || !spec.Updates.RecreateOnImmutableChange:
+		status.Operation = v2alpha1.CacheDryRunRejected
+		status.Message = "the update changes immutable attributes. Set spec.updates.recreateOnImmutableChange to recreate the cache"
+	case spec.Updates.MigrateData:
+		status.Message = "the entries of the cache would be migrated to the recreated cache"
+	default:
+		status.Message = "the entries of the cache would be lost"
+	}
+	return status, nil
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/infinispan/infinispan-operator/pkg/mime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// dryRunContext provides the client of a server that only supports the read operations used by dry runs
+type dryRunContext struct {
+	*testContext
+	client *dryRunServer
+}
+
+func (c *dryRunContext) InfinispanClient() (api.Infinispan, error) { return c.client, nil }
+
+type dryRunServer struct {
+	api.Infinispan
+	exists bool
+	// The configurations are equal if only the mutable attributes differ when immutableChanged is false
+	changed, immutableChanged bool
+}
+
+func (s *dryRunServer) Cache(string) api.Cache { return &dryRunCache{server: s} }
+func (s *dryRunServer) Caches() api.Caches     { return &dryRunCaches{server: s} }
+
+type dryRunCache struct {
+	api.Cache
+	server *dryRunServer
+}
+
+func (c *dryRunCache) Exists() (bool, error)                { return c.server.exists, nil }
+func (c *dryRunCache) Config(mime.MimeType) (string, error) { return `{"distributed-cache":{}}`, nil }
+
+type dryRunCaches struct {
+	api.Caches
+	server *dryRunServer
+}
+
+func (c *dryRunCaches) ConvertConfiguration(config string, _, _ mime.MimeType) (string, error) {
+	return config, nil
+}
+
+func (c *dryRunCaches) EqualConfiguration(_, _ string, _ mime.MimeType, ignoreMutable bool) (bool, error) {
+	if ignoreMutable {
+		return !c.server.immutableChanged, nil
+	}
+	return !c.server.changed, nil
+}
+
+func TestDryRun(t *testing.T) {
+	testTable := []struct {
+		name     string
+		server   dryRunServer
+		updates  *v2alpha1.CacheUpdateSpec
+		expected v2alpha1.CacheDryRunOperation
+	}{
+		{"create", dryRunServer{}, nil, v2alpha1.CacheDryRunCreate},
+		{"unchanged", dryRunServer{exists: true}, nil, v2alpha1.CacheDryRunNone},
+		{"runtime update", dryRunServer{exists: true, changed: true}, nil, v2alpha1.CacheDryRunUpdate},
+		{"immutable change", dryRunServer{exists: true, changed: true, immutableChanged: true}, nil, v2alpha1.CacheDryRunRejected},
+		{"recreate", dryRunServer{exists: true, changed: true, immutableChanged: true}, &v2alpha1.CacheUpdateSpec{RecreateOnImmutableChange: true}, v2alpha1.CacheDryRunRecreate},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCache()
+			c.Annotations = map[string]string{constants.CacheAnnotationDryRun: "true"}
+			c.Spec.Template = `{"distributed-cache":{"mode":"ASYNC"}}`
+			c.Spec.Updates = tt.updates
+			ctx := &dryRunContext{
+				testContext: &testContext{infinispan: &ispnv1.Infinispan{
+					Spec: ispnv1.InfinispanSpec{Service: ispnv1.InfinispanServiceSpec{Type: ispnv1.ServiceTypeDataGrid}},
+				}},
+				client: &tt.server,
+			}
+
+			DryRun(c, ctx)
+			assert.True(t, ctx.status.Stop, "the server is not modified")
+			require.NoError(t, ctx.status.Err)
+			require.NotNil(t, c.Status.DryRun)
+			assert.Equal(t, tt.expected, c.Status.DryRun.Operation)
+			assert.Equal(t, c.Spec.Template, c.Status.DryRun.Configuration)
+			assert.Equal(t, c.GetGeneration(), c.Status.DryRun.ObservedGeneration)
+		})
+	}
+}
+
+func TestDryRunRemoved(t *testing.T) {
+	c := testCache()
+	c.Status.DryRun = &v2alpha1.CacheDryRunStatus{Operation: v2alpha1.CacheDryRunCreate}
+	ctx := &testContext{infinispan: &ispnv1.Infinispan{ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan"}}}
+	DryRun(c, ctx)
+	assert.False(t, ctx.status.Stop, "the Cache CR is reconciled on the server once the annotation is removed")
+	assert.Nil(t, c.Status.DryRun)
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/infinispan/infinispan-operator/pkg/mime"
+	"gopkg.in/yaml.v2"
+)
+
+// addCacheElement adds an element, e.g. the encoding of spec.encoding, to the cache definition of a template. The
+// XML element is inserted as the first child of the root cache element, whereas value is added as an attribute of the
+// JSON or YAML cache definition. An element or attribute that the template already contains is replaced
+func addCacheElement(template string, markup mime.MimeType, name, xmlElement string, value interface{}) (string, error) {
+	switch markup {
+	case mime.ApplicationXml:
+		return addXmlElement(template, xmlElement)
+	case mime.ApplicationJson:
+		return addJsonElement(template, name, value)
+	default:
+		return addYamlElement(template, name, value)
+	}
+}
+
+func addXmlElement(template, element string) (string, error) {
+	name, err := xmlElementName(element)
+	if err != nil {
+		return "", err
+	}
+	decoder := xml.NewDecoder(strings.NewReader(template))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		root, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if !isCache(root.Name.Local) {
+			return "", fmt.Errorf("root element '%s' is not a cache", root.Name.Local)
+		}
+		rootOffset := int(decoder.InputOffset())
+		if strings.HasSuffix(template[:rootOffset], "/>") {
+			// Expand a self-closing root element
+			return fmt.Sprintf("%s>%s</%s>%s", strings.TrimSuffix(template[:rootOffset], "/>"), element, root.Name.Local, template[rootOffset:]), nil
+		}
+		// Replace the child element of the same name, if any
+		depth, start := 0, -1
+		for {
+			offset := int(decoder.InputOffset())
+			token, err := decoder.Token()
+			if err != nil {
+				return "", err
+			}
+			switch t := token.(type) {
+			case xml.StartElement:
+				if depth == 0 && t.Name.Local == name {
+					start = offset
+				}
+				depth++
+			case xml.EndElement:
+				depth--
+				if depth == 0 && start >= 0 {
+					return template[:start] + element + template[decoder.InputOffset():], nil
+				}
+				if depth < 0 {
+					// The end of the root element
+					return template[:rootOffset] + element + template[rootOffset:], nil
+				}
+			}
+		}
+	}
+}
+
+// xmlElementName returns the name of the root element of an XML fragment
+func xmlElementName(element string) (string, error) {
+	decoder := xml.NewDecoder(strings.NewReader(element))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+func addJsonElement(template, name string, value interface{}) (string, error) {
+	config := map[string]map[string]interface{}{}
+	if err := json.Unmarshal([]byte(template), &config); err != nil {
+		return "", err
+	}
+	if len(config) != 1 {
+		return "", fmt.Errorf("template must contain a single cache definition")
+	}
+	for cacheType, cache := range config {
+		if !isCache(cacheType) {
+			return "", fmt.Errorf("'%s' is not a cache", cacheType)
+		}
+		if cache == nil {
+			cache = map[string]interface{}{}
+			config[cacheType] = cache
+		}
+		cache[name] = value
+	}
+	out, err := json.Marshal(config)
+	return string(out), err
+}
+
+func addYamlElement(template, name string, value interface{}) (string, error) {
+	config := yaml.MapSlice{}
+	if err := yaml.Unmarshal([]byte(template), &config); err != nil {
+		return "", err
+	}
+	if len(config) != 1 {
+		return "", fmt.Errorf("template must contain a single cache definition")
+	}
+	if cacheType := fmt.Sprint(config[0].Key); !isCache(cacheType) {
+		return "", fmt.Errorf("'%s' is not a cache", cacheType)
+	}
+	cache, _ := config[0].Value.(yaml.MapSlice)
+	// Preserve the order of the template attributes
+	for i := range cache {
+		if fmt.Sprint(cache[i].Key) == name {
+			cache[i].Value = value
+			value = nil
+			break
+		}
+	}
+	if value != nil {
+		cache = append(cache, yaml.MapItem{Key: name, Value: value})
+	}
+	config[0].Value = cache
+	out, err := yaml.Marshal(config)
+	return string(out), err
+}
+
+// isCache returns true if name is a cache element, e.g. distributed-cache or distributedCache
+func isCache(name string) bool {
+	return strings.HasSuffix(name, "-cache") || strings.HasSuffix(name, "Cache")
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+)
+
+func encodingXml(encoding *v2alpha1.CacheEncodingSpec) string {
+	var element strings.Builder
+	element.WriteString("<encoding>")
+	if encoding.Key != nil {
+		element.WriteString(fmt.Sprintf(`<key media-type="%s"/>`, xmlEscape(encoding.Key.MediaType)))
+	}
+	if encoding.Value != nil {
+		element.WriteString(fmt.Sprintf(`<value media-type="%s"/>`, xmlEscape(encoding.Value.MediaType)))
+	}
+	element.WriteString("</encoding>")
+	return element.String()
+}
+
+func encodingMap(encoding *v2alpha1.CacheEncodingSpec) map[string]interface{} {
+	m := map[string]interface{}{}
+	if encoding.Key != nil {
+		m["key"] = map[string]string{"media-type": encoding.Key.MediaType}
+	}
+	if encoding.Value != nil {
+		m["value"] = map[string]string{"media-type": encoding.Value.MediaType}
+	}
+	return m
+}
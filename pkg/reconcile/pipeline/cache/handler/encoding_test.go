@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/pkg/mime"
+	"github.com/stretchr/testify/assert"
+)
+
+func encodingCache(template string) *v2alpha1.Cache {
+	return &v2alpha1.Cache{
+		Spec: v2alpha1.CacheSpec{
+			Template: template,
+			Encoding: &v2alpha1.CacheEncodingSpec{
+				Key:   &v2alpha1.CacheMediaTypeSpec{MediaType: "application/x-protostream"},
+				Value: &v2alpha1.CacheMediaTypeSpec{MediaType: "application/x-java-object;type=java.lang.String"},
+			},
+		},
+	}
+}
+
+func TestCacheTemplateWithoutEncoding(t *testing.T) {
+	c := encodingCache(`<distributed-cache mode="SYNC"/>`)
+	c.Spec.Encoding = nil
+	template, markup, err := cacheTemplate(c, &testContext{})
+	assert.Nil(t, err)
+	assert.Equal(t, mime.ApplicationXml, markup)
+	assert.Equal(t, `<distributed-cache mode="SYNC"/>`, template)
+}
+
+func TestCacheTemplateXmlEncoding(t *testing.T) {
+	template, markup, err := cacheTemplate(encodingCache(`<distributed-cache mode="SYNC"><memory max-count="10"/></distributed-cache>`), &testContext{})
+	assert.Nil(t, err)
+	assert.Equal(t, mime.ApplicationXml, markup)
+	assert.Equal(t, `<distributed-cache mode="SYNC"><encoding><key media-type="application/x-protostream"/><value media-type="application/x-java-object;type=java.lang.String"/></encoding><memory max-count="10"/></distributed-cache>`, template)
+
+	template, _, err = cacheTemplate(encodingCache(`<replicated-cache mode="ASYNC"/>`), &testContext{})
+	assert.Nil(t, err)
+	assert.Equal(t, `<replicated-cache mode="ASYNC"><encoding><key media-type="application/x-protostream"/><value media-type="application/x-java-object;type=java.lang.String"/></encoding></replicated-cache>`, template)
+
+	_, _, err = cacheTemplate(encodingCache(`<infinispan><cache-container/></infinispan>`), &testContext{})
+	assert.EqualError(t, err, "unable to add spec.encoding to the cache template: root element 'infinispan' is not a cache")
+}
+
+func TestCacheTemplateJsonEncoding(t *testing.T) {
+	template, markup, err := cacheTemplate(encodingCache(`{"distributed-cache":{"mode":"SYNC","owners":2}}`), &testContext{})
+	assert.Nil(t, err)
+	assert.Equal(t, mime.ApplicationJson, markup)
+	assert.JSONEq(t, `{"distributed-cache":{"mode":"SYNC","owners":2,"encoding":{"key":{"media-type":"application/x-protostream"},"value":{"media-type":"application/x-java-object;type=java.lang.String"}}}}`, template)
+}
+
+func TestCacheTemplateYamlEncoding(t *testing.T) {
+	c := encodingCache("distributedCache:\n  mode: SYNC\n  owners: 2\n")
+	c.Spec.Encoding.Value = nil
+	template, markup, err := cacheTemplate(c, &testContext{})
+	assert.Nil(t, err)
+	assert.Equal(t, mime.ApplicationYaml, markup)
+	assert.Equal(t, "distributedCache:\n  mode: SYNC\n  owners: 2\n  encoding:\n    key:\n      media-type: application/x-protostream\n", template)
+}
+
+func TestCacheTemplateDefaultEncoding(t *testing.T) {
+	template, markup, err := cacheTemplate(encodingCache(""), &testContext{})
+	assert.Nil(t, err)
+	assert.Equal(t, mime.ApplicationJson, markup)
+	assert.JSONEq(t, `{"distributed-cache":{"mode":"SYNC","encoding":{"key":{"media-type":"application/x-protostream"},"value":{"media-type":"application/x-java-object;type=java.lang.String"}}}}`, template)
+}
+
+func TestCacheTemplateReplacesEncoding(t *testing.T) {
+	// The template that the listener stores contains the encoding of spec.encoding
+	template, _, err := cacheTemplate(encodingCache(`<distributed-cache mode="SYNC"><encoding media-type="text/plain"><key media-type="text/plain"/></encoding><memory max-count="10"/></distributed-cache>`), &testContext{})
+	assert.Nil(t, err)
+	assert.Equal(t, `<distributed-cache mode="SYNC"><encoding><key media-type="application/x-protostream"/><value media-type="application/x-java-object;type=java.lang.String"/></encoding><memory max-count="10"/></distributed-cache>`, template)
+
+	// Nested elements of the same name are not replaced
+	template, _, err = cacheTemplate(encodingCache(`<distributed-cache><indexing><encoding/></indexing></distributed-cache>`), &testContext{})
+	assert.Nil(t, err)
+	assert.Equal(t, `<distributed-cache><encoding><key media-type="application/x-protostream"/><value media-type="application/x-java-object;type=java.lang.String"/></encoding><indexing><encoding/></indexing></distributed-cache>`, template)
+
+	c := encodingCache("distributedCache:\n  encoding:\n    mediaType: text/plain\n  owners: 2\n")
+	c.Spec.Encoding.Value = nil
+	template, _, err = cacheTemplate(c, &testContext{})
+	assert.Nil(t, err)
+	assert.Equal(t, "distributedCache:\n  encoding:\n    key:\n      media-type: application/x-protostream\n  owners: 2\n", template)
+}
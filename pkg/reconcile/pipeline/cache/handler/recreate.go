@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	users "github.com/infinispan/infinispan-operator/pkg/infinispan/security"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/upgrades"
+	"github.com/infinispan/infinispan-operator/pkg/mime"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/cache"
+)
+
+// updateConfig updates the configuration of an existing cache, recreating the cache when immutable attributes have
+// changed and spec.updates.recreateOnImmutableChange is true
+func updateConfig(c *v2alpha1.Cache, ctx pipeline.Context, cache api.Cache) error {
+	spec := c.Spec
+	template, markup, err := cacheTemplate(c, ctx)
+	if err != nil {
+		return err
+	}
+	err = cache.UpdateConfig(template, markup)
+	if err == nil {
+		return nil
+	}
+
+	var incompatibleErr *api.IncompatibleAttributesError
+	if !errors.As(err, &incompatibleErr) {
+		return fmt.Errorf("unable to update cache template: %w", err)
+	}
+
+	if spec.Updates == nil || !spec.Updates.RecreateOnImmutableChange {
+		return fmt.Errorf("%w. Set spec.updates.recreateOnImmutableChange to recreate the cache", incompatibleErr)
+	}
+	ctx.Log().Info("Recreating cache as immutable attributes have changed", "attributes", incompatibleErr.Attributes)
+	return recreate(c, ctx, cache)
+}
+
+// recreate deletes the cache and creates it with the template of the Cache CR. When spec.updates.migrateData is true
+// the existing entries are copied to a temporary cache first and restored once the cache has been recreated.
+func recreate(c *v2alpha1.Cache, ctx pipeline.Context, cache api.Cache) error {
+	spec := c.Spec
+	cacheName := c.GetCacheName()
+
+	// Prevent the ConfigListener from marking the Cache CR for deletion when the cache is removed from the server
+	if err := ctx.UpdateCache(func() {
+		if c.Annotations == nil {
+			c.Annotations = map[string]string{}
+		}
+		c.Annotations[constants.CacheAnnotationRecreate] = "true"
+	}); err != nil {
+		return err
+	}
+
+	if spec.Updates.MigrateData {
+		config, err := cache.Config(mime.ApplicationJson)
+		if err != nil {
+			return fmt.Errorf("unable to retrieve configuration of cache '%s': %w", cacheName, err)
+		}
+		ispnClient, err := ctx.InfinispanClient()
+		if err != nil {
+			return err
+		}
+		tmpName := cacheName + constants.CacheMigrationSuffix
+		tmpCache := ispnClient.Cache(tmpName)
+		exists, err := tmpCache.Exists()
+		if err != nil {
+			return fmt.Errorf("unable to determine if cache '%s' exists: %w", tmpName, err)
+		}
+		if !exists {
+			if err = tmpCache.Create(config, mime.ApplicationJson); err != nil {
+				return fmt.Errorf("unable to create temporary cache '%s': %w", tmpName, err)
+			}
+		}
+		if err = migrate(ctx, cacheName, tmpCache); err != nil {
+			return err
+		}
+	}
+
+	if err := cache.Delete(); err != nil {
+		return fmt.Errorf("unable to delete cache '%s': %w", cacheName, err)
+	}
+	template, markup, err := cacheTemplate(c, ctx)
+	if err != nil {
+		return err
+	}
+	if err := cache.Create(template, markup); err != nil {
+		return fmt.Errorf("unable to recreate cache '%s': %w", cacheName, err)
+	}
+
+	if err := restoreMigratedData(c, ctx, cache); err != nil {
+		return err
+	}
+	return ctx.UpdateCache(func() {
+		delete(c.Annotations, constants.CacheAnnotationRecreate)
+	})
+}
+
+// restoreMigratedData copies entries from the temporary cache created by recreate back to the cache and removes the
+// temporary cache. This allows a migration that was interrupted after the cache was recreated to complete.
+func restoreMigratedData(c *v2alpha1.Cache, ctx pipeline.Context, cache api.Cache) error {
+	ispnClient, err := ctx.InfinispanClient()
+	if err != nil {
+		return err
+	}
+	tmpName := c.GetCacheName() + constants.CacheMigrationSuffix
+	tmpCache := ispnClient.Cache(tmpName)
+	exists, err := tmpCache.Exists()
+	if err != nil {
+		return fmt.Errorf("unable to determine if cache '%s' exists: %w", tmpName, err)
+	}
+	if !exists {
+		return nil
+	}
+
+	if err = migrate(ctx, tmpName, cache); err != nil {
+		return fmt.Errorf("unable to restore entries from temporary cache '%s': %w", tmpName, err)
+	}
+	if err = tmpCache.Delete(); err != nil {
+		return fmt.Errorf("unable to delete temporary cache '%s': %w", tmpName, err)
+	}
+	return nil
+}
+
+// migrate copies all entries of the source cache to the target cache via the cluster's admin Service
+func migrate(ctx pipeline.Context, sourceName string, target api.Cache) error {
+	i, err := ctx.Infinispan()
+	if err != nil {
+		return err
+	}
+	pass, err := users.AdminPassword(i.GetAdminSecretName(), i.Namespace, ctx.Kubernetes(), ctx.Ctx())
+	if err != nil {
+		return fmt.Errorf("unable to retrieve operator admin password: %w", err)
+	}
+	return upgrades.MigrateCache(pass, i.GetAdminServiceName(), sourceName, target, ctx.Log())
+}
+
+// isIncompatibleAttributesErr returns true if the error was caused by a template changing immutable cache attributes
+func isIncompatibleAttributesErr(err error) bool {
+	var incompatibleErr *api.IncompatibleAttributesError
+	return errors.As(err, &incompatibleErr)
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+)
+
+func securityXml(security *v2alpha1.CacheSecuritySpec) string {
+	return fmt.Sprintf(`<security><authorization enabled="true" roles="%s"/></security>`, xmlEscape(strings.Join(security.Roles, " ")))
+}
+
+func securityMap(security *v2alpha1.CacheSecuritySpec) map[string]interface{} {
+	return map[string]interface{}{
+		"authorization": map[string]interface{}{
+			"enabled": true,
+			"roles":   security.Roles,
+		},
+	}
+}
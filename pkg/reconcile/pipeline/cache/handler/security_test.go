@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	"github.com/infinispan/infinispan-operator/pkg/mime"
+	"github.com/stretchr/testify/assert"
+)
+
+func securityCache(template string) *v2alpha1.Cache {
+	return &v2alpha1.Cache{
+		Spec: v2alpha1.CacheSpec{
+			Template: template,
+			Security: &v2alpha1.CacheSecuritySpec{
+				Roles: []string{"admin", "application"},
+			},
+		},
+	}
+}
+
+func TestCacheTemplateXmlSecurity(t *testing.T) {
+	template, markup, err := cacheTemplate(securityCache(`<distributed-cache mode="SYNC"><memory max-count="10"/></distributed-cache>`), &testContext{})
+	assert.Nil(t, err)
+	assert.Equal(t, mime.ApplicationXml, markup)
+	assert.Equal(t, `<distributed-cache mode="SYNC"><security><authorization enabled="true" roles="admin application"/></security><memory max-count="10"/></distributed-cache>`, template)
+
+	_, _, err = cacheTemplate(securityCache(`<infinispan><cache-container/></infinispan>`), &testContext{})
+	assert.EqualError(t, err, "unable to add spec.security to the cache template: root element 'infinispan' is not a cache")
+}
+
+func TestCacheTemplateJsonSecurity(t *testing.T) {
+	template, markup, err := cacheTemplate(securityCache(`{"distributed-cache":{"mode":"SYNC","owners":2}}`), &testContext{})
+	assert.Nil(t, err)
+	assert.Equal(t, mime.ApplicationJson, markup)
+	assert.JSONEq(t, `{"distributed-cache":{"mode":"SYNC","owners":2,"security":{"authorization":{"enabled":true,"roles":["admin","application"]}}}}`, template)
+}
+
+func TestCacheTemplateYamlSecurity(t *testing.T) {
+	template, markup, err := cacheTemplate(securityCache("distributedCache:\n  mode: SYNC\n"), &testContext{})
+	assert.Nil(t, err)
+	assert.Equal(t, mime.ApplicationYaml, markup)
+	assert.Equal(t, "distributedCache:\n  mode: SYNC\n  security:\n    authorization:\n      enabled: true\n      roles:\n      - admin\n      - application\n", template)
+}
+
+func TestCacheTemplateEncodingAndSecurity(t *testing.T) {
+	c := securityCache("")
+	c.Spec.Encoding = &v2alpha1.CacheEncodingSpec{
+		Value: &v2alpha1.CacheMediaTypeSpec{MediaType: "application/x-protostream"},
+	}
+	template, markup, err := cacheTemplate(c, &testContext{})
+	assert.Nil(t, err)
+	assert.Equal(t, mime.ApplicationJson, markup)
+	assert.JSONEq(t, `{"distributed-cache":{"mode":"SYNC","encoding":{"value":{"media-type":"application/x-protostream"}},"security":{"authorization":{"enabled":true,"roles":["admin","application"]}}}}`, template)
+}
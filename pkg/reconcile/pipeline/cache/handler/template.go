@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
+	"github.com/infinispan/infinispan-operator/pkg/mime"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/cache"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// defaultCacheTemplate is used when a Cache CR does not configure a template
+const defaultCacheTemplate = `{"distributed-cache":{"mode":"SYNC"}}`
+
+// templateVariable matches the $(VARIABLE) references of a cache template
+var templateVariable = regexp.MustCompile(`\$\(([A-Z_]+)\)`)
+
+// TemplateValidation converts the template of spec.template or spec.templateFrom with the REST API of the server, which
+// parses it against the configuration schema of the server, and reports the result with the TemplateValid condition.
+// The pipeline is stopped without a requeue whilst the server rejects the template, as it only changes with the Cache
+// CR or the resource of spec.templateFrom, which both queue a request
+func TemplateValidation(c *v2alpha1.Cache, ctx pipeline.Context) {
+	if !reconcileOnServer(c) || (c.Spec.Template == "" && c.Spec.TemplateFrom == nil) {
+		return
+	}
+	// Templates are rejected by CreateOrUpdateOnServer for CacheService clusters
+	if i, _ := ctx.Infinispan(); !i.IsDataGrid() {
+		return
+	}
+	template, markup, err := cacheTemplate(c, ctx)
+	if err != nil {
+		// Reported by CreateOrUpdateOnServer
+		return
+	}
+
+	ispnClient, err := ctx.InfinispanClient()
+	if err != nil {
+		ctx.Requeue(fmt.Errorf("unable to create Infinispan client: %w", err))
+		return
+	}
+	_, err = ispnClient.Caches().ConvertConfiguration(template, markup, mime.ApplicationJson)
+	var httpErr *httpClient.HttpError
+	if errors.As(err, &httpErr) && httpErr.Status == http.StatusBadRequest {
+		msg := fmt.Sprintf("template rejected by the server: %s", httpErr.Message)
+		ctx.Stop(ctx.UpdateCache(func() {
+			c.SetCondition(v2alpha1.CacheConditionTemplateValid, metav1.ConditionFalse, msg)
+			c.SetCondition(v2alpha1.CacheConditionReady, metav1.ConditionFalse, msg)
+		}))
+		return
+	} else if err != nil {
+		ctx.Requeue(fmt.Errorf("unable to validate the cache template: %w", err))
+		return
+	}
+	if err := ctx.UpdateCache(func() {
+		c.SetCondition(v2alpha1.CacheConditionTemplateValid, metav1.ConditionTrue, "")
+	}); err != nil {
+		ctx.Requeue(err)
+	}
+}
+
+// cacheTemplate returns the configuration used to create or update the cache of a Cache CR. The template is loaded
+// from spec.template or spec.templateFrom, template variables are replaced and the media types of spec.encoding and the
+// authorization of spec.security are added. A distributed cache is created if no template is configured
+func cacheTemplate(c *v2alpha1.Cache, ctx pipeline.Context) (string, mime.MimeType, error) {
+	template, err := loadTemplate(c, ctx)
+	if err != nil {
+		return "", "", err
+	}
+	template = strings.TrimSpace(expandTemplate(c, template))
+	if template == "" {
+		template = defaultCacheTemplate
+	}
+	markup := mime.GuessMarkup(template)
+
+	if encoding := c.Spec.Encoding; encoding != nil {
+		if template, err = addCacheElement(template, markup, "encoding", encodingXml(encoding), encodingMap(encoding)); err != nil {
+			return "", "", fmt.Errorf("unable to add spec.encoding to the cache template: %w", err)
+		}
+	}
+	if security := c.Spec.Security; security != nil {
+		if template, err = addCacheElement(template, markup, "security", securityXml(security), securityMap(security)); err != nil {
+			return "", "", fmt.Errorf("unable to add spec.security to the cache template: %w", err)
+		}
+	}
+	return template, markup, nil
+}
+
+// loadTemplate returns spec.template or the content of the ConfigMap or Secret key referenced by spec.templateFrom
+func loadTemplate(c *v2alpha1.Cache, ctx pipeline.Context) (string, error) {
+	source := c.Spec.TemplateFrom
+	if source == nil {
+		return c.Spec.Template, nil
+	}
+
+	client := ctx.Kubernetes().Client
+	if ref := source.ConfigMapKeyRef; ref != nil {
+		configMap := &corev1.ConfigMap{}
+		if err := client.Get(ctx.Ctx(), types.NamespacedName{Namespace: c.Namespace, Name: ref.Name}, configMap); err != nil {
+			if kerrors.IsNotFound(err) && ref.Optional != nil && *ref.Optional {
+				return "", nil
+			}
+			return "", fmt.Errorf("unable to load cache template from ConfigMap '%s': %w", ref.Name, err)
+		}
+		template, ok := configMap.Data[ref.Key]
+		if !ok && (ref.Optional == nil || !*ref.Optional) {
+			return "", fmt.Errorf("key '%s' not found in ConfigMap '%s'", ref.Key, ref.Name)
+		}
+		return template, nil
+	}
+
+	ref := source.SecretKeyRef
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx.Ctx(), types.NamespacedName{Namespace: c.Namespace, Name: ref.Name}, secret); err != nil {
+		if kerrors.IsNotFound(err) && ref.Optional != nil && *ref.Optional {
+			return "", nil
+		}
+		return "", fmt.Errorf("unable to load cache template from Secret '%s': %w", ref.Name, err)
+	}
+	template, ok := secret.Data[ref.Key]
+	if !ok && (ref.Optional == nil || !*ref.Optional) {
+		return "", fmt.Errorf("key '%s' not found in Secret '%s'", ref.Key, ref.Name)
+	}
+	return string(template), nil
+}
+
+// expandTemplate replaces the $(CLUSTER_NAME), $(NAMESPACE) and $(CACHE_NAME) variables of a template. Unknown
+// variables are left unchanged
+func expandTemplate(c *v2alpha1.Cache, template string) string {
+	variables := map[string]string{
+		"CLUSTER_NAME": c.Spec.ClusterName,
+		"NAMESPACE":    c.Namespace,
+		"CACHE_NAME":   c.GetCacheName(),
+	}
+	return templateVariable.ReplaceAllStringFunc(template, func(match string) string {
+		if value, ok := variables[templateVariable.FindStringSubmatch(match)[1]]; ok {
+			return value
+		}
+		return match
+	})
+}
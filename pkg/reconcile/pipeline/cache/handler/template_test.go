@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/infinispan/infinispan-operator/pkg/mime"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func templateContext(objs ...runtime.Object) *testContext {
+	client := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	return &testContext{kubernetes: &kubernetes.Kubernetes{Client: client}}
+}
+
+func TestCacheTemplateVariables(t *testing.T) {
+	c := testCache()
+	c.Spec.Name = "sessions"
+	c.Spec.Template = `<distributed-cache><persistence><file-store path="$(NAMESPACE)/$(CLUSTER_NAME)/$(CACHE_NAME)"/></persistence><!-- $(UNKNOWN) --></distributed-cache>`
+	template, markup, err := cacheTemplate(c, templateContext())
+	assert.Nil(t, err)
+	assert.Equal(t, mime.ApplicationXml, markup)
+	assert.Equal(t, `<distributed-cache><persistence><file-store path="testing-namespace/example-infinispan/sessions"/></persistence><!-- $(UNKNOWN) --></distributed-cache>`, template)
+}
+
+func TestCacheTemplateFromConfigMap(t *testing.T) {
+	c := testCache()
+	c.Spec.TemplateFrom = &v2alpha1.CacheTemplateSource{
+		ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cache-templates"}, Key: "cache.yaml"},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cache-templates", Namespace: c.Namespace},
+		Data:       map[string]string{"cache.yaml": "distributedCache:\n  remoteTimeout: $(CACHE_NAME)\n"},
+	}
+	template, markup, err := cacheTemplate(c, templateContext(configMap))
+	assert.Nil(t, err)
+	assert.Equal(t, mime.ApplicationYaml, markup)
+	assert.Equal(t, "distributedCache:\n  remoteTimeout: example-cache", template)
+
+	c.Spec.TemplateFrom.ConfigMapKeyRef.Key = "missing.yaml"
+	_, _, err = cacheTemplate(c, templateContext(configMap))
+	assert.EqualError(t, err, "key 'missing.yaml' not found in ConfigMap 'cache-templates'")
+
+	_, _, err = cacheTemplate(c, templateContext())
+	assert.Error(t, err)
+}
+
+func TestCacheTemplateFromSecret(t *testing.T) {
+	c := testCache()
+	c.Spec.TemplateFrom = &v2alpha1.CacheTemplateSource{
+		SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cache-secret"}, Key: "cache.json"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cache-secret", Namespace: c.Namespace},
+		Data:       map[string][]byte{"cache.json": []byte(`{"distributed-cache":{"mode":"SYNC"}}`)},
+	}
+	template, markup, err := cacheTemplate(c, templateContext(secret))
+	assert.Nil(t, err)
+	assert.Equal(t, mime.ApplicationJson, markup)
+	assert.Equal(t, `{"distributed-cache":{"mode":"SYNC"}}`, template)
+
+	// Optional templates that don't exist create a default cache
+	c.Spec.TemplateFrom.SecretKeyRef.Optional = pointer.BoolPtr(true)
+	template, markup, err = cacheTemplate(c, templateContext())
+	assert.Nil(t, err)
+	assert.Equal(t, mime.ApplicationJson, markup)
+	assert.Equal(t, defaultCacheTemplate, template)
+}
+
+// templateValidationContext provides the client of a server that converts templates or fails with err
+type templateValidationContext struct {
+	*testContext
+	err error
+}
+
+func (c *templateValidationContext) InfinispanClient() (api.Infinispan, error) {
+	return &templateValidationServer{err: c.err}, nil
+}
+
+type templateValidationServer struct {
+	api.Infinispan
+	err error
+}
+
+func (s *templateValidationServer) Caches() api.Caches { return &templateValidationCaches{err: s.err} }
+
+type templateValidationCaches struct {
+	api.Caches
+	err error
+}
+
+func (c *templateValidationCaches) ConvertConfiguration(config string, _, _ mime.MimeType) (string, error) {
+	return config, c.err
+}
+
+func TestTemplateValidation(t *testing.T) {
+	validate := func(err error) (*v2alpha1.Cache, *templateValidationContext) {
+		c := testCache()
+		c.Spec.Template = `{"distributed-cache":{"mode":"SYNC"}}`
+		ctx := &templateValidationContext{
+			testContext: &testContext{infinispan: &ispnv1.Infinispan{
+				Spec: ispnv1.InfinispanSpec{Service: ispnv1.InfinispanServiceSpec{Type: ispnv1.ServiceTypeDataGrid}},
+			}},
+			err: err,
+		}
+		TemplateValidation(c, ctx)
+		return c, ctx
+	}
+	condition := func(c *v2alpha1.Cache, conditionType v2alpha1.CacheConditionType) v2alpha1.CacheCondition {
+		for _, condition := range c.Status.Conditions {
+			if condition.Type == conditionType {
+				return condition
+			}
+		}
+		return v2alpha1.CacheCondition{}
+	}
+
+	c, ctx := validate(nil)
+	assert.False(t, ctx.status.Stop)
+	assert.Equal(t, metav1.ConditionTrue, condition(c, v2alpha1.CacheConditionTemplateValid).Status)
+
+	// The cache is not created whilst the server rejects the template, which is not retried until it changes
+	c, ctx = validate(&httpClient.HttpError{Status: http.StatusBadRequest, Message: "ISPN000327: Cannot find a parser for element 'distributed-cach'"})
+	assert.True(t, ctx.status.Stop)
+	assert.False(t, ctx.status.Retry)
+	assert.NoError(t, ctx.status.Err)
+	rejected := condition(c, v2alpha1.CacheConditionTemplateValid)
+	assert.Equal(t, metav1.ConditionFalse, rejected.Status)
+	assert.Equal(t, "template rejected by the server: ISPN000327: Cannot find a parser for element 'distributed-cach'", rejected.Message)
+	assert.Equal(t, metav1.ConditionFalse, condition(c, v2alpha1.CacheConditionReady).Status)
+
+	// Errors reaching the server are retried
+	c, ctx = validate(&httpClient.HttpError{Status: http.StatusServiceUnavailable})
+	assert.True(t, ctx.status.Retry)
+	assert.Empty(t, c.Status.Conditions)
+}
@@ -0,0 +1,101 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/cache"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/cache/handler"
+)
+
+var _ pipeline.Pipeline = &impl{}
+
+type impl struct {
+	*pipeline.ContextProviderConfig
+	ctxProvider pipeline.ContextProvider
+	handlers    []pipeline.Handler
+}
+
+func (i *impl) Process(ctx context.Context) (retry bool, delay time.Duration, err error) {
+	defer func() {
+		if perr := recover(); perr != nil {
+			retry = true
+			err = fmt.Errorf("panic occurred: %v", perr)
+		}
+	}()
+	cacheContext, err := i.ctxProvider.Get(ctx, i.ContextProviderConfig)
+	if err != nil {
+		return false, 0, err
+	}
+
+	var status pipeline.FlowStatus
+	for _, h := range i.handlers {
+		invokeHandler(h, i.Cache, cacheContext)
+		status = cacheContext.FlowStatus()
+		if status.Stop {
+			break
+		}
+	}
+	return status.Retry, status.Delay, status.Err
+}
+
+func invokeHandler(h pipeline.Handler, c *v2alpha1.Cache, ctx pipeline.Context) {
+	defer func() {
+		if err := recover(); err != nil {
+			e := fmt.Errorf("panic occurred: %v", err)
+			ctx.Log().Error(e, string(debug.Stack()))
+			ctx.Requeue(e)
+		}
+	}()
+	h.Handle(c, ctx)
+}
+
+type builder impl
+
+func Builder() *builder {
+	return &builder{
+		ContextProviderConfig: &pipeline.ContextProviderConfig{},
+	}
+}
+
+func (b *builder) For(c *v2alpha1.Cache) *builder {
+	b.Cache = c
+	return b
+}
+
+func (b *builder) WithContextProvider(ctxProvider pipeline.ContextProvider) *builder {
+	b.ctxProvider = ctxProvider
+	return b
+}
+
+func (b *builder) WithLogger(logger logr.Logger) *builder {
+	b.Logger = logger
+	return b
+}
+
+func (b *builder) WithHandlers(handlers ...pipeline.HandlerFunc) *builder {
+	for _, h := range handlers {
+		b.handlers = append(b.handlers, h)
+	}
+	return b
+}
+
+func (b *builder) Build() pipeline.Pipeline {
+	if len(b.handlers) == 0 {
+		b.WithHandlers(
+			handler.ListenerDeletion,
+			handler.ClusterWellFormed,
+			handler.CacheDeletion,
+			handler.DryRun,
+			handler.TemplateValidation,
+			handler.CreateOrUpdateOnServer,
+			handler.ReadyCondition,
+		)
+	}
+	impl := impl(*b)
+	return &impl
+}
@@ -216,8 +216,14 @@ type ConfigFiles struct {
 	UserConfig      UserConfig
 	Keystore        *Keystore
 	Truststore      *Truststore
-	Transport       Transport
-	XSite           *XSite
+	OIDC            *OIDC
+	// The secrets of spec.security.credentials that are added to the credential store of the servers, by alias
+	Credentials map[string]string
+	// The hash of the keytab and of the Kerberos configuration of the servers
+	KerberosHash string
+	CABundle     []byte
+	Transport    Transport
+	XSite        *XSite
 }
 
 type UserConfig struct {
@@ -233,8 +239,15 @@ type AdminIdentities struct {
 	CliProperties  string
 }
 
+// OIDC holds the credentials of the client that the servers introspect bearer tokens with
+type OIDC struct {
+	ClientID     string
+	ClientSecret string
+}
+
 type Keystore struct {
 	Alias    string
+	CA       []byte
 	File     []byte
 	PemFile  []byte
 	Password string
@@ -252,6 +265,8 @@ type Truststore struct {
 type Transport struct {
 	Keystore   *Keystore
 	Truststore *Truststore
+	// The hash of the truststore and the CA certificates of the remote sites, if the relay truststore is assembled by the operator
+	RelayTruststoreHash string
 }
 
 type XSite struct {
@@ -272,9 +287,12 @@ type BackupSite struct {
 }
 
 var (
-	ServiceTypes      = []schema.GroupVersionKind{ServiceGVK, RouteGVK, IngressGVK}
+	ServiceTypes      = []schema.GroupVersionKind{ServiceGVK, RouteGVK, IngressGVK, HTTPRouteGVK, TLSRouteGVK}
 	ServiceGVK        = corev1.SchemeGroupVersion.WithKind("Service")
 	RouteGVK          = routev1.SchemeGroupVersion.WithKind("Route")
 	IngressGVK        = ingressv1.SchemeGroupVersion.WithKind("Ingress")
+	HTTPRouteGVK      = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1beta1", Kind: "HTTPRoute"}
+	TLSRouteGVK       = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Kind: "TLSRoute"}
 	ServiceMonitorGVK = monitoringv1.SchemeGroupVersion.WithKind("ServiceMonitor")
+	ServiceExportGVK  = schema.GroupVersionKind{Group: "multicluster.x-k8s.io", Version: "v1alpha1", Kind: "ServiceExport"}
 )
@@ -3,9 +3,11 @@ package configure
 import (
 	"fmt"
 	"net/url"
+	"sort"
 
 	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
 	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/hash"
 	"github.com/infinispan/infinispan-operator/pkg/infinispan/security"
 	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
 	corev1 "k8s.io/api/core/v1"
@@ -29,7 +31,91 @@ func UserAuthenticationSecret(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	ctx.ConfigFiles().UserIdentities = userIdentities
 }
 
+// OIDCClient loads the credentials of the client that the servers introspect bearer tokens with
+func OIDCClient(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	secret := &corev1.Secret{}
+	if err := ctx.Resources().Load(i.Spec.Security.EndpointOIDC.ClientSecretName, secret, pipeline.RetryOnErr); err != nil {
+		return
+	}
+
+	for _, key := range []string{consts.OIDCClientIDKey, consts.OIDCClientSecretKey} {
+		if len(secret.Data[key]) == 0 {
+			ctx.Requeue(fmt.Errorf("OIDC client secret '%s' missing required field '%s'", secret.Name, key))
+			return
+		}
+	}
+	ctx.ConfigFiles().OIDC = &pipeline.OIDC{
+		ClientID:     string(secret.Data[consts.OIDCClientIDKey]),
+		ClientSecret: string(secret.Data[consts.OIDCClientSecretKey]),
+	}
+}
+
+// Credentials loads the secrets of spec.security.credentials that are added to the credential store of the servers
+func Credentials(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	credentials := make(map[string]string, len(i.Spec.Security.Credentials))
+	for _, credential := range i.Spec.Security.Credentials {
+		secret := &corev1.Secret{}
+		if err := ctx.Resources().Load(credential.SecretName, secret, pipeline.RetryOnErr); err != nil {
+			return
+		}
+		value := secret.Data[credential.GetKey()]
+		if len(value) == 0 {
+			ctx.Requeue(fmt.Errorf("credential secret '%s' missing required field '%s'", secret.Name, credential.GetKey()))
+			return
+		}
+		credentials[credential.Alias] = string(value)
+	}
+	ctx.ConfigFiles().Credentials = credentials
+}
+
+// CredentialAliases returns the sorted aliases of the secrets of spec.security.credentials
+func CredentialAliases(configFiles *pipeline.ConfigFiles) []string {
+	aliases := make([]string, 0, len(configFiles.Credentials))
+	for alias := range configFiles.Credentials {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// KerberosKeytab verifies that the keytab Secret of the Kerberos service principal is complete
+func KerberosKeytab(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	secret := &corev1.Secret{}
+	if err := ctx.Resources().Load(i.Spec.Security.EndpointKerberos.KeytabSecretName, secret, pipeline.RetryOnErr); err != nil {
+		return
+	}
+
+	for _, key := range []string{consts.KerberosKeytabKey, consts.KerberosConfigKey} {
+		if len(secret.Data[key]) == 0 {
+			ctx.Requeue(fmt.Errorf("kerberos keytab secret '%s' missing required field '%s'", secret.Name, key))
+			return
+		}
+	}
+	ctx.ConfigFiles().KerberosHash = hash.HashMap(map[string][]byte{
+		consts.KerberosKeytabKey: secret.Data[consts.KerberosKeytabKey],
+		consts.KerberosConfigKey: secret.Data[consts.KerberosConfigKey],
+	})
+}
+
 func AdminSecret(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	if secretName := i.Spec.Security.AdminSecretName; secretName != "" {
+		// The identities of the operator user are created from the password provided by the user
+		secret := &corev1.Secret{}
+		if err := ctx.Resources().Load(secretName, secret, pipeline.RetryOnErr); err != nil {
+			return
+		}
+		password := string(secret.Data[consts.AdminPasswordKey])
+		if password == "" {
+			ctx.Requeue(fmt.Errorf("admin secret '%s' missing required field '%s'", secretName, consts.AdminPasswordKey))
+			return
+		}
+		ctx.ConfigFiles().AdminIdentities = &pipeline.AdminIdentities{
+			Username: consts.DefaultOperatorUser,
+			Password: password,
+		}
+		return
+	}
+
 	secret := &corev1.Secret{}
 	if err := ctx.Resources().Load(i.GetAdminSecretName(), secret, pipeline.SkipEventRec); err != nil {
 		if !errors.IsNotFound(err) {
@@ -109,15 +195,23 @@ func IdentitiesBatch(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	configFiles := ctx.ConfigFiles()
 
 	// Define admin identities on the server
-	batch, err := security.IdentitiesCliFileFromSecret(configFiles.AdminIdentities.IdentitiesFile, "admin", "cli-admin-users.properties", "cli-admin-groups.properties")
+	batch, err := security.IdentitiesCliFileFromSecret(configFiles.AdminIdentities.IdentitiesFile, "admin", "cli-admin-users.properties", "cli-admin-groups.properties", i.GetAdminPasswordAlgorithms())
 	if err != nil {
 		ctx.Requeue(fmt.Errorf("unable to read admin credentials: %w", err))
 		return
 	}
 
-	// Add user identities only if authentication enabled
-	if i.IsAuthenticationEnabled() {
-		usersCliBatch, err := security.IdentitiesCliFileFromSecret(configFiles.UserIdentities, "default", "cli-users.properties", "cli-groups.properties")
+	// Add user identities only if authentication enabled and the users are not read from Vault
+	if i.IsEndpointSecretEnabled() {
+		userIdentities := configFiles.UserIdentities
+		if i.IsAdminOperationsRestricted() {
+			admin := configFiles.AdminIdentities
+			if userIdentities, err = security.RestrictUserIdentities(userIdentities, admin.Username, admin.Password); err != nil {
+				ctx.Requeue(fmt.Errorf("unable to restrict user credentials: %w", err))
+				return
+			}
+		}
+		usersCliBatch, err := security.IdentitiesCliFileFromSecret(userIdentities, "default", "cli-users.properties", "cli-groups.properties", i.GetPasswordAlgorithms())
 		if err != nil {
 			ctx.Requeue(fmt.Errorf("unable to read user credentials: %w", err))
 			return
@@ -125,6 +219,22 @@ func IdentitiesBatch(i *ispnv1.Infinispan, ctx pipeline.Context) {
 		batch += usersCliBatch
 	}
 
+	if i.IsOIDCEnabled() {
+		batch += fmt.Sprintf("credentials add oidc -c \"%s\" -p secret\n", configFiles.OIDC.ClientSecret)
+	}
+
+	if i.IsSiteTLSEnabled() {
+		// The passwords of the cross-site keystore and truststore are referenced by the transport security realm
+		batch += fmt.Sprintf("credentials add %s -c \"%s\" -p secret\n", consts.TransportKeystoreCredentialAlias, configFiles.Transport.Keystore.Password)
+		if configFiles.Transport.Truststore != nil {
+			batch += fmt.Sprintf("credentials add %s -c \"%s\" -p secret\n", consts.TransportTruststoreCredentialAlias, configFiles.Transport.Truststore.Password)
+		}
+	}
+
+	for _, alias := range CredentialAliases(configFiles) {
+		batch += fmt.Sprintf("credentials add %s -c \"%s\" -p secret\n", alias, configFiles.Credentials[alias])
+	}
+
 	if i.IsEncryptionEnabled() {
 		configFiles := ctx.ConfigFiles()
 
@@ -58,21 +58,72 @@ func InfinispanServer(i *ispnv1.Infinispan, ctx pipeline.Context) {
 		ClusterName:     i.Name,
 		Namespace:       i.Namespace,
 		StatefulSetName: i.GetStatefulSetName(),
+		PingServiceName: i.GetPingServiceName(),
 		Infinispan: config.Infinispan{
 			Authorization: &config.Authorization{
 				Enabled:    i.IsAuthorizationEnabled(),
 				RoleMapper: roleMapper,
+				AuditLog:   i.IsAuditLogEnabled(),
 			},
+			CapacityFactor: i.IsCapacityFactorEnabled(),
 		},
 		JGroups: config.JGroups{
 			Diagnostics: consts.JGroupsDiagnosticsFlag == "TRUE",
 			FastMerge:   consts.JGroupsFastMerge,
+			IPv6:        i.IsIPv6(),
 		},
 		Endpoints: config.Endpoints{
-			Authenticate: i.IsAuthenticationEnabled(),
-			ClientCert:   string(ispnv1.ClientCertNone),
+			Authenticate:  i.IsAuthenticationEnabled(),
+			ClientCert:    string(ispnv1.ClientCertNone),
+			RestrictAdmin: !i.IsConsoleEnabled(),
+			HotRod:        endpointConnector(i.IsHotRodEnabled(), i.HotRodPort()),
+			Rest:          endpointConnector(i.IsRestEnabled(), i.RestPort()),
 		},
 	}
+	configSpec.Endpoints.HotRod.External = i.IsExposedPerPod()
+	if i.IsVaultEnabled() {
+		configSpec.Endpoints.IdentitiesPath = consts.ServerVaultRoot
+	}
+	if i.IsKerberosEnabled() {
+		configSpec.Endpoints.Kerberos = &config.Kerberos{
+			Principal:  i.Spec.Security.EndpointKerberos.Principal,
+			KeytabPath: fmt.Sprintf("%s/%s", consts.ServerKerberosRoot, consts.KerberosKeytabKey),
+		}
+	}
+	if i.IsOIDCEnabled() {
+		configSpec.Endpoints.OIDC = &config.OIDC{
+			AuthServerURL:         strings.TrimSuffix(i.Spec.Security.EndpointOIDC.AuthServerURL, "/"),
+			ClientID:              i.Spec.Security.EndpointOIDC.ClientID,
+			IntrospectionClientID: configFiles.OIDC.ClientID,
+			PrincipalClaim:        i.GetOIDCPrincipalClaim(),
+		}
+	}
+	if i.IsServiceAccountAuthEnabled() {
+		configSpec.Endpoints.TokenReview = &config.TokenReview{
+			IntrospectionURL: fmt.Sprintf("http://%s:%d%s", consts.TokenReviewHost, consts.TokenReviewPort, consts.TokenReviewIntrospectionPath),
+			PrincipalClaim:   consts.TokenReviewPrincipalClaim,
+		}
+	}
+	if i.HasCredentials() {
+		configSpec.Credentials = CredentialAliases(configFiles)
+	}
+	if listeners := i.ClientListeners(); listeners != nil {
+		configSpec.Infinispan.ListenerExecutor = &config.ThreadPool{
+			MaxThreads:  consts.ClientListenerMaxThreads,
+			QueueLength: consts.ClientListenerQueueLength,
+		}
+		if listeners.MaxThreads > 0 {
+			configSpec.Infinispan.ListenerExecutor.MaxThreads = listeners.MaxThreads
+		}
+		if listeners.QueueLength > 0 {
+			configSpec.Infinispan.ListenerExecutor.QueueLength = listeners.QueueLength
+		}
+		configSpec.Endpoints.HotRod.SendBufferSize = listeners.SendBufferSize
+	}
+	// Sidecars can only be bypassed for the FD_SOCK connections if the port is known in advance
+	if i.IsServiceMeshEnabled() {
+		configSpec.JGroups.FDSockPort = consts.JGroupsFDSockPort
+	}
 	// Save the spec for later so that we can reuse it for HR rolling upgrades
 	ctx.ConfigFiles().ConfigSpec = *configSpec
 
@@ -92,17 +143,16 @@ func InfinispanServer(i *ispnv1.Infinispan, ctx pipeline.Context) {
 			ks := configFiles.Transport.Keystore
 			tlsConfig := config.TransportTLS{
 				Enabled: true,
+				// The passwords are added to the credential store by the identities batch
 				KeyStore: config.Keystore{
-					Alias:    ks.Alias,
-					Password: ks.Password,
-					Path:     ks.Path,
+					Alias: ks.Alias,
+					Path:  ks.Path,
 				},
 			}
 			ts := configFiles.Transport.Truststore
 			if ts != nil {
 				tlsConfig.TrustStore = config.Truststore{
-					Path:     ts.Path,
-					Password: ts.Password,
+					Path: ts.Path,
 				}
 			}
 			configSpec.Transport.TLS = tlsConfig
@@ -137,6 +187,10 @@ func InfinispanServer(i *ispnv1.Infinispan, ctx pipeline.Context) {
 			Password: ks.Password,
 			Path:     ks.Path,
 		}
+		if i.IsVaultEnabled() && len(ks.File) > 0 {
+			configSpec.Keystore.PasswordProperty = consts.VaultKeystorePasswordProperty
+		}
+		configSpec.Endpoints.TLS = endpointTLS(i.Spec.Security.EndpointEncryption)
 
 		if i.IsClientCertEnabled() {
 			configSpec.Endpoints.ClientCert = string(i.Spec.Security.EndpointEncryption.ClientCert)
@@ -160,10 +214,28 @@ func InfinispanServer(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	}
 }
 
+// endpointConnector returns the connector of a protocol, with a dedicated port if it does not use the user port
+func endpointConnector(enabled bool, port int32) config.Connector {
+	connector := config.Connector{Disabled: !enabled}
+	if port != consts.InfinispanUserPort {
+		connector.Port = port
+	}
+	return connector
+}
+
 func Logging(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	loggingSpec := &logging.Spec{
 		Categories: i.GetLogCategoriesForConfig(),
 	}
+	if i.IsAuditLogEnabled() {
+		loggingSpec.Audit = &logging.Audit{
+			Category: consts.AuditLogCategory,
+			Level:    i.GetAuditLogLevel(),
+		}
+		if i.GetAuditLogSink() == ispnv1.AuditLogSinkFile {
+			loggingSpec.Audit.Filename = consts.AuditLogFilename
+		}
+	}
 	// TODO utilise a version specific logging once server/operator versions decoupled
 	log4jXml, err := logging.Generate(nil, loggingSpec)
 	if err != nil {
@@ -172,3 +244,27 @@ func Logging(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	}
 	ctx.ConfigFiles().Log4j = log4jXml
 }
+
+// endpointTLS returns the TLS engine configuration of the endpoints, or nil if the server defaults are used
+func endpointTLS(ee *ispnv1.EndpointEncryption) *config.EndpointTLS {
+	if len(ee.Protocols) == 0 && len(ee.CipherSuites) == 0 {
+		return nil
+	}
+	protocols := make([]string, len(ee.Protocols))
+	for idx, protocol := range ee.Protocols {
+		protocols[idx] = string(protocol)
+	}
+	var cipherSuites, cipherSuitesTLS13 []string
+	for _, cipherSuite := range ee.CipherSuites {
+		if ispnv1.IsTLS13CipherSuite(cipherSuite) {
+			cipherSuitesTLS13 = append(cipherSuitesTLS13, cipherSuite)
+		} else {
+			cipherSuites = append(cipherSuites, cipherSuite)
+		}
+	}
+	return &config.EndpointTLS{
+		Protocols:         strings.Join(protocols, " "),
+		CipherSuites:      strings.Join(cipherSuites, ":"),
+		CipherSuitesTLS13: strings.Join(cipherSuitesTLS13, ":"),
+	}
+}
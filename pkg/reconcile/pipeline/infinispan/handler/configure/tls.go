@@ -2,20 +2,193 @@ package configure
 
 import (
 	"fmt"
+	"net"
+	"reflect"
+	"sort"
 	"strings"
+	"time"
 
 	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
 	consts "github.com/infinispan/infinispan-operator/controllers/constants"
 	"github.com/infinispan/infinispan-operator/pkg/infinispan/security"
 	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	routev1 "github.com/openshift/api/route/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
 	EncryptPkcs12KeystoreName = "keystore.p12"
+	EncryptJksKeystoreName    = "keystore.jks"
 	EncryptPemKeystoreName    = "keystore.pem"
+
+	// The validity of the CA and certificates issued by the operator. Certificates are reissued once less than
+	// certRenewBefore of their validity remains
+	caValidity      = 10 * 365 * 24 * time.Hour
+	certValidity    = 365 * 24 * time.Hour
+	certRenewBefore = 30 * 24 * time.Hour
 )
 
+// ServingCertificate issues the certificate of the endpoint if spec.security.endpointEncryption.certServiceName is
+// infinispan.org. The certificate is signed by a CA that is created once per cluster and is valid for all hostnames that
+// clients use to connect to the cluster, including the external hostnames, so that clients do not have to disable
+// hostname verification. The certificate is reissued when the hostnames change or it is about to expire, and is then
+// applied to the running servers by manage.CertificateRotation
+func ServingCertificate(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      i.GetCertCASecretName(),
+			Namespace: i.Namespace,
+		},
+	}
+	mutateCA := func() error {
+		caSecret.Labels = i.Labels("infinispan-secret-cert-ca")
+		if len(caSecret.Data[corev1.TLSCertKey]) > 0 && len(caSecret.Data[corev1.TLSPrivateKeyKey]) > 0 {
+			return nil
+		}
+		cert, key, err := security.GenerateCA(fmt.Sprintf("%s.%s CA", i.Name, i.Namespace), caValidity)
+		if err != nil {
+			return err
+		}
+		if caSecret.CreationTimestamp.IsZero() {
+			caSecret.Type = corev1.SecretTypeTLS
+		}
+		caSecret.Data = map[string][]byte{
+			corev1.TLSCertKey:       cert,
+			corev1.TLSPrivateKeyKey: key,
+		}
+		return nil
+	}
+	if _, err := ctx.Resources().CreateOrUpdate(caSecret, true, mutateCA, pipeline.RetryOnErr); err != nil {
+		return
+	}
+	caCert := caSecret.Data[corev1.TLSCertKey]
+
+	hostnames, err := certificateHostnames(i, ctx)
+	if err != nil {
+		return
+	}
+
+	certSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      i.GetKeystoreSecretName(),
+			Namespace: i.Namespace,
+		},
+	}
+	mutateCert := func() error {
+		certSecret.Labels = i.Labels("infinispan-secret-cert")
+		if certSecret.CreationTimestamp.IsZero() {
+			certSecret.Type = corev1.SecretTypeTLS
+		}
+		if certSecret.Data == nil {
+			certSecret.Data = map[string][]byte{}
+		}
+		if isCertificateValid(certSecret.Data[corev1.TLSCertKey], caCert, hostnames) {
+			certSecret.Data[consts.EncryptCAKey] = caCert
+			return nil
+		}
+		cert, key, err := security.GenerateServingCertificate(caCert, caSecret.Data[corev1.TLSPrivateKeyKey], hostnames[0], hostnames, certValidity)
+		if err != nil {
+			return err
+		}
+		ctx.Log().Info("Issuing endpoint certificate", "secret", certSecret.Name, "hostnames", hostnames)
+		certSecret.Data[corev1.TLSCertKey] = cert
+		certSecret.Data[corev1.TLSPrivateKeyKey] = key
+		certSecret.Data[consts.EncryptCAKey] = caCert
+		return nil
+	}
+	if _, err := ctx.Resources().CreateOrUpdate(certSecret, true, mutateCert, pipeline.RetryOnErr); err != nil {
+		return
+	}
+
+	ctx.ConfigFiles().Keystore = &pipeline.Keystore{
+		Path:    consts.ServerOperatorSecurity + "/" + EncryptPemKeystoreName,
+		PemFile: append(certSecret.Data[corev1.TLSPrivateKeyKey], certSecret.Data[corev1.TLSCertKey]...),
+		CA:      caCert,
+	}
+}
+
+// certificateHostnames returns the sorted hostnames of the certificate issued by the operator. In addition to the
+// hostnames configured in the Infinispan CR, the hostnames assigned by the platform are included once they are known:
+// the host generated for a Route, the address of a LoadBalancer and the addresses of the Services of individual pods
+func certificateHostnames(i *ispnv1.Infinispan, ctx pipeline.Context) ([]string, error) {
+	hostnames := i.EndpointCertificateHostnames()
+	addRouteHost := func(name string) error {
+		route := &routev1.Route{}
+		if err := ctx.Resources().Load(name, route, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+			return err
+		}
+		if route.Spec.Host != "" {
+			hostnames = append(hostnames, route.Spec.Host)
+		}
+		return nil
+	}
+
+	if i.IsExposed() {
+		switch i.GetExposeType() {
+		case ispnv1.ExposeTypeLoadBalancer:
+			svc := &corev1.Service{}
+			if err := ctx.Resources().Load(i.GetServiceExternalName(), svc, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+				return nil, err
+			}
+			for _, ingress := range svc.Status.LoadBalancer.Ingress {
+				if ingress.Hostname != "" {
+					hostnames = append(hostnames, ingress.Hostname)
+				} else if ingress.IP != "" {
+					hostnames = append(hostnames, ingress.IP)
+				}
+			}
+		case ispnv1.ExposeTypeRoute:
+			if i.Spec.Expose.Host == "" && ctx.IsTypeSupported(pipeline.RouteGVK) {
+				if err := addRouteHost(i.GetServiceExternalName()); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if i.HasConsoleExternalService() && i.Spec.Endpoints.Console.Expose.Host == "" && i.GetConsoleExposeType() == ispnv1.ConsoleExposeTypeRoute && ctx.IsTypeSupported(pipeline.RouteGVK) {
+		if err := addRouteHost(i.GetConsoleExternalName()); err != nil {
+			return nil, err
+		}
+	}
+	for _, address := range i.Status.PodPublicAddresses {
+		if host, _, err := net.SplitHostPort(address.Address); err == nil {
+			hostnames = append(hostnames, host)
+		}
+	}
+	return uniqueSorted(hostnames), nil
+}
+
+// uniqueSorted returns the first hostname, which is the common name of the certificate, followed by the remaining
+// hostnames sorted and without duplicates
+func uniqueSorted(hostnames []string) []string {
+	seen := map[string]struct{}{hostnames[0]: {}}
+	var rest []string
+	for _, h := range hostnames[1:] {
+		if _, exists := seen[h]; !exists {
+			seen[h] = struct{}{}
+			rest = append(rest, h)
+		}
+	}
+	sort.Strings(rest)
+	return append([]string{hostnames[0]}, rest...)
+}
+
+// isCertificateValid returns true if the certificate is signed by the CA, is valid for exactly the provided hostnames
+// and does not expire soon
+func isCertificateValid(cert, caCert []byte, hostnames []string) bool {
+	if len(cert) == 0 || !security.IsCertificateSignedBy(cert, caCert) {
+		return false
+	}
+	certHostnames, notAfter, err := security.CertificateHostnames(cert)
+	if err != nil || time.Until(notAfter) < certRenewBefore {
+		return false
+	}
+	expected := append([]string{}, hostnames...)
+	sort.Strings(expected)
+	return reflect.DeepEqual(certHostnames, expected)
+}
+
 func Keystore(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	keystore := &pipeline.Keystore{}
 	if i.IsEncryptionCertFromService() {
@@ -37,20 +210,134 @@ func Keystore(i *ispnv1.Infinispan, ctx pipeline.Context) {
 			return true
 		}
 
-		if userKeystore, exists := keystoreSecret.Data[EncryptPkcs12KeystoreName]; exists {
+		spec := i.Spec.Security.EndpointEncryption.Keystore
+		filename := keystoreFilename(spec, keystoreSecret)
+		if userKeystore, exists := keystoreSecret.Data[filename]; exists {
 			// If the user provides a keystore in secret then use it ...
-			keystore.Path = fmt.Sprintf("%s/%s", consts.ServerEncryptKeystoreRoot, EncryptPkcs12KeystoreName)
-			keystore.Alias = string(keystoreSecret.Data["alias"])
-			keystore.Password = string(keystoreSecret.Data["password"])
+			passwordKey, alias := "password", string(keystoreSecret.Data["alias"])
+			if spec != nil && spec.PasswordKey != "" {
+				passwordKey = spec.PasswordKey
+			}
+			if spec != nil && spec.Alias != "" {
+				alias = spec.Alias
+			}
+			// With Vault, the servers read the password from server.properties instead
+			password, exists := keystoreSecret.Data[passwordKey]
+			if !exists && spec != nil && spec.PasswordKey != "" && !i.IsVaultEnabled() {
+				ctx.Requeue(fmt.Errorf("the '%s' key of the keystore password is missing from Secret '%s'", passwordKey, keystoreSecret.Name))
+				return
+			}
+			keystore.Path = fmt.Sprintf("%s/%s", consts.ServerEncryptKeystoreRoot, filename)
+			keystore.Alias = alias
+			if !i.IsVaultEnabled() {
+				keystore.Password = string(password)
+			}
 			keystore.File = userKeystore
+			keystore.Type = strings.ToLower(string(keystoreType(spec, filename)))
+		} else if spec != nil && spec.Filename != "" {
+			ctx.Requeue(fmt.Errorf("the '%s' key of the keystore is missing from Secret '%s'", filename, keystoreSecret.Name))
+			return
 		} else if isUserProvidedPrivateKey() {
 			keystore.Path = consts.ServerOperatorSecurity + "/" + EncryptPemKeystoreName
 			keystore.PemFile = append(keystoreSecret.Data["tls.key"], keystoreSecret.Data["tls.crt"]...)
 		}
+		keystore.CA = keystoreSecret.Data[consts.EncryptCAKey]
 	}
 	ctx.ConfigFiles().Keystore = keystore
 }
 
+// keystoreFilename returns the key of the keystore in the Secret. Without a configured filename, keystore.jks is used if
+// the Secret does not contain keystore.p12
+func keystoreFilename(spec *ispnv1.EndpointKeystore, secret *corev1.Secret) string {
+	if spec != nil && spec.Filename != "" {
+		return spec.Filename
+	}
+	if _, exists := secret.Data[EncryptPkcs12KeystoreName]; !exists {
+		if _, exists := secret.Data[EncryptJksKeystoreName]; exists {
+			return EncryptJksKeystoreName
+		}
+	}
+	return EncryptPkcs12KeystoreName
+}
+
+// keystoreType returns the configured format of the keystore, or the format implied by the extension of the filename
+func keystoreType(spec *ispnv1.EndpointKeystore, filename string) ispnv1.KeystoreType {
+	if spec != nil && spec.Type != "" {
+		return spec.Type
+	}
+	if strings.HasSuffix(strings.ToLower(filename), ".jks") {
+		return ispnv1.KeystoreTypeJKS
+	}
+	return ispnv1.KeystoreTypePKCS12
+}
+
+// CABundle aggregates the CA certificates that clients must trust in order to verify the certificates presented by the
+// Infinispan pods. The bundle applies to the cluster Services and to passthrough Routes, it does not apply to Ingresses
+// which terminate TLS with the default certificate of the ingress controller.
+func CABundle(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	var pemFiles [][]byte
+	if i.IsEncryptionCertFromService() && !i.IsEncryptionCertFromOperator() {
+		if strings.Contains(i.Spec.Security.EndpointEncryption.CertServiceName, "openshift.io") {
+			serviceCA := &corev1.ConfigMap{}
+			if err := ctx.Resources().Load(consts.ServiceCABundleConfigMapName, serviceCA, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+				return
+			}
+			if ca, exists := serviceCA.Data[consts.ServiceCABundleKey]; exists {
+				pemFiles = append(pemFiles, []byte(ca))
+			}
+		}
+	} else {
+		// Prefer an explicit CA if provided, otherwise clients must trust the certificate chain itself
+		keystore := ctx.ConfigFiles().Keystore
+		if len(keystore.CA) > 0 {
+			pemFiles = append(pemFiles, keystore.CA)
+		} else if len(keystore.PemFile) > 0 {
+			pemFiles = append(pemFiles, keystore.PemFile)
+		} else if len(keystore.File) > 0 && i.IsVaultEnabled() {
+			ctx.Log().Info(fmt.Sprintf("The password of the keystore is stored in Vault, add the CA certificates to the '%s' key of the keystore Secret", consts.EncryptCAKey))
+		} else if len(keystore.File) > 0 {
+			jks := keystore.Type == strings.ToLower(string(ispnv1.KeystoreTypeJKS))
+			certs, err := security.GetKeystoreCertificates(keystore.File, keystore.Password, jks)
+			if err != nil {
+				keepCABundle(i, ctx, fmt.Errorf("unable to read the certificates of the user provided keystore: %w", err))
+				return
+			}
+			pemFiles = append(pemFiles, certs)
+		}
+	}
+
+	caBundle := security.GenerateCABundle(pemFiles)
+	if len(caBundle) == 0 {
+		ctx.Log().Info(fmt.Sprintf("No CA certificates available for ConfigMap '%s'", i.GetCABundleConfigMapName()))
+	}
+	ctx.ConfigFiles().CABundle = caBundle
+	if i.IsConditionTrue(ispnv1.ConditionCABundleFailed) {
+		_ = ctx.UpdateInfinispan(func() {
+			i.SetCondition(ispnv1.ConditionCABundleFailed, metav1.ConditionFalse, "")
+		})
+	}
+}
+
+// keepCABundle keeps the published CA bundle when the certificates of the keystore can't be read, so that clients are
+// still able to verify the certificates of the pods, reports the error with the CABundleFailed condition and retries
+func keepCABundle(i *ispnv1.Infinispan, ctx pipeline.Context, err error) {
+	ctx.Log().Error(err, fmt.Sprintf("keeping the existing ConfigMap '%s'", i.GetCABundleConfigMapName()))
+	configMap := &corev1.ConfigMap{}
+	if err := ctx.Resources().Load(i.GetCABundleConfigMapName(), configMap, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+		return
+	}
+	ctx.ConfigFiles().CABundle = []byte(configMap.Data[consts.EncryptCAKey])
+
+	if condition := i.GetCondition(ispnv1.ConditionCABundleFailed); condition.Status != metav1.ConditionTrue || condition.Message != err.Error() {
+		if err := ctx.UpdateInfinispan(func() {
+			i.SetCondition(ispnv1.ConditionCABundleFailed, metav1.ConditionTrue, err.Error())
+		}); err != nil {
+			return
+		}
+	}
+	ctx.RequeueEventually(consts.DefaultWaitOnCluster)
+}
+
 func Truststore(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	trustSecret := &corev1.Secret{}
 	if err := ctx.Resources().Load(i.GetTruststoreSecretName(), trustSecret, pipeline.RetryOnErr); err != nil {
@@ -0,0 +1,73 @@
+package configure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// testResources loads the ConfigMaps of the test
+type testResources struct {
+	pipeline.Resources
+	configMaps map[string]*corev1.ConfigMap
+}
+
+func (r *testResources) Load(name string, obj client.Object, _ ...func(config *pipeline.ResourcesConfig)) error {
+	if configMap, ok := r.configMaps[name]; ok {
+		configMap.DeepCopyInto(obj.(*corev1.ConfigMap))
+	}
+	return nil
+}
+
+// testContext implements the subset of pipeline.Context used by the configure handlers
+type testContext struct {
+	pipeline.Context
+	configFiles  *pipeline.ConfigFiles
+	resources    *testResources
+	requeueAfter *time.Duration
+}
+
+func (c *testContext) ConfigFiles() *pipeline.ConfigFiles    { return c.configFiles }
+func (c *testContext) Resources() pipeline.Resources         { return c.resources }
+func (c *testContext) Log() logr.Logger                      { return ctrl.Log }
+func (c *testContext) UpdateInfinispan(update func()) error  { update(); return nil }
+func (c *testContext) RequeueEventually(delay time.Duration) { c.requeueAfter = &delay }
+
+func TestCABundleKeystoreDecodeError(t *testing.T) {
+	i := &ispnv1.Infinispan{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan", Namespace: "testing-namespace"},
+		Spec: ispnv1.InfinispanSpec{Security: ispnv1.InfinispanSecurity{
+			EndpointEncryption: &ispnv1.EndpointEncryption{Type: ispnv1.CertificateSourceTypeSecret, CertSecretName: "keystore"},
+		}},
+	}
+	published := &corev1.ConfigMap{Data: map[string]string{consts.EncryptCAKey: "-----BEGIN CERTIFICATE-----"}}
+	ctx := &testContext{
+		configFiles: &pipeline.ConfigFiles{Keystore: &pipeline.Keystore{File: []byte("not a keystore"), Password: "password", Type: "pkcs12"}},
+		resources:   &testResources{configMaps: map[string]*corev1.ConfigMap{i.GetCABundleConfigMapName(): published}},
+	}
+
+	// The published bundle is kept, so that the ConfigMap isn't deleted, and the error is reported
+	CABundle(i, ctx)
+	assert.Equal(t, []byte("-----BEGIN CERTIFICATE-----"), ctx.configFiles.CABundle)
+	assert.True(t, i.IsConditionTrue(ispnv1.ConditionCABundleFailed))
+	assert.Contains(t, i.GetCondition(ispnv1.ConditionCABundleFailed).Message, "unable to read the certificates")
+	if assert.NotNil(t, ctx.requeueAfter) {
+		assert.Equal(t, consts.DefaultWaitOnCluster, *ctx.requeueAfter)
+	}
+
+	// The condition is cleared once the certificates are available
+	ctx.configFiles.Keystore = &pipeline.Keystore{CA: []byte("-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n")}
+	ctx.requeueAfter = nil
+	CABundle(i, ctx)
+	assert.False(t, i.IsConditionTrue(ispnv1.ConditionCABundleFailed))
+	assert.Nil(t, ctx.requeueAfter)
+}
@@ -5,9 +5,12 @@ import (
 	"net"
 	"net/url"
 	"strconv"
+	"strings"
 
 	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
 	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/hash"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/security"
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
 	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
 	routev1 "github.com/openshift/api/route/v1"
@@ -75,8 +78,13 @@ func searchRemoteSites(i *ispnv1.Infinispan, ctx pipeline.Context, xSite *pipeli
 				return fmt.Errorf("unable to link the cross-site service with itself. clusterName '%s' or namespace '%s' for remote location '%s' should be different from the original cluster name or namespace",
 					clusterName, namespace, remoteName)
 			}
-			// Add cross-site FQN service name inside the same k8s cluster
-			appendBackupSite(remoteName, i.GetRemoteSiteServiceFQN(remoteName), 0, xSite)
+			if i.GetCrossSiteExposeType() == ispnv1.CrossSiteExposeTypeClusterSet {
+				// Add cross-site FQN of the service exported to the ClusterSet by the remote site
+				appendBackupSite(remoteName, i.GetRemoteSiteServiceClusterSetFQN(remoteName), 0, xSite)
+			} else {
+				// Add cross-site FQN service name inside the same k8s cluster
+				appendBackupSite(remoteName, i.GetRemoteSiteServiceFQN(remoteName), 0, xSite)
+			}
 		} else if backupSiteURL.Scheme == consts.StaticCrossSiteUriSchema {
 			port, _ := strconv.ParseInt(backupSiteURL.Port(), 10, 32)
 			appendBackupSite(remoteName, backupSiteURL.Hostname(), int32(port), xSite)
@@ -263,6 +271,7 @@ func TransportTLS(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	configFiles := ctx.ConfigFiles()
 	configFiles.Transport.Keystore = &pipeline.Keystore{
 		Alias:    i.GetSiteTransportKeyStoreAlias(),
+		File:     keyStoreSecret.Data[keyStoreFileName],
 		Password: string(keyStoreSecret.Data["password"]),
 		Path:     fmt.Sprintf("%s/%s", consts.SiteTransportKeyStoreRoot, keyStoreFileName),
 		Type:     consts.GetWithDefault(string(keyStoreSecret.Data["type"]), "pkcs12"),
@@ -294,6 +303,66 @@ func TransportTLS(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	}
 }
 
+// RelayTruststore assembles the truststore of the transport and the Gossip Router from the certificates of the site
+// truststore and the CA certificates of the remote site locations. As every new truststore restarts the servers and the
+// Gossip Router, the truststore is only regenerated when the certificates it is assembled from change
+func RelayTruststore(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	transport := &ctx.ConfigFiles().Transport
+
+	var pemFiles [][]byte
+	if ts := transport.Truststore; ts != nil {
+		certs, err := security.GetTruststoreCertificates(ts.File, ts.Password, strings.EqualFold(ts.Type, "jks"))
+		if err != nil {
+			ctx.Stop(fmt.Errorf("unable to read the certificates of the Truststore stored in Secret %s: %w", i.GetSiteTrustoreSecretName(), err))
+			return
+		}
+		pemFiles = append(pemFiles, certs)
+	}
+	for _, secretName := range i.GetSiteCASecretNames() {
+		secret := &corev1.Secret{}
+		if err := ctx.Resources().Load(secretName, secret, pipeline.RetryOnErr); err != nil {
+			return
+		}
+		ca := security.GenerateCABundle([][]byte{secret.Data[consts.EncryptCAKey]})
+		if len(ca) == 0 {
+			ctx.Stop(fmt.Errorf("a PEM encoded '%s' is required for the site CA stored in Secret %s", consts.EncryptCAKey, secretName))
+			return
+		}
+		pemFiles = append(pemFiles, ca)
+	}
+	certs := security.GenerateCABundle(pemFiles)
+	certsHash := hash.HashByte(certs)
+
+	secret := &corev1.Secret{}
+	if err := ctx.Resources().Load(i.GetSiteRelayTrustStoreSecretName(), secret, pipeline.SkipEventRec); err != nil && !errors.IsNotFound(err) {
+		ctx.Requeue(err)
+		return
+	}
+	truststore := secret.Data[consts.DefaultSiteTrustStoreFileName]
+	password := string(secret.Data["password"])
+	if len(truststore) == 0 || password == "" || secret.Annotations[consts.AnnotationRelayTrustStoreHash] != certsHash {
+		var err error
+		if password == "" {
+			if password, err = security.GeneratePassword(); err != nil {
+				ctx.Requeue(err)
+				return
+			}
+		}
+		if truststore, err = security.GenerateTruststore([][]byte{certs}, password); err != nil {
+			ctx.Stop(err)
+			return
+		}
+		ctx.Log().Info("Relay Truststore assembled.", "Secret Name", i.GetSiteRelayTrustStoreSecretName(), "CA Secrets", i.GetSiteCASecretNames())
+	}
+	transport.Truststore = &pipeline.Truststore{
+		File:     truststore,
+		Password: password,
+		Path:     fmt.Sprintf("%s/%s", consts.SiteTrustStoreRoot, consts.DefaultSiteTrustStoreFileName),
+		Type:     "pkcs12",
+	}
+	transport.RelayTruststoreHash = certsHash
+}
+
 func GossipRouterTLS(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	keyStoreSecret := &corev1.Secret{}
 	if err := ctx.Resources().Load(i.GetSiteRouterSecretName(), keyStoreSecret, pipeline.RetryOnErr); err != nil {
@@ -316,6 +385,7 @@ func GossipRouterTLS(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	gossipRouter := &configFiles.XSite.GossipRouter
 	gossipRouter.Keystore = &pipeline.Keystore{
 		Alias:    alias,
+		File:     keyStoreSecret.Data[filename],
 		Password: password,
 		Path:     fmt.Sprintf("%s/%s", consts.SiteRouterKeyStoreRoot, filename),
 		Type:     consts.GetWithDefault(string(keyStoreSecret.Data["type"]), "pkcs12"),
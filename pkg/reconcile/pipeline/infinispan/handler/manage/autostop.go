@@ -0,0 +1,149 @@
+package manage
+
+import (
+	"fmt"
+	"time"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/pkg/cron"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	EventReasonAutostop = "Autostop"
+
+	// autostopLookback the maximum period searched for schedules that fired whilst the operator was unavailable
+	autostopLookback = 31 * 24 * time.Hour
+)
+
+type autostopTransition int
+
+const (
+	autostopNone autostopTransition = iota
+	autostopHibernate
+	autostopWake
+)
+
+// Autostop gracefully shuts the cluster down when spec.autostop.schedule fires and restarts it with the same number of
+// replicas when spec.autostop.wakeSchedule fires. The cluster is shut down by setting spec.replicas=0, so that the
+// GracefulShutdown handler preserves the state of the cluster exactly as if the user had scaled it down
+func Autostop(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	// Upgrades also scale the cluster down, so the schedules are applied once the upgrade has completed
+	if i.IsUpgradeCondition() {
+		return
+	}
+
+	// The schedules and time zone are validated by the webhook
+	loc, err := i.AutostopLocation()
+	if err != nil {
+		ctx.Stop(err)
+		return
+	}
+	stop, err := cron.Parse(i.Spec.Autostop.Schedule)
+	if err != nil {
+		ctx.Stop(err)
+		return
+	}
+	wake, err := cron.Parse(i.Spec.Autostop.WakeSchedule)
+	if err != nil {
+		ctx.Stop(err)
+		return
+	}
+
+	now := time.Now().In(loc)
+	since := i.CreationTimestamp.Time
+	var hibernated bool
+	if status := i.Status.Autostop; status != nil {
+		since = status.LastTransitionTime.Time
+		hibernated = status.Hibernated
+	}
+
+	if hibernated && i.Spec.Replicas != 0 {
+		ctx.Log().Info("Cluster woken before the autostop wake schedule")
+		ctx.Requeue(
+			ctx.UpdateInfinispan(func() {
+				i.Status.Autostop = &ispnv1.AutostopStatus{LastTransitionTime: metav1.Now()}
+			}),
+		)
+		return
+	}
+
+	switch latestTransition(stop, wake, since.In(loc), now) {
+	case autostopHibernate:
+		if i.Spec.Replicas == 0 {
+			// The cluster has already been shut down by the user, so it must not be woken by the schedule
+			ctx.Requeue(
+				ctx.UpdateInfinispan(func() {
+					i.Status.Autostop = &ispnv1.AutostopStatus{LastTransitionTime: metav1.Now()}
+				}),
+			)
+			return
+		}
+		replicas := i.Spec.Replicas
+		ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonAutostop, fmt.Sprintf("Hibernating cluster with %d replicas", replicas))
+		ctx.Requeue(
+			ctx.UpdateInfinispan(func() {
+				i.Status.Autostop = &ispnv1.AutostopStatus{
+					Hibernated:         true,
+					Replicas:           replicas,
+					LastTransitionTime: metav1.Now(),
+				}
+				i.Spec.Replicas = 0
+			}),
+		)
+		return
+	case autostopWake:
+		replicas := i.Status.ReplicasWantedAtRestart
+		if replicas == 0 && i.Status.Autostop != nil {
+			replicas = i.Status.Autostop.Replicas
+		}
+		if hibernated {
+			ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonAutostop, fmt.Sprintf("Waking cluster with %d replicas", replicas))
+		}
+		ctx.Requeue(
+			ctx.UpdateInfinispan(func() {
+				i.Status.Autostop = &ispnv1.AutostopStatus{LastTransitionTime: metav1.Now()}
+				if hibernated {
+					i.Spec.Replicas = replicas
+				}
+			}),
+		)
+		return
+	}
+
+	next := stop.Next(now)
+	if wakeNext := wake.Next(now); next.IsZero() || (!wakeNext.IsZero() && wakeNext.Before(next)) {
+		next = wakeNext
+	}
+	if !next.IsZero() {
+		requeuePeriodic(ctx, time.Until(next))
+	}
+}
+
+// latestTransition returns the transition of the schedule that fired most recently after since and not after now. If
+// both schedules fired at the same time, the cluster is woken
+func latestTransition(stop, wake *cron.Schedule, since, now time.Time) autostopTransition {
+	if lookback := now.Add(-autostopLookback); since.Before(lookback) {
+		since = lookback
+	}
+	lastStop := lastFiring(stop, since, now)
+	lastWake := lastFiring(wake, since, now)
+	switch {
+	case lastStop.IsZero() && lastWake.IsZero():
+		return autostopNone
+	case lastStop.After(lastWake):
+		return autostopHibernate
+	default:
+		return autostopWake
+	}
+}
+
+func lastFiring(s *cron.Schedule, since, now time.Time) time.Time {
+	var last time.Time
+	for next := s.Next(since); !next.IsZero() && !next.After(now); next = s.Next(next) {
+		last = next
+	}
+	return last
+}
@@ -0,0 +1,40 @@
+package manage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/infinispan/infinispan-operator/pkg/cron"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestTransition(t *testing.T) {
+	stop, err := cron.Parse("0 20 * * 1-5")
+	require.NoError(t, err)
+	wake, err := cron.Parse("0 7 * * 1-5")
+	require.NoError(t, err)
+
+	// Monday
+	monday := func(hour int) time.Time {
+		return time.Date(2022, time.March, 7, hour, 0, 0, 0, time.UTC)
+	}
+	testTable := []struct {
+		name     string
+		since    time.Time
+		now      time.Time
+		expected autostopTransition
+	}{
+		{"no schedule fired", monday(8), monday(19), autostopNone},
+		{"stop fired", monday(8), monday(21), autostopHibernate},
+		{"wake fired", monday(21), monday(8).AddDate(0, 0, 1), autostopWake},
+		{"weekend", monday(21).AddDate(0, 0, 4), monday(21).AddDate(0, 0, 6), autostopNone},
+		{"missed stop and wake", monday(8), monday(8).AddDate(0, 0, 1), autostopWake},
+		{"lookback", monday(21).AddDate(-1, 0, 0), monday(21), autostopHibernate},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, latestTransition(stop, wake, tt.since, tt.now))
+		})
+	}
+}
@@ -69,6 +69,11 @@ func DefaultCacheTemplateXML(podName string, infinispan *ispnv1.Infinispan, k8 *
 	nativeMemoryOverhead := containerMaxMemory * (consts.CacheServiceJvmNativePercentageOverhead / 100)
 	evictTotalMemoryBytes := containerMaxMemory - (consts.CacheServiceJvmNativeMb * 1024 * 1024) - (consts.CacheServiceFixedMemoryXmxMb * 1024 * 1024) - nativeMemoryOverhead
 	replicationFactor := infinispan.Spec.Service.ReplicationFactor
+	if replicationFactor == 0 {
+		// The replication factor is only defaulted for the Cache service, the default cache of a dev mode DataGrid
+		// cluster is stored on its single pod
+		replicationFactor = 1
+	}
 
 	logger.Info("calculated maximum off-heap size", "size", evictTotalMemoryBytes, "container max memory", containerMaxMemory, "memory limit (bytes)", memoryLimitBytes, "max memory bound", maxUnboundedMemory)
 
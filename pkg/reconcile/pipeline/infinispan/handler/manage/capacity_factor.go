@@ -0,0 +1,58 @@
+package manage
+
+import (
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// CapacityFactors sets the infinispan.org/capacity-factor annotation of each scheduled pod to the capacity factor of
+// the zone of its node. The pods of all StatefulSets of the cluster are annotated, so that the pods created by a Hot Rod
+// rolling upgrade are not blocked by the capacity-factor init container
+func CapacityFactors(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	podList := &corev1.PodList{}
+	if err := ctx.Resources().List(i.PodSelectorLabels(), podList, pipeline.RetryOnErr); err != nil {
+		return
+	}
+
+	zones := map[string]string{}
+	topologyKey := i.CapacityFactorTopologyKey()
+	for _, pod := range podList.Items {
+		nodeName := pod.Spec.NodeName
+		if nodeName == "" {
+			// The zone of the pod is not known until it is scheduled
+			continue
+		}
+		zone, ok := zones[nodeName]
+		if !ok {
+			node := &corev1.Node{}
+			if err := ctx.Resources().LoadGlobal(nodeName, node, pipeline.RetryOnErr); err != nil {
+				return
+			}
+			zone = node.Labels[topologyKey]
+			zones[nodeName] = zone
+		}
+
+		factor := i.CapacityFactorForZone(zone)
+		if pod.Annotations[consts.AnnotationCapacityFactor] == factor {
+			continue
+		}
+		pod := pod
+		mutateFn := func() error {
+			if pod.CreationTimestamp.IsZero() {
+				return errors.NewNotFound(corev1.Resource(""), pod.Name)
+			}
+			if pod.Annotations == nil {
+				pod.Annotations = map[string]string{}
+			}
+			pod.Annotations[consts.AnnotationCapacityFactor] = factor
+			return nil
+		}
+		if _, err := ctx.Resources().CreateOrPatch(&pod, false, mutateFn, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+			return
+		}
+		ctx.Log().Info("Capacity factor of pod updated", "pod", pod.Name, "zone", zone, "capacityFactor", factor)
+	}
+}
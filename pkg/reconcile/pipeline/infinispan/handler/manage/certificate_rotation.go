@@ -0,0 +1,189 @@
+package manage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/hash"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	EventReasonCertificateRotated  = "CertificateRotated"
+	EventReasonCertificateUpdating = "CertificateUpdating"
+
+	// keystoreSyncDelay is how long to wait after a Secret is modified before the mounted files of all pods can be
+	// expected to be up to date. The kubelet refreshes mounted Secrets within its sync period plus the TTL of its Secret
+	// cache, one minute each by default
+	keystoreSyncDelay = 2 * time.Minute
+)
+
+// CertificateRotation applies renewed endpoint certificates to the running servers without downtime. Once the files of
+// the mounted Secrets have been updated, every server started with the previous certificates reloads them via REST and
+// the hash of the certificates is recorded in the infinispan.org/keystore-hash annotation of the pod. If the server
+// cannot reload certificates, the pods are restarted one at a time by PartitionedRollout instead
+func CertificateRotation(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	certSecret := &corev1.Secret{}
+	if err := ctx.Resources().Load(i.GetKeystoreSecretName(), certSecret, pipeline.RetryOnErr); err != nil {
+		return
+	}
+	securitySecret := &corev1.Secret{}
+	if err := ctx.Resources().Load(i.GetInfinispanSecuritySecretName(), securitySecret, pipeline.RetryOnErr); err != nil {
+		return
+	}
+	keystoreHash := hash.HashMap(certSecret.Data)
+	lastModified := lastModified(certSecret, securitySecret)
+
+	statefulSet := &appsv1.StatefulSet{}
+	if err := ctx.Resources().Load(i.GetStatefulSetName(), statefulSet, pipeline.RetryOnErr); err != nil {
+		return
+	}
+	if rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+		// Pods restarted by a rollout in progress load the current certificates
+		return
+	}
+
+	podList := &corev1.PodList{}
+	if err := ctx.Resources().List(i.PodSelectorLabels(), podList, pipeline.RetryOnErr); err != nil {
+		return
+	}
+
+	var reloaded []string
+	for _, pod := range podList.Items {
+		if !kube.IsPodReady(pod) || pod.Annotations[consts.AnnotationKeystoreHash] == keystoreHash {
+			continue
+		}
+		if startedAfter(pod, lastModified) {
+			if err := setPodAnnotation(pod, consts.AnnotationKeystoreHash, keystoreHash, ctx); err != nil {
+				return
+			}
+			continue
+		}
+
+		if wait := keystoreSyncDelay - time.Since(lastModified); wait > 0 {
+			ctx.Log().Info("Waiting for the renewed certificates to be mounted in the pods", "delay", wait.Round(time.Second))
+			// Do not stop the pipeline while waiting, so that the other handlers are not delayed
+			ctx.RequeueEventually(wait)
+			return
+		}
+
+		if err := ctx.InfinispanClientForPod(pod.Name).Server().ReloadCertificates(); err != nil {
+			if errors.Is(err, api.ErrUnsupported) {
+				ctx.Log().Info("Server does not support reloading certificates, starting rolling restart")
+				msg := "Rolling restart started to load the renewed certificates, as the server cannot reload them"
+				rollingRestart(i, statefulSet, consts.AnnotationKeystoreHash, keystoreHash, EventReasonCertificateRotated, msg, ctx)
+			} else {
+				ctx.Requeue(fmt.Errorf("unable to reload the certificates of pod '%s': %w", pod.Name, err))
+			}
+			return
+		}
+		if err := setPodAnnotation(pod, consts.AnnotationKeystoreHash, keystoreHash, ctx); err != nil {
+			return
+		}
+		ctx.Log().Info("Certificates of pod reloaded", "pod", pod.Name)
+		reloaded = append(reloaded, pod.Name)
+	}
+
+	if len(reloaded) > 0 {
+		msg := fmt.Sprintf("Certificates reloaded by pods '%s'", strings.Join(reloaded, "', '"))
+		ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonCertificateRotated, msg)
+	}
+}
+
+// CertificateUpdate reports the progress of the rolling update started by StatefulSetRollingUpgrade to load the
+// certificates of a renamed Secret in the CertificateUpdating condition, until all pods have been updated
+func CertificateUpdate(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	statefulSet := &appsv1.StatefulSet{}
+	if err := ctx.Resources().Load(i.GetStatefulSetName(), statefulSet, pipeline.RetryOnErr); err != nil {
+		return
+	}
+
+	replicas := *statefulSet.Spec.Replicas
+	status := statefulSet.Status
+	conditionStatus := metav1.ConditionTrue
+	msg := fmt.Sprintf("Loading the certificates of Secret '%s': %d of %d pods updated", i.GetKeystoreSecretName(), status.UpdatedReplicas, replicas)
+	if statefulSet.Spec.UpdateStrategy.RollingUpdate == nil && status.ObservedGeneration >= statefulSet.Generation &&
+		status.UpdatedReplicas >= replicas && status.ReadyReplicas >= replicas {
+		conditionStatus = metav1.ConditionFalse
+		msg = fmt.Sprintf("Certificates of Secret '%s' loaded by all pods", i.GetKeystoreSecretName())
+	}
+	if condition := i.GetCondition(ispnv1.ConditionCertificateUpdating); condition.Status == conditionStatus && condition.Message == msg {
+		return
+	}
+	if err := ctx.UpdateInfinispan(func() {
+		i.SetCondition(ispnv1.ConditionCertificateUpdating, conditionStatus, msg)
+	}); err != nil {
+		return
+	}
+	if conditionStatus == metav1.ConditionFalse {
+		ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonCertificateUpdating, msg)
+	}
+}
+
+// startedAfter returns true if the pod was created after the mounted Secrets were last modified, in which case the
+// server started with their current content
+func startedAfter(pod corev1.Pod, lastModified time.Time) bool {
+	return !pod.CreationTimestamp.Time.Before(lastModified)
+}
+
+// lastModified returns the most recent time any of the objects was created or updated, as recorded by its managed fields
+func lastModified(objs ...metav1.Object) time.Time {
+	var last time.Time
+	for _, obj := range objs {
+		if created := obj.GetCreationTimestamp().Time; created.After(last) {
+			last = created
+		}
+		for _, entry := range obj.GetManagedFields() {
+			if entry.Time != nil && entry.Time.Time.After(last) {
+				last = entry.Time.Time
+			}
+		}
+	}
+	return last
+}
+
+// rollingRestart restarts the pods one at a time with PartitionedRollout, for servers that cannot apply a change at
+// runtime. The hash of the change is added to the StatefulSet pod template with the given annotation, so that the
+// restarted pods are known to have loaded it
+func rollingRestart(i *ispnv1.Infinispan, statefulSet *appsv1.StatefulSet, annotation, value, reason, msg string, ctx pipeline.Context) {
+	template := &statefulSet.Spec.Template
+	if template.Annotations[annotation] == value {
+		return
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[annotation] = value
+	setPartition(statefulSet, *statefulSet.Spec.Replicas)
+	if err := ctx.Resources().Update(statefulSet, pipeline.RetryOnErr); err != nil {
+		return
+	}
+	ctx.EventRecorder().Event(i, corev1.EventTypeNormal, reason, msg)
+	ctx.RequeueAfter(consts.DefaultWaitOnCluster, nil)
+}
+
+// setPodAnnotation records on the pod the hash of a change applied by its server at runtime
+func setPodAnnotation(pod corev1.Pod, annotation, value string, ctx pipeline.Context) error {
+	mutateFn := func() error {
+		if pod.CreationTimestamp.IsZero() {
+			return k8serrors.NewNotFound(corev1.Resource(""), pod.Name)
+		}
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[annotation] = value
+		return nil
+	}
+	_, err := ctx.Resources().CreateOrPatch(&pod, false, mutateFn, pipeline.IgnoreNotFound, pipeline.RetryOnErr)
+	return err
+}
@@ -0,0 +1,89 @@
+package manage
+
+import (
+	"testing"
+	"time"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestLastModified(t *testing.T) {
+	created := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := metav1.NewTime(created.Add(time.Hour))
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)}}
+	assert.Equal(t, created, lastModified(secret))
+
+	other := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		CreationTimestamp: metav1.NewTime(created),
+		ManagedFields:     []metav1.ManagedFieldsEntry{{Manager: "service-ca"}, {Manager: "infinispan-operator", Time: &updated}},
+	}}
+	assert.Equal(t, updated.Time, lastModified(secret, other))
+}
+
+func TestStartedAfter(t *testing.T) {
+	modified := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	pod := func(created time.Time) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: metav1.NewTime(created),
+			Annotations:       map[string]string{consts.AnnotationKeystoreHash: "previous"},
+		}}
+	}
+	assert.False(t, startedAfter(pod(modified.Add(-time.Minute)), modified), "the pod must reload the certificates")
+	assert.True(t, startedAfter(pod(modified), modified))
+	assert.True(t, startedAfter(pod(modified.Add(time.Minute)), modified))
+}
+
+// certificateUpdateContext applies the updates of the Infinispan CR made by CertificateUpdate
+type certificateUpdateContext struct {
+	*rolloutContext
+}
+
+func (c *certificateUpdateContext) UpdateInfinispan(fn func()) error {
+	fn()
+	return nil
+}
+
+func TestCertificateUpdate(t *testing.T) {
+	completed := &appsv1.StatefulSet{
+		Spec:   appsv1.StatefulSetSpec{Replicas: pointer.Int32Ptr(3)},
+		Status: appsv1.StatefulSetStatus{ReadyReplicas: 3, UpdatedReplicas: 3},
+	}
+	testTable := []struct {
+		name            string
+		statefulSet     *appsv1.StatefulSet
+		expectedStatus  metav1.ConditionStatus
+		expectedMessage string
+	}{
+		{"rollout started", partitionedStatefulSet(3, 3, 0), metav1.ConditionTrue, "0 of 3 pods updated"},
+		{"rollout in progress", partitionedStatefulSet(3, 1, 2), metav1.ConditionTrue, "2 of 3 pods updated"},
+		{"rollout completed", completed, metav1.ConditionFalse, "loaded by all pods"},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &ispnv1.Infinispan{
+				ObjectMeta: metav1.ObjectMeta{Name: "example"},
+				Spec: ispnv1.InfinispanSpec{
+					Security: ispnv1.InfinispanSecurity{
+						EndpointEncryption: &ispnv1.EndpointEncryption{
+							Type:           ispnv1.CertificateSourceTypeSecret,
+							CertSecretName: "renewed-certs",
+						},
+					},
+				},
+			}
+			i.SetCondition(ispnv1.ConditionCertificateUpdating, metav1.ConditionTrue, "Rolling update started")
+			ctx := &certificateUpdateContext{&rolloutContext{resources: &rolloutResources{statefulSet: tt.statefulSet}}}
+			CertificateUpdate(i, ctx)
+			condition := i.GetCondition(ispnv1.ConditionCertificateUpdating)
+			assert.Equal(t, tt.expectedStatus, condition.Status)
+			assert.Contains(t, condition.Message, "'renewed-certs'")
+			assert.Contains(t, condition.Message, tt.expectedMessage)
+		})
+	}
+}
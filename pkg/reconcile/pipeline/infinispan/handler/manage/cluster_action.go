@@ -0,0 +1,166 @@
+package manage
+
+import (
+	"fmt"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	EventReasonClusterAction         = "ClusterAction"
+	EventReasonClusterActionRejected = "ClusterActionRejected"
+)
+
+// ClusterAction processes the action requested with the infinispan.org/action annotation. The annotation is removed
+// once the action is accepted and the cluster is shut down and started by setting spec.replicas, so that the
+// GracefulShutdown handler preserves the state of the cluster exactly as if the user had scaled it down and up again.
+// The progress of the action is reported in status.action and the ClusterAction condition
+func ClusterAction(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	if _, requested := i.Annotations[consts.AnnotationAction]; requested {
+		var accepted bool
+		var msg string
+		if err := ctx.UpdateInfinispan(func() {
+			action, ok := i.Annotations[consts.AnnotationAction]
+			if !ok {
+				return
+			}
+			delete(i.Annotations, consts.AnnotationAction)
+			msg, accepted = startClusterAction(i, ispnv1.ClusterActionType(action))
+		}); err != nil {
+			ctx.Requeue(err)
+			return
+		}
+		if msg == "" {
+			return
+		}
+		if accepted {
+			ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonClusterAction, msg)
+		} else {
+			ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonClusterActionRejected, msg)
+		}
+		ctx.Log().Info(msg)
+		ctx.Requeue(nil)
+		return
+	}
+
+	if !i.IsClusterActionInProgress() {
+		return
+	}
+	status := i.Status.Action
+	switch status.Phase {
+	case ispnv1.ClusterActionPhaseShuttingDown:
+		if !i.IsConditionTrue(ispnv1.ConditionGracefulShutdown) {
+			// The GracefulShutdown handler requeues until the StatefulSet has been scaled down
+			return
+		}
+		msg := "Cluster shut down"
+		if err := ctx.UpdateInfinispan(func() {
+			if i.Status.Action.Action == ispnv1.ClusterActionRestart {
+				msg = fmt.Sprintf("Cluster shut down, starting %d replicas", i.Status.Action.Replicas)
+				i.Spec.Replicas = i.Status.Action.Replicas
+				updateClusterAction(i, ispnv1.ClusterActionPhaseStarting, msg)
+			} else {
+				updateClusterAction(i, ispnv1.ClusterActionPhaseSucceeded, msg)
+			}
+		}); err != nil {
+			ctx.Requeue(err)
+			return
+		}
+		ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonClusterAction, msg)
+		ctx.Requeue(nil)
+	case ispnv1.ClusterActionPhaseStarting:
+		if i.IsConditionTrue(ispnv1.ConditionGracefulShutdown) || !i.IsWellFormed() {
+			// AwaitWellFormedCondition requeues until the cluster has formed
+			return
+		}
+		msg := fmt.Sprintf("Cluster started with %d replicas", status.Replicas)
+		if err := ctx.UpdateInfinispan(func() {
+			updateClusterAction(i, ispnv1.ClusterActionPhaseSucceeded, msg)
+		}); err != nil {
+			ctx.Requeue(err)
+			return
+		}
+		ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonClusterAction, msg)
+	}
+}
+
+// startClusterAction validates the requested action against the current state of the cluster and updates spec.replicas
+// to shut down or start the cluster. Returns the message describing the outcome and true if the action was accepted
+func startClusterAction(i *ispnv1.Infinispan, action ispnv1.ClusterActionType) (string, bool) {
+	reject := func(reason string) (string, bool) {
+		msg := fmt.Sprintf("Action '%s' rejected: %s", action, reason)
+		// The status of an action in progress is preserved, so that its progress is still reported
+		if !i.IsClusterActionInProgress() {
+			now := metav1.Now()
+			i.Status.Action = &ispnv1.ClusterActionStatus{
+				Action:         action,
+				Phase:          ispnv1.ClusterActionPhaseFailed,
+				Message:        msg,
+				StartTime:      now,
+				CompletionTime: &now,
+			}
+		}
+		return msg, false
+	}
+
+	if i.IsClusterActionInProgress() {
+		return reject(fmt.Sprintf("action '%s' is in progress", i.Status.Action.Action))
+	}
+	if i.IsUpgradeCondition() {
+		return reject("an upgrade is in progress")
+	}
+
+	status := &ispnv1.ClusterActionStatus{
+		Action:    action,
+		StartTime: metav1.Now(),
+	}
+	var msg string
+	switch action {
+	case ispnv1.ClusterActionGracefulShutdown, ispnv1.ClusterActionRestart:
+		if i.Spec.Replicas == 0 {
+			return reject("the cluster is already shut down")
+		}
+		status.Phase = ispnv1.ClusterActionPhaseShuttingDown
+		status.Replicas = i.Spec.Replicas
+		msg = fmt.Sprintf("Action '%s' accepted, shutting down %d replicas", action, i.Spec.Replicas)
+		i.Spec.Replicas = 0
+	case ispnv1.ClusterActionStart:
+		if i.Spec.Replicas != 0 {
+			return reject("the cluster is not shut down")
+		}
+		replicas := i.Status.ReplicasWantedAtRestart
+		if replicas == 0 && i.Status.Action != nil {
+			replicas = i.Status.Action.Replicas
+		}
+		if replicas == 0 {
+			return reject("the number of replicas before the shutdown is unknown, set spec.replicas instead")
+		}
+		status.Phase = ispnv1.ClusterActionPhaseStarting
+		status.Replicas = replicas
+		msg = fmt.Sprintf("Action '%s' accepted, starting %d replicas", action, replicas)
+		i.Spec.Replicas = replicas
+	default:
+		return reject("unsupported action")
+	}
+	status.Message = msg
+	i.Status.Action = status
+	i.SetCondition(ispnv1.ConditionClusterAction, metav1.ConditionTrue, fmt.Sprintf("%s: %s", action, status.Phase))
+	return msg, true
+}
+
+func updateClusterAction(i *ispnv1.Infinispan, phase ispnv1.ClusterActionPhase, msg string) {
+	action := i.Status.Action
+	action.Phase = phase
+	action.Message = msg
+	if phase == ispnv1.ClusterActionPhaseSucceeded || phase == ispnv1.ClusterActionPhaseFailed {
+		now := metav1.Now()
+		action.CompletionTime = &now
+		i.SetCondition(ispnv1.ConditionClusterAction, metav1.ConditionFalse, msg)
+	} else {
+		i.SetCondition(ispnv1.ConditionClusterAction, metav1.ConditionTrue, fmt.Sprintf("%s: %s", action.Action, phase))
+	}
+}
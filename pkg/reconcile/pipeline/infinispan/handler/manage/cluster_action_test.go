@@ -0,0 +1,56 @@
+package manage
+
+import (
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartClusterAction(t *testing.T) {
+	i := &ispnv1.Infinispan{Spec: ispnv1.InfinispanSpec{Replicas: 3}}
+
+	_, accepted := startClusterAction(i, ispnv1.ClusterActionStart)
+	assert.False(t, accepted, "cluster is not shut down")
+	assert.Equal(t, ispnv1.ClusterActionPhaseFailed, i.Status.Action.Phase)
+	assert.NotNil(t, i.Status.Action.CompletionTime)
+	assert.Equal(t, int32(3), i.Spec.Replicas)
+
+	_, accepted = startClusterAction(i, ispnv1.ClusterActionRestart)
+	assert.True(t, accepted)
+	assert.Equal(t, int32(0), i.Spec.Replicas)
+	assert.Equal(t, ispnv1.ClusterActionPhaseShuttingDown, i.Status.Action.Phase)
+	assert.Equal(t, int32(3), i.Status.Action.Replicas)
+	assert.True(t, i.IsConditionTrue(ispnv1.ConditionClusterAction))
+
+	msg, accepted := startClusterAction(i, ispnv1.ClusterActionGracefulShutdown)
+	assert.False(t, accepted)
+	assert.Contains(t, msg, "'restart' is in progress")
+	assert.Equal(t, ispnv1.ClusterActionRestart, i.Status.Action.Action, "the action in progress is preserved")
+
+	updateClusterAction(i, ispnv1.ClusterActionPhaseSucceeded, "Cluster started with 3 replicas")
+	assert.False(t, i.IsClusterActionInProgress())
+	assert.False(t, i.IsConditionTrue(ispnv1.ConditionClusterAction))
+
+	// The replicas of the most recent action are used if the cluster was shut down by the action
+	i.Spec.Replicas = 0
+	_, accepted = startClusterAction(i, ispnv1.ClusterActionStart)
+	assert.True(t, accepted)
+	assert.Equal(t, int32(3), i.Spec.Replicas)
+	assert.Equal(t, ispnv1.ClusterActionPhaseStarting, i.Status.Action.Phase)
+
+	// The replicas recorded by the GracefulShutdown handler take precedence
+	i.Spec.Replicas = 0
+	i.Status.Action = nil
+	i.Status.ReplicasWantedAtRestart = 2
+	_, accepted = startClusterAction(i, ispnv1.ClusterActionStart)
+	assert.True(t, accepted)
+	assert.Equal(t, int32(2), i.Spec.Replicas)
+
+	i.Spec.Replicas = 0
+	i.Status.Action = nil
+	i.Status.ReplicasWantedAtRestart = 0
+	_, accepted = startClusterAction(i, ispnv1.ClusterActionStart)
+	assert.False(t, accepted, "replicas unknown")
+	assert.Equal(t, int32(0), i.Spec.Replicas)
+}
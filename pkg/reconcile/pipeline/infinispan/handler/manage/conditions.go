@@ -7,6 +7,7 @@ import (
 
 	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
 	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
 	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
 	appsv1 "k8s.io/api/apps/v1"
@@ -132,18 +133,26 @@ func wellFormedCondition(i *ispnv1.Infinispan, ctx pipeline.Context, podList *co
 	return wellFormed
 }
 
+// XSiteViewCondition sets the CrossSiteViewFormed condition and reports the cross-site view of the local site and the
+// status of the backups to each remote site in status.sites
 func XSiteViewCondition(i *ispnv1.Infinispan, ctx pipeline.Context) {
-	podList, err := ctx.InfinispanPods()
-	if err != nil {
+	if !i.HasSites() {
+		if i.Status.Sites != nil || i.HasCondition(ispnv1.ConditionCrossSiteViewFormed) {
+			_ = ctx.UpdateInfinispan(func() {
+				i.Status.Sites = nil
+				i.RemoveCondition(ispnv1.ConditionCrossSiteViewFormed)
+			})
+		}
 		return
 	}
 
-	crossSiteViewCondition, err := getCrossSiteViewCondition(ctx, podList, i.GetSiteLocationsName())
+	podList, err := ctx.InfinispanPods()
 	if err != nil {
-		ctx.Requeue(fmt.Errorf("unable to set CrossSiteViewFormed condition: %w", err))
 		return
 	}
 
+	crossSiteViewCondition, view, coordinator := getCrossSiteViewCondition(ctx, podList, i.GetSiteLocationsName())
+
 	// ISPN-13116 If xsite view has been formed, then we must perform state-transfer to all sites if a SFS recovery has occurred
 	if crossSiteViewCondition.Status == metav1.ConditionTrue {
 		podName := podList.Items[0].Name
@@ -153,53 +162,97 @@ func XSiteViewCondition(i *ispnv1.Infinispan, ctx pipeline.Context) {
 			ctx.Log().Error(err, fmt.Sprintf("Unable to retrive logs for i pod %s", podName))
 		}
 		if strings.Contains(logs, "ISPN000643") {
+			if !acquireOperationLock(i, ctx, ispnv1.OperationXSiteStateTransfer) {
+				return
+			}
 			if err := ctx.InfinispanClientForPod(podName).Container().Xsite().PushAllState(); err != nil {
 				ctx.Log().Error(err, "Unable to push xsite state after SFS data recovery")
 			}
+			_ = ctx.UpdateInfinispan(func() {
+				i.ReleaseOperationLock(ispnv1.OperationXSiteStateTransfer, "")
+			})
+		}
+	}
+
+	sites := &ispnv1.CrossSiteStatus{View: view}
+	if i.Status.Sites != nil {
+		sites.Backups = i.Status.Sites.Backups
+		sites.OfflineLocations = i.Status.Sites.OfflineLocations
+	}
+	if coordinator != "" {
+		if backups, err := ctx.InfinispanClientForPod(coordinator).Container().Xsite().BackupStatus(); err != nil {
+			ctx.Log().Error(err, "Unable to retrieve the status of the cross-site backups")
+		} else {
+			sites.Backups = siteBackups(backups)
 		}
 	}
 
 	err = ctx.UpdateInfinispan(func() {
 		i.SetConditions(*crossSiteViewCondition)
+		i.Status.Sites = sites
 	})
 	if err != nil || crossSiteViewCondition.Status != metav1.ConditionTrue {
 		ctx.RequeueAfter(consts.DefaultWaitOnCluster, err)
 	}
 }
 
-func getCrossSiteViewCondition(ctx pipeline.Context, podList *corev1.PodList, siteLocations []string) (*ispnv1.InfinispanCondition, error) {
+// getCrossSiteViewCondition returns the CrossSiteViewFormed condition, the sites in the cross-site view and the name of
+// the coordinator pod that reported the view, or an empty name if the coordinator is not ready
+func getCrossSiteViewCondition(ctx pipeline.Context, podList *corev1.PodList, siteLocations []string) (*ispnv1.InfinispanCondition, []string, string) {
 	for _, item := range podList.Items {
 		cacheManager, err := ctx.InfinispanClientForPod(item.Name).Container().Info()
 		if err == nil {
 			if cacheManager.Coordinator {
 				// Perform cross-site view validation
 				crossSiteViewFormed := &ispnv1.InfinispanCondition{Type: ispnv1.ConditionCrossSiteViewFormed, Status: metav1.ConditionTrue}
-				sitesView := make(map[string]bool)
-				var err error
 				if cacheManager.SitesView == nil {
-					err = fmt.Errorf("retrieving the cross-site view is not supported with the server image you are using")
+					crossSiteViewFormed.Status = metav1.ConditionUnknown
+					crossSiteViewFormed.Message = "Error: retrieving the cross-site view is not supported with the server image you are using"
+					return crossSiteViewFormed, nil, item.Name
 				}
+				var view []string
+				sitesView := make(map[string]bool)
 				for _, site := range *cacheManager.SitesView {
 					sitesView[site.(string)] = true
+					view = append(view, site.(string))
 				}
-				if err == nil {
-					for _, location := range siteLocations {
-						if !sitesView[location] {
-							crossSiteViewFormed.Status = metav1.ConditionFalse
-							crossSiteViewFormed.Message = fmt.Sprintf("Site '%s' not ready", location)
-							break
-						}
-					}
-					if crossSiteViewFormed.Status == metav1.ConditionTrue {
-						crossSiteViewFormed.Message = fmt.Sprintf("Cross-Site view: %s", strings.Join(siteLocations, ","))
+				sort.Strings(view)
+				for _, location := range siteLocations {
+					if !sitesView[location] {
+						crossSiteViewFormed.Status = metav1.ConditionFalse
+						crossSiteViewFormed.Message = fmt.Sprintf("Site '%s' not ready", location)
+						break
 					}
-				} else {
-					crossSiteViewFormed.Status = metav1.ConditionUnknown
-					crossSiteViewFormed.Message = fmt.Sprintf("Error: %s", err.Error())
 				}
-				return crossSiteViewFormed, nil
+				if crossSiteViewFormed.Status == metav1.ConditionTrue {
+					crossSiteViewFormed.Message = fmt.Sprintf("Cross-Site view: %s", strings.Join(siteLocations, ","))
+				}
+				return crossSiteViewFormed, view, item.Name
 			}
 		}
 	}
-	return &ispnv1.InfinispanCondition{Type: ispnv1.ConditionCrossSiteViewFormed, Status: metav1.ConditionFalse, Message: "Coordinator not ready"}, nil
+	return &ispnv1.InfinispanCondition{Type: ispnv1.ConditionCrossSiteViewFormed, Status: metav1.ConditionFalse, Message: "Coordinator not ready"}, nil, ""
+}
+
+// siteBackups converts the status of the backups to each remote site reported by the server, sorted by site name
+func siteBackups(statuses map[string]api.SiteBackupStatus) []ispnv1.SiteBackupStatus {
+	backups := make([]ispnv1.SiteBackupStatus, 0, len(statuses))
+	for site, status := range statuses {
+		backup := ispnv1.SiteBackupStatus{Name: site}
+		switch status.Status {
+		case "online":
+			backup.Status = ispnv1.SiteBackupOnline
+		case "offline":
+			backup.Status = ispnv1.SiteBackupOffline
+		default:
+			backup.Status = ispnv1.SiteBackupMixed
+			backup.OfflineCaches = append([]string(nil), status.Offline...)
+			sort.Strings(backup.OfflineCaches)
+		}
+		backups = append(backups, backup)
+	}
+	sort.Slice(backups, func(a, b int) bool {
+		return backups[a].Name < backups[b].Name
+	})
+	return backups
 }
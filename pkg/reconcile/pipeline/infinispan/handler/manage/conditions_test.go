@@ -0,0 +1,24 @@
+package manage
+
+import (
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteBackups(t *testing.T) {
+	backups := siteBackups(map[string]api.SiteBackupStatus{
+		"site-c": {Status: "mixed", Online: []string{"cache-a"}, Offline: []string{"cache-c", "cache-b"}},
+		"site-b": {Status: "offline"},
+		"site-a": {Status: "online"},
+	})
+	assert.Equal(t, []ispnv1.SiteBackupStatus{
+		{Name: "site-a", Status: ispnv1.SiteBackupOnline},
+		{Name: "site-b", Status: ispnv1.SiteBackupOffline},
+		{Name: "site-c", Status: ispnv1.SiteBackupMixed, OfflineCaches: []string{"cache-b", "cache-c"}},
+	}, backups)
+
+	assert.Empty(t, siteBackups(nil))
+}
@@ -0,0 +1,16 @@
+package manage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicAddresses(t *testing.T) {
+	assert.Nil(t, publicAddresses("", []string{"infinispan.example.com"}))
+	assert.Equal(t, []string{"10.0.0.1:11222"}, publicAddresses("10.0.0.1:11222", nil))
+
+	hostnames := []string{"infinispan.example.com", "cache.example.com"}
+	assert.Equal(t, []string{"infinispan.example.com:11222", "cache.example.com:11222"}, publicAddresses("10.0.0.1:11222", hostnames))
+	assert.Equal(t, []string{"infinispan.example.com", "cache.example.com"}, publicAddresses("route.apps.example.com", hostnames))
+}
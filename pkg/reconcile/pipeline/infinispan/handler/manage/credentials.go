@@ -0,0 +1,288 @@
+package manage
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/hash"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/security"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	EventReasonCredentialIssued   = "CredentialIssued"
+	EventReasonCredentialExpired  = "CredentialExpired"
+	EventReasonCredentialRejected = "CredentialRejected"
+
+	// TemporaryUserPrefix is prepended to the usernames of short-lived credentials. Users of the default security realm
+	// with this prefix are removed from the servers once they no longer have an unexpired credential request Secret
+	TemporaryUserPrefix = "temp-"
+
+	DefaultCredentialTTL = time.Hour
+	MaxCredentialTTL     = 24 * time.Hour
+)
+
+var credentialRoleRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+type temporaryUser struct {
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	Roles    []string `json:"roles,omitempty"`
+}
+
+// ShortLivedCredentials issues credentials to the Secrets labelled with infinispan.org/credential-request, creating a
+// temporary user in the default security realm of every server for each unexpired credential. Once the credentials
+// expire, the users are removed from the servers and the Secrets are deleted
+func ShortLivedCredentials(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	// Servers that read their users from Vault do not load the temporary users
+	if !i.IsEndpointSecretEnabled() {
+		return
+	}
+
+	secretList := &corev1.SecretList{}
+	if err := ctx.Resources().List(map[string]string{consts.LabelCredentialRequest: i.Name}, secretList, pipeline.RetryOnErr); err != nil {
+		return
+	}
+
+	var users []temporaryUser
+	var nextExpiry time.Time
+	for _, secret := range secretList.Items {
+		secret := secret
+		var expiry time.Time
+		if value, issued := secret.Annotations[consts.AnnotationCredentialExpiry]; issued {
+			var err error
+			if expiry, err = time.Parse(time.RFC3339, value); err != nil {
+				msg := fmt.Sprintf("Credential request Secret '%s' has an invalid expiry: %v", secret.Name, err)
+				ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonCredentialRejected, msg)
+				continue
+			}
+			if !time.Now().Before(expiry) {
+				if err := ctx.Resources().Delete(secret.Name, &corev1.Secret{}, pipeline.RetryOnErr); err != nil {
+					return
+				}
+				msg := fmt.Sprintf("Credentials of Secret '%s' expired", secret.Name)
+				ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonCredentialExpired, msg)
+				continue
+			}
+		} else {
+			ttl, err := credentialTTL(&secret)
+			if err != nil {
+				msg := fmt.Sprintf("Credential request Secret '%s' rejected: %v", secret.Name, err)
+				ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonCredentialRejected, msg)
+				continue
+			}
+			if expiry, err = issueCredentials(&secret, ttl, ctx); err != nil {
+				return
+			}
+			msg := fmt.Sprintf("Credentials issued to Secret '%s', expiring at %s", secret.Name, expiry.Format(time.RFC3339))
+			ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonCredentialIssued, msg)
+		}
+
+		roles, err := credentialRoles(&secret)
+		if err == nil && i.IsAdminOperationsRestricted() {
+			err = restrictedCredentialRoles(roles)
+		}
+		if err != nil {
+			msg := fmt.Sprintf("Credential request Secret '%s' rejected: %v", secret.Name, err)
+			ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonCredentialRejected, msg)
+			continue
+		}
+		users = append(users, temporaryUser{
+			Username: string(secret.Data[consts.AdminUsernameKey]),
+			Password: string(secret.Data[consts.AdminPasswordKey]),
+			Roles:    roles,
+		})
+		if nextExpiry.IsZero() || expiry.Before(nextExpiry) {
+			nextExpiry = expiry
+		}
+	}
+	sort.Slice(users, func(a, b int) bool {
+		return users[a].Username < users[b].Username
+	})
+	if !nextExpiry.IsZero() {
+		requeuePeriodic(ctx, time.Until(nextExpiry))
+	}
+
+	podList, err := ctx.InfinispanPods()
+	if err != nil {
+		return
+	}
+	for _, pod := range podList.Items {
+		if !kube.IsPodReady(pod) {
+			continue
+		}
+		usersHash, err := temporaryUsersHash(users, &pod)
+		if err != nil {
+			ctx.Requeue(err)
+			return
+		}
+		if pod.Annotations[consts.AnnotationTemporaryUsersHash] == usersHash {
+			continue
+		}
+		if _, err := ctx.Kubernetes().ExecWithOptions(kube.ExecOptions{
+			Container: provision.InfinispanContainer,
+			Command:   []string{"sh", "-c", temporaryUsersScript(i, users)},
+			PodName:   pod.Name,
+			Namespace: i.Namespace,
+		}); err != nil {
+			ctx.Requeue(fmt.Errorf("unable to update temporary users of pod '%s': %w", pod.Name, err))
+			return
+		}
+
+		pod := pod
+		mutateFn := func() error {
+			if pod.CreationTimestamp.IsZero() {
+				return errors.NewNotFound(corev1.Resource(""), pod.Name)
+			}
+			if usersHash == "" {
+				delete(pod.Annotations, consts.AnnotationTemporaryUsersHash)
+				return nil
+			}
+			if pod.Annotations == nil {
+				pod.Annotations = map[string]string{}
+			}
+			pod.Annotations[consts.AnnotationTemporaryUsersHash] = usersHash
+			return nil
+		}
+		if _, err := ctx.Resources().CreateOrPatch(&pod, false, mutateFn, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+			return
+		}
+		ctx.Log().Info("Temporary users of pod updated", "pod", pod.Name, "users", len(users))
+	}
+}
+
+// issueCredentials writes the username, password and expiry of a new temporary user to the credential request Secret
+func issueCredentials(secret *corev1.Secret, ttl time.Duration, ctx pipeline.Context) (time.Time, error) {
+	username, err := security.GenerateUsername(fmt.Sprintf("%s%s-", TemporaryUserPrefix, secret.Name))
+	if err != nil {
+		ctx.Requeue(err)
+		return time.Time{}, err
+	}
+	password, err := security.GeneratePassword()
+	if err != nil {
+		ctx.Requeue(err)
+		return time.Time{}, err
+	}
+	expiry := time.Now().Add(ttl).Truncate(time.Second)
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[consts.AnnotationCredentialExpiry] = expiry.Format(time.RFC3339)
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[consts.AdminUsernameKey] = []byte(username)
+	secret.Data[consts.AdminPasswordKey] = []byte(password)
+	if err := ctx.Resources().Update(secret, pipeline.RetryOnErr); err != nil {
+		return time.Time{}, err
+	}
+	return expiry, nil
+}
+
+// credentialTTL returns the lifetime of the credentials requested by the Secret
+func credentialTTL(secret *corev1.Secret) (time.Duration, error) {
+	value, ok := secret.Annotations[consts.AnnotationCredentialTTL]
+	if !ok {
+		return DefaultCredentialTTL, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s '%s': %w", consts.AnnotationCredentialTTL, value, err)
+	}
+	if ttl <= 0 || ttl > MaxCredentialTTL {
+		return 0, fmt.Errorf("%s '%s' must be greater than zero and at most %s", consts.AnnotationCredentialTTL, value, MaxCredentialTTL)
+	}
+	return ttl, nil
+}
+
+// credentialRoles returns the roles of the temporary user requested by the Secret
+func credentialRoles(secret *corev1.Secret) ([]string, error) {
+	value := strings.TrimSpace(secret.Annotations[consts.AnnotationCredentialRoles])
+	if value == "" {
+		return nil, nil
+	}
+	var roles []string
+	for _, role := range strings.Split(value, ",") {
+		role = strings.TrimSpace(role)
+		if !credentialRoleRegex.MatchString(role) {
+			return nil, fmt.Errorf("invalid role '%s' in %s", role, consts.AnnotationCredentialRoles)
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+// restrictedCredentialRoles returns an error if temporary users would have the admin role, which only the operator user
+// has when spec.security.restrictAdminOperations is true
+func restrictedCredentialRoles(roles []string) error {
+	for _, role := range roles {
+		if role == "admin" {
+			return fmt.Errorf("role 'admin' cannot be granted when 'spec.security.restrictAdminOperations' is true")
+		}
+	}
+	return nil
+}
+
+// temporaryUsersHash returns the hash of the temporary users and of the server container of the pod, so that the users
+// are created again when the container restarts with the users of the identities batch only. An empty string is
+// returned if there are no temporary users
+func temporaryUsersHash(users []temporaryUser, pod *corev1.Pod) (string, error) {
+	if len(users) == 0 {
+		return "", nil
+	}
+	var containerID string
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == provision.InfinispanContainer {
+			containerID = status.ContainerID
+		}
+	}
+	data, err := json.Marshal(struct {
+		ContainerID string          `json:"containerID"`
+		Users       []temporaryUser `json:"users"`
+	}{containerID, users})
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal temporary users: %w", err)
+	}
+	return hash.HashByte(data), nil
+}
+
+// temporaryUsersScript returns a shell script that removes the temporary users of the default security realm that are
+// not in the given list and creates the missing ones with a single CLI batch
+func temporaryUsersScript(i *ispnv1.Infinispan, users []temporaryUser) string {
+	serverRoot := i.ServerRoot()
+	opts := fmt.Sprintf("--users-file cli-users.properties --groups-file cli-groups.properties --server-root %s", serverRoot)
+	usernames := make([]string, len(users))
+	for idx, user := range users {
+		usernames[idx] = user.Username
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "batch=$(mktemp)\n")
+	fmt.Fprintf(&b, "existing=$(sed -n 's/^\\(%s[^=]*\\)=.*/\\1/p' %s/conf/cli-users.properties)\n", TemporaryUserPrefix, serverRoot)
+	fmt.Fprintf(&b, "for user in $existing; do\n")
+	fmt.Fprintf(&b, "  case ' %s ' in *\" $user \"*) ;; *) echo \"user remove $user %s\" >> $batch ;; esac\n", strings.Join(usernames, " "), opts)
+	fmt.Fprintf(&b, "done\n")
+	for _, user := range users {
+		create := fmt.Sprintf("user create %s --realm default -p %s %s", user.Username, user.Password, opts)
+		if len(user.Roles) > 0 {
+			create += fmt.Sprintf(" --groups %s", strings.Join(user.Roles, ","))
+		}
+		create += security.AlgorithmsCliOption(i.GetPasswordAlgorithms())
+		fmt.Fprintf(&b, "echo \"$existing\" | grep -qx '%s' || echo '%s' >> $batch\n", user.Username, create)
+	}
+	fmt.Fprintf(&b, "status=0\n")
+	fmt.Fprintf(&b, "if [ -s $batch ]; then /opt/infinispan/bin/cli.sh -f $batch || status=$?; fi\n")
+	fmt.Fprintf(&b, "rm -f $batch\n")
+	fmt.Fprintf(&b, "exit $status\n")
+	return b.String()
+}
@@ -0,0 +1,100 @@
+package manage
+
+import (
+	"testing"
+	"time"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func credentialRequest(annotations map[string]string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "team-a",
+			Labels:      map[string]string{consts.LabelCredentialRequest: "example"},
+			Annotations: annotations,
+		},
+	}
+}
+
+func TestCredentialTTL(t *testing.T) {
+	ttl, err := credentialTTL(credentialRequest(nil))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultCredentialTTL, ttl)
+
+	ttl, err = credentialTTL(credentialRequest(map[string]string{consts.AnnotationCredentialTTL: "30m"}))
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, ttl)
+
+	for _, value := range []string{"1 hour", "0s", "-1h", "25h"} {
+		_, err = credentialTTL(credentialRequest(map[string]string{consts.AnnotationCredentialTTL: value}))
+		assert.Error(t, err, value)
+	}
+}
+
+func TestCredentialRoles(t *testing.T) {
+	roles, err := credentialRoles(credentialRequest(nil))
+	require.NoError(t, err)
+	assert.Empty(t, roles)
+
+	roles, err = credentialRoles(credentialRequest(map[string]string{consts.AnnotationCredentialRoles: "observer, app-writer"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"observer", "app-writer"}, roles)
+
+	_, err = credentialRoles(credentialRequest(map[string]string{consts.AnnotationCredentialRoles: "observer,admin' && rm -rf /"}))
+	assert.Error(t, err)
+}
+
+func TestRestrictedCredentialRoles(t *testing.T) {
+	assert.NoError(t, restrictedCredentialRoles([]string{"observer", "deployer"}))
+	assert.Error(t, restrictedCredentialRoles([]string{"observer", "admin"}))
+}
+
+func TestTemporaryUsersHash(t *testing.T) {
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: provision.InfinispanContainer, ContainerID: "containerd://1"}},
+		},
+	}
+	usersHash, err := temporaryUsersHash(nil, pod)
+	require.NoError(t, err)
+	assert.Empty(t, usersHash)
+
+	users := []temporaryUser{{Username: "temp-team-a-abc123", Password: "secret"}}
+	usersHash, err = temporaryUsersHash(users, pod)
+	require.NoError(t, err)
+	assert.NotEmpty(t, usersHash)
+
+	// The users must be created again when the server container restarts
+	pod.Status.ContainerStatuses[0].ContainerID = "containerd://2"
+	restartedHash, err := temporaryUsersHash(users, pod)
+	require.NoError(t, err)
+	assert.NotEqual(t, usersHash, restartedHash)
+}
+
+func TestTemporaryUsersScript(t *testing.T) {
+	i := &ispnv1.Infinispan{}
+	script := temporaryUsersScript(i, []temporaryUser{
+		{Username: "temp-team-a-abc123", Password: "secret", Roles: []string{"observer", "application"}},
+		{Username: "temp-team-b-def456", Password: "password"},
+	})
+	assert.Contains(t, script, "case ' temp-team-a-abc123 temp-team-b-def456 '")
+	assert.Contains(t, script, "s/^\\(temp-[^=]*\\)=.*/\\1/p' /opt/infinispan/server/conf/cli-users.properties")
+	assert.Contains(t, script, "grep -qx 'temp-team-a-abc123' || echo 'user create temp-team-a-abc123 --realm default -p secret --users-file cli-users.properties --groups-file cli-groups.properties --server-root /opt/infinispan/server --groups observer,application' >> $batch")
+	assert.Contains(t, script, "grep -qx 'temp-team-b-def456' || echo 'user create temp-team-b-def456 --realm default -p password --users-file cli-users.properties --groups-file cli-groups.properties --server-root /opt/infinispan/server' >> $batch")
+
+	i.Spec.Security.PasswordAlgorithms = []ispnv1.PasswordAlgorithm{ispnv1.PasswordAlgorithmScramSHA512}
+	script = temporaryUsersScript(i, []temporaryUser{{Username: "temp-team-b-def456", Password: "password"}})
+	assert.Contains(t, script, "--server-root /opt/infinispan/server --algorithms scram-sha-512' >> $batch")
+
+	// Without temporary users, all existing temporary users are removed
+	script = temporaryUsersScript(i, nil)
+	assert.Contains(t, script, "case '  ' in")
+	assert.NotContains(t, script, "user create")
+}
@@ -0,0 +1,130 @@
+package manage
+
+import (
+	"fmt"
+	"time"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	EventReasonDataMigration = "DataMigration"
+
+	dataMigrationPollInterval = 10 * time.Second
+)
+
+// dataMigrationRequired returns true if upgrading pods running podImage to the operator's default image changes the
+// major version of the server, as the persistent data of the cluster must then be migrated before it is reused
+func dataMigrationRequired(i *ispnv1.Infinispan, podImage string) bool {
+	if i.IsEphemeralStorage() {
+		return false
+	}
+	if i.IsDataMigrationSkipped() {
+		return false
+	}
+	current, ok := kube.ImageMajorVersion(podImage)
+	if !ok {
+		return false
+	}
+	desired, ok := kube.ImageMajorVersion(consts.DefaultImageName)
+	return ok && current != desired
+}
+
+// dataMigrationBlocker returns a message describing why the upgrade of the cluster cannot be scheduled, or an empty
+// string once a data migration is configured and a Backup of the cluster has succeeded since the pods were started
+func dataMigrationBlocker(i *ispnv1.Infinispan, podList *corev1.PodList, ctx pipeline.Context) (string, error) {
+	if i.Spec.Upgrades == nil || i.Spec.Upgrades.DataMigration == nil || i.Spec.Upgrades.DataMigration.ConfigMapName == "" {
+		return "The upgrade changes the major version of the server, configure spec.upgrades.dataMigration.configMapName or set spec.upgrades.dataMigration.skip=true", nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := ctx.Resources().Load(i.Spec.Upgrades.DataMigration.ConfigMapName, configMap); err != nil {
+		return fmt.Sprintf("Unable to load data migration ConfigMap '%s': %v", i.Spec.Upgrades.DataMigration.ConfigMapName, err), nil
+	}
+
+	// Only a Backup taken whilst the current pods were running contains all of their data
+	var started metav1.Time
+	for idx, pod := range podList.Items {
+		if idx == 0 || pod.CreationTimestamp.Before(&started) {
+			started = pod.CreationTimestamp
+		}
+	}
+	backups := &v2alpha1.BackupList{}
+	if err := ctx.Resources().List(map[string]string{}, backups, pipeline.RetryOnErr); err != nil {
+		return "", err
+	}
+	for _, backup := range backups.Items {
+		if backup.Spec.Cluster == i.Name && backup.Status.Phase == v2alpha1.BackupSucceeded && !backup.CreationTimestamp.Before(&started) {
+			return "", nil
+		}
+	}
+	return "The upgrade changes the major version of the server, create a Backup of the cluster before the data is migrated", nil
+}
+
+// migrateData runs a data migration Job for each pod that existed before the GracefulShutdown and returns true once
+// all of them have succeeded. The Jobs are removed on success and retained on failure so that their logs can be
+// inspected
+func migrateData(i *ispnv1.Infinispan, ctx pipeline.Context) bool {
+	var jobs []*batchv1.Job
+	for ordinal := int32(0); ordinal < i.Status.ReplicasWantedAtRestart; ordinal++ {
+		job := &batchv1.Job{}
+		name := provision.DataMigrationJobName(i, ordinal)
+		if err := ctx.Resources().Load(name, job, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+			return false
+		}
+		if job.CreationTimestamp.IsZero() {
+			job = provision.DataMigrationJob(i, ordinal)
+			if err := ctx.Resources().Create(job, true, pipeline.RetryOnErr); err != nil {
+				return false
+			}
+			ctx.Log().Info("Data migration Job created", "job", name)
+		}
+		jobs = append(jobs, job)
+	}
+
+	for _, job := range jobs {
+		if job.Status.Failed > 0 {
+			msg := fmt.Sprintf("Data migration Job '%s' failed, inspect its logs and delete it to retry the migration", job.Name)
+			if i.GetCondition(ispnv1.ConditionDataMigrated).Message != msg {
+				ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonDataMigration, msg)
+			}
+			// Jobs are not watched, so poll for the failed Job to be deleted
+			ctx.RequeueAfter(dataMigrationPollInterval,
+				ctx.UpdateInfinispan(func() {
+					i.SetCondition(ispnv1.ConditionDataMigrated, metav1.ConditionFalse, msg)
+				}),
+			)
+			return false
+		}
+		if job.Status.Succeeded == 0 {
+			ctx.RequeueAfter(dataMigrationPollInterval, nil)
+			return false
+		}
+	}
+
+	for _, job := range jobs {
+		if err := ctx.Resources().Delete(job.Name, &batchv1.Job{}, pipeline.RetryOnErr); err != nil {
+			return false
+		}
+	}
+	// Jobs are deleted with the orphan propagation policy by default, so their pods must be removed explicitly
+	pods := &corev1.PodList{}
+	if err := ctx.Resources().List(i.Labels(provision.DataMigrationLabel), pods, pipeline.RetryOnErr); err != nil {
+		return false
+	}
+	for _, pod := range pods.Items {
+		if err := ctx.Resources().Delete(pod.Name, &corev1.Pod{}, pipeline.RetryOnErr); err != nil {
+			return false
+		}
+	}
+	ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonDataMigration, fmt.Sprintf("Data of %d pods migrated", len(jobs)))
+	return true
+}
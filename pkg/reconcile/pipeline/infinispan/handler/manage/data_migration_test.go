@@ -0,0 +1,40 @@
+package manage
+
+import (
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataMigrationRequired(t *testing.T) {
+	defaultImage := consts.DefaultImageName
+	consts.DefaultImageName = "quay.io/infinispan/server:13.0"
+	defer func() { consts.DefaultImageName = defaultImage }()
+
+	testTable := []struct {
+		name     string
+		podImage string
+		spec     ispnv1.InfinispanSpec
+		expected bool
+	}{
+		{"same major version", "quay.io/infinispan/server:13.0.1", ispnv1.InfinispanSpec{}, false},
+		{"different major version", "quay.io/infinispan/server:12.1", ispnv1.InfinispanSpec{}, true},
+		{"registry port", "localhost:5000/infinispan/server:12.1", ispnv1.InfinispanSpec{}, true},
+		{"no version tag", "localhost:5000/infinispan/server", ispnv1.InfinispanSpec{}, false},
+		{"image digest", "quay.io/infinispan/server@sha256:0123", ispnv1.InfinispanSpec{}, false},
+		{"skipped", "quay.io/infinispan/server:12.1", ispnv1.InfinispanSpec{
+			Upgrades: &ispnv1.InfinispanUpgradesSpec{DataMigration: &ispnv1.DataMigrationSpec{Skip: true}},
+		}, false},
+		{"ephemeral storage", "quay.io/infinispan/server:12.1", ispnv1.InfinispanSpec{
+			Service: ispnv1.InfinispanServiceSpec{Container: &ispnv1.InfinispanServiceContainerSpec{EphemeralStorage: true}},
+		}, false},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &ispnv1.Infinispan{Spec: tt.spec}
+			assert.Equal(t, tt.expected, dataMigrationRequired(i, tt.podImage))
+		})
+	}
+}
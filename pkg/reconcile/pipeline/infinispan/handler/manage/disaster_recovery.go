@@ -0,0 +1,30 @@
+package manage
+
+import (
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var lastBackupTimestamp = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "infinispan_operator_last_backup_timestamp_seconds",
+		Help: "The completion time of the most recent successful Backup of the cluster in seconds since the epoch, or 0 if the cluster has never been backed up",
+	},
+	[]string{"namespace", "cluster"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(lastBackupTimestamp)
+}
+
+// DisasterRecoveryMetrics exposes the most recent successful Backup recorded in the Infinispan status as a Prometheus
+// metric. The metric is derived from the status so that it survives restarts of the operator
+func DisasterRecoveryMetrics(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	var timestamp float64
+	if status := i.Status.DisasterRecovery; status != nil && status.LastBackup != nil {
+		timestamp = float64(status.LastBackup.CompletionTime.Unix())
+	}
+	lastBackupTimestamp.WithLabelValues(i.Namespace, i.Name).Set(timestamp)
+}
@@ -0,0 +1,300 @@
+package manage
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	EventReasonDriftReverted = "DriftReverted"
+)
+
+// driftFields the top level fields of the managed resources that must only be modified by the operator
+var driftFields = []string{"f:spec", "f:data", "f:binaryData"}
+
+// DriftDetection detects changes to the StatefulSet, Services and ConfigMap of the cluster that were made by a field
+// manager other than the operator, e.g. "kubectl edit". In Revert mode the fields owned by the other field managers are
+// patched back to the state desired by the operator, or removed if the operator does not define them. In Detect mode
+// the drifted resources are reported by the Drifted condition
+func DriftDetection(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	// The resources are recreated and scaled by the operator during upgrades and graceful shutdowns
+	if i.IsUpgradeCondition() || i.IsConditionTrue(ispnv1.ConditionStopping) || i.IsConditionTrue(ispnv1.ConditionGracefulShutdown) {
+		return
+	}
+
+	resources := []struct {
+		name    string
+		obj     client.Object
+		desired func() (client.Object, error)
+	}{
+		{i.GetStatefulSetName(), &appsv1.StatefulSet{}, func() (client.Object, error) {
+			return provision.NewStatefulSet(i, ctx)
+		}},
+		{i.GetConfigName(), &corev1.ConfigMap{}, func() (client.Object, error) {
+			config := ctx.ConfigFiles()
+			configMap := &corev1.ConfigMap{}
+			provision.PopulateServerConfigMap(config.ServerConfig, config.ZeroConfig, config.Log4j, configMap)
+			return configMap, nil
+		}},
+		{i.GetServiceName(), &corev1.Service{}, func() (client.Object, error) {
+			svc := &corev1.Service{}
+			provision.ApplyClusterService(i, svc)
+			return svc, nil
+		}},
+		{i.GetPingServiceName(), &corev1.Service{}, func() (client.Object, error) {
+			svc := &corev1.Service{}
+			provision.ApplyPingService(i, svc)
+			return svc, nil
+		}},
+		{i.GetAdminServiceName(), &corev1.Service{}, func() (client.Object, error) {
+			svc := &corev1.Service{}
+			provision.ApplyAdminService(i, svc)
+			return svc, nil
+		}},
+	}
+
+	revert := i.DriftDetectionMode() == ispnv1.DriftDetectionModeRevert
+	var drifted []string
+	for _, r := range resources {
+		if err := ctx.Resources().Load(r.name, r.obj, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+			return
+		}
+		managers := driftManagers(r.obj.GetManagedFields())
+		if len(managers) == 0 {
+			continue
+		}
+		msg := fmt.Sprintf("%s '%s' modified by %s", reflect.TypeOf(r.obj).Elem().Name(), r.name, strings.Join(managers, ", "))
+		if !revert {
+			drifted = append(drifted, msg)
+			continue
+		}
+		desired, err := r.desired()
+		if err != nil {
+			ctx.Requeue(fmt.Errorf("unable to determine the desired state of '%s': %w", r.name, err))
+			return
+		}
+		reverted, err := revertDrift(r.obj, desired, driftedFields(r.obj.GetManagedFields()))
+		if err != nil {
+			ctx.Requeue(fmt.Errorf("unable to revert the drift of '%s': %w", r.name, err))
+			return
+		}
+		if !reverted {
+			// The fields owned by the other field managers have the desired values
+			continue
+		}
+		ctx.Log().Info("Reverting drifted resource", "resource", r.name, "managers", managers)
+		if err := ctx.Resources().Update(r.obj, pipeline.RetryOnErr); err != nil {
+			return
+		}
+		ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonDriftReverted, msg+", reverted to the desired state")
+	}
+
+	if len(drifted) > 0 {
+		msg := strings.Join(drifted, "; ")
+		if i.GetCondition(ispnv1.ConditionDrifted).Message != msg {
+			ctx.EventRecorder().Event(i, corev1.EventTypeWarning, string(ispnv1.ConditionDrifted), msg)
+		}
+		_ = ctx.UpdateInfinispan(func() {
+			i.SetCondition(ispnv1.ConditionDrifted, metav1.ConditionTrue, msg)
+		})
+	} else if i.IsConditionTrue(ispnv1.ConditionDrifted) {
+		_ = ctx.UpdateInfinispan(func() {
+			i.SetCondition(ispnv1.ConditionDrifted, metav1.ConditionFalse, "")
+		})
+	}
+}
+
+// driftManagers returns the sorted names of the field managers, other than the operator, that own the spec or data of a
+// resource
+func driftManagers(entries []metav1.ManagedFieldsEntry) []string {
+	managers := map[string]struct{}{}
+	for _, entry := range entries {
+		if entry.Manager == kube.OperatorFieldManager || entry.FieldsV1 == nil {
+			continue
+		}
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		for _, field := range driftFields {
+			if _, ok := fields[field]; ok {
+				managers[entry.Manager] = struct{}{}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(managers))
+	for manager := range managers {
+		names = append(names, manager)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// driftedFields returns the fields of the spec or data of a resource that are owned by field managers other than the
+// operator, in the FieldsV1 format
+func driftedFields(entries []metav1.ManagedFieldsEntry) map[string]interface{} {
+	drifted := map[string]interface{}{}
+	for _, entry := range entries {
+		if entry.Manager == kube.OperatorFieldManager || entry.FieldsV1 == nil {
+			continue
+		}
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		for _, field := range driftFields {
+			if set, ok := fields[field].(map[string]interface{}); ok {
+				mergeFields(drifted, map[string]interface{}{field: set})
+			}
+		}
+	}
+	return drifted
+}
+
+func mergeFields(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcSet, _ := value.(map[string]interface{})
+		dstSet, ok := dst[key].(map[string]interface{})
+		if !ok {
+			dstSet = map[string]interface{}{}
+			dst[key] = dstSet
+		}
+		mergeFields(dstSet, srcSet)
+	}
+}
+
+// revertDrift sets the fields of obj to their value in desired, removing the fields that desired does not define.
+// Returns true if obj was modified
+func revertDrift(obj, desired client.Object, fields map[string]interface{}) (bool, error) {
+	current, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return false, err
+	}
+	want, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return false, err
+	}
+	reverted := runtime.DeepCopyJSON(current)
+	revertMap(reverted, want, fields)
+	if equality.Semantic.DeepEqual(current, reverted) {
+		return false, nil
+	}
+	// Convert to a new object, so that the removed fields are not retained
+	result := reflect.New(reflect.TypeOf(obj).Elem()).Interface()
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(reverted, result); err != nil {
+		return false, err
+	}
+	reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(result).Elem())
+	return true, nil
+}
+
+// revertMap reverts the fields of a map, e.g. "f:replicas", and of the lists that it contains
+func revertMap(obj, desired map[string]interface{}, fields map[string]interface{}) {
+	for key, value := range fields {
+		if !strings.HasPrefix(key, "f:") {
+			continue
+		}
+		name := strings.TrimPrefix(key, "f:")
+		set, _ := value.(map[string]interface{})
+		desiredValue, defined := desired[name]
+		switch current := obj[name].(type) {
+		case map[string]interface{}:
+			if len(set) > 0 {
+				desiredMap, _ := desiredValue.(map[string]interface{})
+				revertMap(current, desiredMap, set)
+				continue
+			}
+		case []interface{}:
+			if len(set) > 0 {
+				desiredList, _ := desiredValue.([]interface{})
+				obj[name] = revertList(current, desiredList, set)
+				continue
+			}
+		}
+		if defined {
+			obj[name] = runtime.DeepCopyJSONValue(desiredValue)
+		} else {
+			delete(obj, name)
+		}
+	}
+}
+
+// revertList reverts the items of an associative list, e.g. "k:{"name":"infinispan"}", or of a set, e.g. "v:value".
+// Items that were added by other field managers and that are not desired are removed
+func revertList(obj, desired []interface{}, fields map[string]interface{}) []interface{} {
+	for key, value := range fields {
+		set, _ := value.(map[string]interface{})
+		var match func(item interface{}) bool
+		switch {
+		case strings.HasPrefix(key, "k:"):
+			itemKey := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(key, "k:")), &itemKey); err != nil {
+				continue
+			}
+			match = func(item interface{}) bool {
+				m, ok := item.(map[string]interface{})
+				if !ok {
+					return false
+				}
+				for k, v := range itemKey {
+					if fmt.Sprint(m[k]) != fmt.Sprint(v) {
+						return false
+					}
+				}
+				return true
+			}
+		case strings.HasPrefix(key, "v:"):
+			var itemValue interface{}
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(key, "v:")), &itemValue); err != nil {
+				continue
+			}
+			match = func(item interface{}) bool {
+				return fmt.Sprint(item) == fmt.Sprint(itemValue)
+			}
+		default:
+			continue
+		}
+
+		index := indexOf(obj, match)
+		if index < 0 {
+			continue
+		}
+		desiredIndex := indexOf(desired, match)
+		if desiredIndex < 0 {
+			if _, owned := set["."]; owned || strings.HasPrefix(key, "v:") {
+				obj = append(obj[:index], obj[index+1:]...)
+			}
+			continue
+		}
+		current, ok := obj[index].(map[string]interface{})
+		desiredItem, _ := desired[desiredIndex].(map[string]interface{})
+		if ok {
+			revertMap(current, desiredItem, set)
+		}
+	}
+	return obj
+}
+
+func indexOf(list []interface{}, match func(item interface{}) bool) int {
+	for i, item := range list {
+		if match(item) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,108 @@
+package manage
+
+import (
+	"testing"
+
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func entry(manager, fields string) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  metav1.ManagedFieldsOperationUpdate,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+	}
+}
+
+func TestDriftManagers(t *testing.T) {
+	entries := []metav1.ManagedFieldsEntry{
+		entry(kube.OperatorFieldManager, `{"f:spec":{"f:replicas":{}}}`),
+		entry("kube-controller-manager", `{"f:status":{"f:replicas":{}}}`),
+		entry("service-ca-operator", `{"f:metadata":{"f:annotations":{}}}`),
+	}
+	assert.Empty(t, driftManagers(entries))
+
+	entries = append(entries,
+		entry("kubectl-edit", `{"f:spec":{"f:template":{}}}`),
+		entry("kubectl-client-side-apply", `{"f:data":{"f:infinispan.xml":{}}}`),
+		entry("kubectl-edit", `{"f:spec":{"f:replicas":{}}}`),
+	)
+	assert.Equal(t, []string{"kubectl-client-side-apply", "kubectl-edit"}, driftManagers(entries))
+}
+
+func TestRevertServiceDrift(t *testing.T) {
+	desired := &corev1.Service{Spec: corev1.ServiceSpec{
+		Type:  corev1.ServiceTypeClusterIP,
+		Ports: []corev1.ServicePort{{Name: "infinispan-adm", Port: 11223, Protocol: corev1.ProtocolTCP}},
+	}}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "example-infinispan-admin",
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				entry(kube.OperatorFieldManager, `{"f:spec":{"f:ports":{"k:{\"port\":11223,\"protocol\":\"TCP\"}":{".":{},"f:name":{},"f:port":{}}}}}`),
+				entry("kubectl-edit", `{"f:spec":{"f:type":{},"f:sessionAffinity":{},"f:ports":{"k:{\"port\":8080,\"protocol\":\"TCP\"}":{".":{},"f:port":{},"f:protocol":{}}}}}`),
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:            corev1.ServiceTypeNodePort,
+			SessionAffinity: corev1.ServiceAffinityClientIP,
+			ClusterIP:       "10.0.0.1",
+			Ports: []corev1.ServicePort{
+				{Name: "infinispan-adm", Port: 11223, Protocol: corev1.ProtocolTCP},
+				{Port: 8080, Protocol: corev1.ProtocolTCP},
+			},
+		},
+	}
+	reverted, err := revertDrift(svc, desired, driftedFields(svc.ManagedFields))
+	require.NoError(t, err)
+	assert.True(t, reverted)
+	assert.Equal(t, "example-infinispan-admin", svc.Name)
+	assert.Equal(t, corev1.ServiceSpec{
+		Type:      corev1.ServiceTypeClusterIP,
+		ClusterIP: "10.0.0.1",
+		Ports:     []corev1.ServicePort{{Name: "infinispan-adm", Port: 11223, Protocol: corev1.ProtocolTCP}},
+	}, svc.Spec)
+
+	// The fields that have the desired values are not modified
+	reverted, err = revertDrift(svc, desired, driftedFields([]metav1.ManagedFieldsEntry{entry("kubectl-edit", `{"f:spec":{"f:type":{}}}`)}))
+	require.NoError(t, err)
+	assert.False(t, reverted)
+}
+
+func TestRevertStatefulSetDrift(t *testing.T) {
+	statefulSet := func(replicas int32, memory string, env ...corev1.EnvVar) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{
+			Replicas: pointer.Int32Ptr(replicas),
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:      "infinispan",
+				Env:       env,
+				Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memory)}},
+			}}}},
+		}}
+	}
+	desired := statefulSet(3, "1Gi", corev1.EnvVar{Name: "JAVA_OPTIONS", Value: "-Xmx512m"})
+	current := statefulSet(5, "2Gi", corev1.EnvVar{Name: "JAVA_OPTIONS", Value: "-Xmx512m"}, corev1.EnvVar{Name: "DEBUG", Value: "true"})
+	current.Spec.Template.Annotations = map[string]string{"infinispan.org/restartedAt": "2026-10-16T00:00:00Z"}
+	fields := driftedFields([]metav1.ManagedFieldsEntry{
+		entry("kubectl-scale", `{"f:spec":{"f:replicas":{}}}`),
+		entry("kubectl-edit", `{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"infinispan\"}":{"f:env":{"k:{\"name\":\"DEBUG\"}":{".":{},"f:name":{},"f:value":{}}},"f:resources":{"f:limits":{"f:memory":{}}}}}}}}}`),
+	})
+
+	reverted, err := revertDrift(current, desired, fields)
+	require.NoError(t, err)
+	assert.True(t, reverted)
+	assert.Equal(t, int32(3), *current.Spec.Replicas)
+	container := current.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, desired.Spec.Template.Spec.Containers[0].Env, container.Env)
+	assert.True(t, resource.MustParse("1Gi").Equal(container.Resources.Limits[corev1.ResourceMemory]))
+	// The fields that are owned by the operator are not modified
+	assert.Equal(t, "2026-10-16T00:00:00Z", current.Spec.Template.Annotations["infinispan.org/restartedAt"])
+}
@@ -92,12 +92,16 @@ func HotRodRollingUpgrade(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	}
 
 	if upgradeStatus == nil {
+		if !acquireOperationLock(i, ctx, ispnv1.OperationUpgrade) {
+			return
+		}
 		err := ctx.UpdateInfinispan(func() {
 			i.Status.HotRodRollingUpgradeStatus = &ispnv1.HotRodRollingUpgradeStatus{
 				Stage:                 ispnv1.HotRodRollingStageStart,
 				SourceStatefulSetName: i.GetStatefulSetName(),
 				TargetStatefulSetName: getOrCreateTargetStatefulSetName(i),
 			}
+			i.SetCondition(ispnv1.ConditionHotRodRollingUpgrade, metav1.ConditionTrue, string(ispnv1.HotRodRollingStageStart))
 		})
 		if err != nil {
 			log.Error(err, "unable to create initial status")
@@ -211,6 +215,7 @@ func (r *HotRodRollingUpgradeRequest) redirectServices(statefulSet string) error
 func (r *HotRodRollingUpgradeRequest) updateStage(stage ispnv1.HotRodRollingUpgradeStage) error {
 	err := r.ctx.UpdateInfinispan(func() {
 		r.i.Status.HotRodRollingUpgradeStatus.Stage = stage
+		r.i.SetCondition(ispnv1.ConditionHotRodRollingUpgrade, metav1.ConditionTrue, string(stage))
 	})
 	return err
 }
@@ -245,6 +250,7 @@ func (r *HotRodRollingUpgradeRequest) reconcileNewConfigMap() (string, error) {
 	configFiles := ctx.ConfigFiles()
 	configSpec := configFiles.ConfigSpec
 	configSpec.StatefulSetName = targetStatefulSetName
+	configSpec.PingServiceName = fmt.Sprintf("%s-ping", targetStatefulSetName)
 
 	// TODO utilise a version specific configurator once server/operator versions decoupled
 	var serverConfig, zeroConfig string
@@ -491,6 +497,7 @@ func (r *HotRodRollingUpgradeRequest) replaceStatefulSet() error {
 	return r.ctx.UpdateInfinispan(func() {
 		ispn.Status.StatefulSetName = targetStatefulSetName
 		ispn.Status.HotRodRollingUpgradeStatus.Stage = ispnv1.HotRodRollingStageCleanup
+		ispn.SetCondition(ispnv1.ConditionHotRodRollingUpgrade, metav1.ConditionTrue, string(ispnv1.HotRodRollingStageCleanup))
 	})
 }
 
@@ -553,6 +560,7 @@ func (r *HotRodRollingUpgradeRequest) cleanup(statefulSetName string, rollback b
 			ispn.Status.StatefulSetName = rollingUpgradeStatus.SourceStatefulSetName
 		}
 		ispn.Status.HotRodRollingUpgradeStatus = nil
+		ispn.SetCondition(ispnv1.ConditionHotRodRollingUpgrade, metav1.ConditionFalse, "")
 	})
 
 	if err != nil {
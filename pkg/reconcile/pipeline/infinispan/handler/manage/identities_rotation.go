@@ -0,0 +1,137 @@
+package manage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/hash"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/security"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const EventReasonCredentialsRotated = "CredentialsRotated"
+
+// IdentitiesRotation applies changes to the user identities of the endpoint Secret to the running servers without
+// downtime. The users of the default security realm of every server started with previous identities are replaced via
+// the CLI, the security cache of the server is flushed via REST, and the hash of the identities is recorded in the
+// infinispan.org/identities-hash annotation of the pod. If the server cannot flush its security cache, the pods are
+// restarted one at a time by PartitionedRollout instead
+func IdentitiesRotation(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	identities := ctx.ConfigFiles().UserIdentities
+	identitiesHash := hash.HashByte(identities)
+
+	// The identities batch of the security Secret creates the users on startup
+	securitySecret := &corev1.Secret{}
+	if err := ctx.Resources().Load(i.GetInfinispanSecuritySecretName(), securitySecret, pipeline.RetryOnErr); err != nil {
+		return
+	}
+	lastModified := lastModified(securitySecret)
+
+	statefulSet := &appsv1.StatefulSet{}
+	if err := ctx.Resources().Load(i.GetStatefulSetName(), statefulSet, pipeline.RetryOnErr); err != nil {
+		return
+	}
+	if rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+		// Pods restarted by a rollout in progress create the current users
+		return
+	}
+
+	podList := &corev1.PodList{}
+	if err := ctx.Resources().List(i.PodSelectorLabels(), podList, pipeline.RetryOnErr); err != nil {
+		return
+	}
+
+	var script string
+	var rotated []string
+	for _, pod := range podList.Items {
+		if !kube.IsPodReady(pod) || pod.Annotations[consts.AnnotationIdentitiesHash] == identitiesHash {
+			continue
+		}
+		if startedAfter(pod, lastModified) {
+			if err := setPodAnnotation(pod, consts.AnnotationIdentitiesHash, identitiesHash, ctx); err != nil {
+				return
+			}
+			continue
+		}
+
+		if script == "" {
+			var err error
+			userIdentities := identities
+			if i.IsAdminOperationsRestricted() {
+				admin := ctx.ConfigFiles().AdminIdentities
+				if userIdentities, err = security.RestrictUserIdentities(identities, admin.Username, admin.Password); err != nil {
+					ctx.Requeue(fmt.Errorf("unable to restrict user credentials: %w", err))
+					return
+				}
+			}
+			if script, err = identitiesScript(i, userIdentities); err != nil {
+				ctx.Requeue(fmt.Errorf("unable to read user identities: %w", err))
+				return
+			}
+		}
+		if _, err := ctx.Kubernetes().ExecWithOptions(kube.ExecOptions{
+			Container: provision.InfinispanContainer,
+			Command:   []string{"sh", "-c", script},
+			PodName:   pod.Name,
+			Namespace: i.Namespace,
+		}); err != nil {
+			ctx.Requeue(fmt.Errorf("unable to update the users of pod '%s': %w", pod.Name, err))
+			return
+		}
+
+		if err := ctx.InfinispanClientForPod(pod.Name).Server().FlushSecurityCache(); err != nil {
+			if errors.Is(err, api.ErrUnsupported) {
+				ctx.Log().Info("Server does not support flushing its security cache, starting rolling restart")
+				msg := "Rolling restart started to apply the changed credentials, as the server cannot reload them"
+				rollingRestart(i, statefulSet, consts.AnnotationIdentitiesHash, identitiesHash, EventReasonCredentialsRotated, msg, ctx)
+			} else {
+				ctx.Requeue(fmt.Errorf("unable to flush the security cache of pod '%s': %w", pod.Name, err))
+			}
+			return
+		}
+		if err := setPodAnnotation(pod, consts.AnnotationIdentitiesHash, identitiesHash, ctx); err != nil {
+			return
+		}
+		ctx.Log().Info("Users of pod updated", "pod", pod.Name)
+		rotated = append(rotated, pod.Name)
+	}
+
+	if len(rotated) > 0 {
+		msg := fmt.Sprintf("Credentials applied by pods '%s'", strings.Join(rotated, "', '"))
+		ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonCredentialsRotated, msg)
+	}
+}
+
+// identitiesScript returns a shell script that replaces the users of the default security realm with the given
+// identities in a single CLI batch. Temporary users are managed by ShortLivedCredentials and are kept
+func identitiesScript(i *ispnv1.Infinispan, identities []byte) (string, error) {
+	serverRoot := i.ServerRoot()
+	opts := fmt.Sprintf("--users-file cli-users.properties --groups-file cli-groups.properties --server-root %s", serverRoot)
+	create, err := security.IdentitiesCliFileFromSecret(identities, "default", "cli-users.properties", "cli-groups.properties", i.GetPasswordAlgorithms())
+	if err != nil {
+		return "", err
+	}
+	// The CLI resolves the property files against the server root, which is not the working directory of exec commands
+	create = strings.ReplaceAll(create, "\n", fmt.Sprintf(" --server-root %s\n", serverRoot))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "batch=$(mktemp)\n")
+	fmt.Fprintf(&b, "for user in $(sed -n 's/^\\([^#][^=]*\\)=.*/\\1/p' %s/conf/cli-users.properties); do\n", serverRoot)
+	fmt.Fprintf(&b, "  case $user in %s*) ;; *) echo \"user remove $user %s\" >> $batch ;; esac\n", TemporaryUserPrefix, opts)
+	fmt.Fprintf(&b, "done\n")
+	// The identities are written with a quoted here-document, so that passwords are not interpreted by the shell
+	fmt.Fprintf(&b, "cat >> $batch <<'IDENTITIES'\n%sIDENTITIES\n", create)
+	fmt.Fprintf(&b, "status=0\n")
+	fmt.Fprintf(&b, "/opt/infinispan/bin/cli.sh -f $batch || status=$?\n")
+	fmt.Fprintf(&b, "rm -f $batch\n")
+	fmt.Fprintf(&b, "exit $status\n")
+	return b.String(), nil
+}
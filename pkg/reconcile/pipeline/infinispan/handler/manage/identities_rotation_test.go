@@ -0,0 +1,34 @@
+package manage
+
+import (
+	"strings"
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentitiesScript(t *testing.T) {
+	i := &ispnv1.Infinispan{}
+	identities := []byte(`credentials:
+- username: developer
+  password: pa$$'word
+  roles:
+  - admin
+- username: observer
+  password: secret
+`)
+	script, err := identitiesScript(i, identities)
+	require.NoError(t, err)
+
+	root := i.ServerRoot()
+	assert.Contains(t, script, "case $user in temp-*) ;;", "temporary users must be kept")
+	assert.Contains(t, script, "user create developer --realm default -p pa$$'word --users-file cli-users.properties --groups-file cli-groups.properties --groups admin --server-root "+root+"\n")
+	assert.Contains(t, script, "user create observer --realm default -p secret --users-file cli-users.properties --groups-file cli-groups.properties --server-root "+root+"\n")
+	// The users are created from a quoted here-document, after the existing users are removed
+	assert.Less(t, strings.Index(script, "user remove"), strings.Index(script, "<<'IDENTITIES'"))
+
+	_, err = identitiesScript(i, []byte("credentials: ["))
+	assert.Error(t, err)
+}
@@ -0,0 +1,330 @@
+package manage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/infinispan/infinispan-operator/pkg/mime"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	EventReasonIntegrityCheckDiscrepancies = "IntegrityCheckDiscrepancies"
+
+	// integrityCheckPollPeriod the time between reconciliations while an integrity check is running
+	integrityCheckPollPeriod = 10 * time.Second
+)
+
+var integrityCheckDiscrepancies = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "infinispan_operator_integrity_check_discrepancies",
+		Help: "The number of sampled keys of a replicated cache that don't have the same value on all pods",
+	},
+	[]string{"namespace", "cluster", "cache"},
+)
+
+// integrityChecks the integrity check of each cluster that is running, or that has completed but whose results are
+// not reported yet, by the UID of the Infinispan CR so that the check of a deleted cluster is never reported for a
+// new cluster with the same name
+var integrityChecks = struct {
+	sync.Mutex
+	m map[types.UID]*integrityCheck
+}{m: make(map[types.UID]*integrityCheck)}
+
+type integrityCheck struct {
+	cluster types.NamespacedName
+	// cancel stops the check once the Infinispan CR is deleted
+	cancel context.CancelFunc
+	// done is closed once the results of all caches are in the report
+	done   chan struct{}
+	report map[string]*IntegrityCheckResult
+}
+
+func init() {
+	metrics.Registry.MustRegister(integrityCheckDiscrepancies)
+}
+
+// IntegrityCheckResult the result of the integrity check of a single cache, stored in the report ConfigMap
+type IntegrityCheckResult struct {
+	SampledKeys      int      `json:"sampledKeys"`
+	InconsistentKeys []string `json:"inconsistentKeys,omitempty"`
+	Error            string   `json:"error,omitempty"`
+}
+
+// IntegrityCheck periodically samples keys of replicated caches and verifies that each pod holds the same value.
+// The check runs in the background, so that reconciliation isn't blocked while the keys are read from every pod, and
+// is stopped once consts.IntegrityCheckTimeout has elapsed. Once the check is complete the results of each cache are
+// written to a report ConfigMap and exposed as a Prometheus metric. The check is only known to the operator process
+// that started it, so a check interrupted by a restart of the operator is started again
+func IntegrityCheck(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	integrityChecks.Lock()
+	check := integrityChecks.m[i.UID]
+	integrityChecks.Unlock()
+
+	if check != nil {
+		select {
+		case <-check.done:
+			reportIntegrityCheck(i, check.report, ctx)
+			integrityChecks.Lock()
+			delete(integrityChecks.m, i.UID)
+			integrityChecks.Unlock()
+		default:
+			requeuePeriodic(ctx, integrityCheckPollPeriod)
+		}
+		return
+	}
+
+	interval := i.IntegrityCheckInterval()
+	if status := i.Status.IntegrityCheck; status != nil {
+		if next := status.LastCheckTime.Add(interval); time.Now().Before(next) {
+			requeuePeriodic(ctx, time.Until(next))
+			return
+		}
+	}
+
+	podList, err := ctx.InfinispanPods()
+	if err != nil {
+		return
+	}
+	var clients []api.Infinispan
+	for _, pod := range podList.Items {
+		if kube.IsPodReady(pod) {
+			clients = append(clients, ctx.InfinispanClientForPod(pod.Name))
+		}
+	}
+	if len(clients) == 0 {
+		return
+	}
+
+	caches, err := integrityCheckCaches(i, clients[0])
+	if err != nil {
+		ctx.Requeue(fmt.Errorf("unable to determine caches for integrity check: %w", err))
+		return
+	}
+
+	log := ctx.Log()
+	log.Info("Starting integrity check", "caches", caches)
+	checkCtx, cancel := context.WithTimeout(context.Background(), consts.IntegrityCheckTimeout)
+	check = &integrityCheck{
+		cluster: types.NamespacedName{Namespace: i.Namespace, Name: i.Name},
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	integrityChecks.Lock()
+	integrityChecks.m[i.UID] = check
+	integrityChecks.Unlock()
+
+	sampleSize := i.IntegrityCheckSampleSize()
+	go func() {
+		defer close(check.done)
+		defer cancel()
+		check.report = make(map[string]*IntegrityCheckResult, len(caches))
+		for _, cache := range caches {
+			result := checkCacheIntegrity(checkCtx, cache, clients, sampleSize)
+			if result.Error != "" {
+				log.Info("Unable to complete integrity check", "cache", cache, "error", result.Error)
+			}
+			check.report[cache] = result
+		}
+	}()
+	requeuePeriodic(ctx, integrityCheckPollPeriod)
+}
+
+// RemoveIntegrityCheck stops and discards the integrity check of the cluster once the Infinispan CR is deleted
+func RemoveIntegrityCheck(namespace, name string) {
+	cluster := types.NamespacedName{Namespace: namespace, Name: name}
+	integrityChecks.Lock()
+	defer integrityChecks.Unlock()
+	for uid, check := range integrityChecks.m {
+		if check.cluster == cluster {
+			check.cancel()
+			delete(integrityChecks.m, uid)
+		}
+	}
+}
+
+// reportIntegrityCheck writes the results of a completed integrity check to the report ConfigMap, the metrics and the
+// status of the Infinispan CR
+func reportIntegrityCheck(i *ispnv1.Infinispan, results map[string]*IntegrityCheckResult, ctx pipeline.Context) {
+	var discrepancies int32
+	report := make(map[string]string, len(results))
+	for cache, result := range results {
+		discrepancies += int32(len(result.InconsistentKeys))
+		integrityCheckDiscrepancies.WithLabelValues(i.Namespace, i.Name, cache).Set(float64(len(result.InconsistentKeys)))
+
+		data, err := json.Marshal(result)
+		if err != nil {
+			ctx.Requeue(err)
+			return
+		}
+		report[cache] = string(data)
+	}
+
+	configmap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      i.GetIntegrityReportConfigMapName(),
+			Namespace: i.Namespace,
+		},
+	}
+	mutateFn := func() error {
+		// Remove the metrics of caches that no longer exist
+		for cache := range configmap.Data {
+			if _, exists := report[cache]; !exists {
+				integrityCheckDiscrepancies.DeleteLabelValues(i.Namespace, i.Name, cache)
+			}
+		}
+		configmap.Data = report
+		configmap.Labels = i.Labels("infinispan-configmap-integrity-report")
+		return nil
+	}
+	if _, err := ctx.Resources().CreateOrUpdate(configmap, true, mutateFn, pipeline.RetryOnErr); err != nil {
+		return
+	}
+
+	if discrepancies > 0 {
+		msg := fmt.Sprintf("Integrity check found %d inconsistent keys, see ConfigMap '%s'", discrepancies, configmap.Name)
+		ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonIntegrityCheckDiscrepancies, msg)
+	}
+	ctx.Log().Info("Integrity check complete", "discrepancies", discrepancies)
+
+	if err := ctx.UpdateInfinispan(func() {
+		i.Status.IntegrityCheck = &ispnv1.IntegrityCheckStatus{
+			LastCheckTime:   metav1.Now(),
+			Discrepancies:   discrepancies,
+			ReportConfigMap: configmap.Name,
+		}
+	}); err != nil {
+		return
+	}
+	requeuePeriodic(ctx, i.IntegrityCheckInterval())
+}
+
+// requeuePeriodic schedules the next periodic check, unless an earlier requeue has already been requested
+func requeuePeriodic(ctx pipeline.Context, delay time.Duration) {
+	if status := ctx.FlowStatus(); !status.Retry || status.Delay > delay {
+		ctx.RequeueEventually(delay)
+	}
+}
+
+// integrityCheckCaches returns the caches configured in the spec, or all user defined replicated caches
+func integrityCheckCaches(i *ispnv1.Infinispan, ispnClient api.Infinispan) ([]string, error) {
+	if caches := i.Spec.IntegrityCheck.Caches; len(caches) > 0 {
+		return caches, nil
+	}
+	names, err := ispnClient.Caches().Names()
+	if err != nil {
+		return nil, err
+	}
+	var caches []string
+	for _, name := range names {
+		// Ignore internal caches
+		if strings.HasPrefix(name, "___") {
+			continue
+		}
+		config, err := ispnClient.Cache(name).Config(mime.ApplicationJson)
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(config, `"replicated-cache"`) {
+			caches = append(caches, name)
+		}
+	}
+	sort.Strings(caches)
+	return caches, nil
+}
+
+// checkCacheIntegrity compares the value of sampled keys on each pod. Keys with inconsistent values are read a
+// second time so that entries updated during the check are not reported. The check stops once the context is done
+func checkCacheIntegrity(ctx context.Context, cache string, clients []api.Infinispan, sampleSize int) *IntegrityCheckResult {
+	if ctx.Err() != nil {
+		return &IntegrityCheckResult{Error: "check stopped before the cache was checked"}
+	}
+	keysPerPod := make([][]string, len(clients))
+	for idx, client := range clients {
+		keys, err := client.Cache(cache).Keys(sampleSize)
+		if err != nil {
+			return &IntegrityCheckResult{Error: err.Error()}
+		}
+		keysPerPod[idx] = keys
+	}
+	keys := sampleKeys(keysPerPod, sampleSize)
+
+	result := &IntegrityCheckResult{SampledKeys: len(keys)}
+	for idx, key := range keys {
+		if ctx.Err() != nil {
+			result.Error = fmt.Sprintf("check stopped after %d of %d keys", idx, len(keys))
+			return result
+		}
+		consistent, err := isKeyConsistent(cache, key, clients)
+		if err == nil && !consistent {
+			consistent, err = isKeyConsistent(cache, key, clients)
+		}
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		if !consistent {
+			result.InconsistentKeys = append(result.InconsistentKeys, key)
+		}
+	}
+	return result
+}
+
+// sampleKeys returns at most sampleSize distinct keys, taken from the keys of each pod in turn so that keys which are
+// missing from some pods are also sampled
+func sampleKeys(keysPerPod [][]string, sampleSize int) []string {
+	var sampled []string
+	seen := make(map[string]struct{})
+	for pos := 0; len(sampled) < sampleSize; pos++ {
+		remaining := false
+		for _, keys := range keysPerPod {
+			if pos >= len(keys) {
+				continue
+			}
+			remaining = true
+			if _, exists := seen[keys[pos]]; !exists && len(sampled) < sampleSize {
+				seen[keys[pos]] = struct{}{}
+				sampled = append(sampled, keys[pos])
+			}
+		}
+		if !remaining {
+			break
+		}
+	}
+	return sampled
+}
+
+func isKeyConsistent(cache, key string, clients []api.Infinispan) (bool, error) {
+	var expected *[sha256.Size]byte
+	for _, client := range clients {
+		val, exists, err := client.Cache(cache).Get(key)
+		if err != nil {
+			return false, err
+		}
+		var hash [sha256.Size]byte
+		if exists {
+			hash = sha256.Sum256([]byte(val))
+		}
+		if expected == nil {
+			expected = &hash
+		} else if *expected != hash {
+			return false, nil
+		}
+	}
+	return true, nil
+}
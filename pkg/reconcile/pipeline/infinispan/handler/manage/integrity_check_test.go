@@ -0,0 +1,192 @@
+package manage
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// podEntries the entries of a single cache held by each pod
+type podEntries []map[string]string
+
+func (e podEntries) clients() []api.Infinispan {
+	clients := make([]api.Infinispan, len(e))
+	for idx, entries := range e {
+		clients[idx] = &podClient{entries: entries}
+	}
+	return clients
+}
+
+type podClient struct {
+	api.Infinispan
+	entries map[string]string
+}
+
+func (c *podClient) Cache(string) api.Cache {
+	return &podCache{entries: c.entries}
+}
+
+type podCache struct {
+	api.Cache
+	entries map[string]string
+}
+
+func (c *podCache) Get(key string) (string, bool, error) {
+	val, exists := c.entries[key]
+	return val, exists, nil
+}
+
+func (c *podCache) Keys(limit int) ([]string, error) {
+	var keys []string
+	for k := range c.entries {
+		if len(keys) == limit {
+			break
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func TestCheckCacheIntegrity(t *testing.T) {
+	entries := podEntries{
+		{"k1": "v1", "k2": "v2"},
+		{"k1": "v1", "k2": "stale", "k3": "v3"},
+		{"k1": "v1", "k2": "v2"},
+	}
+	ctx := context.TODO()
+	result := checkCacheIntegrity(ctx, "cache", entries.clients(), 10)
+	assert.Equal(t, 3, result.SampledKeys, "keys are sampled from every pod")
+	assert.ElementsMatch(t, []string{"k2", "k3"}, result.InconsistentKeys)
+	assert.Empty(t, result.Error)
+
+	result = checkCacheIntegrity(ctx, "cache", entries[:1].clients(), 2)
+	assert.Equal(t, 2, result.SampledKeys)
+	assert.Empty(t, result.InconsistentKeys)
+
+	stopped, cancel := context.WithCancel(ctx)
+	cancel()
+	result = checkCacheIntegrity(stopped, "cache", entries.clients(), 10)
+	assert.Zero(t, result.SampledKeys)
+	assert.NotEmpty(t, result.Error)
+}
+
+func TestSampleKeys(t *testing.T) {
+	keysPerPod := [][]string{{"k1", "k2", "k3"}, {"k1", "k4"}, {}}
+	assert.Equal(t, []string{"k1", "k2", "k4", "k3"}, sampleKeys(keysPerPod, 10))
+	assert.Equal(t, []string{"k1", "k2", "k4"}, sampleKeys(keysPerPod, 3))
+	assert.Empty(t, sampleKeys(nil, 3))
+}
+
+// integrityContext provides the pods of a cluster whose caches hold podEntries
+type integrityContext struct {
+	pipeline.Context
+	entries   podEntries
+	resources *integrityResources
+	recorder  *record.FakeRecorder
+	flow      pipeline.FlowStatus
+}
+
+func (c *integrityContext) Log() logr.Logger                     { return ctrl.Log }
+func (c *integrityContext) EventRecorder() record.EventRecorder  { return c.recorder }
+func (c *integrityContext) Resources() pipeline.Resources        { return c.resources }
+func (c *integrityContext) UpdateInfinispan(update func()) error { update(); return nil }
+func (c *integrityContext) FlowStatus() pipeline.FlowStatus      { return c.flow }
+func (c *integrityContext) Requeue(err error)                    { c.flow = pipeline.FlowStatus{Retry: true, Err: err} }
+func (c *integrityContext) RequeueEventually(delay time.Duration) {
+	c.flow = pipeline.FlowStatus{Retry: true, Delay: delay}
+}
+
+func (c *integrityContext) InfinispanPods() (*corev1.PodList, error) {
+	podList := &corev1.PodList{}
+	for idx := range c.entries {
+		podList.Items = append(podList.Items, corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: string(rune('a' + idx))},
+			Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}},
+		})
+	}
+	return podList, nil
+}
+
+func (c *integrityContext) InfinispanClientForPod(name string) api.Infinispan {
+	return &podClient{entries: c.entries[name[0]-'a']}
+}
+
+type integrityResources struct {
+	pipeline.Resources
+	report *corev1.ConfigMap
+}
+
+func (r *integrityResources) CreateOrUpdate(obj client.Object, _ bool, mutate func() error, _ ...func(config *pipeline.ResourcesConfig)) (pipeline.OperationResult, error) {
+	if err := mutate(); err != nil {
+		return pipeline.OperationResultNone, err
+	}
+	r.report = obj.(*corev1.ConfigMap)
+	return pipeline.OperationResultCreated, nil
+}
+
+func TestIntegrityCheck(t *testing.T) {
+	i := &ispnv1.Infinispan{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan", Namespace: "testing-namespace", UID: "cluster-uid"},
+		Spec: ispnv1.InfinispanSpec{
+			IntegrityCheck: &ispnv1.IntegrityCheckSpec{Caches: []string{"cache"}},
+		},
+	}
+	ctx := &integrityContext{
+		entries:   podEntries{{"k1": "v1"}, {"k1": "stale"}},
+		resources: &integrityResources{},
+		recorder:  record.NewFakeRecorder(10),
+	}
+	defer integrityCheckDiscrepancies.DeleteLabelValues(i.Namespace, i.Name, "cache")
+
+	// The check is started in the background and the handler is requeued until it completes
+	IntegrityCheck(i, ctx)
+	assert.Equal(t, integrityCheckPollPeriod, ctx.flow.Delay)
+	integrityChecks.Lock()
+	check := integrityChecks.m[i.UID]
+	integrityChecks.Unlock()
+	require.NotNil(t, check)
+	<-check.done
+
+	ctx.flow = pipeline.FlowStatus{}
+	IntegrityCheck(i, ctx)
+	require.NotNil(t, i.Status.IntegrityCheck)
+	assert.Equal(t, int32(1), i.Status.IntegrityCheck.Discrepancies)
+	assert.Contains(t, ctx.resources.report.Data["cache"], `"inconsistentKeys":["k1"]`)
+	assert.Contains(t, <-ctx.recorder.Events, EventReasonIntegrityCheckDiscrepancies)
+	assert.Equal(t, i.IntegrityCheckInterval(), ctx.flow.Delay, "the next check is scheduled")
+	integrityChecks.Lock()
+	assert.NotContains(t, integrityChecks.m, i.UID, "the reported check is discarded")
+	integrityChecks.Unlock()
+}
+
+func TestRemoveIntegrityCheck(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	integrityChecks.Lock()
+	integrityChecks.m["cluster-uid"] = &integrityCheck{
+		cluster: types.NamespacedName{Namespace: "ns", Name: "example"},
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	integrityChecks.Unlock()
+
+	RemoveIntegrityCheck("ns", "example")
+	assert.Error(t, ctx.Err(), "the running check is stopped")
+	integrityChecks.Lock()
+	assert.NotContains(t, integrityChecks.m, types.UID("cluster-uid"))
+	integrityChecks.Unlock()
+}
@@ -0,0 +1,185 @@
+package manage
+
+import (
+	"sync"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/version"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	clusterInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "infinispan_operator_cluster_info",
+			Help: "Always 1, with the image and server version of the cluster as labels. The version is 'unknown' if the image tag does not identify a version",
+		},
+		[]string{"namespace", "cluster", "image", "version"},
+	)
+	clusterReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "infinispan_operator_cluster_replicas",
+			Help: "The number of pods configured with spec.replicas",
+		},
+		[]string{"namespace", "cluster"},
+	)
+	clusterReadyReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "infinispan_operator_cluster_ready_replicas",
+			Help: "The number of pods ready to serve requests",
+		},
+		[]string{"namespace", "cluster"},
+	)
+	clusterWellFormed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "infinispan_operator_cluster_well_formed",
+			Help: "1 if the WellFormed condition of the cluster is true, otherwise 0",
+		},
+		[]string{"namespace", "cluster"},
+	)
+	clusterUpgradePending = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "infinispan_operator_cluster_upgrade_pending",
+			Help: "1 if an upgrade of the cluster is in progress or pods do not run the image the cluster is configured with, otherwise 0",
+		},
+		[]string{"namespace", "cluster"},
+	)
+
+	// clusterInfoLabels records the labels of the clusterInfo series of each cluster, so that the series is replaced
+	// when the image of the cluster changes
+	clusterInfoLabels = map[types.NamespacedName]prometheus.Labels{}
+	clusterInfoMutex  sync.Mutex
+)
+
+func init() {
+	metrics.Registry.MustRegister(clusterInfo, clusterReplicas, clusterReadyReplicas, clusterWellFormed, clusterUpgradePending)
+}
+
+// ClusterInventoryMetrics exposes the version, replicas, health and pending upgrades of the cluster as Prometheus
+// metrics, so that all clusters managed by the operator can be summarized without listing the Infinispan CRs of every
+// namespace. Together with infinispan_operator_last_backup_timestamp_seconds the metrics form the inventory of the
+// clusters. The handler runs before any handler that can stop the pipeline, so that the metrics of unhealthy clusters
+// are also updated
+func ClusterInventoryMetrics(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	// The StatefulSet is only created by the provision handlers, so a new cluster has no pods yet
+	statefulSet := &appsv1.StatefulSet{}
+	if err := ctx.Resources().Load(i.GetStatefulSetName(), statefulSet, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+		return
+	}
+
+	podList := &corev1.PodList{}
+	if !statefulSet.CreationTimestamp.IsZero() {
+		var err error
+		if podList, err = ctx.InfinispanPods(); err != nil {
+			return
+		}
+	}
+
+	image := i.ImageName()
+	setClusterInfo(i.Namespace, i.Name, prometheus.Labels{
+		"namespace": i.Namespace,
+		"cluster":   i.Name,
+		"image":     image,
+		"version":   operandVersion(image),
+	})
+	clusterReplicas.WithLabelValues(i.Namespace, i.Name).Set(float64(i.Spec.Replicas))
+	clusterReadyReplicas.WithLabelValues(i.Namespace, i.Name).Set(float64(len(i.Status.PodStatus.Ready)))
+	clusterWellFormed.WithLabelValues(i.Namespace, i.Name).Set(boolToFloat(i.IsConditionTrue(ispnv1.ConditionWellFormed)))
+	clusterUpgradePending.WithLabelValues(i.Namespace, i.Name).Set(boolToFloat(upgradePending(i, podList)))
+}
+
+// RemoveClusterMetrics removes the series of all per-cluster metrics of the operator once the Infinispan CR is deleted
+func RemoveClusterMetrics(namespace, name string) {
+	clusterInfoMutex.Lock()
+	if labels, ok := clusterInfoLabels[types.NamespacedName{Namespace: namespace, Name: name}]; ok {
+		clusterInfo.Delete(labels)
+		delete(clusterInfoLabels, types.NamespacedName{Namespace: namespace, Name: name})
+	}
+	clusterInfoMutex.Unlock()
+
+	for _, gauge := range []*prometheus.GaugeVec{clusterReplicas, clusterReadyReplicas, clusterWellFormed, clusterUpgradePending, lastBackupTimestamp, vulnerableOperand} {
+		gauge.DeleteLabelValues(namespace, name)
+	}
+	for _, gauge := range []*prometheus.GaugeVec{integrityCheckDiscrepancies, shadowReplicationLag} {
+		deleteClusterSeries(gauge, namespace, name)
+	}
+}
+
+// deleteClusterSeries deletes all series of the cluster from a metric that has labels in addition to the namespace and
+// cluster, e.g. the cache, as the values of the additional labels aren't known once the Infinispan CR is deleted
+func deleteClusterSeries(gauge *prometheus.GaugeVec, namespace, name string) {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		gauge.Collect(ch)
+		close(ch)
+	}()
+
+	// The series are deleted once collected, as Collect holds the lock of the metric
+	var series []prometheus.Labels
+	for m := range ch {
+		metric := &dto.Metric{}
+		if err := m.Write(metric); err != nil {
+			continue
+		}
+		labels := prometheus.Labels{}
+		for _, pair := range metric.GetLabel() {
+			labels[pair.GetName()] = pair.GetValue()
+		}
+		if labels["namespace"] == namespace && labels["cluster"] == name {
+			series = append(series, labels)
+		}
+	}
+	for _, labels := range series {
+		gauge.Delete(labels)
+	}
+}
+
+func setClusterInfo(namespace, name string, labels prometheus.Labels) {
+	clusterInfoMutex.Lock()
+	defer clusterInfoMutex.Unlock()
+	key := types.NamespacedName{Namespace: namespace, Name: name}
+	if existing, ok := clusterInfoLabels[key]; ok && existing["image"] != labels["image"] {
+		clusterInfo.Delete(existing)
+	}
+	clusterInfoLabels[key] = labels
+	clusterInfo.With(labels).Set(1)
+}
+
+// operandVersion returns the server version identified by the tag of the image, or "unknown"
+func operandVersion(image string) string {
+	if tag, ok := kube.ImageTag(image); ok {
+		if v, ok := version.FromImageTag(tag); ok {
+			return v.String()
+		}
+	}
+	return "unknown"
+}
+
+// upgradePending returns true if the cluster is being upgraded, or if any pod runs an image other than the image the
+// cluster is configured with, e.g. because the default image of the operator has changed
+func upgradePending(i *ispnv1.Infinispan, podList *corev1.PodList) bool {
+	if i.IsUpgradeCondition() || i.IsConditionTrue(ispnv1.ConditionHotRodRollingUpgrade) {
+		return true
+	}
+	for _, pod := range podList.Items {
+		if container := kube.GetContainer(provision.InfinispanContainer, &pod.Spec); container != nil && container.Image != i.ImageName() {
+			return true
+		}
+	}
+	return false
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
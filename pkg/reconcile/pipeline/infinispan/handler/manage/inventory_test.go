@@ -0,0 +1,54 @@
+package manage
+
+import (
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOperandVersion(t *testing.T) {
+	assert.Equal(t, "13.0.10", operandVersion("quay.io/infinispan/server:13.0.10.Final"))
+	assert.Equal(t, "unknown", operandVersion("quay.io/infinispan/server:latest"))
+	assert.Equal(t, "unknown", operandVersion("quay.io/infinispan/server@sha256:0123"))
+}
+
+func TestUpgradePending(t *testing.T) {
+	image := "quay.io/infinispan/server:13.0.10.Final"
+	podList := func(image string) *corev1.PodList {
+		return &corev1.PodList{Items: []corev1.Pod{{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: provision.InfinispanContainer, Image: image}}},
+		}}}
+	}
+	i := &ispnv1.Infinispan{Spec: ispnv1.InfinispanSpec{Image: &image}}
+	assert.False(t, upgradePending(i, podList(image)))
+	assert.False(t, upgradePending(i, &corev1.PodList{}))
+	assert.True(t, upgradePending(i, podList("quay.io/infinispan/server:13.0.9.Final")))
+
+	i.SetCondition(ispnv1.ConditionUpgrade, metav1.ConditionTrue, "")
+	assert.True(t, upgradePending(i, podList(image)))
+}
+
+func TestRemoveClusterMetrics(t *testing.T) {
+	labels := func(image string) prometheus.Labels {
+		return prometheus.Labels{"namespace": "ns", "cluster": "example", "image": image, "version": operandVersion(image)}
+	}
+	setClusterInfo("ns", "example", labels("quay.io/infinispan/server:13.0.9.Final"))
+	setClusterInfo("ns", "example", labels("quay.io/infinispan/server:13.0.10.Final"))
+	assert.Equal(t, 1, testutil.CollectAndCount(clusterInfo), "the series of the previous image is replaced")
+	clusterReplicas.WithLabelValues("ns", "example").Set(3)
+	integrityCheckDiscrepancies.WithLabelValues("ns", "example", "sessions").Set(1)
+	integrityCheckDiscrepancies.WithLabelValues("ns", "other", "sessions").Set(1)
+	shadowReplicationLag.WithLabelValues("ns", "example", "target", "sessions").Set(10)
+
+	RemoveClusterMetrics("ns", "example")
+	assert.Equal(t, 0, testutil.CollectAndCount(clusterInfo))
+	assert.Equal(t, 0, testutil.CollectAndCount(clusterReplicas))
+	assert.Equal(t, 1, testutil.CollectAndCount(integrityCheckDiscrepancies), "the series of other clusters are kept")
+	assert.Equal(t, 0, testutil.CollectAndCount(shadowReplicationLag))
+}
@@ -0,0 +1,56 @@
+package manage
+
+import (
+	"fmt"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/version"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const EventReasonIncompatibleOperand = "IncompatibleOperand"
+
+// OperandCompatibility stops the reconciliation of the cluster if the server version of its image is not part of the
+// compatibility matrix of the operator, setting the IncompatibleOperand condition with the nearest supported version.
+// Images whose tag does not identify a version, e.g. latest or a digest, are assumed to be compatible
+func OperandCompatibility(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	msg := incompatibleOperand(i.ImageName())
+	if msg == "" {
+		if i.IsConditionTrue(ispnv1.ConditionIncompatibleOperand) {
+			_ = ctx.UpdateInfinispan(func() {
+				i.SetCondition(ispnv1.ConditionIncompatibleOperand, metav1.ConditionFalse, "")
+			})
+		}
+		return
+	}
+
+	if !i.IsConditionTrue(ispnv1.ConditionIncompatibleOperand) {
+		ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonIncompatibleOperand, msg)
+	}
+	ctx.Log().Info("Reconciliation stopped", "reason", msg)
+	if err := ctx.UpdateInfinispan(func() {
+		i.SetCondition(ispnv1.ConditionIncompatibleOperand, metav1.ConditionTrue, msg)
+	}); err != nil {
+		return
+	}
+	// The cluster is reconciled again once spec.image is updated
+	ctx.Stop(nil)
+}
+
+// incompatibleOperand returns the reason why the server of the image cannot be managed, or an empty string if the
+// server version is supported or unknown
+func incompatibleOperand(image string) string {
+	tag, ok := kube.ImageTag(image)
+	if !ok {
+		return ""
+	}
+	v, ok := version.FromImageTag(tag)
+	if !ok || version.IsSupported(v) {
+		return ""
+	}
+	return fmt.Sprintf("Server version %s of image '%s' is not supported by this version of the operator, the nearest supported version is %s",
+		v, image, version.NearestSupported(v))
+}
@@ -0,0 +1,19 @@
+package manage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncompatibleOperand(t *testing.T) {
+	assert.Empty(t, incompatibleOperand("quay.io/infinispan/server:13.0"))
+	assert.Empty(t, incompatibleOperand("quay.io/infinispan/server:13.0.10.Final"))
+	assert.Empty(t, incompatibleOperand("quay.io/infinispan/server:latest"))
+	assert.Empty(t, incompatibleOperand("quay.io/infinispan/server"))
+	assert.Empty(t, incompatibleOperand("quay.io/infinispan/server@sha256:0123456789abcdef"))
+	assert.Equal(t,
+		"Server version 14.0.1 of image 'quay.io/infinispan/server:14.0.1.Final' is not supported by this version of the operator, the nearest supported version is 13.0.x",
+		incompatibleOperand("quay.io/infinispan/server:14.0.1.Final"))
+	assert.Contains(t, incompatibleOperand("localhost:5000/infinispan/server:12.1"), "Server version 12.1.0")
+}
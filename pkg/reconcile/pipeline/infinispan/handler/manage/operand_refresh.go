@@ -0,0 +1,169 @@
+package manage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/cron"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	EventReasonVulnerableOperand = "VulnerableOperand"
+	EventReasonOperandRefresh    = "OperandRefresh"
+
+	OperandRefreshPolicyAutomatic = "Automatic"
+)
+
+var vulnerableOperand = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "infinispan_operator_vulnerable_operand",
+		Help: "1 if pods of the cluster run an operand image superseded for security reasons, otherwise 0",
+	},
+	[]string{"namespace", "cluster"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(vulnerableOperand)
+}
+
+// OperandRefresh reports clusters whose pods run an operand image listed in SUPERSEDED_OPERAND_IMAGES, by setting the
+// infinispan.org/vulnerable-operand annotation and the infinispan_operator_vulnerable_operand metric. Clusters that
+// use the operator's default image are upgraded by ScheduleGracefulShutdownUpgrade, so with OPERAND_REFRESH_POLICY=Automatic
+// only clusters that pin the superseded image with spec.image are refreshed, by removing spec.image and upgrading the
+// cluster during the next OPERAND_REFRESH_WINDOW
+func OperandRefresh(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	podList, err := ctx.InfinispanPods()
+	if err != nil {
+		return
+	}
+
+	superseded := supersededOperands(consts.SupersededOperandImages)
+	var image, advisory string
+	for _, pod := range podList.Items {
+		if container := kube.GetContainer(provision.InfinispanContainer, &pod.Spec); container != nil {
+			if a, vulnerable := superseded[container.Image]; vulnerable {
+				image, advisory = container.Image, a
+				break
+			}
+		}
+	}
+
+	if image == "" {
+		vulnerableOperand.WithLabelValues(i.Namespace, i.Name).Set(0)
+		if _, annotated := i.Annotations[consts.AnnotationVulnerableOperand]; annotated {
+			ctx.Requeue(
+				ctx.UpdateInfinispan(func() {
+					delete(i.Annotations, consts.AnnotationVulnerableOperand)
+				}),
+			)
+		}
+		return
+	}
+
+	vulnerableOperand.WithLabelValues(i.Namespace, i.Name).Set(1)
+	if i.Annotations[consts.AnnotationVulnerableOperand] != advisory {
+		ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonVulnerableOperand, fmt.Sprintf("Pods run the operand image '%s' superseded by %s", image, advisory))
+		if err := ctx.UpdateInfinispan(func() {
+			if i.Annotations == nil {
+				i.Annotations = map[string]string{}
+			}
+			i.Annotations[consts.AnnotationVulnerableOperand] = advisory
+		}); err != nil {
+			ctx.Requeue(err)
+			return
+		}
+	}
+
+	if consts.OperandRefreshPolicy != OperandRefreshPolicyAutomatic || !i.GracefulShutdownUpgrades() || i.IsUpgradeCondition() {
+		return
+	}
+	// Clusters without spec.image are upgraded as soon as the operator provides a different default image
+	if i.Spec.Image == nil || *i.Spec.Image == "" {
+		return
+	}
+	if _, vulnerable := superseded[consts.DefaultImageName]; vulnerable {
+		return
+	}
+	// Major version upgrades require a data migration, which must be configured by the user
+	if dataMigrationRequired(i, image) {
+		return
+	}
+
+	if wait, err := operandRefreshWait(consts.OperandRefreshWindow, consts.OperandRefreshWindowDuration, time.Now().UTC()); err != nil {
+		ctx.Log().Error(err, "unable to schedule the refresh of the superseded operand")
+		return
+	} else if wait > 0 {
+		ctx.RequeueAfter(wait, nil)
+		return
+	}
+
+	if !acquireOperationLock(i, ctx, ispnv1.OperationUpgrade) {
+		return
+	}
+
+	ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonOperandRefresh, fmt.Sprintf("Upgrading the superseded operand image '%s' to '%s'", image, consts.DefaultImageName))
+	ctx.Requeue(
+		ctx.UpdateInfinispan(func() {
+			i.Spec.Image = nil
+			i.SetCondition(ispnv1.ConditionUpgrade, metav1.ConditionTrue, "")
+			i.RemoveCondition(ispnv1.ConditionDataMigrated)
+			i.Spec.Replicas = 0
+		}),
+	)
+}
+
+// supersededOperands parses the comma separated "image=advisory" entries of SUPERSEDED_OPERAND_IMAGES into a map of
+// image to advisory. Entries without an advisory are mapped to "a security advisory"
+func supersededOperands(catalog string) map[string]string {
+	superseded := map[string]string{}
+	for _, entry := range strings.Split(catalog, ",") {
+		image, advisory := entry, ""
+		if idx := strings.Index(entry, "="); idx >= 0 {
+			image, advisory = entry[:idx], entry[idx+1:]
+		}
+		image, advisory = strings.TrimSpace(image), strings.TrimSpace(advisory)
+		if image == "" {
+			continue
+		}
+		if advisory == "" {
+			advisory = "a security advisory"
+		}
+		superseded[image] = advisory
+	}
+	return superseded
+}
+
+// operandRefreshWait returns 0 if the maintenance window opened by the schedule is open at now, or the time until the
+// window next opens. The window is always open when no schedule is configured
+func operandRefreshWait(schedule, duration string, now time.Time) (time.Duration, error) {
+	if schedule == "" {
+		return 0, nil
+	}
+	window, err := cron.Parse(schedule)
+	if err != nil {
+		return 0, fmt.Errorf("invalid OPERAND_REFRESH_WINDOW: %w", err)
+	}
+	length, err := time.ParseDuration(duration)
+	if err != nil || length <= 0 {
+		return 0, fmt.Errorf("invalid OPERAND_REFRESH_WINDOW_DURATION '%s'", duration)
+	}
+
+	if opened := window.Next(now.Add(-length)); !opened.IsZero() && !opened.After(now) {
+		return 0, nil
+	}
+	next := window.Next(now)
+	if next.IsZero() {
+		return 0, fmt.Errorf("OPERAND_REFRESH_WINDOW '%s' never opens", schedule)
+	}
+	return next.Sub(now), nil
+}
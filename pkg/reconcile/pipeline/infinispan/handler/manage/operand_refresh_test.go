@@ -0,0 +1,49 @@
+package manage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupersededOperands(t *testing.T) {
+	superseded := supersededOperands(" quay.io/infinispan/server:13.0.1=CVE-2022-0001, quay.io/infinispan/server:13.0.2,,")
+	assert.Equal(t, map[string]string{
+		"quay.io/infinispan/server:13.0.1": "CVE-2022-0001",
+		"quay.io/infinispan/server:13.0.2": "a security advisory",
+	}, superseded)
+	assert.Empty(t, supersededOperands(""))
+}
+
+func TestOperandRefreshWait(t *testing.T) {
+	// Saturday
+	saturday := func(hour, minute int) time.Time {
+		return time.Date(2022, time.March, 12, hour, minute, 0, 0, time.UTC)
+	}
+	testTable := []struct {
+		name     string
+		schedule string
+		now      time.Time
+		expected time.Duration
+	}{
+		{"no window", "", saturday(12, 0), 0},
+		{"window open", "0 2 * * 6", saturday(2, 0), 0},
+		{"window closing", "0 2 * * 6", saturday(5, 59), 0},
+		{"window closed", "0 2 * * 6", saturday(6, 0), 7*24*time.Hour - 4*time.Hour},
+		{"before window", "0 2 * * 6", saturday(1, 30), 30 * time.Minute},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, err := operandRefreshWait(tt.schedule, "4h", tt.now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, wait)
+		})
+	}
+
+	_, err := operandRefreshWait("0 2 * *", "4h", saturday(0, 0))
+	assert.Error(t, err)
+	_, err = operandRefreshWait("0 2 * * 6", "0s", saturday(0, 0))
+	assert.Error(t, err)
+}
@@ -0,0 +1,116 @@
+package manage
+
+import (
+	"fmt"
+	"time"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/api/v2alpha1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	EventReasonOperationBlocked  = "OperationBlocked"
+	EventReasonOperationTimedOut = "OperationTimedOut"
+)
+
+// OperationLock releases status.operationLock once the operation holding it has completed, or if the Backup or Restore
+// CR that acquired it no longer exists, so that operations waiting on the lock can proceed. The lock is also released,
+// and the OperationFailed condition set, if the operation does not complete within consts.OperationLockTimeout
+func OperationLock(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	lock := i.Status.OperationLock
+	if lock == nil {
+		if i.HasCondition(ispnv1.ConditionOperationBlocked) {
+			_ = ctx.UpdateInfinispan(func() {
+				i.RemoveCondition(ispnv1.ConditionOperationBlocked)
+			})
+		}
+		return
+	}
+
+	var completed bool
+	switch lock.Operation {
+	case ispnv1.OperationUpgrade:
+		completed = !i.IsUpgradeCondition() && !i.IsConditionTrue(ispnv1.ConditionHotRodRollingUpgrade)
+	case ispnv1.OperationScaling:
+		statefulSet := &appsv1.StatefulSet{}
+		if err := ctx.Resources().Load(i.GetStatefulSetName(), statefulSet, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+			return
+		}
+		completed = statefulSet.Spec.Replicas == nil ||
+			(*statefulSet.Spec.Replicas == i.Spec.Replicas && statefulSet.Status.ReadyReplicas == i.Spec.Replicas && !i.IsConditionTrue(ispnv1.ConditionStopping))
+	case ispnv1.OperationBackup:
+		backup := &v2alpha1.Backup{}
+		if err := ctx.Resources().Load(lock.Owner, backup, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+			return
+		}
+		completed = backup.CreationTimestamp.IsZero() || backup.Status.Phase == v2alpha1.BackupSucceeded || backup.Status.Phase == v2alpha1.BackupFailed
+	case ispnv1.OperationRestore:
+		restore := &v2alpha1.Restore{}
+		if err := ctx.Resources().Load(lock.Owner, restore, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+			return
+		}
+		completed = restore.CreationTimestamp.IsZero() || restore.Status.Phase == v2alpha1.RestoreSucceeded || restore.Status.Phase == v2alpha1.RestoreFailed
+	default:
+		// The lock of the xsite state transfer is released as soon as the transfer has been requested, so it is only
+		// held here if the operator was restarted in the meantime
+		completed = true
+	}
+
+	if completed {
+		ctx.Log().Info("Releasing operation lock", "lock", lock.String())
+		if err := ctx.UpdateInfinispan(func() {
+			i.ReleaseOperationLock(lock.Operation, lock.Owner)
+		}); err != nil {
+			ctx.Requeue(err)
+		}
+		return
+	}
+
+	if remaining := consts.OperationLockTimeout - time.Since(lock.AcquiredTime.Time); remaining > 0 {
+		ctx.RequeueEventually(remaining)
+		return
+	}
+	// The lock is released so that the operations waiting on it are not blocked indefinitely
+	msg := fmt.Sprintf("%s did not complete within %s, releasing the operation lock", lock, consts.OperationLockTimeout)
+	ctx.Log().Info(msg)
+	if err := ctx.UpdateInfinispan(func() {
+		i.ReleaseOperationLock(lock.Operation, lock.Owner)
+		i.SetCondition(ispnv1.ConditionOperationFailed, metav1.ConditionTrue, msg)
+	}); err != nil {
+		ctx.Requeue(err)
+		return
+	}
+	ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonOperationTimedOut, msg)
+}
+
+// acquireOperationLock acquires status.operationLock for an operation started by the operator. If the lock is held by
+// a conflicting operation, the OperationBlocked condition is set and the reconciliation is requeued until the lock has
+// been released. Returns true if the operation can proceed
+func acquireOperationLock(i *ispnv1.Infinispan, ctx pipeline.Context, operation ispnv1.OperationType) bool {
+	var conflict *ispnv1.OperationLock
+	var blocked bool
+	err := ctx.UpdateInfinispan(func() {
+		if conflict = i.AcquireOperationLock(operation, ""); conflict == nil {
+			i.RemoveCondition(ispnv1.ConditionOperationBlocked)
+		} else {
+			blocked = i.SetCondition(ispnv1.ConditionOperationBlocked, metav1.ConditionTrue, fmt.Sprintf("%s waiting for %s to complete", operation, conflict))
+		}
+	})
+	if err != nil {
+		ctx.Requeue(err)
+		return false
+	}
+	if conflict != nil {
+		if blocked {
+			ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonOperationBlocked, fmt.Sprintf("%s waiting for %s to complete", operation, conflict))
+		}
+		ctx.RequeueAfter(consts.DefaultWaitOnCluster, nil)
+		return false
+	}
+	return true
+}
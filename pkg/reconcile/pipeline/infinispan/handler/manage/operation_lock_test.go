@@ -0,0 +1,62 @@
+package manage
+
+import (
+	"testing"
+	"time"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// lockContext records the delay after which OperationLock checks the deadline of the lock again
+type lockContext struct {
+	*certificateUpdateContext
+	delay time.Duration
+}
+
+func (c *lockContext) RequeueEventually(delay time.Duration) { c.delay = delay }
+
+func TestOperationLockDeadline(t *testing.T) {
+	scaling := &appsv1.StatefulSet{
+		Spec:   appsv1.StatefulSetSpec{Replicas: pointer.Int32Ptr(3)},
+		Status: appsv1.StatefulSetStatus{ReadyReplicas: 1},
+	}
+	testTable := []struct {
+		name           string
+		acquired       time.Duration
+		expectReleased bool
+	}{
+		{"waits for the operation", 10 * time.Minute, false},
+		{"releases the lock after the deadline", consts.OperationLockTimeout + time.Minute, true},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &ispnv1.Infinispan{
+				Spec: ispnv1.InfinispanSpec{Replicas: 3},
+				Status: ispnv1.InfinispanStatus{OperationLock: &ispnv1.OperationLock{
+					Operation:    ispnv1.OperationScaling,
+					AcquiredTime: metav1.NewTime(time.Now().Add(-tt.acquired)),
+				}},
+			}
+			ctx := &lockContext{certificateUpdateContext: &certificateUpdateContext{&rolloutContext{resources: &rolloutResources{statefulSet: scaling}}}}
+			OperationLock(i, ctx)
+			if tt.expectReleased {
+				assert.Nil(t, i.Status.OperationLock)
+				assert.True(t, i.IsConditionTrue(ispnv1.ConditionOperationFailed))
+				assert.Contains(t, i.GetCondition(ispnv1.ConditionOperationFailed).Message, "Scaling did not complete within 2h0m0s")
+
+				// The condition is removed once another operation acquires the lock
+				assert.Nil(t, i.AcquireOperationLock(ispnv1.OperationBackup, "example-backup"))
+				assert.False(t, i.HasCondition(ispnv1.ConditionOperationFailed))
+			} else {
+				assert.NotNil(t, i.Status.OperationLock)
+				assert.False(t, i.HasCondition(ispnv1.ConditionOperationFailed))
+				assert.InDelta(t, consts.OperationLockTimeout-tt.acquired, ctx.delay, float64(time.Minute))
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package manage
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// PodExternalAddresses sets the infinispan.org/external-host and infinispan.org/external-port annotations of each pod to
+// the address of the pod's external Service, which the server advertises in the Hot Rod topology, and reports the
+// addresses in status.podPublicAddresses. The address of a NodePort Service is the address of the node of the pod
+func PodExternalAddresses(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	if !i.IsExposedPerPod() {
+		_ = ctx.UpdateInfinispan(func() {
+			i.Status.PodPublicAddresses = nil
+		})
+		return
+	}
+
+	podList := &corev1.PodList{}
+	if err := ctx.Resources().List(i.PodSelectorLabels(), podList, pipeline.RetryOnErr); err != nil {
+		return
+	}
+
+	var addresses []ispnv1.PodPublicAddress
+	for _, pod := range podList.Items {
+		ordinal, ok := podOrdinal(i, pod.Name)
+		if !ok {
+			// Pods of other StatefulSets, e.g. of a Hot Rod rolling upgrade, are not exposed individually
+			continue
+		}
+		svc := &corev1.Service{}
+		if err := ctx.Resources().Load(i.GetPodServiceExternalName(ordinal), svc, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+			return
+		}
+		host, port, err := podServiceAddress(svc, &pod, ctx)
+		if err != nil {
+			return
+		}
+		if host == "" {
+			ctx.Log().Info("External address of pod not ready yet", "pod", pod.Name)
+			ctx.RequeueAfter(consts.DefaultWaitOnCluster, nil)
+			continue
+		}
+		portValue := strconv.Itoa(int(port))
+		addresses = append(addresses, ispnv1.PodPublicAddress{
+			Pod:     pod.Name,
+			Address: net.JoinHostPort(host, portValue),
+		})
+
+		if pod.Annotations[consts.AnnotationExternalHost] == host && pod.Annotations[consts.AnnotationExternalPort] == portValue {
+			continue
+		}
+		pod := pod
+		mutateFn := func() error {
+			if pod.CreationTimestamp.IsZero() {
+				return errors.NewNotFound(corev1.Resource(""), pod.Name)
+			}
+			if pod.Annotations == nil {
+				pod.Annotations = map[string]string{}
+			}
+			pod.Annotations[consts.AnnotationExternalHost] = host
+			pod.Annotations[consts.AnnotationExternalPort] = portValue
+			return nil
+		}
+		if _, err := ctx.Resources().CreateOrPatch(&pod, false, mutateFn, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+			return
+		}
+		ctx.Log().Info("External address of pod updated", "pod", pod.Name, "host", host, "port", portValue)
+	}
+
+	sort.Slice(addresses, func(a, b int) bool {
+		return addresses[a].Pod < addresses[b].Pod
+	})
+	_ = ctx.UpdateInfinispan(func() {
+		i.Status.PodPublicAddresses = addresses
+	})
+}
+
+// podServiceAddress returns the host and port at which the pod is reachable through its external Service, or an empty
+// host if the address is not known yet
+func podServiceAddress(svc *corev1.Service, pod *corev1.Pod, ctx pipeline.Context) (string, int32, error) {
+	if svc.CreationTimestamp.IsZero() || len(svc.Spec.Ports) == 0 {
+		return "", 0, nil
+	}
+	if svc.Spec.Type == corev1.ServiceTypeNodePort {
+		if pod.Spec.NodeName == "" {
+			// The node of the pod is not known until it is scheduled
+			return "", 0, nil
+		}
+		node := &corev1.Node{}
+		if err := ctx.Resources().LoadGlobal(pod.Spec.NodeName, node, pipeline.RetryOnErr); err != nil {
+			return "", 0, err
+		}
+		return nodeAddress(node), svc.Spec.Ports[0].NodePort, nil
+	}
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			return ingress.IP, svc.Spec.Ports[0].Port, nil
+		}
+		if ingress.Hostname != "" {
+			return ingress.Hostname, svc.Spec.Ports[0].Port, nil
+		}
+	}
+	return "", 0, nil
+}
+
+// nodeAddress returns the external IP of the node, or its internal IP if the node has no external IP
+func nodeAddress(node *corev1.Node) string {
+	for _, addressType := range []corev1.NodeAddressType{corev1.NodeExternalIP, corev1.NodeInternalIP} {
+		for _, address := range node.Status.Addresses {
+			if address.Type == addressType {
+				return address.Address
+			}
+		}
+	}
+	return ""
+}
+
+// podOrdinal returns the ordinal of a pod of the cluster's StatefulSet
+func podOrdinal(i *ispnv1.Infinispan, podName string) (int32, bool) {
+	prefix := i.GetStatefulSetName() + "-"
+	if !strings.HasPrefix(podName, prefix) {
+		return 0, false
+	}
+	ordinal, err := strconv.ParseInt(strings.TrimPrefix(podName, prefix), 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(ordinal), true
+}
@@ -0,0 +1,37 @@
+package manage
+
+import (
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodOrdinal(t *testing.T) {
+	i := &ispnv1.Infinispan{ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan"}}
+	ordinal, ok := podOrdinal(i, "example-infinispan-2")
+	assert.True(t, ok)
+	assert.Equal(t, int32(2), ordinal)
+
+	_, ok = podOrdinal(i, "example-infinispan-config-listener-0")
+	assert.False(t, ok)
+	_, ok = podOrdinal(i, "other-0")
+	assert.False(t, ok)
+}
+
+func TestNodeAddress(t *testing.T) {
+	node := &corev1.Node{
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+				{Type: corev1.NodeExternalIP, Address: "203.0.113.1"},
+			},
+		},
+	}
+	assert.Equal(t, "203.0.113.1", nodeAddress(node))
+
+	node.Status.Addresses = node.Status.Addresses[:1]
+	assert.Equal(t, "10.0.0.1", nodeAddress(node))
+}
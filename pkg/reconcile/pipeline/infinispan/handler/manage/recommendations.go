@@ -0,0 +1,212 @@
+package manage
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	EventReasonSizingRecommendation = "SizingRecommendation"
+
+	metricUsedHeap  = "base_memory_usedHeap_bytes"
+	metricMaxHeap   = "base_memory_maxHeap_bytes"
+	metricGcTime    = "base_gc_time_total_seconds"
+	metricJvmUptime = "base_jvm_uptime_seconds"
+
+	// Heap usage above which more memory is recommended
+	heapUsageHighThreshold = 0.85
+	// Heap usage targeted by memory and replica recommendations
+	heapUsageTarget = 0.7
+	// Share of the JVM uptime spent in GC above which more memory is recommended
+	gcOverheadThreshold = 0.1
+	// Recommended memory is rounded up to a multiple of this value
+	memoryIncrement = 512 * 1024 * 1024
+)
+
+// podUsage the heap and GC metrics of a single pod
+type podUsage struct {
+	pod        string
+	usedHeap   float64
+	maxHeap    float64
+	gcOverhead float64
+}
+
+// SizingRecommendations periodically compares the heap usage and GC activity of the pods against the configured memory
+// and replicas, publishing recommendations in the status of the Infinispan CR
+func SizingRecommendations(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	interval := i.RecommendationsInterval()
+	if status := i.Status.Recommendations; status != nil {
+		if next := status.LastEvaluationTime.Add(interval); time.Now().Before(next) {
+			requeuePeriodic(ctx, time.Until(next))
+			return
+		}
+	}
+
+	podList, err := ctx.InfinispanPods()
+	if err != nil {
+		return
+	}
+	var usage []podUsage
+	for _, pod := range podList.Items {
+		if !kube.IsPodReady(pod) {
+			continue
+		}
+		buf, err := ctx.InfinispanClientForPod(pod.Name).Metrics().Get("base")
+		if err != nil {
+			ctx.Log().Info("Unable to retrieve metrics for sizing recommendations", "pod", pod.Name, "error", err)
+			continue
+		}
+		usage = append(usage, newPodUsage(pod.Name, parseMetrics(buf)))
+	}
+	if len(usage) == 0 {
+		requeuePeriodic(ctx, interval)
+		return
+	}
+
+	recommendations := sizingRecommendations(i, usage)
+	// Only emit events for new recommendations, as the usage in the message changes on every evaluation
+	for _, r := range recommendations {
+		if !hasRecommendation(i.Status.Recommendations, r) {
+			ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonSizingRecommendation, r.Message)
+		}
+	}
+	ctx.Log().Info("Sizing recommendations evaluated", "recommendations", len(recommendations))
+
+	if err := ctx.UpdateInfinispan(func() {
+		i.Status.Recommendations = &ispnv1.RecommendationsStatus{
+			LastEvaluationTime: metav1.Now(),
+			Items:              recommendations,
+		}
+	}); err != nil {
+		return
+	}
+	requeuePeriodic(ctx, interval)
+}
+
+func newPodUsage(pod string, metrics map[string]float64) podUsage {
+	usage := podUsage{
+		pod:      pod,
+		usedHeap: metrics[metricUsedHeap],
+		maxHeap:  metrics[metricMaxHeap],
+	}
+	if uptime := metrics[metricJvmUptime]; uptime > 0 {
+		usage.gcOverhead = metrics[metricGcTime] / uptime
+	}
+	return usage
+}
+
+// sizingRecommendations returns the recommendations for the observed usage of the pods. More memory is recommended when
+// the heap usage or GC overhead of any pod is high, fewer replicas when the heap of the remaining pods can hold the
+// data of the whole cluster
+func sizingRecommendations(i *ispnv1.Infinispan, usage []podUsage) []ispnv1.Recommendation {
+	var recommendations []ispnv1.Recommendation
+	var peak podUsage
+	var peakRatio, totalUsed, maxHeap float64
+	for _, u := range usage {
+		if u.maxHeap <= 0 {
+			continue
+		}
+		if ratio := u.usedHeap / u.maxHeap; ratio > peakRatio {
+			peak, peakRatio = u, ratio
+		}
+		totalUsed += u.usedHeap
+		maxHeap = math.Max(maxHeap, u.maxHeap)
+	}
+
+	_, memLimit, err := i.Spec.Container.GetMemoryResources()
+	memoryKnown := err == nil && !memLimit.IsZero()
+	if memoryKnown && peakRatio > heapUsageHighThreshold {
+		recommendations = append(recommendations, ispnv1.Recommendation{
+			Type:    ispnv1.RecommendationTypeMemory,
+			Value:   recommendedMemory(memLimit, peakRatio/heapUsageTarget),
+			Message: fmt.Sprintf("Heap usage of pod '%s' is %.0f%%, increase spec.container.memory", peak.pod, peakRatio*100),
+		})
+	} else if memoryKnown {
+		for _, u := range usage {
+			if u.gcOverhead > gcOverheadThreshold {
+				recommendations = append(recommendations, ispnv1.Recommendation{
+					Type:    ispnv1.RecommendationTypeMemory,
+					Value:   recommendedMemory(memLimit, 1.5),
+					Message: fmt.Sprintf("Pod '%s' spends %.0f%% of its time in garbage collection, increase spec.container.memory", u.pod, u.gcOverhead*100),
+				})
+				break
+			}
+		}
+	}
+
+	// Replicas are only reduced when no pod is short on memory, and never below two so that data is not lost when a
+	// single pod fails
+	replicas := i.Spec.Replicas
+	if len(recommendations) == 0 && maxHeap > 0 && replicas > 2 && !i.IsAutoscalingEnabled() {
+		needed := int32(math.Ceil(totalUsed / (maxHeap * heapUsageTarget)))
+		if needed < 2 {
+			needed = 2
+		}
+		if needed < replicas {
+			recommendations = append(recommendations, ispnv1.Recommendation{
+				Type:    ispnv1.RecommendationTypeReplicas,
+				Value:   strconv.Itoa(int(needed)),
+				Message: fmt.Sprintf("Total heap usage is %.0f%% of the cluster capacity, spec.replicas can be reduced to %d", totalUsed/(maxHeap*float64(replicas))*100, needed),
+			})
+		}
+	}
+	return recommendations
+}
+
+func hasRecommendation(status *ispnv1.RecommendationsStatus, r ispnv1.Recommendation) bool {
+	if status == nil {
+		return false
+	}
+	for _, existing := range status.Items {
+		if existing.Type == r.Type && existing.Value == r.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// recommendedMemory scales the memory limit by the given factor, rounded up to the next memoryIncrement
+func recommendedMemory(limit resource.Quantity, factor float64) string {
+	increments := math.Ceil(float64(limit.Value()) * factor / memoryIncrement)
+	return resource.NewQuantity(int64(increments)*memoryIncrement, resource.BinarySI).String()
+}
+
+// parseMetrics parses metrics in the Prometheus text format, summing the values of all samples with the same name
+func parseMetrics(buf *bytes.Buffer) map[string]float64 {
+	metrics := map[string]float64{}
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, rest := line, ""
+		if idx := strings.IndexAny(line, "{ "); idx >= 0 {
+			name, rest = line[:idx], line[idx:]
+		}
+		// Label values may contain spaces, e.g. the name of a garbage collector
+		if strings.HasPrefix(rest, "{") {
+			rest = rest[strings.LastIndex(rest, "}")+1:]
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		if val, err := strconv.ParseFloat(fields[0], 64); err == nil {
+			metrics[name] += val
+		}
+	}
+	return metrics
+}
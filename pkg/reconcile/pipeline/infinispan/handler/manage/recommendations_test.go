@@ -0,0 +1,80 @@
+package manage
+
+import (
+	"bytes"
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+const gib = 1024 * 1024 * 1024
+
+func TestParseMetrics(t *testing.T) {
+	buf := bytes.NewBufferString(`# HELP base_memory_usedHeap_bytes Displays the amount of used memory.
+# TYPE base_memory_usedHeap_bytes gauge
+base_memory_usedHeap_bytes 5.36870912E8
+# TYPE base_gc_time_total_seconds counter
+base_gc_time_total_seconds{name="G1 Young Generation"} 1.5
+base_gc_time_total_seconds{name="G1 Old Generation"} 0.5 1633000000000
+base_jvm_uptime_seconds 100.0
+`)
+	metrics := parseMetrics(buf)
+	assert.Equal(t, 536870912.0, metrics[metricUsedHeap])
+	assert.Equal(t, 2.0, metrics[metricGcTime])
+	assert.Equal(t, 0.02, newPodUsage("pod-0", metrics).gcOverhead)
+}
+
+func TestSizingRecommendations(t *testing.T) {
+	testTable := []struct {
+		name     string
+		replicas int32
+		usage    []podUsage
+		expected []ispnv1.Recommendation
+	}{
+		{"no recommendations", 3, []podUsage{
+			{pod: "pod-0", usedHeap: 0.6 * gib, maxHeap: gib},
+			{pod: "pod-1", usedHeap: 0.5 * gib, maxHeap: gib},
+			{pod: "pod-2", usedHeap: 0.6 * gib, maxHeap: gib},
+		}, nil},
+		{"high heap usage", 3, []podUsage{
+			{pod: "pod-0", usedHeap: 0.6 * gib, maxHeap: gib},
+			{pod: "pod-1", usedHeap: 0.95 * gib, maxHeap: gib},
+		}, []ispnv1.Recommendation{{Type: ispnv1.RecommendationTypeMemory, Value: "3Gi"}}},
+		{"high gc overhead", 3, []podUsage{
+			{pod: "pod-0", usedHeap: 0.5 * gib, maxHeap: gib, gcOverhead: 0.2},
+		}, []ispnv1.Recommendation{{Type: ispnv1.RecommendationTypeMemory, Value: "3Gi"}}},
+		{"reduce replicas", 4, []podUsage{
+			{pod: "pod-0", usedHeap: 0.2 * gib, maxHeap: gib},
+			{pod: "pod-1", usedHeap: 0.3 * gib, maxHeap: gib},
+			{pod: "pod-2", usedHeap: 0.3 * gib, maxHeap: gib},
+			{pod: "pod-3", usedHeap: 0.2 * gib, maxHeap: gib},
+		}, []ispnv1.Recommendation{{Type: ispnv1.RecommendationTypeReplicas, Value: "2"}}},
+		{"never below two replicas", 3, []podUsage{
+			{pod: "pod-0", usedHeap: 0.1 * gib, maxHeap: gib},
+			{pod: "pod-1", usedHeap: 0.1 * gib, maxHeap: gib},
+			{pod: "pod-2", usedHeap: 0.1 * gib, maxHeap: gib},
+		}, []ispnv1.Recommendation{{Type: ispnv1.RecommendationTypeReplicas, Value: "2"}}},
+		{"two replicas are not reduced", 2, []podUsage{
+			{pod: "pod-0", usedHeap: 0.1 * gib, maxHeap: gib},
+			{pod: "pod-1", usedHeap: 0.1 * gib, maxHeap: gib},
+		}, nil},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &ispnv1.Infinispan{
+				Spec: ispnv1.InfinispanSpec{
+					Replicas:  tt.replicas,
+					Container: ispnv1.InfinispanContainerSpec{Memory: "2Gi"},
+				},
+			}
+			recommendations := sizingRecommendations(i, tt.usage)
+			assert.Len(t, recommendations, len(tt.expected))
+			for idx, r := range recommendations {
+				assert.Equal(t, tt.expected[idx].Type, r.Type)
+				assert.Equal(t, tt.expected[idx].Value, r.Value)
+				assert.NotEmpty(t, r.Message)
+			}
+		})
+	}
+}
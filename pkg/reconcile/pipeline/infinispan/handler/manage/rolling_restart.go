@@ -0,0 +1,166 @@
+package manage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	EventReasonRollingRestart     = "RollingRestart"
+	EventReasonPartitionedRollout = "PartitionedRollout"
+)
+
+// RollingRestart restarts the Infinispan pods one at a time when the infinispan.org/restartedAt annotation of the
+// Infinispan CR changes. The annotation is copied to the StatefulSet pod template and the RollingUpdate partition is
+// set to the number of replicas, so that the restart is performed by PartitionedRollout
+func RollingRestart(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	restartedAt, exists := i.Annotations[consts.AnnotationRestartedAt]
+	if !exists {
+		return
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	if err := ctx.Resources().Load(i.GetStatefulSetName(), statefulSet, pipeline.RetryOnErr); err != nil {
+		return
+	}
+
+	template := &statefulSet.Spec.Template
+	if template.Annotations[consts.AnnotationRestartedAt] == restartedAt {
+		return
+	}
+	// Prevent any pods from being restarted by the StatefulSet controller until the cluster has been checked
+	ctx.Log().Info("Starting rolling restart", "restartedAt", restartedAt)
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[consts.AnnotationRestartedAt] = restartedAt
+	setPartition(statefulSet, *statefulSet.Spec.Replicas)
+	if err := ctx.Resources().Update(statefulSet, pipeline.RetryOnErr); err != nil {
+		return
+	}
+	ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonRollingRestart, fmt.Sprintf("Rolling restart '%s' started", restartedAt))
+	ctx.RequeueAfter(consts.DefaultWaitOnCluster, nil)
+}
+
+// PartitionedRollout progresses a StatefulSet rollout that has been partitioned by the operator. Pods are updated in
+// descending ordinal order, spec.upgrades.maxUnavailable pods at a time, only progressing to the next pods once the
+// updated pods have rejoined the cluster and rebalancing has completed. The StatefulSet controller only updates one pod
+// at a time, so the operator deletes the outdated pods of a batch itself and the StatefulSet controller recreates them
+// with the updated template. The rollout is paused once the partition configured in spec.upgrades.partition is reached
+func PartitionedRollout(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	statefulSet := &appsv1.StatefulSet{}
+	if err := ctx.Resources().Load(i.GetStatefulSetName(), statefulSet, pipeline.RetryOnErr); err != nil {
+		return
+	}
+
+	rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate
+	if rollingUpdate == nil || rollingUpdate.Partition == nil {
+		// No rollout in progress
+		return
+	}
+
+	replicas := *statefulSet.Spec.Replicas
+	partition := *rollingUpdate.Partition
+	if partition > replicas {
+		partition = replicas
+	}
+	// Wait for the StatefulSet controller to update all pods with an ordinal >= partition and for all pods to be ready
+	status := statefulSet.Status
+	if status.ObservedGeneration < statefulSet.Generation || status.UpdatedReplicas < replicas-partition || status.ReadyReplicas < replicas {
+		ctx.RequeueAfter(consts.DefaultWaitOnCluster, nil)
+		return
+	}
+
+	target := i.RolloutPartition()
+	if partition <= target {
+		if target > 0 {
+			// Rollout paused until spec.upgrades.partition is decreased
+			return
+		}
+		ctx.Log().Info("Partitioned rollout complete")
+		statefulSet.Spec.UpdateStrategy.RollingUpdate = nil
+		if err := ctx.Resources().Update(statefulSet, pipeline.RetryOnErr); err != nil {
+			return
+		}
+		ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonPartitionedRollout, "All pods updated")
+		return
+	}
+
+	// Only update the next pod once the cluster has finished rebalancing
+	ispnClient, err := ctx.InfinispanClient()
+	if err != nil {
+		ctx.Requeue(err)
+		return
+	}
+	health, err := ispnClient.Container().HealthStatus()
+	if err != nil {
+		ctx.Requeue(fmt.Errorf("unable to retrieve cluster health during partitioned rollout: %w", err))
+		return
+	}
+	if health != api.HealthStatusHealth {
+		ctx.Log().Info("Waiting for cluster to be healthy before updating the next pod", "health", health)
+		ctx.RequeueAfter(consts.DefaultWaitOnCluster, nil)
+		return
+	}
+
+	next := partition - i.RolloutMaxUnavailable()
+	if next < target {
+		next = target
+	}
+	ctx.Log().Info("Updating pods", "fromOrdinal", next, "toOrdinal", partition-1)
+	setPartition(statefulSet, next)
+	if err := ctx.Resources().Update(statefulSet, pipeline.RetryOnErr); err != nil {
+		return
+	}
+	msg := fmt.Sprintf("Updating pod '%s-%d'", statefulSet.Name, next)
+	if next < partition-1 {
+		msg = fmt.Sprintf("Updating pods '%s-%d' to '%s-%d'", statefulSet.Name, next, statefulSet.Name, partition-1)
+	}
+	ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonPartitionedRollout, msg)
+	if next < partition-1 {
+		if err := deleteOutdatedPods(statefulSet, next, partition, ctx); err != nil {
+			ctx.Requeue(err)
+			return
+		}
+	}
+	ctx.RequeueAfter(consts.DefaultWaitOnCluster, nil)
+}
+
+// deleteOutdatedPods deletes the pods with an ordinal in [from, to) that do not have the update revision of the
+// StatefulSet, so that they are restarted at the same time
+func deleteOutdatedPods(statefulSet *appsv1.StatefulSet, from, to int32, ctx pipeline.Context) error {
+	podList, err := ctx.InfinispanPods()
+	if err != nil {
+		return fmt.Errorf("unable to list the pods to update: %w", err)
+	}
+	for _, pod := range podList.Items {
+		ordinal, err := strconv.ParseInt(strings.TrimPrefix(pod.Name, statefulSet.Name+"-"), 10, 32)
+		if err != nil || int32(ordinal) < from || int32(ordinal) >= to {
+			continue
+		}
+		if pod.Labels[appsv1.StatefulSetRevisionLabel] == statefulSet.Status.UpdateRevision {
+			continue
+		}
+		if err := ctx.Resources().Delete(pod.Name, &corev1.Pod{}); err != nil {
+			return fmt.Errorf("unable to delete pod '%s': %w", pod.Name, err)
+		}
+	}
+	return nil
+}
+
+func setPartition(statefulSet *appsv1.StatefulSet, partition int32) {
+	statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition: &partition,
+		},
+	}
+}
@@ -0,0 +1,168 @@
+package manage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// rolloutContext provides the StatefulSet and cluster health used by PartitionedRollout
+type rolloutContext struct {
+	pipeline.Context
+	resources *rolloutResources
+	health    api.HealthStatus
+	pods      []corev1.Pod
+}
+
+func (c *rolloutContext) Resources() pipeline.Resources       { return c.resources }
+func (c *rolloutContext) Log() logr.Logger                    { return ctrl.Log }
+func (c *rolloutContext) EventRecorder() record.EventRecorder { return record.NewFakeRecorder(10) }
+func (c *rolloutContext) RequeueAfter(time.Duration, error)   {}
+func (c *rolloutContext) InfinispanClient() (api.Infinispan, error) {
+	return &healthClient{container: &healthContainer{health: c.health}}, nil
+}
+func (c *rolloutContext) InfinispanPods() (*corev1.PodList, error) {
+	return &corev1.PodList{Items: c.pods}, nil
+}
+
+type rolloutResources struct {
+	pipeline.Resources
+	statefulSet *appsv1.StatefulSet
+	deleted     []string
+}
+
+func (r *rolloutResources) Delete(name string, _ client.Object, _ ...func(config *pipeline.ResourcesConfig)) error {
+	r.deleted = append(r.deleted, name)
+	return nil
+}
+
+func (r *rolloutResources) Load(_ string, obj client.Object, _ ...func(config *pipeline.ResourcesConfig)) error {
+	r.statefulSet.DeepCopyInto(obj.(*appsv1.StatefulSet))
+	return nil
+}
+
+func (r *rolloutResources) Update(obj client.Object, _ ...func(config *pipeline.ResourcesConfig)) error {
+	r.statefulSet = obj.(*appsv1.StatefulSet)
+	return nil
+}
+
+type healthClient struct {
+	api.Infinispan
+	container *healthContainer
+}
+
+func (c *healthClient) Container() api.Container { return c.container }
+
+type healthContainer struct {
+	api.Container
+	health api.HealthStatus
+}
+
+func (c *healthContainer) HealthStatus() (api.HealthStatus, error) { return c.health, nil }
+
+func partitionedStatefulSet(replicas, partition, updated int32) *appsv1.StatefulSet {
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: pointer.Int32Ptr(replicas)},
+		Status: appsv1.StatefulSetStatus{
+			ReadyReplicas:   replicas,
+			UpdatedReplicas: updated,
+			UpdateRevision:  "updated",
+		},
+	}
+	setPartition(statefulSet, partition)
+	return statefulSet
+}
+
+func TestPartitionedRollout(t *testing.T) {
+	testTable := []struct {
+		name              string
+		rolloutPartition  *int32
+		maxUnavailable    *int32
+		statefulSet       *appsv1.StatefulSet
+		health            api.HealthStatus
+		expectedPartition *int32
+	}{
+		{"waits for updated pod", nil, nil, partitionedStatefulSet(3, 2, 0), api.HealthStatusHealth, pointer.Int32Ptr(2)},
+		{"waits for rebalancing", nil, nil, partitionedStatefulSet(3, 2, 1), api.HealthStatusHealthRebalancing, pointer.Int32Ptr(2)},
+		{"updates next pod", nil, nil, partitionedStatefulSet(3, 2, 1), api.HealthStatusHealth, pointer.Int32Ptr(1)},
+		{"pauses at configured partition", pointer.Int32Ptr(2), nil, partitionedStatefulSet(3, 2, 1), api.HealthStatusHealth, pointer.Int32Ptr(2)},
+		{"resumes when partition decreased", pointer.Int32Ptr(0), nil, partitionedStatefulSet(3, 2, 1), api.HealthStatusHealth, pointer.Int32Ptr(1)},
+		{"completes", pointer.Int32Ptr(0), nil, partitionedStatefulSet(3, 0, 3), api.HealthStatusHealth, nil},
+		{"updates max unavailable pods", nil, pointer.Int32Ptr(2), partitionedStatefulSet(6, 4, 2), api.HealthStatusHealth, pointer.Int32Ptr(2)},
+		{"max unavailable bounded by partition", pointer.Int32Ptr(3), pointer.Int32Ptr(2), partitionedStatefulSet(6, 4, 2), api.HealthStatusHealth, pointer.Int32Ptr(3)},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &ispnv1.Infinispan{
+				Spec: ispnv1.InfinispanSpec{
+					Upgrades: &ispnv1.InfinispanUpgradesSpec{Type: ispnv1.UpgradeTypeShutdown, Partition: tt.rolloutPartition, MaxUnavailable: tt.maxUnavailable},
+				},
+			}
+			ctx := &rolloutContext{resources: &rolloutResources{statefulSet: tt.statefulSet}, health: tt.health}
+			PartitionedRollout(i, ctx)
+			rollingUpdate := ctx.resources.statefulSet.Spec.UpdateStrategy.RollingUpdate
+			if tt.expectedPartition == nil {
+				assert.Nil(t, rollingUpdate)
+			} else {
+				assert.Equal(t, *tt.expectedPartition, *rollingUpdate.Partition)
+			}
+		})
+	}
+}
+
+// rolloutPods returns the pods of a StatefulSet, where the pods with an ordinal >= partition are updated
+func rolloutPods(replicas, partition int32) []corev1.Pod {
+	pods := make([]corev1.Pod, replicas)
+	for ordinal := range pods {
+		revision := "current"
+		if int32(ordinal) >= partition {
+			revision = "updated"
+		}
+		pods[ordinal].Name = fmt.Sprintf("example-infinispan-%d", ordinal)
+		pods[ordinal].Labels = map[string]string{appsv1.StatefulSetRevisionLabel: revision}
+	}
+	return pods
+}
+
+func TestPartitionedRolloutBatches(t *testing.T) {
+	notReady := partitionedStatefulSet(6, 4, 2)
+	notReady.Status.ReadyReplicas = 5
+	testTable := []struct {
+		name              string
+		statefulSet       *appsv1.StatefulSet
+		health            api.HealthStatus
+		expectedPartition int32
+		expectedDeleted   []string
+	}{
+		{"waits for ready pods", notReady, api.HealthStatusHealth, 4, nil},
+		{"waits for rebalancing", partitionedStatefulSet(6, 4, 2), api.HealthStatusHealthRebalancing, 4, nil},
+		{"restarts the batch", partitionedStatefulSet(6, 4, 2), api.HealthStatusHealth, 2, []string{"example-infinispan-2", "example-infinispan-3"}},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &ispnv1.Infinispan{
+				Spec: ispnv1.InfinispanSpec{
+					Upgrades: &ispnv1.InfinispanUpgradesSpec{Type: ispnv1.UpgradeTypeShutdown, MaxUnavailable: pointer.Int32Ptr(2)},
+				},
+			}
+			ctx := &rolloutContext{resources: &rolloutResources{statefulSet: tt.statefulSet}, health: tt.health, pods: rolloutPods(6, 4)}
+			PartitionedRollout(i, ctx)
+			assert.Equal(t, tt.expectedPartition, *ctx.resources.statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition)
+			assert.Equal(t, tt.expectedDeleted, ctx.resources.deleted)
+		})
+	}
+}
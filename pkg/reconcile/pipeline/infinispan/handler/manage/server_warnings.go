@@ -0,0 +1,160 @@
+package manage
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	EventReasonServerDeprecation = "ServerDeprecation"
+	EventReasonServerWarning     = "ServerWarning"
+
+	// serverStartedCode the message logged once the server has started, warnings logged afterwards are not related to
+	// the configuration of the server
+	serverStartedCode = "ISPN080001"
+	// startupLogsLimit the maximum number of bytes read from the start of the logs of a pod
+	startupLogsLimit = 1024 * 1024
+)
+
+var serverWarningRegexp = regexp.MustCompile(`\bWARN\b.*?\b(ISPN\d{6}): (.*)$`)
+
+// checkedPods the UIDs of the pods of each cluster whose startup logs have already been checked
+var checkedPods = struct {
+	sync.Mutex
+	m map[types.NamespacedName]map[types.UID]struct{}
+}{m: make(map[types.NamespacedName]map[types.UID]struct{})}
+
+type serverWarning struct {
+	code    string
+	message string
+}
+
+func (w serverWarning) isDeprecation() bool {
+	return strings.Contains(strings.ToLower(w.message), "deprecated")
+}
+
+func (w serverWarning) String() string {
+	return w.code + ": " + w.message
+}
+
+// ServerWarnings checks the startup logs of each newly started pod for deprecation and configuration warnings, so that
+// they are reported as events and by the ServerWarnings condition instead of being buried in the pod logs
+func ServerWarnings(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	podList, err := ctx.InfinispanPods()
+	if err != nil {
+		return
+	}
+
+	clusterNsn := types.NamespacedName{Namespace: i.Namespace, Name: i.Name}
+	checkedPods.Lock()
+	checked := checkedPods.m[clusterNsn]
+	checkedPods.Unlock()
+
+	current := make(map[types.UID]struct{}, len(podList.Items))
+	var warnings []serverWarning
+	var podsChecked bool
+	for _, pod := range podList.Items {
+		if _, ok := checked[pod.UID]; ok {
+			current[pod.UID] = struct{}{}
+			continue
+		}
+		// The startup logs are only complete once the server is ready
+		if !kube.IsPodReady(pod) {
+			continue
+		}
+		// The server container must be named, as pods can have sidecars, e.g. the Vault agent or the Istio proxy
+		logs, err := ctx.Kubernetes().LogsHead(pod.Name, provision.InfinispanContainer, i.Namespace, startupLogsLimit, ctx.Ctx())
+		if err != nil {
+			ctx.Log().Error(err, "unable to retrieve startup logs", "pod", pod.Name)
+			continue
+		}
+		current[pod.UID] = struct{}{}
+		podsChecked = true
+		warnings = appendServerWarnings(warnings, parseServerWarnings(logs)...)
+	}
+
+	checkedPods.Lock()
+	if len(current) == 0 {
+		delete(checkedPods.m, clusterNsn)
+	} else {
+		checkedPods.m[clusterNsn] = current
+	}
+	checkedPods.Unlock()
+
+	if !podsChecked {
+		return
+	}
+
+	if len(warnings) == 0 {
+		if i.IsConditionTrue(ispnv1.ConditionServerWarnings) {
+			_ = ctx.UpdateInfinispan(func() {
+				i.SetCondition(ispnv1.ConditionServerWarnings, metav1.ConditionFalse, "")
+			})
+		}
+		return
+	}
+
+	reported := i.GetCondition(ispnv1.ConditionServerWarnings).Message
+	messages := make([]string, len(warnings))
+	for idx, w := range warnings {
+		messages[idx] = w.String()
+		if strings.Contains(reported, w.code) {
+			continue
+		}
+		reason := EventReasonServerWarning
+		if w.isDeprecation() {
+			reason = EventReasonServerDeprecation
+		}
+		ctx.EventRecorder().Event(i, corev1.EventTypeWarning, reason, w.String())
+	}
+	_ = ctx.UpdateInfinispan(func() {
+		i.SetCondition(ispnv1.ConditionServerWarnings, metav1.ConditionTrue, strings.Join(messages, "; "))
+	})
+}
+
+// RemoveServerWarnings forgets the pods of the cluster whose startup logs have been checked once the Infinispan CR is
+// deleted
+func RemoveServerWarnings(namespace, name string) {
+	checkedPods.Lock()
+	defer checkedPods.Unlock()
+	delete(checkedPods.m, types.NamespacedName{Namespace: namespace, Name: name})
+}
+
+// parseServerWarnings returns the warnings logged before the server started, once per message code
+func parseServerWarnings(logs string) []serverWarning {
+	var warnings []serverWarning
+	for _, line := range strings.Split(logs, "\n") {
+		if strings.Contains(line, serverStartedCode) {
+			break
+		}
+		if match := serverWarningRegexp.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			warnings = appendServerWarnings(warnings, serverWarning{code: match[1], message: match[2]})
+		}
+	}
+	return warnings
+}
+
+func appendServerWarnings(warnings []serverWarning, add ...serverWarning) []serverWarning {
+	for _, w := range add {
+		duplicate := false
+		for _, existing := range warnings {
+			if existing.code == w.code {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			warnings = append(warnings, w)
+		}
+	}
+	return warnings
+}
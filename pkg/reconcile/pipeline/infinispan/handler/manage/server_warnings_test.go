@@ -0,0 +1,104 @@
+package manage
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	pipelineContext "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/context"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest/fake"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	fakeClient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseServerWarnings(t *testing.T) {
+	logs := `2022-03-07 10:00:00,000 INFO  (main) [BOOT] JVM OpenJDK 64-Bit Server VM Red Hat, Inc. 11.0.14
+2022-03-07 10:00:01,000 WARN  (main) [org.infinispan.CONFIG] ISPN000599: Configuration for cache 'example' uses the deprecated 'expiration.lifespan' attribute
+2022-03-07 10:00:01,100 WARN  (main) [org.infinispan.SERVER] ISPN080059: No script engines are available
+2022-03-07 10:00:01,200 WARN  (main) [org.infinispan.CONFIG] ISPN000599: Configuration for cache 'other' uses the deprecated 'expiration.lifespan' attribute
+2022-03-07 10:00:02,000 INFO  (main) [org.infinispan.SERVER] ISPN080001: Infinispan Server 13.0.5.Final started in 2000ms
+2022-03-07 10:05:00,000 WARN  (timeout-thread) [org.infinispan.CLUSTER] ISPN000071: Caught exception when handling command`
+
+	warnings := parseServerWarnings(logs)
+	assert.Equal(t, []serverWarning{
+		{"ISPN000599", "Configuration for cache 'example' uses the deprecated 'expiration.lifespan' attribute"},
+		{"ISPN080059", "No script engines are available"},
+	}, warnings)
+	assert.True(t, warnings[0].isDeprecation())
+	assert.False(t, warnings[1].isDeprecation())
+
+	assert.Empty(t, parseServerWarnings("2022-03-07 10:00:02,000 INFO  (main) [org.infinispan.SERVER] ISPN080001: Infinispan Server started"))
+}
+
+func TestRemoveServerWarnings(t *testing.T) {
+	cluster := types.NamespacedName{Namespace: "ns", Name: "example"}
+	checkedPods.m[cluster] = map[types.UID]struct{}{"pod-uid": {}}
+
+	RemoveServerWarnings(cluster.Namespace, cluster.Name)
+	assert.NotContains(t, checkedPods.m, cluster)
+}
+
+func TestServerWarningsReadsServerContainer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(ispnv1.AddToScheme(scheme))
+
+	i := &ispnv1.Infinispan{ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan", Namespace: "testing-namespace", CreationTimestamp: metav1.Now()}}
+	statefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: i.GetStatefulSetName(), Namespace: i.Namespace, UID: "sts-uid"}}
+	// The pod has a sidecar, so the log request is rejected unless it names the server container
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "example-infinispan-0",
+			Namespace:       i.Namespace,
+			UID:             "pod-uid",
+			Labels:          i.PodSelectorLabels(),
+			OwnerReferences: []metav1.OwnerReference{{UID: statefulSet.UID}},
+		},
+		Spec:   corev1.PodSpec{Containers: []corev1.Container{{Name: provision.InfinispanContainer}, {Name: "istio-proxy"}}},
+		Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}},
+	}
+	c := fakeClient.NewClientBuilder().WithScheme(scheme).WithObjects(i, statefulSet, pod).Build()
+
+	var containers []string
+	restClient := &fake.RESTClient{
+		NegotiatedSerializer: clientgoscheme.Codecs.WithoutConversion(),
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			containers = append(containers, req.URL.Query().Get("container"))
+			logs := "2022-03-07 10:00:01,100 WARN  (main) [org.infinispan.SERVER] ISPN080059: No script engines are available"
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader(logs))}, nil
+		}),
+	}
+	kubernetes := &kube.Kubernetes{Client: c, RestClient: restClient}
+	recorder := record.NewFakeRecorder(10)
+	ctx, err := pipelineContext.Provider(c, scheme, kubernetes, recorder).Get(context.TODO(), &pipeline.ContextProviderConfig{
+		Infinispan: i,
+		Logger:     ctrl.Log.WithName("test"),
+	})
+	require.NoError(t, err)
+	defer RemoveServerWarnings(i.Namespace, i.Name)
+
+	ServerWarnings(i, ctx)
+	assert.Equal(t, []string{provision.InfinispanContainer}, containers)
+	assert.True(t, i.IsConditionTrue(ispnv1.ConditionServerWarnings))
+	assert.Contains(t, <-recorder.Events, "ISPN080059")
+
+	// The logs of a pod are only read once
+	ServerWarnings(i, ctx)
+	assert.Len(t, containers, 1)
+}
@@ -0,0 +1,326 @@
+package manage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/http/curl"
+	ispnClient "github.com/infinispan/infinispan-operator/pkg/infinispan/client"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	users "github.com/infinispan/infinispan-operator/pkg/infinispan/security"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/upgrades"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	EventReasonShadowReplication = "ShadowReplication"
+)
+
+var shadowReplicationLag = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "infinispan_operator_shadow_replication_lag",
+		Help: "The number of entries of a shadowed cache that have not been replicated to the target cluster",
+	},
+	[]string{"namespace", "cluster", "target", "cache"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(shadowReplicationLag)
+}
+
+// ShadowReplication replicates the writes to the caches of spec.shadowReplication.caches to the cluster of
+// spec.shadowReplication.target. Each cache is created in the target cluster if required and a write-behind Remote Store
+// is added to the cache of this cluster, before the existing entries are copied to the target cluster. The replication
+// lag of each cache is then measured periodically and reported in status.shadowReplication.
+// The Remote Stores are removed when the target or the caches are removed from the spec
+func ShadowReplication(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	status := i.Status.ShadowReplication
+	if !i.IsShadowReplicationEnabled() {
+		if status != nil {
+			stopShadowReplication(i, status.Caches, ctx)
+		}
+		return
+	}
+	if i.IsUpgradeCondition() {
+		// The Remote Stores are added at runtime, so they are added to the pods of the upgraded cluster once it is formed
+		return
+	}
+
+	spec := i.Spec.ShadowReplication
+	if status != nil && status.Target != spec.Target {
+		// Writes must no longer be replicated to the previous target before the new target is connected
+		stopShadowReplication(i, status.Caches, ctx)
+		return
+	}
+
+	interval := i.ShadowReplicationLagCheckInterval()
+	if status != nil && status.LastCheckTime != nil && shadowCachesUnchanged(spec.Caches, status.Caches) {
+		if next := status.LastCheckTime.Add(interval); time.Now().Before(next) {
+			requeuePeriodic(ctx, time.Until(next))
+			return
+		}
+	}
+
+	var removed []ispnv1.ShadowCacheStatus
+	if status != nil {
+		for _, c := range status.Caches {
+			if !containsString(spec.Caches, c.Name) {
+				removed = append(removed, c)
+			}
+		}
+	}
+	if len(removed) > 0 && !disconnectShadowCaches(i, removed, ctx) {
+		return
+	}
+
+	target := &ispnv1.Infinispan{}
+	if err := ctx.Resources().Load(spec.Target, target, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+		return
+	}
+	if target.CreationTimestamp.IsZero() || !target.IsWellFormed() {
+		msg := fmt.Sprintf("Waiting for target cluster '%s' to be well formed", spec.Target)
+		updateShadowReplication(i, spec.Target, shadowCacheStatuses(spec.Caches, status), false, msg, ctx)
+		requeuePeriodic(ctx, interval)
+		return
+	}
+
+	sourceClient, err := ctx.InfinispanClient()
+	if err != nil {
+		return
+	}
+	targetPass, err := users.AdminPassword(target.GetAdminSecretName(), target.Namespace, ctx.Kubernetes(), ctx.Ctx())
+	if err != nil {
+		ctx.Requeue(fmt.Errorf("unable to retrieve operator admin password of target cluster '%s': %w", target.Name, err))
+		return
+	}
+	targetClient, err := shadowTargetClient(target, targetPass, ctx)
+	if err != nil {
+		ctx.Requeue(err)
+		return
+	}
+	sourcePass := ctx.ConfigFiles().AdminIdentities.Password
+
+	var errs []string
+	caches := shadowCacheStatuses(spec.Caches, status)
+	for idx := range caches {
+		c := &caches[idx]
+		if err := shadowCache(c, i, target, sourcePass, targetPass, sourceClient, targetClient, ctx); err != nil {
+			ctx.Log().Error(err, "unable to replicate cache to target cluster", "cache", c.Name, "target", target.Name)
+			errs = append(errs, err.Error())
+			continue
+		}
+		shadowReplicationLag.WithLabelValues(i.Namespace, i.Name, target.Name, c.Name).Set(float64(c.Lag))
+	}
+
+	if len(errs) > 0 {
+		updateShadowReplication(i, target.Name, caches, false, strings.Join(errs, "; "), ctx)
+	} else {
+		msg := fmt.Sprintf("Writes to %d caches replicated to cluster '%s'", len(caches), target.Name)
+		updateShadowReplication(i, target.Name, caches, true, msg, ctx)
+	}
+	requeuePeriodic(ctx, interval)
+}
+
+// shadowCache connects the cache to the target cluster and copies its existing entries if required, before measuring the
+// replication lag. The Remote Stores added at runtime are lost when the pods of the source cluster are restarted, in
+// which case the cache is connected and its entries copied again
+func shadowCache(c *ispnv1.ShadowCacheStatus, i, target *ispnv1.Infinispan, sourcePass, targetPass string, sourceClient, targetClient api.Infinispan, ctx pipeline.Context) error {
+	source := sourceClient.Cache(c.Name)
+	targetCache := targetClient.Cache(c.Name)
+	exists, err := source.Exists()
+	if err != nil {
+		return fmt.Errorf("unable to determine if cache '%s' exists: %w", c.Name, err)
+	}
+	if !exists {
+		c.Connected = false
+		return fmt.Errorf("cache '%s' does not exist", c.Name)
+	}
+	if err = upgrades.CreateShadowCache(c.Name, source, targetCache, ctx.Log()); err != nil {
+		return err
+	}
+	connected, err := upgrades.ConnectShadowCache(targetPass, target.GetAdminServiceName(), c.Name, source)
+	if err != nil {
+		c.Connected = false
+		return err
+	}
+	if connected {
+		msg := fmt.Sprintf("Writes to cache '%s' replicated to cluster '%s'", c.Name, target.Name)
+		ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonShadowReplication, msg)
+		// Writes may have been missed whilst the cache was not connected
+		c.Synced = false
+	}
+	c.Connected = true
+
+	if !c.Synced {
+		if err = upgrades.SyncShadowCache(sourcePass, i.GetAdminServiceName(), c.Name, targetCache, ctx.Log()); err != nil {
+			return err
+		}
+		c.Synced = true
+	}
+
+	sourceEntries, err := source.Size()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve size of cache '%s': %w", c.Name, err)
+	}
+	targetEntries, err := targetCache.Size()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve size of cache '%s' in cluster '%s': %w", c.Name, target.Name, err)
+	}
+	c.SourceEntries = int32(sourceEntries)
+	c.TargetEntries = int32(targetEntries)
+	c.Lag = replicationLag(sourceEntries, targetEntries)
+	return nil
+}
+
+// replicationLag returns the number of entries of the source cache that are missing from the target cache. The size of
+// the source cache includes the entries loaded from its write-behind Remote Store, i.e. the union of the entries of both
+// clusters, so the difference is the number of entries that have not been replicated yet
+func replicationLag(sourceEntries, targetEntries int) int32 {
+	if lag := sourceEntries - targetEntries; lag > 0 {
+		return int32(lag)
+	}
+	return 0
+}
+
+// shadowTargetClient returns a client for a ready pod of the target cluster, authenticated with its admin credentials
+func shadowTargetClient(target *ispnv1.Infinispan, pass string, ctx pipeline.Context) (api.Infinispan, error) {
+	podList := &corev1.PodList{}
+	if err := ctx.Resources().List(target.PodSelectorLabels(), podList); err != nil {
+		return nil, fmt.Errorf("unable to list pods of target cluster '%s': %w", target.Name, err)
+	}
+	var podName string
+	for _, pod := range podList.Items {
+		if kube.IsPodReady(pod) {
+			podName = pod.Name
+			break
+		}
+	}
+	if podName == "" {
+		return nil, fmt.Errorf("no ready pods exist in target cluster '%s'", target.Name)
+	}
+	curlClient := curl.New(curl.Config{
+		Credentials: &curl.Credentials{
+			Username: consts.DefaultOperatorUser,
+			Password: pass,
+		},
+		Container: provision.InfinispanContainer,
+		Podname:   podName,
+		Namespace: target.Namespace,
+		Protocol:  "http",
+		Port:      consts.InfinispanAdminPort,
+	}, ctx.Kubernetes())
+	return ispnClient.New(curlClient), nil
+}
+
+// stopShadowReplication disconnects all caches from the previous target and removes status.shadowReplication
+func stopShadowReplication(i *ispnv1.Infinispan, caches []ispnv1.ShadowCacheStatus, ctx pipeline.Context) {
+	if !disconnectShadowCaches(i, caches, ctx) {
+		return
+	}
+	_ = ctx.UpdateInfinispan(func() {
+		if i.Status.ShadowReplication != nil {
+			msg := fmt.Sprintf("Writes no longer replicated to cluster '%s'", i.Status.ShadowReplication.Target)
+			i.SetCondition(ispnv1.ConditionShadowReplication, metav1.ConditionFalse, msg)
+		}
+		i.Status.ShadowReplication = nil
+	})
+}
+
+// disconnectShadowCaches removes the write-behind Remote Stores of the given caches. Returns false if a cache could not
+// be disconnected, in which case the reconciliation is requeued
+func disconnectShadowCaches(i *ispnv1.Infinispan, caches []ispnv1.ShadowCacheStatus, ctx pipeline.Context) bool {
+	target := ""
+	if status := i.Status.ShadowReplication; status != nil {
+		target = status.Target
+	}
+	var connected []string
+	for _, c := range caches {
+		if c.Connected {
+			connected = append(connected, c.Name)
+		}
+		shadowReplicationLag.DeleteLabelValues(i.Namespace, i.Name, target, c.Name)
+	}
+	if len(connected) == 0 || !i.IsWellFormed() {
+		// The Remote Stores of a cluster that is not running are lost with its pods
+		return true
+	}
+	sourceClient, err := ctx.InfinispanClient()
+	if err != nil {
+		return false
+	}
+	for _, name := range connected {
+		if err := upgrades.DisconnectShadowCache(name, sourceClient.Cache(name)); err != nil {
+			ctx.Requeue(err)
+			return false
+		}
+		msg := fmt.Sprintf("Writes to cache '%s' no longer replicated to cluster '%s'", name, target)
+		ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonShadowReplication, msg)
+	}
+	return true
+}
+
+func updateShadowReplication(i *ispnv1.Infinispan, target string, caches []ispnv1.ShadowCacheStatus, replicating bool, msg string, ctx pipeline.Context) {
+	_ = ctx.UpdateInfinispan(func() {
+		now := metav1.Now()
+		i.Status.ShadowReplication = &ispnv1.ShadowReplicationStatus{
+			Target:        target,
+			LastCheckTime: &now,
+			Caches:        caches,
+		}
+		if replicating {
+			i.SetCondition(ispnv1.ConditionShadowReplication, metav1.ConditionTrue, msg)
+		} else {
+			i.SetCondition(ispnv1.ConditionShadowReplication, metav1.ConditionFalse, msg)
+		}
+	})
+}
+
+// shadowCacheStatuses returns the status of each of the given caches, retaining the state recorded by the previous check
+func shadowCacheStatuses(names []string, status *ispnv1.ShadowReplicationStatus) []ispnv1.ShadowCacheStatus {
+	caches := make([]ispnv1.ShadowCacheStatus, len(names))
+	for idx, name := range names {
+		caches[idx] = ispnv1.ShadowCacheStatus{Name: name}
+		if status == nil {
+			continue
+		}
+		for _, c := range status.Caches {
+			if c.Name == name {
+				caches[idx] = c
+				break
+			}
+		}
+	}
+	return caches
+}
+
+// shadowCachesUnchanged returns true if the status contains exactly the configured caches and all of them are connected
+// and synced, so that only the replication lag needs to be measured
+func shadowCachesUnchanged(names []string, caches []ispnv1.ShadowCacheStatus) bool {
+	if len(names) != len(caches) {
+		return false
+	}
+	for idx, c := range caches {
+		if c.Name != names[idx] || !c.Connected || !c.Synced {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
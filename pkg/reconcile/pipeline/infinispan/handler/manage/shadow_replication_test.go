@@ -0,0 +1,34 @@
+package manage
+
+import (
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicationLag(t *testing.T) {
+	assert.Equal(t, int32(5), replicationLag(15, 10))
+	assert.Equal(t, int32(0), replicationLag(10, 10))
+	// Entries written directly to the target cluster are not lag
+	assert.Equal(t, int32(0), replicationLag(10, 12))
+}
+
+func TestShadowCacheStatuses(t *testing.T) {
+	status := &ispnv1.ShadowReplicationStatus{
+		Target: "target",
+		Caches: []ispnv1.ShadowCacheStatus{
+			{Name: "sessions", Connected: true, Synced: true, Lag: 3},
+			{Name: "removed", Connected: true, Synced: true},
+		},
+	}
+	caches := shadowCacheStatuses([]string{"sessions", "products"}, status)
+	assert.Equal(t, []ispnv1.ShadowCacheStatus{
+		{Name: "sessions", Connected: true, Synced: true, Lag: 3},
+		{Name: "products"},
+	}, caches)
+
+	assert.False(t, shadowCachesUnchanged([]string{"sessions", "products"}, status.Caches))
+	assert.False(t, shadowCachesUnchanged([]string{"sessions", "products"}, caches))
+	assert.True(t, shadowCachesUnchanged([]string{"sessions"}, caches[:1]))
+}
@@ -3,6 +3,7 @@ package manage
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
@@ -13,7 +14,10 @@ import (
 	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 func StatefulSetRollingUpgrade(i *ispnv1.Infinispan, ctx pipeline.Context) {
@@ -32,10 +36,14 @@ func StatefulSetRollingUpgrade(i *ispnv1.Infinispan, ctx pipeline.Context) {
 
 	updateNeeded := false
 	rollingUpgrade := true
+	template := statefulSet.Spec.Template.DeepCopy()
 	// Ensure the deployment size is the same as the spec
 	replicas := i.Spec.Replicas
 	previousReplicas := *statefulSet.Spec.Replicas
 	if previousReplicas != replicas {
+		if !acquireOperationLock(i, ctx, ispnv1.OperationScaling) {
+			return
+		}
 		statefulSet.Spec.Replicas = &replicas
 		log.Info("replicas changed, update i", "replicas", replicas, "previous replicas", previousReplicas)
 		updateNeeded = true
@@ -77,11 +85,34 @@ func StatefulSetRollingUpgrade(i *ispnv1.Infinispan, ctx pipeline.Context) {
 		updateNeeded = true
 	}
 
+	// An empty list and a nil list are equivalent, DeepEqual alone would cause an update on every reconcile
+	if (len(spec.ImagePullSecrets) > 0 || len(i.Spec.ImagePullSecrets) > 0) && !reflect.DeepEqual(spec.ImagePullSecrets, i.Spec.ImagePullSecrets) {
+		spec.ImagePullSecrets = i.Spec.ImagePullSecrets
+		updateNeeded = true
+	}
+
+	if serviceAccount := i.GetPodServiceAccountName(); spec.ServiceAccountName != serviceAccount {
+		spec.ServiceAccountName = serviceAccount
+		// DeprecatedServiceAccount is defaulted by the api-server and takes precedence when ServiceAccountName is empty
+		spec.DeprecatedServiceAccount = serviceAccount
+		updateNeeded = true
+	}
+
+	if !reflect.DeepEqual(spec.AutomountServiceAccountToken, i.Spec.AutomountServiceAccountToken) {
+		spec.AutomountServiceAccountToken = i.Spec.AutomountServiceAccountToken
+		updateNeeded = true
+	}
+
+	if period := i.TerminationGracePeriodSeconds(); spec.TerminationGracePeriodSeconds == nil || *spec.TerminationGracePeriodSeconds != period {
+		spec.TerminationGracePeriodSeconds = pointer.Int64Ptr(period)
+		updateNeeded = true
+	}
+
 	// Validate ConfigMap changes (by the hash of the i.yaml key value)
 	updateNeeded = updateStatefulSetEnv(container, statefulSet, "CONFIG_HASH", hash.HashString(configFiles.ServerConfig)) || updateNeeded
 	updateNeeded = updateStatefulSetEnv(container, statefulSet, "ADMIN_IDENTITIES_HASH", hash.HashByte(configFiles.AdminIdentities.IdentitiesFile)) || updateNeeded
 
-	if updateCmdArgs, err := updateStartupArgs(container, configFiles.UserConfig); err != nil {
+	if updateCmdArgs, err := updateStartupArgs(i, container, configFiles.UserConfig); err != nil {
 		ctx.Requeue(err)
 		return
 	} else {
@@ -93,7 +124,7 @@ func StatefulSetRollingUpgrade(i *ispnv1.Infinispan, ctx pipeline.Context) {
 		hashVal = hash.HashString(configFiles.UserConfig.ServerConfig)
 	}
 	updateNeeded = updateStatefulSetAnnotations(statefulSet, "checksum/overlayConfig", hashVal) || updateNeeded
-	updateNeeded = applyOverlayConfigVolume(container, i.Spec.ConfigMapName, spec) || updateNeeded
+	updateNeeded = applyOverlayConfigVolume(i, container, spec) || updateNeeded
 
 	externalArtifactsUpd, err := provision.ApplyExternalArtifactsDownload(i, container, spec)
 	if err != nil {
@@ -101,7 +132,22 @@ func StatefulSetRollingUpgrade(i *ispnv1.Infinispan, ctx pipeline.Context) {
 		return
 	}
 	updateNeeded = externalArtifactsUpd || updateNeeded
+	updateNeeded = updateImagePullPolicy(i, spec) || updateNeeded
+	updateNeeded = updateSecurityContext(i, spec) || updateNeeded
 	updateNeeded = provision.ApplyExternalDependenciesVolume(i, &container.VolumeMounts, spec) || updateNeeded
+	updateNeeded = provision.ApplyJmx(i, container, spec) || updateNeeded
+	updateNeeded = provision.ApplyEndpointPorts(i, container) || updateNeeded
+	updateNeeded = provision.ApplyServiceMesh(i, &statefulSet.Spec.Template) || updateNeeded
+	updateNeeded = provision.ApplyCapacityFactor(i, container, spec) || updateNeeded
+	updateNeeded = provision.ApplyExternalAddress(i, container, spec) || updateNeeded
+	updateNeeded = provision.ApplyKerberos(i, container, spec) || updateNeeded
+	updateNeeded = provision.ApplyVault(i, container, &statefulSet.Spec.Template) || updateNeeded
+	if image, err := provision.TokenReviewImage(i, ctx); err != nil {
+		ctx.Requeue(fmt.Errorf("unable to determine the image of the token review sidecar: %w", err))
+		return
+	} else {
+		updateNeeded = provision.ApplyTokenReview(i, image, spec) || updateNeeded
+	}
 
 	// Validate identities Secret name changes
 	if secretName, secretIndex := findSecretInVolume(spec, provision.IdentitiesVolumeName); secretIndex >= 0 && secretName != i.GetSecretName() {
@@ -111,32 +157,76 @@ func StatefulSetRollingUpgrade(i *ispnv1.Infinispan, ctx pipeline.Context) {
 		updateNeeded = true
 	}
 
-	if i.IsAuthenticationEnabled() {
-		if provision.AddVolumeForUserAuthentication(i, spec) {
-			container.Env = append(container.Env,
-				corev1.EnvVar{Name: "IDENTITIES_HASH", Value: hash.HashByte(configFiles.UserIdentities)},
-			)
-			updateNeeded = true
-		} else {
-			// Validate Secret changes (by the hash of the identities.yaml key value)
-			updateNeeded = updateStatefulSetEnv(container, statefulSet, "IDENTITIES_HASH", hash.HashByte(configFiles.UserIdentities)) || updateNeeded
-		}
+	// Changed identities are applied to the running servers by IdentitiesRotation
+	if provision.AddVolumeForUserAuthentication(i, spec) {
+		updateNeeded = true
 	}
 
+	var oidcClientHash string
+	if i.IsOIDCEnabled() {
+		oidcClientHash = hash.HashString(configFiles.OIDC.ClientSecret)
+	}
+	updateNeeded = updateStatefulSetOptionalEnv(container, statefulSet, "OIDC_CLIENT_HASH", oidcClientHash) || updateNeeded
+	var kerberosHash string
+	if i.IsKerberosEnabled() {
+		kerberosHash = configFiles.KerberosHash
+	}
+	updateNeeded = updateStatefulSetOptionalEnv(container, statefulSet, "KERBEROS_HASH", kerberosHash) || updateNeeded
+	var credentialsHash string
+	if i.HasCredentials() {
+		credentialsHash = provision.CredentialsHash(configFiles.Credentials)
+	}
+	updateNeeded = updateStatefulSetOptionalEnv(container, statefulSet, provision.CredentialsHashEnv, credentialsHash) || updateNeeded
+	passwordAlgorithms := strings.Join(i.GetPasswordAlgorithms(), ",")
+	updateNeeded = updateStatefulSetOptionalEnv(container, statefulSet, provision.PasswordAlgorithmsEnv, passwordAlgorithms) || updateNeeded
+
+	var certificateUpdate bool
 	if i.IsEncryptionEnabled() {
+		// The certificates of a renamed Secret are loaded by restarting the pods one at a time with PartitionedRollout,
+		// so that each pod only restarts once the cluster is healthy
+		secretVolumes := map[string]string{provision.EncryptKeystoreVolumeName: i.GetKeystoreSecretName()}
+		if i.IsClientCertEnabled() {
+			secretVolumes[provision.EncryptTruststoreVolumeName] = i.GetTruststoreSecretName()
+		}
+		for volumeName, secretName := range secretVolumes {
+			if currentName, secretIndex := findSecretInVolume(spec, volumeName); secretIndex >= 0 && currentName != secretName {
+				spec.Volumes[secretIndex].Secret.SecretName = secretName
+				log.Info("encryption secret renamed, starting rolling update", "secret", secretName, "previous secret", currentName)
+				certificateUpdate = true
+				updateNeeded = true
+			}
+		}
 		provision.AddVolumesForEncryption(i, spec)
-		updateNeeded = updateStatefulSetEnv(container, statefulSet, "KEYSTORE_HASH", hash.HashByte(configFiles.Keystore.PemFile)+hash.HashByte(configFiles.Keystore.File)) || updateNeeded
-
 		if i.IsClientCertEnabled() {
 			updateNeeded = updateStatefulSetEnv(container, statefulSet, "TRUSTSTORE_HASH", hash.HashByte(configFiles.Truststore.File)) || updateNeeded
 		}
 	}
+	var siteTLSHash string
+	if i.IsSiteTLSEnabled() {
+		siteTLSHash = provision.SiteTLSHash(configFiles.Transport.Keystore, configFiles.Transport.Truststore)
+	}
+	updateNeeded = updateStatefulSetOptionalEnv(container, statefulSet, provision.SiteTLSHashEnv, siteTLSHash) || updateNeeded
+	if i.IsSiteTLSEnabled() && configFiles.Transport.Truststore != nil {
+		// The relay truststore replaces the truststore Secret of the user once CA certificates of remote sites are configured
+		secretName := provision.SiteTrustStoreSecretName(i)
+		if currentName, secretIndex := findSecretInVolume(spec, provision.SiteTruststoreVolumeName); secretIndex < 0 {
+			provision.AddSecretVolume(secretName, provision.SiteTruststoreVolumeName, consts.SiteTrustStoreRoot, spec, provision.InfinispanContainer)
+			updateNeeded = true
+		} else if currentName != secretName {
+			spec.Volumes[secretIndex].Secret.SecretName = secretName
+			updateNeeded = true
+		}
+	}
 
-	// Validate extra Java options changes
-	if updateStatefulSetEnv(container, statefulSet, "EXTRA_JAVA_OPTIONS", ispnContr.ExtraJvmOpts) {
-		updateStatefulSetEnv(container, statefulSet, "JAVA_OPTIONS", i.GetJavaOptions())
-		updateNeeded = true
+	// Validate Java options changes. JAVA_OPTIONS is compared separately as the heap size derived from
+	// spec.container.maxRamPercentage depends on the memory limit
+	updateNeeded = updateStatefulSetEnv(container, statefulSet, "EXTRA_JAVA_OPTIONS", ispnContr.ExtraJvmOpts) || updateNeeded
+	updateNeeded = updateStatefulSetEnv(container, statefulSet, "JAVA_OPTIONS", i.GetJavaOptions()) || updateNeeded
+
+	for _, env := range provision.LocalizationEnv(i) {
+		updateNeeded = updateStatefulSetOptionalEnv(container, statefulSet, env.Name, env.Value) || updateNeeded
 	}
+	updateNeeded = updateContainerEnv(i, container, statefulSet) || updateNeeded
 
 	if updateNeeded {
 		log.Info("updateNeeded")
@@ -146,9 +236,21 @@ func StatefulSetRollingUpgrade(i *ispnv1.Infinispan, ctx pipeline.Context) {
 			labelsForPod[consts.StatefulSetPodLabel] = i.Name
 			statefulSet.Spec.Template.Labels = labelsForPod
 		}
+		// Hold all pods at the current revision so that PartitionedRollout updates a single pod at a time
+		if (i.IsPartitionedRollout() || certificateUpdate) && !equality.Semantic.DeepEqual(template, &statefulSet.Spec.Template) {
+			setPartition(statefulSet, replicas)
+		}
 		err := ctx.Resources().Update(statefulSet, pipeline.RetryOnErr)
 		if err != nil {
 			log.Error(err, "failed to update StatefulSet", "StatefulSet.Name", statefulSet.Name)
+			return
+		}
+		if certificateUpdate {
+			msg := fmt.Sprintf("Rolling update started to load the certificates of Secret '%s'", i.GetKeystoreSecretName())
+			_ = ctx.UpdateInfinispan(func() {
+				i.SetCondition(ispnv1.ConditionCertificateUpdating, metav1.ConditionTrue, msg)
+			})
+			ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonCertificateUpdating, msg)
 		}
 		return
 	}
@@ -175,8 +277,51 @@ func updateStatefulSetEnv(ispnContainer *corev1.Container, statefulSet *appsv1.S
 	return false
 }
 
-func updateStartupArgs(ispnContainer *corev1.Container, userConfig pipeline.UserConfig) (bool, error) {
-	newArgs := provision.BuildServerContainerArgs(userConfig)
+// updateStatefulSetOptionalEnv behaves like updateStatefulSetEnv, but removes the env variable when newValue is empty
+func updateStatefulSetOptionalEnv(ispnContainer *corev1.Container, statefulSet *appsv1.StatefulSet, envName, newValue string) bool {
+	if newValue != "" {
+		return updateStatefulSetEnv(ispnContainer, statefulSet, envName, newValue)
+	}
+	env := &ispnContainer.Env
+	envIndex := kube.GetEnvVarIndex(envName, env)
+	if envIndex < 0 {
+		return false
+	}
+	*env = append((*env)[:envIndex], (*env)[envIndex+1:]...)
+	statefulSet.Spec.Template.Annotations["updateDate"] = time.Now().String()
+	return true
+}
+
+// updateContainerEnv applies the spec.container.env variables to the server container, removing the variables that
+// have been removed from the spec since the StatefulSet was last updated
+func updateContainerEnv(i *ispnv1.Infinispan, ispnContainer *corev1.Container, statefulSet *appsv1.StatefulSet) (updated bool) {
+	env := &ispnContainer.Env
+	userEnv := provision.ContainerEnv(i)
+	if previous := statefulSet.Annotations[consts.AnnotationContainerEnv]; previous != "" {
+		for _, name := range strings.Split(previous, ",") {
+			if envIndex := kube.GetEnvVarIndex(name, env); envIndex >= 0 && kube.GetEnvVarIndex(name, &userEnv) < 0 {
+				*env = append((*env)[:envIndex], (*env)[envIndex+1:]...)
+				updated = true
+			}
+		}
+	}
+	for _, e := range userEnv {
+		if envIndex := kube.GetEnvVarIndex(e.Name, env); envIndex < 0 {
+			*env = append(*env, e)
+			updated = true
+		} else if !reflect.DeepEqual((*env)[envIndex], e) {
+			(*env)[envIndex] = e
+			updated = true
+		}
+	}
+	if updated {
+		statefulSet.Spec.Template.Annotations["updateDate"] = time.Now().String()
+	}
+	return updateStatefulSetAnnotations(statefulSet, consts.AnnotationContainerEnv, provision.ContainerEnvNames(i)) || updated
+}
+
+func updateStartupArgs(ispn *ispnv1.Infinispan, ispnContainer *corev1.Container, userConfig pipeline.UserConfig) (bool, error) {
+	newArgs := provision.BuildServerContainerArgs(ispn, userConfig)
 	if len(newArgs) == len(ispnContainer.Args) {
 		var changed bool
 		for i := range newArgs {
@@ -218,14 +363,15 @@ func updateStatefulSetAnnotations(statefulSet *appsv1.StatefulSet, name, value s
 }
 
 // TODO create generic function for adding/removing volumes from PodSpec
-func applyOverlayConfigVolume(ispnContainer *corev1.Container, configMapName string, spec *corev1.PodSpec) bool {
+func applyOverlayConfigVolume(ispn *ispnv1.Infinispan, ispnContainer *corev1.Container, spec *corev1.PodSpec) bool {
+	configMapName := ispn.Spec.ConfigMapName
 	volumes := &spec.Volumes
 	volumeMounts := &ispnContainer.VolumeMounts
 	volumePosition := findVolume(*volumes, provision.UserConfVolumeName)
 	if configMapName != "" {
 		// Add the overlay volume if needed
 		if volumePosition < 0 {
-			*volumeMounts = append(*volumeMounts, corev1.VolumeMount{Name: provision.UserConfVolumeName, MountPath: provision.OverlayConfigMountPath})
+			*volumeMounts = append(*volumeMounts, corev1.VolumeMount{Name: provision.UserConfVolumeName, MountPath: provision.OverlayConfigMountPath(ispn)})
 			*volumes = append(*volumes, corev1.Volume{
 				Name: provision.UserConfVolumeName,
 				VolumeSource: corev1.VolumeSource{
@@ -277,3 +423,46 @@ func findSecretInVolume(pod *corev1.PodSpec, volumeName string) (string, int) {
 	}
 	return "", -1
 }
+
+// serverContainers returns the containers of the pod that run the Infinispan image
+func serverContainers(spec *corev1.PodSpec) []*corev1.Container {
+	var serverContainers []*corev1.Container
+	for _, containers := range [][]corev1.Container{spec.Containers, spec.InitContainers} {
+		for idx := range containers {
+			if c := &containers[idx]; c.Name == provision.InfinispanContainer || c.Name == provision.ExternalArtifactsDownloadInitContainer || c.Name == provision.JmxInitContainer {
+				serverContainers = append(serverContainers, c)
+			}
+		}
+	}
+	return serverContainers
+}
+
+// updateImagePullPolicy sets the pull policy of the containers running the Infinispan image, restoring the default
+// policy when spec.imagePullPolicy is removed
+func updateImagePullPolicy(i *ispnv1.Infinispan, spec *corev1.PodSpec) (updated bool) {
+	policy := i.ImagePullPolicy()
+	for _, c := range serverContainers(spec) {
+		if c.ImagePullPolicy != policy {
+			c.ImagePullPolicy = policy
+			updated = true
+		}
+	}
+	return
+}
+
+// updateSecurityContext applies spec.security.podSecurityContext and spec.security.containerSecurityContext to the pod
+// and to the containers running the Infinispan image
+func updateSecurityContext(i *ispnv1.Infinispan, spec *corev1.PodSpec) (updated bool) {
+	if !provision.PodSecurityContextEquals(spec.SecurityContext, i.Spec.Security.PodSecurityContext) {
+		spec.SecurityContext = i.Spec.Security.PodSecurityContext
+		updated = true
+	}
+
+	for _, c := range serverContainers(spec) {
+		if !reflect.DeepEqual(c.SecurityContext, i.Spec.Security.ContainerSecurityContext) {
+			c.SecurityContext = i.Spec.Security.ContainerSecurityContext
+			updated = true
+		}
+	}
+	return
+}
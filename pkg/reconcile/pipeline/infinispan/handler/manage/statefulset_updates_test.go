@@ -0,0 +1,118 @@
+package manage
+
+import (
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestUpdateImagePullPolicy(t *testing.T) {
+	testTable := []struct {
+		name           string
+		pullPolicy     corev1.PullPolicy
+		current        corev1.PullPolicy
+		expectedPolicy corev1.PullPolicy
+		expectedUpdate bool
+	}{
+		{"policy unchanged", corev1.PullNever, corev1.PullNever, corev1.PullNever, false},
+		{"policy changed", corev1.PullNever, corev1.PullIfNotPresent, corev1.PullNever, true},
+		{"policy removed", "", corev1.PullNever, corev1.PullIfNotPresent, true},
+		{"default policy unchanged", "", corev1.PullIfNotPresent, corev1.PullIfNotPresent, false},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &ispnv1.Infinispan{
+				Spec: ispnv1.InfinispanSpec{
+					Image:           pointer.StringPtr("quay.io/infinispan/server:13.0"),
+					ImagePullPolicy: tt.pullPolicy,
+				},
+			}
+			spec := &corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{Name: "data-chmod-pv", ImagePullPolicy: corev1.PullAlways},
+					{Name: provision.ExternalArtifactsDownloadInitContainer, ImagePullPolicy: tt.current},
+				},
+				Containers: []corev1.Container{{Name: provision.InfinispanContainer, ImagePullPolicy: tt.current}},
+			}
+			assert.Equal(t, tt.expectedUpdate, updateImagePullPolicy(i, spec))
+			assert.Equal(t, tt.expectedPolicy, spec.Containers[0].ImagePullPolicy)
+			assert.Equal(t, tt.expectedPolicy, spec.InitContainers[1].ImagePullPolicy)
+			assert.Equal(t, corev1.PullAlways, spec.InitContainers[0].ImagePullPolicy, "unrelated init containers are untouched")
+		})
+	}
+}
+
+func TestUpdateSecurityContext(t *testing.T) {
+	podSecurityContext := &corev1.PodSecurityContext{RunAsNonRoot: pointer.BoolPtr(true)}
+	containerSecurityContext := &corev1.SecurityContext{AllowPrivilegeEscalation: pointer.BoolPtr(false)}
+	testTable := []struct {
+		name             string
+		pod              *corev1.PodSecurityContext
+		container        *corev1.SecurityContext
+		currentPod       *corev1.PodSecurityContext
+		currentContainer *corev1.SecurityContext
+		expectedUpdate   bool
+	}{
+		{"unset", nil, nil, nil, nil, false},
+		{"defaulted empty pod context", nil, nil, &corev1.PodSecurityContext{}, nil, false},
+		{"unchanged", podSecurityContext, containerSecurityContext, podSecurityContext.DeepCopy(), containerSecurityContext.DeepCopy(), false},
+		{"added", podSecurityContext, containerSecurityContext, nil, nil, true},
+		{"removed", nil, nil, podSecurityContext.DeepCopy(), containerSecurityContext.DeepCopy(), true},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &ispnv1.Infinispan{
+				Spec: ispnv1.InfinispanSpec{
+					Security: ispnv1.InfinispanSecurity{PodSecurityContext: tt.pod, ContainerSecurityContext: tt.container},
+				},
+			}
+			spec := &corev1.PodSpec{
+				SecurityContext: tt.currentPod,
+				InitContainers:  []corev1.Container{{Name: provision.ExternalArtifactsDownloadInitContainer, SecurityContext: tt.currentContainer}},
+				Containers:      []corev1.Container{{Name: provision.InfinispanContainer, SecurityContext: tt.currentContainer}},
+			}
+			assert.Equal(t, tt.expectedUpdate, updateSecurityContext(i, spec))
+			assert.True(t, provision.PodSecurityContextEquals(tt.pod, spec.SecurityContext))
+			assert.Equal(t, tt.container, spec.Containers[0].SecurityContext)
+			assert.Equal(t, tt.container, spec.InitContainers[0].SecurityContext)
+		})
+	}
+}
+
+func TestUpdateContainerEnv(t *testing.T) {
+	podName := corev1.EnvVar{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}}
+	i := &ispnv1.Infinispan{
+		Spec: ispnv1.InfinispanSpec{
+			Container: ispnv1.InfinispanContainerSpec{Env: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}, podName}},
+		},
+	}
+	container := &corev1.Container{Env: []corev1.EnvVar{{Name: "JAVA_OPTIONS", Value: "-Xmx1G"}, {Name: "AGENT", Value: "removed"}}}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{consts.AnnotationContainerEnv: "AGENT"}},
+		Spec:       appsv1.StatefulSetSpec{Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}},
+	}
+
+	assert.True(t, updateContainerEnv(i, container, statefulSet))
+	assert.Equal(t, []corev1.EnvVar{{Name: "JAVA_OPTIONS", Value: "-Xmx1G"}, {Name: "HTTP_PROXY", Value: "http://proxy:3128"}, provision.ContainerEnv(i)[1]}, container.Env)
+	assert.Equal(t, "HTTP_PROXY,POD_NAME", statefulSet.Annotations[consts.AnnotationContainerEnv])
+	assert.Equal(t, "v1", container.Env[2].ValueFrom.FieldRef.APIVersion)
+
+	// The api-server defaults must not cause an update on every reconcile
+	assert.False(t, updateContainerEnv(i, container, statefulSet))
+
+	i.Spec.Container.Env = []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://other-proxy:3128"}}
+	assert.True(t, updateContainerEnv(i, container, statefulSet))
+	assert.Equal(t, []corev1.EnvVar{{Name: "JAVA_OPTIONS", Value: "-Xmx1G"}, {Name: "HTTP_PROXY", Value: "http://other-proxy:3128"}}, container.Env)
+
+	i.Spec.Container.Env = nil
+	assert.True(t, updateContainerEnv(i, container, statefulSet))
+	assert.Equal(t, []corev1.EnvVar{{Name: "JAVA_OPTIONS", Value: "-Xmx1G"}}, container.Env)
+	assert.NotContains(t, statefulSet.Annotations, consts.AnnotationContainerEnv)
+}
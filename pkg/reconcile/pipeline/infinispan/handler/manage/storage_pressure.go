@@ -0,0 +1,208 @@
+package manage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	EventReasonStoragePressure        = "StoragePressure"
+	EventReasonStorageExpanded        = "StorageExpanded"
+	EventReasonStorageExpansionFailed = "StorageExpansionFailed"
+
+	// Expanded storage is rounded up to a multiple of this value
+	storageIncrement = 1024 * 1024
+)
+
+// StoragePressure periodically measures the usage of the data volume of each ready pod, reporting the volumes whose
+// usage exceeds the threshold with the StoragePressure condition and events before file stores start failing writes.
+// If enabled, the PersistentVolumeClaims of the volumes under pressure are expanded up to the configured maximum size
+func StoragePressure(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	interval := i.StorageMonitoringInterval()
+	if status := i.Status.Storage; status != nil {
+		if next := status.LastCheckTime.Add(interval); time.Now().Before(next) {
+			requeuePeriodic(ctx, time.Until(next))
+			return
+		}
+	}
+
+	podList, err := ctx.InfinispanPods()
+	if err != nil {
+		return
+	}
+	var volumes []ispnv1.VolumeUsage
+	for _, pod := range podList.Items {
+		if !kube.IsPodReady(pod) {
+			continue
+		}
+		usage, err := volumeUsage(i, pod.Name, ctx)
+		if err != nil {
+			ctx.Log().Info("Unable to measure the usage of the data volume", "pod", pod.Name, "error", err)
+			continue
+		}
+		volumes = append(volumes, *usage)
+	}
+	if len(volumes) == 0 {
+		requeuePeriodic(ctx, interval)
+		return
+	}
+
+	threshold := i.StoragePressureThreshold()
+	var pressure []string
+	for _, v := range volumes {
+		if v.UsedPercent <= threshold {
+			continue
+		}
+		msg := fmt.Sprintf("Data volume of pod '%s' is %d%% full", v.Pod, v.UsedPercent)
+		pressure = append(pressure, msg)
+		// Only emit events when a volume crosses the threshold, not on every check whilst it remains under pressure
+		if !wasUnderPressure(i.Status.Storage, v.Pod, threshold) {
+			ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonStoragePressure, msg)
+		}
+		if i.IsStorageAutoExpandEnabled() {
+			expandVolume(i, v.PVC, ctx)
+		}
+	}
+	ctx.Log().Info("Data volume usage measured", "volumes", len(volumes), "underPressure", len(pressure))
+
+	if err := ctx.UpdateInfinispan(func() {
+		i.Status.Storage = &ispnv1.StorageStatus{
+			LastCheckTime: metav1.Now(),
+			Volumes:       volumes,
+		}
+		if len(pressure) > 0 {
+			i.SetCondition(ispnv1.ConditionStoragePressure, metav1.ConditionTrue, strings.Join(pressure, "; "))
+		} else if i.HasCondition(ispnv1.ConditionStoragePressure) {
+			i.SetCondition(ispnv1.ConditionStoragePressure, metav1.ConditionFalse, "")
+		}
+	}); err != nil {
+		return
+	}
+	requeuePeriodic(ctx, interval)
+}
+
+// volumeUsage measures the usage of the file system mounted at the data path of the given pod
+func volumeUsage(i *ispnv1.Infinispan, podName string, ctx pipeline.Context) (*ispnv1.VolumeUsage, error) {
+	execOut, err := ctx.Kubernetes().ExecWithOptions(kube.ExecOptions{
+		Container: provision.InfinispanContainer,
+		Command:   []string{"df", "-Pk", provision.DataMountPath(i)},
+		PodName:   podName,
+		Namespace: i.Namespace,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error executing df: %w", err)
+	}
+	capacity, usedPercent, err := parseDiskFree(execOut.String())
+	if err != nil {
+		return nil, err
+	}
+	return &ispnv1.VolumeUsage{
+		Pod:         podName,
+		PVC:         dataVolumeClaimName(i, podName),
+		Capacity:    resource.NewQuantity(capacity, resource.BinarySI).String(),
+		UsedPercent: usedPercent,
+	}, nil
+}
+
+// dataVolumeClaimName returns the name of the PersistentVolumeClaim created by the StatefulSet for the given pod
+func dataVolumeClaimName(i *ispnv1.Infinispan, podName string) string {
+	ordinal := strings.TrimPrefix(podName, i.GetStatefulSetName()+"-")
+	return fmt.Sprintf("%s-%s-%s", provision.DataMountVolume, i.GetStatefulSetName(), ordinal)
+}
+
+// parseDiskFree returns the capacity in bytes and the used percentage of the file system reported by 'df -Pk'. The
+// percentage is rounded up in the same way as df, so that a volume is never reported as less full than it is
+func parseDiskFree(out string) (int64, int32, error) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 2 {
+		return 0, 0, fmt.Errorf("unexpected df output '%s'", out)
+	}
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 6 {
+		return 0, 0, fmt.Errorf("unexpected df output '%s'", out)
+	}
+	values := make([]int64, 3)
+	for idx := range values {
+		val, err := strconv.ParseInt(fields[idx+1], 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("unexpected df output '%s': %w", out, err)
+		}
+		values[idx] = val
+	}
+	capacityKb, usedKb, availableKb := values[0], values[1], values[2]
+	if usedKb+availableKb == 0 {
+		return capacityKb * 1024, 0, nil
+	}
+	usedPercent := (usedKb*100 + usedKb + availableKb - 1) / (usedKb + availableKb)
+	return capacityKb * 1024, int32(usedPercent), nil
+}
+
+func wasUnderPressure(status *ispnv1.StorageStatus, pod string, threshold int32) bool {
+	if status == nil {
+		return false
+	}
+	for _, v := range status.Volumes {
+		if v.Pod == pod {
+			return v.UsedPercent > threshold
+		}
+	}
+	return false
+}
+
+// expandVolume increases the storage requested by the PersistentVolumeClaim, unless a previous expansion is still in
+// progress or the claim has already reached spec.storageMonitoring.autoExpand.maxSize
+func expandVolume(i *ispnv1.Infinispan, pvcName string, ctx pipeline.Context) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := ctx.Resources().Load(pvcName, pvc); err != nil {
+		ctx.Log().Error(err, "unable to load PersistentVolumeClaim", "pvc", pvcName)
+		return
+	}
+	requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok && capacity.Cmp(requested) < 0 {
+		ctx.Log().Info("Expansion of PersistentVolumeClaim in progress", "pvc", pvcName, "requested", requested.String(), "capacity", capacity.String())
+		return
+	}
+	maxSize, err := resource.ParseQuantity(i.Spec.StorageMonitoring.AutoExpand.MaxSize)
+	if err != nil {
+		ctx.Log().Error(err, "invalid spec.storageMonitoring.autoExpand.maxSize")
+		return
+	}
+	expanded := expandedStorageSize(requested, maxSize, i.StorageExpansionIncrement())
+	if expanded.Cmp(requested) <= 0 {
+		ctx.Log().Info("PersistentVolumeClaim has reached the maximum size", "pvc", pvcName, "maxSize", maxSize.String())
+		return
+	}
+
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = expanded
+	if err := ctx.Resources().Update(pvc); err != nil {
+		// The API server rejects the update if the StorageClass of the claim does not allow volume expansion
+		msg := fmt.Sprintf("Unable to expand PersistentVolumeClaim '%s' to %s: %s", pvcName, expanded.String(), err)
+		ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonStorageExpansionFailed, msg)
+		ctx.Log().Info(msg)
+		return
+	}
+	msg := fmt.Sprintf("Expanded PersistentVolumeClaim '%s' from %s to %s", pvcName, requested.String(), expanded.String())
+	ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonStorageExpanded, msg)
+	ctx.Log().Info(msg)
+}
+
+// expandedStorageSize increases the requested storage by the given percentage, rounded up to the next storageIncrement
+// and limited to maxSize
+func expandedStorageSize(requested, maxSize resource.Quantity, increment int32) resource.Quantity {
+	size := requested.Value() + requested.Value()*int64(increment)/100
+	size = (size + storageIncrement - 1) / storageIncrement * storageIncrement
+	if size > maxSize.Value() {
+		size = maxSize.Value()
+	}
+	return *resource.NewQuantity(size, resource.BinarySI)
+}
@@ -0,0 +1,42 @@
+package manage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestParseDiskFree(t *testing.T) {
+	out := `Filesystem     1024-blocks    Used Available Capacity Mounted on
+/dev/sdb           1038336  850000    188336      82% /opt/infinispan/server/data
+`
+	capacity, usedPercent, err := parseDiskFree(out)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1038336*1024), capacity)
+	assert.Equal(t, int32(82), usedPercent)
+
+	_, _, err = parseDiskFree("df: /opt/infinispan/server/data: No such file or directory")
+	assert.Error(t, err)
+}
+
+func TestExpandedStorageSize(t *testing.T) {
+	testTable := []struct {
+		name      string
+		requested string
+		maxSize   string
+		increment int32
+		expected  string
+	}{
+		{"increment", "1Gi", "10Gi", 25, "1280Mi"},
+		{"rounded up", "1G", "10Gi", 10, "1050Mi"},
+		{"limited to max size", "1Gi", "1100Mi", 25, "1100Mi"},
+		{"max size reached", "2Gi", "2Gi", 25, "2Gi"},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded := expandedStorageSize(resource.MustParse(tt.requested), resource.MustParse(tt.maxSize), tt.increment)
+			assert.Equal(t, tt.expected, expanded.String())
+		})
+	}
+}
@@ -8,10 +8,8 @@ import (
 	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
 	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
 	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
-	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	ingressv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,10 +39,37 @@ func ScheduleGracefulShutdownUpgrade(i *ispnv1.Infinispan, ctx pipeline.Context)
 	// If the operator's default image differs from the pod's default image,
 	// schedule an upgrade by gracefully shutting down the current cluster.
 	if podDefaultImage != consts.DefaultImageName {
+		migrate := dataMigrationRequired(i, podDefaultImage)
+		if migrate {
+			blocker, err := dataMigrationBlocker(i, podList, ctx)
+			if err != nil {
+				return
+			}
+			if blocker != "" {
+				if i.GetCondition(ispnv1.ConditionDataMigrated).Message != blocker {
+					ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonDataMigration, blocker)
+					_ = ctx.UpdateInfinispan(func() {
+						i.SetCondition(ispnv1.ConditionDataMigrated, metav1.ConditionFalse, blocker)
+					})
+				}
+				ctx.RequeueEventually(dataMigrationPollInterval)
+				return
+			}
+		}
+
+		if !acquireOperationLock(i, ctx, ispnv1.OperationUpgrade) {
+			return
+		}
+
 		ctx.Log().Info("schedule an Infinispan cluster upgrade", "pod default image", podDefaultImage, "desired image", consts.DefaultImageName)
 		ctx.Requeue(
 			ctx.UpdateInfinispan(func() {
 				i.SetCondition(ispnv1.ConditionUpgrade, metav1.ConditionTrue, "")
+				if migrate {
+					i.SetCondition(ispnv1.ConditionDataMigrated, metav1.ConditionFalse, "Data migration pending")
+				} else {
+					i.RemoveCondition(ispnv1.ConditionDataMigrated)
+				}
 				i.Spec.Replicas = 0
 			}),
 		)
@@ -73,6 +98,9 @@ func GracefulShutdown(i *ispnv1.Infinispan, ctx pipeline.Context) {
 			logger.Info("StatefulSet.Spec.Replicas!=0")
 			// Only send a GracefulShutdown request to the server if it hasn't succeeded already
 			if !i.IsConditionTrue(ispnv1.ConditionStopping) {
+				if !i.IsUpgradeCondition() && !acquireOperationLock(i, ctx, ispnv1.OperationScaling) {
+					return
+				}
 				logger.Info("Sending GracefulShutdown request to the Infinispan cluster")
 
 				podList, err := ctx.InfinispanPods()
@@ -162,6 +190,20 @@ func GracefulShutdownUpgrade(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	logger := ctx.Log()
 
 	if i.IsUpgradeCondition() && !i.IsConditionTrue(ispnv1.ConditionStopping) && i.Status.ReplicasWantedAtRestart > 0 {
+		// The data of the pods must be migrated before the StatefulSet is recreated with the upgraded image
+		if i.HasCondition(ispnv1.ConditionDataMigrated) && !i.IsConditionTrue(ispnv1.ConditionDataMigrated) && !i.IsDataMigrationSkipped() {
+			logger.Info("GracefulShutdown complete, migrating data")
+			if !migrateData(i, ctx) {
+				return
+			}
+			if err := ctx.UpdateInfinispan(func() {
+				i.SetCondition(ispnv1.ConditionDataMigrated, metav1.ConditionTrue, "")
+			}); err != nil {
+				ctx.Requeue(err)
+				return
+			}
+		}
+
 		logger.Info("GracefulShutdown complete, removing existing Infinispan resources")
 		destroyResources(i, ctx)
 		logger.Info("Infinispan resources removed", "replicasWantedAtRestart", i.Status.ReplicasWantedAtRestart)
@@ -214,12 +256,8 @@ func destroyResources(i *ispnv1.Infinispan, ctx pipeline.Context) {
 		}
 	}
 
-	if ctx.IsTypeSupported(pipeline.RouteGVK) {
-		if err := del(i.GetServiceExternalName(), &routev1.Route{}); err != nil {
-			return
-		}
-	} else if ctx.IsTypeSupported(pipeline.IngressGVK) {
-		if err := del(i.GetServiceExternalName(), &ingressv1.Ingress{}); err != nil {
+	for _, provider := range provision.ExposeProviders(ctx) {
+		if err := provider.Remove(i, ctx); err != nil {
 			return
 		}
 	}
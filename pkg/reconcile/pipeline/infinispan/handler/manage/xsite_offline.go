@@ -0,0 +1,94 @@
+package manage
+
+import (
+	"fmt"
+	"sort"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	EventReasonXSiteTakenOffline  = "XSiteTakenOffline"
+	EventReasonXSiteBroughtOnline = "XSiteBroughtOnline"
+)
+
+// XSiteOfflineLocations takes the backups to the sites of spec.service.sites.locations[].offline offline, and brings
+// them online again once the field is removed. Only the sites taken offline by the operator, which are recorded in
+// status.sites.offlineLocations, are brought online, so that backups taken offline by the server after failures or
+// with the CLI are left as they are
+func XSiteOfflineLocations(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	if !i.IsWellFormed() || i.Status.Sites == nil {
+		return
+	}
+
+	takeOffline, bringOnline, offlineLocations := siteOfflineActions(i)
+	if len(takeOffline) == 0 && len(bringOnline) == 0 && len(offlineLocations) == len(i.Status.Sites.OfflineLocations) {
+		return
+	}
+
+	ispnClient, err := ctx.InfinispanClient()
+	if err != nil {
+		ctx.Requeue(err)
+		return
+	}
+	xsite := ispnClient.Container().Xsite()
+	for _, site := range takeOffline {
+		if err := xsite.TakeOffline(site); err != nil {
+			ctx.Requeue(fmt.Errorf("unable to take the backups to site '%s' offline: %w", site, err))
+			return
+		}
+		ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonXSiteTakenOffline, fmt.Sprintf("Backups to site '%s' taken offline", site))
+	}
+	for _, site := range bringOnline {
+		if err := xsite.BringOnline(site); err != nil {
+			ctx.Requeue(fmt.Errorf("unable to bring the backups to site '%s' online: %w", site, err))
+			return
+		}
+		ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonXSiteBroughtOnline, fmt.Sprintf("Backups to site '%s' brought online", site))
+	}
+
+	if err := ctx.UpdateInfinispan(func() {
+		if i.Status.Sites != nil {
+			i.Status.Sites.OfflineLocations = offlineLocations
+		}
+	}); err != nil {
+		ctx.Requeue(err)
+	}
+}
+
+// siteOfflineActions returns the sites whose backups must be taken offline or brought online, and the sorted sites
+// that are offline because of spec.service.sites.locations[].offline once the actions are complete
+func siteOfflineActions(i *ispnv1.Infinispan) (takeOffline, bringOnline, offlineLocations []string) {
+	backups := map[string]ispnv1.SiteBackupState{}
+	for _, backup := range i.Status.Sites.Backups {
+		backups[backup.Name] = backup.Status
+	}
+	takenOffline := map[string]bool{}
+	for _, site := range i.Status.Sites.OfflineLocations {
+		takenOffline[site] = true
+	}
+
+	remoteLocations := i.GetRemoteSiteLocations()
+	for name, location := range remoteLocations {
+		if !location.Offline {
+			continue
+		}
+		offlineLocations = append(offlineLocations, name)
+		// The backups are taken offline again if they were brought online by other means
+		if status, reported := backups[name]; !takenOffline[name] || (reported && status != ispnv1.SiteBackupOffline) {
+			takeOffline = append(takeOffline, name)
+		}
+	}
+	for site := range takenOffline {
+		// Sites removed from spec.service.sites.locations are no longer backup locations of the cluster
+		if location, exists := remoteLocations[site]; exists && !location.Offline {
+			bringOnline = append(bringOnline, site)
+		}
+	}
+	sort.Strings(takeOffline)
+	sort.Strings(bringOnline)
+	sort.Strings(offlineLocations)
+	return
+}
@@ -0,0 +1,54 @@
+package manage
+
+import (
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteOfflineActions(t *testing.T) {
+	i := &ispnv1.Infinispan{
+		Spec: ispnv1.InfinispanSpec{
+			Service: ispnv1.InfinispanServiceSpec{
+				Sites: &ispnv1.InfinispanSitesSpec{
+					Local: ispnv1.InfinispanSitesLocalSpec{Name: "SiteA"},
+					Locations: []ispnv1.InfinispanSiteLocationSpec{
+						{Name: "SiteA"},
+						{Name: "SiteB", Offline: true},
+						{Name: "SiteC"},
+						{Name: "SiteD", Offline: true},
+					},
+				},
+			},
+		},
+		Status: ispnv1.InfinispanStatus{
+			Sites: &ispnv1.CrossSiteStatus{
+				Backups: []ispnv1.SiteBackupStatus{
+					{Name: "SiteB", Status: ispnv1.SiteBackupOffline},
+					{Name: "SiteC", Status: ispnv1.SiteBackupOffline},
+					{Name: "SiteD", Status: ispnv1.SiteBackupOnline},
+				},
+			},
+		},
+	}
+
+	takeOffline, bringOnline, offline := siteOfflineActions(i)
+	assert.Equal(t, []string{"SiteB", "SiteD"}, takeOffline)
+	assert.Empty(t, bringOnline, "SiteC was not taken offline by the operator")
+	assert.Equal(t, []string{"SiteB", "SiteD"}, offline)
+
+	// SiteD was brought online with the CLI
+	i.Status.Sites.OfflineLocations = offline
+	takeOffline, bringOnline, _ = siteOfflineActions(i)
+	assert.Equal(t, []string{"SiteD"}, takeOffline)
+	assert.Empty(t, bringOnline)
+
+	// SiteB is no longer offline and SiteD is no longer a backup location
+	i.Spec.Service.Sites.Locations[1].Offline = false
+	i.Spec.Service.Sites.Locations = i.Spec.Service.Sites.Locations[:3]
+	takeOffline, bringOnline, offline = siteOfflineActions(i)
+	assert.Empty(t, takeOffline)
+	assert.Equal(t, []string{"SiteB"}, bringOnline)
+	assert.Empty(t, offline)
+}
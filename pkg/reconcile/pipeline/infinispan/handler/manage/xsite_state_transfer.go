@@ -0,0 +1,284 @@
+package manage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/infinispan/client/api"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	EventReasonXSiteStateTransfer         = "XSiteStateTransfer"
+	EventReasonXSiteStateTransferRejected = "XSiteStateTransferRejected"
+
+	// XSiteStateTransferCancel is the value of the infinispan.org/xsite-state-transfer annotation that cancels the state
+	// transfer in progress
+	XSiteStateTransferCancel = "cancel"
+
+	xsiteStateSending  = "SENDING"
+	xsiteStateOk       = "OK"
+	xsiteStateCanceled = "CANCELED"
+)
+
+// XSiteStateTransfer processes the state transfer requested with the infinispan.org/xsite-state-transfer annotation.
+// The state of all caches with a backup to the requested site, or of the caches of the
+// infinispan.org/xsite-state-transfer-caches annotation, is pushed to the site and the progress of each cache is
+// reported in status.xsiteStateTransfer until all caches have completed
+func XSiteStateTransfer(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	if request, requested := i.Annotations[consts.AnnotationXSiteStateTransfer]; requested {
+		if request == XSiteStateTransferCancel {
+			cancelXSiteStateTransfer(i, ctx)
+		} else {
+			startXSiteStateTransfer(i, request, ctx)
+		}
+		return
+	}
+
+	if !i.IsXSiteStateTransferInProgress() {
+		return
+	}
+
+	ispnClient, err := ctx.InfinispanClient()
+	if err != nil {
+		ctx.Requeue(err)
+		return
+	}
+	transfer := i.Status.XSiteStateTransfer
+	caches := make([]ispnv1.XSiteCacheStateTransfer, len(transfer.Caches))
+	for idx, cache := range transfer.Caches {
+		caches[idx] = cache
+		if cache.State != "" && cache.State != xsiteStateSending {
+			continue
+		}
+		states, err := ispnClient.Cache(cache.Name).Xsite().PushStateStatus()
+		if err != nil {
+			ctx.Requeue(fmt.Errorf("unable to retrieve the state transfer status of cache '%s': %w", cache.Name, err))
+			return
+		}
+		caches[idx].State = states[transfer.Site]
+	}
+
+	phase, msg := xsiteStateTransferPhase(transfer.Site, caches)
+	if err := ctx.UpdateInfinispan(func() {
+		updateXSiteStateTransfer(i, caches, phase, msg)
+	}); err != nil {
+		ctx.Requeue(err)
+		return
+	}
+	switch phase {
+	case ispnv1.XSiteStateTransferPhaseInProgress:
+		ctx.RequeueAfter(consts.DefaultWaitOnCluster, nil)
+	case ispnv1.XSiteStateTransferPhaseFailed:
+		ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonXSiteStateTransfer, msg)
+	default:
+		ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonXSiteStateTransfer, msg)
+	}
+}
+
+// startXSiteStateTransfer pushes the state of the requested caches to the site once the cluster is well formed
+func startXSiteStateTransfer(i *ispnv1.Infinispan, site string, ctx pipeline.Context) {
+	if i.IsXSiteStateTransferInProgress() {
+		rejectXSiteStateTransfer(i, fmt.Sprintf("the state transfer to site '%s' is in progress", i.Status.XSiteStateTransfer.Site), ctx)
+		return
+	}
+	if !i.IsWellFormed() || i.GetCondition(ispnv1.ConditionCrossSiteViewFormed).Status != metav1.ConditionTrue {
+		ctx.Log().Info("Waiting for the cluster and the cross-site view to form before starting the state transfer", "site", site)
+		ctx.RequeueAfter(consts.DefaultWaitOnCluster, nil)
+		return
+	}
+
+	ispnClient, err := ctx.InfinispanClient()
+	if err != nil {
+		ctx.Requeue(err)
+		return
+	}
+	cacheNames := xsiteStateTransferCaches(i.Annotations[consts.AnnotationXSiteStateTransferCaches])
+	if len(cacheNames) == 0 {
+		if cacheNames, err = xsiteBackupCaches(ispnClient, site); err != nil {
+			ctx.Requeue(err)
+			return
+		}
+		if len(cacheNames) == 0 {
+			rejectXSiteStateTransfer(i, fmt.Sprintf("no cache has a backup to site '%s'", site), ctx)
+			return
+		}
+	}
+
+	if !acquireOperationLock(i, ctx, ispnv1.OperationXSiteStateTransfer) {
+		return
+	}
+	now := metav1.Now()
+	transfer := &ispnv1.XSiteStateTransferStatus{
+		Site:      site,
+		Phase:     ispnv1.XSiteStateTransferPhaseInProgress,
+		StartTime: now,
+	}
+	for _, cache := range cacheNames {
+		if err := ispnClient.Cache(cache).Xsite().PushState(site); err != nil {
+			// The caches already pushing their state are reported, so that their progress is still visible
+			transfer.Phase = ispnv1.XSiteStateTransferPhaseFailed
+			transfer.Message = fmt.Sprintf("Unable to push the state of cache '%s' to site '%s': %v", cache, site, err)
+			transfer.CompletionTime = &now
+			break
+		}
+		transfer.Caches = append(transfer.Caches, ispnv1.XSiteCacheStateTransfer{Name: cache, State: xsiteStateSending})
+	}
+	if transfer.Phase == ispnv1.XSiteStateTransferPhaseInProgress {
+		transfer.Message = fmt.Sprintf("Pushing the state of %d caches to site '%s'", len(transfer.Caches), site)
+	}
+
+	if err := ctx.UpdateInfinispan(func() {
+		delete(i.Annotations, consts.AnnotationXSiteStateTransfer)
+		delete(i.Annotations, consts.AnnotationXSiteStateTransferCaches)
+		i.Status.XSiteStateTransfer = transfer
+		i.ReleaseOperationLock(ispnv1.OperationXSiteStateTransfer, "")
+	}); err != nil {
+		ctx.Requeue(err)
+		return
+	}
+	if transfer.Phase == ispnv1.XSiteStateTransferPhaseFailed {
+		ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonXSiteStateTransfer, transfer.Message)
+		return
+	}
+	ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonXSiteStateTransfer, transfer.Message)
+	ctx.RequeueAfter(consts.DefaultWaitOnCluster, nil)
+}
+
+// cancelXSiteStateTransfer cancels the state transfer of the caches that are still pushing their state
+func cancelXSiteStateTransfer(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	if !i.IsXSiteStateTransferInProgress() {
+		rejectXSiteStateTransfer(i, "no state transfer is in progress", ctx)
+		return
+	}
+
+	ispnClient, err := ctx.InfinispanClient()
+	if err != nil {
+		ctx.Requeue(err)
+		return
+	}
+	transfer := i.Status.XSiteStateTransfer
+	caches := make([]ispnv1.XSiteCacheStateTransfer, len(transfer.Caches))
+	for idx, cache := range transfer.Caches {
+		caches[idx] = cache
+		if cache.State != "" && cache.State != xsiteStateSending {
+			continue
+		}
+		if err := ispnClient.Cache(cache.Name).Xsite().CancelPushState(transfer.Site); err != nil {
+			ctx.Requeue(fmt.Errorf("unable to cancel the state transfer of cache '%s': %w", cache.Name, err))
+			return
+		}
+		caches[idx].State = xsiteStateCanceled
+	}
+
+	msg := fmt.Sprintf("State transfer to site '%s' cancelled", transfer.Site)
+	if err := ctx.UpdateInfinispan(func() {
+		delete(i.Annotations, consts.AnnotationXSiteStateTransfer)
+		delete(i.Annotations, consts.AnnotationXSiteStateTransferCaches)
+		updateXSiteStateTransfer(i, caches, ispnv1.XSiteStateTransferPhaseCancelled, msg)
+	}); err != nil {
+		ctx.Requeue(err)
+		return
+	}
+	ctx.EventRecorder().Event(i, corev1.EventTypeNormal, EventReasonXSiteStateTransfer, msg)
+}
+
+// rejectXSiteStateTransfer removes the request annotations and records the reason the request was rejected. The status
+// of a state transfer in progress is preserved, so that its progress is still reported
+func rejectXSiteStateTransfer(i *ispnv1.Infinispan, reason string, ctx pipeline.Context) {
+	request := i.Annotations[consts.AnnotationXSiteStateTransfer]
+	msg := fmt.Sprintf("State transfer request '%s' rejected: %s", request, reason)
+	if err := ctx.UpdateInfinispan(func() {
+		delete(i.Annotations, consts.AnnotationXSiteStateTransfer)
+		delete(i.Annotations, consts.AnnotationXSiteStateTransferCaches)
+		if !i.IsXSiteStateTransferInProgress() && request != XSiteStateTransferCancel {
+			now := metav1.Now()
+			i.Status.XSiteStateTransfer = &ispnv1.XSiteStateTransferStatus{
+				Site:           request,
+				Phase:          ispnv1.XSiteStateTransferPhaseFailed,
+				Message:        msg,
+				StartTime:      now,
+				CompletionTime: &now,
+			}
+		}
+	}); err != nil {
+		ctx.Requeue(err)
+		return
+	}
+	ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonXSiteStateTransferRejected, msg)
+	ctx.Log().Info(msg)
+}
+
+func updateXSiteStateTransfer(i *ispnv1.Infinispan, caches []ispnv1.XSiteCacheStateTransfer, phase ispnv1.XSiteStateTransferPhase, msg string) {
+	transfer := i.Status.XSiteStateTransfer
+	transfer.Caches = caches
+	transfer.Phase = phase
+	transfer.Message = msg
+	if phase != ispnv1.XSiteStateTransferPhaseInProgress {
+		now := metav1.Now()
+		transfer.CompletionTime = &now
+	}
+}
+
+// xsiteBackupCaches returns the sorted names of the caches that have a backup to the site
+func xsiteBackupCaches(ispnClient api.Infinispan, site string) ([]string, error) {
+	names, err := ispnClient.Caches().Names()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve cache names: %w", err)
+	}
+	var caches []string
+	for _, name := range names {
+		if strings.HasPrefix(name, "___") {
+			continue
+		}
+		backups, err := ispnClient.Cache(name).Xsite().BackupStatus()
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve the backups of cache '%s': %w", name, err)
+		}
+		if _, ok := backups[site]; ok {
+			caches = append(caches, name)
+		}
+	}
+	sort.Strings(caches)
+	return caches, nil
+}
+
+// xsiteStateTransferCaches returns the caches of the infinispan.org/xsite-state-transfer-caches annotation
+func xsiteStateTransferCaches(value string) []string {
+	var caches []string
+	for _, cache := range strings.Split(value, ",") {
+		if cache = strings.TrimSpace(cache); cache != "" {
+			caches = append(caches, cache)
+		}
+	}
+	return caches
+}
+
+// xsiteStateTransferPhase returns the phase of the state transfer to the site from the state of each cache. The state
+// transfer is in progress until no cache is sending its state, and has failed if the state transfer of any cache failed
+func xsiteStateTransferPhase(site string, caches []ispnv1.XSiteCacheStateTransfer) (ispnv1.XSiteStateTransferPhase, string) {
+	var failed, canceled []string
+	for _, cache := range caches {
+		switch cache.State {
+		case "", xsiteStateSending:
+			return ispnv1.XSiteStateTransferPhaseInProgress, fmt.Sprintf("Pushing the state of %d caches to site '%s'", len(caches), site)
+		case xsiteStateOk:
+		case xsiteStateCanceled:
+			canceled = append(canceled, cache.Name)
+		default:
+			failed = append(failed, cache.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return ispnv1.XSiteStateTransferPhaseFailed, fmt.Sprintf("State transfer to site '%s' failed for caches: %s", site, strings.Join(failed, ","))
+	}
+	if len(canceled) > 0 {
+		return ispnv1.XSiteStateTransferPhaseCancelled, fmt.Sprintf("State transfer to site '%s' cancelled for caches: %s", site, strings.Join(canceled, ","))
+	}
+	return ispnv1.XSiteStateTransferPhaseSucceeded, fmt.Sprintf("State of %d caches pushed to site '%s'", len(caches), site)
+}
@@ -0,0 +1,40 @@
+package manage
+
+import (
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXSiteStateTransferCaches(t *testing.T) {
+	assert.Empty(t, xsiteStateTransferCaches(""))
+	assert.Equal(t, []string{"cache-a", "cache-b"}, xsiteStateTransferCaches(" cache-a, ,cache-b "))
+}
+
+func TestXSiteStateTransferPhase(t *testing.T) {
+	caches := []ispnv1.XSiteCacheStateTransfer{
+		{Name: "cache-a", State: "OK"},
+		{Name: "cache-b", State: "SENDING"},
+		{Name: "cache-c"},
+	}
+	phase, _ := xsiteStateTransferPhase("SiteB", caches)
+	assert.Equal(t, ispnv1.XSiteStateTransferPhaseInProgress, phase)
+
+	caches[1].State = "CANCELED"
+	caches[2].State = "OK"
+	phase, msg := xsiteStateTransferPhase("SiteB", caches)
+	assert.Equal(t, ispnv1.XSiteStateTransferPhaseCancelled, phase)
+	assert.Contains(t, msg, "cache-b")
+
+	caches[2].State = "ERROR"
+	phase, msg = xsiteStateTransferPhase("SiteB", caches)
+	assert.Equal(t, ispnv1.XSiteStateTransferPhaseFailed, phase, "failures take precedence over cancellations")
+	assert.Contains(t, msg, "cache-c")
+
+	caches[1].State = "OK"
+	caches[2].State = "OK"
+	phase, msg = xsiteStateTransferPhase("SiteB", caches)
+	assert.Equal(t, ispnv1.XSiteStateTransferPhaseSucceeded, phase)
+	assert.Equal(t, "State of 3 caches pushed to site 'SiteB'", msg)
+}
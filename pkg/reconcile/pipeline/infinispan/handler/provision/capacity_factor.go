@@ -0,0 +1,110 @@
+package provision
+
+import (
+	"encoding/json"
+	"fmt"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/infinispan/infinispan-operator/pkg/hash"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	CapacityFactorInitContainer = "capacity-factor"
+	CapacityFactorVolumeName    = "capacity-factor"
+	// CapacityFactorEnv is referenced by the capacity-factor attribute of the distributed cache templates
+	CapacityFactorEnv = "INFINISPAN_CAPACITY_FACTOR"
+
+	capacityFactorHashEnv   = "CAPACITY_FACTOR_HASH"
+	capacityFactorMountPath = "/etc/capacity-factor"
+	capacityFactorFile      = "factor"
+)
+
+// ApplyCapacityFactor configures the server container to read its capacity factor from the infinispan.org/capacity-factor
+// annotation, which the operator sets once the pod is scheduled and the zone of its node is known. As the annotation is
+// only resolved when the container starts, an init container waits until the annotation is exposed by the downward API.
+// Changes to spec.capacityFactor restart the pods, as the capacity factor of a running server cannot be changed
+func ApplyCapacityFactor(ispn *ispnv1.Infinispan, ispnContainer *corev1.Container, spec *corev1.PodSpec) (updated bool) {
+	initContainers := &spec.InitContainers
+	volumes := &spec.Volumes
+	env := &ispnContainer.Env
+	containerPosition := kube.ContainerIndex(*initContainers, CapacityFactorInitContainer)
+	if ispn.IsCapacityFactorEnabled() {
+		if containerPosition < 0 {
+			*initContainers = append(*initContainers, capacityFactorInitContainer(ispn))
+			*volumes = append(*volumes, capacityFactorVolume())
+			updated = true
+		}
+		if kube.GetEnvVarIndex(CapacityFactorEnv, env) < 0 {
+			*env = append(*env, corev1.EnvVar{
+				Name: CapacityFactorEnv,
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: annotationFieldRef(consts.AnnotationCapacityFactor),
+				},
+			})
+			updated = true
+		}
+		specHash := capacityFactorHash(ispn)
+		if hashPosition := kube.GetEnvVarIndex(capacityFactorHashEnv, env); hashPosition < 0 {
+			*env = append(*env, corev1.EnvVar{Name: capacityFactorHashEnv, Value: specHash})
+			updated = true
+		} else if (*env)[hashPosition].Value != specHash {
+			(*env)[hashPosition].Value = specHash
+			updated = true
+		}
+		return
+	}
+
+	if containerPosition >= 0 {
+		*initContainers = append((*initContainers)[:containerPosition], (*initContainers)[containerPosition+1:]...)
+		if volumePosition := findVolume(*volumes, CapacityFactorVolumeName); volumePosition >= 0 {
+			*volumes = append((*volumes)[:volumePosition], (*volumes)[volumePosition+1:]...)
+		}
+		updated = true
+	}
+	for _, name := range []string{CapacityFactorEnv, capacityFactorHashEnv} {
+		if envPosition := kube.GetEnvVarIndex(name, env); envPosition >= 0 {
+			*env = append((*env)[:envPosition], (*env)[envPosition+1:]...)
+			updated = true
+		}
+	}
+	return
+}
+
+func capacityFactorInitContainer(ispn *ispnv1.Infinispan) corev1.Container {
+	script := fmt.Sprintf(`until [ -s %[1]s/%[2]s ]; do echo "Waiting for the %[3]s annotation"; sleep 2; done`,
+		capacityFactorMountPath, capacityFactorFile, consts.AnnotationCapacityFactor)
+	return corev1.Container{
+		Image:           ispn.ImageName(),
+		ImagePullPolicy: ispn.ImagePullPolicy(),
+		SecurityContext: ispn.Spec.Security.ContainerSecurityContext,
+		Name:            CapacityFactorInitContainer,
+		Command:         []string{"sh", "-c", script},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      CapacityFactorVolumeName,
+			MountPath: capacityFactorMountPath,
+			ReadOnly:  true,
+		}},
+	}
+}
+
+func capacityFactorVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: CapacityFactorVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{{
+					Path:     capacityFactorFile,
+					FieldRef: annotationFieldRef(consts.AnnotationCapacityFactor),
+				}},
+			},
+		},
+	}
+}
+
+func capacityFactorHash(ispn *ispnv1.Infinispan) string {
+	spec, _ := json.Marshal(ispn.Spec.CapacityFactor)
+	return hash.HashByte(spec)
+}
@@ -1,6 +1,10 @@
 package provision
 
 import (
+	"fmt"
+	"reflect"
+	"sort"
+
 	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
 	"github.com/infinispan/infinispan-operator/api/v2alpha1"
 	"github.com/infinispan/infinispan-operator/controllers/constants"
@@ -21,18 +25,11 @@ func ConfigListener(i *ispnv1.Infinispan, ctx pipeline.Context) {
 		return
 	}
 
-	var configListenerImage string
-	if constants.ConfigListenerImageName != "" {
-		configListenerImage = constants.ConfigListenerImageName
-	} else {
-		// If env not explicitly set, use the Operator image
-		if image, err := kube.GetOperatorImage(ctx.Ctx(), ctx.Kubernetes().Client); err != nil {
-			ctx.Log().Error(err, "unable to create ConfigListener deployment")
-			ctx.Requeue(err)
-			return
-		} else {
-			configListenerImage = image
-		}
+	configListenerImage, err := OperatorImage(ctx)
+	if err != nil {
+		ctx.Log().Error(err, "unable to create ConfigListener deployment")
+		ctx.Requeue(err)
+		return
 	}
 
 	r := ctx.Resources()
@@ -44,12 +41,19 @@ func ConfigListener(i *ispnv1.Infinispan, ctx pipeline.Context) {
 		Namespace: namespace,
 	}
 
+	// The Role is always reconciled so that the permissions of existing listeners are restricted on operator upgrade
+	if err := configListenerRole(i, ctx); err != nil {
+		return
+	}
+
 	deployment := &appsv1.Deployment{}
 	listenerExists := r.Load(name, deployment) == nil
 	if listenerExists {
 		container := kube.GetContainer(InfinispanListenerContainer, &deployment.Spec.Template.Spec)
-		if container != nil && container.Image == configListenerImage {
-			// The Deployment already exists with the expected image, do nothing
+		if container != nil && container.Image == configListenerImage &&
+			reflect.DeepEqual(container.SecurityContext, i.Spec.Security.ContainerSecurityContext) &&
+			PodSecurityContextEquals(deployment.Spec.Template.Spec.SecurityContext, i.Spec.Security.PodSecurityContext) {
+			// The Deployment already exists with the expected image and security context, do nothing
 			return
 		}
 	}
@@ -69,46 +73,6 @@ func ConfigListener(i *ispnv1.Infinispan, ctx pipeline.Context) {
 		return
 	}
 
-	role := &rbacv1.Role{
-		ObjectMeta: objectMeta,
-		Rules: []rbacv1.PolicyRule{
-			{
-				APIGroups: []string{v2alpha1.GroupVersion.Group},
-				Resources: []string{"caches"},
-				Verbs: []string{
-					"create",
-					"delete",
-					"get",
-					"list",
-					"patch",
-					"update",
-					"watch",
-				},
-			},
-			{
-				APIGroups: []string{ispnv1.GroupVersion.Group},
-				Resources: []string{"infinispans"},
-				Verbs:     []string{"get"},
-			}, {
-				APIGroups: []string{""},
-				Resources: []string{"pods"},
-				Verbs:     []string{"list"},
-			}, {
-				APIGroups: []string{""},
-				Resources: []string{"pods/exec"},
-				Verbs:     []string{"create"},
-			},
-			{
-				APIGroups: []string{""},
-				Resources: []string{"secrets"},
-				Verbs:     []string{"get"},
-			},
-		},
-	}
-	if err := createOrUpdate(role); err != nil {
-		return
-	}
-
 	roleBinding := &rbacv1.RoleBinding{
 		ObjectMeta: objectMeta,
 		RoleRef: rbacv1.RoleRef{
@@ -142,8 +106,9 @@ func ConfigListener(i *ispnv1.Infinispan, ctx pipeline.Context) {
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
 						{
-							Name:  InfinispanListenerContainer,
-							Image: configListenerImage,
+							Name:            InfinispanListenerContainer,
+							Image:           configListenerImage,
+							SecurityContext: i.Spec.Security.ContainerSecurityContext,
 							Args: []string{
 								"listener",
 								"-namespace",
@@ -154,6 +119,7 @@ func ConfigListener(i *ispnv1.Infinispan, ctx pipeline.Context) {
 						},
 					},
 					ServiceAccountName: name,
+					SecurityContext:    i.Spec.Security.PodSecurityContext,
 				},
 			},
 		},
@@ -163,6 +129,100 @@ func ConfigListener(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	}
 }
 
+// OperatorImage returns the image of the containers that run the operator binary, such as the ConfigListener
+func OperatorImage(ctx pipeline.Context) (string, error) {
+	if constants.ConfigListenerImageName != "" {
+		return constants.ConfigListenerImageName, nil
+	}
+	// If env not explicitly set, use the Operator image
+	return kube.GetOperatorImage(ctx.Ctx(), ctx.Kubernetes().Client)
+}
+
+// configListenerRole creates or updates the Role of the ConfigListener ServiceAccount. The listener authenticates with
+// the Kubernetes API using its own ServiceAccount token, so access is restricted to the resources of this cluster only.
+// Kubernetes cannot restrict the list and create verbs to named resources, so the listener can list the pods and
+// manage the Cache CRs of the whole namespace
+func configListenerRole(i *ispnv1.Infinispan, ctx pipeline.Context) error {
+	podNames, err := configListenerPodNames(i, ctx)
+	if err != nil {
+		return err
+	}
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      i.GetConfigListenerName(),
+			Namespace: i.Namespace,
+		},
+	}
+	_, err = ctx.Resources().CreateOrUpdate(role, true, func() error {
+		role.Rules = []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{v2alpha1.GroupVersion.Group},
+				Resources: []string{"caches"},
+				Verbs: []string{
+					"create",
+					"delete",
+					"get",
+					"list",
+					"patch",
+					"update",
+					"watch",
+				},
+			},
+			{
+				APIGroups:     []string{ispnv1.GroupVersion.Group},
+				Resources:     []string{"infinispans"},
+				ResourceNames: []string{i.Name},
+				Verbs:         []string{"get"},
+			}, {
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+				Verbs:     []string{"list"},
+			},
+			{
+				// Only the admin credentials are required to connect to the server admin endpoint
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				ResourceNames: []string{i.GetAdminSecretName()},
+				Verbs:         []string{"get"},
+			},
+		}
+		// The listener executes requests in the server pods of this cluster only. A rule without resource names applies
+		// to all the pods of the namespace, so it is omitted when the cluster has no pods
+		if len(podNames) > 0 {
+			role.Rules = append(role.Rules, rbacv1.PolicyRule{
+				APIGroups:     []string{""},
+				Resources:     []string{"pods/exec"},
+				ResourceNames: podNames,
+				Verbs:         []string{"create"},
+			})
+		}
+		return nil
+	}, pipeline.RetryOnErr)
+	return err
+}
+
+// configListenerPodNames returns the names of the existing server pods and of the pods of all the replicas in the spec,
+// so that the listener can execute requests in the pods that are created when the cluster is scaled up
+func configListenerPodNames(i *ispnv1.Infinispan, ctx pipeline.Context) ([]string, error) {
+	podList := &corev1.PodList{}
+	if err := ctx.Resources().List(i.PodSelectorLabels(), podList, pipeline.RetryOnErr); err != nil {
+		return nil, err
+	}
+	names := map[string]struct{}{}
+	for _, pod := range podList.Items {
+		names[pod.Name] = struct{}{}
+	}
+	for ordinal := int32(0); ordinal < i.Spec.Replicas; ordinal++ {
+		names[fmt.Sprintf("%s-%d", i.GetStatefulSetName(), ordinal)] = struct{}{}
+	}
+	podNames := make([]string, 0, len(names))
+	for name := range names {
+		podNames = append(podNames, name)
+	}
+	sort.Strings(podNames)
+	return podNames, nil
+}
+
 func RemoveConfigListener(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	resources := []client.Object{
 		&appsv1.Deployment{},
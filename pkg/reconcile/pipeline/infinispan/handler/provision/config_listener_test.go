@@ -0,0 +1,41 @@
+package provision
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConfigListenerRole(t *testing.T) {
+	execRule := func(role *rbacv1.Role) *rbacv1.PolicyRule {
+		for idx, rule := range role.Rules {
+			if rule.Resources[0] == "pods/exec" {
+				return &role.Rules[idx]
+			}
+		}
+		return nil
+	}
+
+	i := testInfinispan()
+	i.Spec.Replicas = 2
+	ctx := newTestContext()
+	// A pod that is being removed by a scale down
+	ctx.resources.pods = []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan-2"}}}
+	require.NoError(t, configListenerRole(i, ctx))
+	require.Len(t, ctx.resources.created, 1)
+	role := ctx.resources.created[0].(*rbacv1.Role)
+	assert.Equal(t, "example-infinispan-config-listener", role.Name)
+	rule := execRule(role)
+	require.NotNil(t, rule)
+	assert.Equal(t, []string{"example-infinispan-0", "example-infinispan-1", "example-infinispan-2"}, rule.ResourceNames)
+
+	// A rule without resource names would grant access to all the pods of the namespace
+	i.Spec.Replicas = 0
+	ctx = newTestContext()
+	require.NoError(t, configListenerRole(i, ctx))
+	assert.Nil(t, execRule(ctx.resources.created[0].(*rbacv1.Role)))
+}
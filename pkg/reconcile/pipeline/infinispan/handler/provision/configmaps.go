@@ -2,6 +2,7 @@ package provision
 
 import (
 	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
 	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,6 +26,31 @@ func InfinispanConfigMap(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	_, _ = ctx.Resources().CreateOrUpdate(configmap, true, mutateFn, pipeline.RetryOnErr)
 }
 
+func CABundleConfigMap(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	caBundle := ctx.ConfigFiles().CABundle
+	if len(caBundle) == 0 {
+		// Encryption is disabled or no CA certificates are available, remove any stale bundle
+		_ = ctx.Resources().Delete(i.GetCABundleConfigMapName(), &corev1.ConfigMap{}, pipeline.RetryOnErr)
+		return
+	}
+
+	configmap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      i.GetCABundleConfigMapName(),
+			Namespace: i.Namespace,
+		},
+	}
+
+	mutateFn := func() error {
+		configmap.Data = map[string]string{
+			consts.EncryptCAKey: string(caBundle),
+		}
+		configmap.Labels = i.Labels("infinispan-configmap-ca-bundle")
+		return nil
+	}
+	_, _ = ctx.Resources().CreateOrUpdate(configmap, true, mutateFn, pipeline.RetryOnErr)
+}
+
 func PopulateServerConfigMap(serverConfig, zeroConfig, log4jConfig string, cm *corev1.ConfigMap) {
 	cm.Data = map[string]string{
 		"infinispan.xml":      serverConfig,
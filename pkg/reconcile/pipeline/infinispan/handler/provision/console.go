@@ -0,0 +1,172 @@
+package provision
+
+import (
+	"fmt"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	routev1 "github.com/openshift/api/route/v1"
+	ingressv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ConsoleExternalService exposes the Console with a dedicated Route or Ingress if spec.endpoints.console.expose.type is
+// Route or Ingress, and removes the dedicated resources otherwise. The resources are selected with their own labels, so
+// that they are not removed by the providers of spec.expose
+func ConsoleExternalService(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	var gvk schema.GroupVersionKind
+	if i.HasConsoleExternalService() {
+		if gvk = consoleExternalGVK(i, ctx); gvk.Empty() {
+			ctx.Stop(fmt.Errorf("unable to expose the console with type %s, as no implementations are supported", i.GetConsoleExposeType()))
+			return
+		}
+	}
+
+	for _, other := range []schema.GroupVersionKind{pipeline.RouteGVK, pipeline.IngressGVK} {
+		if other != gvk && ctx.IsTypeSupported(other) {
+			if err := removeConsoleExternalService(i, other, ctx); err != nil {
+				return
+			}
+		}
+	}
+
+	switch gvk {
+	case pipeline.RouteGVK:
+		consoleRoute(i, ctx)
+	case pipeline.IngressGVK:
+		consoleIngress(i, ctx)
+	}
+}
+
+// ConsoleExternalAddress returns the host of the dedicated Route or Ingress of the Console. ok is false if the resource
+// cannot be loaded, in which case the reconciliation has been requeued
+func ConsoleExternalAddress(i *ispnv1.Infinispan, ctx pipeline.Context) (address string, ok bool) {
+	switch consoleExternalGVK(i, ctx) {
+	case pipeline.RouteGVK:
+		route := &routev1.Route{}
+		if err := ctx.Resources().Load(i.GetConsoleExternalName(), route, pipeline.RetryOnErr); err != nil {
+			return "", false
+		}
+		return route.Spec.Host, true
+	case pipeline.IngressGVK:
+		ingress := &ingressv1.Ingress{}
+		if err := ctx.Resources().Load(i.GetConsoleExternalName(), ingress, pipeline.RetryOnErr); err != nil {
+			return "", false
+		}
+		if len(ingress.Spec.Rules) > 0 {
+			return ingress.Spec.Rules[0].Host, true
+		}
+	}
+	return "", true
+}
+
+// consoleExternalGVK returns the kind of the dedicated resource of the Console, or an empty GroupVersionKind if the
+// type is not supported by the platform. The Route type uses an Ingress on platforms without Routes
+func consoleExternalGVK(i *ispnv1.Infinispan, ctx pipeline.Context) schema.GroupVersionKind {
+	switch i.GetConsoleExposeType() {
+	case ispnv1.ConsoleExposeTypeRoute:
+		if ctx.IsTypeSupported(pipeline.RouteGVK) {
+			return pipeline.RouteGVK
+		} else if ctx.IsTypeSupported(pipeline.IngressGVK) {
+			return pipeline.IngressGVK
+		}
+	case ispnv1.ConsoleExposeTypeIngress:
+		if ctx.IsTypeSupported(pipeline.IngressGVK) {
+			return pipeline.IngressGVK
+		}
+	}
+	return schema.GroupVersionKind{}
+}
+
+func consoleRoute(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	route := newRoute(i, i.GetConsoleExternalName())
+	mutateFn := func() error {
+		route.Annotations = i.ConsoleExternalAnnotations()
+		route.Labels = i.ConsoleExternalLabels()
+		route.Spec.Host = i.Spec.Endpoints.Console.Expose.Host
+		route.Spec.Port = &routev1.RoutePort{
+			TargetPort: intstr.FromInt(int(i.RestPort())),
+		}
+		route.Spec.To = routev1.RouteTargetReference{
+			Kind: "Service",
+			Name: i.Name,
+		}
+		route.Spec.TLS = nil
+		if i.IsEncryptionEnabled() {
+			route.Spec.TLS = &routev1.TLSConfig{Termination: routev1.TLSTerminationPassthrough}
+		}
+		return nil
+	}
+	_, _ = ctx.Resources().CreateOrUpdate(route, true, mutateFn, pipeline.RetryOnErr)
+}
+
+func consoleIngress(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	pathTypePrefix := ingressv1.PathTypePrefix
+	ingress := &ingressv1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.k8s.io/v1",
+			Kind:       "Ingress",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      i.GetConsoleExternalName(),
+			Namespace: i.Namespace,
+		},
+	}
+	mutateFn := func() error {
+		host := i.Spec.Endpoints.Console.Expose.Host
+		ingress.Annotations = i.ConsoleExternalAnnotations()
+		ingress.Labels = i.ConsoleExternalLabels()
+		ingress.Spec.Rules = []ingressv1.IngressRule{
+			{
+				Host: host,
+				IngressRuleValue: ingressv1.IngressRuleValue{
+					HTTP: &ingressv1.HTTPIngressRuleValue{
+						Paths: []ingressv1.HTTPIngressPath{
+							{
+								PathType: &pathTypePrefix,
+								Path:     "/",
+								Backend: ingressv1.IngressBackend{
+									Service: &ingressv1.IngressServiceBackend{
+										Name: i.Name,
+										Port: ingressv1.ServiceBackendPort{Number: i.RestPort()},
+									},
+								}}},
+					},
+				},
+			},
+		}
+		ingress.Spec.TLS = nil
+		if i.IsEncryptionEnabled() {
+			ingress.Spec.TLS = []ingressv1.IngressTLS{{Hosts: []string{host}}}
+		}
+		return nil
+	}
+	_, _ = ctx.Resources().CreateOrUpdate(ingress, true, mutateFn, pipeline.RetryOnErr)
+}
+
+func removeConsoleExternalService(i *ispnv1.Infinispan, gvk schema.GroupVersionKind, ctx pipeline.Context) error {
+	if gvk == pipeline.RouteGVK {
+		routeList := &routev1.RouteList{}
+		if err := ctx.Resources().List(i.ConsoleExternalSelectorLabels(), routeList, pipeline.RetryOnErr); err != nil {
+			return err
+		}
+		for _, route := range routeList.Items {
+			if err := ctx.Resources().Delete(route.Name, &routev1.Route{}, pipeline.RetryOnErr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	ingressList := &ingressv1.IngressList{}
+	if err := ctx.Resources().List(i.ConsoleExternalSelectorLabels(), ingressList, pipeline.RetryOnErr); err != nil {
+		return err
+	}
+	for _, ingress := range ingressList.Items {
+		if err := ctx.Resources().Delete(ingress.Name, &ingressv1.Ingress{}, pipeline.RetryOnErr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
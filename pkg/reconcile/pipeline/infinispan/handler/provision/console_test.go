@@ -0,0 +1,80 @@
+package provision
+
+import (
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ingressv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestConsoleExternalService(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.Security.EndpointEncryption = &ispnv1.EndpointEncryption{Type: ispnv1.CertificateSourceTypeSecret, CertSecretName: "tls"}
+	i.Spec.Endpoints = &ispnv1.InfinispanEndpointsSpec{
+		Console: &ispnv1.InfinispanConsoleSpec{
+			Expose: &ispnv1.ConsoleExposeSpec{
+				Type:        ispnv1.ConsoleExposeTypeRoute,
+				Host:        "console.example.com",
+				Annotations: map[string]string{"haproxy.router.openshift.io/ip_whitelist": "10.0.0.0/8"},
+			},
+		},
+	}
+
+	ctx := newTestContext()
+	ctx.supportedTypes = []schema.GroupVersionKind{pipeline.RouteGVK, pipeline.IngressGVK}
+	ConsoleExternalService(i, ctx)
+	require.NoError(t, ctx.err)
+	require.Len(t, ctx.resources.created, 1)
+	route := ctx.resources.created[0].(*routev1.Route)
+	assert.Equal(t, i.GetConsoleExternalName(), route.Name)
+	assert.Equal(t, "console.example.com", route.Spec.Host)
+	assert.Equal(t, routev1.TLSTerminationPassthrough, route.Spec.TLS.Termination)
+	assert.Equal(t, "10.0.0.0/8", route.Annotations["haproxy.router.openshift.io/ip_whitelist"])
+	assert.Equal(t, i.ConsoleExternalSelectorLabels()["app"], route.Labels["app"])
+
+	// The Route type uses an Ingress on platforms without Routes
+	ctx = newTestContext()
+	ctx.supportedTypes = []schema.GroupVersionKind{pipeline.IngressGVK}
+	ConsoleExternalService(i, ctx)
+	require.NoError(t, ctx.err)
+	require.Len(t, ctx.resources.created, 1)
+	ingress := ctx.resources.created[0].(*ingressv1.Ingress)
+	assert.Equal(t, "console.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "/", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, []ingressv1.IngressTLS{{Hosts: []string{"console.example.com"}}}, ingress.Spec.TLS)
+
+	ctx = newTestContext()
+	ConsoleExternalService(i, ctx)
+	assert.Error(t, ctx.err)
+	assert.Empty(t, ctx.resources.created)
+
+	// No resources are created for the Console if it is reached at the address of spec.expose
+	i.Spec.Endpoints.Console.Expose = nil
+	ctx = newTestContext()
+	ctx.supportedTypes = []schema.GroupVersionKind{pipeline.RouteGVK, pipeline.IngressGVK}
+	ConsoleExternalService(i, ctx)
+	require.NoError(t, ctx.err)
+	assert.Empty(t, ctx.resources.created)
+}
+
+func TestExposedPathWithoutConsole(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.Expose = &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeIngress, Host: "infinispan.example.com"}
+	i.Spec.Endpoints = &ispnv1.InfinispanEndpointsSpec{
+		Console: &ispnv1.InfinispanConsoleSpec{Expose: &ispnv1.ConsoleExposeSpec{Type: ispnv1.ConsoleExposeTypeNone}},
+	}
+	ctx := newTestContext()
+	ingressExposeProvider{}.Reconcile(i, ctx)
+	require.Len(t, ctx.resources.created, 1)
+	ingress := ctx.resources.created[0].(*ingressv1.Ingress)
+	assert.Equal(t, "/rest", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+
+	// The Console does not need to be excluded once it is disabled
+	i.Spec.Endpoints.Console.Enabled = new(bool)
+	assert.Equal(t, "/", exposedPath(i))
+}
@@ -0,0 +1,101 @@
+package provision
+
+import (
+	"fmt"
+	"strings"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	DataMigrationContainer  = "data-migration"
+	DataMigrationVolumeName = "data-migration-config"
+	DataMigrationConfigRoot = "/etc/data-migration"
+	DataMigrationLabel      = "infinispan-data-migration"
+	StoreMigratorClass      = "org.infinispan.tools.store.migrator.StoreMigrator"
+)
+
+// DataMigrationJobName returns the name of the Job that migrates the data of the pod with the given ordinal
+func DataMigrationJobName(i *ispnv1.Infinispan, ordinal int32) string {
+	return fmt.Sprintf("%s-data-migration-%d", i.GetStatefulSetName(), ordinal)
+}
+
+// DataMigrationJob returns the Job that applies each StoreMigrator properties file of spec.upgrades.dataMigration to the
+// persistent volume of the pod with the given ordinal. The volume is mounted at the same path as in the server pods,
+// so that the properties files can reference the store locations of the server. The StoreMigrator is provided by the
+// infinispan-tools artifact, which must be added to spec.dependencies
+func DataMigrationJob(i *ispnv1.Infinispan, ordinal int32) *batchv1.Job {
+	classpath := strings.Join([]string{
+		"/opt/infinispan/lib/*",
+		i.ServerRoot() + "/lib/*",
+		externalArtifactsServerMountPath(i) + "/*",
+		CustomLibrariesMountPath(i) + "/*",
+	}, ":")
+	script := fmt.Sprintf(`for props in %s/*; do echo "Migrating stores of $props"; java -cp '%s' %s "$props" || exit 1; done`,
+		DataMigrationConfigRoot, classpath, StoreMigratorClass)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DataMigrationJobName(i, ordinal),
+			Namespace: i.Namespace,
+			Labels:    i.Labels(DataMigrationLabel),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: pointer.Int32Ptr(0),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: i.Labels(DataMigrationLabel),
+				},
+				Spec: corev1.PodSpec{
+					ImagePullSecrets:             i.Spec.ImagePullSecrets,
+					ServiceAccountName:           i.Spec.ServiceAccountName,
+					AutomountServiceAccountToken: i.Spec.AutomountServiceAccountToken,
+					SecurityContext:              i.Spec.Security.PodSecurityContext,
+					RestartPolicy:                corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:            DataMigrationContainer,
+						Image:           i.ImageName(),
+						ImagePullPolicy: i.ImagePullPolicy(),
+						SecurityContext: i.Spec.Security.ContainerSecurityContext,
+						Command:         []string{"sh", "-c", script},
+						Env:             ContainerEnv(i),
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      DataMountVolume,
+							MountPath: DataMountPath(i),
+						}, {
+							Name:      DataMigrationVolumeName,
+							MountPath: DataMigrationConfigRoot,
+							ReadOnly:  true,
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: DataMountVolume,
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: fmt.Sprintf("%s-%s-%d", DataMountVolume, i.GetStatefulSetName(), ordinal),
+							},
+						},
+					}, {
+						Name: DataMigrationVolumeName,
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: i.Spec.Upgrades.DataMigration.ConfigMapName},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	spec := &job.Spec.Template.Spec
+	container := &spec.Containers[0]
+	// The webhook validates the artifacts, so the download container can always be created
+	_, _ = ApplyExternalArtifactsDownload(i, container, spec)
+	ApplyExternalDependenciesVolume(i, &container.VolumeMounts, spec)
+	return job
+}
@@ -9,7 +9,6 @@ import (
 )
 
 const (
-	CustomLibrariesMountPath               = "/opt/infinispan/server/lib/custom-libraries"
 	CustomLibrariesVolumeName              = "custom-libraries"
 	ExternalArtifactsLibsRoot              = "server/lib/external-artifacts"
 	ExternalArtifactsMountPath             = "/opt/infinispan/" + ExternalArtifactsLibsRoot + "/lib"
@@ -17,11 +16,22 @@ const (
 	ExternalArtifactsDownloadInitContainer = "external-artifacts-download"
 )
 
+// CustomLibrariesMountPath returns the path of the custom libraries volume in the Infinispan container
+func CustomLibrariesMountPath(i *ispnv1.Infinispan) string {
+	return i.ServerRoot() + "/lib/custom-libraries"
+}
+
+// externalArtifactsServerMountPath returns the path of the downloaded artifacts in the Infinispan container.
+// The download init container always uses ExternalArtifactsMountPath, as SERVER_LIBS_DIR is relative to the image
+func externalArtifactsServerMountPath(i *ispnv1.Infinispan) string {
+	return i.ServerRoot() + "/lib/external-artifacts/lib"
+}
+
 func ApplyExternalDependenciesVolume(ispn *ispnv1.Infinispan, volumeMounts *[]corev1.VolumeMount, spec *corev1.PodSpec) (updated bool) {
 	volumes := &spec.Volumes
 	volumePosition := findVolume(*volumes, CustomLibrariesVolumeName)
 	if ispn.HasDependenciesVolume() && volumePosition < 0 {
-		*volumeMounts = append(*volumeMounts, corev1.VolumeMount{Name: CustomLibrariesVolumeName, MountPath: CustomLibrariesMountPath, ReadOnly: true})
+		*volumeMounts = append(*volumeMounts, corev1.VolumeMount{Name: CustomLibrariesVolumeName, MountPath: CustomLibrariesMountPath(ispn), ReadOnly: true})
 		*volumes = append(*volumes, corev1.Volume{Name: CustomLibrariesVolumeName, VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: ispn.Spec.Dependencies.VolumeClaimName, ReadOnly: true}}})
 		updated = true
 	} else if !ispn.HasDependenciesVolume() && volumePosition >= 0 {
@@ -47,8 +57,10 @@ func ApplyExternalArtifactsDownload(ispn *ispnv1.Infinispan, ispnContainer *core
 			}
 		} else {
 			*initContainers = append(*initContainers, corev1.Container{
-				Image: ispn.ImageName(),
-				Name:  ExternalArtifactsDownloadInitContainer,
+				Image:           ispn.ImageName(),
+				ImagePullPolicy: ispn.ImagePullPolicy(),
+				SecurityContext: ispn.Spec.Security.ContainerSecurityContext,
+				Name:            ExternalArtifactsDownloadInitContainer,
 				Env: []corev1.EnvVar{
 					{Name: "SERVER_LIBS", Value: serverLibs},
 					{Name: "SERVER_LIBS_DIR", Value: ExternalArtifactsLibsRoot},
@@ -60,7 +72,7 @@ func ApplyExternalArtifactsDownload(ispn *ispnv1.Infinispan, ispnContainer *core
 					MountPath: ExternalArtifactsMountPath,
 				}},
 			})
-			*volumeMounts = append(*volumeMounts, corev1.VolumeMount{Name: ExternalArtifactsVolumeName, MountPath: ExternalArtifactsMountPath, ReadOnly: true})
+			*volumeMounts = append(*volumeMounts, corev1.VolumeMount{Name: ExternalArtifactsVolumeName, MountPath: externalArtifactsServerMountPath(ispn), ReadOnly: true})
 			*volumes = append(*volumes, corev1.Volume{Name: ExternalArtifactsVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}})
 			updated = true
 		}
@@ -0,0 +1,555 @@
+package provision
+
+import (
+	"fmt"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	ingressv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/cloud-provider/service/helpers"
+)
+
+const (
+	EventLoadBalancerUnsupported = "LoadBalancerUnsupported"
+)
+
+// ExposeProvider exposes the cluster outside of Kubernetes using a single mechanism
+type ExposeProvider interface {
+	// Name identifies the provider in logs and error messages
+	Name() string
+	// Reconcile creates or updates the resources that expose the cluster
+	Reconcile(i *ispnv1.Infinispan, ctx pipeline.Context)
+	// Address returns the host[:port] at which the cluster is exposed, or an empty string if it has no address.
+	// ok is false if the address is not known yet, in which case the provider has already requeued the reconciliation
+	Address(i *ispnv1.Infinispan, ctx pipeline.Context) (address string, ok bool)
+	// Remove deletes all resources created by the provider
+	Remove(i *ispnv1.Infinispan, ctx pipeline.Context) error
+}
+
+// ExposeProviders returns all providers supported by the platform
+func ExposeProviders(ctx pipeline.Context) []ExposeProvider {
+	providers := []ExposeProvider{
+		noneExposeProvider{},
+		serviceExposeProvider{corev1.ServiceTypeNodePort},
+		serviceExposeProvider{corev1.ServiceTypeLoadBalancer},
+	}
+	if ctx.IsTypeSupported(pipeline.RouteGVK) {
+		providers = append(providers, routeExposeProvider{})
+	}
+	if ctx.IsTypeSupported(pipeline.IngressGVK) {
+		providers = append(providers, ingressExposeProvider{})
+	}
+	if ctx.IsTypeSupported(pipeline.HTTPRouteGVK) || ctx.IsTypeSupported(pipeline.TLSRouteGVK) {
+		providers = append(providers, gatewayExposeProvider{})
+	}
+	return providers
+}
+
+// ExposeProviderFor returns the provider for the configured spec.expose.type, or nil if the type is not supported by
+// the platform. Route exposure uses an Ingress on platforms without Routes
+func ExposeProviderFor(i *ispnv1.Infinispan, ctx pipeline.Context) ExposeProvider {
+	if !i.IsExposed() {
+		return noneExposeProvider{}
+	}
+	switch i.GetExposeType() {
+	case ispnv1.ExposeTypeNodePort, ispnv1.ExposeTypeLoadBalancer:
+		return serviceExposeProvider{corev1.ServiceType(i.GetExposeType())}
+	case ispnv1.ExposeTypeRoute:
+		if ctx.IsTypeSupported(pipeline.RouteGVK) {
+			return routeExposeProvider{}
+		} else if ctx.IsTypeSupported(pipeline.IngressGVK) {
+			return ingressExposeProvider{}
+		}
+	case ispnv1.ExposeTypeIngress:
+		if ctx.IsTypeSupported(pipeline.IngressGVK) {
+			return ingressExposeProvider{}
+		}
+	case ispnv1.ExposeTypeGateway:
+		if ctx.IsTypeSupported(gatewayRouteGVK(i)) {
+			return gatewayExposeProvider{}
+		}
+	}
+	return nil
+}
+
+// ExternalService exposes the cluster with the provider of spec.expose.type, removing the resources of all other
+// providers so that changing the type does not leave stale resources behind
+func ExternalService(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	provider := ExposeProviderFor(i, ctx)
+	if provider == nil {
+		ctx.Stop(fmt.Errorf("unable to expose cluster with type %s, as no implementations are supported", i.GetExposeType()))
+		return
+	}
+
+	for _, p := range ExposeProviders(ctx) {
+		if p.Name() != provider.Name() {
+			if err := p.Remove(i, ctx); err != nil {
+				return
+			}
+		}
+	}
+	provider.Reconcile(i, ctx)
+}
+
+// exposedPath returns the path prefix of the requests that the Route, Ingress and HTTPRoute providers route to the
+// cluster. Only the REST API is routed if the Console is not reached at the address of spec.expose
+func exposedPath(i *ispnv1.Infinispan) string {
+	if i.IsConsoleEnabled() && !i.IsConsoleExposedWithCluster() {
+		return consts.RestPathPrefix
+	}
+	return "/"
+}
+
+// noneExposeProvider is used when spec.expose is not configured
+type noneExposeProvider struct{}
+
+func (noneExposeProvider) Name() string {
+	return "None"
+}
+
+func (noneExposeProvider) Reconcile(*ispnv1.Infinispan, pipeline.Context) {}
+
+func (noneExposeProvider) Address(*ispnv1.Infinispan, pipeline.Context) (string, bool) {
+	return "", true
+}
+
+func (noneExposeProvider) Remove(*ispnv1.Infinispan, pipeline.Context) error {
+	return nil
+}
+
+// serviceExposeProvider exposes the cluster with a NodePort or LoadBalancer Service
+type serviceExposeProvider struct {
+	serviceType corev1.ServiceType
+}
+
+func (p serviceExposeProvider) Name() string {
+	return string(p.serviceType)
+}
+
+func (p serviceExposeProvider) Reconcile(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	createOrUpdateService(i, i.GetServiceExternalName(), p.apply, ctx)
+}
+
+// apply sets the state desired by the operator on the external Service
+func (p serviceExposeProvider) apply(i *ispnv1.Infinispan, svc *corev1.Service) {
+	svc.Annotations = i.ExternalServiceAnnotations()
+	svc.Labels = i.ExternalServiceLabels()
+	svc.Spec.Type = p.serviceType
+	svc.Spec.Selector = i.ServiceSelectorLabels()
+
+	exposeConf := i.Spec.Expose
+	if p.serviceType == corev1.ServiceTypeLoadBalancer {
+		svc.Spec.LoadBalancerIP = exposeConf.LoadBalancerIP
+		svc.Spec.LoadBalancerSourceRanges = exposeConf.LoadBalancerSourceRanges
+	}
+	applyServiceTrafficPolicy(svc, exposeConf)
+
+	if exposeConf.Endpoints != nil {
+		svc.Spec.Ports = p.endpointPorts(i, svc.Spec.Ports)
+		return
+	}
+
+	// We must utilise the existing ServicePort values if updating the service, to prevent the created ports being overwritten
+	if svc.CreationTimestamp.IsZero() || len(svc.Spec.Ports) == 0 {
+		svc.Spec.Ports = []corev1.ServicePort{{}}
+	}
+	svc.Spec.Ports = svc.Spec.Ports[:1]
+	servicePort := &svc.Spec.Ports[0]
+	servicePort.Name = ""
+	servicePort.Port = int32(consts.InfinispanUserPort)
+	servicePort.TargetPort = intstr.FromInt(consts.InfinispanUserPort)
+
+	if exposeConf.NodePort > 0 && p.serviceType == corev1.ServiceTypeNodePort {
+		servicePort.NodePort = exposeConf.NodePort
+	}
+	if exposeConf.Port > 0 && p.serviceType == corev1.ServiceTypeLoadBalancer {
+		servicePort.Port = exposeConf.Port
+	}
+}
+
+// applyServiceTrafficPolicy configures how a Service of the NodePort or LoadBalancer expose type routes the traffic of
+// clients. The defaults are set explicitly, as the API server would otherwise restore them on every update
+func applyServiceTrafficPolicy(svc *corev1.Service, exposeConf *ispnv1.ExposeSpec) {
+	svc.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
+	if exposeConf.ExternalTrafficPolicy != "" {
+		svc.Spec.ExternalTrafficPolicy = exposeConf.ExternalTrafficPolicy
+	}
+	// The health check port allocated for the Local policy must be released with the Cluster policy
+	if svc.Spec.ExternalTrafficPolicy != corev1.ServiceExternalTrafficPolicyTypeLocal || svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		svc.Spec.HealthCheckNodePort = 0
+	}
+
+	svc.Spec.SessionAffinity = corev1.ServiceAffinityNone
+	svc.Spec.SessionAffinityConfig = nil
+	if exposeConf.SessionAffinity == corev1.ServiceAffinityClientIP {
+		timeout := exposeConf.SessionAffinityTimeoutSeconds
+		if timeout == 0 {
+			timeout = corev1.DefaultClientIPServiceAffinitySeconds
+		}
+		svc.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+		svc.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+			ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: &timeout},
+		}
+	}
+}
+
+// endpointPorts returns a named ServicePort for each endpoint of spec.expose.endpoints. The NodePorts allocated to
+// existing ports are kept if the endpoint does not configure one, so that clients are not disconnected on updates
+func (p serviceExposeProvider) endpointPorts(i *ispnv1.Infinispan, existing []corev1.ServicePort) []corev1.ServicePort {
+	allocated := map[string]int32{}
+	for _, port := range existing {
+		allocated[port.Name] = port.NodePort
+	}
+	var ports []corev1.ServicePort
+	for _, endpoint := range i.GetExposedEndpoints() {
+		port := corev1.ServicePort{
+			Name:       endpoint.Name,
+			Port:       endpoint.Port,
+			TargetPort: intstr.FromInt(endpoint.TargetPort),
+		}
+		if p.serviceType == corev1.ServiceTypeNodePort {
+			port.NodePort = endpoint.NodePort
+			if port.NodePort == 0 {
+				port.NodePort = allocated[endpoint.Name]
+			}
+		}
+		ports = append(ports, port)
+	}
+	return ports
+}
+
+func (p serviceExposeProvider) Address(i *ispnv1.Infinispan, ctx pipeline.Context) (string, bool) {
+	// Wait for the cluster external Service to be created by service-controller
+	externalService := &corev1.Service{}
+	if err := ctx.Resources().Load(i.GetServiceExternalName(), externalService, pipeline.RetryOnErr); err != nil {
+		return "", false
+	}
+
+	log := ctx.Log()
+	k := ctx.Kubernetes()
+	if p.serviceType == corev1.ServiceTypeNodePort {
+		if len(externalService.Spec.Ports) == 0 {
+			return "", true
+		}
+		exposeHost, err := k.GetNodeHost(log, ctx.Ctx())
+		if err != nil {
+			ctx.Requeue(err)
+			return "", false
+		}
+		return fmt.Sprintf("%s:%d", exposeHost, externalService.Spec.Ports[0].NodePort), true
+	}
+
+	// Waiting for LoadBalancer cloud provider to update the configured hostname inside Status field
+	if exposeAddress := k.GetExternalAddress(externalService); exposeAddress != "" {
+		return exposeAddress, true
+	}
+	if !helpers.HasLBFinalizer(externalService) {
+		errMsg := "LoadBalancer expose type is not supported on the target platform"
+		ctx.EventRecorder().Event(externalService, corev1.EventTypeWarning, EventLoadBalancerUnsupported, errMsg)
+		log.Info(errMsg)
+	} else {
+		log.Info("LoadBalancer address not ready yet. Waiting on value in reconcile loop")
+	}
+	ctx.RequeueAfter(consts.DefaultWaitOnCluster, nil)
+	return "", false
+}
+
+// Remove deletes the external Service only if it has the provider's type, as all Service types share the same name
+func (p serviceExposeProvider) Remove(i *ispnv1.Infinispan, ctx pipeline.Context) error {
+	serviceList := &corev1.ServiceList{}
+	if err := ctx.Resources().List(i.ExternalServiceSelectorLabels(), serviceList, pipeline.RetryOnErr); err != nil {
+		return err
+	}
+	for _, svc := range serviceList.Items {
+		if svc.Spec.Type == p.serviceType {
+			if err := ctx.Resources().Delete(svc.Name, &corev1.Service{}, pipeline.RetryOnErr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// routeExposeProvider exposes the cluster with an OpenShift Route
+type routeExposeProvider struct{}
+
+func (routeExposeProvider) Name() string {
+	return "Route"
+}
+
+func (routeExposeProvider) Reconcile(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	termination := i.GetRouteTermination()
+	var destinationCA []byte
+	if termination == ispnv1.RouteTerminationReencrypt {
+		if secretName := i.Spec.Expose.RouteDestinationCASecretName; secretName != "" {
+			secret := &corev1.Secret{}
+			if err := ctx.Resources().Load(secretName, secret, pipeline.RetryOnErr); err != nil {
+				return
+			}
+			if destinationCA = secret.Data[consts.EncryptCAKey]; len(destinationCA) == 0 {
+				ctx.Stop(fmt.Errorf("'%s' key missing from Secret '%s'", consts.EncryptCAKey, secretName))
+				return
+			}
+		} else {
+			destinationCA = ctx.ConfigFiles().CABundle
+		}
+	}
+
+	route := newRoute(i, i.GetServiceExternalName())
+	mutateFn := func() error {
+		route.Annotations = i.ExternalServiceAnnotations()
+		route.Labels = i.ExternalServiceLabels()
+		route.Spec.Host = i.Spec.Expose.Host
+		route.Spec.Path = ""
+		if termination != ispnv1.RouteTerminationPassthrough {
+			route.Spec.Path = exposedPath(i)
+		}
+		route.Spec.Port = &routev1.RoutePort{
+			TargetPort: intstr.FromInt(consts.InfinispanUserPort),
+		}
+		route.Spec.To = routev1.RouteTargetReference{
+			Kind: "Service",
+			Name: i.Name,
+		}
+
+		route.Spec.TLS = nil
+		if termination != "" {
+			route.Spec.TLS = &routev1.TLSConfig{Termination: routev1.TLSTerminationType(termination)}
+			if termination == ispnv1.RouteTerminationReencrypt {
+				route.Spec.TLS.DestinationCACertificate = string(destinationCA)
+			}
+		}
+		return nil
+	}
+	_, _ = ctx.Resources().CreateOrUpdate(route, true, mutateFn, pipeline.RetryOnErr)
+}
+
+func (routeExposeProvider) Address(i *ispnv1.Infinispan, ctx pipeline.Context) (string, bool) {
+	externalRoute := &routev1.Route{}
+	if err := ctx.Resources().Load(i.GetServiceExternalName(), externalRoute, pipeline.RetryOnErr); err != nil {
+		return "", false
+	}
+	return externalRoute.Spec.Host, true
+}
+
+func (routeExposeProvider) Remove(i *ispnv1.Infinispan, ctx pipeline.Context) error {
+	routeList := &routev1.RouteList{}
+	if err := ctx.Resources().List(i.ExternalServiceSelectorLabels(), routeList, pipeline.RetryOnErr); err != nil {
+		return err
+	}
+	for _, route := range routeList.Items {
+		if err := ctx.Resources().Delete(route.Name, &routev1.Route{}, pipeline.RetryOnErr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ingressExposeProvider exposes the cluster with an Ingress, either explicitly or on platforms without Routes
+type ingressExposeProvider struct{}
+
+func (ingressExposeProvider) Name() string {
+	return "Ingress"
+}
+
+func (ingressExposeProvider) Reconcile(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	pathTypePrefix := ingressv1.PathTypePrefix
+
+	ingress := &ingressv1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.k8s.io/v1",
+			Kind:       "Ingress",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      i.GetServiceExternalName(),
+			Namespace: i.Namespace,
+		},
+	}
+
+	mutateFn := func() error {
+		ingress.Annotations = i.ExternalServiceAnnotations()
+		ingress.Labels = i.ExternalServiceLabels()
+		ingress.Spec.Rules = []ingressv1.IngressRule{
+			{
+				Host: i.Spec.Expose.Host,
+				IngressRuleValue: ingressv1.IngressRuleValue{
+					HTTP: &ingressv1.HTTPIngressRuleValue{
+						Paths: []ingressv1.HTTPIngressPath{
+							{
+								PathType: &pathTypePrefix,
+								Path:     exposedPath(i),
+								Backend: ingressv1.IngressBackend{
+									Service: &ingressv1.IngressServiceBackend{
+										Name: i.Name,
+										Port: ingressv1.ServiceBackendPort{Number: i.RestPort()},
+									},
+								}}},
+					},
+				},
+			},
+		}
+
+		ingress.Spec.IngressClassName = nil
+		if className := i.Spec.Expose.IngressClassName; className != "" {
+			ingress.Spec.IngressClassName = &className
+		}
+
+		ingress.Spec.TLS = nil
+		if secretName := i.Spec.Expose.TLSSecretName; secretName != "" {
+			ingress.Spec.TLS = []ingressv1.IngressTLS{
+				{
+					Hosts:      []string{i.Spec.Expose.Host},
+					SecretName: secretName,
+				},
+			}
+		} else if i.IsEncryptionEnabled() {
+			ingress.Spec.TLS = []ingressv1.IngressTLS{
+				{
+					Hosts: []string{i.Spec.Expose.Host},
+				},
+			}
+		}
+		return nil
+	}
+	_, _ = ctx.Resources().CreateOrUpdate(ingress, true, mutateFn, pipeline.RetryOnErr)
+}
+
+func (ingressExposeProvider) Address(i *ispnv1.Infinispan, ctx pipeline.Context) (string, bool) {
+	externalIngress := &ingressv1.Ingress{}
+	if err := ctx.Resources().Load(i.GetServiceExternalName(), externalIngress, pipeline.RetryOnErr); err != nil {
+		return "", false
+	}
+	if len(externalIngress.Spec.Rules) > 0 {
+		return externalIngress.Spec.Rules[0].Host, true
+	}
+	return "", true
+}
+
+func (ingressExposeProvider) Remove(i *ispnv1.Infinispan, ctx pipeline.Context) error {
+	ingressList := &ingressv1.IngressList{}
+	if err := ctx.Resources().List(i.ExternalServiceSelectorLabels(), ingressList, pipeline.RetryOnErr); err != nil {
+		return err
+	}
+	for _, ingress := range ingressList.Items {
+		if err := ctx.Resources().Delete(ingress.Name, &ingressv1.Ingress{}, pipeline.RetryOnErr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gatewayExposeProvider exposes the cluster with a Gateway API route attached to an existing Gateway. An HTTPRoute is
+// used unless encryption is enabled, in which case a TLSRoute passes the encrypted traffic through to the pods so that
+// Hot Rod clients can also connect. The routes are managed as unstructured objects so that the Gateway API CRDs are
+// only required when this provider is used
+type gatewayExposeProvider struct{}
+
+func (gatewayExposeProvider) Name() string {
+	return "Gateway"
+}
+
+// gatewayRouteGVK returns the kind of route that exposes the cluster through the Gateway
+func gatewayRouteGVK(i *ispnv1.Infinispan) schema.GroupVersionKind {
+	if i.IsGatewayTLSPassthrough() {
+		return pipeline.TLSRouteGVK
+	}
+	return pipeline.HTTPRouteGVK
+}
+
+func (p gatewayExposeProvider) Reconcile(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	gvk := gatewayRouteGVK(i)
+	// Remove the route of the other kind if encryption has been enabled or disabled
+	for _, other := range []schema.GroupVersionKind{pipeline.HTTPRouteGVK, pipeline.TLSRouteGVK} {
+		if other != gvk && ctx.IsTypeSupported(other) {
+			if err := p.removeRoutes(i, other, ctx); err != nil {
+				return
+			}
+		}
+	}
+
+	route := newGatewayRoute(i, gvk)
+	mutateFn := func() error {
+		route.SetAnnotations(i.ExternalServiceAnnotations())
+		route.SetLabels(i.ExternalServiceLabels())
+
+		parentRef := map[string]interface{}{"name": i.Spec.Expose.GatewayName}
+		if i.Spec.Expose.GatewayNamespace != "" {
+			parentRef["namespace"] = i.Spec.Expose.GatewayNamespace
+		}
+		if i.Spec.Expose.GatewayListenerName != "" {
+			parentRef["sectionName"] = i.Spec.Expose.GatewayListenerName
+		}
+		port := i.RestPort()
+		if gvk == pipeline.TLSRouteGVK && i.IsHotRodEnabled() {
+			port = i.HotRodPort()
+		}
+		rule := map[string]interface{}{
+			"backendRefs": []interface{}{
+				map[string]interface{}{"name": i.Name, "port": int64(port)},
+			},
+		}
+		if gvk == pipeline.HTTPRouteGVK {
+			rule["matches"] = []interface{}{
+				map[string]interface{}{
+					"path": map[string]interface{}{"type": "PathPrefix", "value": exposedPath(i)},
+				},
+			}
+		}
+		spec := map[string]interface{}{
+			"parentRefs": []interface{}{parentRef},
+			"rules":      []interface{}{rule},
+		}
+		if i.Spec.Expose.Host != "" {
+			spec["hostnames"] = []interface{}{i.Spec.Expose.Host}
+		}
+		return unstructured.SetNestedField(route.Object, spec, "spec")
+	}
+	_, _ = ctx.Resources().CreateOrUpdate(route, true, mutateFn, pipeline.RetryOnErr)
+}
+
+// Address returns spec.expose.host, as the address of the Gateway is not known to the route
+func (gatewayExposeProvider) Address(i *ispnv1.Infinispan, _ pipeline.Context) (string, bool) {
+	return i.Spec.Expose.Host, true
+}
+
+func (p gatewayExposeProvider) Remove(i *ispnv1.Infinispan, ctx pipeline.Context) error {
+	for _, gvk := range []schema.GroupVersionKind{pipeline.HTTPRouteGVK, pipeline.TLSRouteGVK} {
+		if ctx.IsTypeSupported(gvk) {
+			if err := p.removeRoutes(i, gvk, ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (gatewayExposeProvider) removeRoutes(i *ispnv1.Infinispan, gvk schema.GroupVersionKind, ctx pipeline.Context) error {
+	routeList := &unstructured.UnstructuredList{}
+	routeList.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := ctx.Resources().List(i.ExternalServiceSelectorLabels(), routeList, pipeline.RetryOnErr); err != nil {
+		return err
+	}
+	for _, route := range routeList.Items {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		if err := ctx.Resources().Delete(route.GetName(), obj, pipeline.RetryOnErr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newGatewayRoute(i *ispnv1.Infinispan, gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(gvk)
+	route.SetName(i.GetServiceExternalName())
+	route.SetNamespace(i.Namespace)
+	return route
+}
@@ -0,0 +1,246 @@
+package provision
+
+import (
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	ingressv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestExposeProviderFor(t *testing.T) {
+	testTable := []struct {
+		name           string
+		expose         *ispnv1.ExposeSpec
+		supportedTypes []schema.GroupVersionKind
+		expected       string
+	}{
+		{"not exposed", nil, nil, "None"},
+		{"node port", &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeNodePort}, nil, "NodePort"},
+		{"load balancer", &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeLoadBalancer}, nil, "LoadBalancer"},
+		{"route", &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeRoute}, []schema.GroupVersionKind{pipeline.RouteGVK, pipeline.IngressGVK}, "Route"},
+		{"route with ingress", &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeRoute}, []schema.GroupVersionKind{pipeline.IngressGVK}, "Ingress"},
+		{"route unsupported", &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeRoute}, nil, ""},
+		{"ingress", &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeIngress}, []schema.GroupVersionKind{pipeline.RouteGVK, pipeline.IngressGVK}, "Ingress"},
+		{"ingress unsupported", &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeIngress}, []schema.GroupVersionKind{pipeline.RouteGVK}, ""},
+		{"gateway", &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeGateway}, []schema.GroupVersionKind{pipeline.HTTPRouteGVK}, "Gateway"},
+		{"gateway unsupported", &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeGateway}, []schema.GroupVersionKind{pipeline.IngressGVK}, ""},
+		{"cluster ip", &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeClusterIP}, []schema.GroupVersionKind{pipeline.RouteGVK, pipeline.IngressGVK}, "None"},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			i := testInfinispan()
+			i.Spec.Expose = tt.expose
+			ctx := newTestContext()
+			ctx.supportedTypes = tt.supportedTypes
+
+			provider := ExposeProviderFor(i, ctx)
+			if tt.expected == "" {
+				assert.Nil(t, provider)
+			} else {
+				require.NotNil(t, provider)
+				assert.Equal(t, tt.expected, provider.Name())
+			}
+		})
+	}
+}
+
+func TestExternalServiceClusterIP(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.Expose = &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeClusterIP}
+	ctx := newTestContext()
+	ctx.supportedTypes = []schema.GroupVersionKind{pipeline.RouteGVK}
+	ctx.resources.services = []corev1.Service{{
+		ObjectMeta: metav1.ObjectMeta{Name: i.GetServiceExternalName()},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+	}}
+
+	// The resources of all expose types are removed and none are created
+	ExternalService(i, ctx)
+	require.NoError(t, ctx.err)
+	assert.Empty(t, ctx.resources.created)
+	assert.Contains(t, ctx.resources.deleted, i.GetServiceExternalName())
+	assert.False(t, i.IsExposed())
+	assert.Empty(t, i.ExternalClientIntelligence())
+}
+
+func TestServiceExposeProvider(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.Expose = &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeNodePort, NodePort: 30222, Port: 11333}
+	ctx := newTestContext()
+	serviceExposeProvider{corev1.ServiceTypeNodePort}.Reconcile(i, ctx)
+	require.Len(t, ctx.resources.created, 1)
+	svc := createdService(t, ctx.resources.created[0])
+	assert.Equal(t, corev1.ServiceTypeNodePort, svc.Spec.Type)
+	assert.Equal(t, int32(30222), svc.Spec.Ports[0].NodePort)
+	assert.Equal(t, int32(11222), svc.Spec.Ports[0].Port)
+	assert.Equal(t, corev1.ServiceExternalTrafficPolicyTypeCluster, svc.Spec.ExternalTrafficPolicy)
+	assert.Equal(t, corev1.ServiceAffinityNone, svc.Spec.SessionAffinity)
+	assert.Nil(t, svc.Spec.SessionAffinityConfig)
+
+	i.Spec.Expose = &ispnv1.ExposeSpec{
+		Type:                     ispnv1.ExposeTypeLoadBalancer,
+		Port:                     11333,
+		LoadBalancerIP:           "192.0.2.10",
+		LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+		Annotations:              map[string]string{"service.beta.kubernetes.io/aws-load-balancer-internal": "true"},
+		ExternalTrafficPolicy:    corev1.ServiceExternalTrafficPolicyTypeLocal,
+		SessionAffinity:          corev1.ServiceAffinityClientIP,
+	}
+	ctx = newTestContext()
+	serviceExposeProvider{corev1.ServiceTypeLoadBalancer}.Reconcile(i, ctx)
+	require.Len(t, ctx.resources.created, 1)
+	svc = createdService(t, ctx.resources.created[0])
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, svc.Spec.Type)
+	assert.Equal(t, int32(11333), svc.Spec.Ports[0].Port)
+	assert.Equal(t, "192.0.2.10", svc.Spec.LoadBalancerIP)
+	assert.Equal(t, []string{"10.0.0.0/8"}, svc.Spec.LoadBalancerSourceRanges)
+	assert.Equal(t, "true", svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-internal"])
+	assert.Equal(t, corev1.ServiceExternalTrafficPolicyTypeLocal, svc.Spec.ExternalTrafficPolicy)
+	assert.Equal(t, corev1.ServiceAffinityClientIP, svc.Spec.SessionAffinity)
+	require.NotNil(t, svc.Spec.SessionAffinityConfig)
+	assert.Equal(t, corev1.DefaultClientIPServiceAffinitySeconds, *svc.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds)
+
+	// Only Services of the provider's type are removed, as all types share the external Service name
+	ctx = newTestContext()
+	ctx.resources.services = []corev1.Service{{
+		ObjectMeta: metav1.ObjectMeta{Name: i.GetServiceExternalName()},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort},
+	}}
+	require.NoError(t, serviceExposeProvider{corev1.ServiceTypeLoadBalancer}.Remove(i, ctx))
+	assert.Empty(t, ctx.resources.deleted)
+	require.NoError(t, serviceExposeProvider{corev1.ServiceTypeNodePort}.Remove(i, ctx))
+	assert.Equal(t, []string{i.GetServiceExternalName()}, ctx.resources.deleted)
+}
+
+func TestServiceExposeProviderEndpoints(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.Expose = &ispnv1.ExposeSpec{
+		Type: ispnv1.ExposeTypeNodePort,
+		Endpoints: &ispnv1.ExposeEndpointsSpec{
+			HotRod: &ispnv1.ExposeEndpointSpec{NodePort: 30222},
+			Rest:   &ispnv1.ExposeEndpointSpec{Port: 80},
+			Admin:  &ispnv1.ExposeEndpointSpec{NodePort: 30223},
+		},
+	}
+	ctx := newTestContext()
+	serviceExposeProvider{corev1.ServiceTypeNodePort}.Reconcile(i, ctx)
+	require.Len(t, ctx.resources.created, 1)
+	svc := createdService(t, ctx.resources.created[0])
+	assert.Equal(t, []corev1.ServicePort{
+		{Name: "hotrod", Port: 11222, NodePort: 30222, TargetPort: intstr.FromInt(11222)},
+		{Name: "rest", Port: 80, TargetPort: intstr.FromInt(11222)},
+		{Name: "admin", Port: 11223, NodePort: 30223, TargetPort: intstr.FromInt(11223)},
+	}, svc.Spec.Ports)
+
+	// NodePorts allocated by Kubernetes are kept if not configured
+	existing := []corev1.ServicePort{{Name: "rest", NodePort: 31080}}
+	ports := serviceExposeProvider{corev1.ServiceTypeNodePort}.endpointPorts(i, existing)
+	assert.Equal(t, int32(31080), ports[1].NodePort)
+	ports = serviceExposeProvider{corev1.ServiceTypeLoadBalancer}.endpointPorts(i, existing)
+	assert.Zero(t, ports[1].NodePort)
+}
+
+func TestRouteExposeProviderTermination(t *testing.T) {
+	testTable := []struct {
+		name        string
+		termination ispnv1.RouteTerminationType
+		encryption  bool
+		expected    *routev1.TLSConfig
+	}{
+		{"unencrypted", "", false, nil},
+		{"default passthrough", "", true, &routev1.TLSConfig{Termination: routev1.TLSTerminationPassthrough}},
+		{"edge", ispnv1.RouteTerminationEdge, false, &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge}},
+		{"reencrypt", ispnv1.RouteTerminationReencrypt, true, &routev1.TLSConfig{Termination: routev1.TLSTerminationReencrypt, DestinationCACertificate: "ca-bundle"}},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			i := testInfinispan()
+			i.Spec.Expose = &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeRoute, RouteTermination: tt.termination}
+			if tt.encryption {
+				i.Spec.Security.EndpointEncryption = &ispnv1.EndpointEncryption{Type: ispnv1.CertificateSourceTypeSecret, CertSecretName: "tls"}
+			}
+			ctx := newTestContext()
+			ctx.configFiles.CABundle = []byte("ca-bundle")
+			routeExposeProvider{}.Reconcile(i, ctx)
+			require.NoError(t, ctx.err)
+			require.Len(t, ctx.resources.created, 1)
+			assert.Equal(t, tt.expected, ctx.resources.created[0].(*routev1.Route).Spec.TLS)
+		})
+	}
+}
+
+func TestIngressExposeProvider(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.Expose = &ispnv1.ExposeSpec{
+		Type:             ispnv1.ExposeTypeIngress,
+		Host:             "infinispan.example.com",
+		IngressClassName: "nginx",
+		TLSSecretName:    "infinispan-tls",
+		Annotations:      map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "HTTPS"},
+	}
+	ctx := newTestContext()
+	ingressExposeProvider{}.Reconcile(i, ctx)
+	require.Len(t, ctx.resources.created, 1)
+
+	ingress := ctx.resources.created[0].(*ingressv1.Ingress)
+	assert.Equal(t, i.GetServiceExternalName(), ingress.Name)
+	assert.Equal(t, "HTTPS", ingress.Annotations["nginx.ingress.kubernetes.io/backend-protocol"])
+	require.NotNil(t, ingress.Spec.IngressClassName)
+	assert.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+	assert.Equal(t, "infinispan.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, i.Name, ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+	assert.Equal(t, []ingressv1.IngressTLS{{Hosts: []string{"infinispan.example.com"}, SecretName: "infinispan-tls"}}, ingress.Spec.TLS)
+}
+
+func TestGatewayExposeProvider(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.Expose = &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeGateway, Host: "infinispan.example.com", GatewayName: "shared", GatewayNamespace: "gateways"}
+	ctx := newTestContext()
+	gatewayExposeProvider{}.Reconcile(i, ctx)
+	require.Len(t, ctx.resources.created, 1)
+
+	route := ctx.resources.created[0].(*unstructured.Unstructured)
+	assert.Equal(t, pipeline.HTTPRouteGVK, route.GroupVersionKind())
+	assert.Equal(t, i.GetServiceExternalName(), route.GetName())
+
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "shared", "namespace": "gateways"}}, parentRefs)
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	assert.Equal(t, []string{"infinispan.example.com"}, hostnames)
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	backend := rules[0].(map[string]interface{})["backendRefs"].([]interface{})[0]
+	assert.Equal(t, map[string]interface{}{"name": i.Name, "port": int64(11222)}, backend)
+
+	address, ok := gatewayExposeProvider{}.Address(i, ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "infinispan.example.com", address)
+}
+
+func TestGatewayExposeProviderTLSPassthrough(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.Expose = &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeGateway, Host: "infinispan.example.com", GatewayName: "shared", GatewayListenerName: "tls"}
+	i.Spec.Security.EndpointEncryption = &ispnv1.EndpointEncryption{Type: ispnv1.CertificateSourceTypeSecret, CertSecretName: "tls"}
+	ctx := newTestContext()
+	ctx.supportedTypes = []schema.GroupVersionKind{pipeline.HTTPRouteGVK, pipeline.TLSRouteGVK}
+	gatewayExposeProvider{}.Reconcile(i, ctx)
+	require.Len(t, ctx.resources.created, 1)
+
+	route := ctx.resources.created[0].(*unstructured.Unstructured)
+	assert.Equal(t, pipeline.TLSRouteGVK, route.GroupVersionKind())
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "shared", "sectionName": "tls"}}, parentRefs)
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	assert.Equal(t, []string{"infinispan.example.com"}, hostnames)
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	backend := rules[0].(map[string]interface{})["backendRefs"].([]interface{})[0]
+	assert.Equal(t, map[string]interface{}{"name": i.Name, "port": int64(i.HotRodPort())}, backend)
+}
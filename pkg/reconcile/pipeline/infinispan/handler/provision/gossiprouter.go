@@ -38,12 +38,7 @@ func GossipRouter(i *ispnv1.Infinispan, ctx pipeline.Context) {
 		routerLabels := i.GossipRouterPodLabels()
 
 		// if the user configures 0 replicas, shutdown the gossip router pod too.
-		var replicas *int32
-		if i.Spec.Replicas <= 0 {
-			replicas = pointer.Int32(0)
-		} else {
-			replicas = pointer.Int32(i.Spec.Replicas)
-		}
+		replicas := pointer.Int32(i.GossipRouterReplicas())
 
 		args := []string{
 			"-port", strconv.Itoa(consts.CrossSitePort),
@@ -76,6 +71,13 @@ func GossipRouter(i *ispnv1.Infinispan, ctx pipeline.Context) {
 			log.Info("No TLS configured")
 		}
 
+		// Read by launch.sh, spec.container.extraJvmOpts only applies to the server
+		env := []corev1.EnvVar{{Name: "JAVA_OPTIONS", Value: i.Spec.Container.RouterExtraJvmOpts}}
+		if i.IsSiteTLSEnabled() {
+			xsite := ctx.ConfigFiles().XSite
+			env = append(env, corev1.EnvVar{Name: SiteTLSHashEnv, Value: SiteTLSHash(xsite.GossipRouter.Keystore, xsite.GossipRouter.Truststore)})
+		}
+
 		router.Labels = routerLabels
 		router.Spec = appsv1.DeploymentSpec{
 			Selector: &metav1.LabelSelector{
@@ -83,16 +85,22 @@ func GossipRouter(i *ispnv1.Infinispan, ctx pipeline.Context) {
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      router.Name,
-					Namespace: router.Namespace,
-					Labels:    routerLabels,
+					Name:        router.Name,
+					Namespace:   router.Namespace,
+					Labels:      routerLabels,
+					Annotations: GossipRouterServiceMeshAnnotations(i),
 				},
 				Spec: corev1.PodSpec{
+					ImagePullSecrets: i.Spec.ImagePullSecrets,
+					SecurityContext:  i.Spec.Security.PodSecurityContext,
 					Containers: []corev1.Container{{
-						Name:    GossipRouterContainer,
-						Image:   i.ImageName(),
-						Command: []string{"/opt/gossiprouter/bin/launch.sh"},
-						Args:    args,
+						Name:            GossipRouterContainer,
+						Image:           i.ImageName(),
+						ImagePullPolicy: i.ImagePullPolicy(),
+						SecurityContext: i.Spec.Security.ContainerSecurityContext,
+						Command:         []string{"/opt/gossiprouter/bin/launch.sh"},
+						Args:            args,
+						Env:             env,
 						Ports: []corev1.ContainerPort{
 							{
 								ContainerPort: consts.CrossSitePort,
@@ -112,7 +120,7 @@ func GossipRouter(i *ispnv1.Infinispan, ctx pipeline.Context) {
 			AddSecretVolume(i.GetSiteRouterSecretName(), SiteRouterKeystoreVolumeName, consts.SiteRouterKeyStoreRoot, &router.Spec.Template.Spec, GossipRouterContainer)
 		}
 		if addTruststoreVolume {
-			AddSecretVolume(i.GetSiteTrustoreSecretName(), SiteTruststoreVolumeName, consts.SiteTrustStoreRoot, &router.Spec.Template.Spec, GossipRouterContainer)
+			AddSecretVolume(SiteTrustStoreSecretName(i), SiteTruststoreVolumeName, consts.SiteTrustStoreRoot, &router.Spec.Template.Spec, GossipRouterContainer)
 		}
 		return nil
 	}
@@ -132,8 +140,9 @@ func GossipRouter(i *ispnv1.Infinispan, ctx pipeline.Context) {
 		return
 	}
 
-	if len(pods.Items) == 0 || !kube.AreAllPodsReady(pods) {
-		if i.Spec.Replicas == 0 {
+	replicas := i.GossipRouterReplicas()
+	if replicas == 0 || len(pods.Items) < int(replicas) || !kube.AreAllPodsReady(pods) {
+		if replicas == 0 {
 			// shutdown request, ignore
 			// retry on error set!
 			_ = ctx.UpdateInfinispan(func() {
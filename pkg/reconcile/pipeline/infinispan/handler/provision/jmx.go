@@ -0,0 +1,147 @@
+package provision
+
+import (
+	"fmt"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	JmxVolumeName       = "jmx-credentials"
+	JmxInitContainer    = "jmx-credentials"
+	JmxUsernameKey      = "username"
+	JmxPasswordKey      = "password"
+	EventReasonJmxError = "JmxError"
+)
+
+// JmxService creates a headless service exposing the internal JMX port of each pod when spec.jmx.enabled is true,
+// otherwise any existing service is removed
+func JmxService(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	if !i.IsJmxEnabled() {
+		_ = ctx.Resources().Delete(i.GetJmxServiceName(), &corev1.Service{}, pipeline.RetryOnErr, pipeline.IgnoreNotFound)
+		return
+	}
+
+	secret := &corev1.Secret{}
+	if err := ctx.Resources().Load(i.Spec.Jmx.SecretName, secret, pipeline.RetryOnErr); err != nil {
+		return
+	}
+	for _, key := range []string{JmxUsernameKey, JmxPasswordKey} {
+		if len(secret.Data[key]) == 0 {
+			err := fmt.Errorf("JMX Secret '%s' must contain the '%s' key", secret.Name, key)
+			ctx.EventRecorder().Event(i, corev1.EventTypeWarning, EventReasonJmxError, err.Error())
+			ctx.Requeue(err)
+			return
+		}
+	}
+
+	svc := newService(i, i.GetJmxServiceName())
+	mutateFn := func() error {
+		svc.Annotations = i.InternalServiceAnnotations()
+		svc.Labels = i.InternalServiceLabels("infinispan-service-jmx")
+		svc.Spec.Type = corev1.ServiceTypeClusterIP
+		svc.Spec.ClusterIP = corev1.ClusterIPNone
+		svc.Spec.Selector = i.ServiceSelectorLabels()
+		// We must utilise the existing ServicePort values if updating the service, to prevent the created ports being overwritten
+		if svc.CreationTimestamp.IsZero() {
+			svc.Spec.Ports = []corev1.ServicePort{{}}
+		}
+		servicePort := &svc.Spec.Ports[0]
+		servicePort.Name = consts.InfinispanJmxPortName
+		servicePort.Port = consts.InfinispanJmxPort
+		return nil
+	}
+	_, _ = ctx.Resources().CreateOrUpdate(svc, true, mutateFn, pipeline.RetryOnErr)
+}
+
+// ApplyJmx adds the JMX port and the credential files required by remote JMX to the Infinispan container when
+// spec.jmx.enabled is true, removing them otherwise. The files are written to an emptyDir volume by an init container,
+// as the JVM requires the password file to be only readable by its owner
+func ApplyJmx(ispn *ispnv1.Infinispan, ispnContainer *corev1.Container, spec *corev1.PodSpec) (updated bool) {
+	initContainers := &spec.InitContainers
+	volumes := &spec.Volumes
+	volumeMounts := &ispnContainer.VolumeMounts
+	containerPosition := kube.ContainerIndex(*initContainers, JmxInitContainer)
+	portPosition := findContainerPort(ispnContainer.Ports, consts.InfinispanJmxPortName)
+	if ispn.IsJmxEnabled() {
+		if containerPosition >= 0 {
+			initContainer := &spec.InitContainers[containerPosition]
+			for idx := range initContainer.Env {
+				if ref := initContainer.Env[idx].ValueFrom.SecretKeyRef; ref.Name != ispn.Spec.Jmx.SecretName {
+					ref.Name = ispn.Spec.Jmx.SecretName
+					updated = true
+				}
+			}
+		} else {
+			*initContainers = append(*initContainers, jmxInitContainer(ispn))
+			*volumeMounts = append(*volumeMounts, corev1.VolumeMount{Name: JmxVolumeName, MountPath: consts.ServerJmxRoot, ReadOnly: true})
+			*volumes = append(*volumes, corev1.Volume{Name: JmxVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}})
+			updated = true
+		}
+		if portPosition < 0 {
+			ispnContainer.Ports = append(ispnContainer.Ports, corev1.ContainerPort{ContainerPort: consts.InfinispanJmxPort, Name: consts.InfinispanJmxPortName, Protocol: corev1.ProtocolTCP})
+			updated = true
+		}
+		return
+	}
+
+	if containerPosition >= 0 {
+		volumePosition := findVolume(*volumes, JmxVolumeName)
+		volumeMountPosition := findVolumeMount(*volumeMounts, JmxVolumeName)
+		*initContainers = append((*initContainers)[:containerPosition], (*initContainers)[containerPosition+1:]...)
+		*volumes = append(spec.Volumes[:volumePosition], spec.Volumes[volumePosition+1:]...)
+		*volumeMounts = append((*volumeMounts)[:volumeMountPosition], (*volumeMounts)[volumeMountPosition+1:]...)
+		updated = true
+	}
+	if portPosition >= 0 {
+		ispnContainer.Ports = append(ispnContainer.Ports[:portPosition], ispnContainer.Ports[portPosition+1:]...)
+		updated = true
+	}
+	return
+}
+
+func jmxInitContainer(ispn *ispnv1.Infinispan) corev1.Container {
+	secretEnv := func(name, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: ispn.Spec.Jmx.SecretName},
+					Key:                  key,
+				},
+			},
+		}
+	}
+	passwordFile := consts.ServerJmxRoot + "/" + consts.JmxPasswordFilename
+	accessFile := consts.ServerJmxRoot + "/" + consts.JmxAccessFilename
+	script := fmt.Sprintf(`printf '%%s %%s\n' "$JMX_USERNAME" "$JMX_PASSWORD" > %[1]s && printf '%%s readwrite\n' "$JMX_USERNAME" > %[2]s && chmod 0400 %[1]s %[2]s`,
+		passwordFile, accessFile)
+	return corev1.Container{
+		Image:           ispn.ImageName(),
+		ImagePullPolicy: ispn.ImagePullPolicy(),
+		SecurityContext: ispn.Spec.Security.ContainerSecurityContext,
+		Name:            JmxInitContainer,
+		Command:         []string{"sh", "-c", script},
+		Env: []corev1.EnvVar{
+			secretEnv("JMX_USERNAME", JmxUsernameKey),
+			secretEnv("JMX_PASSWORD", JmxPasswordKey),
+		},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      JmxVolumeName,
+			MountPath: consts.ServerJmxRoot,
+		}},
+	}
+}
+
+func findContainerPort(ports []corev1.ContainerPort, portName string) int {
+	for i, port := range ports {
+		if port.Name == portName {
+			return i
+		}
+	}
+	return -1
+}
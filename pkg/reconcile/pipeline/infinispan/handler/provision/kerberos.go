@@ -0,0 +1,49 @@
+package provision
+
+import (
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const KerberosVolumeName = "kerberos-keytab"
+
+// ApplyKerberos mounts the keytab Secret of spec.security.endpointKerberos in the Infinispan container, removing the
+// volume once Kerberos is disabled. Returns true if the pod spec was updated
+func ApplyKerberos(i *ispnv1.Infinispan, ispnContainer *corev1.Container, spec *corev1.PodSpec) (updated bool) {
+	volumes := &spec.Volumes
+	volumeMounts := &ispnContainer.VolumeMounts
+	volumePosition := findVolume(*volumes, KerberosVolumeName)
+	volumeMountPosition := findVolumeMount(*volumeMounts, KerberosVolumeName)
+
+	if i.IsKerberosEnabled() {
+		secretName := i.Spec.Security.EndpointKerberos.KeytabSecretName
+		if volumePosition < 0 {
+			*volumes = append(*volumes, corev1.Volume{
+				Name: KerberosVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: secretName},
+				},
+			})
+			updated = true
+		} else if secret := (*volumes)[volumePosition].Secret; secret.SecretName != secretName {
+			secret.SecretName = secretName
+			updated = true
+		}
+		if volumeMountPosition < 0 {
+			*volumeMounts = append(*volumeMounts, corev1.VolumeMount{Name: KerberosVolumeName, MountPath: consts.ServerKerberosRoot, ReadOnly: true})
+			updated = true
+		}
+		return
+	}
+
+	if volumePosition >= 0 {
+		*volumes = append((*volumes)[:volumePosition], (*volumes)[volumePosition+1:]...)
+		updated = true
+	}
+	if volumeMountPosition >= 0 {
+		*volumeMounts = append((*volumeMounts)[:volumeMountPosition], (*volumeMounts)[volumeMountPosition+1:]...)
+		updated = true
+	}
+	return
+}
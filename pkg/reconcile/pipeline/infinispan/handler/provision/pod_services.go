@@ -0,0 +1,174 @@
+package provision
+
+import (
+	"fmt"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	ExternalAddressInitContainer = "external-address"
+	ExternalAddressVolumeName    = "external-address"
+	// ExternalHostEnv and ExternalPortEnv are referenced by the Hot Rod connector of the server configuration
+	ExternalHostEnv = "INFINISPAN_EXTERNAL_HOST"
+	ExternalPortEnv = "INFINISPAN_EXTERNAL_PORT"
+
+	externalAddressMountPath = "/etc/external-address"
+	externalHostFile         = "host"
+	externalPortFile         = "port"
+)
+
+// PodExternalServices creates a NodePort or LoadBalancer Service for each pod of the StatefulSet when
+// spec.expose.perPod is true, removing the Services of pods that no longer exist after scaling down. The Services are
+// created for each ordinal of spec.replicas, so that their addresses are retained whilst pods are restarted
+func PodExternalServices(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	desired := map[string]int32{}
+	if i.IsExposedPerPod() {
+		for ordinal := int32(0); ordinal < i.Spec.Replicas; ordinal++ {
+			desired[i.GetPodServiceExternalName(ordinal)] = ordinal
+		}
+	}
+
+	serviceList := &corev1.ServiceList{}
+	if err := ctx.Resources().List(i.PodExternalServiceSelectorLabels(), serviceList, pipeline.RetryOnErr); err != nil {
+		return
+	}
+	for _, svc := range serviceList.Items {
+		if _, ok := desired[svc.Name]; ok && svc.Spec.Type == corev1.ServiceType(i.GetExposeType()) {
+			continue
+		}
+		if err := ctx.Resources().Delete(svc.Name, &corev1.Service{}, pipeline.RetryOnErr); err != nil {
+			return
+		}
+	}
+
+	for name, ordinal := range desired {
+		svc := newService(i, name)
+		podName := fmt.Sprintf("%s-%d", i.GetStatefulSetName(), ordinal)
+		mutateFn := func() error {
+			svc.Annotations = i.PodExternalServiceAnnotations()
+			svc.Labels = i.PodExternalServiceLabels()
+			svc.Spec.Type = corev1.ServiceType(i.GetExposeType())
+			svc.Spec.Selector = i.ServiceSelectorLabels()
+			svc.Spec.Selector[appsv1.StatefulSetPodNameLabel] = podName
+
+			exposeConf := i.Spec.Expose
+			if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+				svc.Spec.LoadBalancerSourceRanges = exposeConf.LoadBalancerSourceRanges
+			}
+			applyServiceTrafficPolicy(svc, exposeConf)
+			// The NodePort allocated to an existing Service is kept, so that clients are not disconnected on updates
+			if len(svc.Spec.Ports) == 0 {
+				svc.Spec.Ports = []corev1.ServicePort{{}}
+			}
+			svc.Spec.Ports = svc.Spec.Ports[:1]
+			servicePort := &svc.Spec.Ports[0]
+			servicePort.Name = "hotrod"
+			servicePort.Port = int32(consts.InfinispanUserPort)
+			servicePort.TargetPort = intstr.FromInt(int(i.HotRodPort()))
+			if exposeConf.Port > 0 && svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+				servicePort.Port = exposeConf.Port
+			}
+			return nil
+		}
+		if _, err := ctx.Resources().CreateOrUpdate(svc, true, mutateFn, pipeline.RetryOnErr); err != nil {
+			return
+		}
+	}
+}
+
+// ApplyExternalAddress configures the server container to advertise the address of its pod's external Service in the
+// Hot Rod topology. The address is read from the infinispan.org/external-host and infinispan.org/external-port
+// annotations, which the operator sets once the Service has an address. As the annotations are only resolved when the
+// container starts, an init container waits until they are exposed by the downward API
+func ApplyExternalAddress(ispn *ispnv1.Infinispan, ispnContainer *corev1.Container, spec *corev1.PodSpec) (updated bool) {
+	initContainers := &spec.InitContainers
+	volumes := &spec.Volumes
+	env := &ispnContainer.Env
+	containerPosition := kube.ContainerIndex(*initContainers, ExternalAddressInitContainer)
+	envVars := map[string]string{
+		ExternalHostEnv: consts.AnnotationExternalHost,
+		ExternalPortEnv: consts.AnnotationExternalPort,
+	}
+	if ispn.IsExposedPerPod() {
+		if containerPosition < 0 {
+			*initContainers = append(*initContainers, externalAddressInitContainer(ispn))
+			*volumes = append(*volumes, externalAddressVolume())
+			updated = true
+		}
+		for _, name := range []string{ExternalHostEnv, ExternalPortEnv} {
+			if kube.GetEnvVarIndex(name, env) < 0 {
+				*env = append(*env, corev1.EnvVar{
+					Name: name,
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: annotationFieldRef(envVars[name]),
+					},
+				})
+				updated = true
+			}
+		}
+		return
+	}
+
+	if containerPosition >= 0 {
+		*initContainers = append((*initContainers)[:containerPosition], (*initContainers)[containerPosition+1:]...)
+		if volumePosition := findVolume(*volumes, ExternalAddressVolumeName); volumePosition >= 0 {
+			*volumes = append((*volumes)[:volumePosition], (*volumes)[volumePosition+1:]...)
+		}
+		updated = true
+	}
+	for _, name := range []string{ExternalHostEnv, ExternalPortEnv} {
+		if envPosition := kube.GetEnvVarIndex(name, env); envPosition >= 0 {
+			*env = append((*env)[:envPosition], (*env)[envPosition+1:]...)
+			updated = true
+		}
+	}
+	return
+}
+
+func externalAddressInitContainer(ispn *ispnv1.Infinispan) corev1.Container {
+	script := fmt.Sprintf(`until [ -s %[1]s/%[2]s ] && [ -s %[1]s/%[3]s ]; do echo "Waiting for the address of the external Service"; sleep 2; done`,
+		externalAddressMountPath, externalHostFile, externalPortFile)
+	return corev1.Container{
+		Image:           ispn.ImageName(),
+		ImagePullPolicy: ispn.ImagePullPolicy(),
+		SecurityContext: ispn.Spec.Security.ContainerSecurityContext,
+		Name:            ExternalAddressInitContainer,
+		Command:         []string{"sh", "-c", script},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      ExternalAddressVolumeName,
+			MountPath: externalAddressMountPath,
+			ReadOnly:  true,
+		}},
+	}
+}
+
+func externalAddressVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: ExternalAddressVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{{
+					Path:     externalHostFile,
+					FieldRef: annotationFieldRef(consts.AnnotationExternalHost),
+				}, {
+					Path:     externalPortFile,
+					FieldRef: annotationFieldRef(consts.AnnotationExternalPort),
+				}},
+			},
+		},
+	}
+}
+
+func annotationFieldRef(annotation string) *corev1.ObjectFieldSelector {
+	return &corev1.ObjectFieldSelector{
+		APIVersion: "v1",
+		FieldPath:  fmt.Sprintf("metadata.annotations['%s']", annotation),
+	}
+}
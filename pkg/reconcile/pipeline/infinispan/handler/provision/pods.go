@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"reflect"
+	"strings"
 
 	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
 	consts "github.com/infinispan/infinispan-operator/controllers/constants"
@@ -25,7 +27,49 @@ func PodPortsWithXsite(i *ispnv1.Infinispan) []corev1.ContainerPort {
 	return ports
 }
 
-func PodLivenessProbe() *corev1.Probe {
+// ApplyEndpointPorts adds the dedicated protocol ports of spec.endpoints to the Infinispan container, updating or
+// removing the ports of protocols whose configuration has changed
+func ApplyEndpointPorts(i *ispnv1.Infinispan, ispnContainer *corev1.Container) (updated bool) {
+	desired := map[string]int32{}
+	for _, port := range DedicatedEndpointPorts(i) {
+		desired[port.Name] = port.Port
+	}
+	for _, name := range []string{consts.InfinispanHotRodPortName, consts.InfinispanRestPortName} {
+		position := findContainerPort(ispnContainer.Ports, name)
+		port, dedicated := desired[name]
+		switch {
+		case position >= 0 && !dedicated:
+			ispnContainer.Ports = append(ispnContainer.Ports[:position], ispnContainer.Ports[position+1:]...)
+			updated = true
+		case position >= 0 && ispnContainer.Ports[position].ContainerPort != port:
+			ispnContainer.Ports[position].ContainerPort = port
+			updated = true
+		case position < 0 && dedicated:
+			ispnContainer.Ports = append(ispnContainer.Ports, corev1.ContainerPort{ContainerPort: port, Name: name, Protocol: corev1.ProtocolTCP})
+			updated = true
+		}
+	}
+	return
+}
+
+// DedicatedEndpointPorts returns the ports of the enabled protocols that are configured with a dedicated port in
+// spec.endpoints, instead of sharing the user port
+func DedicatedEndpointPorts(i *ispnv1.Infinispan) []corev1.ServicePort {
+	var ports []corev1.ServicePort
+	if port := i.HotRodPort(); i.IsHotRodEnabled() && port != consts.InfinispanUserPort {
+		ports = append(ports, corev1.ServicePort{Name: consts.InfinispanHotRodPortName, Port: port, Protocol: corev1.ProtocolTCP})
+	}
+	if port := i.RestPort(); i.IsRestEnabled() && port != consts.InfinispanUserPort {
+		ports = append(ports, corev1.ServicePort{Name: consts.InfinispanRestPortName, Port: port, Protocol: corev1.ProtocolTCP})
+	}
+	return ports
+}
+
+// PodLivenessProbe returns nil in dev mode, so that pods are not restarted whilst the server is suspended by a debugger
+func PodLivenessProbe(i *ispnv1.Infinispan) *corev1.Probe {
+	if i.IsDevMode() {
+		return nil
+	}
 	return probe(5, 0, 10, 1, 80)
 }
 
@@ -104,7 +148,76 @@ func PodEnv(i *ispnv1.Infinispan, systemEnv *[]corev1.EnvVar) []corev1.EnvVar {
 		{Name: "DEFAULT_IMAGE", Value: consts.DefaultImageName},
 	}
 
-	// Adding additional variables listed in ADDITIONAL_VARS env var
+	localization := LocalizationEnv(i)
+	for _, env := range localization {
+		if env.Value != "" {
+			envVars = append(envVars, env)
+		}
+	}
+
+	// Adding additional variables listed in ADDITIONAL_VARS env var, localization variables have already been added and
+	// variables in spec.container.env take precedence
+	userEnv := ContainerEnv(i)
+	for _, env := range additionalEnvVars() {
+		if kube.GetEnvVarIndex(env.Name, &localization) < 0 && kube.GetEnvVarIndex(env.Name, &userEnv) < 0 {
+			envVars = append(envVars, env)
+		}
+	}
+
+	if systemEnv != nil {
+		envVars = append(envVars, *systemEnv...)
+	}
+
+	return append(envVars, userEnv...)
+}
+
+// ContainerEnv returns the spec.container.env variables with the defaults applied by the api-server, so that they
+// can be compared with the variables of an existing StatefulSet
+func ContainerEnv(i *ispnv1.Infinispan) []corev1.EnvVar {
+	env := make([]corev1.EnvVar, len(i.Spec.Container.Env))
+	for idx := range i.Spec.Container.Env {
+		i.Spec.Container.Env[idx].DeepCopyInto(&env[idx])
+		if from := env[idx].ValueFrom; from != nil && from.FieldRef != nil && from.FieldRef.APIVersion == "" {
+			from.FieldRef.APIVersion = "v1"
+		}
+	}
+	return env
+}
+
+// ContainerEnvNames returns the names of the spec.container.env variables as stored in the AnnotationContainerEnv
+// annotation of the pod template
+func ContainerEnvNames(i *ispnv1.Infinispan) string {
+	names := make([]string, len(i.Spec.Container.Env))
+	for idx, env := range i.Spec.Container.Env {
+		names[idx] = env.Name
+	}
+	return strings.Join(names, ",")
+}
+
+// LocalizationEnv returns the TZ, LANG and LC_ALL env variables of the server container. Values not configured in
+// spec.container fall back to the variables listed in ADDITIONAL_VARS, an empty value means that the variable is unset.
+// TZ takes precedence over /etc/localtime, so the time zone files of the host don't need to be mounted
+func LocalizationEnv(i *ispnv1.Infinispan) []corev1.EnvVar {
+	additional := additionalEnvVars()
+	value := func(name, specValue string) string {
+		if specValue != "" {
+			return specValue
+		}
+		if index := kube.GetEnvVarIndex(name, &additional); index >= 0 {
+			return additional[index].Value
+		}
+		return ""
+	}
+	return []corev1.EnvVar{
+		{Name: "TZ", Value: value("TZ", i.Spec.Container.Timezone)},
+		{Name: "LANG", Value: value("LANG", i.Spec.Container.Locale)},
+		{Name: "LC_ALL", Value: value("LC_ALL", i.Spec.Container.Locale)},
+	}
+}
+
+// additionalEnvVars returns the operator env variables listed in the ADDITIONAL_VARS env var
+func additionalEnvVars() []corev1.EnvVar {
+	var envVars []corev1.EnvVar
 	envVar, defined := os.LookupEnv("ADDITIONAL_VARS")
 	if defined {
 		var addVars []string
@@ -118,17 +231,12 @@ func PodEnv(i *ispnv1.Infinispan, systemEnv *[]corev1.EnvVar) []corev1.EnvVar {
 			}
 		}
 	}
-
-	if systemEnv != nil {
-		envVars = append(envVars, *systemEnv...)
-	}
-
 	return envVars
 }
 
 // AddVolumeForUserAuthentication returns true if the volume has been added
 func AddVolumeForUserAuthentication(i *ispnv1.Infinispan, spec *corev1.PodSpec) bool {
-	if _, index := findSecretInVolume(spec, IdentitiesVolumeName); !i.IsAuthenticationEnabled() || index >= 0 {
+	if _, index := findSecretInVolume(spec, IdentitiesVolumeName); !i.IsEndpointSecretEnabled() || index >= 0 {
 		return false
 	}
 
@@ -150,6 +258,17 @@ func AddVolumeForUserAuthentication(i *ispnv1.Infinispan, spec *corev1.PodSpec)
 	return true
 }
 
+// PodSecurityContextEquals compares PodSecurityContexts, treating nil as the empty struct an unset context is defaulted to by the api-server
+func PodSecurityContextEquals(a, b *corev1.PodSecurityContext) bool {
+	if a == nil {
+		a = &corev1.PodSecurityContext{}
+	}
+	if b == nil {
+		b = &corev1.PodSecurityContext{}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
 // AddVolumeChmodInitContainer adds an init container that run chmod if needed
 func AddVolumeChmodInitContainer(containerName, volumeName, mountPath string, spec *corev1.PodSpec) {
 	if chmod, ok := os.LookupEnv("MAKE_DATADIR_WRITABLE"); ok && chmod == "true" {
@@ -5,6 +5,7 @@ import (
 	consts "github.com/infinispan/infinispan-operator/controllers/constants"
 	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -32,7 +33,20 @@ func AdminSecret(i *ispnv1.Infinispan, ctx pipeline.Context) {
 		}
 		return nil
 	}
-	_, _ = ctx.Resources().CreateOrUpdate(secret, true, mutateFn, pipeline.RetryOnErr)
+	if _, err := ctx.Resources().CreateOrUpdate(secret, true, mutateFn, pipeline.RetryOnErr); err != nil {
+		return
+	}
+
+	// Report the secrets that contain the credentials of the operator and of the application users
+	security := &ispnv1.InfinispanSecurity{AdminSecretName: i.GetAdminCredentialsSecretName()}
+	if i.IsEndpointSecretEnabled() {
+		security.EndpointSecretName = i.GetSecretName()
+	}
+	if !equality.Semantic.DeepEqual(i.Status.Security, security) {
+		_ = ctx.UpdateInfinispan(func() {
+			i.Status.Security = security
+		})
+	}
 }
 
 func InfinispanSecuritySecret(i *ispnv1.Infinispan, ctx pipeline.Context) {
@@ -72,6 +86,25 @@ func TruststoreSecret(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	_, _ = ctx.Resources().CreateOrUpdate(secret, false, mutateFn, pipeline.RetryOnErr)
 }
 
+// RelayTruststoreSecret stores the relay truststore assembled from the CA certificates of the remote sites
+func RelayTruststoreSecret(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	transport := ctx.ConfigFiles().Transport
+	secret := newSecret(i, i.GetSiteRelayTrustStoreSecretName())
+	mutateFn := func() error {
+		secret.Labels = i.Labels("infinispan-secret-relay-truststore")
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[consts.AnnotationRelayTrustStoreHash] = transport.RelayTruststoreHash
+		secret.Data = map[string][]byte{
+			consts.DefaultSiteTrustStoreFileName: transport.Truststore.File,
+			"password":                           []byte(transport.Truststore.Password),
+		}
+		return nil
+	}
+	_, _ = ctx.Resources().CreateOrUpdate(secret, true, mutateFn, pipeline.RetryOnErr)
+}
+
 func newSecret(i *ispnv1.Infinispan, name string) *corev1.Secret {
 	return &corev1.Secret{
 		TypeMeta: metav1.TypeMeta{
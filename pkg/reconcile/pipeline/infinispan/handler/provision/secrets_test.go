@@ -0,0 +1,40 @@
+package provision
+
+import (
+	"testing"
+
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestAdminSecretStatus(t *testing.T) {
+	i := testInfinispan()
+	ctx := newTestContext()
+	ctx.configFiles.AdminIdentities.Username = consts.DefaultOperatorUser
+	ctx.configFiles.AdminIdentities.Password = "password"
+	AdminSecret(i, ctx)
+	require.NoError(t, ctx.err)
+	require.Len(t, ctx.resources.created, 1)
+	secret := ctx.resources.created[0].(*corev1.Secret)
+	assert.Equal(t, i.GetAdminSecretName(), secret.Name)
+	assert.Equal(t, "password", string(secret.Data[consts.AdminPasswordKey]))
+	require.NotNil(t, i.Status.Security)
+	assert.Equal(t, i.GetAdminSecretName(), i.Status.Security.AdminSecretName)
+	assert.Equal(t, i.GenerateSecretName(), i.Status.Security.EndpointSecretName)
+
+	// Secrets provided by the user are reported instead of the generated secrets
+	i.Spec.Security.AdminSecretName = "admin-credentials"
+	i.Spec.Security.EndpointSecretName = "app-credentials"
+	AdminSecret(i, newTestContext())
+	assert.Equal(t, "admin-credentials", i.Status.Security.AdminSecretName)
+	assert.Equal(t, "app-credentials", i.Status.Security.EndpointSecretName)
+
+	// No application secret is used without authentication
+	i.Spec.Security.EndpointAuthentication = pointer.BoolPtr(false)
+	AdminSecret(i, newTestContext())
+	assert.Equal(t, "admin-credentials", i.Status.Security.AdminSecretName)
+	assert.Empty(t, i.Status.Security.EndpointSecretName)
+}
@@ -0,0 +1,80 @@
+package provision
+
+import (
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ServerServiceAccount waits until spec.serviceAccountName exists and binds it to a Role with the permissions that
+// Infinispan pods require from the Kubernetes API. The ServiceAccount itself is never created or modified by the
+// operator. The Role and RoleBinding are removed if the ServiceAccount is unset or its API token is not mounted
+func ServerServiceAccount(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	if i.Spec.ServiceAccountName != "" {
+		// Pods cannot be created with a ServiceAccount that does not exist
+		if err := ctx.Resources().Load(i.Spec.ServiceAccountName, &corev1.ServiceAccount{}, pipeline.RetryOnErr); err != nil {
+			return
+		}
+	}
+
+	if !i.IsServerRoleRequired() {
+		removeServerRole(i, ctx)
+		return
+	}
+
+	objectMeta := metav1.ObjectMeta{
+		Name:      i.GetServerRoleName(),
+		Namespace: i.Namespace,
+	}
+	role := &rbacv1.Role{ObjectMeta: objectMeta}
+	if _, err := ctx.Resources().CreateOrUpdate(role, true, func() error {
+		role.Rules = serverRoleRules()
+		return nil
+	}, pipeline.RetryOnErr); err != nil {
+		return
+	}
+
+	roleBinding := &rbacv1.RoleBinding{ObjectMeta: objectMeta}
+	_, _ = ctx.Resources().CreateOrUpdate(roleBinding, true, func() error {
+		roleBinding.RoleRef = rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     role.Name,
+		}
+		roleBinding.Subjects = []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      i.Spec.ServiceAccountName,
+			Namespace: i.Namespace,
+		}}
+		return nil
+	}, pipeline.RetryOnErr)
+}
+
+// serverRoleRules returns the minimal permissions of Infinispan pods. The default server configuration discovers
+// cluster members with DNS queries, but custom configurations that use KUBE_PING discover them by listing pods
+func serverRoleRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{{
+		APIGroups: []string{""},
+		Resources: []string{"pods"},
+		Verbs:     []string{"get", "list"},
+	}}
+}
+
+func removeServerRole(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	name := i.GetServerRoleName()
+	for _, obj := range []client.Object{&rbacv1.RoleBinding{}, &rbacv1.Role{}} {
+		// Load from the cache first, so that clusters without a Role do not delete it on every reconciliation
+		if err := ctx.Resources().Load(name, obj, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+			return
+		}
+		if obj.GetUID() == "" {
+			continue
+		}
+		if err := ctx.Resources().Delete(name, obj, pipeline.RetryOnErr); err != nil {
+			return
+		}
+	}
+}
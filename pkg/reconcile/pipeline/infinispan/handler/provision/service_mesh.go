@@ -0,0 +1,83 @@
+package provision
+
+import (
+	"strconv"
+	"strings"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	IstioExcludeInboundPortsAnnotation  = "traffic.sidecar.istio.io/excludeInboundPorts"
+	IstioExcludeOutboundPortsAnnotation = "traffic.sidecar.istio.io/excludeOutboundPorts"
+	IstioRewriteProbesAnnotation        = "sidecar.istio.io/rewriteAppHTTPProbers"
+)
+
+var serviceMeshAnnotations = []string{
+	IstioExcludeInboundPortsAnnotation,
+	IstioExcludeOutboundPortsAnnotation,
+	IstioRewriteProbesAnnotation,
+}
+
+// ApplyServiceMesh adds the Istio annotations of spec.serviceMesh to the pod template, removing them when the service
+// mesh compatibility is disabled unless they are configured by the user with the pod target annotations.
+//
+// JGroups connects to the pod IPs directly and the operator is not part of the mesh, so mTLS in STRICT mode breaks
+// cluster formation and the operator's calls to the admin endpoint of each pod. Those ports bypass the sidecars instead
+func ApplyServiceMesh(i *ispnv1.Infinispan, template *corev1.PodTemplateSpec) (updated bool) {
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	annotations := template.Annotations
+	if !i.IsServiceMeshEnabled() {
+		userAnnotations := i.PodAnnotations()
+		for _, key := range serviceMeshAnnotations {
+			if _, exists := annotations[key]; exists {
+				if _, userDefined := userAnnotations[key]; !userDefined {
+					delete(annotations, key)
+					updated = true
+				}
+			}
+		}
+		return
+	}
+
+	clusterPorts := []int{consts.JGroupsTcpPort, consts.JGroupsFDSockPort}
+	if i.HasSites() {
+		clusterPorts = append(clusterPorts, consts.CrossSitePort)
+	}
+	desired := map[string]string{
+		IstioExcludeInboundPortsAnnotation:  joinPorts(append(clusterPorts, consts.InfinispanAdminPort)),
+		IstioExcludeOutboundPortsAnnotation: joinPorts(clusterPorts),
+		// The sidecar agent forwards the probes to the admin port, as the kubelet cannot present a mesh certificate
+		IstioRewriteProbesAnnotation: strconv.FormatBool(true),
+	}
+	for key, value := range desired {
+		if annotations[key] != value {
+			annotations[key] = value
+			updated = true
+		}
+	}
+	return
+}
+
+// GossipRouterServiceMeshAnnotations returns the Istio annotations of the Gossip Router pods, which receive plain TCP
+// connections from the tunnels of the Infinispan pods
+func GossipRouterServiceMeshAnnotations(i *ispnv1.Infinispan) map[string]string {
+	if !i.IsServiceMeshEnabled() {
+		return nil
+	}
+	return map[string]string{
+		IstioExcludeInboundPortsAnnotation: strconv.Itoa(consts.CrossSitePort),
+	}
+}
+
+func joinPorts(ports []int) string {
+	values := make([]string, len(ports))
+	for idx, port := range ports {
+		values[idx] = strconv.Itoa(port)
+	}
+	return strings.Join(values, ",")
+}
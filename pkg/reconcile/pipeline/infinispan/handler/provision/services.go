@@ -9,248 +9,90 @@ import (
 	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
 	routev1 "github.com/openshift/api/route/v1"
 	corev1 "k8s.io/api/core/v1"
-	ingressv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func PingService(i *ispnv1.Infinispan, ctx pipeline.Context) {
-	svc := newService(i, i.GetPingServiceName())
-
-	mutateFn := func() error {
-		svc.Annotations = i.ServiceAnnotations()
-		svc.Labels = i.ServiceLabels("infinispan-service-ping")
-		svc.Spec.Type = corev1.ServiceTypeClusterIP
-		svc.Spec.ClusterIP = corev1.ClusterIPNone
-		svc.Spec.Selector = i.ServiceSelectorLabels()
-		// We must utilise the existing ServicePort values if updating the service, to prevent the created ports being overwritten
-		if svc.CreationTimestamp.IsZero() {
-			svc.Spec.Ports = []corev1.ServicePort{{}}
-		}
-		servicePort := &svc.Spec.Ports[0]
-		servicePort.Name = consts.InfinispanPingPortName
-		servicePort.Port = consts.InfinispanPingPort
-		return nil
-	}
-	_, _ = ctx.Resources().CreateOrUpdate(svc, true, mutateFn, pipeline.RetryOnErr)
+	createOrUpdateService(i, i.GetPingServiceName(), ApplyPingService, ctx)
 }
 
-func ClusterService(i *ispnv1.Infinispan, ctx pipeline.Context) {
-	svc := newService(i, i.GetServiceName())
-	mutateFn := func() error {
-		svc.Annotations = i.ServiceAnnotations()
-		svc.Labels = i.ServiceLabels("infinispan-service")
-		svc.Spec.Type = corev1.ServiceTypeClusterIP
-		svc.Spec.Selector = i.ServiceSelectorLabels()
-		// We must utilise the existing ServicePort values if updating the service, to prevent the created ports being overwritten
-		if svc.CreationTimestamp.IsZero() {
-			svc.Spec.Ports = []corev1.ServicePort{{}}
-		}
-		servicePort := &svc.Spec.Ports[0]
-		servicePort.Name = consts.InfinispanUserPortName
-		servicePort.Port = consts.InfinispanUserPort
-
-		if i.IsEncryptionCertFromService() {
-			if strings.Contains(i.Spec.Security.EndpointEncryption.CertServiceName, "openshift.io") {
-				// Using platform service. Only OpenShift is integrated atm
-				secretName := i.GetKeystoreSecretName()
-				svc.Annotations[i.Spec.Security.EndpointEncryption.CertServiceName+"/serving-cert-secret-name"] = secretName
-			}
-		}
-		return nil
+// ApplyPingService sets the state desired by the operator on the ping Service
+func ApplyPingService(i *ispnv1.Infinispan, svc *corev1.Service) {
+	svc.Annotations = i.InternalServiceAnnotations()
+	svc.Labels = i.InternalServiceLabels("infinispan-service-ping")
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	svc.Spec.ClusterIP = corev1.ClusterIPNone
+	svc.Spec.Selector = i.ServiceSelectorLabels()
+	svc.Spec.PublishNotReadyAddresses = i.IsPublishNotReadyAddresses()
+	// We must utilise the existing ServicePort values if updating the service, to prevent the created ports being overwritten
+	if svc.CreationTimestamp.IsZero() {
+		svc.Spec.Ports = []corev1.ServicePort{{}}
 	}
-	_, _ = ctx.Resources().CreateOrUpdate(svc, true, mutateFn, pipeline.RetryOnErr)
+	servicePort := &svc.Spec.Ports[0]
+	servicePort.Name = consts.InfinispanPingPortName
+	servicePort.Port = consts.InfinispanPingPort
 }
 
-func AdminService(i *ispnv1.Infinispan, ctx pipeline.Context) {
-	svc := newService(i, i.GetAdminServiceName())
-
-	mutateFn := func() error {
-		svc.Annotations = i.ServiceAnnotations()
-		svc.Labels = i.ServiceLabels("infinispan-service-admin")
-
-		svc.Spec.Type = corev1.ServiceTypeClusterIP
-		svc.Spec.Selector = i.ServiceSelectorLabels()
-		// We must utilise the existing ServicePort values if updating the service, to prevent the created ports being overwritten
-		if svc.CreationTimestamp.IsZero() {
-			svc.Spec.Ports = []corev1.ServicePort{{}}
-			// If an upgrade is in progress, we wait for the GracefulShutdown to destroy the old admin service before
-			// defining a new one with ClusterIpNone
-			svc.Spec.ClusterIP = corev1.ClusterIPNone
-		}
-		servicePort := &svc.Spec.Ports[0]
-		servicePort.Name = consts.InfinispanAdminPortName
-		servicePort.Port = consts.InfinispanAdminPort
-		return nil
-	}
-	_, _ = ctx.Resources().CreateOrUpdate(svc, true, mutateFn, pipeline.RetryOnErr)
+func ClusterService(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	createOrUpdateService(i, i.GetServiceName(), ApplyClusterService, ctx)
 }
 
-func ExternalService(i *ispnv1.Infinispan, ctx pipeline.Context) {
-	if !i.IsExposed() {
-		return
-	}
-
-	// If expose type has changed, ensure that we remove all existing expose definitions
-	exposeType := i.GetExposeType()
-	for _, gvk := range pipeline.ServiceTypes {
-		if ctx.IsTypeSupported(gvk) && gvk.Kind != string(exposeType) {
-			labels := i.ExternalServiceSelectorLabels()
-			switch gvk {
-			case pipeline.ServiceGVK:
-				serviceList := &corev1.ServiceList{}
-				if err := ctx.Resources().List(labels, serviceList); err != nil {
-					ctx.Log().Error(err, "unable to list Services for deletion")
-				}
-				for _, svc := range serviceList.Items {
-					if err := ctx.Resources().Delete(svc.Name, &svc, pipeline.RetryOnErr); err != nil {
-						return
-					}
-				}
-			case pipeline.RouteGVK:
-				routeList := &routev1.RouteList{}
-				if err := ctx.Resources().List(labels, routeList); err != nil {
-					ctx.Log().Error(err, "unable to list Routes for deletion")
-				}
-				for _, route := range routeList.Items {
-					if err := ctx.Resources().Delete(route.Name, &route, pipeline.RetryOnErr); err != nil {
-						return
-					}
-				}
-			case pipeline.IngressGVK:
-				ingressList := &ingressv1.IngressList{}
-				if err := ctx.Resources().List(labels, ingressList); err != nil {
-					ctx.Log().Error(err, "unable to list Ingress' for deletion")
-				}
-				for _, route := range ingressList.Items {
-					if err := ctx.Resources().Delete(route.Name, &route, pipeline.RetryOnErr); err != nil {
-						return
-					}
-				}
-			}
-		}
+// ApplyClusterService sets the state desired by the operator on the user Service
+func ApplyClusterService(i *ispnv1.Infinispan, svc *corev1.Service) {
+	svc.Annotations = i.InternalServiceAnnotations()
+	svc.Labels = i.InternalServiceLabels("infinispan-service")
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	svc.Spec.Selector = i.ServiceSelectorLabels()
+	// We must utilise the existing ServicePort values if updating the service, to prevent the created ports being overwritten
+	if svc.CreationTimestamp.IsZero() {
+		svc.Spec.Ports = []corev1.ServicePort{{}}
 	}
-
-	switch exposeType {
-	case ispnv1.ExposeTypeLoadBalancer, ispnv1.ExposeTypeNodePort:
-		defineExternalService(i, ctx)
-	case ispnv1.ExposeTypeRoute:
-		if ctx.IsTypeSupported(pipeline.RouteGVK) {
-			defineExternalRoute(i, ctx)
-		} else if ctx.IsTypeSupported(pipeline.IngressGVK) {
-			defineExternalIngress(i, ctx)
-		} else {
-			ctx.Stop(fmt.Errorf("unable to expose cluster with type Route, as no implementations are supported"))
+	svc.Spec.Ports = svc.Spec.Ports[:1]
+	servicePort := &svc.Spec.Ports[0]
+	servicePort.Name = consts.InfinispanUserPortName
+	servicePort.Port = consts.InfinispanUserPort
+	svc.Spec.Ports = append(svc.Spec.Ports, DedicatedEndpointPorts(i)...)
+
+	if i.IsEncryptionCertFromService() {
+		if strings.Contains(i.Spec.Security.EndpointEncryption.CertServiceName, "openshift.io") {
+			// Using platform service. Only OpenShift is integrated atm
+			secretName := i.GetKeystoreSecretName()
+			svc.Annotations[i.Spec.Security.EndpointEncryption.CertServiceName+"/serving-cert-secret-name"] = secretName
 		}
 	}
 }
 
-func defineExternalService(i *ispnv1.Infinispan, ctx pipeline.Context) {
-	externalServiceType := corev1.ServiceType(i.Spec.Expose.Type)
-
-	svc := newService(i, i.GetServiceExternalName())
-	mutateFn := func() error {
-		svc.Annotations = i.ServiceAnnotations()
-		for k, v := range i.Spec.Expose.Annotations {
-			svc.Annotations[k] = v
-		}
-		svc.Labels = i.ExternalServiceLabels()
-		svc.Spec.Type = externalServiceType
-		svc.Spec.Selector = i.ServiceSelectorLabels()
-
-		// We must utilise the existing ServicePort values if updating the service, to prevent the created ports being overwritten
-		if svc.CreationTimestamp.IsZero() {
-			svc.Spec.Ports = []corev1.ServicePort{{}}
-		}
-		servicePort := &svc.Spec.Ports[0]
-		servicePort.Port = int32(consts.InfinispanUserPort)
-		servicePort.TargetPort = intstr.FromInt(consts.InfinispanUserPort)
-
-		exposeConf := i.Spec.Expose
-		if exposeConf.NodePort > 0 && exposeConf.Type == ispnv1.ExposeTypeNodePort {
-			servicePort.NodePort = exposeConf.NodePort
-		}
-		if exposeConf.Port > 0 && exposeConf.Type == ispnv1.ExposeTypeLoadBalancer {
-			servicePort.Port = exposeConf.Port
-		}
-		return nil
-	}
-	_, _ = ctx.Resources().CreateOrUpdate(svc, true, mutateFn, pipeline.RetryOnErr)
-}
-
-func defineExternalRoute(i *ispnv1.Infinispan, ctx pipeline.Context) {
-	route := newRoute(i, i.GetServiceExternalName())
-	mutateFn := func() error {
-		route.Annotations = i.ServiceAnnotations()
-		route.Labels = i.ExternalServiceLabels()
-		route.Spec.Host = i.Spec.Expose.Host
-		route.Spec.Port = &routev1.RoutePort{
-			TargetPort: intstr.FromInt(consts.InfinispanUserPort),
-		}
-		route.Spec.To = routev1.RouteTargetReference{
-			Kind: "Service",
-			Name: i.Name,
-		}
-
-		if i.IsEncryptionEnabled() {
-			route.Spec.TLS = &routev1.TLSConfig{Termination: routev1.TLSTerminationPassthrough}
-		}
-		return nil
-	}
-	_, _ = ctx.Resources().CreateOrUpdate(route, true, mutateFn, pipeline.RetryOnErr)
+func AdminService(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	createOrUpdateService(i, i.GetAdminServiceName(), ApplyAdminService, ctx)
 }
 
-func defineExternalIngress(i *ispnv1.Infinispan, ctx pipeline.Context) {
-	pathTypePrefix := ingressv1.PathTypePrefix
-
-	ingress := &ingressv1.Ingress{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: "networking.k8s.io/v1",
-			Kind:       "Ingress",
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      i.GetServiceExternalName(),
-			Namespace: i.Namespace,
-		},
-	}
-
-	mutateFn := func() error {
-		ingress.Annotations = i.ServiceAnnotations()
-		ingress.Labels = i.ExternalServiceLabels()
-		ingress.Spec.Rules = []ingressv1.IngressRule{
-			{
-				Host: i.Spec.Expose.Host,
-				IngressRuleValue: ingressv1.IngressRuleValue{
-					HTTP: &ingressv1.HTTPIngressRuleValue{
-						Paths: []ingressv1.HTTPIngressPath{
-							{
-								PathType: &pathTypePrefix,
-								Path:     "/",
-								Backend: ingressv1.IngressBackend{
-									Service: &ingressv1.IngressServiceBackend{
-										Name: i.Name,
-										Port: ingressv1.ServiceBackendPort{Number: consts.InfinispanUserPort},
-									},
-								}}},
-					},
-				},
-			},
-		}
-
-		if i.IsEncryptionEnabled() {
-			ingress.Spec.TLS = []ingressv1.IngressTLS{
-				{
-					Hosts: []string{i.Spec.Expose.Host},
-				},
-			}
-		}
-		return nil
+// ApplyAdminService sets the state desired by the operator on the admin Service
+func ApplyAdminService(i *ispnv1.Infinispan, svc *corev1.Service) {
+	svc.Annotations = i.InternalServiceAnnotations()
+	svc.Labels = i.InternalServiceLabels("infinispan-service-admin")
+
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	svc.Spec.Selector = i.ServiceSelectorLabels()
+	// We must utilise the existing ServicePort values if updating the service, to prevent the created ports being overwritten
+	if svc.CreationTimestamp.IsZero() {
+		svc.Spec.Ports = []corev1.ServicePort{{}}
+		// If an upgrade is in progress, we wait for the GracefulShutdown to destroy the old admin service before
+		// defining a new one with ClusterIpNone
+		svc.Spec.ClusterIP = corev1.ClusterIPNone
 	}
-	_, _ = ctx.Resources().CreateOrUpdate(ingress, true, mutateFn, pipeline.RetryOnErr)
+	servicePort := &svc.Spec.Ports[0]
+	servicePort.Name = consts.InfinispanAdminPortName
+	servicePort.Port = consts.InfinispanAdminPort
 }
 
 func XSiteService(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	if !i.HasSites() {
+		if err := removeSiteServiceExport(i, ctx); err != nil {
+			return
+		}
 		_ = ctx.Resources().Delete(i.GetSiteServiceName(), &corev1.Service{}, pipeline.RetryOnErr)
 		return
 	}
@@ -258,7 +100,7 @@ func XSiteService(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	exposeConf := i.Spec.Service.Sites.Local.Expose
 	exposeType := i.GetCrossSiteExposeType()
 	var svcType corev1.ServiceType
-	if exposeType == ispnv1.CrossSiteExposeTypeRoute {
+	if exposeType == ispnv1.CrossSiteExposeTypeRoute || exposeType == ispnv1.CrossSiteExposeTypeClusterSet {
 		svcType = corev1.ServiceTypeClusterIP
 	} else {
 		svcType = corev1.ServiceType(exposeType)
@@ -270,6 +112,14 @@ func XSiteService(i *ispnv1.Infinispan, ctx pipeline.Context) {
 			return
 		}
 	}
+	if exposeType == ispnv1.CrossSiteExposeTypeClusterSet {
+		if !ctx.IsTypeSupported(pipeline.ServiceExportGVK) {
+			ctx.Stop(fmt.Errorf("ClusterSet cross-site expose type is not supported, as the multi-cluster ServiceExport API is not installed"))
+			return
+		}
+	} else if err := removeSiteServiceExport(i, ctx); err != nil {
+		return
+	}
 
 	var annotations map[string]string
 	if exposeConf.Annotations != nil && len(exposeConf.Annotations) > 0 {
@@ -297,6 +147,9 @@ func XSiteService(i *ispnv1.Infinispan, ctx pipeline.Context) {
 			servicePort.Port = consts.CrossSitePort
 		}
 		servicePort.TargetPort = intstr.IntOrString{IntVal: consts.CrossSitePort}
+		if exposeType == ispnv1.CrossSiteExposeTypeNodePort && exposeConf.NodePort > 0 {
+			servicePort.NodePort = exposeConf.NodePort
+		}
 		return nil
 	}
 
@@ -325,6 +178,98 @@ func XSiteService(i *ispnv1.Infinispan, ctx pipeline.Context) {
 		}
 		_, _ = ctx.Resources().CreateOrUpdate(route, true, mutateFn, pipeline.RetryOnErr)
 	}
+
+	if exposeType == ispnv1.CrossSiteExposeTypeClusterSet {
+		// Export the service to the ClusterSet, so that the remote sites resolve it as <service>.<namespace>.svc.clusterset.local
+		serviceExport := newSiteServiceExport(i)
+		mutateFn = func() error {
+			serviceExport.SetLabels(i.ServiceLabels("infinispan-service-xsite"))
+			return nil
+		}
+		_, _ = ctx.Resources().CreateOrUpdate(serviceExport, true, mutateFn, pipeline.RetryOnErr)
+	}
+}
+
+// removeSiteServiceExport deletes the ServiceExport of the cross-site service, if the multi-cluster API is installed,
+// so that the service is no longer exported once the ClusterSet expose type is no longer used
+func removeSiteServiceExport(i *ispnv1.Infinispan, ctx pipeline.Context) error {
+	if !ctx.IsTypeSupported(pipeline.ServiceExportGVK) {
+		return nil
+	}
+	return ctx.Resources().Delete(i.GetSiteServiceName(), newSiteServiceExport(i), pipeline.RetryOnErr)
+}
+
+// newSiteServiceExport returns the ServiceExport of the cross-site service. The ServiceExport is managed as an
+// unstructured object so that the multi-cluster Services CRDs are only required when the ClusterSet expose type is used
+func newSiteServiceExport(i *ispnv1.Infinispan) *unstructured.Unstructured {
+	serviceExport := &unstructured.Unstructured{}
+	serviceExport.SetGroupVersionKind(pipeline.ServiceExportGVK)
+	serviceExport.SetName(i.GetSiteServiceName())
+	serviceExport.SetNamespace(i.Namespace)
+	return serviceExport
+}
+
+// createOrUpdateService creates or updates a Service with the state desired by the apply function and the IP family
+// fields of the cluster. The IP family fields are unknown to the Kubernetes API version of the operator, so the Service
+// is managed as an unstructured object, which keeps them, and the other fields added by later API versions, on update
+func createOrUpdateService(i *ispnv1.Infinispan, name string, apply func(*ispnv1.Infinispan, *corev1.Service), ctx pipeline.Context) {
+	svc := &unstructured.Unstructured{}
+	svc.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Service"))
+	svc.SetName(name)
+	svc.SetNamespace(i.Namespace)
+	mutateFn := func() error {
+		return ApplyService(i, svc, apply)
+	}
+	_, _ = ctx.Resources().CreateOrUpdate(svc, true, mutateFn, pipeline.RetryOnErr)
+}
+
+// ApplyService applies the state desired by the apply function to the spec, labels and annotations of the unstructured
+// Service and sets its IP family policy. The IP families are only set when the Service is created, as the primary IP
+// family of a Service is immutable and Kubernetes adds or removes the secondary family when the policy changes
+func ApplyService(i *ispnv1.Infinispan, svc *unstructured.Unstructured, apply func(*ispnv1.Infinispan, *corev1.Service)) error {
+	typed := &corev1.Service{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(svc.Object, typed); err != nil {
+		return err
+	}
+	current, err := runtime.DefaultUnstructuredConverter.ToUnstructured(typed)
+	if err != nil {
+		return err
+	}
+	apply(i, typed)
+	desired, err := runtime.DefaultUnstructuredConverter.ToUnstructured(typed)
+	if err != nil {
+		return err
+	}
+
+	spec, _, err := unstructured.NestedMap(svc.Object, "spec")
+	if err != nil {
+		return err
+	}
+	if spec == nil {
+		spec = map[string]interface{}{}
+	}
+	// Only the fields of the typed Service are replaced, the fields unknown to the typed Service are kept
+	if currentSpec, ok := current["spec"].(map[string]interface{}); ok {
+		for field := range currentSpec {
+			delete(spec, field)
+		}
+	}
+	if desiredSpec, ok := desired["spec"].(map[string]interface{}); ok {
+		for field, value := range desiredSpec {
+			spec[field] = value
+		}
+	}
+	spec["ipFamilyPolicy"] = string(i.GetIPFamilyPolicy())
+	if typed.CreationTimestamp.IsZero() {
+		var families []interface{}
+		for _, family := range i.GetServiceIPFamilies() {
+			families = append(families, string(family))
+		}
+		spec["ipFamilies"] = families
+	}
+	svc.SetLabels(typed.Labels)
+	svc.SetAnnotations(typed.Annotations)
+	return unstructured.SetNestedMap(svc.Object, spec, "spec")
 }
 
 func newService(i *ispnv1.Infinispan, name string) *corev1.Service {
@@ -0,0 +1,132 @@
+package provision
+
+import (
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// createdService returns the typed Service of a Service created by the handlers as an unstructured object
+func createdService(t *testing.T, obj client.Object) *corev1.Service {
+	svc := &corev1.Service{}
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).Object, svc))
+	return svc
+}
+
+func TestServiceIPFamilies(t *testing.T) {
+	i := testInfinispan()
+	ctx := newTestContext()
+	for _, handler := range []func(*ispnv1.Infinispan, pipeline.Context){PingService, ClusterService, AdminService} {
+		handler(i, ctx)
+	}
+	require.NoError(t, ctx.err)
+	require.Len(t, ctx.resources.created, 3)
+	for _, obj := range ctx.resources.created {
+		svc := obj.(*unstructured.Unstructured)
+		policy, _, _ := unstructured.NestedString(svc.Object, "spec", "ipFamilyPolicy")
+		assert.Equal(t, "PreferDualStack", policy, svc.GetName())
+		families, _, _ := unstructured.NestedStringSlice(svc.Object, "spec", "ipFamilies")
+		assert.Equal(t, []string{"IPv4"}, families, svc.GetName())
+		assert.Equal(t, corev1.ServiceTypeClusterIP, createdService(t, svc).Spec.Type)
+	}
+	assert.Equal(t, corev1.ClusterIPNone, createdService(t, ctx.resources.created[0]).Spec.ClusterIP)
+
+	// The ipFamily of the cluster is the primary IP family of the Services
+	i.Spec.IPFamily = corev1.IPv6Protocol
+	i.Spec.IPFamilyPolicy = ispnv1.IPFamilyPolicyRequireDualStack
+	i.Spec.Expose = &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeNodePort}
+	ctx = newTestContext()
+	serviceExposeProvider{corev1.ServiceTypeNodePort}.Reconcile(i, ctx)
+	require.Len(t, ctx.resources.created, 1)
+	svc := ctx.resources.created[0].(*unstructured.Unstructured)
+	policy, _, _ := unstructured.NestedString(svc.Object, "spec", "ipFamilyPolicy")
+	assert.Equal(t, "RequireDualStack", policy)
+	families, _, _ := unstructured.NestedStringSlice(svc.Object, "spec", "ipFamilies")
+	assert.Equal(t, []string{"IPv6", "IPv4"}, families)
+
+	// Updates change the policy and keep the IP families, cluster IPs and other fields that the typed Service doesn't
+	// define, as the primary IP family is immutable and Kubernetes allocates the cluster IP of the secondary family
+	existing := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata": map[string]interface{}{
+			"name":              i.GetServiceName(),
+			"namespace":         i.Namespace,
+			"creationTimestamp": metav1.Now().UTC().Format("2006-01-02T15:04:05Z"),
+		},
+		"spec": map[string]interface{}{
+			"type":                  "ClusterIP",
+			"clusterIP":             "10.96.0.10",
+			"clusterIPs":            []interface{}{"10.96.0.10", "fd00::10"},
+			"ipFamilies":            []interface{}{"IPv4", "IPv6"},
+			"ipFamilyPolicy":        "RequireDualStack",
+			"internalTrafficPolicy": "Cluster",
+			"sessionAffinity":       "ClientIP",
+			"ports":                 []interface{}{map[string]interface{}{"name": "infinispan", "port": int64(11222), "protocol": "TCP", "targetPort": int64(11222)}},
+		},
+	}}
+	i.Spec.IPFamilyPolicy = ispnv1.IPFamilyPolicySingleStack
+	require.NoError(t, ApplyService(i, existing, ApplyClusterService))
+	policy, _, _ = unstructured.NestedString(existing.Object, "spec", "ipFamilyPolicy")
+	assert.Equal(t, "SingleStack", policy)
+	families, _, _ = unstructured.NestedStringSlice(existing.Object, "spec", "ipFamilies")
+	assert.Equal(t, []string{"IPv4", "IPv6"}, families)
+	clusterIPs, _, _ := unstructured.NestedStringSlice(existing.Object, "spec", "clusterIPs")
+	assert.Equal(t, []string{"10.96.0.10", "fd00::10"}, clusterIPs)
+	internalTrafficPolicy, _, _ := unstructured.NestedString(existing.Object, "spec", "internalTrafficPolicy")
+	assert.Equal(t, "Cluster", internalTrafficPolicy)
+	updated := createdService(t, existing)
+	assert.Equal(t, "10.96.0.10", updated.Spec.ClusterIP)
+	assert.Equal(t, i.ServiceSelectorLabels(), updated.Spec.Selector)
+	assert.Equal(t, i.InternalServiceLabels("infinispan-service"), updated.Labels)
+	require.Len(t, updated.Spec.Ports, 1)
+	assert.Equal(t, corev1.ProtocolTCP, updated.Spec.Ports[0].Protocol)
+	// The fields of the typed Service that the operator doesn't set are kept
+	assert.Equal(t, corev1.ServiceAffinityClientIP, updated.Spec.SessionAffinity)
+}
+
+func TestXSiteServiceClusterSet(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.Service.Type = ispnv1.ServiceTypeDataGrid
+	i.Spec.Service.Sites = &ispnv1.InfinispanSitesSpec{
+		Local: ispnv1.InfinispanSitesLocalSpec{
+			Name:   "SiteA",
+			Expose: ispnv1.CrossSiteExposeSpec{Type: ispnv1.CrossSiteExposeTypeClusterSet},
+		},
+	}
+
+	ctx := newTestContext()
+	ctx.supportedTypes = []schema.GroupVersionKind{pipeline.ServiceExportGVK}
+	XSiteService(i, ctx)
+	require.NoError(t, ctx.err)
+	require.Len(t, ctx.resources.created, 2)
+	assert.Equal(t, corev1.ServiceTypeClusterIP, ctx.resources.created[0].(*corev1.Service).Spec.Type)
+	serviceExport := ctx.resources.created[1].(*unstructured.Unstructured)
+	assert.Equal(t, pipeline.ServiceExportGVK, serviceExport.GroupVersionKind())
+	assert.Equal(t, i.GetSiteServiceName(), serviceExport.GetName())
+	assert.Empty(t, ctx.resources.deleted)
+
+	// The ServiceExport is removed once the site is exposed with another type
+	i.Spec.Service.Sites.Local.Expose.Type = ispnv1.CrossSiteExposeTypeLoadBalancer
+	ctx = newTestContext()
+	ctx.supportedTypes = []schema.GroupVersionKind{pipeline.ServiceExportGVK}
+	XSiteService(i, ctx)
+	require.NoError(t, ctx.err)
+	require.Len(t, ctx.resources.created, 1)
+	assert.Equal(t, []string{i.GetSiteServiceName()}, ctx.resources.deleted)
+
+	// The multi-cluster Services API is only required with the ClusterSet expose type
+	ctx = newTestContext()
+	XSiteService(i, ctx)
+	require.NoError(t, ctx.err)
+	assert.Empty(t, ctx.resources.deleted)
+}
@@ -22,8 +22,6 @@ import (
 const (
 	InfinispanContainer          = "infinispan"
 	GossipRouterContainer        = "gossiprouter"
-	DataMountPath                = consts.ServerRoot + "/data"
-	OperatorConfMountPath        = consts.ServerRoot + "/conf/operator"
 	DataMountVolume              = "data-volume"
 	ConfigVolumeName             = "config-volume"
 	EncryptKeystoreVolumeName    = "encrypt-volume"
@@ -31,7 +29,6 @@ const (
 	IdentitiesVolumeName         = "identities-volume"
 	UserConfVolumeName           = "user-conf-volume"
 	InfinispanSecurityVolumeName = "infinispan-security-volume"
-	OverlayConfigMountPath       = consts.ServerRoot + "/conf/user"
 
 	EventReasonEphemeralStorage = "EphemeralStorageEnables"
 
@@ -40,6 +37,31 @@ const (
 	SiteTruststoreVolumeName        = "encrypt-truststore-site-tls-volume"
 )
 
+// CredentialsHashEnv restarts the servers when the secrets of spec.security.credentials change
+const CredentialsHashEnv = "CREDENTIALS_HASH"
+
+// PasswordAlgorithmsEnv restarts the servers when spec.security.passwordAlgorithms changes, so that the passwords are
+// hashed again when the users are created on startup
+const PasswordAlgorithmsEnv = "PASSWORD_ALGORITHMS"
+
+// SiteTLSHashEnv restarts the servers and the Gossip Router when the cross-site keystores change
+const SiteTLSHashEnv = "SITE_TLS_HASH"
+
+// DataMountPath returns the path of the data volume in the Infinispan container
+func DataMountPath(i *ispnv1.Infinispan) string {
+	return i.ServerRoot() + "/data"
+}
+
+// OperatorConfMountPath returns the path of the operator generated configuration in the Infinispan container
+func OperatorConfMountPath(i *ispnv1.Infinispan) string {
+	return i.ServerRoot() + "/conf/operator"
+}
+
+// OverlayConfigMountPath returns the path of the user provided configuration in the Infinispan container
+func OverlayConfigMountPath(i *ispnv1.Infinispan) string {
+	return i.ServerRoot() + "/conf/user"
+}
+
 func ClusterStatefulSet(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	// If StatefulSet already exists, continue to the next handler in the pipeline
 	if err := ctx.Resources().Load(i.GetStatefulSetName(), &appsv1.StatefulSet{}); err == nil {
@@ -49,12 +71,36 @@ func ClusterStatefulSet(i *ispnv1.Infinispan, ctx pipeline.Context) {
 		return
 	}
 
+	statefulSet, err := NewStatefulSet(i, ctx)
+	if err != nil {
+		ctx.Requeue(err)
+		return
+	}
+	if err := ctx.Resources().Create(statefulSet, true, pipeline.RetryOnErr); err != nil {
+		return
+	}
+
+	_ = ctx.UpdateInfinispan(func() {
+		i.Status.StatefulSetName = statefulSet.Name
+	})
+}
+
+// NewStatefulSet returns the StatefulSet of the cluster with the state desired by the operator
+func NewStatefulSet(i *ispnv1.Infinispan, ctx pipeline.Context) (*appsv1.StatefulSet, error) {
 	labelsForPod := i.PodLabels()
 	labelsForPod[consts.StatefulSetPodLabel] = i.Name
 
 	annotationsForPod := i.PodAnnotations()
 	annotationsForPod["updateDate"] = time.Now().String()
 
+	annotations := make(map[string]string, len(consts.DeploymentAnnotations)+1)
+	for k, v := range consts.DeploymentAnnotations {
+		annotations[k] = v
+	}
+	if envNames := ContainerEnvNames(i); envNames != "" {
+		annotations[consts.AnnotationContainerEnv] = envNames
+	}
+
 	// We can ignore the err here as the validating webhook ensures that the resources are valid
 	podResources, _ := PodResources(i.Spec.Container)
 	configFiles := ctx.ConfigFiles()
@@ -67,7 +113,7 @@ func ClusterStatefulSet(i *ispnv1.Infinispan, ctx pipeline.Context) {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        i.GetStatefulSetName(),
 			Namespace:   i.Namespace,
-			Annotations: consts.DeploymentAnnotations,
+			Annotations: annotations,
 			Labels:      map[string]string{},
 		},
 		Spec: appsv1.StatefulSetSpec{
@@ -82,30 +128,37 @@ func ClusterStatefulSet(i *ispnv1.Infinispan, ctx pipeline.Context) {
 					Annotations: annotationsForPod,
 				},
 				Spec: corev1.PodSpec{
-					Affinity: i.Spec.Affinity,
+					Affinity:                      i.Spec.Affinity,
+					ImagePullSecrets:              i.Spec.ImagePullSecrets,
+					ServiceAccountName:            i.GetPodServiceAccountName(),
+					AutomountServiceAccountToken:  i.Spec.AutomountServiceAccountToken,
+					SecurityContext:               i.Spec.Security.PodSecurityContext,
+					TerminationGracePeriodSeconds: pointer.Int64Ptr(i.TerminationGracePeriodSeconds()),
 					Containers: []corev1.Container{{
-						Image: i.ImageName(),
-						Args:  BuildServerContainerArgs(ctx.ConfigFiles().UserConfig),
-						Name:  InfinispanContainer,
+						Image:           i.ImageName(),
+						ImagePullPolicy: i.ImagePullPolicy(),
+						SecurityContext: i.Spec.Security.ContainerSecurityContext,
+						Args:            BuildServerContainerArgs(i, ctx.ConfigFiles().UserConfig),
+						Name:            InfinispanContainer,
 						Env: PodEnv(i, &[]corev1.EnvVar{
 							{Name: "CONFIG_HASH", Value: hash.HashString(configFiles.ServerConfig)},
 							{Name: "ADMIN_IDENTITIES_HASH", Value: hash.HashByte(configFiles.AdminIdentities.IdentitiesFile)},
 							{Name: "IDENTITIES_BATCH", Value: consts.ServerOperatorSecurity + "/" + consts.ServerIdentitiesBatchFilename},
 						}),
-						LivenessProbe:  PodLivenessProbe(),
+						LivenessProbe:  PodLivenessProbe(i),
 						Ports:          PodPortsWithXsite(i),
 						ReadinessProbe: PodReadinessProbe(),
 						StartupProbe:   PodStartupProbe(),
 						Resources:      *podResources,
 						VolumeMounts: []corev1.VolumeMount{{
 							Name:      ConfigVolumeName,
-							MountPath: OperatorConfMountPath,
+							MountPath: OperatorConfMountPath(i),
 						}, {
 							Name:      InfinispanSecurityVolumeName,
 							MountPath: consts.ServerOperatorSecurity,
 						}, {
 							Name:      DataMountVolume,
-							MountPath: DataMountPath,
+							MountPath: DataMountPath(i),
 						}},
 					}},
 					Volumes: []corev1.Volume{{
@@ -130,40 +183,96 @@ func ClusterStatefulSet(i *ispnv1.Infinispan, ctx pipeline.Context) {
 	}
 
 	if err := addDataMountVolume(ctx, i, statefulSet); err != nil {
-		ctx.Requeue(err)
-		return
+		return nil, err
 	}
 
 	container := kube.GetContainer(InfinispanContainer, &statefulSet.Spec.Template.Spec)
 	if _, err := ApplyExternalArtifactsDownload(i, container, &statefulSet.Spec.Template.Spec); err != nil {
-		ctx.Requeue(err)
-		return
+		return nil, err
 	}
 	ApplyExternalDependenciesVolume(i, &container.VolumeMounts, &statefulSet.Spec.Template.Spec)
+	ApplyJmx(i, container, &statefulSet.Spec.Template.Spec)
+	ApplyEndpointPorts(i, container)
+	ApplyServiceMesh(i, &statefulSet.Spec.Template)
+	ApplyCapacityFactor(i, container, &statefulSet.Spec.Template.Spec)
+	ApplyExternalAddress(i, container, &statefulSet.Spec.Template.Spec)
+	ApplyKerberos(i, container, &statefulSet.Spec.Template.Spec)
+	ApplyVault(i, container, &statefulSet.Spec.Template)
+	if image, err := TokenReviewImage(i, ctx); err != nil {
+		return nil, fmt.Errorf("unable to determine the image of the token review sidecar: %w", err)
+	} else {
+		ApplyTokenReview(i, image, &statefulSet.Spec.Template.Spec)
+	}
 
-	addUserIdentities(ctx, i, statefulSet)
+	addUserIdentities(i, statefulSet)
+	addOIDCClient(ctx, i, statefulSet)
+	addKerberosKeytab(ctx, i, statefulSet)
+	addCredentials(ctx, i, statefulSet)
+	addPasswordAlgorithms(i, statefulSet)
 	addUserConfigVolumes(ctx, i, statefulSet)
 	addTLS(ctx, i, statefulSet)
 	addXSiteTLS(ctx, i, statefulSet)
+	return statefulSet, nil
+}
 
-	if err := ctx.Resources().Create(statefulSet, true, pipeline.RetryOnErr); err != nil {
-		return
+func addUserIdentities(i *ispnv1.Infinispan, statefulset *appsv1.StatefulSet) {
+	// Only append the secret volume if authentication is enabled. Changed identities are applied to the running servers
+	// with manage.IdentitiesRotation, so they are not part of the pod template
+	AddVolumeForUserAuthentication(i, &statefulset.Spec.Template.Spec)
+}
+
+func addOIDCClient(ctx pipeline.Context, i *ispnv1.Infinispan, statefulset *appsv1.StatefulSet) {
+	// The client secret is added to the credential store on startup, so the servers are restarted when it changes
+	if i.IsOIDCEnabled() {
+		ispnContainer := kube.GetContainer(InfinispanContainer, &statefulset.Spec.Template.Spec)
+		ispnContainer.Env = append(ispnContainer.Env,
+			corev1.EnvVar{
+				Name:  "OIDC_CLIENT_HASH",
+				Value: hash.HashString(ctx.ConfigFiles().OIDC.ClientSecret),
+			})
 	}
+}
 
-	_ = ctx.UpdateInfinispan(func() {
-		i.Status.StatefulSetName = statefulSet.Name
-	})
+func addCredentials(ctx pipeline.Context, i *ispnv1.Infinispan, statefulset *appsv1.StatefulSet) {
+	// The credentials are added to the credential store on startup, so the servers are restarted when they change
+	if i.HasCredentials() {
+		ispnContainer := kube.GetContainer(InfinispanContainer, &statefulset.Spec.Template.Spec)
+		ispnContainer.Env = append(ispnContainer.Env,
+			corev1.EnvVar{
+				Name:  CredentialsHashEnv,
+				Value: CredentialsHash(ctx.ConfigFiles().Credentials),
+			})
+	}
 }
 
-func addUserIdentities(ctx pipeline.Context, i *ispnv1.Infinispan, statefulset *appsv1.StatefulSet) {
-	// Only append IDENTITIES_HASH and secret volume if authentication is enabled
-	spec := &statefulset.Spec.Template.Spec
-	ispnContainer := kube.GetContainer(InfinispanContainer, spec)
-	if AddVolumeForUserAuthentication(i, spec) {
+func addPasswordAlgorithms(i *ispnv1.Infinispan, statefulset *appsv1.StatefulSet) {
+	if algorithms := i.GetPasswordAlgorithms(); algorithms != nil {
+		ispnContainer := kube.GetContainer(InfinispanContainer, &statefulset.Spec.Template.Spec)
 		ispnContainer.Env = append(ispnContainer.Env,
 			corev1.EnvVar{
-				Name:  "IDENTITIES_HASH",
-				Value: hash.HashByte(ctx.ConfigFiles().UserIdentities),
+				Name:  PasswordAlgorithmsEnv,
+				Value: strings.Join(algorithms, ","),
+			})
+	}
+}
+
+// CredentialsHash returns the hash of the secrets added to the credential store with spec.security.credentials
+func CredentialsHash(credentials map[string]string) string {
+	files := make(map[string][]byte, len(credentials))
+	for alias, credential := range credentials {
+		files[alias] = []byte(credential)
+	}
+	return hash.HashMap(files)
+}
+
+func addKerberosKeytab(ctx pipeline.Context, i *ispnv1.Infinispan, statefulset *appsv1.StatefulSet) {
+	// The keytab is only read on startup, so the servers are restarted when it changes
+	if i.IsKerberosEnabled() {
+		ispnContainer := kube.GetContainer(InfinispanContainer, &statefulset.Spec.Template.Spec)
+		ispnContainer.Env = append(ispnContainer.Env,
+			corev1.EnvVar{
+				Name:  "KERBEROS_HASH",
+				Value: ctx.ConfigFiles().KerberosHash,
 			})
 	}
 }
@@ -225,7 +334,7 @@ func addDataMountVolume(ctx pipeline.Context, i *ispnv1.Infinispan, statefulset
 	}
 	statefulset.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{*pvc}
 
-	AddVolumeChmodInitContainer("data-chmod-pv", DataMountVolume, DataMountPath, &statefulset.Spec.Template.Spec)
+	AddVolumeChmodInitContainer("data-chmod-pv", DataMountVolume, DataMountPath(i), &statefulset.Spec.Template.Spec)
 	return nil
 }
 
@@ -248,22 +357,28 @@ func addUserConfigVolumes(ctx pipeline.Context, i *ispnv1.Infinispan, statefulse
 	volumeMounts := &container.VolumeMounts
 	*volumeMounts = append(*volumeMounts, corev1.VolumeMount{
 		Name:      UserConfVolumeName,
-		MountPath: OverlayConfigMountPath,
+		MountPath: OverlayConfigMountPath(i),
 	})
 }
 
-func BuildServerContainerArgs(userConfig pipeline.UserConfig) []string {
+func BuildServerContainerArgs(i *ispnv1.Infinispan, userConfig pipeline.UserConfig) []string {
 	var args strings.Builder
 
 	// Preallocate a buffer to speed up string building (saves code from growing the memory dynamically)
 	args.Grow(110)
 
+	// Check if the user overrides the server root directory
+	if i.Spec.Container.ServerRoot != "" {
+		args.WriteString(" -s ")
+		args.WriteString(i.Spec.Container.ServerRoot)
+	}
+
 	// Check if the user defined a custom log4j config
 	args.WriteString(" -l ")
 	if userConfig.Log4j != "" {
 		args.WriteString("user/log4j.xml")
 	} else {
-		args.WriteString(OperatorConfMountPath)
+		args.WriteString(OperatorConfMountPath(i))
 		args.WriteString("/log4j.xml")
 	}
 
@@ -274,21 +389,31 @@ func BuildServerContainerArgs(userConfig pipeline.UserConfig) []string {
 	}
 	args.WriteString(" -c operator/infinispan.xml")
 
-	return strings.Fields(args.String())
+	// The keystore password of servers that read their credentials from Vault is a system property of server.properties
+	if i.IsVaultEnabled() {
+		args.WriteString(" -P ")
+		args.WriteString(consts.ServerVaultRoot)
+		args.WriteString("/")
+		args.WriteString(consts.VaultServerPropertiesFilename)
+	}
+
+	// Check if the user overrides the server log directory
+	if i.Spec.Container.LogDir != "" {
+		args.WriteString(" -Dinfinispan.server.log.path=")
+		args.WriteString(i.Spec.Container.LogDir)
+	}
+
+	// Extra arguments are appended as is, so that arguments containing whitespace are preserved
+	return append(strings.Fields(args.String()), i.Spec.Container.ExtraArgs...)
 }
 
 func addTLS(ctx pipeline.Context, i *ispnv1.Infinispan, statefulSet *appsv1.StatefulSet) {
 	if i.IsEncryptionEnabled() {
+		// Renewed certificates are reloaded by the running servers with manage.CertificateRotation, so the keystore is
+		// not part of the pod template
 		AddVolumesForEncryption(i, &statefulSet.Spec.Template.Spec)
 		configFiles := ctx.ConfigFiles()
 		ispnContainer := kube.GetContainer(InfinispanContainer, &statefulSet.Spec.Template.Spec)
-		ispnContainer.Env = append(ispnContainer.Env,
-			corev1.EnvVar{
-				Name: "KEYSTORE_HASH",
-				// Compute the hash using both the Pem and P12 file for simplicity. Only one field should be set at anyone time
-				Value: hash.HashByte(configFiles.Keystore.PemFile) + hash.HashByte(configFiles.Keystore.File),
-			})
-
 		if i.IsClientCertEnabled() {
 			ispnContainer.Env = append(ispnContainer.Env,
 				corev1.EnvVar{
@@ -302,9 +427,34 @@ func addTLS(ctx pipeline.Context, i *ispnv1.Infinispan, statefulSet *appsv1.Stat
 func addXSiteTLS(ctx pipeline.Context, i *ispnv1.Infinispan, statefulset *appsv1.StatefulSet) {
 	if i.IsSiteTLSEnabled() {
 		spec := &statefulset.Spec.Template.Spec
+		transport := ctx.ConfigFiles().Transport
 		AddSecretVolume(i.GetSiteTransportSecretName(), SiteTransportKeystoreVolumeName, consts.SiteTransportKeyStoreRoot, spec, InfinispanContainer)
-		if ctx.ConfigFiles().Transport.Truststore != nil {
-			AddSecretVolume(i.GetSiteTrustoreSecretName(), SiteTruststoreVolumeName, consts.SiteTrustStoreRoot, spec, InfinispanContainer)
+		if transport.Truststore != nil {
+			AddSecretVolume(SiteTrustStoreSecretName(i), SiteTruststoreVolumeName, consts.SiteTrustStoreRoot, spec, InfinispanContainer)
 		}
+		// JGroups only reads the keystores on startup, so the servers are restarted when they change
+		ispnContainer := kube.GetContainer(InfinispanContainer, spec)
+		ispnContainer.Env = append(ispnContainer.Env, corev1.EnvVar{
+			Name:  SiteTLSHashEnv,
+			Value: SiteTLSHash(transport.Keystore, transport.Truststore),
+		})
+	}
+}
+
+// SiteTrustStoreSecretName returns the name of the Secret mounted as the truststore of the transport and the Gossip
+// Router, which is the relay truststore assembled by the operator if CA certificates of remote sites are configured
+func SiteTrustStoreSecretName(i *ispnv1.Infinispan) string {
+	if i.HasSiteCAs() {
+		return i.GetSiteRelayTrustStoreSecretName()
+	}
+	return i.GetSiteTrustoreSecretName()
+}
+
+// SiteTLSHash returns the hash of the files of a cross-site keystore and truststore
+func SiteTLSHash(keystore *pipeline.Keystore, truststore *pipeline.Truststore) string {
+	files := map[string][]byte{"keystore": keystore.File}
+	if truststore != nil {
+		files["truststore"] = truststore.File
 	}
+	return hash.HashMap(files)
 }
@@ -0,0 +1,499 @@
+package provision
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// testResources records created and deleted objects and reports every Load as NotFound
+type testResources struct {
+	pipeline.Resources
+	created  []client.Object
+	deleted  []string
+	services []corev1.Service
+	pods     []corev1.Pod
+}
+
+func (r *testResources) Load(name string, _ client.Object, opts ...func(config *pipeline.ResourcesConfig)) error {
+	config := &pipeline.ResourcesConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.IgnoreNotFound {
+		return nil
+	}
+	return errors.NewNotFound(schema.GroupResource{}, name)
+}
+
+func (r *testResources) LoadGlobal(name string, obj client.Object, opts ...func(config *pipeline.ResourcesConfig)) error {
+	return r.Load(name, obj, opts...)
+}
+
+func (r *testResources) Create(obj client.Object, _ bool, _ ...func(config *pipeline.ResourcesConfig)) error {
+	r.created = append(r.created, obj)
+	return nil
+}
+
+func (r *testResources) CreateOrUpdate(obj client.Object, _ bool, mutate func() error, _ ...func(config *pipeline.ResourcesConfig)) (pipeline.OperationResult, error) {
+	if err := mutate(); err != nil {
+		return pipeline.OperationResultNone, err
+	}
+	r.created = append(r.created, obj)
+	return pipeline.OperationResultCreated, nil
+}
+
+func (r *testResources) List(_ map[string]string, list client.ObjectList, _ ...func(config *pipeline.ResourcesConfig)) error {
+	if serviceList, ok := list.(*corev1.ServiceList); ok {
+		serviceList.Items = r.services
+	}
+	if podList, ok := list.(*corev1.PodList); ok {
+		podList.Items = r.pods
+	}
+	return nil
+}
+
+func (r *testResources) Delete(name string, _ client.Object, _ ...func(config *pipeline.ResourcesConfig)) error {
+	r.deleted = append(r.deleted, name)
+	return nil
+}
+
+// testContext implements the subset of pipeline.Context used by the provision handlers
+type testContext struct {
+	pipeline.Context
+	configFiles    *pipeline.ConfigFiles
+	resources      *testResources
+	supportedTypes []schema.GroupVersionKind
+	err            error
+	requeueAfter   time.Duration
+}
+
+func newTestContext() *testContext {
+	return &testContext{
+		configFiles: &pipeline.ConfigFiles{AdminIdentities: &pipeline.AdminIdentities{}},
+		resources:   &testResources{},
+	}
+}
+
+func (c *testContext) ConfigFiles() *pipeline.ConfigFiles   { return c.configFiles }
+func (c *testContext) Resources() pipeline.Resources        { return c.resources }
+func (c *testContext) Log() logr.Logger                     { return ctrl.Log }
+func (c *testContext) EventRecorder() record.EventRecorder  { return record.NewFakeRecorder(10) }
+func (c *testContext) UpdateInfinispan(update func()) error { update(); return nil }
+func (c *testContext) Requeue(reason error)                 { c.err = reason }
+func (c *testContext) Stop(err error)                       { c.err = err }
+
+func (c *testContext) RequeueAfter(delay time.Duration, reason error) {
+	c.requeueAfter = delay
+	c.err = reason
+}
+
+func (c *testContext) IsTypeSupported(gvk schema.GroupVersionKind) bool {
+	for _, t := range c.supportedTypes {
+		if t == gvk {
+			return true
+		}
+	}
+	return false
+}
+
+func testInfinispan() *ispnv1.Infinispan {
+	return &ispnv1.Infinispan{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan", Namespace: "testing-namespace"},
+		Spec: ispnv1.InfinispanSpec{
+			Replicas:  1,
+			Image:     pointer.StringPtr("quay.io/infinispan/server:13.0"),
+			Container: ispnv1.InfinispanContainerSpec{Memory: "1Gi"},
+			Service:   ispnv1.InfinispanServiceSpec{Container: &ispnv1.InfinispanServiceContainerSpec{EphemeralStorage: true}},
+		},
+	}
+}
+
+// clusterStatefulSet executes the ClusterStatefulSet handler and returns the pod spec of the created StatefulSet
+func clusterStatefulSet(t *testing.T, i *ispnv1.Infinispan) *corev1.PodSpec {
+	ctx := newTestContext()
+	ClusterStatefulSet(i, ctx)
+	require.NoError(t, ctx.err)
+	require.Len(t, ctx.resources.created, 1)
+	return &ctx.resources.created[0].(*appsv1.StatefulSet).Spec.Template.Spec
+}
+
+func TestClusterStatefulSetImagePull(t *testing.T) {
+	pullSecrets := []corev1.LocalObjectReference{{Name: "registry-secret"}}
+	testTable := []struct {
+		name           string
+		pullPolicy     corev1.PullPolicy
+		expectedPolicy corev1.PullPolicy
+	}{
+		{"explicit policy", corev1.PullNever, corev1.PullNever},
+		{"default policy", "", corev1.PullIfNotPresent},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			i := testInfinispan()
+			i.Spec.ImagePullSecrets = pullSecrets
+			i.Spec.ImagePullPolicy = tt.pullPolicy
+			i.Spec.Dependencies = &ispnv1.InfinispanExternalDependencies{
+				Artifacts: []ispnv1.InfinispanExternalArtifacts{{Maven: "org.postgresql:postgresql:42.3.1"}},
+			}
+
+			spec := clusterStatefulSet(t, i)
+			assert.Equal(t, pullSecrets, spec.ImagePullSecrets)
+			assert.Equal(t, tt.expectedPolicy, kube.GetContainer(InfinispanContainer, spec).ImagePullPolicy)
+			initContainer := spec.InitContainers[kube.ContainerIndex(spec.InitContainers, ExternalArtifactsDownloadInitContainer)]
+			assert.Equal(t, tt.expectedPolicy, initContainer.ImagePullPolicy)
+		})
+	}
+}
+
+func TestClusterStatefulSetServiceAccount(t *testing.T) {
+	i := testInfinispan()
+	assert.Empty(t, clusterStatefulSet(t, i).ServiceAccountName)
+
+	i.Spec.ServiceAccountName = "workload-identity"
+	assert.Equal(t, "workload-identity", clusterStatefulSet(t, i).ServiceAccountName)
+	assert.Nil(t, clusterStatefulSet(t, i).AutomountServiceAccountToken)
+
+	i.Spec.AutomountServiceAccountToken = pointer.BoolPtr(false)
+	assert.Equal(t, pointer.BoolPtr(false), clusterStatefulSet(t, i).AutomountServiceAccountToken)
+}
+
+func TestClusterStatefulSetTerminationGracePeriod(t *testing.T) {
+	i := testInfinispan()
+	assert.Equal(t, int64(30), *clusterStatefulSet(t, i).TerminationGracePeriodSeconds)
+
+	i.Spec.Container.TerminationGracePeriodSeconds = pointer.Int64Ptr(600)
+	assert.Equal(t, int64(600), *clusterStatefulSet(t, i).TerminationGracePeriodSeconds)
+}
+
+func TestClusterStatefulSetDevModeProbes(t *testing.T) {
+	i := testInfinispan()
+	container := kube.GetContainer(InfinispanContainer, clusterStatefulSet(t, i))
+	assert.NotNil(t, container.LivenessProbe)
+
+	i.Spec.DevMode = true
+	container = kube.GetContainer(InfinispanContainer, clusterStatefulSet(t, i))
+	assert.Nil(t, container.LivenessProbe)
+	assert.NotNil(t, container.ReadinessProbe)
+	assert.NotNil(t, container.StartupProbe)
+}
+
+func TestClusterStatefulSetSecurityContext(t *testing.T) {
+	i := testInfinispan()
+	spec := clusterStatefulSet(t, i)
+	assert.Nil(t, spec.SecurityContext)
+	assert.Nil(t, kube.GetContainer(InfinispanContainer, spec).SecurityContext)
+
+	i.Spec.Security.PodSecurityContext = &corev1.PodSecurityContext{
+		RunAsNonRoot:   pointer.BoolPtr(true),
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+	i.Spec.Security.ContainerSecurityContext = &corev1.SecurityContext{AllowPrivilegeEscalation: pointer.BoolPtr(false)}
+	spec = clusterStatefulSet(t, i)
+	assert.Equal(t, i.Spec.Security.PodSecurityContext, spec.SecurityContext)
+	assert.Equal(t, i.Spec.Security.ContainerSecurityContext, kube.GetContainer(InfinispanContainer, spec).SecurityContext)
+}
+
+func TestClusterStatefulSetServerArgs(t *testing.T) {
+	i := testInfinispan()
+	container := kube.GetContainer(InfinispanContainer, clusterStatefulSet(t, i))
+	assert.Equal(t, []string{"-l", "/opt/infinispan/server/conf/operator/log4j.xml", "-c", "operator/infinispan.xml"}, container.Args)
+
+	i.Spec.Container.ServerRoot = "/data/server"
+	i.Spec.Container.LogDir = "/var/log/infinispan"
+	i.Spec.Container.ExtraArgs = []string{"-P", "/data/server.properties", "-Dcustom.property=a value"}
+	container = kube.GetContainer(InfinispanContainer, clusterStatefulSet(t, i))
+	assert.Equal(t, []string{
+		"-s", "/data/server",
+		"-l", "/data/server/conf/operator/log4j.xml",
+		"-c", "operator/infinispan.xml",
+		"-Dinfinispan.server.log.path=/var/log/infinispan",
+		"-P", "/data/server.properties",
+		"-Dcustom.property=a value",
+	}, container.Args)
+	for _, mount := range container.VolumeMounts {
+		if mount.Name == ConfigVolumeName {
+			assert.Equal(t, "/data/server/conf/operator", mount.MountPath)
+		}
+	}
+}
+
+func TestClusterStatefulSetLocalization(t *testing.T) {
+	i := testInfinispan()
+	env := kube.GetContainer(InfinispanContainer, clusterStatefulSet(t, i)).Env
+	assert.Equal(t, -1, kube.GetEnvVarIndex("TZ", &env))
+	assert.Equal(t, -1, kube.GetEnvVarIndex("LANG", &env))
+
+	t.Setenv("ADDITIONAL_VARS", `["TZ", "LANG"]`)
+	t.Setenv("TZ", "UTC")
+	t.Setenv("LANG", "C.UTF-8")
+	i.Spec.Container.Timezone = "Europe/Rome"
+	env = kube.GetContainer(InfinispanContainer, clusterStatefulSet(t, i)).Env
+	assert.Equal(t, corev1.EnvVar{Name: "TZ", Value: "Europe/Rome"}, env[kube.GetEnvVarIndex("TZ", &env)])
+	assert.Equal(t, corev1.EnvVar{Name: "LANG", Value: "C.UTF-8"}, env[kube.GetEnvVarIndex("LANG", &env)])
+	assert.Equal(t, -1, kube.GetEnvVarIndex("LC_ALL", &env))
+	for _, name := range []string{"TZ", "LANG"} {
+		count := 0
+		for _, e := range env {
+			if e.Name == name {
+				count++
+			}
+		}
+		assert.Equal(t, 1, count, name)
+	}
+}
+
+func TestClusterStatefulSetJmx(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.Service.Type = ispnv1.ServiceTypeDataGrid
+	i.Spec.Jmx = &ispnv1.InfinispanJmxSpec{Enabled: true, SecretName: "jmx-secret"}
+	spec := clusterStatefulSet(t, i)
+	container := kube.GetContainer(InfinispanContainer, spec)
+	assert.Contains(t, container.Ports, corev1.ContainerPort{ContainerPort: consts.InfinispanJmxPort, Name: consts.InfinispanJmxPortName, Protocol: corev1.ProtocolTCP})
+	assert.GreaterOrEqual(t, findVolumeMount(container.VolumeMounts, JmxVolumeName), 0)
+	assert.GreaterOrEqual(t, findVolume(spec.Volumes, JmxVolumeName), 0)
+	env := container.Env
+	assert.Contains(t, env[kube.GetEnvVarIndex("JAVA_OPTIONS", &env)].Value, "-Dcom.sun.management.jmxremote.port=9999")
+
+	initContainer := spec.InitContainers[kube.ContainerIndex(spec.InitContainers, JmxInitContainer)]
+	for _, e := range initContainer.Env {
+		assert.Equal(t, "jmx-secret", e.ValueFrom.SecretKeyRef.Name)
+	}
+
+	// Disabling JMX removes the init container, volume and port
+	i.Spec.Jmx.Enabled = false
+	assert.True(t, ApplyJmx(i, container, spec))
+	assert.Equal(t, -1, kube.ContainerIndex(spec.InitContainers, JmxInitContainer))
+	assert.Equal(t, -1, findVolume(spec.Volumes, JmxVolumeName))
+	assert.Equal(t, -1, findVolumeMount(container.VolumeMounts, JmxVolumeName))
+	assert.Equal(t, -1, findContainerPort(container.Ports, consts.InfinispanJmxPortName))
+	assert.False(t, ApplyJmx(i, container, spec))
+}
+
+func TestClusterStatefulSetEndpointPorts(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.Endpoints = &ispnv1.InfinispanEndpointsSpec{
+		HotRod: &ispnv1.InfinispanEndpointSpec{Port: 11322},
+		Rest:   &ispnv1.InfinispanEndpointSpec{Enabled: pointer.BoolPtr(false), Port: 8080},
+	}
+	spec := clusterStatefulSet(t, i)
+	container := kube.GetContainer(InfinispanContainer, spec)
+	assert.Contains(t, container.Ports, corev1.ContainerPort{ContainerPort: 11322, Name: consts.InfinispanHotRodPortName, Protocol: corev1.ProtocolTCP})
+	// Disabled protocols do not have a port
+	assert.Equal(t, -1, findContainerPort(container.Ports, consts.InfinispanRestPortName))
+	assert.False(t, ApplyEndpointPorts(i, container))
+
+	i.Spec.Endpoints.HotRod.Port = 11422
+	i.Spec.Endpoints.Rest.Enabled = nil
+	assert.True(t, ApplyEndpointPorts(i, container))
+	assert.Contains(t, container.Ports, corev1.ContainerPort{ContainerPort: 11422, Name: consts.InfinispanHotRodPortName, Protocol: corev1.ProtocolTCP})
+	assert.Contains(t, container.Ports, corev1.ContainerPort{ContainerPort: 8080, Name: consts.InfinispanRestPortName, Protocol: corev1.ProtocolTCP})
+
+	// Removing the dedicated ports moves the protocols back to the user port
+	i.Spec.Endpoints = nil
+	assert.True(t, ApplyEndpointPorts(i, container))
+	assert.Equal(t, -1, findContainerPort(container.Ports, consts.InfinispanHotRodPortName))
+	assert.Equal(t, -1, findContainerPort(container.Ports, consts.InfinispanRestPortName))
+}
+
+func TestClusterStatefulSetUserEnv(t *testing.T) {
+	t.Setenv("ADDITIONAL_VARS", `["HTTP_PROXY"]`)
+	t.Setenv("HTTP_PROXY", "http://operator-proxy:3128")
+	i := testInfinispan()
+	i.Spec.Container.Env = []corev1.EnvVar{
+		{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+		{Name: "AGENT_TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "agent"},
+			Key:                  "token",
+		}}},
+	}
+	ctx := newTestContext()
+	ClusterStatefulSet(i, ctx)
+	require.NoError(t, ctx.err)
+	statefulSet := ctx.resources.created[0].(*appsv1.StatefulSet)
+	assert.Equal(t, "HTTP_PROXY,AGENT_TOKEN", statefulSet.Annotations[consts.AnnotationContainerEnv])
+	assert.Equal(t, "Infinispan Cluster", statefulSet.Annotations["openshift.io/display-name"], "deployment annotations are retained")
+	assert.NotContains(t, consts.DeploymentAnnotations, consts.AnnotationContainerEnv)
+
+	env := kube.GetContainer(InfinispanContainer, &statefulSet.Spec.Template.Spec).Env
+	assert.Equal(t, i.Spec.Container.Env[0], env[kube.GetEnvVarIndex("HTTP_PROXY", &env)], "spec.container.env takes precedence over ADDITIONAL_VARS")
+	assert.Equal(t, i.Spec.Container.Env[1], env[kube.GetEnvVarIndex("AGENT_TOKEN", &env)])
+	count := 0
+	for _, e := range env {
+		if e.Name == "HTTP_PROXY" {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+}
+
+func TestClusterStatefulSetServiceMesh(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.ServiceMesh = &ispnv1.ServiceMeshSpec{Enabled: true}
+	ctx := newTestContext()
+	ClusterStatefulSet(i, ctx)
+	require.NoError(t, ctx.err)
+	template := &ctx.resources.created[0].(*appsv1.StatefulSet).Spec.Template
+	assert.Equal(t, "7800,57800,11223", template.Annotations[IstioExcludeInboundPortsAnnotation])
+	assert.Equal(t, "7800,57800", template.Annotations[IstioExcludeOutboundPortsAnnotation])
+	assert.Equal(t, "true", template.Annotations[IstioRewriteProbesAnnotation])
+	assert.False(t, ApplyServiceMesh(i, template))
+
+	// Disabling the service mesh compatibility keeps the annotations configured by the user
+	i.Spec.ServiceMesh.Enabled = false
+	i.Annotations = map[string]string{
+		ispnv1.PodTargetAnnotations:  IstioRewriteProbesAnnotation,
+		IstioRewriteProbesAnnotation: "true",
+	}
+	assert.True(t, ApplyServiceMesh(i, template))
+	assert.NotContains(t, template.Annotations, IstioExcludeInboundPortsAnnotation)
+	assert.NotContains(t, template.Annotations, IstioExcludeOutboundPortsAnnotation)
+	assert.Equal(t, "true", template.Annotations[IstioRewriteProbesAnnotation])
+}
+
+func TestClusterStatefulSetCapacityFactor(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.CapacityFactor = &ispnv1.CapacityFactorSpec{
+		Zones: []ispnv1.ZoneCapacityFactor{{Zone: "zone-a", Factor: "2"}},
+	}
+	spec := clusterStatefulSet(t, i)
+	container := kube.GetContainer(InfinispanContainer, spec)
+	assert.GreaterOrEqual(t, kube.ContainerIndex(spec.InitContainers, CapacityFactorInitContainer), 0)
+	assert.GreaterOrEqual(t, findVolume(spec.Volumes, CapacityFactorVolumeName), 0)
+	env := container.Env
+	factorEnv := env[kube.GetEnvVarIndex(CapacityFactorEnv, &env)]
+	assert.Equal(t, "metadata.annotations['infinispan.org/capacity-factor']", factorEnv.ValueFrom.FieldRef.FieldPath)
+	assert.False(t, ApplyCapacityFactor(i, container, spec))
+
+	// Changing the factors restarts the pods
+	hashIndex := kube.GetEnvVarIndex(capacityFactorHashEnv, &container.Env)
+	specHash := container.Env[hashIndex].Value
+	i.Spec.CapacityFactor.Zones[0].Factor = "1.5"
+	assert.True(t, ApplyCapacityFactor(i, container, spec))
+	assert.NotEqual(t, specHash, container.Env[hashIndex].Value)
+
+	// Removing the factors removes the init container, volume and env
+	i.Spec.CapacityFactor = nil
+	assert.True(t, ApplyCapacityFactor(i, container, spec))
+	assert.Equal(t, -1, kube.ContainerIndex(spec.InitContainers, CapacityFactorInitContainer))
+	assert.Equal(t, -1, findVolume(spec.Volumes, CapacityFactorVolumeName))
+	assert.Equal(t, -1, kube.GetEnvVarIndex(CapacityFactorEnv, &container.Env))
+	assert.Equal(t, -1, kube.GetEnvVarIndex(capacityFactorHashEnv, &container.Env))
+	assert.False(t, ApplyCapacityFactor(i, container, spec))
+}
+
+func TestClusterStatefulSetExternalAddress(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.Expose = &ispnv1.ExposeSpec{Type: ispnv1.ExposeTypeLoadBalancer, PerPod: true}
+	spec := clusterStatefulSet(t, i)
+	container := kube.GetContainer(InfinispanContainer, spec)
+	assert.GreaterOrEqual(t, kube.ContainerIndex(spec.InitContainers, ExternalAddressInitContainer), 0)
+	assert.GreaterOrEqual(t, findVolume(spec.Volumes, ExternalAddressVolumeName), 0)
+	env := container.Env
+	hostEnv := env[kube.GetEnvVarIndex(ExternalHostEnv, &env)]
+	assert.Equal(t, "metadata.annotations['infinispan.org/external-host']", hostEnv.ValueFrom.FieldRef.FieldPath)
+	portEnv := env[kube.GetEnvVarIndex(ExternalPortEnv, &env)]
+	assert.Equal(t, "metadata.annotations['infinispan.org/external-port']", portEnv.ValueFrom.FieldRef.FieldPath)
+	assert.False(t, ApplyExternalAddress(i, container, spec))
+
+	// Per-pod Services are only created for the NodePort and LoadBalancer expose types
+	i.Spec.Expose.Type = ispnv1.ExposeTypeRoute
+	assert.True(t, ApplyExternalAddress(i, container, spec))
+	assert.Equal(t, -1, kube.ContainerIndex(spec.InitContainers, ExternalAddressInitContainer))
+	assert.Equal(t, -1, findVolume(spec.Volumes, ExternalAddressVolumeName))
+	assert.Equal(t, -1, kube.GetEnvVarIndex(ExternalHostEnv, &container.Env))
+	assert.Equal(t, -1, kube.GetEnvVarIndex(ExternalPortEnv, &container.Env))
+	assert.False(t, ApplyExternalAddress(i, container, spec))
+}
+
+func TestClusterStatefulSetKerberos(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.Security.EndpointKerberos = &ispnv1.EndpointKerberos{Principal: "hotrod/host@EXAMPLE.COM", KeytabSecretName: "keytab"}
+	spec := clusterStatefulSet(t, i)
+	container := kube.GetContainer(InfinispanContainer, spec)
+	volumeIndex := findVolume(spec.Volumes, KerberosVolumeName)
+	require.GreaterOrEqual(t, volumeIndex, 0)
+	assert.Equal(t, "keytab", spec.Volumes[volumeIndex].Secret.SecretName)
+	assert.GreaterOrEqual(t, findVolumeMount(container.VolumeMounts, KerberosVolumeName), 0)
+	assert.False(t, ApplyKerberos(i, container, spec))
+
+	// Changing the Secret updates the volume
+	i.Spec.Security.EndpointKerberos.KeytabSecretName = "renewed-keytab"
+	assert.True(t, ApplyKerberos(i, container, spec))
+	assert.Equal(t, "renewed-keytab", spec.Volumes[volumeIndex].Secret.SecretName)
+
+	// Removing Kerberos removes the volume
+	i.Spec.Security.EndpointKerberos = nil
+	assert.True(t, ApplyKerberos(i, container, spec))
+	assert.Equal(t, -1, findVolume(spec.Volumes, KerberosVolumeName))
+	assert.Equal(t, -1, findVolumeMount(container.VolumeMounts, KerberosVolumeName))
+	assert.False(t, ApplyKerberos(i, container, spec))
+}
+
+func TestClusterStatefulSetVault(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.Security.Vault = &ispnv1.Vault{
+		Provider:   ispnv1.VaultProviderAgentInjector,
+		Role:       "infinispan",
+		SecretPath: "secret/data/infinispan",
+	}
+	ctx := newTestContext()
+	ClusterStatefulSet(i, ctx)
+	require.NoError(t, ctx.err)
+	template := &ctx.resources.created[0].(*appsv1.StatefulSet).Spec.Template
+	container := kube.GetContainer(InfinispanContainer, &template.Spec)
+	assert.Equal(t, "true", template.Annotations[VaultAgentInjectAnnotation])
+	assert.Equal(t, "infinispan", template.Annotations[VaultRoleAnnotation])
+	assert.Equal(t, "secret/data/infinispan", template.Annotations[VaultSecretAnnotationPrefix+"users.properties"])
+	assert.Equal(t, `{{- with secret "secret/data/infinispan" -}}{{ .Data.data.users }}{{- end -}}`, template.Annotations[VaultTemplateAnnotationPrefix+"users.properties"])
+	assert.Contains(t, container.Args, "/vault/secrets/server.properties")
+	assert.Equal(t, -1, findVolume(template.Spec.Volumes, IdentitiesVolumeName), "users are not read from the endpoint Secret")
+	assert.Equal(t, -1, findVolume(template.Spec.Volumes, VaultVolumeName))
+	assert.False(t, ApplyVault(i, container, template))
+
+	// Switching to the CSI provider replaces the annotations with a volume
+	i.Spec.Security.Vault = &ispnv1.Vault{Provider: ispnv1.VaultProviderCSI, SecretProviderClass: "infinispan-vault"}
+	assert.True(t, ApplyVault(i, container, template))
+	for _, key := range vaultAnnotations() {
+		assert.NotContains(t, template.Annotations, key)
+	}
+	volumeIndex := findVolume(template.Spec.Volumes, VaultVolumeName)
+	require.GreaterOrEqual(t, volumeIndex, 0)
+	assert.Equal(t, VaultCSIDriver, template.Spec.Volumes[volumeIndex].CSI.Driver)
+	assert.Equal(t, "infinispan-vault", template.Spec.Volumes[volumeIndex].CSI.VolumeAttributes["secretProviderClass"])
+	assert.GreaterOrEqual(t, findVolumeMount(container.VolumeMounts, VaultVolumeName), 0)
+	assert.False(t, ApplyVault(i, container, template))
+
+	// Removing Vault removes the volume
+	i.Spec.Security.Vault = nil
+	assert.True(t, ApplyVault(i, container, template))
+	assert.Equal(t, -1, findVolume(template.Spec.Volumes, VaultVolumeName))
+	assert.Equal(t, -1, findVolumeMount(container.VolumeMounts, VaultVolumeName))
+	assert.False(t, ApplyVault(i, container, template))
+}
+
+func TestSiteTLSHash(t *testing.T) {
+	keystore := &pipeline.Keystore{File: []byte("keystore"), Password: "password"}
+	truststore := &pipeline.Truststore{File: []byte("truststore")}
+	initial := SiteTLSHash(keystore, truststore)
+	assert.Equal(t, initial, SiteTLSHash(keystore, truststore))
+	assert.NotEqual(t, initial, SiteTLSHash(keystore, nil))
+	assert.NotEqual(t, initial, SiteTLSHash(&pipeline.Keystore{File: []byte("renewed")}, truststore))
+	assert.NotEqual(t, initial, SiteTLSHash(keystore, &pipeline.Truststore{File: []byte("renewed")}))
+}
@@ -0,0 +1,296 @@
+package provision
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	pipeline "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	TokenReviewContainer  = "token-review"
+	TokenReviewVolumeName = "token-review"
+	// TokenReviewClusterRole grants the permissions to create TokenReviews and SubjectAccessReviews
+	TokenReviewClusterRole = "system:auth-delegator"
+	// tokenReviewRootCAConfigMap is published in every namespace by the kube-controller-manager and contains the CA
+	// that signs the certificate of the Kubernetes API
+	tokenReviewRootCAConfigMap = "kube-root-ca.crt"
+	// tokenReviewTokenExpiration is the lifetime of the projected token, which the kubelet rotates before it expires
+	tokenReviewTokenExpiration = 3600
+)
+
+// TokenReview creates the ServiceAccount of the pods when spec.serviceAccountName is not set, and the
+// ClusterRoleBinding that allows the token review sidecar to review tokens with the projected token of that
+// ServiceAccount
+func TokenReview(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	if !i.IsServiceAccountAuthEnabled() {
+		removeTokenReview(i, ctx)
+		return
+	}
+
+	// The sidecar of previous operator versions authenticated with a token Secret
+	if err := deleteIfExists(i.GetTokenReviewName(), &corev1.Secret{}, ctx); err != nil {
+		return
+	}
+
+	if i.Spec.ServiceAccountName == "" {
+		// The token is not automounted, so that only the sidecar, which mounts a projected token, is granted access
+		// to the TokenReview API and not the server container
+		sa := &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      i.GetTokenReviewName(),
+				Namespace: i.Namespace,
+				Labels:    i.Labels("infinispan-token-review"),
+			},
+		}
+		if _, err := ctx.Resources().CreateOrUpdate(sa, true, func() error {
+			sa.AutomountServiceAccountToken = pointer.BoolPtr(false)
+			return nil
+		}, pipeline.RetryOnErr); err != nil {
+			return
+		}
+	} else if err := deleteIfExists(i.GetTokenReviewName(), &corev1.ServiceAccount{}, ctx); err != nil {
+		return
+	}
+
+	// A ClusterRoleBinding cannot be owned by the namespaced Infinispan CR, so it is deleted by the controller once the
+	// CR is not found, or by RemoveOrphanedTokenReviewClusterRoleBindings if the operator was not running at the time
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: TokenReviewClusterRoleBindingName(i.Namespace, i.Name),
+		},
+	}
+	_, _ = ctx.Resources().CreateOrUpdate(binding, false, func() error {
+		if binding.Labels == nil {
+			binding.Labels = map[string]string{}
+		}
+		for k, v := range i.Labels("infinispan-token-review") {
+			binding.Labels[k] = v
+		}
+		binding.Labels[consts.LabelTokenReviewNamespace] = i.Namespace
+		binding.RoleRef = rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     TokenReviewClusterRole,
+		}
+		binding.Subjects = []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      i.GetPodServiceAccountName(),
+			Namespace: i.Namespace,
+		}}
+		return nil
+	}, pipeline.RetryOnErr)
+}
+
+// TokenReviewClusterRoleBindingName returns the name of the ClusterRoleBinding of the token review ServiceAccount of an
+// Infinispan cluster, which includes the namespace as ClusterRoleBindings are not namespaced
+func TokenReviewClusterRoleBindingName(namespace, name string) string {
+	return fmt.Sprintf("%s-%s-token-review", namespace, name)
+}
+
+// RemoveTokenReviewClusterRoleBinding deletes the ClusterRoleBinding of a deleted Infinispan cluster, if it exists
+func RemoveTokenReviewClusterRoleBinding(ctx context.Context, c client.Client, namespace, name string) error {
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: TokenReviewClusterRoleBindingName(namespace, name)},
+	}
+	return client.IgnoreNotFound(c.Delete(ctx, binding))
+}
+
+// RemoveOrphanedTokenReviewClusterRoleBindings deletes the token review ClusterRoleBindings of the Infinispan clusters
+// that no longer exist. Clusters that cannot be read, e.g. because their namespace is not watched, are skipped
+func RemoveOrphanedTokenReviewClusterRoleBindings(ctx context.Context, reader client.Reader, c client.Client) error {
+	bindings := &rbacv1.ClusterRoleBindingList{}
+	if err := reader.List(ctx, bindings, client.HasLabels{consts.LabelTokenReviewNamespace}); err != nil {
+		return err
+	}
+	for _, binding := range bindings.Items {
+		namespace, name := binding.Labels[consts.LabelTokenReviewNamespace], binding.Labels["infinispan_cr"]
+		err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &ispnv1.Infinispan{})
+		if !errors.IsNotFound(err) {
+			continue
+		}
+		if err := RemoveTokenReviewClusterRoleBinding(ctx, c, namespace, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removeTokenReview(i *ispnv1.Infinispan, ctx pipeline.Context) {
+	// Load from the cache first, so that clusters without token review do not delete the resources on every reconciliation
+	binding := &rbacv1.ClusterRoleBinding{}
+	bindingName := TokenReviewClusterRoleBindingName(i.Namespace, i.Name)
+	if err := ctx.Resources().LoadGlobal(bindingName, binding, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+		return
+	}
+	if binding.UID != "" {
+		if err := ctx.Resources().Delete(bindingName, binding, pipeline.RetryOnErr); err != nil {
+			return
+		}
+	}
+
+	if err := deleteIfExists(i.GetTokenReviewName(), &corev1.Secret{}, ctx); err != nil {
+		return
+	}
+	_ = deleteIfExists(i.GetTokenReviewName(), &corev1.ServiceAccount{}, ctx)
+}
+
+// deleteIfExists loads obj from the cache first, so that a resource that does not exist is not deleted on every
+// reconciliation
+func deleteIfExists(name string, obj client.Object, ctx pipeline.Context) error {
+	if err := ctx.Resources().Load(name, obj, pipeline.IgnoreNotFound, pipeline.RetryOnErr); err != nil {
+		return err
+	}
+	if obj.GetUID() == "" {
+		return nil
+	}
+	return ctx.Resources().Delete(name, obj, pipeline.RetryOnErr)
+}
+
+// ApplyTokenReview adds the token review sidecar to the pods when ServiceAccount tokens are accepted, or removes it
+func ApplyTokenReview(i *ispnv1.Infinispan, image string, spec *corev1.PodSpec) (updated bool) {
+	volumePosition := findVolume(spec.Volumes, TokenReviewVolumeName)
+	containerPosition := -1
+	for idx, c := range spec.Containers {
+		if c.Name == TokenReviewContainer {
+			containerPosition = idx
+		}
+	}
+
+	if !i.IsServiceAccountAuthEnabled() {
+		if containerPosition >= 0 {
+			spec.Containers = append(spec.Containers[:containerPosition], spec.Containers[containerPosition+1:]...)
+			updated = true
+		}
+		if volumePosition >= 0 {
+			spec.Volumes = append(spec.Volumes[:volumePosition], spec.Volumes[volumePosition+1:]...)
+			updated = true
+		}
+		return
+	}
+
+	// The token Secret of previous operator versions is replaced by the projected token. The volume is not compared
+	// with DeepEqual, as the api-server defaults the mode of the projected files
+	volume := corev1.Volume{
+		Name: TokenReviewVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Path:              corev1.ServiceAccountTokenKey,
+						ExpirationSeconds: pointer.Int64Ptr(tokenReviewTokenExpiration),
+					},
+				}, {
+					ConfigMap: &corev1.ConfigMapProjection{
+						LocalObjectReference: corev1.LocalObjectReference{Name: tokenReviewRootCAConfigMap},
+						Items:                []corev1.KeyToPath{{Key: corev1.ServiceAccountRootCAKey, Path: corev1.ServiceAccountRootCAKey}},
+					},
+				}},
+			},
+		},
+	}
+	if volumePosition < 0 {
+		spec.Volumes = append(spec.Volumes, volume)
+		updated = true
+	} else if spec.Volumes[volumePosition].Projected == nil {
+		spec.Volumes[volumePosition] = volume
+		updated = true
+	}
+
+	container := corev1.Container{
+		Name:  TokenReviewContainer,
+		Image: image,
+		Args: []string{
+			"token-review",
+			"-address",
+			TokenReviewAddress(),
+			"-audiences",
+			strings.Join(i.GetServiceAccountTokenAudiences(), ","),
+			"-token-dir",
+			consts.TokenReviewRoot,
+		},
+		LivenessProbe:   tokenReviewProbe(),
+		ReadinessProbe:  tokenReviewProbe(),
+		Resources:       tokenReviewResources(),
+		SecurityContext: i.Spec.Security.ContainerSecurityContext,
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      TokenReviewVolumeName,
+			MountPath: consts.TokenReviewRoot,
+			ReadOnly:  true,
+		}},
+	}
+	if containerPosition < 0 {
+		spec.Containers = append(spec.Containers, container)
+		return true
+	}
+	current := &spec.Containers[containerPosition]
+	if current.Image != container.Image || !reflect.DeepEqual(current.Args, container.Args) ||
+		!reflect.DeepEqual(current.SecurityContext, container.SecurityContext) ||
+		!reflect.DeepEqual(current.Resources, container.Resources) ||
+		current.LivenessProbe == nil || current.ReadinessProbe == nil {
+		current.Image = container.Image
+		current.Args = container.Args
+		current.LivenessProbe = container.LivenessProbe
+		current.ReadinessProbe = container.ReadinessProbe
+		current.Resources = container.Resources
+		current.SecurityContext = container.SecurityContext
+		updated = true
+	}
+	return
+}
+
+// TokenReviewAddress returns the address that the token review sidecar listens on
+func TokenReviewAddress() string {
+	return net.JoinHostPort(consts.TokenReviewHost, strconv.Itoa(consts.TokenReviewPort))
+}
+
+// tokenReviewProbe calls the health endpoint of the sidecar from within the container, as the kubelet cannot reach
+// the loopback address that the sidecar listens on
+func tokenReviewProbe() *corev1.Probe {
+	return &corev1.Probe{
+		Handler: corev1.Handler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"infinispan-operator", "token-review", "-probe", "-address", TokenReviewAddress()},
+			},
+		},
+		FailureThreshold: 3,
+		PeriodSeconds:    10,
+		SuccessThreshold: 1,
+		TimeoutSeconds:   5,
+	}
+}
+
+func tokenReviewResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("10m"),
+			corev1.ResourceMemory: resource.MustParse("32Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+		},
+	}
+}
+
+// TokenReviewImage returns the image of the token review sidecar, or an empty string if the sidecar is not required
+func TokenReviewImage(i *ispnv1.Infinispan, ctx pipeline.Context) (string, error) {
+	if !i.IsServiceAccountAuthEnabled() {
+		return "", nil
+	}
+	return OperatorImage(ctx)
+}
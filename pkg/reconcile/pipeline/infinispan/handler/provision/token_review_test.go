@@ -0,0 +1,129 @@
+package provision
+
+import (
+	"context"
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTokenReview(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.Security.EndpointServiceAccount = &ispnv1.EndpointServiceAccount{}
+
+	ctx := newTestContext()
+	TokenReview(i, ctx)
+	require.Len(t, ctx.resources.created, 2)
+	sa := ctx.resources.created[0].(*corev1.ServiceAccount)
+	assert.Equal(t, "example-infinispan-token-review", sa.Name)
+	// Only the sidecar mounts a token of the ServiceAccount
+	assert.False(t, *sa.AutomountServiceAccountToken)
+
+	binding := ctx.resources.created[1].(*rbacv1.ClusterRoleBinding)
+	assert.Equal(t, "testing-namespace-example-infinispan-token-review", binding.Name)
+	assert.Empty(t, binding.Namespace)
+	assert.Equal(t, "testing-namespace", binding.Labels[consts.LabelTokenReviewNamespace])
+	assert.Equal(t, "example-infinispan", binding.Labels["infinispan_cr"])
+	assert.Equal(t, TokenReviewClusterRole, binding.RoleRef.Name)
+	assert.Equal(t, []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "example-infinispan-token-review", Namespace: "testing-namespace"}}, binding.Subjects)
+	assert.Zero(t, ctx.requeueAfter)
+
+	// The pods of spec.serviceAccountName review tokens with a token of that ServiceAccount
+	i.Spec.ServiceAccountName = "workload-identity"
+	ctx = newTestContext()
+	TokenReview(i, ctx)
+	require.Len(t, ctx.resources.created, 1)
+	binding = ctx.resources.created[0].(*rbacv1.ClusterRoleBinding)
+	assert.Equal(t, []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "workload-identity", Namespace: "testing-namespace"}}, binding.Subjects)
+}
+
+func TestRemoveOrphanedTokenReviewClusterRoleBindings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, ispnv1.AddToScheme(scheme))
+
+	binding := func(namespace, name string) *rbacv1.ClusterRoleBinding {
+		return &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   TokenReviewClusterRoleBindingName(namespace, name),
+				Labels: map[string]string{"infinispan_cr": name, consts.LabelTokenReviewNamespace: namespace},
+			},
+		}
+	}
+	existing := &ispnv1.Infinispan{ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "team-a"}}
+	unrelated := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Labels: map[string]string{"infinispan_cr": "deleted"}}}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(existing, binding("team-a", "existing"), binding("team-a", "deleted"), binding("team-b", "existing"), unrelated).
+		Build()
+
+	require.NoError(t, RemoveOrphanedTokenReviewClusterRoleBindings(context.TODO(), c, c))
+	remaining := &rbacv1.ClusterRoleBindingList{}
+	require.NoError(t, c.List(context.TODO(), remaining))
+	var names []string
+	for _, b := range remaining.Items {
+		names = append(names, b.Name)
+	}
+	assert.ElementsMatch(t, []string{"team-a-existing-token-review", "unrelated"}, names)
+
+	err := c.Get(context.TODO(), types.NamespacedName{Name: "team-b-existing-token-review"}, &rbacv1.ClusterRoleBinding{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestApplyTokenReview(t *testing.T) {
+	i := testInfinispan()
+	i.Spec.Security.EndpointServiceAccount = &ispnv1.EndpointServiceAccount{}
+	spec := &corev1.PodSpec{Containers: []corev1.Container{{Name: InfinispanContainer}}}
+
+	assert.True(t, ApplyTokenReview(i, "quay.io/infinispan/operator:latest", spec))
+	require.Len(t, spec.Containers, 2)
+	sidecar := spec.Containers[1]
+	assert.Equal(t, TokenReviewContainer, sidecar.Name)
+	assert.Equal(t, "quay.io/infinispan/operator:latest", sidecar.Image)
+	assert.Equal(t, []string{"token-review", "-address", "localhost:11230", "-audiences", "example-infinispan.testing-namespace.svc", "-token-dir", consts.TokenReviewRoot}, sidecar.Args)
+	assert.Equal(t, []string{"infinispan-operator", "token-review", "-probe", "-address", "localhost:11230"}, sidecar.LivenessProbe.Exec.Command)
+	assert.Equal(t, sidecar.LivenessProbe, sidecar.ReadinessProbe)
+	assert.NotEmpty(t, sidecar.Resources.Requests)
+	assert.NotEmpty(t, sidecar.Resources.Limits)
+
+	volumeIndex := findVolume(spec.Volumes, TokenReviewVolumeName)
+	require.GreaterOrEqual(t, volumeIndex, 0)
+	sources := spec.Volumes[volumeIndex].Projected.Sources
+	require.Len(t, sources, 2)
+	assert.Equal(t, corev1.ServiceAccountTokenKey, sources[0].ServiceAccountToken.Path)
+	assert.Equal(t, "kube-root-ca.crt", sources[1].ConfigMap.Name)
+	// Only the sidecar mounts the token
+	assert.Empty(t, spec.Containers[0].VolumeMounts)
+	assert.False(t, ApplyTokenReview(i, "quay.io/infinispan/operator:latest", spec))
+
+	// Changing the audiences or the operator image updates the sidecar
+	i.Spec.Security.EndpointServiceAccount.Audiences = []string{"infinispan", "cache"}
+	assert.True(t, ApplyTokenReview(i, "quay.io/infinispan/operator:2.3", spec))
+	assert.Equal(t, "infinispan,cache", spec.Containers[1].Args[4])
+	assert.Equal(t, "quay.io/infinispan/operator:2.3", spec.Containers[1].Image)
+
+	// The token Secret of previous operator versions is replaced by the projected token
+	spec.Volumes[volumeIndex].VolumeSource = corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "example-infinispan-token-review"}}
+	spec.Containers[1].LivenessProbe, spec.Containers[1].ReadinessProbe = nil, nil
+	assert.True(t, ApplyTokenReview(i, "quay.io/infinispan/operator:2.3", spec))
+	assert.NotNil(t, spec.Volumes[volumeIndex].Projected)
+	assert.Nil(t, spec.Volumes[volumeIndex].Secret)
+	assert.NotNil(t, spec.Containers[1].LivenessProbe)
+
+	// Disabling ServiceAccount tokens removes the sidecar
+	i.Spec.Security.EndpointServiceAccount = nil
+	assert.True(t, ApplyTokenReview(i, "", spec))
+	assert.Len(t, spec.Containers, 1)
+	assert.Equal(t, -1, findVolume(spec.Volumes, TokenReviewVolumeName))
+	assert.False(t, ApplyTokenReview(i, "", spec))
+}
@@ -0,0 +1,119 @@
+package provision
+
+import (
+	"fmt"
+	"strconv"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	consts "github.com/infinispan/infinispan-operator/controllers/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	VaultVolumeName = "vault-secrets"
+	// VaultCSIDriver is the driver of the Secrets Store CSI volumes that the Vault provider mounts secrets with
+	VaultCSIDriver = "secrets-store.csi.k8s.io"
+
+	VaultAgentInjectAnnotation = "vault.hashicorp.com/agent-inject"
+	VaultRoleAnnotation        = "vault.hashicorp.com/role"
+	// VaultSecretAnnotationPrefix and VaultTemplateAnnotationPrefix are followed by the name of the file rendered in
+	// the /vault/secrets directory of the pod by the Vault Agent
+	VaultSecretAnnotationPrefix   = "vault.hashicorp.com/agent-inject-secret-"
+	VaultTemplateAnnotationPrefix = "vault.hashicorp.com/agent-inject-template-"
+)
+
+// vaultFiles are the files of the Vault secret fields that the servers read
+var vaultFiles = []struct {
+	filename string
+	template string
+}{
+	{consts.VaultUsersFilename, fmt.Sprintf("{{ .Data.data.%s }}", consts.VaultUsersKey)},
+	{consts.VaultGroupsFilename, fmt.Sprintf("{{ .Data.data.%s }}", consts.VaultGroupsKey)},
+	// The keystore password is optional, as the servers only use it with a keystore provided in the certificate Secret
+	{consts.VaultServerPropertiesFilename, fmt.Sprintf("{{ with .Data.data.%s }}%s={{ . }}{{ end }}", consts.VaultKeystorePasswordKey, consts.VaultKeystorePasswordProperty)},
+}
+
+// vaultAnnotations returns the keys of all the Vault Agent Injector annotations that the operator manages
+func vaultAnnotations() []string {
+	keys := []string{VaultAgentInjectAnnotation, VaultRoleAnnotation}
+	for _, f := range vaultFiles {
+		keys = append(keys, VaultSecretAnnotationPrefix+f.filename, VaultTemplateAnnotationPrefix+f.filename)
+	}
+	return keys
+}
+
+// ApplyVault configures the pod template to provide the files of spec.security.vault in the /vault/secrets directory of
+// the Infinispan container. With the AgentInjector provider the Vault Agent Injector annotations are added to the pods,
+// with the CSI provider a Secrets Store CSI volume of the SecretProviderClass is mounted. Annotations and volumes are
+// removed once they are no longer used, unless the annotations are configured by the user with the pod target
+// annotations. Returns true if the pod template was updated
+func ApplyVault(i *ispnv1.Infinispan, ispnContainer *corev1.Container, template *corev1.PodTemplateSpec) (updated bool) {
+	vault := i.Spec.Security.Vault
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	annotations := template.Annotations
+	if vault != nil && vault.Provider == ispnv1.VaultProviderAgentInjector {
+		desired := map[string]string{
+			VaultAgentInjectAnnotation: strconv.FormatBool(true),
+			VaultRoleAnnotation:        vault.Role,
+		}
+		for _, f := range vaultFiles {
+			desired[VaultSecretAnnotationPrefix+f.filename] = vault.SecretPath
+			desired[VaultTemplateAnnotationPrefix+f.filename] = fmt.Sprintf("{{- with secret %q -}}%s{{- end -}}", vault.SecretPath, f.template)
+		}
+		for key, value := range desired {
+			if annotations[key] != value {
+				annotations[key] = value
+				updated = true
+			}
+		}
+	} else {
+		userAnnotations := i.PodAnnotations()
+		for _, key := range vaultAnnotations() {
+			if _, exists := annotations[key]; exists {
+				if _, userDefined := userAnnotations[key]; !userDefined {
+					delete(annotations, key)
+					updated = true
+				}
+			}
+		}
+	}
+
+	volumes := &template.Spec.Volumes
+	volumeMounts := &ispnContainer.VolumeMounts
+	volumePosition := findVolume(*volumes, VaultVolumeName)
+	volumeMountPosition := findVolumeMount(*volumeMounts, VaultVolumeName)
+	if vault != nil && vault.Provider == ispnv1.VaultProviderCSI {
+		source := corev1.VolumeSource{
+			CSI: &corev1.CSIVolumeSource{
+				Driver:           VaultCSIDriver,
+				ReadOnly:         pointer.BoolPtr(true),
+				VolumeAttributes: map[string]string{"secretProviderClass": vault.SecretProviderClass},
+			},
+		}
+		if volumePosition < 0 {
+			*volumes = append(*volumes, corev1.Volume{Name: VaultVolumeName, VolumeSource: source})
+			updated = true
+		} else if csi := (*volumes)[volumePosition].CSI; csi == nil || csi.VolumeAttributes["secretProviderClass"] != vault.SecretProviderClass {
+			(*volumes)[volumePosition].VolumeSource = source
+			updated = true
+		}
+		if volumeMountPosition < 0 {
+			*volumeMounts = append(*volumeMounts, corev1.VolumeMount{Name: VaultVolumeName, MountPath: consts.ServerVaultRoot, ReadOnly: true})
+			updated = true
+		}
+		return
+	}
+
+	if volumePosition >= 0 {
+		*volumes = append((*volumes)[:volumePosition], (*volumes)[volumePosition+1:]...)
+		updated = true
+	}
+	if volumeMountPosition >= 0 {
+		*volumeMounts = append((*volumeMounts)[:volumeMountPosition], (*volumeMounts)[volumeMountPosition+1:]...)
+		updated = true
+	}
+	return
+}
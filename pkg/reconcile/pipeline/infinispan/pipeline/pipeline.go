@@ -13,6 +13,7 @@ import (
 	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/configure"
 	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/manage"
 	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -141,20 +142,30 @@ func (b *builder) Build() pipeline.Pipeline {
 	// Apply default meta before doing anything else
 	handlers.Add(manage.PrelimChecksCondition)
 
+	// Record the cluster in the inventory metrics before any handler can stop the pipeline
+	handlers.Add(manage.ClusterInventoryMetrics)
+
+	// Stop before any resources are managed if the server version is not supported by this version of the operator
+	handlers.Add(manage.OperandCompatibility)
+
 	// Provision/Remove the XSite service before performing configuration so that Remote site information can be retrieved
 	handlers.Add(provision.XSiteService)
 
 	// Configuration Handlers
 	handlers.AddFeatureSpecific(i.HasSites(), configure.XSite)
-	handlers.AddFeatureSpecific(i.IsSiteTLSEnabled(),
-		configure.TransportTLS,
-		configure.GossipRouterTLS,
-	)
-	handlers.AddFeatureSpecific(i.IsAuthenticationEnabled(), configure.UserAuthenticationSecret)
+	handlers.AddFeatureSpecific(i.IsSiteTLSEnabled(), configure.TransportTLS)
+	handlers.AddFeatureSpecific(i.HasSiteCAs(), configure.RelayTruststore)
+	handlers.AddFeatureSpecific(i.IsSiteTLSEnabled(), configure.GossipRouterTLS)
+	handlers.AddFeatureSpecific(i.IsEndpointSecretEnabled(), configure.UserAuthenticationSecret)
+	handlers.AddFeatureSpecific(i.IsOIDCEnabled(), configure.OIDCClient)
+	handlers.AddFeatureSpecific(i.IsKerberosEnabled(), configure.KerberosKeytab)
+	handlers.AddFeatureSpecific(i.HasCredentials(), configure.Credentials)
 	handlers.AddFeatureSpecific(i.UserConfigDefined(), configure.UserConfigMap)
-	handlers.AddFeatureSpecific(i.IsEncryptionEnabled(), configure.Keystore)
+	handlers.AddFeatureSpecific(i.IsEncryptionEnabled() && !i.IsEncryptionCertFromOperator(), configure.Keystore)
+	handlers.AddFeatureSpecific(i.IsEncryptionCertFromOperator(), configure.ServingCertificate)
+	handlers.AddFeatureSpecific(i.IsEncryptionEnabled(), configure.CABundle)
 	handlers.AddFeatureSpecific(i.IsClientCertEnabled(), configure.Truststore)
-	handlers.AddFeatureSpecific(i.IsAuthenticationEnabled() && i.IsGeneratedSecret(), configure.UserIdentities)
+	handlers.AddFeatureSpecific(i.IsEndpointSecretEnabled() && i.IsGeneratedSecret(), configure.UserIdentities)
 	handlers.Add(
 		configure.AdminSecret,
 		configure.InfinispanServer,
@@ -164,9 +175,11 @@ func (b *builder) Build() pipeline.Pipeline {
 	)
 
 	// Provision Handlers
-	handlers.AddFeatureSpecific(i.IsAuthenticationEnabled() && i.IsGeneratedSecret(), provision.UserAuthenticationSecret)
+	handlers.AddFeatureSpecific(i.IsEndpointSecretEnabled() && i.IsGeneratedSecret(), provision.UserAuthenticationSecret)
 	handlers.AddFeatureSpecific(i.IsClientCertEnabled(), provision.TruststoreSecret)
+	handlers.AddFeatureSpecific(i.HasSiteCAs(), provision.RelayTruststoreSecret)
 	handlers.Add(
+		provision.CABundleConfigMap,
 		provision.GossipRouter,
 		provision.AdminSecret,
 		provision.InfinispanSecuritySecret,
@@ -174,12 +187,22 @@ func (b *builder) Build() pipeline.Pipeline {
 		provision.PingService,
 		provision.AdminService,
 		provision.ClusterService,
+		provision.JmxService,
+		provision.ServerServiceAccount,
+		provision.TokenReview,
 		provision.ClusterStatefulSet,
 	)
-	handlers.AddFeatureSpecific(i.IsExposed(), provision.ExternalService)
+	handlers.Add(provision.ExternalService, provision.PodExternalServices, provision.ConsoleExternalService)
 
 	// Manage the created Cluster
-	handlers.Add(manage.PodStatus)
+	// Pods wait in the capacity-factor and external-address init containers until annotated, so these must run before
+	// any handler that waits for pods, including those of Hot Rod rolling upgrades
+	handlers.AddFeatureSpecific(i.IsCapacityFactorEnabled(), manage.CapacityFactors)
+	handlers.AddFeatureSpecific(i.IsExposedPerPod() || len(i.Status.PodPublicAddresses) > 0, manage.PodExternalAddresses)
+	handlers.Add(
+		manage.PodStatus,
+		manage.OperationLock,
+	)
 	handlers.AddFeatureSpecific(i.HotRodRollingUpgrades(), manage.HotRodRollingUpgrade)
 	handlers.AddFeatureSpecific(i.GracefulShutdownUpgrades(), manage.GracefulShutdownUpgrade)
 	handlers.Add(
@@ -187,24 +210,42 @@ func (b *builder) Build() pipeline.Pipeline {
 		manage.UpdatePodLabels,
 	)
 	handlers.AddFeatureSpecific(i.GracefulShutdownUpgrades(), manage.ScheduleGracefulShutdownUpgrade)
+	handlers.Add(manage.OperandRefresh)
+	handlers.AddFeatureSpecific(i.IsAutostopEnabled(), manage.Autostop)
 
 	handlers.Add(
+		manage.ClusterAction,
 		manage.GracefulShutdown,
 		manage.AwaitUpgrade,
 		manage.StatefulSetRollingUpgrade,
-		manage.AwaitPodIps,
 	)
+	handlers.AddFeatureSpecific(i.GetCondition(ispnv1.ConditionCertificateUpdating).Status == metav1.ConditionTrue, manage.CertificateUpdate)
+	handlers.Add(manage.AwaitPodIps)
 	handlers.AddFeatureSpecific(i.IsCache(), manage.AutoScaling)
 	handlers.Add(
 		manage.AwaitWellFormedCondition,
+		manage.RollingRestart,
+		manage.PartitionedRollout,
 		manage.ConfigureLoggers,
+		manage.ShortLivedCredentials,
 		provision.ConfigListener,
 	)
-	handlers.AddFeatureSpecific(i.IsCache(), manage.CacheService)
+	handlers.AddFeatureSpecific(i.IsEncryptionEnabled(), manage.CertificateRotation)
+	handlers.AddFeatureSpecific(i.IsEndpointSecretEnabled(), manage.IdentitiesRotation)
+	handlers.AddFeatureSpecific(i.IsCache() || i.IsDevMode(), manage.CacheService)
+	handlers.AddFeatureSpecific(i.IsIntegrityCheckEnabled(), manage.IntegrityCheck)
+	handlers.AddFeatureSpecific(i.IsRecommendationsEnabled(), manage.SizingRecommendations)
+	handlers.AddFeatureSpecific(i.IsStorageMonitoringEnabled(), manage.StoragePressure)
+	handlers.AddFeatureSpecific(i.IsShadowReplicationEnabled() || i.Status.ShadowReplication != nil, manage.ShadowReplication)
 	handlers.Add(
 		manage.ConsoleUrl,
+		manage.DisasterRecoveryMetrics,
 	)
-	handlers.AddFeatureSpecific(i.HasSites(), manage.XSiteViewCondition)
+	handlers.Add(manage.ServerWarnings)
+	handlers.AddFeatureSpecific(i.HasSites() || i.Status.Sites != nil, manage.XSiteViewCondition)
+	handlers.AddFeatureSpecific(i.HasSites(), manage.XSiteOfflineLocations)
+	handlers.AddFeatureSpecific(i.HasSites(), manage.XSiteStateTransfer)
+	handlers.AddFeatureSpecific(i.IsDriftDetectionEnabled(), manage.DriftDetection)
 
 	b.handlers = handlers.Build()
 
@@ -0,0 +1,79 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	pipelineContext "github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// TestProcessProvisionsStatefulSet provisions a new cluster with all of the handlers that run before the StatefulSet
+// exists, including the inventory metrics
+func TestProcessProvisionsStatefulSet(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(ispnv1.AddToScheme(scheme))
+
+	i := &ispnv1.Infinispan{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-infinispan", Namespace: "testing-namespace", CreationTimestamp: metav1.Now()},
+		Spec:       ispnv1.InfinispanSpec{Replicas: 1},
+	}
+	i.Default()
+	i.SetCondition(ispnv1.ConditionPrelimChecksPassed, metav1.ConditionTrue, "")
+
+	// The StatefulSet doesn't exist, so no handler may require the pods of the cluster before it is provisioned
+	c := kube.NewTypedObjectClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(i).Build(), scheme)
+	_, _, err := Builder().
+		For(i).
+		WithContextProvider(pipelineContext.Provider(c, scheme, &kube.Kubernetes{Client: c}, &record.FakeRecorder{})).
+		WithLogger(ctrl.Log.WithName("test")).
+		Build().
+		Process(context.TODO())
+	require.NoError(t, err)
+
+	statefulSet := &appsv1.StatefulSet{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: i.Namespace, Name: i.GetStatefulSetName()}, statefulSet))
+
+	// The cluster is recorded in the inventory before it has any pods
+	replicas, ok := gauge(t, "infinispan_operator_cluster_replicas", i.Namespace, i.Name)
+	require.True(t, ok, "the cluster is not in the inventory")
+	assert.Equal(t, float64(1), replicas)
+	upgradePending, ok := gauge(t, "infinispan_operator_cluster_upgrade_pending", i.Namespace, i.Name)
+	require.True(t, ok)
+	assert.Equal(t, float64(0), upgradePending)
+}
+
+// gauge returns the value of the series of the cluster from the metrics registry of the operator
+func gauge(t *testing.T, name, namespace, cluster string) (float64, bool) {
+	families, err := metrics.Registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, pair := range m.GetLabel() {
+				labels[pair.GetName()] = pair.GetValue()
+			}
+			if labels["namespace"] == namespace && labels["cluster"] == cluster {
+				return m.GetGauge().GetValue(), true
+			}
+		}
+	}
+	return 0, false
+}
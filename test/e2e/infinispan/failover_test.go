@@ -13,6 +13,7 @@ import (
 	"github.com/infinispan/infinispan-operator/pkg/mime"
 	tutils "github.com/infinispan/infinispan-operator/test/e2e/utils"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestPodDegradationAfterOOM(t *testing.T) {
@@ -92,3 +93,31 @@ func GenerateStringWithCharset(length int) string {
 	}
 	return string(b)
 }
+
+func TestWellFormedConditionOnServerFaults(t *testing.T) {
+	defer testKube.CleanNamespaceAndLogOnPanic(t, tutils.Namespace)
+
+	ispn := tutils.DefaultSpec(t, testKube, func(i *ispnv1.Infinispan) {
+		i.Spec.Replicas = 2
+	})
+	testKube.CreateInfinispan(ispn, tutils.Namespace)
+	testKube.WaitForInfinispanPods(int(ispn.Spec.Replicas), tutils.SinglePodTimeout, ispn.Name, tutils.Namespace)
+	ispn = testKube.WaitForInfinispanCondition(ispn.Name, ispn.Namespace, ispnv1.ConditionWellFormed)
+
+	podList := &corev1.PodList{}
+	tutils.ExpectNoError(testKube.Kubernetes.ResourcesList(tutils.Namespace, ispn.PodSelectorLabels(), podList, context.TODO()))
+	podName := podList.Items[len(podList.Items)-1].Name
+	faults := tutils.NewFaultInjector(ispn, tutils.HTTPClientForCluster(ispn, testKube), testKube)
+
+	// A paused server must be removed from the view and the cluster must form again once it resumes
+	faults.PauseServer(podName)
+	testKube.WaitForInfinispanConditionStatus(ispn.Name, ispn.Namespace, ispnv1.ConditionWellFormed, metav1.ConditionFalse)
+	faults.ResumeServer(podName)
+	testKube.WaitForInfinispanCondition(ispn.Name, ispn.Namespace, ispnv1.ConditionWellFormed)
+
+	// A server that leaves the view must rejoin once its container has been restarted
+	faults.DropMember(podName)
+	testKube.WaitForInfinispanConditionStatus(ispn.Name, ispn.Namespace, ispnv1.ConditionWellFormed, metav1.ConditionFalse)
+	testKube.WaitForInfinispanPods(int(ispn.Spec.Replicas), tutils.SinglePodTimeout, ispn.Name, tutils.Namespace)
+	testKube.WaitForInfinispanCondition(ispn.Name, ispn.Namespace, ispnv1.ConditionWellFormed)
+}
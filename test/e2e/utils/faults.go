@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	ispnv1 "github.com/infinispan/infinispan-operator/api/v1"
+	httpClient "github.com/infinispan/infinispan-operator/pkg/http"
+	kube "github.com/infinispan/infinispan-operator/pkg/kubernetes"
+	"github.com/infinispan/infinispan-operator/pkg/reconcile/pipeline/infinispan/handler/provision"
+)
+
+// FaultInjector injects faults into the servers of an Infinispan cluster, so that the failure handling of the operator
+// is exercised deterministically
+type FaultInjector struct {
+	kube      *TestKubernetes
+	client    HTTPClient
+	namespace string
+}
+
+// NewFaultInjector returns a FaultInjector for the servers of the cluster, using the client to access the REST API
+func NewFaultInjector(i *ispnv1.Infinispan, client HTTPClient, kube *TestKubernetes) *FaultInjector {
+	return &FaultInjector{
+		kube:      kube,
+		client:    client,
+		namespace: i.Namespace,
+	}
+}
+
+// PauseServer suspends the server JVM of the pod, so that the server stops responding to requests and JGroups
+// messages exactly as during a long GC pause. The other members remove the server from the view once its failure
+// detection times out
+func (f *FaultInjector) PauseServer(podName string) {
+	f.signalServer(podName, "STOP")
+}
+
+// ResumeServer resumes the server JVM of the pod suspended with PauseServer
+func (f *FaultInjector) ResumeServer(podName string) {
+	f.signalServer(podName, "CONT")
+}
+
+// SlowServer suspends the server JVM of the pod for the duration, so that every request received in the meantime is
+// delayed until the server resumes. The returned channel is closed once the server has been resumed
+func (f *FaultInjector) SlowServer(podName string, delay time.Duration) <-chan struct{} {
+	f.PauseServer(podName)
+	resumed := make(chan struct{})
+	go func() {
+		defer close(resumed)
+		time.Sleep(delay)
+		f.ResumeServer(podName)
+	}()
+	return resumed
+}
+
+// DropMember stops the server of the pod with the REST API, so that it leaves the cluster view until Kubernetes
+// restarts the container
+func (f *FaultInjector) DropMember(podName string) {
+	path := fmt.Sprintf("rest/v2/cluster?action=stop&server=%s", podName)
+	rsp, err := f.client.Post(path, "", nil)
+	ExpectNoError(httpClient.ValidateResponse(rsp, err, "stopping server", http.StatusNoContent))
+	ExpectNoError(httpClient.CloseBody(rsp, nil))
+}
+
+// signalServer sends the signal to the java processes of the server container of the pod
+func (f *FaultInjector) signalServer(podName, signal string) {
+	script := fmt.Sprintf("for p in /proc/[0-9]*; do case \"$(readlink $p/exe)\" in */java) kill -%s ${p#/proc/} ;; esac; done", signal)
+	_, err := f.kube.Kubernetes.ExecWithOptions(kube.ExecOptions{
+		Container: provision.InfinispanContainer,
+		Command:   []string{"sh", "-c", script},
+		PodName:   podName,
+		Namespace: f.namespace,
+	})
+	ExpectNoError(err)
+}
@@ -605,6 +605,16 @@ func (k TestKubernetes) WaitForPods(required int, timeout time.Duration, listOps
 }
 
 func (k TestKubernetes) WaitForInfinispanConditionWithTimeout(name, namespace string, condition ispnv1.ConditionType, timeout time.Duration) *ispnv1.Infinispan {
+	return k.waitForInfinispanConditionStatus(name, namespace, condition, metav1.ConditionTrue, timeout)
+}
+
+// WaitForInfinispanConditionStatus waits for the condition of the Infinispan CR to have the status, e.g. for the
+// WellFormed condition to become False once a fault has been injected
+func (k TestKubernetes) WaitForInfinispanConditionStatus(name, namespace string, condition ispnv1.ConditionType, status metav1.ConditionStatus) *ispnv1.Infinispan {
+	return k.waitForInfinispanConditionStatus(name, namespace, condition, status, ConditionWaitTimeout)
+}
+
+func (k TestKubernetes) waitForInfinispanConditionStatus(name, namespace string, condition ispnv1.ConditionType, status metav1.ConditionStatus, timeout time.Duration) *ispnv1.Infinispan {
 	ispn := &ispnv1.Infinispan{}
 	err := wait.Poll(ConditionPollPeriod, timeout, func() (done bool, err error) {
 		err = k.Kubernetes.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, ispn)
@@ -614,8 +624,8 @@ func (k TestKubernetes) WaitForInfinispanConditionWithTimeout(name, namespace st
 			}
 			return false, err
 		}
-		if ispn.IsConditionTrue(condition) {
-			log.Info("infinispan condition met", "condition", condition, "status", metav1.ConditionTrue)
+		if ispn.GetCondition(condition).Status == status {
+			log.Info("infinispan condition met", "condition", condition, "status", status)
 			return true, nil
 		}
 		return false, nil